@@ -7,12 +7,16 @@ import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
 import React from 'react'
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
+import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
+import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
 import { PhabricatorIcon } from '../../../../shared/src/components/icons'
 import { EditorAction } from '../../site-admin/configHelpers'
@@ -719,6 +723,235 @@ const BITBUCKET_CLOUD: AddExternalServiceOptions = {
         </div>
     ),
 }
+const AZURE_DEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com/<organization>",
+  "username": "<username>",
+  "token": "<personal access token>"
+}`,
+    editorActions: [
+        {
+            id: 'setAccessToken',
+            label: 'Set personal access token',
+            run: (config: string) => {
+                const value = '<personal access token>'
+                const edits = setProperty(config, ['token'], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addProjects',
+            label: 'Add individual projects',
+            run: (config: string) => {
+                const value = '<project name>'
+                const edits = setProperty(config, ['projects', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeRepo',
+            label: 'Exclude a repository',
+            run: (config: string) => {
+                const value = { name: '<project>/<repository>' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '<project>/<repository>' }
+            },
+        },
+        {
+            id: 'enforcePermissions',
+            label: 'Enforce permissions',
+            run: (config: string) => {
+                const value = { identityProvider: { type: 'username' } }
+                const edits = setProperty(config, ['authorization'], value, defaultFormattingOptions)
+                return { edits, selectText: '"authorization"' }
+            },
+        },
+    ],
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    Set <Field>url</Field> to the URL of your Azure DevOps organization (such as
+                    https://dev.azure.com/myorg) or Azure DevOps Server collection.
+                </li>
+                <li>
+                    In Azure DevOps, create a personal access token with the <b>Code (Read)</b> and{' '}
+                    <b>Project and Team (Read)</b> scopes. Set the <Field>username</Field> and{' '}
+                    <Field>token</Field> fields in the configuration below.
+                </li>
+                <li>
+                    Optionally, set the <Field>projects</Field> field to only index the repositories of some
+                    projects. Otherwise, all repositories visible to the user are indexed.
+                </li>
+            </ol>
+            <p>
+                See{' '}
+                <a
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    href="https://docs.sourcegraph.com/admin/external_service/azuredevops#configuration"
+                >
+                    the docs for more options
+                </a>
+                , or try one of the buttons below.
+            </p>
+        </div>
+    ),
+}
+const GERRIT: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GERRIT,
+    title: 'Gerrit',
+    icon: GitIcon,
+    jsonSchema: gerritSchemaJSON,
+    defaultDisplayName: 'Gerrit',
+    defaultConfig: `{
+  "url": "https://gerrit.example.com",
+  "username": "<username>",
+  "password": "<HTTP password>"
+}`,
+    editorActions: [
+        {
+            id: 'setCredentials',
+            label: 'Set HTTP credentials',
+            run: (config: string) => {
+                const value = '<HTTP password>'
+                const edits = setProperty(config, ['password'], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addProjects',
+            label: 'Add individual projects',
+            run: (config: string) => {
+                const value = '<project name>'
+                const edits = setProperty(config, ['projects', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeProject',
+            label: 'Exclude a project',
+            run: (config: string) => {
+                const value = { name: '<project name>' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '<project name>' }
+            },
+        },
+    ],
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    Set <Field>url</Field> to the URL of your Gerrit instance.
+                </li>
+                <li>
+                    In Gerrit, generate an HTTP password under <b>Settings &gt; HTTP Credentials</b> for a user that
+                    can read the projects you want to index. Set the <Field>username</Field> and{' '}
+                    <Field>password</Field> fields in the configuration below.
+                </li>
+                <li>
+                    Optionally, set the <Field>projects</Field> field to only index some projects. Otherwise, all
+                    projects visible to the user are indexed.
+                </li>
+            </ol>
+            <p>
+                See{' '}
+                <a
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    href="https://docs.sourcegraph.com/admin/external_service/gerrit#configuration"
+                >
+                    the docs for more options
+                </a>
+                , or try one of the buttons below.
+            </p>
+        </div>
+    ),
+}
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    editorActions: [
+        {
+            id: 'setAccessToken',
+            label: 'Set access token',
+            run: (config: string) => {
+                const value = '<access token>'
+                const edits = setProperty(config, ['token'], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addOrgRepositories',
+            label: 'Add repositories belonging to organization',
+            run: (config: string) => {
+                const value = '<organization name>'
+                const edits = setProperty(config, ['orgs', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addAffiliatedRepositories',
+            label: 'Add all repositories the token can access',
+            run: (config: string) => {
+                const value = 'affiliated'
+                const edits = setProperty(config, ['repositoryQuery', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeRepo',
+            label: 'Exclude a repository',
+            run: (config: string) => {
+                const value = { name: '<owner>/<repository>' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '<owner>/<repository>' }
+            },
+        },
+    ],
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    Set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    In Gitea, create an access token under <b>Settings &gt; Applications</b> with read access to the
+                    repositories you want to index. Set it to be the value of the <Field>token</Field> field in the
+                    configuration below.
+                </li>
+                <li>
+                    Set the <Field>orgs</Field>, <Field>users</Field> or <Field>repositoryQuery</Field> fields to
+                    select which repositories Sourcegraph should index.
+                </li>
+            </ol>
+            <p>
+                See{' '}
+                <a
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    href="https://docs.sourcegraph.com/admin/external_service/gitea#configuration"
+                >
+                    the docs for more options
+                </a>
+                , or try one of the buttons below.
+            </p>
+        </div>
+    ),
+}
 const BITBUCKET_SERVER: AddExternalServiceOptions = {
     kind: ExternalServiceKind.BITBUCKETSERVER,
     title: 'Bitbucket Server',
@@ -1007,6 +1240,77 @@ const GITOLITE: AddExternalServiceOptions = {
         },
     ],
 }
+const PERFORCE: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.PERFORCE,
+    title: 'Perforce',
+    icon: GitIcon,
+    jsonSchema: perforceSchemaJSON,
+    defaultDisplayName: 'Perforce',
+    defaultConfig: `{
+  "p4.port": "ssl:perforce.example.com:1666",
+  "p4.user": "<admin username>",
+  "p4.passwd": "<admin password>",
+  "depots": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>p4.port</Field> to the address of the Perforce server, as
+                    it would be set in the <Value>P4PORT</Value> environment variable.
+                </li>
+                <li>
+                    Set <Field>p4.user</Field> and <Field>p4.passwd</Field> to the credentials of a Perforce user
+                    with read access to the depots to mirror.
+                </li>
+                <li>
+                    Optionally, list the depots to mirror in the <Field>depots</Field> array, such as{' '}
+                    <Value>"//Engine/"</Value>. If it is empty, all local and stream depots are mirrored.
+                </li>
+            </ol>
+            <p>
+                Depots are converted into Git repositories with <code>git p4</code>. See{' '}
+                <a
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    href="https://docs.sourcegraph.com/admin/external_service/perforce#configuration"
+                >
+                    the docs for more advanced options
+                </a>
+                , or try one of the buttons below.
+            </p>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'addDepot',
+            label: 'Add a depot',
+            run: (config: string) => {
+                const value = '//depot/'
+                const edits = setProperty(config, ['depots', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeDepot',
+            label: 'Exclude a depot',
+            run: (config: string) => {
+                const value = { depot: '//depot/' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '//depot/' }
+            },
+        },
+        {
+            id: 'enforcePermissions',
+            label: 'Enforce permissions',
+            run: (config: string) => {
+                const value = {}
+                const edits = setProperty(config, ['authorization'], value, defaultFormattingOptions)
+                return { edits, selectText: '"authorization"' }
+            },
+        },
+    ],
+}
 const PHABRICATOR_SERVICE: AddExternalServiceOptions = {
     kind: ExternalServiceKind.PHABRICATOR,
     title: 'Phabricator connection',
@@ -1120,9 +1424,13 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     gitlab: GITLAB_SELF_MANAGED,
     bitbucket: BITBUCKET_CLOUD,
     bitbucketserver: BITBUCKET_SERVER,
+    gitea: GITEA,
+    gerrit: GERRIT,
+    azuredevops: AZURE_DEVOPS,
     aws_codecommit: AWS_CODE_COMMIT,
     srcservegit: SRC_SERVE_GIT,
     gitolite: GITOLITE,
+    perforce: PERFORCE,
     git: GENERIC_GIT,
 }
 
@@ -1139,9 +1447,13 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.GITHUB]: GITHUB_DOTCOM,
     [ExternalServiceKind.BITBUCKETCLOUD]: BITBUCKET_CLOUD,
     [ExternalServiceKind.BITBUCKETSERVER]: BITBUCKET_SERVER,
+    [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.GITLAB]: GITLAB_DOTCOM,
     [ExternalServiceKind.GITOLITE]: GITOLITE,
+    [ExternalServiceKind.PERFORCE]: PERFORCE,
     [ExternalServiceKind.PHABRICATOR]: PHABRICATOR_SERVICE,
     [ExternalServiceKind.OTHER]: GENERIC_GIT,
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
+    [ExternalServiceKind.AZUREDEVOPS]: AZURE_DEVOPS,
 }
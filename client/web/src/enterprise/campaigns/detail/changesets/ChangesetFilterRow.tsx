@@ -220,7 +220,11 @@ function changesetUIStateToChangesetFilters(
             }
         case ChangesetUIState.PROCESSING:
             return {
-                reconcilerState: [ChangesetReconcilerState.QUEUED, ChangesetReconcilerState.PROCESSING],
+                reconcilerState: [
+                    ChangesetReconcilerState.QUEUED,
+                    ChangesetReconcilerState.SCHEDULED,
+                    ChangesetReconcilerState.PROCESSING,
+                ],
                 externalState: null,
                 publicationState: null,
             }
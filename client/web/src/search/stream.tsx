@@ -15,6 +15,8 @@ type SearchEvent =
     | { type: 'commitmatches'; matches: CommitMatch[] }
     | { type: 'symbolmatches'; matches: FileSymbolMatch[] }
     | { type: 'filters'; filters: Filter[] }
+    | { type: 'progress'; progress: Progress }
+    | { type: 'alert'; alert: Alert }
 
 interface FileMatch extends RepositoryMatch {
     name: string
@@ -69,6 +71,36 @@ interface Filter {
     kind: string
 }
 
+/**
+ * The progress of a search. It is sent while the search is running, and once
+ * more with done set when it is finished.
+ */
+interface Progress {
+    done: boolean
+    durationMs: number
+    matchCount: number
+    limitHit: boolean
+    repositoriesCount: number
+    searched: number
+    indexed: number
+    cloning: string[]
+    missing: string[]
+    timedout: string[]
+    excludedForks: number
+    excludedArchived: number
+}
+
+interface Alert {
+    title: string
+    description?: string
+    proposedQueries: ProposedQuery[]
+}
+
+interface ProposedQuery {
+    description?: string
+    query: string
+}
+
 const toGQLLineMatch = (line: LineMatch): GQL.ILineMatch => ({
     __typename: 'LineMatch',
     limitHit: false,
@@ -189,6 +221,21 @@ const toGQLSearchFilter = (filter: Omit<Filter, 'type'>): GQL.ISearchFilter => (
     ...filter,
 })
 
+const toGQLRepositories = (names: string[]): GQL.IRepository[] =>
+    // eslint-disable-next-line @typescript-eslint/consistent-type-assertions
+    names.map(name => ({ __typename: 'Repository', name } as GQL.IRepository))
+
+const toGQLSearchAlert = (alert: Alert): GQL.ISearchAlert => ({
+    __typename: 'SearchAlert',
+    title: alert.title,
+    description: alert.description || null,
+    proposedQueries: alert.proposedQueries.map(query => ({
+        __typename: 'SearchQueryDescription',
+        description: query.description || null,
+        query: query.query,
+    })),
+})
+
 const emptyGQLSearchResults: GQL.ISearchResults = {
     __typename: 'SearchResults',
     matchCount: 0,
@@ -251,12 +298,36 @@ export const switchToGQLISearchResults: OperatorFunction<SearchEvent, GQL.ISearc
                     // New filter results replace all previous ones
                     dynamicFilters: newEvent.filters.map(toGQLSearchFilter),
                 }
+
+            case 'progress':
+                return {
+                    ...results,
+                    // Progress is a snapshot, so it replaces the previous one
+                    matchCount: newEvent.progress.matchCount,
+                    resultCount: newEvent.progress.matchCount,
+                    approximateResultCount: `${newEvent.progress.matchCount}${newEvent.progress.done ? '' : '+'}`,
+                    limitHit: newEvent.progress.limitHit,
+                    repositoriesCount: newEvent.progress.repositoriesCount,
+                    cloning: toGQLRepositories(newEvent.progress.cloning),
+                    missing: toGQLRepositories(newEvent.progress.missing),
+                    timedout: toGQLRepositories(newEvent.progress.timedout),
+                    elapsedMilliseconds: newEvent.progress.durationMs,
+                }
+
+            case 'alert':
+                return {
+                    ...results,
+                    alert: toGQLSearchAlert(newEvent.alert),
+                }
         }
     }, emptyGQLSearchResults),
     defaultIfEmpty(emptyGQLSearchResults)
 )
 
-const observeMessages = <T extends {}>(eventSource: EventSource, eventName: SearchEvent['type']): Observable<T> =>
+const observeMessages = <T extends {}>(
+    eventSource: EventSource,
+    eventName: SearchEvent['type'] | 'start'
+): Observable<T> =>
     fromEvent(eventSource, eventName).pipe(
         map((event: Event) => {
             if (!(event instanceof MessageEvent)) {
@@ -296,6 +367,16 @@ export function search(
 
         const eventSource = new EventSource('/search/stream?' + parameterEncoded)
         const subscriptions = new Subscription()
+
+        // The ID of the stream, used to cancel the search on the backend if
+        // we unsubscribe before it is done.
+        let streamID: string | undefined
+        let done = false
+        subscriptions.add(
+            observeMessages<{ id: string }>(eventSource, 'start').subscribe(({ id }) => {
+                streamID = id
+            })
+        )
         subscriptions.add(
             observeMessages<FileMatch[]>(eventSource, 'filematches')
                 .pipe(map(matches => ({ type: 'filematches' as const, matches })))
@@ -321,14 +402,26 @@ export function search(
                 .pipe(map(filters => ({ type: 'filters' as const, filters })))
                 .subscribe(observer)
         )
+        subscriptions.add(
+            observeMessages<Progress>(eventSource, 'progress')
+                .pipe(map(progress => ({ type: 'progress' as const, progress })))
+                .subscribe(observer)
+        )
+        subscriptions.add(
+            observeMessages<Alert>(eventSource, 'alert')
+                .pipe(map(alert => ({ type: 'alert' as const, alert })))
+                .subscribe(observer)
+        )
         subscriptions.add(
             fromEvent(eventSource, 'done').subscribe(() => {
+                done = true
                 observer.complete()
                 eventSource.close()
             })
         )
         subscriptions.add(
             fromEvent(eventSource, 'error').subscribe(error => {
+                done = true
                 observer.error(error)
                 eventSource.close()
             })
@@ -336,6 +429,17 @@ export function search(
         return () => {
             subscriptions.unsubscribe()
             eventSource.close()
+            if (!done && streamID) {
+                // Closing the event source cancels the search as well, but
+                // not when a proxy keeps the connection to the backend open.
+                fetch('/.api/search/stream/cancel?id=' + encodeURIComponent(streamID), {
+                    credentials: 'same-origin',
+                    method: 'POST',
+                    headers: window.context.xhrHeaders,
+                }).catch(() => {
+                    // The search is done already.
+                })
+            }
         }
     })
 }
@@ -15,6 +15,7 @@ type Services struct {
 	BitbucketServerWebhook           http.Handler
 	NewCodeIntelUploadHandler        NewCodeIntelUploadHandler
 	NewCodeIntelInternalProxyHandler NewCodeIntelInternalProxyHandler
+	NewCampaignsInternalProxyHandler NewCampaignsInternalProxyHandler
 	AuthzResolver                    graphqlbackend.AuthzResolver
 	CampaignsResolver                graphqlbackend.CampaignsResolver
 	CodeIntelResolver                graphqlbackend.CodeIntelResolver
@@ -28,6 +29,10 @@ type NewCodeIntelUploadHandler func(internal bool) http.Handler
 // accessible from the precise-code-intel-indexer (deployed separately from the k8s cluster).
 type NewCodeIntelInternalProxyHandler func() http.Handler
 
+// NewCampaignsInternalProxyHandler creates a new proxy handler for internal campaigns routes
+// accessible from the campaign-executor (deployed separately from the k8s cluster).
+type NewCampaignsInternalProxyHandler func() http.Handler
+
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
@@ -36,6 +41,7 @@ func DefaultServices() Services {
 		BitbucketServerWebhook:           makeNotFoundHandler("bitbucket server webhook"),
 		NewCodeIntelUploadHandler:        func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewCodeIntelInternalProxyHandler: func() http.Handler { return makeNotFoundHandler("code intel internal proxy") },
+		NewCampaignsInternalProxyHandler: func() http.Handler { return makeNotFoundHandler("campaigns internal proxy") },
 		AuthzResolver:                    graphqlbackend.DefaultAuthzResolver,
 		CampaignsResolver:                graphqlbackend.DefaultCampaignsResolver,
 	}
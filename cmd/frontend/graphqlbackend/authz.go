@@ -10,6 +10,7 @@ import (
 type AuthzResolver interface {
 	// Mutations
 	SetRepositoryPermissionsForUsers(ctx context.Context, args *RepoPermsArgs) (*EmptyResponse, error)
+	SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error)
 	ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error)
 	ScheduleUserPermissionsSync(ctx context.Context, args *UserIDArgs) (*EmptyResponse, error)
 
@@ -33,6 +34,10 @@ func (defaultAuthzResolver) SetRepositoryPermissionsForUsers(ctx context.Context
 	return nil, authzInEnterprise
 }
 
+func (defaultAuthzResolver) SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error) {
+	return nil, authzInEnterprise
+}
+
 func (defaultAuthzResolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error) {
 	return nil, authzInEnterprise
 }
@@ -81,6 +86,15 @@ type RepoPermsArgs struct {
 	}
 }
 
+type SubRepoPermsArgs struct {
+	Repository      graphql.ID
+	UserPermissions []struct {
+		BindID       string
+		PathIncludes *[]string
+		PathExcludes *[]string
+	}
+}
+
 type AuthorizedRepoArgs struct {
 	Username *string
 	Email    *string
@@ -47,6 +47,10 @@ type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type ExecuteCampaignSpecArgs struct {
+	CampaignSpec graphql.ID
+}
+
 type CreateChangesetSpecArgs struct {
 	ChangesetSpec string
 }
@@ -83,6 +87,7 @@ type CampaignsResolver interface {
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	CreateCampaignSpec(ctx context.Context, args *CreateCampaignSpecArgs) (CampaignSpecResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
+	ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (*EmptyResponse, error)
 
 	// Queries
 	Campaigns(ctx context.Context, args *ListCampaignsArgs) (CampaignsConnectionResolver, error)
@@ -297,6 +302,7 @@ type ExternalChangesetResolver interface {
 	Labels(ctx context.Context) ([]ChangesetLabelResolver, error)
 
 	Error() *string
+	ScheduleEstimateAt() *DateTime
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
 }
@@ -359,6 +365,10 @@ func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncCha
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error) {
 	return nil, campaignsOnlyInEnterprise
 }
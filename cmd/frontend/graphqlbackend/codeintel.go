@@ -19,6 +19,9 @@ type CodeIntelResolver interface {
 	LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	LSIFIndexesByRepo(ctx context.Context, args *LSIFRepositoryIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	DeleteLSIFIndex(ctx context.Context, id graphql.ID) (*EmptyResponse, error)
+	QueueAutoIndexJobForRepo(ctx context.Context, args *QueueAutoIndexJobForRepoArgs) (*EmptyResponse, error)
+	UpdateLSIFRetentionConfigurationForRepo(ctx context.Context, args *UpdateLSIFRetentionConfigurationForRepoArgs) (*EmptyResponse, error)
+	LSIFIndexingStatusByRepo(ctx context.Context, repositoryID graphql.ID) (LSIFIndexingStatusResolver, error)
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
 }
 
@@ -60,6 +63,18 @@ func (defaultCodeIntelResolver) DeleteLSIFIndex(ctx context.Context, id graphql.
 	return nil, codeIntelOnlyInEnterprise
 }
 
+func (defaultCodeIntelResolver) QueueAutoIndexJobForRepo(ctx context.Context, args *QueueAutoIndexJobForRepoArgs) (*EmptyResponse, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) UpdateLSIFRetentionConfigurationForRepo(ctx context.Context, args *UpdateLSIFRetentionConfigurationForRepoArgs) (*EmptyResponse, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFIndexingStatusByRepo(ctx context.Context, repositoryID graphql.ID) (LSIFIndexingStatusResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
 func (defaultCodeIntelResolver) GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error) {
 	return nil, codeIntelOnlyInEnterprise
 }
@@ -80,6 +95,14 @@ func (r *schemaResolver) DeleteLSIFIndex(ctx context.Context, args *struct{ ID g
 	return r.CodeIntelResolver.DeleteLSIFIndex(ctx, args.ID)
 }
 
+func (r *schemaResolver) QueueAutoIndexJobForRepo(ctx context.Context, args *QueueAutoIndexJobForRepoArgs) (*EmptyResponse, error) {
+	return r.CodeIntelResolver.QueueAutoIndexJobForRepo(ctx, args)
+}
+
+func (r *schemaResolver) UpdateLSIFRetentionConfigurationForRepo(ctx context.Context, args *UpdateLSIFRetentionConfigurationForRepoArgs) (*EmptyResponse, error) {
+	return r.CodeIntelResolver.UpdateLSIFRetentionConfigurationForRepo(ctx, args)
+}
+
 type LSIFUploadsQueryArgs struct {
 	graphqlutil.ConnectionArgs
 	Query           *string
@@ -126,6 +149,28 @@ type LSIFRepositoryIndexesQueryArgs struct {
 	RepositoryID graphql.ID
 }
 
+type QueueAutoIndexJobForRepoArgs struct {
+	Repository graphql.ID
+	Rev        *string
+}
+
+type UpdateLSIFRetentionConfigurationForRepoArgs struct {
+	Repository   graphql.ID
+	MaxAge       *string
+	TagPattern   *string
+	MaxTaggedAge *string
+}
+
+type LSIFIndexingStatusResolver interface {
+	ConfigurationError() LSIFIndexConfigurationErrorResolver
+}
+
+type LSIFIndexConfigurationErrorResolver interface {
+	Commit() string
+	Message() string
+	UpdatedAt() DateTime
+}
+
 type LSIFIndexResolver interface {
 	ID() graphql.ID
 	InputCommit() string
@@ -156,7 +201,10 @@ type GitBlobLSIFDataResolver interface {
 	Ranges(ctx context.Context, args *LSIFRangesArgs) (CodeIntelligenceRangeConnectionResolver, error)
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Implementations(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
+	TypeDefinitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
+	DocumentSymbols(ctx context.Context) (DocumentSymbolConnectionResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
@@ -221,3 +269,16 @@ type DiagnosticResolver interface {
 	Message() (*string, error)
 	Location(ctx context.Context) (LocationResolver, error)
 }
+
+type DocumentSymbolConnectionResolver interface {
+	Nodes(ctx context.Context) ([]DocumentSymbolResolver, error)
+}
+
+type DocumentSymbolResolver interface {
+	Name() string
+	Detail() *string
+	Kind() string
+	Location(ctx context.Context) (LocationResolver, error)
+	FullRange() RangeResolver
+	Children() []DocumentSymbolResolver
+}
@@ -194,6 +194,10 @@ func (r *GitCommitResolver) Tree(ctx context.Context, args *struct {
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Paths that the current user can't read must look like they don't exist.
+	if err := checkSubRepoPerms(ctx, r.repoResolver.repo.ID, stat); err != nil {
+		return nil, err
+	}
 	if !stat.Mode().IsDir() {
 		return nil, fmt.Errorf("not a directory: %q", args.Path)
 	}
@@ -215,6 +219,10 @@ func (r *GitCommitResolver) Blob(ctx context.Context, args *struct {
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Paths that the current user can't read must look like they don't exist.
+	if err := checkSubRepoPerms(ctx, r.repoResolver.repo.ID, stat); err != nil {
+		return nil, err
+	}
 	if !stat.Mode().IsRegular() {
 		return nil, fmt.Errorf("not a blob: %q", args.Path)
 	}
@@ -52,6 +52,12 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 		}
 	}
 
+	// 🚨 SECURITY: Drop files and directories that the current user can't read.
+	entries, err = filterTreeEntriesBySubRepoPerms(ctx, r.commit.repoResolver.repo.ID, entries)
+	if err != nil {
+		return nil, err
+	}
+
 	sort.Sort(byDirectory(entries))
 
 	if args.First != nil && len(entries) > int(*args.First) {
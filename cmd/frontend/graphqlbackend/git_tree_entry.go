@@ -212,6 +212,9 @@ func reposourceCloneURLToRepoName(ctx context.Context, cloneURL string) (repoNam
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindGitea,
+			extsvc.KindGerrit,
+			extsvc.KindAzureDevOps,
 			extsvc.KindAWSCodeCommit,
 			extsvc.KindGitolite,
 		},
@@ -243,6 +246,12 @@ func reposourceCloneURLToRepoName(ctx context.Context, cloneURL string) (repoNam
 				rs = reposource.GitLab{GitLabConnection: c}
 			case *schema.BitbucketServerConnection:
 				rs = reposource.BitbucketServer{BitbucketServerConnection: c}
+			case *schema.GiteaConnection:
+				rs = reposource.Gitea{GiteaConnection: c}
+			case *schema.GerritConnection:
+				rs = reposource.Gerrit{GerritConnection: c}
+			case *schema.AzureDevOpsConnection:
+				rs = reposource.AzureDevOps{AzureDevOpsConnection: c}
 			case *schema.AWSCodeCommitConnection:
 				rs = reposource.AWS{AWSCodeCommitConnection: c}
 			case *schema.GitoliteConnection:
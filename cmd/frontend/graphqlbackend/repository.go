@@ -330,6 +330,10 @@ func (r *RepositoryResolver) LSIFIndexes(ctx context.Context, args *LSIFIndexesQ
 	})
 }
 
+func (r *RepositoryResolver) LSIFIndexingStatus(ctx context.Context) (LSIFIndexingStatusResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFIndexingStatusByRepo(ctx, r.ID())
+}
+
 type AuthorizedUserArgs struct {
 	RepositoryID graphql.ID
 	Permission   string
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/highlight"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -247,11 +248,23 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 				return
 			}
 
+			// 🚨 SECURITY: Drop the diffs of files that the current user can't read.
+			var perms *authz.SubRepoPermissions
+			perms, err = subRepoPerms(ctx, cmp.repo.repo.ID)
+			if err != nil {
+				return
+			}
+			var canReadPath func(string) bool
+			if perms != nil {
+				canReadPath = perms.CanReadFile
+			}
+
 			var iter *git.DiffFileIterator
 			iter, err = git.Diff(ctx, git.DiffOptions{
-				Repo: *cachedRepo,
-				Base: base,
-				Head: string(cmp.head.OID()),
+				Repo:        *cachedRepo,
+				Base:        base,
+				Head:        string(cmp.head.OID()),
+				CanReadPath: canReadPath,
 			})
 			if err != nil {
 				return
@@ -518,6 +518,59 @@ type Mutation {
     """
     deleteLSIFIndex(id: ID!): EmptyResponse
 
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    Requests that LSIF index jobs be scheduled for the given revision of a repository.
+    The jobs are determined by the repository's index configuration, or inferred from
+    the repository's contents. Jobs are not scheduled for a commit that already has an
+    LSIF upload or index. Only site admins may perform this mutation.
+    """
+    queueAutoIndexJobForRepo(
+        """
+        The repository to index.
+        """
+        repository: ID!
+
+        """
+        The revision to index. Defaults to the tip of the default branch.
+        """
+        rev: String
+    ): EmptyResponse
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    Overrides the data retention policy of the LSIF uploads of a repository. Arguments
+    that are not set fall back to the global data retention policy, so that setting none
+    of them removes the override. Only site admins may perform this mutation.
+    """
+    updateLSIFRetentionConfigurationForRepo(
+        """
+        The repository whose data retention policy to override.
+        """
+        repository: ID!
+
+        """
+        The age after which uploads that are not visible from the tip of the default branch
+        expire, as a duration such as "720h".
+        """
+        maxAge: String
+
+        """
+        A regular expression of the names of the tags whose uploads are kept for maxTaggedAge.
+        """
+        tagPattern: String
+
+        """
+        The age after which uploads of tags matching tagPattern expire, as a duration such
+        as "8760h".
+        """
+        maxTaggedAge: String
+    ): EmptyResponse
+
     """
     Set the permissions of a repository (i.e., which users may view it on Sourcegraph). This
     operation overwrites the previous permissions for the repository.
@@ -535,6 +588,22 @@ type Mutation {
         userPermissions: [UserPermission!]!
     ): EmptyResponse!
     """
+    Set the path-level permissions of users in a repository (i.e., which files and directories of the
+    repository they may view on Sourcegraph). This operation overwrites the previous path-level
+    permissions of all users for the repository.
+    """
+    setSubRepositoryPermissionsForUsers(
+        """
+        The repository whose path-level permissions to set.
+        """
+        repository: ID!
+        """
+        A list of user identifiers and their path-level permissions. Users not included in the list
+        may view all paths of the repository they have access to. All users must already exist.
+        """
+        userPermissions: [UserSubRepositoryPermission!]!
+    ): EmptyResponse!
+    """
     Schedule a permissions sync for given repository. This queries the repository's code host for
     all users' permissions associated with the repository, so that the current permissions apply
     to all users' operations on that repository on Sourcegraph.
@@ -662,6 +731,14 @@ type Mutation {
     """
     syncChangeset(changeset: ID!): EmptyResponse!
 
+    """
+    Enqueue the server-side execution of the steps of the given campaign spec in every repository
+    it applies to. The campaign executor adds the resulting changeset specs to the campaign spec.
+
+    Only site admins and the creator of the campaign spec may execute it.
+    """
+    executeCampaignSpec(campaignSpec: ID!): EmptyResponse!
+
     """
     OBSERVABILITY
 
@@ -1041,6 +1118,29 @@ input UserPermission {
     permission: RepositoryPermission = READ
 }
 
+"""
+A user (identified either by username or email address) with its path-level permissions in a
+repository.
+"""
+input UserSubRepositoryPermission {
+    """
+    Depending on the bindID option in the permissions.userMapping site configuration property,
+    the elements of the list are either all usernames (bindID of "username") or all email
+    addresses (bindID of "email").
+    """
+    bindID: String!
+    """
+    Glob patterns of the paths the user may view, relative to the repository root (e.g.,
+    "src/**"). "*" matches within a path segment and "**" matches any number of path segments.
+    If empty, all paths not excluded may be viewed.
+    """
+    pathIncludes: [String!] = []
+    """
+    Glob patterns of the paths the user may not view, even if they are included.
+    """
+    pathExcludes: [String!] = []
+}
+
 """
 A campaign is a set of related changes to apply to code across one or more repositories.
 """
@@ -1272,6 +1372,12 @@ enum ChangesetReconcilerState {
     The changeset is not enqueued for processing.
     """
     COMPLETED
+
+    """
+    The changeset is held back by the publication schedule of its campaign
+    and will be published at the time given by scheduleEstimateAt.
+    """
+    SCHEDULED
 }
 
 """
@@ -1528,6 +1634,11 @@ type ExternalChangeset implements Node & Changeset {
     """
     reconcilerState: ChangesetReconcilerState!
 
+    """
+    If the changeset is scheduled to be published later, because of the publication schedule of the campaign that owns it, the estimated date and time at which it will be published. Null if the changeset isn't scheduled.
+    """
+    scheduleEstimateAt: DateTime
+
     """
     The external state of the changeset, or null when not yet published to the code host or when the changeset data hasn't been synced from the code host yet.
     """
@@ -2932,11 +3043,15 @@ A specific kind of external service.
 """
 enum ExternalServiceKind {
     AWSCODECOMMIT
+    AZUREDEVOPS
     BITBUCKETCLOUD
     BITBUCKETSERVER
+    GERRIT
+    GITEA
     GITHUB
     GITLAB
     GITOLITE
+    PERFORCE
     PHABRICATOR
     OTHER
 }
@@ -3314,6 +3429,14 @@ type Repository implements Node & GenericSearchResultInterface {
         after: String
     ): LSIFIndexConnection!
 
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    The status of automatic LSIF indexing for this repository.
+    """
+    lsifIndexingStatus: LSIFIndexingStatus!
+
     """
     A list of authorized users to access this repository with the given permission.
     This API currently only returns permissions from the Sourcegraph provider, i.e.
@@ -5145,6 +5268,42 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
         first: Int
     ): LocationConnection!
 
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    A list of implementations of the symbol under the given document position.
+    """
+    implementations(
+        """
+        The line on which the symbol occurs (zero-based, inclusive).
+        """
+        line: Int!
+
+        """
+        The character (not byte) of the start line on which the symbol occurs (zero-based, inclusive).
+        """
+        character: Int!
+    ): LocationConnection!
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    A list of definitions of the type of the symbol under the given document position.
+    """
+    typeDefinitions(
+        """
+        The line on which the symbol occurs (zero-based, inclusive).
+        """
+        line: Int!
+
+        """
+        The character (not byte) of the start line on which the symbol occurs (zero-based, inclusive).
+        """
+        character: Int!
+    ): LocationConnection!
+
     """
     (experimental) The LSIF API may change substantially in the near future as we
     continue to adjust it for our use cases. Changes will not be documented in the
@@ -5170,6 +5329,14 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
     Code diagnostics provided through LSIF.
     """
     diagnostics(first: Int): DiagnosticConnection!
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    The hierarchy of symbols defined in this document.
+    """
+    documentSymbols: DocumentSymbolConnection!
 }
 
 """
@@ -6992,6 +7159,51 @@ type CodeIntelligenceRange {
     hover: Hover
 }
 
+"""
+A list of symbols defined in a document.
+"""
+type DocumentSymbolConnection {
+    """
+    A list of symbols defined in a document.
+    """
+    nodes: [DocumentSymbol!]!
+}
+
+"""
+A symbol defined in a document, as provided through LSIF.
+"""
+type DocumentSymbol {
+    """
+    The name of the symbol.
+    """
+    name: String!
+
+    """
+    More detail for the symbol, such as the signature of a function.
+    """
+    detail: String
+
+    """
+    The kind of the symbol.
+    """
+    kind: SymbolKind!
+
+    """
+    The location of the symbol's name, e.g. the name of a function.
+    """
+    location: Location!
+
+    """
+    The range enclosing the symbol, e.g. the entire body of a function.
+    """
+    fullRange: Range!
+
+    """
+    The symbols defined within this symbol, e.g. the methods of a class.
+    """
+    children: [DocumentSymbol!]!
+}
+
 """
 A list of locations within a file.
 """
@@ -7235,6 +7447,38 @@ type LSIFIndexConnection {
     pageInfo: PageInfo!
 }
 
+"""
+The status of automatic LSIF indexing for a repository.
+"""
+type LSIFIndexingStatus {
+    """
+    The error that occurred while reading the repository's explicit index configuration
+    during the last scheduling attempt. This is null when the configuration was read
+    successfully or when the repository has no explicit index configuration.
+    """
+    configurationError: LSIFIndexConfigurationError
+}
+
+"""
+An error that occurred while reading a repository's explicit index configuration.
+"""
+type LSIFIndexConfigurationError {
+    """
+    The 40-character commit at which the index configuration was read.
+    """
+    commit: String!
+
+    """
+    A description of the error.
+    """
+    message: String!
+
+    """
+    The time the error occurred.
+    """
+    updatedAt: DateTime!
+}
+
 """
 Mutations that are only used on Sourcegraph.com.
 FOR INTERNAL USE ONLY.
@@ -50,6 +50,11 @@ type SearchArgs struct {
 	After          *string
 	First          *int32
 	VersionContext *string
+
+	// Progress, if non-nil, is called with a snapshot of the progress of the
+	// search whenever it changes. It is not part of the GraphQL API, and is
+	// called concurrently from the goroutines running the search.
+	Progress func(SearchProgress)
 }
 
 type SearchImplementer interface {
@@ -136,6 +141,7 @@ func NewSearchImplementer(ctx context.Context, args *SearchArgs) (_ SearchImplem
 		patternType:    searchType,
 		zoekt:          search.Indexed(),
 		searcherURLs:   search.SearcherURLs(),
+		progress:       args.Progress,
 	}, nil
 }
 
@@ -294,8 +300,35 @@ type searchResolver struct {
 
 	zoekt        *searchbackend.Zoekt
 	searcherURLs *endpoint.Map
+
+	// progress, if non-nil, is called with the progress of the search.
+	progress func(SearchProgress)
+
+	// searcherExpression is the part of the search that evaluateLeaf performs
+	// while searcher evaluates an and/or expression of search patterns.
+	searcherExpression searcherExpressionMode
 }
 
+// searcherExpressionMode is the part of a search that is performed while
+// searcher evaluates an and/or expression of search patterns for the file
+// contents and paths of unindexed repositories.
+type searcherExpressionMode int
+
+const (
+	// searcherExpressionNone is a search that is not part of such an
+	// evaluation.
+	searcherExpressionNone searcherExpressionMode = iota
+
+	// searcherExpressionOperand is the search for an operand of the
+	// expression, which skips the file contents and paths of unindexed
+	// repositories.
+	searcherExpressionOperand
+
+	// searcherExpressionUnindexed is the search of the file contents and
+	// paths of unindexed repositories for the whole expression.
+	searcherExpressionUnindexed
+)
+
 // rawQuery returns the original query string input.
 func (r *searchResolver) rawQuery() string {
 	return r.originalQuery
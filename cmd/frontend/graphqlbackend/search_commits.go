@@ -210,6 +210,17 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 		IsRegExp:        op.PatternInfo.IsRegExp,
 		IsCaseSensitive: op.PatternInfo.IsCaseSensitive,
 	}
+	// 🚨 SECURITY: Drop diffs of files that the current user can't read, and commits that only change
+	// such files.
+	perms, err := subRepoPerms(ctx, repo.ID)
+	if err != nil {
+		return nil, false, false, err
+	}
+	var canReadPath func(string) bool
+	if perms != nil {
+		canReadPath = perms.CanReadFile
+	}
+
 	diffParameters := search.DiffParameters{
 		Repo: op.RepoRevs.GitserverRepo(),
 		Options: git.RawLogDiffSearchOptions{
@@ -220,6 +231,7 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 				IsCaseSensitive: op.PatternInfo.PathPatternsAreCaseSensitive,
 				IsRegExp:        op.PatternInfo.PathPatternsAreRegExps,
 			},
+			CanReadPath:       canReadPath,
 			Diff:              op.Diff,
 			OnlyMatchingHunks: true,
 			Args:              args,
@@ -430,6 +442,7 @@ func searchCommitDiffsInRepos(ctx context.Context, args *search.TextParametersFo
 		wg          sync.WaitGroup
 		mu          sync.Mutex
 		unflattened [][]*CommitSearchResultResolver
+		matchCount  int32
 		common      = &searchResultsCommon{}
 	)
 	common.repos = make([]*types.Repo, len(args.Repos))
@@ -464,7 +477,9 @@ func searchCommitDiffsInRepos(ctx context.Context, args *search.TextParametersFo
 			}
 			if len(results) > 0 {
 				unflattened = append(unflattened, results)
+				matchCount += int32(len(results))
 			}
+			reportRepoProgress(ctx, common, matchCount)
 		}(repoRev)
 	}
 	wg.Wait()
@@ -501,6 +516,7 @@ func searchCommitLogInRepos(ctx context.Context, args *search.TextParametersForC
 		wg          sync.WaitGroup
 		mu          sync.Mutex
 		unflattened [][]*CommitSearchResultResolver
+		matchCount  int32
 		common      = &searchResultsCommon{}
 	)
 	common.repos = make([]*types.Repo, len(args.Repos))
@@ -529,7 +545,9 @@ func searchCommitLogInRepos(ctx context.Context, args *search.TextParametersForC
 			}
 			if len(results) > 0 {
 				unflattened = append(unflattened, results)
+				matchCount += int32(len(results))
 			}
+			reportRepoProgress(ctx, common, matchCount)
 		}(repoRev)
 	}
 	wg.Wait()
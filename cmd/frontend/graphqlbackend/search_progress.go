@@ -0,0 +1,181 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// SearchProgress is a snapshot of the progress of a running search. It is
+// reported incrementally to SearchArgs.Progress while the search is running,
+// and is available for a finished search through
+// SearchResultsResolver.Progress.
+type SearchProgress struct {
+	// RepositoriesCount is the number of repositories matched by the
+	// repo-related filters of the query.
+	RepositoriesCount int
+
+	// Searched is the number of repositories that were searched so far.
+	Searched int
+
+	// Indexed is the number of repositories that were searched using an
+	// index so far.
+	Indexed int
+
+	// Cloning, Missing and Timedout are the repositories that could not be
+	// searched because they are still being cloned, do not exist or did not
+	// finish being searched in time.
+	Cloning  []api.RepoName
+	Missing  []api.RepoName
+	Timedout []api.RepoName
+
+	// ExcludedForks and ExcludedArchived are the number of repositories that
+	// were excluded from the search because they are forks or archived.
+	ExcludedForks    int
+	ExcludedArchived int
+
+	// MatchCount is the number of matches found so far.
+	MatchCount int
+
+	// LimitHit is true if the limit on results was hit.
+	LimitHit bool
+}
+
+// progress returns a snapshot of the progress described by c, given the
+// number of matches found.
+func (c *searchResultsCommon) progress(matchCount int32) SearchProgress {
+	return SearchProgress{
+		RepositoriesCount: len(dedupedRepos(c.repos)),
+		Searched:          len(dedupedRepos(c.searched)),
+		Indexed:           len(dedupedRepos(c.indexed)),
+		Cloning:           repoNames(c.cloning),
+		Missing:           repoNames(c.missing),
+		Timedout:          repoNames(c.timedout),
+		ExcludedForks:     c.excluded.forks,
+		ExcludedArchived:  c.excluded.archived,
+		MatchCount:        int(matchCount),
+		LimitHit:          c.limitHit || matchCount > c.maxResultsCount,
+	}
+}
+
+// Progress returns the progress of the finished search.
+func (sr *SearchResultsResolver) Progress() SearchProgress {
+	return sr.searchResultsCommon.progress(sr.MatchCount())
+}
+
+// report calls the progress callback of a, if any, with a snapshot of the
+// progress of the search so far, including the progress reported by the
+// sub-searches that are still running.
+func (a *aggregator) report() {
+	if a.progress == nil {
+		return
+	}
+
+	a.fileMatchesMu.Lock()
+	a.resultsMu.Lock()
+	var matchCount int32
+	for _, r := range a.results {
+		matchCount += r.resultCount()
+	}
+	a.resultsMu.Unlock()
+	a.fileMatchesMu.Unlock()
+
+	a.commonMu.Lock()
+	common := &a.common
+	if len(a.running) > 0 {
+		merged := searchResultsCommon{maxResultsCount: a.common.maxResultsCount}
+		merged.update(a.common)
+		for r := range a.running {
+			merged.update(r.common)
+			matchCount += r.matchCount
+		}
+		common = &merged
+	}
+	p := common.progress(matchCount)
+	a.commonMu.Unlock()
+
+	a.progress(p)
+}
+
+// runningSearch is the progress reported so far by a sub-search that is still
+// running.
+type runningSearch struct {
+	common     searchResultsCommon
+	matchCount int32
+}
+
+// repoProgressKey is the context key of the callback that sub-searches call
+// each time they finish searching a repository.
+type repoProgressKey struct{}
+
+// trackProgress returns a context that makes the sub-search run with it report
+// its progress through a each time it finishes searching a repository, and a
+// function to call with the results of the sub-search once it is done, which
+// replace the progress it reported.
+func (a *aggregator) trackProgress(ctx context.Context) (context.Context, func(*searchResultsCommon)) {
+	finish := func(common *searchResultsCommon) {
+		if common != nil {
+			a.commonMu.Lock()
+			a.common.update(*common)
+			a.commonMu.Unlock()
+		}
+	}
+	if a.progress == nil {
+		return ctx, finish
+	}
+
+	running := &runningSearch{}
+	a.commonMu.Lock()
+	if a.running == nil {
+		a.running = make(map[*runningSearch]struct{})
+	}
+	a.running[running] = struct{}{}
+	a.commonMu.Unlock()
+
+	ctx = context.WithValue(ctx, repoProgressKey{}, func(common *searchResultsCommon, matchCount int32) {
+		a.commonMu.Lock()
+		// Copy common, since the sub-search keeps modifying it.
+		running.common = searchResultsCommon{}
+		running.common.update(*common)
+		running.matchCount = matchCount
+		a.commonMu.Unlock()
+		a.report()
+	})
+
+	return ctx, func(common *searchResultsCommon) {
+		a.commonMu.Lock()
+		delete(a.running, running)
+		if common != nil {
+			a.common.update(*common)
+		}
+		a.commonMu.Unlock()
+	}
+}
+
+// reportRepoProgress reports the progress of a running sub-search and the
+// number of matches it found so far to the aggregator tracking it, if any.
+// Sub-searches call it each time they finish searching a repository, while
+// holding the lock that guards common.
+func reportRepoProgress(ctx context.Context, common *searchResultsCommon, matchCount int32) {
+	if report, ok := ctx.Value(repoProgressKey{}).(func(*searchResultsCommon, int32)); ok {
+		report(common, matchCount)
+	}
+}
+
+// dedupedRepos returns a sorted and deduplicated copy of repos, leaving repos
+// untouched.
+func dedupedRepos(repos types.Repos) types.Repos {
+	repos = append(types.Repos(nil), repos...)
+	dedupSort(&repos)
+	return repos
+}
+
+func repoNames(repos types.Repos) []api.RepoName {
+	repos = dedupedRepos(repos)
+	names := make([]api.RepoName, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	return names
+}
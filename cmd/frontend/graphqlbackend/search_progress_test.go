@@ -0,0 +1,113 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestSearchResultsCommon_progress(t *testing.T) {
+	a := &types.Repo{ID: 1, Name: "a"}
+	b := &types.Repo{ID: 2, Name: "b"}
+	c := &types.Repo{ID: 3, Name: "c"}
+
+	common := searchResultsCommon{
+		maxResultsCount: 10,
+		repos:           []*types.Repo{c, a, b, a},
+		searched:        []*types.Repo{a, a},
+		indexed:         []*types.Repo{a},
+		cloning:         []*types.Repo{c, b, c},
+		timedout:        []*types.Repo{b},
+		excluded:        excludedRepos{forks: 2, archived: 1},
+	}
+
+	want := SearchProgress{
+		RepositoriesCount: 3,
+		Searched:          1,
+		Indexed:           1,
+		Cloning:           []api.RepoName{"b", "c"},
+		Missing:           []api.RepoName{},
+		Timedout:          []api.RepoName{"b"},
+		ExcludedForks:     2,
+		ExcludedArchived:  1,
+		MatchCount:        11,
+		LimitHit:          true,
+	}
+	if diff := cmp.Diff(want, common.progress(11)); diff != "" {
+		t.Errorf("progress mismatch (-want +got):\n%s", diff)
+	}
+
+	// progress must not modify the repos of the common it's called on, since
+	// it's called while the search is still running.
+	if diff := cmp.Diff([]*types.Repo{c, b, c}, common.cloning); diff != "" {
+		t.Errorf("cloning modified (-want +got):\n%s", diff)
+	}
+}
+
+func TestAggregator_report(t *testing.T) {
+	var reported []SearchProgress
+	agg := aggregator{
+		common:      searchResultsCommon{maxResultsCount: 30},
+		fileMatches: make(map[string]*FileMatchResolver),
+		progress:    func(p SearchProgress) { reported = append(reported, p) },
+	}
+
+	agg.report()
+	agg.results = append(agg.results, &FileMatchResolver{MatchCount: 2})
+	agg.common.update(searchResultsCommon{searched: []*types.Repo{{ID: 1, Name: "a"}}})
+	agg.report()
+
+	want := []SearchProgress{
+		{Cloning: []api.RepoName{}, Missing: []api.RepoName{}, Timedout: []api.RepoName{}},
+		{Searched: 1, MatchCount: 2, Cloning: []api.RepoName{}, Missing: []api.RepoName{}, Timedout: []api.RepoName{}},
+	}
+	if diff := cmp.Diff(want, reported); diff != "" {
+		t.Errorf("reported progress mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAggregator_trackProgress(t *testing.T) {
+	var reported []SearchProgress
+	agg := aggregator{
+		common:      searchResultsCommon{maxResultsCount: 30},
+		fileMatches: make(map[string]*FileMatchResolver),
+		progress:    func(p SearchProgress) { reported = append(reported, p) },
+	}
+
+	a := &types.Repo{ID: 1, Name: "a"}
+	b := &types.Repo{ID: 2, Name: "b"}
+
+	ctx, finish := agg.trackProgress(context.Background())
+
+	// A sub-search reports its progress after each repository, with the
+	// matches found so far.
+	common := &searchResultsCommon{searched: []*types.Repo{a}}
+	reportRepoProgress(ctx, common, 2)
+	common.searched = append(common.searched, b)
+	common.cloning = append(common.cloning, b)
+	reportRepoProgress(ctx, common, 5)
+
+	// Once done, its results replace the progress it reported.
+	agg.results = append(agg.results, &FileMatchResolver{MatchCount: 5})
+	finish(common)
+	agg.report()
+
+	// Reporting without a tracked context is a no-op.
+	reportRepoProgress(context.Background(), common, 10)
+
+	want := []SearchProgress{
+		{Searched: 1, MatchCount: 2, Cloning: []api.RepoName{}, Missing: []api.RepoName{}, Timedout: []api.RepoName{}},
+		{Searched: 2, MatchCount: 5, Cloning: []api.RepoName{"b"}, Missing: []api.RepoName{}, Timedout: []api.RepoName{}},
+		{Searched: 2, MatchCount: 5, Cloning: []api.RepoName{"b"}, Missing: []api.RepoName{}, Timedout: []api.RepoName{}},
+	}
+	if diff := cmp.Diff(want, reported); diff != "" {
+		t.Errorf("reported progress mismatch (-want +got):\n%s", diff)
+	}
+	if len(agg.running) != 0 {
+		t.Errorf("got %d running sub-searches, want none", len(agg.running))
+	}
+}
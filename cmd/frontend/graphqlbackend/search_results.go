@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/usagestats"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -1007,11 +1008,98 @@ func (r *searchResolver) evaluateOperator(ctx context.Context, scopeParameters [
 	return result, nil
 }
 
+// isSearcherPatternExpression returns true if searcher can evaluate the and/or
+// expression of search patterns in operator in a single search of the file
+// contents and paths of unindexed repositories in the scope of
+// scopeParameters. Structural search, negated patterns, content: and
+// paginated searches need an evaluation of each operand instead.
+func (r *searchResolver) isSearcherPatternExpression(scopeParameters []query.Node, operator query.Operator) bool {
+	if r.patternType == query.SearchTypeStructural || r.pagination != nil {
+		return false
+	}
+
+	negated := false
+	query.VisitPattern([]query.Node{operator}, func(_ string, isNegated bool, _ query.Annotation) {
+		negated = negated || isNegated
+	})
+	if negated {
+		return false
+	}
+
+	q := query.AndOrQuery{Query: scopeParameters}
+	if q.BoolValue(query.FieldStable) || len(q.Values(query.FieldContent)) > 0 {
+		return false
+	}
+	index, _ := q.StringValues(query.FieldIndex)
+	if len(index) > 0 && parseYesNoOnly(index[len(index)-1]) == Only {
+		return false
+	}
+	resultTypes, _ := q.StringValues(query.FieldType)
+	for _, resultType := range resultTypes {
+		if resultType == "file" || resultType == "path" {
+			return true
+		}
+	}
+	return len(resultTypes) == 0
+}
+
+// isSearcherOnly returns true if the scope of scopeParameters only searches
+// the file contents and paths of unindexed repositories, so that there is
+// nothing to search for each operand of an and/or expression of search
+// patterns once searcher has evaluated it.
+func isSearcherOnly(scopeParameters []query.Node) bool {
+	q := query.AndOrQuery{Query: scopeParameters}
+	index, _ := q.StringValues(query.FieldIndex)
+	if len(index) == 0 || parseYesNoOnly(index[len(index)-1]) != No {
+		return false
+	}
+	resultTypes, _ := q.StringValues(query.FieldType)
+	for _, resultType := range resultTypes {
+		if resultType != "file" && resultType != "path" {
+			return false
+		}
+	}
+	return len(resultTypes) > 0
+}
+
+// evaluateSearcherPatternExpression evaluates an and/or expression of search
+// patterns that searcher can evaluate. Searcher evaluates the expression in a
+// single search of the file contents and paths of unindexed repositories,
+// whereas indexed search and the other result types need an evaluation of
+// each operand.
+func (r *searchResolver) evaluateSearcherPatternExpression(ctx context.Context, scopeParameters []query.Node, operator query.Operator) (*SearchResultsResolver, error) {
+	defer func() { r.searcherExpression = searcherExpressionNone }()
+
+	var result *SearchResultsResolver
+	if !isSearcherOnly(scopeParameters) {
+		r.searcherExpression = searcherExpressionOperand
+		var err error
+		result, err = r.evaluateOperator(ctx, scopeParameters, operator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.searcherExpression = searcherExpressionUnindexed
+	q := append(scopeParameters, operator)
+	r.query.(*query.AndOrQuery).Query = q
+	unindexedResult, err := r.evaluateLeaf(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return union(result, unindexedResult), nil
+}
+
 // evaluatePatternExpression evaluates a search pattern containing and/or expressions.
 func (r *searchResolver) evaluatePatternExpression(ctx context.Context, scopeParameters []query.Node, node query.Node) (*SearchResultsResolver, error) {
 	switch term := node.(type) {
 	case query.Operator:
 		if term.Kind == query.And || term.Kind == query.Or {
+			// Nested expressions are part of the expression that searcher
+			// evaluates, so only the outermost one is handed to it.
+			if r.searcherExpression == searcherExpressionNone && r.isSearcherPatternExpression(scopeParameters, term) {
+				return r.evaluateSearcherPatternExpression(ctx, scopeParameters, term)
+			}
 			return r.evaluateOperator(ctx, scopeParameters, term)
 		} else if term.Kind == query.Concat {
 			q := append(scopeParameters, term)
@@ -1248,6 +1336,11 @@ type getPatternInfoOptions struct {
 	performStructuralSearch bool
 	performLiteralSearch    bool
 
+	// patternExpression, when true, specifies that an and/or expression of
+	// search patterns is evaluated by searcher in a single search instead of
+	// being concatenated into a single pattern.
+	patternExpression bool
+
 	fileMatchLimit int32
 }
 
@@ -1392,9 +1485,62 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 	if len(excludePatterns) > 0 {
 		patternInfo.ExcludePattern = unionRegExps(excludePatterns)
 	}
+	if andOrQuery, ok := q.(*query.AndOrQuery); ok && opts.patternExpression && isRegExp && !opts.forceFileSearch && len(q.Values(query.FieldContent)) == 0 {
+		expr, err := patternExpression(andOrQuery.Query)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			patternInfo.Pattern = ""
+			patternInfo.PatternExpression = expr
+		}
+	}
 	return patternInfo, nil
 }
 
+// patternExpression returns the and/or expression of the search patterns in
+// nodes, or nil if the search pattern is not an and/or expression.
+func patternExpression(nodes []query.Node) (*protocol.PatternExpression, error) {
+	_, pattern, err := query.PartitionSearchPattern(nodes)
+	if err != nil {
+		return nil, err
+	}
+	if operator, ok := pattern.(query.Operator); !ok || (operator.Kind != query.And && operator.Kind != query.Or) {
+		return nil, nil
+	}
+	return toPatternExpression(pattern), nil
+}
+
+func toPatternExpression(node query.Node) *protocol.PatternExpression {
+	if operator, ok := node.(query.Operator); ok && operator.Kind != query.Concat {
+		operands := make([]*protocol.PatternExpression, 0, len(operator.Operands))
+		for _, operand := range operator.Operands {
+			operands = append(operands, toPatternExpression(operand))
+		}
+		if operator.Kind == query.And {
+			return &protocol.PatternExpression{And: operands}
+		}
+		return &protocol.PatternExpression{Or: operands}
+	}
+
+	// A pattern or a concatenation of patterns is a leaf, and is converted
+	// like the pattern of a query without and/or expressions.
+	var pieces []string
+	for _, v := range (query.AndOrQuery{Query: []query.Node{node}}).Values(query.FieldDefault) {
+		var piece string
+		switch {
+		case v.String != nil:
+			piece = regexp.QuoteMeta(*v.String)
+		case v.Regexp != nil:
+			piece = v.Regexp.String()
+		}
+		if piece != "" {
+			pieces = append(pieces, piece)
+		}
+	}
+	return &protocol.PatternExpression{Pattern: orderedFuzzyRegexp(pieces)}
+}
+
 // langIncludeExcludePatterns returns regexps for the include/exclude path patterns given the lang:
 // and -lang: filter values in a search query. For example, a query containing "lang:go" should
 // include files whose paths match /\.go$/.
@@ -1558,6 +1704,13 @@ type aggregator struct {
 	// to merge multiple results of different types for the same file
 	fileMatchesMu sync.Mutex
 	fileMatches   map[string]*FileMatchResolver
+
+	// progress, if non-nil, is called by report.
+	progress func(SearchProgress)
+
+	// running is the set of sub-searches whose progress is tracked until they
+	// are done. It is guarded by commonMu.
+	running map[*runningSearch]struct{}
 }
 
 func (a *aggregator) doRepoSearch(ctx context.Context, args *search.TextParameters, limit int32) {
@@ -1565,6 +1718,8 @@ func (a *aggregator) doRepoSearch(ctx context.Context, args *search.TextParamete
 	defer func() {
 		tr.Finish()
 	}()
+	defer a.report()
+	ctx, finish := a.trackProgress(ctx)
 	repoResults, repoCommon, err := searchRepositories(ctx, args, limit)
 	// Timeouts are reported through searchResultsCommon so don't report an error for them
 	if err != nil && !isContextError(ctx, err) {
@@ -1577,17 +1732,15 @@ func (a *aggregator) doRepoSearch(ctx context.Context, args *search.TextParamete
 		a.results = append(a.results, repoResults...)
 		a.resultsMu.Unlock()
 	}
-	if repoCommon != nil {
-		a.commonMu.Lock()
-		a.common.update(*repoCommon)
-		a.commonMu.Unlock()
-	}
+	finish(repoCommon)
 }
 func (a *aggregator) doSymbolSearch(ctx context.Context, args *search.TextParameters, limit int) {
 	tr, ctx := trace.New(ctx, "doSymbolSearch", "")
 	defer func() {
 		tr.Finish()
 	}()
+	defer a.report()
+	ctx, finish := a.trackProgress(ctx)
 	symbolFileMatches, symbolsCommon, err := searchSymbols(ctx, args, limit)
 	// Timeouts are reported through searchResultsCommon so don't report an error for them
 	if err != nil && !isContextError(ctx, err) {
@@ -1608,17 +1761,15 @@ func (a *aggregator) doSymbolSearch(ctx context.Context, args *search.TextParame
 		}
 		a.fileMatchesMu.Unlock()
 	}
-	if symbolsCommon != nil {
-		a.commonMu.Lock()
-		a.common.update(*symbolsCommon)
-		a.commonMu.Unlock()
-	}
+	finish(symbolsCommon)
 }
 func (a *aggregator) doFilePathSearch(ctx context.Context, args *search.TextParameters) {
 	tr, ctx := trace.New(ctx, "doFilePathSearch", "")
 	defer func() {
 		tr.Finish()
 	}()
+	defer a.report()
+	ctx, finish := a.trackProgress(ctx)
 	fileResults, fileCommon, err := searchFilesInRepos(ctx, args)
 	// Timeouts are reported through searchResultsCommon so don't report an error for them
 	if err != nil && !isContextError(ctx, err) {
@@ -1656,11 +1807,7 @@ func (a *aggregator) doFilePathSearch(ctx context.Context, args *search.TextPara
 		}
 		a.fileMatchesMu.Unlock()
 	}
-	if fileCommon != nil {
-		a.commonMu.Lock()
-		a.common.update(*fileCommon)
-		a.commonMu.Unlock()
-	}
+	finish(fileCommon)
 }
 
 func (a *aggregator) doDiffSearch(ctx context.Context, tp *search.TextParameters) {
@@ -1668,6 +1815,7 @@ func (a *aggregator) doDiffSearch(ctx context.Context, tp *search.TextParameters
 	defer func() {
 		tr.Finish()
 	}()
+	defer a.report()
 	old := tp.PatternInfo
 	patternInfo := &search.CommitPatternInfo{
 		Pattern:                      old.Pattern,
@@ -1690,6 +1838,7 @@ func (a *aggregator) doDiffSearch(ctx context.Context, tp *search.TextParameters
 		Repos:       repos,
 		Query:       tp.Query,
 	}
+	ctx, finish := a.trackProgress(ctx)
 	diffResults, diffCommon, err := searchCommitDiffsInRepos(ctx, &args)
 	// Timeouts are reported through searchResultsCommon so don't report an error for them
 	if err != nil && !isContextError(ctx, err) {
@@ -1702,11 +1851,7 @@ func (a *aggregator) doDiffSearch(ctx context.Context, tp *search.TextParameters
 		a.results = append(a.results, diffResults...)
 		a.resultsMu.Unlock()
 	}
-	if diffCommon != nil {
-		a.commonMu.Lock()
-		a.common.update(*diffCommon)
-		a.commonMu.Unlock()
-	}
+	finish(diffCommon)
 }
 
 func (a *aggregator) doCommitSearch(ctx context.Context, tp *search.TextParameters) {
@@ -1714,6 +1859,7 @@ func (a *aggregator) doCommitSearch(ctx context.Context, tp *search.TextParamete
 	defer func() {
 		tr.Finish()
 	}()
+	defer a.report()
 	old := tp.PatternInfo
 	patternInfo := &search.CommitPatternInfo{
 		Pattern:                      old.Pattern,
@@ -1736,6 +1882,7 @@ func (a *aggregator) doCommitSearch(ctx context.Context, tp *search.TextParamete
 		Repos:       repos,
 		Query:       tp.Query,
 	}
+	ctx, finish := a.trackProgress(ctx)
 	commitResults, commitCommon, err := searchCommitLogInRepos(ctx, &args)
 	// Timeouts are reported through searchResultsCommon so don't report an error for them
 	if err != nil && !isContextError(ctx, err) {
@@ -1748,11 +1895,7 @@ func (a *aggregator) doCommitSearch(ctx context.Context, tp *search.TextParamete
 		a.results = append(a.results, commitResults...)
 		a.resultsMu.Unlock()
 	}
-	if commitCommon != nil {
-		a.commonMu.Lock()
-		a.common.update(*commitCommon)
-		a.commonMu.Unlock()
-	}
+	finish(commitCommon)
 }
 
 // isGlobalSearch returns true if the query contains the filters repo or
@@ -1797,6 +1940,9 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	if r.patternType == query.SearchTypeLiteral {
 		options = &getPatternInfoOptions{performLiteralSearch: true}
 	}
+	// The query only contains an and/or expression of search patterns if
+	// evaluatePatternExpression leaves its evaluation to searcher.
+	options.patternExpression = true
 	p, err := r.getPatternInfo(options)
 	if err != nil {
 		return nil, err
@@ -1814,6 +1960,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		PatternInfo:     p,
 		Query:           r.query,
 		UseFullDeadline: r.searchTimeoutFieldSet(),
+		SkipUnindexed:   r.searcherExpression == searcherExpressionOperand,
 		Zoekt:           r.zoekt,
 		SearcherURLs:    r.searcherURLs,
 		RepoPromise:     &search.Promise{},
@@ -1828,6 +1975,18 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	}
 
 	resultTypes := r.determineResultTypes(args, forceOnlyResultType)
+	if r.searcherExpression == searcherExpressionUnindexed {
+		// Only the file contents and paths of unindexed repositories are
+		// searched for the whole and/or expression of search patterns.
+		fileOrPathTypes := resultTypes[:0]
+		for _, rt := range resultTypes {
+			if rt == "file" || rt == "path" {
+				fileOrPathTypes = append(fileOrPathTypes, rt)
+			}
+		}
+		resultTypes = fileOrPathTypes
+		args.Mode = search.SearcherOnly
+	}
 	tr.LazyPrintf("resultTypes: %v", resultTypes)
 	var (
 		requiredWg sync.WaitGroup
@@ -1851,6 +2010,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	agg := aggregator{
 		common:      searchResultsCommon{maxResultsCount: r.maxResults()},
 		fileMatches: make(map[string]*FileMatchResolver),
+		progress:    r.progress,
 	}
 
 	isFileOrPath := func() bool {
@@ -1864,7 +2024,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 
 	// performance optimization: call zoekt early, resolve repos concurrently, filter
 	// search results with resolved repos.
-	if r.isGlobalSearch() && isFileOrPath() {
+	if r.isGlobalSearch() && isFileOrPath() && r.searcherExpression != searcherExpressionUnindexed {
 		// to protect us from regression, we explicitly create a child context which is
 		// canceled if we return from doResults
 		ctx, cancel := context.WithCancel(ctx)
@@ -1880,7 +2040,9 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 		// On sourcegraph.com and for unscoped queries, determineRepos returns the subset
 		// of indexed default repositories. No need to call searcher, because
 		// len(searcherRepos) will always be 0.
-		if envvar.SourcegraphDotComMode() {
+		// Unindexed repositories are also not searched if searcher evaluates
+		// the whole and/or expression of search patterns for them.
+		if envvar.SourcegraphDotComMode() || args.SkipUnindexed {
 			args.Mode = search.NoFilePath
 		} else {
 			args.Mode = search.SearcherOnly
@@ -1899,6 +2061,13 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	agg.commonMu.Lock()
 	agg.common.excluded = resolved.excludedRepos
 	agg.commonMu.Unlock()
+	agg.report()
+
+	// The search may have been canceled while resolving repositories, e.g. by
+	// a streaming client going away. Don't fan out to the backends then.
+	if ctx.Err() == context.Canceled {
+		return nil, ctx.Err()
+	}
 
 	// Apply search limits and generate warnings before firing off workers.
 	// This currently limits diff and commit search to a set number of
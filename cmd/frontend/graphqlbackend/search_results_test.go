@@ -6,15 +6,18 @@ import (
 	"math/rand"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/zoekt"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -1455,3 +1458,96 @@ func TestEvaluateAnd(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluatePatternExpression_Searcher(t *testing.T) {
+	db.Mocks.Repos.List = func(_ context.Context, op db.ReposListOptions) ([]*types.Repo, error) {
+		return []*types.Repo{{ID: 1, Name: "repo"}}, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "repo", 1)
+	db.Mocks.Repos.MockGet(t, 1)
+	db.Mocks.Repos.Count = mockCount
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	var patternInfos []*search.TextPatternInfo
+	mockSearchFilesInRepos = func(args *search.TextParameters) ([]*FileMatchResolver, *searchResultsCommon, error) {
+		patternInfos = append(patternInfos, args.PatternInfo)
+		return nil, &searchResultsCommon{}, nil
+	}
+	defer func() { mockSearchFilesInRepos = nil }()
+
+	q, err := query.ProcessAndOr("repo:^repo$ index:no type:file foo.bar and (baz or qux)", query.ParserOptions{SearchType: query.SearchTypeLiteral})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := &searchResolver{query: q, patternType: query.SearchTypeLiteral, userSettings: &schema.Settings{}}
+	if _, err := resolver.Results(context.Background()); err != nil {
+		t.Fatal("Results:", err)
+	}
+
+	if len(patternInfos) != 1 {
+		t.Fatalf("want a single search of file contents, got %d", len(patternInfos))
+	}
+	if patternInfos[0].Pattern != "" {
+		t.Errorf("want no pattern, got %q", patternInfos[0].Pattern)
+	}
+	want := &protocol.PatternExpression{And: []*protocol.PatternExpression{
+		{Pattern: `foo\.bar`},
+		{Or: []*protocol.PatternExpression{{Pattern: "baz"}, {Pattern: "qux"}}},
+	}}
+	if diff := cmp.Diff(want, patternInfos[0].PatternExpression); diff != "" {
+		t.Errorf("pattern expression mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvaluatePatternExpression_SearcherUnindexed(t *testing.T) {
+	indexedRepo := &types.Repo{ID: 1, Name: "indexed"}
+	unindexedRepo := &types.Repo{ID: 2, Name: "unindexed"}
+	db.Mocks.Repos.List = func(_ context.Context, op db.ReposListOptions) ([]*types.Repo, error) {
+		return []*types.Repo{indexedRepo, unindexedRepo}, nil
+	}
+	db.Mocks.Repos.Count = mockCount
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	var (
+		mu              sync.Mutex
+		searcherCalls   = map[api.RepoName]int{}
+		searcherPattern []*protocol.PatternExpression
+	)
+	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) ([]*FileMatchResolver, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		searcherCalls[repo.Name]++
+		searcherPattern = append(searcherPattern, info.PatternExpression)
+		return nil, false, nil
+	}
+	defer func() { mockSearchFilesInRepo = nil }()
+
+	z := &searchbackend.Zoekt{
+		Client: &fakeSearcher{
+			repos: []*zoekt.RepoListEntry{{
+				Repository: zoekt.Repository{
+					Name:     string(indexedRepo.Name),
+					Branches: []zoekt.RepositoryBranch{{Name: "HEAD", Version: "deadbeef"}},
+				},
+			}},
+		},
+		DisableCache: true,
+	}
+
+	q, err := query.ProcessAndOr("foo and bar", query.ParserOptions{SearchType: query.SearchTypeLiteral})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolver := &searchResolver{query: q, patternType: query.SearchTypeLiteral, zoekt: z, userSettings: &schema.Settings{}}
+	if _, err := resolver.Results(context.Background()); err != nil {
+		t.Fatal("Results:", err)
+	}
+
+	if diff := cmp.Diff(map[api.RepoName]int{"unindexed": 1}, searcherCalls); diff != "" {
+		t.Errorf("searcher calls per repo mismatch (-want +got):\n%s", diff)
+	}
+	want := []*protocol.PatternExpression{{And: []*protocol.PatternExpression{{Pattern: "foo"}, {Pattern: "bar"}}}}
+	if diff := cmp.Diff(want, searcherPattern); diff != "" {
+		t.Errorf("pattern expression mismatch (-want +got):\n%s", diff)
+	}
+}
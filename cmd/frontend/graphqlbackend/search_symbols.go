@@ -104,12 +104,28 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 
 		unflattened       [][]*FileMatchResolver
 		flattenedSize     int
+		matchCount        int32
 		overLimitCanceled bool
 	)
 
+	// addMatches assumes the caller holds mu.
 	addMatches := func(matches []*FileMatchResolver) {
+		// 🚨 SECURITY: Drop symbols in paths that the current user can't read before they are
+		// counted, so that neither results nor progress reveal them.
+		matches, permsErr := filterFileMatchesBySubRepoPerms(ctx, matches)
+		if permsErr != nil {
+			if ctx.Err() == nil {
+				run.Error(errors.Wrap(permsErr, "filter symbols by sub-repository permissions"))
+				cancelAll()
+			}
+			return
+		}
+
 		if len(matches) > 0 {
 			common.resultCount += int32(len(matches))
+			for _, m := range matches {
+				matchCount += m.resultCount()
+			}
 			sort.Slice(matches, func(i, j int) bool {
 				a, b := matches[i].uri, matches[j].uri
 				return a > b
@@ -150,6 +166,7 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 			tr.LazyPrintf("cancel indexed symbol search due to error: %v", err)
 		}
 		addMatches(matches)
+		reportRepoProgress(ctx, common, matchCount)
 	})
 
 	for _, repoRevs := range searcherRepos {
@@ -182,6 +199,7 @@ func searchSymbols(ctx context.Context, args *search.TextParameters, limit int)
 			if repoSymbols != nil {
 				addMatches(repoSymbols)
 			}
+			reportRepoProgress(ctx, common, matchCount)
 		})
 	}
 	err = run.Wait()
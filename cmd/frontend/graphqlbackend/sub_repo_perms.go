@@ -0,0 +1,109 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// subRepoPerms returns the path-level permissions of the current user in the repository, or nil if
+// the user can read all paths of the repository.
+func subRepoPerms(ctx context.Context, repoID api.RepoID) (*authz.SubRepoPermissions, error) {
+	perms, err := db.SubRepoPerms(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	return perms[repoID], nil
+}
+
+// filterFileMatchesBySubRepoPerms removes the file matches in paths that the current user can't
+// read. The file matches are filtered in place and returned.
+func filterFileMatchesBySubRepoPerms(ctx context.Context, fms []*FileMatchResolver) ([]*FileMatchResolver, error) {
+	if len(fms) == 0 {
+		return fms, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(fms))
+	for _, fm := range fms {
+		repoIDs = append(repoIDs, fm.Repo.repo.ID)
+	}
+	perms, err := db.SubRepoPerms(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(perms) == 0 {
+		return fms, nil
+	}
+
+	filtered := fms[:0]
+	for _, fm := range fms {
+		if perms[fm.Repo.repo.ID].CanReadFile(fm.JPath) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, nil
+}
+
+// filterSymbolsBySubRepoPerms removes the symbols in paths of the repository that the current user
+// can't read. The symbols are filtered in place and returned.
+func filterSymbolsBySubRepoPerms(ctx context.Context, repoID api.RepoID, symbols []*symbolResolver) ([]*symbolResolver, error) {
+	if len(symbols) == 0 {
+		return symbols, nil
+	}
+
+	perms, err := subRepoPerms(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if perms == nil {
+		return symbols, nil
+	}
+
+	filtered := symbols[:0]
+	for _, s := range symbols {
+		if perms.CanReadFile(s.symbol.Path) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
+// filterTreeEntriesBySubRepoPerms removes the files and directories of the repository that the
+// current user can't read. The entries are filtered in place and returned.
+func filterTreeEntriesBySubRepoPerms(ctx context.Context, repoID api.RepoID, entries []os.FileInfo) ([]os.FileInfo, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	perms, err := subRepoPerms(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if perms == nil {
+		return entries, nil
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.IsDir() && perms.CanReadDir(e.Name()) || !e.IsDir() && perms.CanReadFile(e.Name()) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+// checkSubRepoPerms returns a not found error if the current user can't read the file or directory
+// of the repository, like git.Stat does for paths that don't exist.
+func checkSubRepoPerms(ctx context.Context, repoID api.RepoID, fi os.FileInfo) error {
+	entries, err := filterTreeEntriesBySubRepoPerms(ctx, repoID, []os.FileInfo{fi})
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return &os.PathError{Op: "ls-tree", Path: fi.Name(), Err: os.ErrNotExist}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func mockSubRepoPerms(t *testing.T, perms map[api.RepoID]*authz.SubRepoPermissions) {
+	db.MockSubRepoPerms = func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+		return perms, nil
+	}
+	t.Cleanup(func() { db.MockSubRepoPerms = nil })
+}
+
+func TestFilterFileMatchesBySubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t, map[api.RepoID]*authz.SubRepoPermissions{
+		1: {PathIncludes: []string{"src/**"}, PathExcludes: []string{"src/secret/**"}},
+	})
+
+	restricted := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "restricted"}}
+	unrestricted := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "unrestricted"}}
+	fms := []*FileMatchResolver{
+		{JPath: "src/main.go", Repo: restricted},
+		{JPath: "src/secret/token", Repo: restricted},
+		{JPath: "README.md", Repo: restricted},
+		{JPath: "src/secret/token", Repo: unrestricted},
+	}
+
+	got, err := filterFileMatchesBySubRepoPerms(context.Background(), fms)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, fm := range got {
+		paths = append(paths, string(fm.Repo.repo.Name)+"/"+fm.JPath)
+	}
+	want := []string{"restricted/src/main.go", "unrestricted/src/secret/token"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got %v, want %v", paths, want)
+	}
+}
+
+func TestFilterSymbolsBySubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t, map[api.RepoID]*authz.SubRepoPermissions{
+		1: {PathExcludes: []string{"internal/**"}},
+	})
+
+	symbols := []*symbolResolver{
+		{symbol: protocol.Symbol{Name: "main", Path: "cmd/main.go"}},
+		{symbol: protocol.Symbol{Name: "secret", Path: "internal/secret.go"}},
+	}
+
+	got, err := filterSymbolsBySubRepoPerms(context.Background(), 1, symbols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name() != "main" {
+		t.Errorf("got %d symbols, want only main", len(got))
+	}
+}
+
+func TestCheckSubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t, map[api.RepoID]*authz.SubRepoPermissions{
+		1: {PathIncludes: []string{"src/**"}},
+	})
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		fi       os.FileInfo
+		notExist bool
+	}{
+		{fi: CreateFileInfo("", true), notExist: false},
+		{fi: CreateFileInfo("src", true), notExist: false},
+		{fi: CreateFileInfo("src/main.go", false), notExist: false},
+		{fi: CreateFileInfo("docs", true), notExist: true},
+		{fi: CreateFileInfo("README.md", false), notExist: true},
+	} {
+		err := checkSubRepoPerms(ctx, 1, tc.fi)
+		if got := os.IsNotExist(err); got != tc.notExist {
+			t.Errorf("%q: got error %v, want not exist %v", tc.fi.Name(), err, tc.notExist)
+		}
+	}
+
+	if err := checkSubRepoPerms(ctx, 2, CreateFileInfo("README.md", false)); err != nil {
+		t.Errorf("unexpected error %v for unrestricted repository", err)
+	}
+}
@@ -29,6 +29,11 @@ func (r *GitTreeEntryResolver) Symbols(ctx context.Context, args *symbolsArgs) (
 	if err != nil && len(symbols) == 0 {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop symbols in paths that the current user can't read.
+	symbols, err = filterSymbolsBySubRepoPerms(ctx, r.commit.repoResolver.repo.ID, symbols)
+	if err != nil {
+		return nil, err
+	}
 	return &symbolConnectionResolver{symbols: symbols, first: args.First}, nil
 }
 
@@ -37,6 +42,11 @@ func (r *GitCommitResolver) Symbols(ctx context.Context, args *symbolsArgs) (*sy
 	if err != nil && len(symbols) == 0 {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop symbols in paths that the current user can't read.
+	symbols, err = filterSymbolsBySubRepoPerms(ctx, r.repoResolver.repo.ID, symbols)
+	if err != nil {
+		return nil, err
+	}
 	return &symbolConnectionResolver{symbols: symbols, first: args.First}, nil
 }
 
@@ -346,7 +346,9 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 	tr.LazyPrintf("%d indexed repos, %d unindexed repos", len(indexed.Repos()), len(indexed.Unindexed))
 
 	var searcherRepos []*search.RepositoryRevisions
-	if indexed.DisableUnindexedSearch {
+	if args.SkipUnindexed {
+		tr.LazyPrintf("skipping unindexed search")
+	} else if indexed.DisableUnindexedSearch {
 		tr.LazyPrintf("disabling unindexed search")
 		common.missing = make([]*types.Repo, len(indexed.Unindexed))
 		for i, r := range indexed.Unindexed {
@@ -369,13 +371,28 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		searchErr         error
 		unflattened       [][]*FileMatchResolver
 		flattenedSize     int
+		matchCount        int32
 		overLimitCanceled bool // canceled because we were over the limit
 	)
 
 	// addMatches assumes the caller holds mu.
 	addMatches := func(matches []*FileMatchResolver) {
+		// 🚨 SECURITY: Drop matches in paths that the current user can't read before they are
+		// counted, so that neither results nor progress reveal them.
+		matches, err := filterFileMatchesBySubRepoPerms(ctx, matches)
+		if err != nil {
+			if searchErr == nil && ctx.Err() == nil {
+				searchErr = errors.Wrap(err, "filter matches by sub-repository permissions")
+				cancel()
+			}
+			return
+		}
+
 		if len(matches) > 0 {
 			common.resultCount += int32(len(matches))
+			for _, m := range matches {
+				matchCount += m.resultCount()
+			}
 			sort.Slice(matches, func(i, j int) bool {
 				a, b := matches[i].uri, matches[j].uri
 				return a > b
@@ -499,6 +516,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 						}
 					}
 					addMatches(matches)
+					reportRepoProgress(ctx, common, matchCount)
 				}(limitCtx, limitDone) // ends the Go routine for a call to searcher for a repo
 			} // ends the for loop iterating over repo's revs
 		} // ends the for loop iterating over repos
@@ -578,6 +596,7 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 			} else {
 				addMatches(matches)
 			}
+			reportRepoProgress(ctx, common, matchCount)
 		}()
 	}
 
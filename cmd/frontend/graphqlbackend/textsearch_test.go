@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -32,13 +33,15 @@ func TestSearchFilesInRepos(t *testing.T) {
 		case "foo/one":
 			return []*FileMatchResolver{
 				{
-					uri: "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					uri:  "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					Repo: &RepositoryResolver{repo: repo},
 				},
 			}, false, nil
 		case "foo/two":
 			return []*FileMatchResolver{
 				{
-					uri: "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					uri:  "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					Repo: &RepositoryResolver{repo: repo},
 				},
 			}, false, nil
 		case "foo/empty":
@@ -112,6 +115,52 @@ func TestSearchFilesInRepos(t *testing.T) {
 	}
 }
 
+func TestSearchFilesInRepos_subRepoPerms(t *testing.T) {
+	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
+		return []*FileMatchResolver{mkFileMatch(repo, "main.go"), mkFileMatch(repo, "secret/key.go")}, false, nil
+	}
+	defer func() { mockSearchFilesInRepo = nil }()
+	mockSubRepoPerms(t, map[api.RepoID]*authz.SubRepoPermissions{
+		1: {PathExcludes: []string{"secret/**"}},
+	})
+
+	q, err := query.ParseAndCheck("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := &search.TextParameters{
+		PatternInfo: &search.TextPatternInfo{
+			FileMatchLimit: defaultMaxSearchResults,
+			Pattern:        "foo",
+		},
+		RepoPromise: (&search.Promise{}).Resolve([]*search.RepositoryRevisions{
+			{Repo: &types.Repo{ID: 1, Name: "foo/restricted"}, Revs: []search.RevisionSpecifier{{RevSpec: ""}}},
+			{Repo: &types.Repo{ID: 2, Name: "foo/unrestricted"}, Revs: []search.RevisionSpecifier{{RevSpec: ""}}},
+		}),
+		Query:        q,
+		Zoekt:        &searchbackend.Zoekt{Client: &fakeSearcher{}},
+		SearcherURLs: endpoint.Static("test"),
+	}
+	results, common, err := searchFilesInRepos(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, r := range results {
+		paths = append(paths, string(r.Repo.repo.Name)+"/"+r.JPath)
+	}
+	sort.Strings(paths)
+	want := []string{"foo/restricted/main.go", "foo/unrestricted/main.go", "foo/unrestricted/secret/key.go"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("got results %v, want %v", paths, want)
+	}
+	// Matches in paths that can't be read must not be counted either.
+	if common.resultCount != int32(len(want)) {
+		t.Errorf("got result count %d, want %d", common.resultCount, len(want))
+	}
+}
+
 func TestSearchFilesInRepos_multipleRevsPerRepo(t *testing.T) {
 	mockSearchFilesInRepo = func(ctx context.Context, repo *types.Repo, gitserverRepo gitserver.Repo, rev string, info *search.TextPatternInfo, fetchTimeout time.Duration) (matches []*FileMatchResolver, limitHit bool, err error) {
 		repoName := repo.Name
@@ -119,7 +168,8 @@ func TestSearchFilesInRepos_multipleRevsPerRepo(t *testing.T) {
 		case "foo":
 			return []*FileMatchResolver{
 				{
-					uri: "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					uri:  "git://" + string(repoName) + "?" + rev + "#" + "main.go",
+					Repo: &RepositoryResolver{repo: repo},
 				},
 			}, false, nil
 		default:
@@ -20,6 +20,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/vfsutil"
@@ -99,6 +100,13 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		return nil
 	}
 
+	// 🚨 SECURITY: Paths that the current user can't read must look like they don't exist.
+	subRepoPerms, err := db.SubRepoPerms(r.Context(), common.Repo.ID)
+	if err != nil {
+		return err
+	}
+	perms := subRepoPerms[common.Repo.ID]
+
 	const (
 		textPlain       = "text/plain"
 		applicationZip  = "application/zip"
@@ -156,6 +164,13 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		w.Header().Set("Content-Type", contentType)
 		w.Header().Set("Content-Disposition", mime.FormatMediaType("Attachment", map[string]string{"filename": downloadName}))
 
+		// Archives are built by git, which has no notion of path-level permissions.
+		if perms != nil {
+			requestType = "403"
+			http.Error(w, "archives are not available for repositories with path-level permissions", http.StatusForbidden)
+			return nil // request handled
+		}
+
 		format := vfsutil.ArchiveFormatZip
 		if contentType == applicationXTar {
 			format = vfsutil.ArchiveFormatTar
@@ -237,6 +252,9 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		}
 
 		fi, err := git.Stat(r.Context(), *cachedRepo, common.CommitID, requestedPath)
+		if err == nil && (fi.IsDir() && !perms.CanReadDir(requestedPath) || !fi.IsDir() && !perms.CanReadFile(requestedPath)) {
+			err = &os.PathError{Op: "ls-tree", Path: requestedPath, Err: os.ErrNotExist}
+		}
 		if err != nil {
 			if os.IsNotExist(err) {
 				requestType = "404"
@@ -255,6 +273,9 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 			size = int64(len(infos))
 			var names []string
 			for _, info := range infos {
+				if info.IsDir() && !perms.CanReadDir(info.Name()) || !info.IsDir() && !perms.CanReadFile(info.Name()) {
+					continue
+				}
 				// A previous version of this code returned relative paths so we trim the paths
 				// here too so as not to break backwards compatibility
 				name := path.Base(info.Name())
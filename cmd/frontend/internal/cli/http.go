@@ -29,7 +29,7 @@ import (
 
 // newExternalHTTPHandler creates and returns the HTTP handler that serves the app and API pages to
 // external clients.
-func newExternalHTTPHandler(schema *graphql.Schema, gitHubWebhook, gitLabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, newCodeIntelInternalProxyHandler enterprise.NewCodeIntelInternalProxyHandler) (http.Handler, error) {
+func newExternalHTTPHandler(schema *graphql.Schema, gitHubWebhook, gitLabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, newCodeIntelInternalProxyHandler enterprise.NewCodeIntelInternalProxyHandler, newCampaignsInternalProxyHandler enterprise.NewCampaignsInternalProxyHandler) (http.Handler, error) {
 	// Each auth middleware determines on a per-request basis whether it should be enabled (if not, it
 	// immediately delegates the request to the next middleware in the chain).
 	authMiddlewares := auth.AuthMiddleware()
@@ -51,6 +51,9 @@ func newExternalHTTPHandler(schema *graphql.Schema, gitHubWebhook, gitLabWebhook
 	// 🚨 SECURITY: This handler implements its own token auth inside enterprise
 	internalCodeIntelHandler := newCodeIntelInternalProxyHandler()
 
+	// 🚨 SECURITY: This handler implements its own token auth inside enterprise
+	internalCampaignsHandler := newCampaignsInternalProxyHandler()
+
 	// App handler (HTML pages), the call order of middleware is LIFO.
 	appHandler := app.NewHandler()
 	if hooks.PostAuthMiddleware != nil {
@@ -68,6 +71,7 @@ func newExternalHTTPHandler(schema *graphql.Schema, gitHubWebhook, gitLabWebhook
 	sm := http.NewServeMux()
 	sm.Handle("/.api/", apiHandler)
 	sm.Handle("/.internal-code-intel/", internalCodeIntelHandler)
+	sm.Handle("/.internal-campaigns/", internalCampaignsHandler)
 	sm.Handle("/", appHandler)
 	assetsutil.Mount(sm)
 
@@ -2,6 +2,7 @@ package httpapi
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -29,7 +30,11 @@ func AccessTokenAuthMiddleware(next http.Handler) http.Handler {
 			}
 		}
 
-		if headerValue := r.Header.Get("Authorization"); headerValue != "" && token == "" {
+		// 🚨 SECURITY: SCIM clients send the SCIM token, not an access token, in the
+		// Authorization header, which must not be logged below.
+		isSCIMRequest := strings.HasPrefix(r.URL.Path, scimPathPrefix+"/")
+
+		if headerValue := r.Header.Get("Authorization"); headerValue != "" && token == "" && !isSCIMRequest {
 			// Handle Authorization header
 			var err error
 			token, sudoUser, err = authz.ParseAuthorizationHeader(headerValue)
@@ -62,6 +62,7 @@ func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook, gitlabWebh
 	m.Get(apirouter.GraphQL).Handler(trace.TraceRoute(handler(serveGraphQL(schema))))
 
 	m.Get(apirouter.SearchStream).Handler(trace.TraceRoute(http.HandlerFunc(frontendsearch.ServeStream)))
+	m.Get(apirouter.SearchStreamCancel).Handler(trace.TraceRoute(http.HandlerFunc(frontendsearch.ServeStreamCancel)))
 
 	// Return the minimum src-cli version that's compatible with this instance
 	m.Get(apirouter.SrcCliVersion).Handler(trace.TraceRoute(handler(srcCliVersionServe)))
@@ -69,6 +70,13 @@ func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook, gitlabWebh
 
 	m.Get(apirouter.Registry).Handler(trace.TraceRoute(handler(registry.HandleRegistry)))
 
+	// 🚨 SECURITY: The SCIM handlers authenticate the SCIM client themselves.
+	m.Get(apirouter.SCIMServiceProviderConfig).Handler(trace.TraceRoute(scimHandler(serveSCIMServiceProviderConfig)))
+	m.Get(apirouter.SCIMUsers).Handler(trace.TraceRoute(scimHandler(serveSCIMUsers)))
+	m.Get(apirouter.SCIMUser).Handler(trace.TraceRoute(scimHandler(serveSCIMUser)))
+	m.Get(apirouter.SCIMGroups).Handler(trace.TraceRoute(scimHandler(serveSCIMGroups)))
+	m.Get(apirouter.SCIMGroup).Handler(trace.TraceRoute(scimHandler(serveSCIMGroup)))
+
 	m.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("API no route: %s %s from %s", r.Method, r.URL, r.Referer())
 		http.Error(w, "no route", http.StatusNotFound)
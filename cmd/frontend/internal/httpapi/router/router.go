@@ -10,7 +10,8 @@ const (
 	LSIFUpload = "lsif.upload"
 	GraphQL    = "graphql"
 
-	SearchStream = "search.stream"
+	SearchStream       = "search.stream"
+	SearchStreamCancel = "search.stream.cancel"
 
 	SrcCliVersion  = "src-cli.version"
 	SrcCliDownload = "src-cli.download"
@@ -21,6 +22,12 @@ const (
 	RepoRefresh = "repo.refresh"
 	Telemetry   = "telemetry"
 
+	SCIMServiceProviderConfig = "scim.service-provider-config"
+	SCIMUsers                 = "scim.users"
+	SCIMUser                  = "scim.user"
+	SCIMGroups                = "scim.groups"
+	SCIMGroup                 = "scim.group"
+
 	GitHubWebhooks          = "github.webhooks"
 	GitLabWebhooks          = "gitlab.webhooks"
 	BitbucketServerWebhooks = "bitbucketServer.webhooks"
@@ -72,8 +79,14 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/stream/cancel").Methods("POST").Name(SearchStreamCancel)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
+	base.Path("/scim/v2/ServiceProviderConfig").Methods("GET").Name(SCIMServiceProviderConfig)
+	base.Path("/scim/v2/Users").Methods("GET", "POST").Name(SCIMUsers)
+	base.Path("/scim/v2/Users/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMUser)
+	base.Path("/scim/v2/Groups").Methods("GET", "POST").Name(SCIMGroups)
+	base.Path("/scim/v2/Groups/{id}").Methods("GET", "PUT", "PATCH", "DELETE").Name(SCIMGroup)
 
 	// repo contains routes that are NOT specific to a revision. In these routes, the URL may not contain a revspec after the repo (that is, no "github.com/foo/bar@myrevspec").
 	repoPath := `/repos/` + routevar.Repo
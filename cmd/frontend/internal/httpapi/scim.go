@@ -0,0 +1,286 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// This file implements the parts of SCIM 2.0 (https://tools.ietf.org/html/rfc7644) shared by the
+// /Users endpoint (see scim_users.go) and the /Groups endpoint (see scim_groups.go). SCIM lets
+// identity providers provision Sourcegraph users and map their groups onto organizations.
+
+const (
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// scimPathPrefix is the path of the SCIM endpoint.
+const scimPathPrefix = "/.api/scim/v2"
+
+const (
+	scimDefaultCount = 100
+	scimMaxCount     = 1000
+)
+
+// scimError is an error that is reported to the SCIM client in the format described in
+// https://tools.ietf.org/html/rfc7644#section-3.12.
+type scimError struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *scimError) Error() string { return e.Detail }
+
+func scimBadRequest(scimType, format string, args ...interface{}) error {
+	return &scimError{Status: http.StatusBadRequest, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func scimNotFound(resourceType string, id string) error {
+	return &scimError{Status: http.StatusNotFound, Detail: fmt.Sprintf("%s %q not found", resourceType, id)}
+}
+
+func scimConflict(format string, args ...interface{}) error {
+	return &scimError{Status: http.StatusConflict, ScimType: "uniqueness", Detail: fmt.Sprintf(format, args...)}
+}
+
+// scimHandler returns a handler that authenticates the SCIM client and then calls h, reporting
+// the errors it returns as SCIM errors.
+//
+// 🚨 SECURITY: SCIM clients are authenticated with the token in the auth.scimToken site
+// configuration property, not with access tokens or sessions.
+func scimHandler(h func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := conf.Get().AuthScimToken
+		if token == "" {
+			writeSCIMError(w, r, &scimError{Status: http.StatusNotFound, Detail: "SCIM provisioning is not enabled"})
+			return
+		}
+
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			writeSCIMError(w, r, &scimError{Status: http.StatusUnauthorized, Detail: "invalid SCIM token"})
+			return
+		}
+
+		// The SCIM client acts on behalf of the site, not of any user.
+		r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{Internal: true}))
+
+		if err := h(w, r); err != nil {
+			writeSCIMError(w, r, err)
+		}
+	})
+}
+
+func writeSCIM(w http.ResponseWriter, status int, v interface{}) error {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeSCIMError(w http.ResponseWriter, r *http.Request, err error) {
+	trace.SetRequestErrorCause(r.Context(), err)
+
+	e, ok := err.(*scimError)
+	if !ok {
+		log15.Error("SCIM HTTP handler error response", "method", r.Method, "request_uri", r.URL.RequestURI(), "error", err)
+		e = &scimError{Status: http.StatusInternalServerError, Detail: "internal error"}
+	}
+
+	_ = writeSCIM(w, e.Status, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+	})
+}
+
+func readSCIM(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return scimBadRequest("invalidSyntax", "invalid request body: %s", err)
+	}
+	return nil
+}
+
+// scimMeta is the metadata of a SCIM resource.
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+func newSCIMMeta(resourceType string, id int32, created, lastModified time.Time) *scimMeta {
+	return &scimMeta{
+		ResourceType: resourceType,
+		Created:      created,
+		LastModified: lastModified,
+		Location:     scimLocation(resourceType, id),
+	}
+}
+
+// scimLocation returns the URL of the resource with the given type and ID.
+func scimLocation(resourceType string, id int32) string {
+	return fmt.Sprintf("%s%s/%ss/%d", strings.TrimSuffix(globals.ExternalURL().String(), "/"), scimPathPrefix, resourceType, id)
+}
+
+// scimListResponse is the response of a query for resources, see
+// https://tools.ietf.org/html/rfc7644#section-3.4.2.
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimPage is the page of resources requested by a query.
+type scimPage struct {
+	// StartIndex is the 1-based index of the first requested resource.
+	StartIndex int
+	Count      int
+}
+
+func parseSCIMPage(r *http.Request) (scimPage, error) {
+	p := scimPage{StartIndex: 1, Count: scimDefaultCount}
+	if v := r.URL.Query().Get("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, scimBadRequest("invalidValue", "invalid startIndex %q", v)
+		}
+		// Values less than 1 are interpreted as 1.
+		if n > 1 {
+			p.StartIndex = n
+		}
+	}
+	if v := r.URL.Query().Get("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, scimBadRequest("invalidValue", "invalid count %q", v)
+		}
+		switch {
+		case n < 0:
+			p.Count = 0
+		case n > scimMaxCount:
+			p.Count = scimMaxCount
+		default:
+			p.Count = n
+		}
+	}
+	return p, nil
+}
+
+// scimFilter is a filter of the form `attribute eq "value"`, which is the only kind of filter
+// identity providers use to look up resources before provisioning them.
+type scimFilter struct {
+	Attribute string
+	Value     string
+}
+
+var scimFilterPattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9.$_-]*)\s+(?i:eq)\s+"((?:[^"\\]|\\.)*)"\s*$`)
+
+// parseSCIMFilter parses the given filter. The attributes it accepts are given in lowercase.
+func parseSCIMFilter(filter string, attributes ...string) (*scimFilter, error) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	m := scimFilterPattern.FindStringSubmatch(filter)
+	if m == nil {
+		return nil, scimBadRequest("invalidFilter", `unsupported filter %q (only filters of the form 'attribute eq "value"' are supported)`, filter)
+	}
+
+	var value string
+	if err := json.Unmarshal([]byte(`"`+m[2]+`"`), &value); err != nil {
+		return nil, scimBadRequest("invalidFilter", "invalid value in filter %q", filter)
+	}
+
+	attribute := strings.ToLower(m[1])
+	for _, a := range attributes {
+		if attribute == a {
+			return &scimFilter{Attribute: attribute, Value: value}, nil
+		}
+	}
+	return nil, scimBadRequest("invalidFilter", "unsupported filter attribute %q", m[1])
+}
+
+// scimPatchOp is an operation of a PATCH request, see
+// https://tools.ietf.org/html/rfc7644#section-3.5.2.
+type scimPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+func readSCIMPatch(r *http.Request) ([]scimPatchOp, error) {
+	var req struct {
+		Schemas    []string      `json:"schemas"`
+		Operations []scimPatchOp `json:"Operations"`
+	}
+	if err := readSCIM(r, &req); err != nil {
+		return nil, err
+	}
+	for i := range req.Operations {
+		// Some identity providers capitalize the operation.
+		req.Operations[i].Op = strings.ToLower(req.Operations[i].Op)
+		switch req.Operations[i].Op {
+		case "add", "replace", "remove":
+		default:
+			return nil, scimBadRequest("invalidSyntax", "unsupported PATCH operation %q", req.Operations[i].Op)
+		}
+	}
+	return req.Operations, nil
+}
+
+// scimResourceID returns the ID of the resource in the URL of the request.
+func scimResourceID(r *http.Request, resourceType string) (int32, error) {
+	v := mux.Vars(r)["id"]
+	id, err := strconv.ParseInt(v, 10, 32)
+	if err != nil {
+		return 0, scimNotFound(resourceType, v)
+	}
+	return int32(id), nil
+}
+
+func serveSCIMServiceProviderConfig(w http.ResponseWriter, r *http.Request) error {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	return writeSCIM(w, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimSchemaServiceProviderConfig},
+		"patch":          supported{true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxCount},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Authentication with the token in the auth.scimToken site configuration property",
+			"primary":     true,
+		}},
+	})
+}
@@ -0,0 +1,410 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// scimGroup is the SCIM representation of a Sourcegraph organization, see
+// https://tools.ietf.org/html/rfc7643#section-4.2.
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// scimMember is a member of a group. Its value is the ID of the user.
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// serveSCIMGroups serves the /Groups endpoint, which lists and creates organizations.
+func serveSCIMGroups(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodGet:
+		return listSCIMGroups(w, r)
+	case http.MethodPost:
+		return createSCIMGroup(w, r)
+	default:
+		return &scimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"}
+	}
+}
+
+// serveSCIMGroup serves the /Groups/{id} endpoint, which gets, updates and deletes an
+// organization. Organizations that weren't created via SCIM are not groups.
+func serveSCIMGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id, err := scimResourceID(r, "Group")
+	if err != nil {
+		return err
+	}
+	org, err := db.Orgs.GetByID(ctx, id)
+	if errcode.IsNotFound(err) || (err == nil && !org.SCIMProvisioned) {
+		return scimNotFound("Group", strconv.Itoa(int(id)))
+	} else if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		g, err := toSCIMGroup(ctx, org)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, g)
+
+	case http.MethodPut:
+		var next scimGroup
+		if err := readSCIM(r, &next); err != nil {
+			return err
+		}
+		g, err := updateSCIMGroup(ctx, org, &next)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, g)
+
+	case http.MethodPatch:
+		ops, err := readSCIMPatch(r)
+		if err != nil {
+			return err
+		}
+		next, err := toSCIMGroup(ctx, org)
+		if err != nil {
+			return err
+		}
+		if err := applySCIMGroupPatch(next, ops); err != nil {
+			return err
+		}
+		g, err := updateSCIMGroup(ctx, org, next)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, g)
+
+	case http.MethodDelete:
+		if err := db.Orgs.Delete(ctx, org.ID); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+
+	default:
+		return &scimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"}
+	}
+}
+
+func listSCIMGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	page, err := parseSCIMPage(r)
+	if err != nil {
+		return err
+	}
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"), "displayname")
+	if err != nil {
+		return err
+	}
+
+	var (
+		orgs  []*types.Org
+		total int
+	)
+	if filter != nil {
+		org, err := findSCIMGroup(ctx, filter.Value)
+		if err != nil {
+			return err
+		}
+		if org != nil {
+			total = 1
+			if page.StartIndex == 1 && page.Count > 0 {
+				orgs = append(orgs, org)
+			}
+		}
+	} else {
+		total, err = db.Orgs.Count(ctx, db.OrgsListOptions{OnlySCIMProvisioned: true})
+		if err != nil {
+			return err
+		}
+		if page.Count > 0 {
+			orgs, err = db.Orgs.List(ctx, &db.OrgsListOptions{
+				OnlySCIMProvisioned: true,
+				LimitOffset:         &db.LimitOffset{Limit: page.Count, Offset: page.StartIndex - 1},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	resources := make([]*scimGroup, 0, len(orgs))
+	for _, org := range orgs {
+		g, err := toSCIMGroup(ctx, org)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, g)
+	}
+
+	return writeSCIM(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   page.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// findSCIMGroup returns the organization of the group with the given display name, or nil if
+// there is none or the organization wasn't created via SCIM.
+func findSCIMGroup(ctx context.Context, displayName string) (*types.Org, error) {
+	name, err := scimOrgName(displayName)
+	if err != nil {
+		return nil, nil
+	}
+	org, err := db.Orgs.GetByName(ctx, name)
+	if errcode.IsNotFound(err) || (err == nil && !org.SCIMProvisioned) {
+		return nil, nil
+	}
+	return org, err
+}
+
+// scimOrgName returns the name of the organization of the group with the given display name.
+// Organization names follow the same rules as usernames.
+func scimOrgName(displayName string) (string, error) {
+	return auth.NormalizeUsername(strings.Join(strings.Fields(displayName), "-"))
+}
+
+func createSCIMGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var in scimGroup
+	if err := readSCIM(r, &in); err != nil {
+		return err
+	}
+	if in.DisplayName == "" {
+		return scimBadRequest("invalidValue", "displayName is required")
+	}
+	name, err := scimOrgName(in.DisplayName)
+	if err != nil {
+		return scimBadRequest("invalidValue", "invalid displayName %q: %s", in.DisplayName, err)
+	}
+	memberIDs, err := scimMemberIDs(ctx, in.Members)
+	if err != nil {
+		return err
+	}
+
+	displayName := in.DisplayName
+	org, err := db.Orgs.CreateSCIMProvisioned(ctx, name, &displayName)
+	if err != nil {
+		if existing, _ := db.Orgs.GetByName(ctx, name); existing != nil {
+			return scimConflict("an organization named %q already exists", name)
+		}
+		return err
+	}
+	for _, userID := range memberIDs {
+		if _, err := db.OrgMembers.Create(ctx, org.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	g, err := toSCIMGroup(ctx, org)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", g.Meta.Location)
+	return writeSCIM(w, http.StatusCreated, g)
+}
+
+// updateSCIMGroup updates the given organization to match next, which is the complete new SCIM
+// representation of the group.
+func updateSCIMGroup(ctx context.Context, org *types.Org, next *scimGroup) (*scimGroup, error) {
+	// The name of an organization can't be changed, so only its display name follows the
+	// group's.
+	if next.DisplayName != "" && (org.DisplayName == nil || *org.DisplayName != next.DisplayName) {
+		displayName := next.DisplayName
+		var err error
+		if org, err = db.Orgs.Update(ctx, org.ID, &displayName); err != nil {
+			return nil, err
+		}
+	}
+
+	want, err := scimMemberIDs(ctx, next.Members)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := db.OrgMembers.GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	have := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		have[m.UserID] = true
+	}
+	for _, userID := range want {
+		if !have[userID] {
+			if _, err := db.OrgMembers.Create(ctx, org.ID, userID); err != nil {
+				return nil, err
+			}
+		}
+		delete(have, userID)
+	}
+	for userID := range have {
+		if err := db.OrgMembers.Remove(ctx, org.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return toSCIMGroup(ctx, org)
+}
+
+// scimMemberIDs returns the IDs of the users that are the given members. It returns an error if
+// any of them doesn't exist. Deactivated users can be members, but aren't listed until they are
+// reactivated.
+func scimMemberIDs(ctx context.Context, members []scimMember) ([]int32, error) {
+	ids := make([]int32, 0, len(members))
+	seen := make(map[int32]bool, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m.Value, 10, 32)
+		if err != nil {
+			return nil, scimBadRequest("invalidValue", "invalid member %q", m.Value)
+		}
+		if seen[int32(id)] {
+			continue
+		}
+		if _, err := db.Users.GetByIDIncludingDeactivated(ctx, int32(id)); errcode.IsNotFound(err) {
+			return nil, scimBadRequest("invalidValue", "member %q does not exist", m.Value)
+		} else if err != nil {
+			return nil, err
+		}
+		seen[int32(id)] = true
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+func toSCIMGroup(ctx context.Context, org *types.Org) (*scimGroup, error) {
+	memberships, err := db.OrgMembers.GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: org.Name,
+		Members:     make([]scimMember, 0, len(memberships)),
+		Meta:        newSCIMMeta("Group", org.ID, org.CreatedAt, org.UpdatedAt),
+	}
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		g.DisplayName = *org.DisplayName
+	}
+	for _, m := range memberships {
+		g.Members = append(g.Members, scimMember{
+			Value: strconv.Itoa(int(m.UserID)),
+			Ref:   scimLocation("User", m.UserID),
+		})
+	}
+	return g, nil
+}
+
+// scimMemberPathPattern matches paths like `members[value eq "42"]`.
+var scimMemberPathPattern = regexp.MustCompile(`^members\[value eq "([^"]*)"\]$`)
+
+// applySCIMGroupPatch applies the given PATCH operations to g.
+func applySCIMGroupPatch(g *scimGroup, ops []scimPatchOp) error {
+	for _, op := range ops {
+		path := strings.ToLower(op.Path)
+
+		if path == "" {
+			// Without a path, the value contains the attributes to add or replace.
+			if op.Op == "remove" {
+				return scimBadRequest("noTarget", "remove operations require a path")
+			}
+			var attrs struct {
+				DisplayName *string      `json:"displayName"`
+				Members     []scimMember `json:"members"`
+			}
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return scimBadRequest("invalidValue", "invalid value of %s operation: %s", op.Op, err)
+			}
+			if attrs.DisplayName != nil {
+				g.DisplayName = *attrs.DisplayName
+			}
+			if attrs.Members != nil {
+				g.Members = patchSCIMMembers(g.Members, op.Op, attrs.Members)
+			}
+			continue
+		}
+
+		switch {
+		case path == "displayname":
+			if op.Op != "remove" {
+				if err := json.Unmarshal(op.Value, &g.DisplayName); err != nil {
+					return scimBadRequest("invalidValue", "invalid value of %q: %s", op.Path, err)
+				}
+			}
+
+		case path == "members":
+			var members []scimMember
+			if len(op.Value) > 0 {
+				if err := json.Unmarshal(op.Value, &members); err != nil {
+					return scimBadRequest("invalidValue", "invalid value of %q: %s", op.Path, err)
+				}
+			}
+			if op.Op == "remove" && len(members) == 0 {
+				// Removing the members without a value removes all of them.
+				g.Members = nil
+			} else {
+				g.Members = patchSCIMMembers(g.Members, op.Op, members)
+			}
+
+		case scimMemberPathPattern.MatchString(path):
+			if op.Op != "remove" {
+				return scimBadRequest("invalidPath", "unsupported %s operation on %q", op.Op, op.Path)
+			}
+			value := scimMemberPathPattern.FindStringSubmatch(path)[1]
+			g.Members = patchSCIMMembers(g.Members, op.Op, []scimMember{{Value: value}})
+
+		default:
+			return scimBadRequest("invalidPath", "unsupported path %q", op.Path)
+		}
+	}
+	return nil
+}
+
+func patchSCIMMembers(members []scimMember, op string, values []scimMember) []scimMember {
+	switch op {
+	case "replace":
+		return values
+	case "add":
+		return append(members, values...)
+	}
+
+	remove := make(map[string]bool, len(values))
+	for _, v := range values {
+		remove[v.Value] = true
+	}
+	kept := members[:0:0]
+	for _, m := range members {
+		if !remove[m.Value] {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,418 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseSCIMFilter(t *testing.T) {
+	tests := []struct {
+		filter  string
+		want    *scimFilter
+		wantErr bool
+	}{
+		{filter: "", want: nil},
+		{filter: `userName eq "alice@example.com"`, want: &scimFilter{Attribute: "username", Value: "alice@example.com"}},
+		{filter: `  externalId EQ "00u1\"x"  `, want: &scimFilter{Attribute: "externalid", Value: `00u1"x`}},
+		{filter: `userName ne "alice"`, wantErr: true},
+		{filter: `userName eq "alice" and active eq true`, wantErr: true},
+		{filter: `title eq "engineer"`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			got, err := parseSCIMFilter(test.filter, "username", "externalid")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("filter mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplySCIMUserPatch(t *testing.T) {
+	yes := true
+	u := &scimUser{
+		UserName:    "alice",
+		DisplayName: "Alice",
+		Emails:      []scimEmail{{Value: "alice@example.com", Primary: true}},
+		Active:      &yes,
+	}
+
+	var ops []scimPatchOp
+	if err := json.Unmarshal([]byte(`[
+		{"op": "replace", "path": "displayName", "value": "Alice Smith"},
+		{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@work.example.com"},
+		{"op": "add", "path": "title", "value": "Engineer"},
+		{"op": "replace", "value": {"active": "False", "externalId": "00u1"}}
+	]`), &ops); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applySCIMUserPatch(u, ops); err != nil {
+		t.Fatal(err)
+	}
+
+	no := false
+	want := &scimUser{
+		UserName:    "alice",
+		DisplayName: "Alice Smith",
+		ExternalID:  "00u1",
+		Emails: []scimEmail{
+			{Value: "alice@work.example.com", Type: "work", Primary: true},
+			{Value: "alice@example.com"},
+		},
+		Active: &no,
+	}
+	if diff := cmp.Diff(want, u); diff != "" {
+		t.Errorf("user mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := u.primaryEmail(), "alice@work.example.com"; got != want {
+		t.Errorf("got primary email %q, want %q", got, want)
+	}
+}
+
+func TestApplySCIMGroupPatch(t *testing.T) {
+	g := &scimGroup{
+		DisplayName: "Engineering",
+		Members:     []scimMember{{Value: "1"}, {Value: "2"}},
+	}
+
+	var ops []scimPatchOp
+	if err := json.Unmarshal([]byte(`[
+		{"op": "add", "path": "members", "value": [{"value": "3"}]},
+		{"op": "remove", "path": "members[value eq \"1\"]"},
+		{"op": "remove", "path": "members", "value": [{"value": "2"}]},
+		{"op": "replace", "value": {"displayName": "Engineering Team"}}
+	]`), &ops); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applySCIMGroupPatch(g, ops); err != nil {
+		t.Fatal(err)
+	}
+
+	want := &scimGroup{
+		DisplayName: "Engineering Team",
+		Members:     []scimMember{{Value: "3"}},
+	}
+	if diff := cmp.Diff(want, g); diff != "" {
+		t.Errorf("group mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSCIM(t *testing.T) {
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	const token = "a-scim-token-that-is-long-enough-to-be-valid"
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthScimToken: token}})
+	defer conf.Mock(nil)
+
+	c := newTest()
+
+	do := func(t *testing.T, method, path string, body interface{}, wantStatus int, out interface{}) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		if body != nil {
+			if err := json.NewEncoder(&buf).Encode(body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		req, err := http.NewRequest(method, path, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/scim+json")
+
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != wantStatus {
+			var b bytes.Buffer
+			_, _ = b.ReadFrom(resp.Body)
+			t.Fatalf("%s %s: got status %d, want %d: %s", method, path, resp.StatusCode, wantStatus, b.String())
+		}
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/scim/v2/Users", nil)
+		req.Header.Set("Authorization", "Bearer wrong")
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := http.StatusUnauthorized; resp.StatusCode != want {
+			t.Errorf("got status %d, want %d", resp.StatusCode, want)
+		}
+	})
+
+	var alice scimUser
+	t.Run("create user", func(t *testing.T) {
+		do(t, "POST", "/scim/v2/Users", map[string]interface{}{
+			"schemas":    []string{scimSchemaUser},
+			"userName":   "alice@example.com",
+			"externalId": "00u1",
+			"name":       map[string]string{"givenName": "Alice", "familyName": "Smith"},
+			"emails":     []map[string]interface{}{{"value": "alice@example.com", "primary": true}},
+			"active":     true,
+		}, http.StatusCreated, &alice)
+
+		if alice.UserName != "alice" || alice.ExternalID != "00u1" || alice.DisplayName != "Alice Smith" || !alice.active() {
+			t.Errorf("unexpected user %+v", alice)
+		}
+		if got, want := alice.primaryEmail(), "alice@example.com"; got != want {
+			t.Errorf("got primary email %q, want %q", got, want)
+		}
+
+		do(t, "POST", "/scim/v2/Users", map[string]interface{}{
+			"userName":   "alice@example.com",
+			"externalId": "00u2",
+		}, http.StatusConflict, nil)
+	})
+
+	t.Run("find user", func(t *testing.T) {
+		for _, filter := range []string{`userName eq "alice@example.com"`, `externalId eq "00u1"`} {
+			var resp struct {
+				TotalResults int
+				Resources    []scimUser
+			}
+			do(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape(filter), nil, http.StatusOK, &resp)
+			if resp.TotalResults != 1 || len(resp.Resources) != 1 || resp.Resources[0].ID != alice.ID {
+				t.Errorf("filter %q: unexpected response %+v", filter, resp)
+			}
+		}
+
+		var resp struct{ TotalResults int }
+		do(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "bob"`), nil, http.StatusOK, &resp)
+		if resp.TotalResults != 0 {
+			t.Errorf("got %d results, want 0", resp.TotalResults)
+		}
+	})
+
+	t.Run("update user", func(t *testing.T) {
+		var u scimUser
+		do(t, "PATCH", "/scim/v2/Users/"+alice.ID, map[string]interface{}{
+			"schemas": []string{scimSchemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "replace", "path": "displayName", "value": "Alice Jones"},
+				{"op": "add", "path": "emails", "value": []map[string]string{{"value": "alice.jones@example.com"}}},
+			},
+		}, http.StatusOK, &u)
+
+		if u.DisplayName != "Alice Jones" {
+			t.Errorf("got display name %q, want %q", u.DisplayName, "Alice Jones")
+		}
+
+		userID := mustParseInt32(t, alice.ID)
+		if _, verified, err := db.UserEmails.Get(ctx, userID, "alice.jones@example.com"); err != nil || !verified {
+			t.Errorf("got verified %v, error %v, want verified email", verified, err)
+		}
+
+		// A new primary email address of the identity provider becomes the primary email address.
+		do(t, "PATCH", "/scim/v2/Users/"+alice.ID, map[string]interface{}{
+			"schemas": []string{scimSchemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "replace", "path": `emails[type eq "work"].value`, "value": "alice.smith@example.com"},
+			},
+		}, http.StatusOK, &u)
+		if got, want := u.primaryEmail(), "alice.smith@example.com"; got != want {
+			t.Errorf("got primary email %q, want %q", got, want)
+		}
+		do(t, "GET", "/scim/v2/Users/"+alice.ID, nil, http.StatusOK, &u)
+		if got, want := u.primaryEmail(), "alice.smith@example.com"; got != want {
+			t.Errorf("got primary email %q, want %q", got, want)
+		}
+	})
+
+	var bob scimUser
+	var engineering scimGroup
+	t.Run("create group", func(t *testing.T) {
+		do(t, "POST", "/scim/v2/Users", map[string]interface{}{"userName": "bob"}, http.StatusCreated, &bob)
+
+		do(t, "POST", "/scim/v2/Groups", map[string]interface{}{
+			"schemas":     []string{scimSchemaGroup},
+			"displayName": "Engineering Team",
+			"members":     []map[string]string{{"value": alice.ID}, {"value": bob.ID}},
+		}, http.StatusCreated, &engineering)
+
+		org, err := db.Orgs.GetByName(ctx, "Engineering-Team")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if engineering.ID != strconv.Itoa(int(org.ID)) || engineering.DisplayName != "Engineering Team" || len(engineering.Members) != 2 {
+			t.Errorf("unexpected group %+v", engineering)
+		}
+
+		do(t, "POST", "/scim/v2/Groups", map[string]interface{}{
+			"displayName": "Engineering Team",
+		}, http.StatusConflict, nil)
+		do(t, "POST", "/scim/v2/Groups", map[string]interface{}{
+			"displayName": "Sales",
+			"members":     []map[string]string{{"value": "999999"}},
+		}, http.StatusBadRequest, nil)
+	})
+
+	t.Run("update user with an email address of another user", func(t *testing.T) {
+		do(t, "PATCH", "/scim/v2/Users/"+bob.ID, map[string]interface{}{
+			"schemas": []string{scimSchemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "add", "path": "emails", "value": []map[string]interface{}{{"value": "alice@example.com", "primary": true}}},
+			},
+		}, http.StatusConflict, nil)
+
+		if _, _, err := db.UserEmails.Get(ctx, mustParseInt32(t, bob.ID), "alice@example.com"); !errcode.IsNotFound(err) {
+			t.Errorf("got error %v, want email not to be added", err)
+		}
+	})
+
+	t.Run("update group", func(t *testing.T) {
+		var g scimGroup
+		do(t, "PATCH", "/scim/v2/Groups/"+engineering.ID, map[string]interface{}{
+			"schemas": []string{scimSchemaPatchOp},
+			"Operations": []map[string]interface{}{
+				{"op": "remove", "path": `members[value eq "` + bob.ID + `"]`},
+			},
+		}, http.StatusOK, &g)
+
+		if len(g.Members) != 1 || g.Members[0].Value != alice.ID {
+			t.Errorf("got members %+v, want only %s", g.Members, alice.ID)
+		}
+		if _, err := db.OrgMembers.GetByOrgIDAndUserID(ctx, mustParseInt32(t, engineering.ID), mustParseInt32(t, bob.ID)); err == nil {
+			t.Error("bob is still a member")
+		}
+	})
+
+	t.Run("deactivate user", func(t *testing.T) {
+		aliceID, bobID := mustParseInt32(t, alice.ID), mustParseInt32(t, bob.ID)
+		setActive := func(active bool) map[string]interface{} {
+			return map[string]interface{}{
+				"schemas":    []string{scimSchemaPatchOp},
+				"Operations": []map[string]interface{}{{"op": "replace", "path": "active", "value": active}},
+			}
+		}
+
+		// The last site admin can't be deactivated.
+		if err := db.Users.SetIsSiteAdmin(ctx, aliceID, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Users.SetIsSiteAdmin(ctx, bobID, false); err != nil {
+			t.Fatal(err)
+		}
+		do(t, "PATCH", "/scim/v2/Users/"+alice.ID, setActive(false), http.StatusBadRequest, nil)
+		if err := db.Users.SetIsSiteAdmin(ctx, bobID, true); err != nil {
+			t.Fatal(err)
+		}
+
+		_, accessToken, err := db.AccessTokens.Create(ctx, aliceID, []string{authz.ScopeUserAll}, "test", aliceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var u scimUser
+		do(t, "PATCH", "/scim/v2/Users/"+alice.ID, setActive(false), http.StatusOK, &u)
+		if u.active() {
+			t.Error("user is still active")
+		}
+		if _, err := db.AccessTokens.Lookup(ctx, accessToken, authz.ScopeUserAll); err == nil {
+			t.Error("access token was not revoked")
+		}
+		if _, err := db.Users.GetByID(ctx, aliceID); err == nil {
+			t.Error("deactivated user can still be looked up")
+		}
+
+		var resp struct {
+			TotalResults int
+			Resources    []scimUser
+		}
+		do(t, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`externalId eq "00u1"`), nil, http.StatusOK, &resp)
+		if resp.TotalResults != 1 || len(resp.Resources) != 1 || resp.Resources[0].active() {
+			t.Errorf("unexpected response %+v", resp)
+		}
+
+		var g scimGroup
+		do(t, "GET", "/scim/v2/Groups/"+engineering.ID, nil, http.StatusOK, &g)
+		if len(g.Members) != 0 {
+			t.Errorf("got members %+v, want none", g.Members)
+		}
+
+		do(t, "PATCH", "/scim/v2/Users/"+alice.ID, setActive(true), http.StatusOK, &u)
+		if !u.active() || u.UserName != "alice" || u.ExternalID != "00u1" {
+			t.Errorf("unexpected reactivated user %+v", u)
+		}
+		if _, err := db.AccessTokens.Lookup(ctx, accessToken, authz.ScopeUserAll); err == nil {
+			t.Error("access token was restored")
+		}
+
+		do(t, "GET", "/scim/v2/Groups/"+engineering.ID, nil, http.StatusOK, &g)
+		if len(g.Members) != 1 || g.Members[0].Value != alice.ID {
+			t.Errorf("got members %+v, want only %s", g.Members, alice.ID)
+		}
+	})
+
+	t.Run("delete user", func(t *testing.T) {
+		do(t, "DELETE", "/scim/v2/Users/"+bob.ID, nil, http.StatusNoContent, nil)
+		do(t, "GET", "/scim/v2/Users/"+bob.ID, nil, http.StatusNotFound, nil)
+	})
+
+	t.Run("organizations not created via SCIM", func(t *testing.T) {
+		org, err := db.Orgs.Create(ctx, "Sales-Team", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := strconv.Itoa(int(org.ID))
+
+		do(t, "GET", "/scim/v2/Groups/"+id, nil, http.StatusNotFound, nil)
+		do(t, "DELETE", "/scim/v2/Groups/"+id, nil, http.StatusNotFound, nil)
+
+		var resp struct{ TotalResults int }
+		do(t, "GET", "/scim/v2/Groups?filter="+url.QueryEscape(`displayName eq "Sales Team"`), nil, http.StatusOK, &resp)
+		if resp.TotalResults != 0 {
+			t.Errorf("got %d results, want 0", resp.TotalResults)
+		}
+		do(t, "GET", "/scim/v2/Groups", nil, http.StatusOK, &resp)
+		if resp.TotalResults != 1 {
+			t.Errorf("got %d results, want 1", resp.TotalResults)
+		}
+
+		if _, err := db.Orgs.GetByID(ctx, org.ID); err != nil {
+			t.Errorf("organization was deleted: %s", err)
+		}
+	})
+
+	t.Run("delete group", func(t *testing.T) {
+		do(t, "DELETE", "/scim/v2/Groups/"+engineering.ID, nil, http.StatusNoContent, nil)
+		do(t, "GET", "/scim/v2/Groups/"+engineering.ID, nil, http.StatusNotFound, nil)
+	})
+}
+
+func mustParseInt32(t *testing.T, s string) int32 {
+	t.Helper()
+	id, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int32(id)
+}
@@ -0,0 +1,608 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// scimServiceType is the service type of the external accounts that link users to the
+// externalId that the SCIM client assigned to them.
+const scimServiceType = "scim"
+
+// scimUser is the SCIM representation of a Sourcegraph user, see
+// https://tools.ietf.org/html/rfc7643#section-4.1.
+type scimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *scimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []scimEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *scimMeta   `json:"meta,omitempty"`
+}
+
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+func (u *scimUser) displayName() string {
+	if u.DisplayName != "" || u.Name == nil {
+		return u.DisplayName
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+func (u *scimUser) primaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary && e.Value != "" {
+			return e.Value
+		}
+	}
+	for _, e := range u.Emails {
+		if e.Value != "" {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func (u *scimUser) active() bool {
+	return u.Active == nil || *u.Active
+}
+
+func scimAccountSpec(externalID string) extsvc.AccountSpec {
+	return extsvc.AccountSpec{ServiceType: scimServiceType, AccountID: externalID}
+}
+
+// serveSCIMUsers serves the /Users endpoint, which lists and creates users.
+func serveSCIMUsers(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodGet:
+		return listSCIMUsers(w, r)
+	case http.MethodPost:
+		return createSCIMUser(w, r)
+	default:
+		return &scimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"}
+	}
+}
+
+// serveSCIMUser serves the /Users/{id} endpoint, which gets, updates and deletes a user.
+// Deactivated users are still served, so that they can be reactivated.
+func serveSCIMUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	id, err := scimResourceID(r, "User")
+	if err != nil {
+		return err
+	}
+	user, err := db.Users.GetByIDIncludingDeactivated(ctx, id)
+	if errcode.IsNotFound(err) {
+		return scimNotFound("User", strconv.Itoa(int(id)))
+	} else if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		u, err := toSCIMUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, u)
+
+	case http.MethodPut:
+		var next scimUser
+		if err := readSCIM(r, &next); err != nil {
+			return err
+		}
+		u, err := updateSCIMUser(ctx, user, &next)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, u)
+
+	case http.MethodPatch:
+		ops, err := readSCIMPatch(r)
+		if err != nil {
+			return err
+		}
+		next, err := toSCIMUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		if err := applySCIMUserPatch(next, ops); err != nil {
+			return err
+		}
+		u, err := updateSCIMUser(ctx, user, next)
+		if err != nil {
+			return err
+		}
+		return writeSCIM(w, http.StatusOK, u)
+
+	case http.MethodDelete:
+		if err := deleteSCIMUser(ctx, user); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+
+	default:
+		return &scimError{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"}
+	}
+}
+
+func listSCIMUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	page, err := parseSCIMPage(r)
+	if err != nil {
+		return err
+	}
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"), "username", "externalid")
+	if err != nil {
+		return err
+	}
+
+	var (
+		users []*types.User
+		total int
+	)
+	if filter != nil {
+		user, err := findSCIMUser(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			total = 1
+			if page.StartIndex == 1 && page.Count > 0 {
+				users = append(users, user)
+			}
+		}
+	} else {
+		total, err = db.Users.Count(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if page.Count > 0 {
+			users, err = db.Users.List(ctx, &db.UsersListOptions{
+				LimitOffset: &db.LimitOffset{Limit: page.Count, Offset: page.StartIndex - 1},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	resources := make([]*scimUser, 0, len(users))
+	for _, user := range users {
+		u, err := toSCIMUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, u)
+	}
+
+	return writeSCIM(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   page.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// findSCIMUser returns the user matched by the given filter, or nil if there is none. Deactivated
+// users are matched too, so that identity providers reactivate them instead of creating them again.
+func findSCIMUser(ctx context.Context, filter *scimFilter) (*types.User, error) {
+	var (
+		user *types.User
+		err  error
+	)
+	switch filter.Attribute {
+	case "username":
+		username, nerr := auth.NormalizeUsername(filter.Value)
+		if nerr != nil {
+			return nil, nil
+		}
+		user, err = db.Users.GetByUsernameIncludingDeactivated(ctx, username)
+
+	case "externalid":
+		accounts, lerr := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+			ServiceType: scimServiceType,
+			AccountID:   filter.Value,
+		})
+		if lerr != nil || len(accounts) == 0 {
+			return nil, lerr
+		}
+		user, err = db.Users.GetByIDIncludingDeactivated(ctx, accounts[0].UserID)
+	}
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func createSCIMUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	var in scimUser
+	if err := readSCIM(r, &in); err != nil {
+		return err
+	}
+	if in.UserName == "" {
+		return scimBadRequest("invalidValue", "userName is required")
+	}
+	if !in.active() {
+		return scimBadRequest("invalidValue", "users can't be created inactive")
+	}
+	username, err := auth.NormalizeUsername(in.UserName)
+	if err != nil {
+		return scimBadRequest("invalidValue", "invalid userName %q: %s", in.UserName, err)
+	}
+
+	// The identity provider has verified the user's email address, so that users who sign in via
+	// SSO later are linked to this user by their verified email address.
+	newUser := db.NewUser{
+		Username:        username,
+		DisplayName:     in.displayName(),
+		Email:           in.primaryEmail(),
+		EmailIsVerified: in.primaryEmail() != "",
+	}
+
+	var userID int32
+	if in.ExternalID != "" {
+		existing, err := findSCIMUser(ctx, &scimFilter{Attribute: "externalid", Value: in.ExternalID})
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return scimConflict("a user with externalId %q already exists", in.ExternalID)
+		}
+		userID, err = db.ExternalAccounts.CreateUserAndSave(ctx, newUser, scimAccountSpec(in.ExternalID), extsvc.AccountData{})
+		if err != nil {
+			return scimUserError(err, username)
+		}
+	} else {
+		user, err := db.Users.Create(ctx, newUser)
+		if err != nil {
+			return scimUserError(err, username)
+		}
+		userID = user.ID
+	}
+
+	user, err := db.Users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	u, err := toSCIMUser(ctx, user)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", u.Meta.Location)
+	return writeSCIM(w, http.StatusCreated, u)
+}
+
+// updateSCIMUser updates the given user to match next, which is the complete new SCIM
+// representation of the user. Other changes to a user that is being deactivated are ignored.
+func updateSCIMUser(ctx context.Context, user *types.User, next *scimUser) (*scimUser, error) {
+	if !next.active() {
+		if !user.Deactivated {
+			if err := deactivateSCIMUser(ctx, user.ID); err != nil {
+				return nil, err
+			}
+		}
+		user, err := db.Users.GetByIDIncludingDeactivated(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return toSCIMUser(ctx, user)
+	}
+
+	if user.Deactivated {
+		if err := db.Users.Reactivate(ctx, user.ID); err != nil {
+			return nil, scimUserError(err, user.Username)
+		}
+	}
+
+	current, err := toSCIMUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var update db.UserUpdate
+	if next.UserName != "" {
+		username, err := auth.NormalizeUsername(next.UserName)
+		if err != nil {
+			return nil, scimBadRequest("invalidValue", "invalid userName %q: %s", next.UserName, err)
+		}
+		if username != user.Username {
+			update.Username = username
+		}
+	}
+	if displayName := next.displayName(); displayName != current.displayName() {
+		update.DisplayName = &displayName
+	}
+	if update.Username != "" || update.DisplayName != nil {
+		if err := db.Users.Update(ctx, user.ID, update); err != nil {
+			return nil, scimUserError(err, update.Username)
+		}
+	}
+
+	for _, e := range next.Emails {
+		if e.Value == "" {
+			continue
+		}
+		if err := addVerifiedEmail(ctx, user.ID, e.Value); err != nil {
+			return nil, err
+		}
+	}
+	if email := next.primaryEmail(); email != "" && !strings.EqualFold(email, current.primaryEmail()) {
+		if err := db.UserEmails.SetPrimaryEmail(ctx, user.ID, email); err != nil {
+			return nil, err
+		}
+	}
+
+	if next.ExternalID != "" && next.ExternalID != current.ExternalID {
+		if err := db.ExternalAccounts.AssociateUserAndSave(ctx, user.ID, scimAccountSpec(next.ExternalID), extsvc.AccountData{}); err != nil {
+			return nil, scimConflict("externalId %q is already assigned to another user", next.ExternalID)
+		}
+	}
+
+	if user, err = db.Users.GetByID(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	return toSCIMUser(ctx, user)
+}
+
+// addVerifiedEmail adds the given email address, which the identity provider has verified, to
+// the user. It returns a conflict if another user has verified the email address.
+func addVerifiedEmail(ctx context.Context, userID int32, email string) error {
+	_, verified, err := db.UserEmails.Get(ctx, userID, email)
+	added := errcode.IsNotFound(err)
+	if added {
+		if err := db.UserEmails.Add(ctx, userID, email, nil); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else if verified {
+		return nil
+	}
+
+	err = db.UserEmails.SetVerified(ctx, userID, email, true)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "user_emails_unique_verified_email" {
+		if added {
+			if err := db.UserEmails.Remove(ctx, userID, email); err != nil {
+				return err
+			}
+		}
+		return scimConflict("email address %q is already in use by another user", email)
+	}
+	return err
+}
+
+// deactivateSCIMUser deactivates the user with the given ID. Deactivated users can be
+// reactivated by setting active to true again.
+//
+// 🚨 SECURITY: The user must lose access immediately, so deactivating the user invalidates their
+// sessions and revokes their access tokens.
+func deactivateSCIMUser(ctx context.Context, userID int32) error {
+	err := db.Users.Deactivate(ctx, userID)
+	if err == db.ErrCannotDeactivateLastSiteAdmin {
+		return scimBadRequest("mutability", "the last site admin can't be deactivated")
+	}
+	return err
+}
+
+// deleteSCIMUser deletes the given user. The user is deactivated first, which signs them out and
+// refuses to remove the last site admin.
+func deleteSCIMUser(ctx context.Context, user *types.User) error {
+	if !user.Deactivated {
+		if err := deactivateSCIMUser(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+	return db.Users.Delete(ctx, user.ID)
+}
+
+func scimUserError(err error, username string) error {
+	switch {
+	case db.IsUsernameExists(err):
+		return scimConflict("username %q is already taken", username)
+	case db.IsEmailExists(err):
+		return scimConflict("email address is already in use by another user")
+	}
+	return err
+}
+
+func toSCIMUser(ctx context.Context, user *types.User) (*scimUser, error) {
+	emails, err := db.UserEmails.ListByUser(ctx, db.UserEmailsListOptions{UserID: user.ID, OnlyVerified: true})
+	if err != nil {
+		return nil, err
+	}
+	primary, _, err := db.UserEmails.GetPrimaryEmail(ctx, user.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, err
+	}
+	accounts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+		UserID:      user.ID,
+		ServiceType: scimServiceType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	active := !user.Deactivated
+	u := &scimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          strconv.Itoa(int(user.ID)),
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Meta:        newSCIMMeta("User", user.ID, user.CreatedAt, user.UpdatedAt),
+	}
+	if user.DisplayName != "" {
+		u.Name = &scimName{Formatted: user.DisplayName}
+	}
+	for _, e := range emails {
+		u.Emails = append(u.Emails, scimEmail{Value: e.Email, Primary: e.Email == primary})
+	}
+	if len(accounts) > 0 {
+		u.ExternalID = accounts[0].AccountID
+	}
+	return u, nil
+}
+
+// scimEmailValuePathPattern matches paths like `emails[type eq "work"].value`.
+var scimEmailValuePathPattern = regexp.MustCompile(`^emails\[type eq "([^"]*)"\]\.value$`)
+
+// applySCIMUserPatch applies the given PATCH operations to u.
+func applySCIMUserPatch(u *scimUser, ops []scimPatchOp) error {
+	for _, op := range ops {
+		if op.Path == "" {
+			// Without a path, the value contains the attributes to add or replace.
+			if op.Op == "remove" {
+				return scimBadRequest("noTarget", "remove operations require a path")
+			}
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return scimBadRequest("invalidValue", "invalid value of %s operation: %s", op.Op, err)
+			}
+			for path, value := range attrs {
+				if err := setSCIMUserAttribute(u, op.Op, path, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if op.Op == "remove" {
+			switch p := strings.ToLower(op.Path); {
+			case p == "displayname":
+				u.DisplayName = ""
+			case p == "name" || strings.HasPrefix(p, "name."):
+				u.Name = nil
+			}
+			continue
+		}
+		if err := setSCIMUserAttribute(u, op.Op, op.Path, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setSCIMUserAttribute(u *scimUser, op, path string, value json.RawMessage) error {
+	unmarshal := func(v interface{}) error {
+		if err := json.Unmarshal(value, v); err != nil {
+			return scimBadRequest("invalidValue", "invalid value of %q: %s", path, err)
+		}
+		return nil
+	}
+
+	switch p := strings.ToLower(path); {
+	case p == "active":
+		// Some identity providers send booleans as strings.
+		var active interface{}
+		if err := unmarshal(&active); err != nil {
+			return err
+		}
+		switch v := active.(type) {
+		case bool:
+			u.Active = &v
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return scimBadRequest("invalidValue", "invalid value of %q: %q", path, v)
+			}
+			u.Active = &b
+		default:
+			return scimBadRequest("invalidValue", "invalid value of %q", path)
+		}
+
+	case p == "username":
+		return unmarshal(&u.UserName)
+
+	case p == "displayname":
+		return unmarshal(&u.DisplayName)
+
+	case p == "externalid":
+		return unmarshal(&u.ExternalID)
+
+	case p == "name":
+		return unmarshal(&u.Name)
+
+	case strings.HasPrefix(p, "name."):
+		if u.Name == nil {
+			u.Name = &scimName{}
+		}
+		switch strings.TrimPrefix(p, "name.") {
+		case "formatted":
+			return unmarshal(&u.Name.Formatted)
+		case "givenname":
+			return unmarshal(&u.Name.GivenName)
+		case "familyname":
+			return unmarshal(&u.Name.FamilyName)
+		}
+
+	case p == "emails":
+		var emails []scimEmail
+		if err := unmarshal(&emails); err != nil {
+			return err
+		}
+		if op == "add" {
+			u.Emails = append(u.Emails, emails...)
+		} else {
+			u.Emails = emails
+		}
+
+	case scimEmailValuePathPattern.MatchString(p):
+		var email string
+		if err := unmarshal(&email); err != nil {
+			return err
+		}
+		typ := scimEmailValuePathPattern.FindStringSubmatch(p)[1]
+		for i := range u.Emails {
+			if strings.EqualFold(u.Emails[i].Type, typ) {
+				u.Emails[i].Value = email
+				return nil
+			}
+		}
+		// The emails of Sourcegraph users have no type, so a new email address replaces the
+		// primary email address.
+		u.Emails = append([]scimEmail{{Value: email, Type: typ, Primary: true}}, u.Emails...)
+		for i := 1; i < len(u.Emails); i++ {
+			u.Emails[i].Primary = false
+		}
+
+	default:
+		// Identity providers send attributes that Sourcegraph doesn't store (e.g. title or phone
+		// numbers), which are ignored.
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+// ServeStreamCancel is an http handler which cancels the running search stream
+// whose ID is given in the "id" query parameter. The ID is sent to the client
+// in the "start" event of the stream, and only the user who started a stream
+// can cancel it.
+//
+// Streams are only known to the frontend instance serving them. Clients
+// should always close the stream as well, which cancels the search wherever
+// it is running.
+func ServeStreamCancel(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "no stream id found", http.StatusBadRequest)
+		return
+	}
+
+	if !streams.cancel(actor.FromContext(r.Context()).UID, id) {
+		http.Error(w, "search stream not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// streams is the registry of the search streams running in this process.
+var streams = &streamRegistry{running: map[string]runningStream{}}
+
+type runningStream struct {
+	userID int32
+	cancel context.CancelFunc
+}
+
+// streamRegistry keeps track of running search streams so that they can be
+// canceled with ServeStreamCancel.
+type streamRegistry struct {
+	mu      sync.Mutex
+	running map[string]runningStream
+}
+
+// register records a stream started by the given user, which is canceled by
+// calling cancel. It returns the ID of the stream, and a function to remove it
+// from the registry once it's done.
+func (r *streamRegistry) register(userID int32, cancel context.CancelFunc) (id string, unregister func()) {
+	id = uuid.New().String()
+
+	r.mu.Lock()
+	r.running[id] = runningStream{userID: userID, cancel: cancel}
+	r.mu.Unlock()
+
+	return id, func() {
+		r.mu.Lock()
+		delete(r.running, id)
+		r.mu.Unlock()
+	}
+}
+
+// cancel cancels the stream with the given ID if it was started by the given
+// user. It returns false if there is no such stream.
+func (r *streamRegistry) cancel(userID int32, id string) bool {
+	r.mu.Lock()
+	s, ok := r.running[id]
+	r.mu.Unlock()
+
+	if !ok || s.userID != userID {
+		return false
+	}
+
+	s.cancel()
+	return true
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStreamRegistry(t *testing.T) {
+	r := &streamRegistry{running: map[string]runningStream{}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id, unregister := r.register(1, cancel)
+
+	if r.cancel(2, id) {
+		t.Fatal("other user canceled the stream")
+	}
+	if ctx.Err() != nil {
+		t.Fatal("stream canceled by other user")
+	}
+
+	if !r.cancel(1, id) {
+		t.Fatal("stream not found")
+	}
+	if ctx.Err() != context.Canceled {
+		t.Fatalf("want stream to be canceled, got %v", ctx.Err())
+	}
+
+	unregister()
+	if r.cancel(1, id) {
+		t.Fatal("unregistered stream found")
+	}
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// progressAggregator keeps track of the latest progress reported by a running
+// search, so that it can be sent to the client at regular intervals instead of
+// on every update.
+type progressAggregator struct {
+	start time.Time
+
+	mu      sync.Mutex
+	current graphqlbackend.SearchProgress
+	sent    *graphqlbackend.SearchProgress
+}
+
+// Update records the latest progress of the search. It is safe to call
+// concurrently.
+func (p *progressAggregator) Update(progress graphqlbackend.SearchProgress) {
+	p.mu.Lock()
+	p.current = progress
+	p.mu.Unlock()
+}
+
+// Changed returns the progress event for the latest progress of the search,
+// and false if it didn't change since the last call to Changed.
+func (p *progressAggregator) Changed() (eventProgress, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.sent != nil && reflect.DeepEqual(*p.sent, p.current) {
+		return eventProgress{}, false
+	}
+	current := p.current
+	p.sent = &current
+	return fromProgress(current, time.Since(p.start), false), true
+}
+
+// Final returns the progress event for the finished search.
+func (p *progressAggregator) Final(progress graphqlbackend.SearchProgress) eventProgress {
+	return fromProgress(progress, time.Since(p.start), true)
+}
+
+func fromProgress(p graphqlbackend.SearchProgress, duration time.Duration, done bool) eventProgress {
+	return eventProgress{
+		Done:              done,
+		DurationMs:        int(duration / time.Millisecond),
+		MatchCount:        p.MatchCount,
+		LimitHit:          p.LimitHit,
+		RepositoriesCount: p.RepositoriesCount,
+		Searched:          p.Searched,
+		Indexed:           p.Indexed,
+		Cloning:           repoNamesToStrings(p.Cloning),
+		Missing:           repoNamesToStrings(p.Missing),
+		Timedout:          repoNamesToStrings(p.Timedout),
+		ExcludedForks:     p.ExcludedForks,
+		ExcludedArchived:  p.ExcludedArchived,
+	}
+}
+
+func repoNamesToStrings(names []api.RepoName) []string {
+	strs := make([]string, 0, len(names))
+	for _, n := range names {
+		strs = append(strs, string(n))
+	}
+	return strs
+}
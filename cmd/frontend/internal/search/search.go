@@ -10,11 +10,18 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
 // ServeStream is an http handler which streams back search results.
+//
+// Besides the results, the stream contains a "start" event with the ID that
+// can be passed to ServeStreamCancel, "progress" events while the search is
+// running and once it's done, and an "alert" event if the search has an alert.
 func ServeStream(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
@@ -31,19 +38,66 @@ func ServeStream(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	id, unregister := streams.register(actor.FromContext(ctx).UID, cancel)
+	defer unregister()
+
+	if err := eventWriter.Event("start", eventStart{ID: id}); err != nil {
+		// EOF
+		return
+	}
+
+	progress := &progressAggregator{start: time.Now()}
+
 	search, err := graphqlbackend.NewSearchImplementer(ctx, &graphqlbackend.SearchArgs{
 		Query:          args.Query,
 		Version:        args.Version,
 		PatternType:    strPtr(args.PatternType),
 		VersionContext: strPtr(args.VersionContext),
+		Progress:       progress.Update,
 	})
 	if err != nil {
 		eventWriter.Event("error", err.Error())
 		return
 	}
 
-	resultsResolver, err := search.Results(ctx)
+	type searchResults struct {
+		resolver *graphqlbackend.SearchResultsResolver
+		err      error
+	}
+	resultsC := make(chan searchResults, 1)
+	goroutine.Go(func() {
+		resolver, err := search.Results(ctx)
+		resultsC <- searchResults{resolver: resolver, err: err}
+	})
+
+	// Send the progress of the search at regular intervals until it's done.
+	// This is the only goroutine writing to eventWriter.
+	ticker := time.NewTicker(progressInterval)
+	var results searchResults
+loop:
+	for {
+		select {
+		case results = <-resultsC:
+			break loop
+		case <-ticker.C:
+			if event, ok := progress.Changed(); ok {
+				if err := eventWriter.Event("progress", event); err != nil {
+					// EOF, stop searching but wait for the search to
+					// return.
+					cancel()
+				}
+			}
+		}
+	}
+	ticker.Stop()
+
+	resultsResolver, err := results.resolver, results.err
 	if err != nil {
+		if ctx.Err() == context.Canceled {
+			// The client canceled the search, or went away.
+			_ = eventWriter.Event("done", map[string]interface{}{})
+			return
+		}
 		eventWriter.Event("error", err.Error())
 		return
 	}
@@ -164,10 +218,40 @@ func ServeStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// TODO stats
+	if alert := resultsResolver.Alert(); alert != nil {
+		// Inlining to avoid exporting a bunch of stuff from graphqlbackend
+		var proposedQueries []eventProposedQuery
+		if pqs := alert.ProposedQueries(); pqs != nil {
+			for _, pq := range *pqs {
+				proposedQueries = append(proposedQueries, eventProposedQuery{
+					Description: fromStrPtr(pq.Description()),
+					Query:       pq.Query(),
+				})
+			}
+		}
+
+		if err := eventWriter.Event("alert", eventAlert{
+			Title:           alert.Title(),
+			Description:     fromStrPtr(alert.Description()),
+			ProposedQueries: proposedQueries,
+		}); err != nil {
+			// EOF
+			return
+		}
+	}
+
+	if err := eventWriter.Event("progress", progress.Final(resultsResolver.Progress())); err != nil {
+		// EOF
+		return
+	}
+
 	_ = eventWriter.Event("done", map[string]interface{}{})
 }
 
+// progressInterval is how often progress events are sent while a search is
+// running.
+const progressInterval = 500 * time.Millisecond
+
 type args struct {
 	Query          string
 	Version        string
@@ -402,3 +486,41 @@ type eventFilter struct {
 	LimitHit bool   `json:"limitHit"`
 	Kind     string `json:"kind"`
 }
+
+// eventStart is the first event of a stream. ID can be used to cancel the
+// search with ServeStreamCancel.
+type eventStart struct {
+	ID string `json:"id"`
+}
+
+// eventProgress is the progress of a search. It is sent while the search is
+// running, and once more with Done set when it's finished.
+type eventProgress struct {
+	Done       bool `json:"done"`
+	DurationMs int  `json:"durationMs"`
+	MatchCount int  `json:"matchCount"`
+	LimitHit   bool `json:"limitHit"`
+
+	RepositoriesCount int      `json:"repositoriesCount"`
+	Searched          int      `json:"searched"`
+	Indexed           int      `json:"indexed"`
+	Cloning           []string `json:"cloning"`
+	Missing           []string `json:"missing"`
+	Timedout          []string `json:"timedout"`
+	ExcludedForks     int      `json:"excludedForks"`
+	ExcludedArchived  int      `json:"excludedArchived"`
+}
+
+// eventAlert is the alert of a search. Currently has a 1-1 correspondance with
+// the SearchAlert graphql type.
+type eventAlert struct {
+	Title           string               `json:"title"`
+	Description     string               `json:"description,omitempty"`
+	ProposedQueries []eventProposedQuery `json:"proposedQueries"`
+}
+
+// eventProposedQuery is a suggested query to run when we emit an alert.
+type eventProposedQuery struct {
+	Description string `json:"description,omitempty"`
+	Query       string `json:"query"`
+}
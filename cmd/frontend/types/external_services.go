@@ -4,6 +4,18 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
+type AzureDevOpsConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.AzureDevOpsConnection
+}
+
+type BitbucketCloudConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.BitbucketCloudConnection
+}
+
 type BitbucketServerConnection struct {
 	// The unique resource identifier of the external service.
 	URN string
@@ -21,3 +33,15 @@ type GitLabConnection struct {
 	URN string
 	*schema.GitLabConnection
 }
+
+type GitoliteConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GitoliteConnection
+}
+
+type PerforceConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.PerforceConnection
+}
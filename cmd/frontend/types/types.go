@@ -115,6 +115,9 @@ type User struct {
 	BuiltinAuth           bool
 	Tags                  []string
 	InvalidatedSessionsAt time.Time
+	// Deactivated is whether the user was deactivated by the identity provider that
+	// provisions users via SCIM.
+	Deactivated bool
 }
 
 type Org struct {
@@ -123,6 +126,9 @@ type Org struct {
 	DisplayName *string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// SCIMProvisioned is whether the organization was created by the identity provider that
+	// provisions groups via SCIM.
+	SCIMProvisioned bool
 }
 
 type OrgMembership struct {
@@ -17,6 +17,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
@@ -30,6 +31,7 @@ var (
 	runRepoCleanup, _ = strconv.ParseBool(env.Get("SRC_RUN_REPO_CLEANUP", "", "Periodically remove inactive repositories."))
 	wantPctFree       = env.Get("SRC_REPOS_DESIRED_PERCENT_FREE", "10", "Target percentage of free space on disk.")
 	janitorInterval   = env.Get("SRC_REPOS_JANITOR_INTERVAL", "1m", "Interval between cleanup runs")
+	gitserverAddr     = env.Get("SRC_GITSERVER_ADDR", "", "The address of this gitserver as it appears in the list of gitservers. If set, repositories owned by another gitserver are transferred to it.")
 )
 
 func main() {
@@ -54,6 +56,13 @@ func main() {
 		ReposDir:                reposDir,
 		DeleteStaleRepositories: runRepoCleanup,
 		DesiredPercentFree:      wantPctFree2,
+		Hostname:                gitserverAddr,
+		GitServerAddrs: func() []string {
+			return conf.Get().ServiceConnections.GitServers
+		},
+		ReplicationFactor: func() int {
+			return conf.Get().GitServerReplicationFactor
+		},
 	}
 	gitserver.RegisterMetrics()
 
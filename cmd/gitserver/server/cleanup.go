@@ -60,10 +60,13 @@ const reposStatsName = "repos-stats.json"
 // 2. Remove stale lock files.
 // 3. Remove inactive repos on sourcegraph.com
 // 4. Reclone repos after a while. (simulate git gc)
+// 5. Update replicas of repos this gitserver owns after a while.
 func (s *Server) cleanupRepos() {
 	bCtx, bCancel := s.serverContext()
 	defer bCancel()
 
+	addrs := s.gitServerAddrs()
+
 	stats := protocol.ReposStats{
 		UpdatedAt: time.Now(),
 	}
@@ -146,6 +149,10 @@ func (s *Server) cleanupRepos() {
 		return true, nil
 	}
 
+	reconcileReplicas := func(dir GitDir) (done bool, err error) {
+		return false, s.maybeReconcileReplicas(bCtx, dir, addrs)
+	}
+
 	removeStaleLocks := func(dir GitDir) (done bool, err error) {
 		gitDir := string(dir)
 
@@ -198,6 +205,9 @@ func (s *Server) cleanupRepos() {
 		// these problems. git gc is slow and resource intensive. It is
 		// cheaper and faster to just reclone the repository.
 		{"maybe reclone", maybeReclone},
+		// Replicas which missed updates, for example because they were
+		// down or just added, are brought up to date by their owner.
+		{"reconcile replicas", reconcileReplicas},
 	}
 
 	err := bestEffortWalk(s.ReposDir, func(dir string, fi os.FileInfo) error {
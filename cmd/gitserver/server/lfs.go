@@ -0,0 +1,456 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// lfsPointerMaxSize is the maximum size of a Git LFS pointer file. Larger
+	// blobs are never pointers.
+	lfsPointerMaxSize = 1024
+
+	// defaultLFSMaxFileSize is the default for gitLFS.maxFileSize.
+	defaultLFSMaxFileSize = 1 << 20
+
+	// lfsBatchSize is the number of objects requested in one call to the LFS
+	// batch API.
+	lfsBatchSize = 100
+)
+
+var lfsObjectsFetched = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_lfs_objects_fetched",
+	Help: "number of Git LFS objects downloaded into the LFS cache of a repo",
+})
+
+var lfsSettings = conf.Cached(func() interface{} {
+	return buildLFSConfig(conf.Get().GitLFS)
+})
+
+// lfsConfig is the parsed gitLFS site configuration.
+type lfsConfig struct {
+	repos       []*regexp.Regexp
+	include     []glob.Glob
+	maxFileSize int64
+}
+
+func buildLFSConfig(c *schema.GitLFS) *lfsConfig {
+	lc := &lfsConfig{maxFileSize: defaultLFSMaxFileSize}
+	if c == nil {
+		return lc
+	}
+
+	for _, pattern := range c.Repos {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log15.Error("ignoring invalid gitLFS.repos pattern", "pattern", pattern, "error", err)
+			continue
+		}
+		lc.repos = append(lc.repos, re)
+	}
+	for _, pattern := range c.Include {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log15.Error("ignoring invalid gitLFS.include pattern", "pattern", pattern, "error", err)
+			continue
+		}
+		lc.include = append(lc.include, g)
+	}
+	if c.MaxFileSize > 0 {
+		lc.maxFileSize = int64(c.MaxFileSize)
+	}
+	return lc
+}
+
+// enabled returns whether the LFS objects of repo are fetched.
+func (c *lfsConfig) enabled(repo api.RepoName) bool {
+	for _, re := range c.repos {
+		if re.MatchString(string(repo)) {
+			return true
+		}
+	}
+	return false
+}
+
+// includes returns whether the LFS object of the file at path is fetched.
+func (c *lfsConfig) includes(path string) bool {
+	if len(c.include) == 0 {
+		return true
+	}
+	for _, g := range c.include {
+		if g.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// lfsConfigForRepo returns the LFS configuration if the LFS objects of repo
+// are fetched, and nil otherwise.
+func lfsConfigForRepo(repo api.RepoName) *lfsConfig {
+	c := lfsSettings().(*lfsConfig)
+	if !c.enabled(repo) {
+		return nil
+	}
+	return c
+}
+
+// lfsPointer is a parsed Git LFS pointer file, which is stored in git in
+// place of the content of a file tracked by Git LFS.
+type lfsPointer struct {
+	OID  string `json:"oid"` // hex encoded SHA-256 of the content
+	Size int64  `json:"size"`
+}
+
+// parseLFSPointer parses a Git LFS pointer file as described in
+// https://github.com/git-lfs/git-lfs/blob/master/docs/spec.md.
+func parseLFSPointer(b []byte) (p lfsPointer, ok bool) {
+	if len(b) > lfsPointerMaxSize || !bytes.HasPrefix(b, []byte("version https://git-lfs.github.com/spec/v1\n")) {
+		return p, false
+	}
+
+	p.Size = -1
+	for _, line := range strings.Split(string(b), "\n")[1:] {
+		switch {
+		case strings.HasPrefix(line, "oid sha256:"):
+			p.OID = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			size, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+			if err != nil {
+				return p, false
+			}
+			p.Size = size
+		}
+	}
+	if !validLFSOID(p.OID) || p.Size < 0 {
+		return p, false
+	}
+	return p, true
+}
+
+// validLFSOID returns whether oid is a hex encoded SHA-256. This must be
+// checked before using an oid in a path.
+func validLFSOID(oid string) bool {
+	_, err := hex.DecodeString(oid)
+	return err == nil && len(oid) == sha256.Size*2
+}
+
+// lfsObjectPath returns the path of the LFS object with the given oid in the
+// LFS cache of dir. It uses the same layout as git-lfs.
+func lfsObjectPath(dir GitDir, oid string) string {
+	return dir.Path("lfs", "objects", oid[:2], oid[2:4], oid)
+}
+
+// openLFSObject returns the cached LFS object if b is an LFS pointer file. The
+// caller must close the returned file.
+func openLFSObject(dir GitDir, b []byte) (*os.File, int64, bool) {
+	p, ok := parseLFSPointer(b)
+	if !ok {
+		return nil, 0, false
+	}
+	f, err := os.Open(lfsObjectPath(dir, p.OID))
+	if err != nil {
+		return nil, 0, false
+	}
+	if fi, err := f.Stat(); err != nil || fi.Size() != p.Size {
+		f.Close()
+		return nil, 0, false
+	}
+	return f, p.Size, true
+}
+
+// maybeFetchLFSObjects fetches the LFS objects of repo if it is configured to,
+// logging failures. LFS objects are best-effort: without them files are shown
+// as LFS pointer files.
+func maybeFetchLFSObjects(ctx context.Context, repo api.RepoName, dir GitDir, remoteURL string) {
+	c := lfsConfigForRepo(repo)
+	if c == nil {
+		return
+	}
+	if err := fetchLFSObjects(ctx, dir, remoteURL, c); err != nil {
+		log15.Warn("failed to fetch LFS objects", "repo", repo, "error", err)
+	}
+}
+
+// fetchLFSObjects downloads the LFS objects of the files at HEAD of dir which
+// are included by c into the LFS cache of dir, unless they are cached
+// already.
+func fetchLFSObjects(ctx context.Context, dir GitDir, remoteURL string, c *lfsConfig) error {
+	pointers, err := lfsPointersAtHead(ctx, dir, c)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	var missing []lfsPointer
+	for _, p := range pointers {
+		if seen[p.OID] {
+			continue
+		}
+		seen[p.OID] = true
+		if _, err := os.Stat(lfsObjectPath(dir, p.OID)); err == nil {
+			continue
+		}
+		missing = append(missing, p)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	endpoint, err := lfsEndpoint(remoteURL)
+	if err != nil {
+		return err
+	}
+	for len(missing) > 0 {
+		batch := missing
+		if len(batch) > lfsBatchSize {
+			batch = batch[:lfsBatchSize]
+		}
+		missing = missing[len(batch):]
+
+		if err := fetchLFSBatch(ctx, dir, endpoint, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lfsPointersAtHead returns the LFS pointers of the files at HEAD of dir which
+// are included by c, and whose objects are not larger than c allows.
+func lfsPointersAtHead(ctx context.Context, dir GitDir, c *lfsConfig) ([]lfsPointer, error) {
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-l", "-z", "HEAD")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "failed to list files")
+	}
+
+	// Entries look like "<mode> blob <oid>   <size>\t<path>".
+	var blobs []string
+	for _, entry := range strings.Split(string(out), "\x00") {
+		i := strings.IndexByte(entry, '\t')
+		if i < 0 {
+			continue
+		}
+		fields := strings.Fields(entry[:i])
+		if len(fields) != 4 || fields[1] != "blob" || !c.includes(entry[i+1:]) {
+			continue
+		}
+		if size, err := strconv.Atoi(fields[3]); err != nil || size > lfsPointerMaxSize {
+			continue
+		}
+		blobs = append(blobs, fields[2])
+	}
+	if len(blobs) == 0 {
+		return nil, nil
+	}
+
+	// Read all candidates with a single git process.
+	cmd = exec.CommandContext(ctx, "git", "cat-file", "--batch")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Drain the output so that git exits if we return early.
+		_, _ = io.Copy(ioutil.Discard, stdout)
+		_ = cmd.Wait()
+	}()
+
+	var pointers []lfsPointer
+	r := bufio.NewReader(stdout)
+	for range blobs {
+		// Each blob is output as "<oid> blob <size>\n<content>\n".
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read blobs")
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue // missing
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Errorf("unexpected git cat-file output %q", header)
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, errors.Wrap(err, "failed to read blobs")
+		}
+		if p, ok := parseLFSPointer(content[:size]); ok && p.Size <= c.maxFileSize {
+			pointers = append(pointers, p)
+		}
+	}
+	return pointers, nil
+}
+
+// lfsEndpoint returns the LFS API endpoint of the repository with the given
+// remote URL, following the conventions of git-lfs.
+func lfsEndpoint(remoteURL string) (*url.URL, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported LFS remote scheme %q", u.Scheme)
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(p, ".git") {
+		p += ".git"
+	}
+	u.Path = p + "/info/lfs"
+	return u, nil
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		OID     string `json:"oid"`
+		Size    int64  `json:"size"`
+		Actions struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// fetchLFSBatch downloads objects using the LFS batch API at endpoint. The
+// credentials in endpoint are used for the batch request.
+func fetchLFSBatch(ctx context.Context, dir GitDir, endpoint *url.URL, objects []lfsPointer) error {
+	body, err := json.Marshal(&lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   objects,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", endpoint.String()+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "LFS batch request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("LFS batch request: bad HTTP response status %d", resp.StatusCode)
+	}
+
+	var res lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return errors.Wrap(err, "failed to decode LFS batch response")
+	}
+
+	for _, o := range res.Objects {
+		if o.Error != nil {
+			log15.Warn("LFS object not available", "dir", dir, "oid", o.OID, "code", o.Error.Code, "message", o.Error.Message)
+			continue
+		}
+		if o.Actions.Download == nil {
+			continue
+		}
+		if err := downloadLFSObject(ctx, dir, lfsPointer{OID: o.OID, Size: o.Size}, o.Actions.Download.Href, o.Actions.Download.Header); err != nil {
+			return errors.Wrapf(err, "failed to download LFS object %s", o.OID)
+		}
+		lfsObjectsFetched.Inc()
+	}
+	return nil
+}
+
+// downloadLFSObject downloads p from href into the LFS cache of dir. The
+// object is only added to the cache if its content matches p.
+func downloadLFSObject(ctx context.Context, dir GitDir, p lfsPointer, href string, header map[string]string) error {
+	if !validLFSOID(p.OID) {
+		return errors.New("invalid oid in LFS batch response")
+	}
+
+	req, err := http.NewRequest("GET", href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad HTTP response status %d", resp.StatusCode)
+	}
+
+	tmpDir := dir.Path("lfs", "tmp")
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(tmpDir, p.OID)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(resp.Body, p.Size+1))
+	if err != nil {
+		return err
+	}
+	if n != p.Size || hex.EncodeToString(h.Sum(nil)) != p.OID {
+		return errors.New("downloaded content does not match LFS pointer")
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	dst := lfsObjectPath(dir, p.OID)
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
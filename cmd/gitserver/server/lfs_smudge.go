@@ -0,0 +1,233 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// newLFSSmudgeWriter returns a writer which replaces the Git LFS pointer files
+// in the output of the git command args with their cached LFS objects. It
+// returns nil if the LFS objects of repo are not fetched, or if the command
+// does not output files.
+//
+// Smudged commands are git archive, and git show <commit>:<path> as sent by
+// git.ReadFile. The writer must be closed once the command is done.
+func newLFSSmudgeWriter(repo api.RepoName, dir GitDir, args []string, w io.Writer) io.WriteCloser {
+	if len(args) == 0 || lfsConfigForRepo(repo) == nil {
+		return nil
+	}
+
+	switch args[0] {
+	case "archive":
+		for _, arg := range args[1:] {
+			if format := strings.TrimPrefix(arg, "--format="); format != arg {
+				return newLFSArchiveWriter(dir, format, w)
+			}
+		}
+	case "show":
+		if len(args) == 2 && strings.Contains(args[1], ":") {
+			return &lfsBlobWriter{dir: dir, w: w}
+		}
+	}
+	return nil
+}
+
+// lfsBlobWriter smudges the content of a single file. Since pointer files are
+// small, it buffers small content until it is closed.
+type lfsBlobWriter struct {
+	dir GitDir
+	w   io.Writer
+
+	buf         bytes.Buffer
+	passthrough bool // content is too large to be a pointer
+}
+
+func (b *lfsBlobWriter) Write(p []byte) (int, error) {
+	if b.passthrough {
+		return b.w.Write(p)
+	}
+	b.buf.Write(p)
+	if b.buf.Len() <= lfsPointerMaxSize {
+		return len(p), nil
+	}
+
+	b.passthrough = true
+	if _, err := b.buf.WriteTo(b.w); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (b *lfsBlobWriter) Close() error {
+	if b.passthrough {
+		return nil
+	}
+	if f, _, ok := openLFSObject(b.dir, b.buf.Bytes()); ok {
+		defer f.Close()
+		_, err := io.Copy(b.w, f)
+		return err
+	}
+	_, err := b.buf.WriteTo(b.w)
+	return err
+}
+
+// lfsArchiveWriter smudges the files of a tar or zip archive.
+type lfsArchiveWriter struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+// newLFSArchiveWriter returns a writer which smudges an archive in format
+// written to it, and writes the result to w. It returns nil for formats other
+// than tar and zip.
+func newLFSArchiveWriter(dir GitDir, format string, w io.Writer) io.WriteCloser {
+	var smudge func(GitDir, io.Reader, io.Writer) error
+	switch format {
+	case "tar":
+		smudge = smudgeLFSTar
+	case "zip":
+		smudge = smudgeLFSZip
+	default:
+		return nil
+	}
+
+	r, pw := io.Pipe()
+	a := &lfsArchiveWriter{pw: pw, done: make(chan error, 1)}
+	go func() {
+		err := smudge(dir, r, w)
+		// Drain the input so that the command writing it does not block if
+		// we failed.
+		_, _ = io.Copy(ioutil.Discard, r)
+		a.done <- err
+	}()
+	return a
+}
+
+func (a *lfsArchiveWriter) Write(p []byte) (int, error) {
+	return a.pw.Write(p)
+}
+
+func (a *lfsArchiveWriter) Close() error {
+	a.pw.Close()
+	return <-a.done
+}
+
+// smudgeLFSTar copies the tar archive src to dst, replacing pointer files with
+// their cached LFS objects.
+func smudgeLFSTar(dir GitDir, src io.Reader, dst io.Writer) error {
+	tr := tar.NewReader(src)
+	tw := tar.NewWriter(dst)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read tar archive")
+		}
+
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > lfsPointerMaxSize {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return errors.Wrap(err, "failed to read tar archive")
+		}
+		if err := writeLFSTarFile(dir, tw, hdr, b); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// writeLFSTarFile writes the file with header hdr and content b to tw,
+// replacing b with its LFS object if it is a pointer file.
+func writeLFSTarFile(dir GitDir, tw *tar.Writer, hdr *tar.Header, b []byte) error {
+	var r io.Reader = bytes.NewReader(b)
+	if f, size, ok := openLFSObject(dir, b); ok {
+		defer f.Close()
+		r, hdr.Size = f, size
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, r)
+	return err
+}
+
+// smudgeLFSZip copies the zip archive src to dst, replacing pointer files with
+// their cached LFS objects. Since zip archives can't be read sequentially, src
+// is buffered in a temporary file.
+func smudgeLFSZip(dir GitDir, src io.Reader, dst io.Writer) error {
+	tmp, err := ioutil.TempFile("", "lfs-archive-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	n, err := io.Copy(tmp, src)
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(tmp, n)
+	if err != nil {
+		return errors.Wrap(err, "failed to read zip archive")
+	}
+
+	zw := zip.NewWriter(dst)
+	if err := zw.SetComment(zr.Comment); err != nil {
+		return err
+	}
+	for _, file := range zr.File {
+		if err := copyLFSZipFile(dir, zw, file); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func copyLFSZipFile(dir GitDir, zw *zip.Writer, file *zip.File) error {
+	rc, err := file.Open()
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s from zip archive", file.Name)
+	}
+	defer rc.Close()
+
+	hdr := file.FileHeader
+	var r io.Reader = rc
+	if !hdr.Mode().IsDir() && hdr.UncompressedSize64 <= lfsPointerMaxSize {
+		b, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s from zip archive", file.Name)
+		}
+		r = bytes.NewReader(b)
+		if f, size, ok := openLFSObject(dir, b); ok {
+			defer f.Close()
+			r, hdr.UncompressedSize64 = f, uint64(size)
+		}
+	}
+
+	w, err := zw.CreateHeader(&hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
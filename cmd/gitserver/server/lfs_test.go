@@ -0,0 +1,299 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	for name, tc := range map[string]struct {
+		content string
+		want    lfsPointer
+		ok      bool
+	}{
+		"pointer": {
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			want:    lfsPointer{OID: oid, Size: 12345},
+			ok:      true,
+		},
+		"not a pointer": {
+			content: "hello world\n",
+		},
+		"missing size": {
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+		"bad oid": {
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:../../config\nsize 1\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, ok := parseLFSPointer([]byte(tc.content))
+			if ok != tc.ok {
+				t.Fatalf("got ok %v, want %v", ok, tc.ok)
+			}
+			if ok && p != tc.want {
+				t.Errorf("got pointer %+v, want %+v", p, tc.want)
+			}
+		})
+	}
+}
+
+func TestLFSConfig(t *testing.T) {
+	c := buildLFSConfig(&schema.GitLFS{
+		Repos:   []string{"^github\\.com/foo/", "("},
+		Include: []string{"**/*.proto"},
+	})
+	if !c.enabled("github.com/foo/bar") || c.enabled("github.com/baz/foo") {
+		t.Error("unexpected repos matched")
+	}
+	if !c.includes("api/v1/service.proto") || c.includes("README.md") {
+		t.Error("unexpected paths included")
+	}
+	if c.maxFileSize != defaultLFSMaxFileSize {
+		t.Errorf("got maxFileSize %d, want default %d", c.maxFileSize, defaultLFSMaxFileSize)
+	}
+
+	if buildLFSConfig(nil).enabled("github.com/foo/bar") {
+		t.Error("expected LFS to be disabled without configuration")
+	}
+}
+
+// lfsObject returns the pointer file for content.
+func lfsObject(content string) (pointer string, oid string) {
+	h := sha256.Sum256([]byte(content))
+	oid = hex.EncodeToString(h[:])
+	return fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, len(content)), oid
+}
+
+func TestFetchLFSObjects(t *testing.T) {
+	protoPointer, protoOID := lfsObject("syntax = \"proto3\";\n")
+	bigPointer, _ := lfsObject(strings.Repeat("x", 100))
+	dataPointer, _ := lfsObject("fixture\n")
+	objects := map[string]string{protoOID: "syntax = \"proto3\";\n"}
+
+	var requested []string
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/foo/bar.git/info/lfs/objects/batch":
+			var req lfsBatchRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			var objs []interface{}
+			for _, o := range req.Objects {
+				requested = append(requested, o.OID)
+				objs = append(objs, map[string]interface{}{
+					"oid":  o.OID,
+					"size": o.Size,
+					"actions": map[string]interface{}{
+						"download": map[string]interface{}{
+							"href":   srv.URL + "/objects/" + o.OID,
+							"header": map[string]string{"Authorization": "secret"},
+						},
+					},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"objects": objs})
+
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/objects/"):
+			if r.Header.Get("Authorization") != "secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			_, _ = io.WriteString(w, objects[strings.TrimPrefix(r.URL.Path, "/objects/")])
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	for path, content := range map[string]string{
+		"api/service.proto": protoPointer,
+		"api/big.proto":     bigPointer,
+		"data/fixture.txt":  dataPointer,
+		"README.md":         "hello\n",
+	} {
+		if err := os.MkdirAll(filepath.Join(remote, filepath.Dir(path)), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(remote, path), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "lfs")
+	dir := GitDir(filepath.Join(remote, ".git"))
+
+	c := buildLFSConfig(&schema.GitLFS{
+		Repos:       []string{"."},
+		Include:     []string{"**/*.proto"},
+		MaxFileSize: 50,
+	})
+	if err := fetchLFSObjects(context.Background(), dir, srv.URL+"/foo/bar", c); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the included object within the size limit is fetched.
+	if diff := cmp.Diff([]string{protoOID}, requested); diff != "" {
+		t.Errorf("requested objects mismatch (-want +got):\n%s", diff)
+	}
+	got, err := ioutil.ReadFile(lfsObjectPath(dir, protoOID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != objects[protoOID] {
+		t.Errorf("got cached object %q, want %q", got, objects[protoOID])
+	}
+
+	// Cached objects are not fetched again.
+	requested = nil
+	if err := fetchLFSObjects(context.Background(), dir, srv.URL+"/foo/bar", c); err != nil {
+		t.Fatal(err)
+	}
+	if len(requested) != 0 {
+		t.Errorf("expected no objects to be requested, got %v", requested)
+	}
+}
+
+func TestLFSSmudgeWriter(t *testing.T) {
+	pointer, oid := lfsObject("the real content\n")
+	missingPointer, _ := lfsObject("not fetched\n")
+
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	for path, content := range map[string]string{
+		"lfs.txt":     pointer,
+		"missing.txt": missingPointer,
+		"plain.txt":   "plain\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(remote, path), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "lfs")
+	dir := GitDir(filepath.Join(remote, ".git"))
+
+	if err := os.MkdirAll(filepath.Dir(lfsObjectPath(dir, oid)), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(lfsObjectPath(dir, oid), []byte("the real content\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"lfs.txt":     "the real content\n",
+		"missing.txt": missingPointer,
+		"plain.txt":   "plain\n",
+	}
+
+	// smudge runs the git command args through w, like exec does.
+	smudge := func(t *testing.T, newWriter func(io.Writer) io.WriteCloser, args ...string) []byte {
+		t.Helper()
+		var out bytes.Buffer
+		w := newWriter(&out)
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		cmd.Stdout = w
+		if err := cmd.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return out.Bytes()
+	}
+
+	t.Run("show", func(t *testing.T) {
+		for path, content := range want {
+			got := smudge(t, func(w io.Writer) io.WriteCloser {
+				return &lfsBlobWriter{dir: dir, w: w}
+			}, "show", "HEAD:"+path)
+			if string(got) != content {
+				t.Errorf("got %s content %q, want %q", path, got, content)
+			}
+		}
+	})
+
+	t.Run("tar", func(t *testing.T) {
+		data := smudge(t, func(w io.Writer) io.WriteCloser {
+			return newLFSArchiveWriter(dir, "tar", w)
+		}, "archive", "--format=tar", "HEAD")
+
+		got := map[string]string{}
+		tr := tar.NewReader(bytes.NewReader(data))
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hdr.Typeflag != tar.TypeReg {
+				continue
+			}
+			b, err := ioutil.ReadAll(tr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[hdr.Name] = string(b)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("archive mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		data := smudge(t, func(w io.Writer) io.WriteCloser {
+			return newLFSArchiveWriter(dir, "zip", w)
+		}, "archive", "--format=zip", "-0", "HEAD")
+
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]string{}
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got[f.Name] = string(b)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("archive mismatch (-want +got):\n%s", diff)
+		}
+		if zr.Comment == "" {
+			t.Error("expected the commit ID in the archive comment to be kept")
+		}
+	})
+}
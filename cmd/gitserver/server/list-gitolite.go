@@ -9,7 +9,16 @@ import (
 )
 
 func (s *Server) handleListGitolite(w http.ResponseWriter, r *http.Request) {
-	defaultGitolite.listRepos(r.Context(), r.URL.Query().Get("gitolite"), w)
+	q := r.URL.Query()
+	if user := q.Get("user"); user != "" {
+		defaultGitolite.listReposForUser(r.Context(), q.Get("gitolite"), user, w)
+		return
+	}
+	defaultGitolite.listRepos(r.Context(), q.Get("gitolite"), w)
+}
+
+func (s *Server) handleListGitoliteUsers(w http.ResponseWriter, r *http.Request) {
+	defaultGitolite.listUsers(r.Context(), r.URL.Query().Get("gitolite"), w)
 }
 
 var defaultGitolite = gitoliteFetcher{client: gitoliteClient{}}
@@ -20,6 +29,8 @@ type gitoliteFetcher struct {
 
 type iGitoliteClient interface {
 	ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListReposForUser(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
+	ListUsers(ctx context.Context, host string) ([]string, error)
 }
 
 // listRepos lists the repos of a Gitolite server reachable at the address in gitoliteHost
@@ -42,8 +53,61 @@ func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost string, w h
 	}
 }
 
+// listReposForUser lists the repos of a Gitolite server reachable at the address in
+// gitoliteHost that the given Gitolite user can read.
+func (g gitoliteFetcher) listReposForUser(ctx context.Context, gitoliteHost, user string, w http.ResponseWriter) {
+	if gitoliteHost == "" {
+		http.Error(w, "missing gitolite host", http.StatusBadRequest)
+		return
+	}
+
+	repos, err := g.client.ListReposForUser(ctx, gitoliteHost, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if repos == nil {
+		repos = []*gitolite.Repo{}
+	}
+
+	if err = json.NewEncoder(w).Encode(repos); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// listUsers lists the users of a Gitolite server reachable at the address in gitoliteHost.
+func (g gitoliteFetcher) listUsers(ctx context.Context, gitoliteHost string, w http.ResponseWriter) {
+	if gitoliteHost == "" {
+		http.Error(w, "missing gitolite host", http.StatusBadRequest)
+		return
+	}
+
+	users, err := g.client.ListUsers(ctx, gitoliteHost)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if users == nil {
+		users = []string{}
+	}
+
+	if err = json.NewEncoder(w).Encode(users); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 type gitoliteClient struct{}
 
 func (c gitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
 	return gitolite.NewClient(host).ListRepos(ctx)
 }
+
+func (c gitoliteClient) ListReposForUser(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	return gitolite.NewClient(host).ListReposForUser(ctx, user)
+}
+
+func (c gitoliteClient) ListUsers(ctx context.Context, host string) ([]string, error) {
+	return gitolite.NewClient(host).ListUsers(ctx)
+}
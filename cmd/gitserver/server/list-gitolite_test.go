@@ -63,10 +63,98 @@ func Test_Gitolite_listRepos(t *testing.T) {
 	}
 }
 
+func Test_Gitolite_listReposForUser(t *testing.T) {
+	g := gitoliteFetcher{
+		client: stubGitoliteClient{
+			ListReposForUser_: func(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+				if user == "alice" {
+					return []*gitolite.Repo{{Name: "myrepo", URL: host + ":myrepo"}}, nil
+				}
+				return nil, nil
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		user            string
+		gitoliteHost    string
+		expResponseCode int
+		expResponseBody string
+	}{
+		{
+			user:            "alice",
+			gitoliteHost:    "git@gitolite.example.com",
+			expResponseCode: 200,
+			expResponseBody: `[{"Name":"myrepo","URL":"git@gitolite.example.com:myrepo"}]` + "\n",
+		},
+		{
+			user:            "bob",
+			gitoliteHost:    "git@gitolite.example.com",
+			expResponseCode: 200,
+			expResponseBody: "[]\n",
+		},
+		{
+			user:            "alice",
+			expResponseCode: 400,
+			expResponseBody: "missing gitolite host\n",
+		},
+	} {
+		t.Run(test.user, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			g.listReposForUser(context.Background(), test.gitoliteHost, test.user, w)
+			resp := w.Result()
+			respBody, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.expResponseBody, string(respBody)); diff != "" {
+				t.Errorf("unexpected response body diff:\n%s", diff)
+			}
+			if diff := cmp.Diff(test.expResponseCode, resp.StatusCode); diff != "" {
+				t.Errorf("unexpected response code diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_Gitolite_listUsers(t *testing.T) {
+	g := gitoliteFetcher{
+		client: stubGitoliteClient{
+			ListUsers_: func(ctx context.Context, host string) ([]string, error) {
+				return []string{"admin", "alice"}, nil
+			},
+		},
+	}
+
+	w := httptest.NewRecorder()
+	g.listUsers(context.Background(), "git@gitolite.example.com", w)
+	resp := w.Result()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(`["admin","alice"]`+"\n", string(respBody)); diff != "" {
+		t.Errorf("unexpected response body diff:\n%s", diff)
+	}
+	if diff := cmp.Diff(200, resp.StatusCode); diff != "" {
+		t.Errorf("unexpected response code diff:\n%s", diff)
+	}
+}
+
 type stubGitoliteClient struct {
-	ListRepos_ func(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListRepos_        func(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListReposForUser_ func(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
+	ListUsers_        func(ctx context.Context, host string) ([]string, error)
 }
 
 func (c stubGitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
 	return c.ListRepos_(ctx, host)
 }
+
+func (c stubGitoliteClient) ListReposForUser(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	return c.ListReposForUser_(ctx, host, user)
+}
+
+func (c stubGitoliteClient) ListUsers(ctx context.Context, host string) ([]string, error) {
+	return c.ListUsers_(ctx, host)
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+var (
+	reposMigrated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_migrated",
+		Help: "number of repos transferred to the gitserver which now owns them",
+	})
+	reposMigrateFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_migrate_failed",
+		Help: "number of failed attempts to transfer a repo to the gitserver which now owns it",
+	})
+)
+
+// migrateRepos transfers every repository in s.ReposDir which is owned by
+// another gitserver to its owner, and then removes the local copy. This
+// happens when gitserver replicas are added or removed.
+//
+// The owner clones the repository from this gitserver rather than from the
+// code host. The local copy is only removed once the owner reports a
+// complete clone, so a repository is never unavailable on both gitservers.
+//
+// If repositories are replicated, repositories for which this gitserver is a
+// replica are kept.
+func (s *Server) migrateRepos() {
+	if s.Hostname == "" || s.GitServerAddrs == nil {
+		return
+	}
+
+	addrs := s.GitServerAddrs()
+	if !containsAddr(addrs, s.Hostname) {
+		// If we are not in the list we would consider every repository to be
+		// owned by someone else. This is most likely a misconfiguration, so
+		// rather do nothing than move everything away.
+		log15.Warn("migrate: gitserver address not in list of gitservers, skipping migration", "hostname", s.Hostname, "addrs", addrs)
+		return
+	}
+
+	ctx, cancel := s.serverContext()
+	defer cancel()
+
+	dirs, err := s.findGitDirs()
+	if err != nil {
+		log15.Error("migrate: error finding repositories", "error", err)
+		return
+	}
+
+	for _, dir := range dirs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		repo := s.name(dir)
+		owner := gitserver.AddrForKey(string(repo), addrs)
+		if owner == s.Hostname || containsAddr(s.replicaSet(repo, addrs), s.Hostname) {
+			continue
+		}
+
+		// Do not transfer a repository we are busy cloning. We will pick it
+		// up on the next run.
+		if _, cloneInProgress := s.locker.Status(dir); cloneInProgress {
+			continue
+		}
+
+		if err := s.migrateRepo(ctx, repo, dir, owner); err != nil {
+			log15.Error("migrate: failed to transfer repo", "repo", repo, "owner", owner, "error", err)
+			reposMigrateFailed.Inc()
+			continue
+		}
+		log15.Info("migrate: transferred repo", "repo", repo, "owner", owner)
+		reposMigrated.Inc()
+	}
+}
+
+// migrateRepo asks owner to clone repo from us, and removes dir once owner
+// has a complete copy.
+func (s *Server) migrateRepo(ctx context.Context, repo api.RepoName, dir GitDir, owner string) error {
+	remoteURL, err := repoRemoteURL(ctx, dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to get remote URL")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, longGitCommandTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(&protocol.RepoMigrateRequest{
+		Repo:   repo,
+		URL:    remoteURL,
+		Source: s.Hostname,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "http://"+owner+"/repo-migrate", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("repo-migrate: bad HTTP response status %d", resp.StatusCode)
+	}
+
+	var res protocol.RepoMigrateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+	if !res.Cloned {
+		return errors.New("owner does not have a complete clone yet")
+	}
+
+	return s.removeRepoDirectory(dir)
+}
+
+// handleRepoMigrate clones a repository from the gitserver which previously
+// owned it. It blocks until the clone is done.
+func (s *Server) handleRepoMigrate(w http.ResponseWriter, r *http.Request) {
+	var req protocol.RepoMigrateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Source == "" || req.URL == "" {
+		http.Error(w, "source and url are required", http.StatusBadRequest)
+		return
+	}
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+	dir := s.dir(req.Repo)
+
+	var resp protocol.RepoMigrateResponse
+	if !repoCloned(dir) {
+		// Like repo-update, we do not want to cancel the clone partway
+		// through if the request terminates.
+		ctx, cancel1 := s.serverContext()
+		defer cancel1()
+		ctx, cancel2 := context.WithTimeout(ctx, longGitCommandTimeout)
+		defer cancel2()
+
+		_, err := s.cloneRepo(ctx, req.Repo, req.URL, &cloneOptions{
+			Block:     true,
+			SourceURL: "http://" + req.Source + "/git/" + string(req.Repo),
+		})
+		if err != nil {
+			log15.Warn("error cloning repo from previous owner", "repo", req.Repo, "source", req.Source, "err", err)
+			resp.Error = err.Error()
+		}
+	}
+	resp.Cloned = repoCloned(dir)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func containsAddr(addrs []string, addr string) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
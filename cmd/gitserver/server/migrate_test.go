@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestMigrateRepos(t *testing.T) {
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	runCmd(t, remote, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, remote, "git", "add", "hello.txt")
+	runCmd(t, remote, "git", "commit", "-m", "hello")
+	wantCommit := runCmd(t, remote, "git", "rev-parse", "HEAD")
+
+	newServer := func() (*Server, string) {
+		s := &Server{ReposDir: tmpDir(t)}
+		srv := httptest.NewServer(s.Handler())
+		t.Cleanup(srv.Close)
+		u, _ := url.Parse(srv.URL)
+		return s, u.Host
+	}
+	src, srcAddr := newServer()
+	dst, dstAddr := newServer()
+	addrs := []string{srcAddr, dstAddr}
+
+	// Find a repository name which is owned by dst.
+	var repo api.RepoName
+	for i := 0; repo == ""; i++ {
+		name := fmt.Sprintf("example.com/foo/bar%d", i)
+		if gitserver.AddrForKey(name, addrs) == dstAddr {
+			repo = api.RepoName(name)
+		}
+	}
+
+	if _, err := src.cloneRepo(context.Background(), repo, remote, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without knowing our own address nothing is migrated.
+	src.migrateRepos()
+	if !repoCloned(src.dir(repo)) {
+		t.Fatal("expected repo to not be migrated without Hostname")
+	}
+
+	// If we are not a known gitserver nothing is migrated.
+	src.Hostname = "unknown:3178"
+	src.GitServerAddrs = func() []string { return addrs }
+	src.migrateRepos()
+	if !repoCloned(src.dir(repo)) {
+		t.Fatal("expected repo to not be migrated when Hostname is not in the list of gitservers")
+	}
+
+	src.Hostname = srcAddr
+	src.migrateRepos()
+
+	if _, err := os.Stat(string(src.dir(repo))); !os.IsNotExist(err) {
+		t.Fatalf("expected repo to be removed from previous owner: %v", err)
+	}
+	dir := filepath.Dir(string(dst.dir(repo)))
+	if got := runCmd(t, dir, "git", "rev-parse", "HEAD"); got != wantCommit {
+		t.Fatalf("got commit %q on new owner, want %q", got, wantCommit)
+	}
+	if got := strings.TrimSpace(runCmd(t, dir, "git", "remote", "get-url", "origin")); got != remote {
+		t.Fatalf("got remote URL %q on new owner, want %q", got, remote)
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -242,12 +243,22 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 	}
 
 	if req.Push {
-		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL, fmt.Sprintf("%s:%s", cmtHash, ref))
+		pushRef := ref
+		if req.PushRef != "" {
+			pushRef = req.PushRef
+		}
+
+		cmd = exec.CommandContext(ctx, "git", "push", "--force", remoteURL, fmt.Sprintf("%s:%s", cmtHash, pushRef))
 		cmd.Dir = repoGitDir
 
 		if out, err = run(cmd, "pushing ref"); err != nil {
-			log15.Error("Failed to push", "ref", ref, "commit", cmtHash, "output", string(out))
-			return http.StatusInternalServerError, resp
+			// Gerrit rejects pushing a commit that is already the current
+			// patch set of a change, which happens when we retry publishing.
+			if req.PushRef == "" || !bytes.Contains(out, []byte("(no new changes)")) {
+				log15.Error("Failed to push", "ref", pushRef, "commit", cmtHash, "output", string(out))
+				return http.StatusInternalServerError, resp
+			}
+			resp.Error = nil
 		}
 	}
 
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
+)
+
+// Perforce depots are converted into Git repositories with git p4, which imports the
+// changelists of a depot into refs/remotes/p4/master. Incremental imports start from the
+// changelist recorded in the last commit of that ref, so it must not be rewritten.
+const p4ImportRef = "refs/remotes/p4/master"
+
+// p4ClientFromCloneURL returns a client for the Perforce server of the given clone URL, as
+// returned by perforce.CloneURL, and the depot path of the clone URL.
+func p4ClientFromCloneURL(cloneURL string) (*perforce.Client, string, error) {
+	port, user, password, depot, err := perforce.ParseCloneURL(cloneURL)
+	if err != nil {
+		return nil, "", err
+	}
+	return perforce.NewClient(port, user, password), depot, nil
+}
+
+// isP4Cloneable checks that the depot of the given clone URL exists and that its changelists
+// can be read with the credentials of the clone URL.
+func isP4Cloneable(ctx context.Context, cloneURL string) error {
+	cli, depot, err := p4ClientFromCloneURL(cloneURL)
+	if err != nil {
+		return err
+	}
+	_, err = cli.Changes(ctx, depot, 1)
+	return err
+}
+
+// p4CloneCmd returns the command that converts the depot of the given clone URL into a bare
+// Git repository at dir, importing all changelists of the depot. git p4 creates the master
+// branch from the imported changelists.
+func p4CloneCmd(ctx context.Context, cloneURL, dir string) (*exec.Cmd, error) {
+	cli, depot, err := p4ClientFromCloneURL(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "p4", "clone", "--bare", depot+"@all", dir)
+	cmd.Env = cli.Env()
+	return cmd, nil
+}
+
+// p4FetchCmd returns the command that imports the changelists submitted to the depot of the
+// given clone URL since the last import. The depot path isn't passed to git p4, because it
+// would then import the head revision of the depot instead of the new changelists.
+func p4FetchCmd(ctx context.Context, cloneURL string) (*exec.Cmd, error) {
+	cli, _, err := p4ClientFromCloneURL(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "p4", "sync", "--branch="+p4ImportRef)
+	cmd.Env = cli.Env()
+	return cmd, nil
+}
+
+// updateP4Master points the master branch of the repository at dir to the last changelist
+// imported by git p4.
+func updateP4Master(ctx context.Context, dir GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "update-ref", "refs/heads/master", p4ImportRef)
+	dir.Set(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "failed to update master branch. Output: %s", string(output))
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+// replicaReconcileInterval is how often the owner of a repository checks
+// that its replicas are up to date, in addition to pushing every update.
+const replicaReconcileInterval = time.Hour
+
+var (
+	reposReplicated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_replicated",
+		Help: "number of repo updates pushed to a replica",
+	})
+	reposReplicateFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_replicate_failed",
+		Help: "number of failed attempts to push a repo update to a replica",
+	})
+)
+
+// replicaSet returns the addresses of the gitservers which store repo,
+// starting with its owner. It returns nil if replication is disabled, or if
+// this gitserver does not know its own address or is not in addrs.
+func (s *Server) replicaSet(repo api.RepoName, addrs []string) []string {
+	if s.Hostname == "" || s.ReplicationFactor == nil {
+		return nil
+	}
+	n := s.ReplicationFactor()
+	if n < 2 || !containsAddr(addrs, s.Hostname) {
+		return nil
+	}
+	return gitserver.AddrsForKey(string(repo), addrs, n)
+}
+
+// gitServerAddrs returns the addresses of all gitservers, or nil if they are
+// unknown.
+func (s *Server) gitServerAddrs() []string {
+	if s.GitServerAddrs == nil {
+		return nil
+	}
+	return s.GitServerAddrs()
+}
+
+// isReplica returns whether this gitserver stores a replica of repo, as
+// opposed to owning it. Replicas receive updates from the owner, and never
+// clone from the code host themselves.
+func (s *Server) isReplica(repo api.RepoName) bool {
+	set := s.replicaSet(repo, s.gitServerAddrs())
+	return len(set) > 0 && set[0] != s.Hostname && containsAddr(set, s.Hostname)
+}
+
+// replicateRepo pushes repo to its replicas in the background if this
+// gitserver owns it. It is called after repo was cloned or updated.
+func (s *Server) replicateRepo(repo api.RepoName) {
+	set := s.replicaSet(repo, s.gitServerAddrs())
+	if len(set) < 2 || set[0] != s.Hostname {
+		return
+	}
+
+	go func() {
+		ctx, cancel := s.serverContext()
+		defer cancel()
+
+		if err := s.sendReplicas(ctx, repo, set[1:]); err != nil {
+			log15.Warn("replicate: failed to update replicas", "repo", repo, "error", err)
+		}
+	}()
+}
+
+// sendReplicas asks each of replicas to fetch repo from us unless their copy
+// has the same refs as ours.
+func (s *Server) sendReplicas(ctx context.Context, repo api.RepoName, replicas []string) error {
+	dir := s.dir(repo)
+	remoteURL, err := repoRemoteURL(ctx, dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to get remote URL")
+	}
+	refHash, err := computeRefHash(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute ref hash")
+	}
+
+	req := &protocol.RepoReplicateRequest{
+		Repo:    repo,
+		URL:     remoteURL,
+		Source:  s.Hostname,
+		RefHash: string(refHash),
+	}
+	var failed []string
+	for _, addr := range replicas {
+		if err := sendReplicateRequest(ctx, addr, req); err != nil {
+			log15.Warn("replicate: failed to update replica", "repo", repo, "replica", addr, "error", err)
+			reposReplicateFailed.Inc()
+			failed = append(failed, addr)
+			continue
+		}
+		reposReplicated.Inc()
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update replicas %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func sendReplicateRequest(ctx context.Context, addr string, req *protocol.RepoReplicateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, longGitCommandTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	r, err := http.NewRequest("POST", "http://"+addr+"/repo-replicate", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(r.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("repo-replicate: bad HTTP response status %d", resp.StatusCode)
+	}
+
+	var res protocol.RepoReplicateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+	return nil
+}
+
+// handleRepoReplicate updates the local replica of a repository from the
+// gitserver which owns it. It blocks until the replica is up to date.
+func (s *Server) handleRepoReplicate(w http.ResponseWriter, r *http.Request) {
+	var req protocol.RepoReplicateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Source == "" || req.URL == "" {
+		http.Error(w, "source and url are required", http.StatusBadRequest)
+		return
+	}
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+
+	// Like repo-update, we do not want to cancel the update partway through
+	// if the request terminates.
+	ctx, cancel1 := s.serverContext()
+	defer cancel1()
+	ctx, cancel2 := context.WithTimeout(ctx, longGitCommandTimeout)
+	defer cancel2()
+
+	var resp protocol.RepoReplicateResponse
+	if err := s.replicateFrom(ctx, &req); err != nil {
+		log15.Warn("error updating replica from owner", "repo", req.Repo, "source", req.Source, "err", err)
+		resp.Error = err.Error()
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// replicateFrom clones or fetches req.Repo from req.Source.
+func (s *Server) replicateFrom(ctx context.Context, req *protocol.RepoReplicateRequest) error {
+	dir := s.dir(req.Repo)
+	sourceURL := "http://" + req.Source + "/git/" + string(req.Repo)
+
+	if !repoCloned(dir) {
+		_, err := s.cloneRepo(ctx, req.Repo, req.URL, &cloneOptions{
+			Block:     true,
+			SourceURL: sourceURL,
+		})
+		return err
+	}
+
+	if refHash, err := computeRefHash(dir); err == nil && string(refHash) == req.RefHash {
+		return nil
+	}
+
+	ctx, cancel, err := s.acquireCloneLimiter(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	defer s.cleanTmpFiles(dir)
+
+	// The owner already filtered the refs it fetched from the code host, so
+	// we mirror all of them.
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--prune", sourceURL, "+refs/*:refs/*")
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch from owner. Output: %s", string(output))
+	}
+
+	// Use the same default branch as the owner.
+	cmd = exec.CommandContext(ctx, "git", "ls-remote", "--symref", sourceURL, "HEAD")
+	dir.Set(cmd)
+	output, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get HEAD of owner. Output: %s", string(output))
+	}
+	if head := parseSymrefHead(output); head != "" {
+		cmd = exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD", head)
+		dir.Set(cmd)
+		if output, err := runWith(ctx, cmd, false, nil); err != nil {
+			return errors.Wrapf(err, "failed to set HEAD. Output: %s", string(output))
+		}
+	}
+
+	removeBadRefs(ctx, dir)
+
+	// Update the last-changed stamp.
+	return setLastChanged(dir)
+}
+
+// parseSymrefHead returns the ref HEAD points to in the output of
+// `git ls-remote --symref <url> HEAD`, or the empty string if HEAD is
+// detached.
+func parseSymrefHead(output []byte) string {
+	for _, line := range strings.Split(string(output), "\n") {
+		if !strings.HasPrefix(line, "ref: ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "ref: "))
+		if len(fields) == 2 && fields[1] == "HEAD" {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// maybeReconcileReplicas pushes repo to its replicas if this gitserver owns
+// it and they have not been checked for a while. This repairs replicas which
+// missed updates, or were added after the repository was last updated.
+func (s *Server) maybeReconcileReplicas(ctx context.Context, dir GitDir, addrs []string) error {
+	repo := s.name(dir)
+	set := s.replicaSet(repo, addrs)
+	if len(set) < 2 || set[0] != s.Hostname {
+		return nil
+	}
+
+	reconciled, err := getReplicaReconcileTime(dir)
+	if err != nil {
+		return err
+	}
+	// Add a jitter to spread out the requests for repos cloned at the same
+	// time.
+	if time.Since(reconciled) < replicaReconcileInterval+jitterDuration(string(dir), replicaReconcileInterval/4) {
+		return nil
+	}
+
+	// Update the time first, so that we back off if a replica is down.
+	if err := gitConfigSet(dir, "sourcegraph.replicaReconcileTimestamp", strconv.FormatInt(time.Now().Unix(), 10)); err != nil {
+		return errors.Wrap(err, "failed to update replicaReconcileTimestamp")
+	}
+	return s.sendReplicas(ctx, repo, set[1:])
+}
+
+// getReplicaReconcileTime returns the time the replicas of the repository in
+// dir were last reconciled, or the zero time if they never were.
+func getReplicaReconcileTime(dir GitDir) (time.Time, error) {
+	value, err := gitConfigGet(dir, "sourcegraph.replicaReconcileTimestamp")
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to determine replica reconcile timestamp")
+	}
+	sec, err := strconv.ParseInt(strings.TrimSpace(value), 10, 0)
+	if err != nil {
+		// Missing or bad values mean we should reconcile now.
+		return time.Time{}, nil
+	}
+	return time.Unix(sec, 0), nil
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+func TestReplicateRepos(t *testing.T) {
+	remote := tmpDir(t)
+	runCmd(t, remote, "git", "init", ".")
+	runCmd(t, remote, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, remote, "git", "add", "hello.txt")
+	runCmd(t, remote, "git", "commit", "-m", "hello")
+
+	newServer := func() (*Server, string) {
+		s := &Server{ReposDir: tmpDir(t)}
+		srv := httptest.NewServer(s.Handler())
+		t.Cleanup(srv.Close)
+		u, _ := url.Parse(srv.URL)
+		return s, u.Host
+	}
+	owner, ownerAddr := newServer()
+	replica, replicaAddr := newServer()
+	addrs := []string{ownerAddr, replicaAddr}
+
+	// Find a repository name which is owned by owner.
+	var repo api.RepoName
+	for i := 0; repo == ""; i++ {
+		name := fmt.Sprintf("example.com/foo/bar%d", i)
+		if gitserver.AddrForKey(name, addrs) == ownerAddr {
+			repo = api.RepoName(name)
+		}
+	}
+
+	// Clone before enabling replication, so that we control when replicas
+	// are updated.
+	if _, err := owner.cloneRepo(context.Background(), repo, remote, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []*Server{owner, replica} {
+		s.GitServerAddrs = func() []string { return addrs }
+		s.ReplicationFactor = func() int { return 2 }
+	}
+	owner.Hostname = ownerAddr
+	replica.Hostname = replicaAddr
+
+	if owner.isReplica(repo) || !replica.isReplica(repo) {
+		t.Fatal("expected only replica to be a replica of repo")
+	}
+
+	// Replicas do not clone from the code host on demand.
+	resp, err := http.Post("http://"+replicaAddr+"/exec", "application/json", strings.NewReader(
+		fmt.Sprintf(`{"repo": %q, "url": %q, "args": ["rev-parse", "HEAD"]}`, repo, remote)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("got status %d from replica exec, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if _, cloneInProgress := replica.locker.Status(replica.dir(repo)); cloneInProgress || repoCloned(replica.dir(repo)) {
+		t.Fatal("expected replica to not clone repo")
+	}
+
+	// Reconciling clones missing replicas from the owner.
+	ctx := context.Background()
+	if err := owner.maybeReconcileReplicas(ctx, owner.dir(repo), addrs); err != nil {
+		t.Fatal(err)
+	}
+	ownerDir := filepath.Dir(string(owner.dir(repo)))
+	replicaDir := filepath.Dir(string(replica.dir(repo)))
+	assertSameHead := func() {
+		t.Helper()
+		want := runCmd(t, ownerDir, "git", "rev-parse", "HEAD")
+		if got := runCmd(t, replicaDir, "git", "rev-parse", "HEAD"); got != want {
+			t.Fatalf("got commit %q on replica, want %q", got, want)
+		}
+	}
+	assertSameHead()
+	if got := strings.TrimSpace(runCmd(t, replicaDir, "git", "remote", "get-url", "origin")); got != remote {
+		t.Fatalf("got remote URL %q on replica, want %q", got, remote)
+	}
+
+	// Update the owner behind the replica's back.
+	runCmd(t, remote, "sh", "-c", "echo bye > hello.txt")
+	runCmd(t, remote, "git", "commit", "-am", "bye")
+	runCmd(t, ownerDir, "git", "fetch", remote, "+refs/heads/*:refs/heads/*")
+
+	// Reconciling is throttled.
+	if err := owner.maybeReconcileReplicas(ctx, owner.dir(repo), addrs); err != nil {
+		t.Fatal(err)
+	}
+	if runCmd(t, replicaDir, "git", "rev-parse", "HEAD") == runCmd(t, ownerDir, "git", "rev-parse", "HEAD") {
+		t.Fatal("expected recently reconciled replica to not be updated")
+	}
+
+	// Pushing updates the replica.
+	if err := owner.sendReplicas(ctx, repo, []string{replicaAddr}); err != nil {
+		t.Fatal(err)
+	}
+	assertSameHead()
+
+	// Replicas are not migrated to the owner.
+	replica.migrateRepos()
+	if !repoCloned(replica.dir(repo)) {
+		t.Fatal("expected replica to keep its copy of repo")
+	}
+}
+
+func TestParseSymrefHead(t *testing.T) {
+	for output, want := range map[string]string{
+		"ref: refs/heads/main\tHEAD\n0123456789abcdef0123456789abcdef01234567\tHEAD\n": "refs/heads/main",
+		"0123456789abcdef0123456789abcdef01234567\tHEAD\n":                             "",
+		"": "",
+	} {
+		if got := parseSymrefHead([]byte(output)); got != want {
+			t.Errorf("parseSymrefHead(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -117,6 +118,20 @@ type Server struct {
 	// DiskSizer tells how much disk is free and how large the disk is.
 	DiskSizer DiskSizer
 
+	// Hostname is the address of this gitserver as it appears in the list of
+	// gitserver addresses. If set, repositories which are owned by another
+	// gitserver are migrated to their owner by the Janitor.
+	Hostname string
+
+	// GitServerAddrs returns the addresses of all gitservers. It is only
+	// used if Hostname is set.
+	GitServerAddrs func() []string
+
+	// ReplicationFactor returns the number of gitservers each repository is
+	// stored on. If it is nil or returns less than 2, repositories are not
+	// replicated. It is only used if Hostname is set.
+	ReplicationFactor func() int
+
 	// skipCloneForTests is set by tests to avoid clones.
 	skipCloneForTests bool
 
@@ -139,6 +154,10 @@ type Server struct {
 
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
+
+	// migrating is 1 while migrateRepos is running. It must be accessed
+	// atomically.
+	migrating int32
 }
 
 type locks struct {
@@ -228,6 +247,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/exec", s.handleExec)
 	mux.HandleFunc("/list", s.handleList)
 	mux.HandleFunc("/list-gitolite", s.handleListGitolite)
+	mux.HandleFunc("/list-gitolite-users", s.handleListGitoliteUsers)
 	mux.HandleFunc("/is-repo-cloneable", s.handleIsRepoCloneable)
 	mux.HandleFunc("/is-repo-cloned", s.handleIsRepoCloned)
 	mux.HandleFunc("/repos", s.handleRepoInfo)
@@ -235,6 +255,8 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-clone-progress", s.handleRepoCloneProgress)
 	mux.HandleFunc("/delete", s.handleRepoDelete)
 	mux.HandleFunc("/repo-update", s.handleRepoUpdate)
+	mux.HandleFunc("/repo-migrate", s.handleRepoMigrate)
+	mux.HandleFunc("/repo-replicate", s.handleRepoReplicate)
 	mux.HandleFunc("/getGitolitePhabricatorMetadata", s.handleGetGitolitePhabricatorMetadata)
 	mux.HandleFunc("/create-commit-from-patch", s.handleCreateCommitFromPatch)
 	mux.HandleFunc("/ping", func(w http.ResponseWriter, _ *http.Request) {
@@ -251,6 +273,16 @@ func (s *Server) Handler() http.Handler {
 // Janitor does clean up tasks over s.ReposDir.
 func (s *Server) Janitor() {
 	s.cleanupRepos()
+
+	// Transferring repositories can take much longer than a Janitor run, so
+	// it happens in the background. A run is skipped if the previous one is
+	// still in progress.
+	if atomic.CompareAndSwapInt32(&s.migrating, 0, 1) {
+		go func() {
+			defer atomic.StoreInt32(&s.migrating, 0)
+			s.migrateRepos()
+		}()
+	}
 }
 
 // Stop cancels the running background jobs and returns when done.
@@ -639,7 +671,9 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 			return
 		}
 
-		if req.URL == "" {
+		// Replicas only receive repositories from their owner. Clients
+		// only ask us if the owner failed, so there is no point in cloning.
+		if req.URL == "" || s.isReplica(req.Repo) {
 			status = "repo-not-found"
 			w.WriteHeader(http.StatusNotFound)
 			_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{CloneInProgress: false})
@@ -699,8 +733,14 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 		}
 	}
 
+	var stdout io.Writer = w
+	lfsW := newLFSSmudgeWriter(req.Repo, dir, req.Args, w)
+	if lfsW != nil {
+		stdout = lfsW
+	}
+
 	var stderrBuf bytes.Buffer
-	stdoutW := &writeCounter{w: w}
+	stdoutW := &writeCounter{w: stdout}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
 
 	cmdStart = time.Now()
@@ -710,6 +750,11 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	cmd.Stderr = stderrW
 
 	exitStatus, execErr = runCommand(ctx, cmd)
+	if lfsW != nil {
+		if err := lfsW.Close(); err != nil && execErr == nil {
+			execErr = errors.Wrap(err, "failed to replace LFS pointer files")
+		}
+	}
 
 	status = strconv.Itoa(exitStatus)
 	stdoutN = stdoutW.n
@@ -756,6 +801,11 @@ type cloneOptions struct {
 
 	// Overwrite will overwrite the existing clone.
 	Overwrite bool
+
+	// SourceURL, if set, is cloned from instead of the remote URL. The
+	// resulting clone still has the remote URL as its origin. This is used
+	// to copy a repository from another gitserver.
+	SourceURL string
 }
 
 // cloneRepo issues a git clone command for the given repo. It is
@@ -784,7 +834,11 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		return "", err // err will be a context error
 	}
 	defer cancel()
-	if err := s.isCloneable(ctx, url); err != nil {
+	cloneURL := url
+	if opts != nil && opts.SourceURL != "" {
+		cloneURL = opts.SourceURL
+	}
+	if err := s.isCloneable(ctx, cloneURL); err != nil {
 		return "", fmt.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactor.redact(err.Error()))
 	}
 
@@ -840,26 +894,46 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 		tmp := GitDir(tmpPath)
 
 		var cmd *exec.Cmd
-		if useRefspecOverrides() {
-			cmd, err = refspecOverridesCloneCmd(ctx, url, tmpPath)
+		isP4 := perforce.IsCloneURL(cloneURL)
+		if isP4 {
+			cmd, err = p4CloneCmd(ctx, cloneURL, tmpPath)
+			if err != nil {
+				return err
+			}
+		} else if useRefspecOverrides() {
+			cmd, err = refspecOverridesCloneCmd(ctx, cloneURL, tmpPath)
 			if err != nil {
 				return err
 			}
 		} else {
-			cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
+			cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", cloneURL, tmpPath)
+		}
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
 		}
 		// see issue #7322: skip LFS content in repositories with Git LFS configured
-		cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
 		log15.Info("cloning repo", "repo", repo, "tmp", tmpPath, "dst", dstPath)
 
 		pr, pw := io.Pipe()
 		defer pw.Close()
 		go readCloneProgress(redactor, lock, pr)
 
-		if output, err := runWithRemoteOpts(ctx, cmd, pw); err != nil {
+		// git p4 talks to the Perforce server with p4, so the Git remote options don't apply.
+		if output, err := runWith(ctx, cmd, !isP4, pw); err != nil {
 			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
 		}
 
+		if cloneURL != url {
+			// Point origin back at the code host, so that future fetches do
+			// not go via the gitserver we copied from.
+			cmd := exec.CommandContext(ctx, "git", "remote", "set-url", "origin", url)
+			tmp.Set(cmd)
+			if output, err := runWith(ctx, cmd, false, nil); err != nil {
+				return errors.Wrapf(err, "failed to set remote URL. Output: %s", string(output))
+			}
+		}
+
 		removeBadRefs(ctx, tmp)
 
 		// Update the last-changed stamp.
@@ -887,8 +961,13 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 			return err
 		}
 
+		if !perforce.IsCloneURL(url) {
+			maybeFetchLFSObjects(ctx, repo, dir, url)
+		}
+
 		log15.Info("repo cloned", "repo", repo)
 		repoClonedCounter.Inc()
+		s.replicateRepo(repo)
 
 		return nil
 	}
@@ -948,6 +1027,12 @@ type urlRedactor struct {
 // credentials found in rawurl, and the rawurl itself.
 func newURLRedactor(rawurl string) *urlRedactor {
 	var sensitive []string
+	if perforce.IsCloneURL(rawurl) {
+		// Perforce clone URLs can't be parsed by net/url.
+		if _, _, pw, _, err := perforce.ParseCloneURL(rawurl); err == nil && pw != "" {
+			sensitive = append(sensitive, pw)
+		}
+	}
 	parsedURL, _ := url.Parse(rawurl)
 	if parsedURL != nil {
 		pw, _ := parsedURL.User.Password()
@@ -1021,6 +1106,10 @@ func (s *Server) isCloneable(ctx context.Context, url string) error {
 		return testRepoExists(ctx, url)
 	}
 
+	if perforce.IsCloneURL(url) {
+		return isP4Cloneable(ctx, url)
+	}
+
 	cmd := exec.CommandContext(ctx, "git", args...)
 	out, err := runWithRemoteOpts(ctx, cmd, nil)
 	if err != nil {
@@ -1118,6 +1207,9 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, url string
 			s.repoUpdateLocksMu.Unlock()
 
 			err = s.doRepoUpdate2(repo, url)
+			if err == nil {
+				s.replicateRepo(repo)
+			}
 		})
 	}()
 
@@ -1339,8 +1431,15 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 	}
 
 	configRemoteOpts := true
+	isP4 := perforce.IsCloneURL(url)
 	var cmd *exec.Cmd
-	if customCmd := customFetchCmd(ctx, url); customCmd != nil {
+	if isP4 {
+		cmd, err = p4FetchCmd(ctx, url)
+		if err != nil {
+			return err
+		}
+		configRemoteOpts = false
+	} else if customCmd := customFetchCmd(ctx, url); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
 	} else if useRefspecOverrides() {
@@ -1371,6 +1470,13 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 		return errors.Wrap(err, "failed to update")
 	}
 
+	if isP4 {
+		if err := updateP4Master(ctx, dir); err != nil {
+			log15.Error("Failed to update", "repo", repo, "error", err)
+			return err
+		}
+	}
+
 	removeBadRefs(ctx, dir)
 
 	// Update the last-changed stamp.
@@ -1381,19 +1487,23 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 	// Fallback to git's default branch name if git remote show fails.
 	headBranch := "master"
 
-	// try to fetch HEAD from origin
-	cmd = exec.CommandContext(ctx, "git", "remote", "show", url)
-	cmd.Dir = path.Join(s.ReposDir, string(repo))
-	output, err := runWithRemoteOpts(ctx, cmd, nil)
-	if err != nil {
-		log15.Error("Failed to fetch remote info", "repo", repo, "error", err, "output", string(output))
-		return errors.Wrap(err, "failed to fetch remote info")
-	}
-	submatches := headBranchPattern.FindSubmatch(output)
-	if len(submatches) == 2 {
-		submatch := string(submatches[1])
-		if submatch != "(unknown)" {
-			headBranch = string(submatch)
+	// try to fetch HEAD from origin. Depots converted with git p4 only have
+	// the master branch.
+	var output []byte
+	if !isP4 {
+		cmd = exec.CommandContext(ctx, "git", "remote", "show", url)
+		cmd.Dir = path.Join(s.ReposDir, string(repo))
+		output, err = runWithRemoteOpts(ctx, cmd, nil)
+		if err != nil {
+			log15.Error("Failed to fetch remote info", "repo", repo, "error", err, "output", string(output))
+			return errors.Wrap(err, "failed to fetch remote info")
+		}
+		submatches := headBranchPattern.FindSubmatch(output)
+		if len(submatches) == 2 {
+			submatch := string(submatches[1])
+			if submatch != "(unknown)" {
+				headBranch = string(submatch)
+			}
 		}
 	}
 
@@ -1423,6 +1533,10 @@ func (s *Server) doRepoUpdate2(repo api.RepoName, url string) error {
 		log15.Error("Failed to set HEAD", "repo", repo, "error", err, "output", string(output))
 		return errors.Wrap(err, "Failed to set HEAD")
 	}
+
+	if !isP4 {
+		maybeFetchLFSObjects(ctx, repo, dir, url)
+	}
 	return nil
 }
 
@@ -10,10 +10,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/defaults"
 	"github.com/aws/aws-sdk-go-v2/aws/endpoints"
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 	"golang.org/x/net/http2"
 )
@@ -247,3 +250,181 @@ func (t stubBadHTTPRedirectTransport) RoundTrip(r *http.Request) (*http.Response
 // settings. https://github.com/sourcegraph/sourcegraph/issues/71 and
 // https://github.com/sourcegraph/sourcegraph/issues/7738
 func (stubBadHTTPRedirectTransport) UnwrappableTransport() {}
+
+var _ ChangesetSource = &AWSCodeCommitSource{}
+
+// CreateChangeset creates the given *Changeset in the code host.
+func (s *AWSCodeCommitSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	var exists bool
+
+	repo := c.Repo.Metadata.(*awscodecommit.Repository)
+
+	sourceRef := git.EnsureRefPrefix(c.HeadRef)
+	destinationRef := git.EnsureRefPrefix(c.BaseRef)
+
+	// AWS CodeCommit doesn't reject pull requests that duplicate an open one,
+	// so we have to look for an existing pull request ourselves.
+	pr, err := s.client.FindOpenPullRequest(ctx, repo.Name, sourceRef, destinationRef)
+	if err != nil {
+		return exists, errors.Wrap(err, "looking for existing pull request")
+	}
+
+	if pr != nil {
+		exists = true
+	} else {
+		pr, err = s.client.CreatePullRequest(ctx, &awscodecommit.CreatePullRequestInput{
+			RepositoryName:       repo.Name,
+			Title:                c.Title,
+			Description:          c.Body,
+			SourceReference:      sourceRef,
+			DestinationReference: destinationRef,
+		})
+		if err != nil {
+			return exists, err
+		}
+	}
+
+	if err := s.setPullRequest(ctx, c, pr); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset closes the AWS CodeCommit pull request.
+func (s *AWSCodeCommitSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	closed, err := s.client.ClosePullRequest(ctx, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "closing AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, closed)
+}
+
+// LoadChangesets loads the latest state of the given Changesets from the codehost.
+func (s *AWSCodeCommitSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for _, c := range cs {
+		repo := c.Repo.Metadata.(*awscodecommit.Repository)
+
+		pr, err := s.client.GetPullRequest(ctx, c.ExternalID)
+		if err != nil {
+			if awscodecommit.IsNotFound(err) {
+				notFound = append(notFound, c)
+				if c.Changeset.Metadata == nil {
+					c.Changeset.Metadata = &awscodecommit.PullRequest{
+						ID:             c.ExternalID,
+						RepositoryName: repo.Name,
+					}
+				}
+				continue
+			}
+			return errors.Wrapf(err, "retrieving pull request %s", c.ExternalID)
+		}
+
+		if err := s.setPullRequest(ctx, c, pr); err != nil {
+			return errors.Wrapf(err, "pull request %s", c.ExternalID)
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+// UpdateChangeset updates the title and description of the AWS CodeCommit
+// pull request. AWS CodeCommit doesn't support changing the destination of a
+// pull request, so changes to the base ref are not applied.
+func (s *AWSCodeCommitSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequestTitleAndDescription(ctx, pr.ID, c.Title, c.Body)
+	if err != nil {
+		return errors.Wrap(err, "updating AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, updated)
+}
+
+// ReopenChangeset reopens the AWS CodeCommit pull request. Closed pull
+// requests can't be reopened in AWS CodeCommit, so a new pull request with
+// the same title, description and references is created instead, which
+// changes the ExternalID of the Changeset.
+func (s *AWSCodeCommitSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	if pr.Status == awscodecommit.PullRequestStatusOpen {
+		return nil
+	}
+
+	reopened, err := s.client.FindOpenPullRequest(ctx, pr.RepositoryName, pr.SourceReference, pr.DestinationReference)
+	if err != nil {
+		return errors.Wrap(err, "looking for existing pull request")
+	}
+
+	if reopened == nil {
+		reopened, err = s.client.CreatePullRequest(ctx, &awscodecommit.CreatePullRequestInput{
+			RepositoryName:       pr.RepositoryName,
+			Title:                pr.Title,
+			Description:          pr.Description,
+			SourceReference:      pr.SourceReference,
+			DestinationReference: pr.DestinationReference,
+		})
+		if err != nil {
+			return errors.Wrap(err, "recreating AWS CodeCommit pull request")
+		}
+	}
+
+	return s.setPullRequest(ctx, c, reopened)
+}
+
+// MergeChangeset merges the pull request on AWS CodeCommit. AWS CodeCommit
+// can't rebase pull requests.
+func (s *AWSCodeCommitSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*awscodecommit.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an AWS CodeCommit pull request")
+	}
+
+	var squash bool
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+	case campaigns.ChangesetMergeMethodSquash:
+		squash = true
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	merged, err := s.client.MergePullRequest(ctx, pr, squash)
+	if err != nil {
+		return errors.Wrap(err, "merging AWS CodeCommit pull request")
+	}
+
+	return s.setPullRequest(ctx, c, merged)
+}
+
+// setPullRequest loads the approval events of the given pull request and sets
+// it as the metadata of the given Changeset.
+func (s *AWSCodeCommitSource) setPullRequest(ctx context.Context, c *Changeset, pr *awscodecommit.PullRequest) error {
+	if err := s.client.LoadPullRequestApprovalEvents(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading pr approval events")
+	}
+	if err := c.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
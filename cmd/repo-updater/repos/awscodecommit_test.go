@@ -1,8 +1,11 @@
 package repos
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
@@ -51,3 +54,285 @@ func TestAWSCodeCommitSource_Exclude(t *testing.T) {
 		})
 	}
 }
+
+func newTestAWSCodeCommitChangesetSource(t *testing.T, name string) (*AWSCodeCommitSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+
+	svc := &ExternalService{
+		Kind: extsvc.KindAWSCodeCommit,
+		Config: marshalJSON(t, &schema.AWSCodeCommitConnection{
+			AccessKeyID:     getAWSEnv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: getAWSEnv("AWS_SECRET_ACCESS_KEY"),
+			Region:          "us-west-1",
+			GitCredentials: schema.AWSCodeCommitGitCredentials{
+				Username: "git-username",
+				Password: "git-password",
+			},
+		}),
+	}
+
+	src, err := NewAWSCodeCommitSource(svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src, save
+}
+
+func testAWSCodeCommitRepo() *Repo {
+	return &Repo{
+		Name: "test",
+		Metadata: &awscodecommit.Repository{
+			ARN:       "arn:aws:codecommit:us-west-1:185007729374:test",
+			AccountID: "185007729374",
+			ID:        "020a4751-0f46-4e19-82bf-07d0989b67dd",
+			Name:      "test",
+		},
+	}
+}
+
+// testAWSCodeCommitPullRequest returns the pull request that the changeset
+// action tests start out with.
+func testAWSCodeCommitPullRequest(status awscodecommit.PullRequestStatus) *awscodecommit.PullRequest {
+	return &awscodecommit.PullRequest{
+		ID:                   "5",
+		Title:                "Update README",
+		Description:          "Fixes the installation instructions.",
+		Status:               status,
+		RepositoryName:       "test",
+		SourceReference:      "refs/heads/campaigns/update-readme",
+		DestinationReference: "refs/heads/master",
+	}
+}
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name      string
+		id        string
+		approvals int
+		exists    bool
+	}{
+		{name: "success", id: "7"},
+		{name: "already-exists", id: "5", approvals: 1, exists: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitChangesetSource(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Title:     "Update README",
+				Body:      "Fixes the installation instructions.",
+				HeadRef:   "refs/heads/campaigns/update-readme",
+				BaseRef:   "refs/heads/master",
+				Repo:      testAWSCodeCommitRepo(),
+				Changeset: &campaigns.Changeset{},
+			}
+
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != tc.exists {
+				t.Errorf("exists: have %v, want %v", exists, tc.exists)
+			}
+
+			pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			if have, want := pr.ID, tc.id; have != want {
+				t.Errorf("pull request ID: have %q, want %q", have, want)
+			}
+			if have, want := pr.Status, awscodecommit.PullRequestStatusOpen; have != want {
+				t.Errorf("status: have %q, want %q", have, want)
+			}
+			if have, want := len(pr.ApprovalEvents), tc.approvals; have != want {
+				t.Errorf("approval events: have %d, want %d", have, want)
+			}
+			if have, want := cs.Changeset.ExternalID, tc.id; have != want {
+				t.Errorf("external ID: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalServiceType, extsvc.TypeAWSCodeCommit; have != want {
+				t.Errorf("external service type: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalBranch, "campaigns/update-readme"; have != want {
+				t.Errorf("external branch: have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_LoadChangesets(t *testing.T) {
+	repo := testAWSCodeCommitRepo()
+
+	changesets := []*Changeset{
+		{Repo: repo, Changeset: &campaigns.Changeset{ExternalID: "5"}},
+		{Repo: repo, Changeset: &campaigns.Changeset{ExternalID: "4242"}},
+	}
+
+	testCases := []struct {
+		name string
+		cs   []*Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   []*Changeset{changesets[0]},
+		},
+		{
+			name: "subset-not-found",
+			cs:   []*Changeset{changesets[0], changesets[1]},
+			err:  `Changeset with external ID "4242" not found`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_LoadChangesets_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitChangesetSource(t, tc.name)
+			defer save(t)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangesets(context.Background(), tc.cs...)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			pr := tc.cs[0].Changeset.Metadata.(*awscodecommit.PullRequest)
+			if have, want := pr.Status, awscodecommit.PullRequestStatusOpen; have != want {
+				t.Errorf("status: have %q, want %q", have, want)
+			}
+			if have, want := len(pr.ApprovalEvents), 1; have != want {
+				t.Errorf("approval events: have %d, want %d", have, want)
+			}
+
+			if len(tc.cs) > 1 {
+				// Pull requests that weren't found keep enough metadata to
+				// be identified.
+				missing := tc.cs[1].Changeset.Metadata.(*awscodecommit.PullRequest)
+				if have, want := missing.ID, "4242"; have != want {
+					t.Errorf("missing pull request ID: have %q, want %q", have, want)
+				}
+				if have, want := missing.RepositoryName, "test"; have != want {
+					t.Errorf("missing pull request repository: have %q, want %q", have, want)
+				}
+			}
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_ChangesetActions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		action      func(AWSCodeCommitSource, context.Context, *Changeset) error
+		status      awscodecommit.PullRequestStatus
+		title       string
+		description string
+		wantStatus  awscodecommit.PullRequestStatus
+		wantID      string
+		wantTitle   string
+	}{
+		{
+			name:       "CloseChangeset_success",
+			action:     AWSCodeCommitSource.CloseChangeset,
+			status:     awscodecommit.PullRequestStatusOpen,
+			wantStatus: awscodecommit.PullRequestStatusClosed,
+			wantID:     "5",
+			wantTitle:  "Update README",
+		},
+		{
+			// AWS CodeCommit doesn't support changing the destination of a
+			// pull request, so only the title and description are updated.
+			name:        "UpdateChangeset_success",
+			action:      AWSCodeCommitSource.UpdateChangeset,
+			status:      awscodecommit.PullRequestStatusOpen,
+			title:       "Update README (v2)",
+			description: "Fixes the installation instructions and badges.",
+			wantStatus:  awscodecommit.PullRequestStatusOpen,
+			wantID:      "5",
+			wantTitle:   "Update README (v2)",
+		},
+		{
+			// Closed pull requests can't be reopened, so a new one is
+			// created, which changes the external ID of the changeset.
+			name:       "ReopenChangeset_recreated",
+			action:     AWSCodeCommitSource.ReopenChangeset,
+			status:     awscodecommit.PullRequestStatusClosed,
+			wantStatus: awscodecommit.PullRequestStatusOpen,
+			wantID:     "8",
+			wantTitle:  "Update README",
+		},
+		{
+			// An open pull request with the same references is reused
+			// instead of creating a duplicate.
+			name:       "ReopenChangeset_open-pull-request-exists",
+			action:     AWSCodeCommitSource.ReopenChangeset,
+			status:     awscodecommit.PullRequestStatusClosed,
+			wantStatus: awscodecommit.PullRequestStatusOpen,
+			wantID:     "8",
+			wantTitle:  "Update README",
+		},
+		{
+			name:       "ReopenChangeset_already-open",
+			action:     AWSCodeCommitSource.ReopenChangeset,
+			status:     awscodecommit.PullRequestStatusOpen,
+			wantStatus: awscodecommit.PullRequestStatusOpen,
+			wantID:     "5",
+			wantTitle:  "Update README",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestAWSCodeCommitChangesetSource(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Title:     tc.title,
+				Body:      tc.description,
+				HeadRef:   "refs/heads/campaigns/update-readme",
+				BaseRef:   "refs/heads/master",
+				Repo:      testAWSCodeCommitRepo(),
+				Changeset: &campaigns.Changeset{},
+			}
+			if err := cs.SetMetadata(testAWSCodeCommitPullRequest(tc.status)); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tc.action(*src, context.Background(), cs); err != nil {
+				t.Fatal(err)
+			}
+
+			pr := cs.Changeset.Metadata.(*awscodecommit.PullRequest)
+			if have, want := pr.Status, tc.wantStatus; have != want {
+				t.Errorf("status: have %q, want %q", have, want)
+			}
+			if have, want := pr.ID, tc.wantID; have != want {
+				t.Errorf("pull request ID: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalID, tc.wantID; have != want {
+				t.Errorf("external ID: have %q, want %q", have, want)
+			}
+			if have, want := pr.Title, tc.wantTitle; have != want {
+				t.Errorf("title: have %q, want %q", have, want)
+			}
+			if have, want := pr.DestinationReference, "refs/heads/master"; have != want {
+				t.Errorf("destination: have %q, want %q", have, want)
+			}
+			if tc.description != "" && pr.Description != tc.description {
+				t.Errorf("description: have %q, want %q", pr.Description, tc.description)
+			}
+		})
+	}
+}
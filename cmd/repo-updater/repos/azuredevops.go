@@ -0,0 +1,315 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps
+// connection configured in Sourcegraph via the external services
+// configuration.
+type AzureDevOpsSource struct {
+	svc     *ExternalService
+	config  *schema.AzureDevOpsConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *azuredevops.Client
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(svc *ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newAzureDevOpsSource(svc, &c, cf)
+}
+
+func newAzureDevOpsSource(svc *ExternalService, c *schema.AzureDevOpsConnection, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Exact(r.Id)
+		eb.Pattern(r.Pattern)
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := azuredevops.NewClient(baseURL, cli)
+	client.Username = c.Username
+	client.Token = c.Token
+
+	return &AzureDevOpsSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  client,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories accessible to all connections
+// configured in Sourcegraph via the external services configuration.
+func (s AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	if len(s.config.Projects) == 0 {
+		s.listRepos(ctx, "", results)
+		return
+	}
+
+	for _, project := range s.config.Projects {
+		s.listRepos(ctx, project, results)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s AzureDevOpsSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+func (s AzureDevOpsSource) makeRepo(r *azuredevops.Repository) *Repo {
+	urn := s.svc.URN()
+	org := strings.Trim(s.baseURL.Path, "/")
+	return &Repo{
+		Name: string(reposource.AzureDevOpsRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			org,
+			r.Project.Name,
+			r.Name,
+		)),
+		URI: string(reposource.AzureDevOpsRepoName(
+			"",
+			s.baseURL.Hostname(),
+			org,
+			r.Project.Name,
+			r.Name,
+		)),
+		ExternalRepo: azuredevops.ExternalRepoSpec(r, *s.baseURL),
+		Description:  r.Project.Description,
+		Private:      r.IsPrivate(),
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.authenticatedRemoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// authenticatedRemoteURL returns the repository's Git remote URL with the
+// configured username and personal access token inserted in the URL userinfo.
+func (s *AzureDevOpsSource) authenticatedRemoteURL(r *azuredevops.Repository) string {
+	u, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		return r.RemoteURL
+	}
+	u.User = url.UserPassword(s.config.Username, s.config.Token)
+	return u.String()
+}
+
+// excludes returns true if the repository is disabled, since disabled
+// repositories can't be cloned, or if it's excluded in the configuration.
+func (s *AzureDevOpsSource) excludes(r *azuredevops.Repository) bool {
+	return r.IsDisabled || s.exclude(r.FullName()) || s.exclude(r.ID)
+}
+
+func (s *AzureDevOpsSource) listRepos(ctx context.Context, project string, results chan SourceResult) {
+	repos, err := s.client.ListRepositories(ctx, project)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.repositories: project=%q", project)}
+		return
+	}
+
+	for _, r := range repos {
+		if !s.excludes(r) {
+			results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+		}
+	}
+}
+
+var _ ChangesetSource = AzureDevOpsSource{}
+
+// CreateChangeset creates the given *Changeset in the code host.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	var exists bool
+
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	pr, err := s.client.CreatePullRequest(ctx, repo, &azuredevops.CreatePullRequestInput{
+		SourceRefName: git.EnsureRefPrefix(c.HeadRef),
+		TargetRefName: git.EnsureRefPrefix(c.BaseRef),
+		Title:         c.Title,
+		Description:   c.Body,
+	})
+	if err != nil {
+		ae, ok := err.(*azuredevops.ErrAlreadyExists)
+		if !ok || ae.Existing == nil {
+			return exists, err
+		}
+		pr = ae.Existing
+		exists = true
+	}
+
+	if err := s.loadPullRequestData(ctx, pr); err != nil {
+		return false, errors.Wrap(err, "loading extra metadata")
+	}
+	if err := c.SetMetadata(pr); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset abandons the Azure DevOps pull request.
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	return s.updatePullRequest(ctx, c, &azuredevops.UpdatePullRequestInput{
+		Status: azuredevops.PullRequestStatusAbandoned,
+	})
+}
+
+// LoadChangesets loads the latest state of the given Changesets from the codehost.
+func (s AzureDevOpsSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for _, c := range cs {
+		repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+		id, err := strconv.Atoi(c.ExternalID)
+		if err != nil {
+			return errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+		}
+
+		pr, err := s.client.GetPullRequest(ctx, repo, id)
+		if err != nil {
+			if azuredevops.IsNotFound(err) {
+				notFound = append(notFound, c)
+				if c.Changeset.Metadata == nil {
+					c.Changeset.Metadata = &azuredevops.PullRequest{PullRequestID: id, Repository: *repo}
+				}
+				continue
+			}
+			return errors.Wrapf(err, "retrieving pull request %d", id)
+		}
+
+		if err := s.loadPullRequestData(ctx, pr); err != nil {
+			return errors.Wrap(err, "loading pull request data")
+		}
+		if err := c.SetMetadata(pr); err != nil {
+			return errors.Wrapf(err, "setting changeset metadata for pull request %d", id)
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+func (s AzureDevOpsSource) loadPullRequestData(ctx context.Context, pr *azuredevops.PullRequest) error {
+	if err := s.client.LoadPullRequestVotes(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading pr votes")
+	}
+
+	if err := s.client.LoadPullRequestStatuses(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading pr statuses")
+	}
+
+	return nil
+}
+
+// UpdateChangeset updates the title, description and target branch of the
+// Azure DevOps pull request.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	return s.updatePullRequest(ctx, c, &azuredevops.UpdatePullRequestInput{
+		Title:         c.Title,
+		Description:   c.Body,
+		TargetRefName: git.EnsureRefPrefix(c.BaseRef),
+	})
+}
+
+// ReopenChangeset reactivates the abandoned Azure DevOps pull request.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	return s.updatePullRequest(ctx, c, &azuredevops.UpdatePullRequestInput{
+		Status: azuredevops.PullRequestStatusActive,
+	})
+}
+
+// MergeChangeset completes the pull request on Azure DevOps, merging it with
+// the merge strategy matching the given method.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	var strategy azuredevops.MergeStrategy
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+		strategy = azuredevops.MergeStrategyNoFastForward
+	case campaigns.ChangesetMergeMethodSquash:
+		strategy = azuredevops.MergeStrategySquash
+	case campaigns.ChangesetMergeMethodRebase:
+		strategy = azuredevops.MergeStrategyRebase
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	return s.updatePullRequest(ctx, c, &azuredevops.UpdatePullRequestInput{
+		Status:                azuredevops.PullRequestStatusCompleted,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions:     &azuredevops.CompletionOptions{MergeStrategy: strategy},
+	})
+}
+
+func (s AzureDevOpsSource) updatePullRequest(ctx context.Context, c *Changeset, input *azuredevops.UpdatePullRequestInput) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, pr, input)
+	if err != nil {
+		return errors.Wrap(err, "updating Azure DevOps pull request")
+	}
+
+	if err := s.loadPullRequestData(ctx, updated); err != nil {
+		return errors.Wrap(err, "loading pull request data")
+	}
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
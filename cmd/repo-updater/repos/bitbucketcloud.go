@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"sync"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -231,3 +234,192 @@ func (s *BitbucketCloudSource) listAllRepos(ctx context.Context, results chan So
 		}
 	}
 }
+
+var _ ChangesetSource = BitbucketCloudSource{}
+
+// CreateChangeset creates the given *Changeset in the code host.
+func (s BitbucketCloudSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	var exists bool
+
+	repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+
+	source := git.AbbreviateRef(c.HeadRef)
+	destination := git.AbbreviateRef(c.BaseRef)
+
+	// Bitbucket Cloud doesn't reject pull requests that duplicate an open one,
+	// so we have to look for an existing pull request ourselves.
+	pr, err := s.client.FindOpenPullRequest(ctx, repo, source, destination)
+	if err != nil {
+		return exists, errors.Wrap(err, "looking for existing pull request")
+	}
+
+	if pr != nil {
+		exists = true
+	} else {
+		pr, err = s.client.CreatePullRequest(ctx, repo, &bitbucketcloud.CreatePullRequestInput{
+			Title:       c.Title,
+			Description: c.Body,
+			Source:      bitbucketcloud.PullRequestEndpoint{Branch: bitbucketcloud.Branch{Name: source}},
+			Destination: bitbucketcloud.PullRequestEndpoint{Branch: bitbucketcloud.Branch{Name: destination}},
+		})
+		if err != nil {
+			return exists, err
+		}
+	}
+
+	if err := s.setPullRequest(ctx, c, pr); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset declines the Bitbucket Cloud pull request.
+func (s BitbucketCloudSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	declined, err := s.client.DeclinePullRequest(ctx, pr)
+	if err != nil {
+		return errors.Wrap(err, "declining Bitbucket Cloud pull request")
+	}
+
+	return s.setPullRequest(ctx, c, declined)
+}
+
+// LoadChangesets loads the latest state of the given Changesets from the codehost.
+func (s BitbucketCloudSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for _, c := range cs {
+		repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+
+		id, err := strconv.ParseInt(c.ExternalID, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+		}
+
+		pr, err := s.client.GetPullRequest(ctx, repo, id)
+		if err != nil {
+			if bitbucketcloud.IsNotFound(err) {
+				notFound = append(notFound, c)
+				if c.Changeset.Metadata == nil {
+					c.Changeset.Metadata = &bitbucketcloud.PullRequest{
+						ID:          id,
+						Destination: bitbucketcloud.PullRequestEndpoint{Repository: repo},
+					}
+				}
+				continue
+			}
+			return errors.Wrapf(err, "retrieving pull request %d", id)
+		}
+
+		if err := s.setPullRequest(ctx, c, pr); err != nil {
+			return errors.Wrapf(err, "pull request %d", id)
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+// UpdateChangeset updates the title, description and destination branch of
+// the Bitbucket Cloud pull request.
+func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, pr, &bitbucketcloud.UpdatePullRequestInput{
+		Title:       c.Title,
+		Description: c.Body,
+		Destination: bitbucketcloud.PullRequestEndpoint{
+			Branch: bitbucketcloud.Branch{Name: git.AbbreviateRef(c.BaseRef)},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating Bitbucket Cloud pull request")
+	}
+
+	return s.setPullRequest(ctx, c, updated)
+}
+
+// ReopenChangeset reopens the Bitbucket Cloud pull request. Declined pull
+// requests can't be reopened in Bitbucket Cloud, so a new pull request with
+// the same title, description and branches is created instead, which changes
+// the ExternalID of the Changeset.
+func (s BitbucketCloudSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	if pr.State == bitbucketcloud.PullRequestStateOpen {
+		return nil
+	}
+
+	repo := c.Repo.Metadata.(*bitbucketcloud.Repo)
+
+	reopened, err := s.client.FindOpenPullRequest(ctx, repo, pr.Source.Branch.Name, pr.Destination.Branch.Name)
+	if err != nil {
+		return errors.Wrap(err, "looking for existing pull request")
+	}
+
+	if reopened == nil {
+		reopened, err = s.client.CreatePullRequest(ctx, repo, &bitbucketcloud.CreatePullRequestInput{
+			Title:       pr.Title,
+			Description: pr.Description,
+			Source:      bitbucketcloud.PullRequestEndpoint{Branch: pr.Source.Branch},
+			Destination: bitbucketcloud.PullRequestEndpoint{Branch: pr.Destination.Branch},
+		})
+		if err != nil {
+			return errors.Wrap(err, "recreating Bitbucket Cloud pull request")
+		}
+	}
+
+	return s.setPullRequest(ctx, c, reopened)
+}
+
+// MergeChangeset merges the pull request on Bitbucket Cloud. Bitbucket Cloud
+// can't rebase pull requests.
+func (s BitbucketCloudSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Cloud pull request")
+	}
+
+	var strategy string
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+		strategy = bitbucketcloud.MergeStrategyMergeCommit
+	case campaigns.ChangesetMergeMethodSquash:
+		strategy = bitbucketcloud.MergeStrategySquash
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	merged, err := s.client.MergePullRequest(ctx, pr, strategy)
+	if err != nil {
+		return errors.Wrap(err, "merging Bitbucket Cloud pull request")
+	}
+
+	return s.setPullRequest(ctx, c, merged)
+}
+
+// setPullRequest loads the statuses of the given pull request and sets it as
+// the metadata of the given Changeset.
+func (s BitbucketCloudSource) setPullRequest(ctx context.Context, c *Changeset, pr *bitbucketcloud.PullRequest) error {
+	if err := s.client.LoadPullRequestStatuses(ctx, pr); err != nil {
+		return errors.Wrap(err, "loading pr statuses")
+	}
+	if err := c.SetMetadata(pr); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
@@ -247,3 +248,247 @@ func TestBitbucketCloudSource_Exclude(t *testing.T) {
 		})
 	}
 }
+
+func newTestBitbucketCloudChangesetSource(t *testing.T, name string) (*BitbucketCloudSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+
+	svc := &ExternalService{
+		Kind: extsvc.KindBitbucketCloud,
+		Config: marshalJSON(t, &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			Username:    bitbucketcloud.GetenvTestBitbucketCloudUsername(),
+			AppPassword: os.Getenv("BITBUCKET_CLOUD_APP_PASSWORD"),
+		}),
+	}
+
+	src, err := NewBitbucketCloudSource(svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src, save
+}
+
+func testBitbucketCloudRepo() *Repo {
+	return &Repo{
+		Name: "bitbucket.org/sgtest/tools",
+		Metadata: &bitbucketcloud.Repo{
+			Name:     "tools",
+			FullName: "sgtest/tools",
+			UUID:     "{b090a669-d1d8-4a8b-b3f8-0cbd3c36c35e}",
+		},
+	}
+}
+
+func TestBitbucketCloudSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       int64
+		statuses int
+		exists   bool
+	}{
+		{name: "success", id: 5},
+		{name: "already-exists", id: 4, statuses: 1, exists: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "BitbucketCloudSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestBitbucketCloudChangesetSource(t, tc.name)
+			defer save(t)
+
+			cs := &Changeset{
+				Title:     "Update README",
+				Body:      "Fixes the installation instructions.",
+				HeadRef:   "refs/heads/campaigns/update-readme",
+				BaseRef:   "refs/heads/main",
+				Repo:      testBitbucketCloudRepo(),
+				Changeset: &campaigns.Changeset{},
+			}
+
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if exists != tc.exists {
+				t.Errorf("exists: have %v, want %v", exists, tc.exists)
+			}
+
+			pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+			if have, want := pr.ID, tc.id; have != want {
+				t.Errorf("pull request ID: have %d, want %d", have, want)
+			}
+			if have, want := len(pr.Statuses), tc.statuses; have != want {
+				t.Errorf("statuses: have %d, want %d", have, want)
+			}
+			if have, want := cs.Changeset.ExternalID, fmt.Sprint(tc.id); have != want {
+				t.Errorf("external ID: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalServiceType, extsvc.TypeBitbucketCloud; have != want {
+				t.Errorf("external service type: have %q, want %q", have, want)
+			}
+			if have, want := cs.Changeset.ExternalBranch, "campaigns/update-readme"; have != want {
+				t.Errorf("external branch: have %q, want %q", have, want)
+			}
+		})
+	}
+}
+
+func TestBitbucketCloudSource_LoadChangesets(t *testing.T) {
+	repo := testBitbucketCloudRepo()
+
+	changesets := []*Changeset{
+		{Repo: repo, Changeset: &campaigns.Changeset{ExternalID: "4"}},
+		{Repo: repo, Changeset: &campaigns.Changeset{ExternalID: "4242"}},
+	}
+
+	testCases := []struct {
+		name string
+		cs   []*Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   []*Changeset{changesets[0]},
+		},
+		{
+			name: "subset-not-found",
+			cs:   []*Changeset{changesets[0], changesets[1]},
+			err:  `Changeset with external ID "4242" not found`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "BitbucketCloudSource_LoadChangesets_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestBitbucketCloudChangesetSource(t, tc.name)
+			defer save(t)
+
+			if tc.err == "" {
+				tc.err = "<nil>"
+			}
+
+			err := src.LoadChangesets(context.Background(), tc.cs...)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+
+			pr := tc.cs[0].Changeset.Metadata.(*bitbucketcloud.PullRequest)
+			if have, want := pr.State, bitbucketcloud.PullRequestStateOpen; have != want {
+				t.Errorf("state: have %q, want %q", have, want)
+			}
+			if have, want := len(pr.Participants), 1; have != want {
+				t.Errorf("participants: have %d, want %d", have, want)
+			}
+			if have, want := len(pr.Statuses), 1; have != want {
+				t.Errorf("statuses: have %d, want %d", have, want)
+			}
+		})
+	}
+}
+
+func TestBitbucketCloudSource_ChangesetActions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		action      func(BitbucketCloudSource, context.Context, *Changeset) error
+		title       string
+		base        string
+		state       bitbucketcloud.PullRequestState
+		id          int64
+		destination string
+	}{
+		{
+			name:        "CloseChangeset_success",
+			action:      BitbucketCloudSource.CloseChangeset,
+			state:       bitbucketcloud.PullRequestStateDeclined,
+			id:          4,
+			destination: "main",
+		},
+		{
+			// Declined pull requests can't be reopened, so a new one is
+			// created.
+			name:        "ReopenChangeset_success",
+			action:      BitbucketCloudSource.ReopenChangeset,
+			state:       bitbucketcloud.PullRequestStateOpen,
+			id:          6,
+			destination: "main",
+		},
+		{
+			name:        "UpdateChangeset_success",
+			action:      BitbucketCloudSource.UpdateChangeset,
+			title:       "Update README (v2)",
+			base:        "refs/heads/release",
+			state:       bitbucketcloud.PullRequestStateOpen,
+			id:          4,
+			destination: "release",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "BitbucketCloudSource_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newTestBitbucketCloudChangesetSource(t, tc.name)
+			defer save(t)
+
+			state := bitbucketcloud.PullRequestStateOpen
+			if tc.name == "BitbucketCloudSource_ReopenChangeset_success" {
+				state = bitbucketcloud.PullRequestStateDeclined
+			}
+
+			repo := testBitbucketCloudRepo()
+			cs := &Changeset{
+				Title:   tc.title,
+				Body:    "Fixes the installation instructions and badges.",
+				BaseRef: tc.base,
+				Repo:    repo,
+				Changeset: &campaigns.Changeset{
+					Metadata: &bitbucketcloud.PullRequest{
+						ID:          4,
+						Title:       "Update README",
+						Description: "Fixes the installation instructions.",
+						State:       state,
+						Source: bitbucketcloud.PullRequestEndpoint{
+							Branch:     bitbucketcloud.Branch{Name: "campaigns/update-readme"},
+							Repository: repo.Metadata.(*bitbucketcloud.Repo),
+						},
+						Destination: bitbucketcloud.PullRequestEndpoint{
+							Branch:     bitbucketcloud.Branch{Name: "main"},
+							Repository: repo.Metadata.(*bitbucketcloud.Repo),
+						},
+					},
+				},
+			}
+			if cs.BaseRef == "" {
+				cs.BaseRef = "refs/heads/main"
+			}
+
+			if err := tc.action(*src, context.Background(), cs); err != nil {
+				t.Fatal(err)
+			}
+
+			pr := cs.Changeset.Metadata.(*bitbucketcloud.PullRequest)
+			if have, want := pr.State, tc.state; have != want {
+				t.Errorf("state: have %q, want %q", have, want)
+			}
+			if have, want := pr.ID, tc.id; have != want {
+				t.Errorf("pull request ID: have %d, want %d", have, want)
+			}
+			if have, want := cs.Changeset.ExternalID, fmt.Sprint(tc.id); have != want {
+				t.Errorf("external ID: have %q, want %q", have, want)
+			}
+			if have, want := pr.Destination.Branch.Name, tc.destination; have != want {
+				t.Errorf("destination: have %q, want %q", have, want)
+			}
+			if tc.title != "" && pr.Title != tc.title {
+				t.Errorf("title: have %q, want %q", pr.Title, tc.title)
+			}
+		})
+	}
+}
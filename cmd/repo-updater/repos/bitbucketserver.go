@@ -11,6 +11,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -246,6 +247,35 @@ func (s BitbucketServerSource) ReopenChangeset(ctx context.Context, c *Changeset
 	return nil
 }
 
+// MergeChangeset merges the *Changeset on Bitbucket Server with the merge
+// strategy matching the given method.
+func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	var strategy string
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+		strategy = bitbucketserver.MergeStrategyNoFastForward
+	case campaigns.ChangesetMergeMethodSquash:
+		strategy = bitbucketserver.MergeStrategySquash
+	case campaigns.ChangesetMergeMethodRebase:
+		strategy = bitbucketserver.MergeStrategyRebaseNoFastForward
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	if err := s.client.MergePullRequest(ctx, pr, strategy); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s BitbucketServerSource) ExternalServices() ExternalServices {
 	return ExternalServices{s.svc}
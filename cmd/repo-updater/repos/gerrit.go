@@ -0,0 +1,316 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GerritSource yields repositories from a single Gerrit connection configured
+// in Sourcegraph via the external services configuration.
+type GerritSource struct {
+	svc     *ExternalService
+	config  *schema.GerritConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *gerrit.Client
+}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(svc *ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGerritSource(svc, &c, cf)
+}
+
+func newGerritSource(svc *ExternalService, c *schema.GerritConnection, cf *httpcli.Factory) (*GerritSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, p := range c.Exclude {
+		eb.Exact(p.Name)
+		eb.Pattern(p.Pattern)
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := gerrit.NewClient(baseURL, cli)
+	client.Username = c.Username
+	client.Password = c.Password
+
+	return &GerritSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  client,
+	}, nil
+}
+
+// ListRepos returns all Gerrit projects accessible to all connections configured
+// in Sourcegraph via the external services configuration.
+func (s GerritSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	if len(s.config.Projects) > 0 {
+		s.listConfiguredProjects(ctx, results)
+		return
+	}
+	s.listAllProjects(ctx, results)
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s GerritSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+func (s GerritSource) makeRepo(p *gerrit.Project) *Repo {
+	urn := s.svc.URN()
+	return &Repo{
+		Name: string(reposource.GerritRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			p.Name,
+		)),
+		URI: string(reposource.GerritRepoName(
+			"",
+			s.baseURL.Hostname(),
+			p.Name,
+		)),
+		ExternalRepo: gerrit.ExternalRepoSpec(p, *s.baseURL),
+		Description:  p.Description,
+		Archived:     p.State == gerrit.ProjectStateReadOnly,
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.authenticatedRemoteURL(p),
+			},
+		},
+		Metadata: p,
+	}
+}
+
+// authenticatedRemoteURL returns the project's Git remote URL with the
+// configured Gerrit HTTP credentials inserted in the URL userinfo.
+func (s *GerritSource) authenticatedRemoteURL(p *gerrit.Project) string {
+	// Authenticated Git requests are served below the "/a/" prefix, just like
+	// authenticated REST API requests.
+	u := s.baseURL.ResolveReference(&url.URL{Path: "a/" + p.Name})
+	u.User = url.UserPassword(s.config.Username, s.config.Password)
+	return u.String()
+}
+
+func (s *GerritSource) excludes(p *gerrit.Project) bool {
+	return p.State == gerrit.ProjectStateHidden || s.exclude(p.Name)
+}
+
+func (s *GerritSource) listConfiguredProjects(ctx context.Context, results chan SourceResult) {
+	for _, name := range s.config.Projects {
+		p, err := s.client.GetProject(ctx, name)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projects: name=%q", name)}
+			continue
+		}
+
+		if !s.excludes(p) {
+			results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+		}
+	}
+}
+
+func (s *GerritSource) listAllProjects(ctx context.Context, results chan SourceResult) {
+	for start, hasNext := 0, true; hasNext; start += gerrit.PerPage {
+		var (
+			projects []*gerrit.Project
+			err      error
+		)
+		if projects, hasNext, err = s.client.ListProjects(ctx, start); err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projects: start=%d", start)}
+			return
+		}
+
+		for _, p := range projects {
+			if !s.excludes(p) {
+				results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+			}
+		}
+	}
+}
+
+// CreateChangeset looks up the Gerrit change that was created by pushing the
+// changeset's commit to the refs/for/<base> ref of the project. Since pushing
+// the commit creates the change, this never returns true.
+func (s *GerritSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	project := c.Repo.Metadata.(*gerrit.Project)
+
+	change, err := s.findChange(ctx, project.Name, c.Repo.Name, c.HeadRef, c.BaseRef)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.SetMetadata(change); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+	return false, nil
+}
+
+func (s *GerritSource) findChange(ctx context.Context, project, repoName, headRef, baseRef string) (*gerrit.Change, error) {
+	changeID := gerrit.ChangeID(repoName, headRef)
+	query := fmt.Sprintf("change:%s project:%s branch:%s", changeID, project, git.AbbreviateRef(baseRef))
+
+	changes, err := s.client.QueryChanges(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "querying Gerrit changes")
+	}
+	if len(changes) == 0 {
+		return nil, errors.Errorf("no Gerrit change with Change-Id %s found in project %q", changeID, project)
+	}
+	return changes[0], nil
+}
+
+// CloseChangeset abandons the Gerrit change.
+func (s *GerritSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if change.Status == gerrit.ChangeStatusNew {
+		if err := s.client.AbandonChange(ctx, change); err != nil {
+			return errors.Wrap(err, "abandoning Gerrit change")
+		}
+	}
+
+	return s.loadChange(ctx, c, change)
+}
+
+// LoadChangesets loads the given Gerrit changes and updates them.
+func (s *GerritSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for _, c := range cs {
+		project := c.Repo.Metadata.(*gerrit.Project)
+
+		number, err := strconv.Atoi(c.ExternalID)
+		if err != nil {
+			return errors.Wrapf(err, "parsing changeset external ID %s", c.ExternalID)
+		}
+
+		change, err := s.client.GetChange(ctx, project.Name, number)
+		if err != nil {
+			if gerrit.IsNotFound(err) {
+				notFound = append(notFound, c)
+				if c.Changeset.Metadata == nil {
+					c.Changeset.Metadata = &gerrit.Change{Project: project.Name, Number: number}
+				}
+				continue
+			}
+			return errors.Wrapf(err, "retrieving change %d", number)
+		}
+
+		if err := c.SetMetadata(change); err != nil {
+			return errors.Wrapf(err, "setting changeset metadata for change %d", number)
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+// ReopenChangeset restores the Gerrit change, if it was abandoned.
+func (s *GerritSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if err := s.client.RestoreChange(ctx, change); err != nil {
+			return errors.Wrap(err, "restoring Gerrit change")
+		}
+	}
+
+	return s.loadChange(ctx, c, change)
+}
+
+// MergeChangeset submits the Gerrit change. How the change is merged is
+// determined by the submit type of the project, so only
+// campaigns.ChangesetMergeMethodMerge is supported.
+func (s *GerritSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if method != campaigns.ChangesetMergeMethodMerge {
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	if change.Status == gerrit.ChangeStatusNew {
+		if err := s.client.SubmitChange(ctx, change); err != nil {
+			return errors.Wrap(err, "submitting Gerrit change")
+		}
+	}
+
+	return s.loadChange(ctx, c, change)
+}
+
+// UpdateChangeset moves the Gerrit change to the changeset's base branch, if
+// it changed. The title and body of a change are the subject and body of its
+// commit message, so they are updated by pushing a new patch set, not here.
+func (s *GerritSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	change, ok := c.Changeset.Metadata.(*gerrit.Change)
+	if !ok {
+		return errors.New("Changeset is not a Gerrit change")
+	}
+
+	if branch := git.AbbreviateRef(c.BaseRef); branch != change.Branch {
+		if err := s.client.MoveChange(ctx, change, branch); err != nil {
+			return errors.Wrap(err, "moving Gerrit change")
+		}
+	}
+
+	return s.loadChange(ctx, c, change)
+}
+
+func (s *GerritSource) loadChange(ctx context.Context, c *Changeset, change *gerrit.Change) error {
+	updated, err := s.client.GetChange(ctx, change.Project, change.Number)
+	if err != nil {
+		return errors.Wrap(err, "retrieving Gerrit change")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
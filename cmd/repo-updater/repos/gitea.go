@@ -0,0 +1,269 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo) connection configured
+// in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc             *ExternalService
+	config          *schema.GiteaConnection
+	exclude         excludeFunc
+	excludeArchived bool
+	excludeForks    bool
+	baseURL         *url.URL
+	client          *gitea.Client
+}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(svc *ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGiteaSource(svc, &c, cf)
+}
+
+func newGiteaSource(svc *ExternalService, c *schema.GiteaConnection, cf *httpcli.Factory) (*GiteaSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		eb              excludeBuilder
+		excludeArchived bool
+		excludeForks    bool
+	)
+
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+
+		if r.Archived {
+			excludeArchived = true
+		}
+
+		if r.Forks {
+			excludeForks = true
+		}
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := gitea.NewClient(baseURL, cli)
+	client.Token = c.Token
+
+	return &GiteaSource{
+		svc:             svc,
+		config:          c,
+		exclude:         exclude,
+		excludeArchived: excludeArchived,
+		excludeForks:    excludeForks,
+		baseURL:         baseURL,
+		client:          client,
+	}, nil
+}
+
+// ListRepos returns all Gitea repositories accessible to all connections configured
+// in Sourcegraph via the external services configuration.
+func (s GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.listAllRepos(ctx, results)
+}
+
+// GetRepo returns the Gitea repository with the given "owner/name".
+func (s GiteaSource) GetRepo(ctx context.Context, nameWithOwner string) (*Repo, error) {
+	r, err := s.client.GetRepo(ctx, nameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeRepo(r), nil
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s GiteaSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+func (s GiteaSource) makeRepo(r *gitea.Repo) *Repo {
+	urn := s.svc.URN()
+	return &Repo{
+		Name: string(reposource.GiteaRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			r.FullName,
+		)),
+		URI: string(reposource.GiteaRepoName(
+			"",
+			s.baseURL.Hostname(),
+			r.FullName,
+		)),
+		ExternalRepo: gitea.ExternalRepoSpec(r, *s.baseURL),
+		Description:  r.Description,
+		Fork:         r.Fork,
+		Archived:     r.Archived,
+		Private:      r.Private,
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.authenticatedRemoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// authenticatedRemoteURL returns the repository's Git remote URL with the
+// configured Gitea access token inserted in the URL userinfo.
+func (s *GiteaSource) authenticatedRemoteURL(r *gitea.Repo) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL // SSH authentication must be provided out-of-band
+	}
+	if s.config.Token == "" {
+		return r.CloneURL
+	}
+	u, err := url.Parse(r.CloneURL)
+	if err != nil {
+		log15.Warn("Error adding authentication to Gitea repository Git remote URL.", "url", r.CloneURL, "error", err)
+		return r.CloneURL
+	}
+	// Gitea accepts an access token in place of the password for any
+	// username.
+	u.User = url.UserPassword("git", s.config.Token)
+	return u.String()
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repo) bool {
+	if s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) {
+		return true
+	}
+
+	if s.excludeArchived && r.Archived {
+		return true
+	}
+
+	if s.excludeForks && r.Fork {
+		return true
+	}
+
+	return false
+}
+
+// giteaRepoPager returns the repositories on the given page (starting at 1)
+// and whether there is a next page.
+type giteaRepoPager func(ctx context.Context, page int) ([]*gitea.Repo, bool, error)
+
+func (s *GiteaSource) listAllRepos(ctx context.Context, results chan SourceResult) {
+	type batch struct {
+		repos []*gitea.Repo
+		err   error
+	}
+
+	ch := make(chan batch)
+
+	var wg sync.WaitGroup
+
+	paginate := func(item string, pager giteaRepoPager) {
+		defer wg.Done()
+
+		for page, hasNext := 1, true; hasNext; page++ {
+			var (
+				repos []*gitea.Repo
+				err   error
+			)
+			if repos, hasNext, err = pager(ctx, page); err != nil {
+				ch <- batch{err: errors.Wrapf(err, "gitea.repos: item=%q, page=%d", item, page)}
+				return
+			}
+
+			ch <- batch{repos: repos}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		org := org
+		wg.Add(1)
+		go paginate("org:"+org, func(ctx context.Context, page int) ([]*gitea.Repo, bool, error) {
+			return s.client.ListOrgRepos(ctx, org, page)
+		})
+	}
+
+	for _, user := range s.config.Users {
+		user := user
+		wg.Add(1)
+		go paginate("user:"+user, func(ctx context.Context, page int) ([]*gitea.Repo, bool, error) {
+			return s.client.ListUserRepos(ctx, user, page)
+		})
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		query := query
+		switch query {
+		case "none":
+			continue
+		case "affiliated":
+			wg.Add(1)
+			go paginate(query, s.client.ListAffiliatedRepos)
+		default:
+			wg.Add(1)
+			go paginate("query:"+query, func(ctx context.Context, page int) ([]*gitea.Repo, bool, error) {
+				return s.client.SearchRepos(ctx, query, page)
+			})
+		}
+	}
+
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	seen := make(map[int64]bool)
+	for r := range ch {
+		if r.err != nil {
+			results <- SourceResult{Source: s, Err: r.err}
+			continue
+		}
+
+		for _, repo := range r.repos {
+			// Empty repositories cannot be cloned.
+			if repo.Empty {
+				continue
+			}
+
+			if !seen[repo.ID] && !s.excludes(repo) {
+				results <- SourceResult{Source: s, Repo: s.makeRepo(repo)}
+				seen[repo.ID] = true
+			}
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -276,6 +277,35 @@ func (s GithubSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// MergeChangeset merges a *Changeset on GitHub with the given method.
+func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	var m github.PullRequestMergeMethod
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+		m = github.PullRequestMergeMethodMerge
+	case campaigns.ChangesetMergeMethodSquash:
+		m = github.PullRequestMergeMethodSquash
+	case campaigns.ChangesetMergeMethodRebase:
+		m = github.PullRequestMergeMethodRebase
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	err := s.client.MergePullRequest(ctx, pr, m)
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // GetRepo returns the Github repository with the given name and owner
 // ("org/repo-name")
 func (s GithubSource) GetRepo(ctx context.Context, nameWithOwner string) (*Repo, error) {
@@ -11,6 +11,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -418,6 +419,35 @@ func (s *GitLabSource) ReopenChangeset(ctx context.Context, c *Changeset) error
 	return nil
 }
 
+// MergeChangeset merges the given *Changeset on GitLab. GitLab can only merge
+// or squash merge requests; how they are merged into the target branch is
+// configured on the project.
+func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	opts := gitlab.MergeMergeRequestOpts{SHA: mr.DiffRefs.HeadSHA}
+	switch method {
+	case campaigns.ChangesetMergeMethodMerge:
+	case campaigns.ChangesetMergeMethodSquash:
+		opts.Squash = true
+	default:
+		return MergeMethodNotSupportedError{Method: method}
+	}
+
+	updated, err := s.client.MergeMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr, opts)
+	if err != nil {
+		return errors.Wrap(err, "merging GitLab merge request")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
 func (s *GitLabSource) decorateMergeRequestData(ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) error {
 	notes, err := s.getMergeRequestNotes(ctx, project, mr)
 	if err != nil {
@@ -99,6 +99,9 @@ func (s GitoliteSource) makeRepo(repo *gitolite.Repo) *Repo {
 		Name:         name,
 		URI:          name,
 		ExternalRepo: gitolite.ExternalRepoSpec(repo, gitolite.ServiceID(s.conn.Host)),
+		// Gitolite doesn't distinguish between public and private repositories, so all
+		// repositories are private when permissions are enforced.
+		Private: s.conn.Authorization != nil,
 		Sources: map[string]*SourceInfo{
 			urn: {
 				ID:       urn,
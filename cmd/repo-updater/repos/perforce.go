@@ -0,0 +1,117 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A PerforceSource yields depots from a single Perforce connection configured
+// in Sourcegraph via the external services configuration. Each depot is
+// converted into a Git repository by gitserver.
+type PerforceSource struct {
+	svc     *ExternalService
+	config  *schema.PerforceConnection
+	exclude excludeFunc
+	client  *perforce.Client
+}
+
+// NewPerforceSource returns a new PerforceSource from the given external service.
+func NewPerforceSource(svc *ExternalService) (*PerforceSource, error) {
+	var c schema.PerforceConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newPerforceSource(svc, &c)
+}
+
+func newPerforceSource(svc *ExternalService, c *schema.PerforceConnection) (*PerforceSource, error) {
+	var eb excludeBuilder
+	for _, d := range c.Exclude {
+		eb.Exact(d.Depot)
+		eb.Pattern(d.Pattern)
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PerforceSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		client:  perforce.NewClient(c.P4Port, c.P4User, c.P4Passwd),
+	}, nil
+}
+
+// ListRepos returns all Perforce depots accessible to all connections configured
+// in Sourcegraph via the external services configuration.
+func (s PerforceSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	if len(s.config.Depots) > 0 {
+		s.listConfiguredDepots(ctx, results)
+		return
+	}
+	s.listAllDepots(ctx, results)
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s PerforceSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+func (s PerforceSource) makeRepo(d *perforce.Depot) *Repo {
+	urn := s.svc.URN()
+	return &Repo{
+		Name:         string(reposource.PerforceRepoName(s.config.RepositoryPathPattern, d.Path)),
+		URI:          string(reposource.PerforceRepoName("", d.Path)),
+		ExternalRepo: perforce.ExternalRepoSpec(d, s.config.P4Port),
+		Description:  d.Description,
+		// Depots are always private, since their files are only visible to
+		// the users the protections table grants access to.
+		Private: true,
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: perforce.CloneURL(s.config.P4Port, s.config.P4User, s.config.P4Passwd, d.Path),
+			},
+		},
+		Metadata: d,
+	}
+}
+
+func (s *PerforceSource) listConfiguredDepots(ctx context.Context, results chan SourceResult) {
+	for _, path := range s.config.Depots {
+		if s.exclude(path) {
+			continue
+		}
+
+		// Check that the depot path exists and can be read, so that typos
+		// are reported as sync errors rather than as clone failures.
+		if _, err := s.client.Changes(ctx, path, 1); err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "perforce.depots: path=%q", path)}
+			continue
+		}
+
+		results <- SourceResult{Source: s, Repo: s.makeRepo(&perforce.Depot{Path: path})}
+	}
+}
+
+func (s *PerforceSource) listAllDepots(ctx context.Context, results chan SourceResult) {
+	depots, err := s.client.Depots(ctx)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: errors.Wrap(err, "perforce.depots")}
+		return
+	}
+
+	for _, d := range depots {
+		if !s.exclude(d.Path) {
+			results <- SourceResult{Source: s, Repo: s.makeRepo(d)}
+		}
+	}
+}
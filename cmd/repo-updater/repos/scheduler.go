@@ -189,11 +189,15 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 				defer cancel()
 				defer s.updateQueue.remove(repo, true)
 
+				requestedAt := time.Now()
 				resp, err := requestRepoUpdate(ctx, repo, 1*time.Second)
 				if err != nil {
 					schedError.Inc()
 					log15.Warn("error requesting repo update", "uri", repo.Name, "err", err)
 				}
+				if RepoChangedHook != nil && err == nil && repoChanged(resp, requestedAt) {
+					RepoChangedHook(ctx, repo.ID)
+				}
 				if resp != nil && resp.LastFetched != nil && resp.LastChanged != nil {
 					// This is the heuristic that is described in the updateScheduler documentation.
 					// Update that documentation if you update this logic.
@@ -205,6 +209,27 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 	}
 }
 
+// RepoChangedHook, if non-nil, is called once an update request to gitserver reports that a
+// repository has been cloned or that its set of references has changed.
+var RepoChangedHook func(ctx context.Context, repo api.RepoID)
+
+// repoChanged determines if the given update response indicates that the repository was cloned
+// or that its set of references has changed since the update was requested.
+func repoChanged(resp *gitserverprotocol.RepoUpdateResponse, requestedAt time.Time) bool {
+	if resp == nil || resp.Error != "" {
+		return false
+	}
+	if resp.CloneInProgress {
+		// Clones requested by repo-updater are blocking, so a successful
+		// response indicates that the repository was newly cloned.
+		return true
+	}
+
+	// The last changed time is derived from the modification time of a file and
+	// may be truncated to a second.
+	return resp.LastChanged != nil && !resp.LastChanged.Before(requestedAt.Truncate(time.Second))
+}
+
 // requestRepoUpdate sends a request to gitserver to request an update.
 var requestRepoUpdate = func(ctx context.Context, repo configuredRepo, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
 	return gitserver.DefaultClient.RequestRepoUpdate(ctx, gitserver.Repo{Name: repo.Name, URL: repo.URL}, since)
@@ -1458,6 +1458,35 @@ func timePtr(t time.Time) *time.Time {
 	return &t
 }
 
+func Test_repoChanged(t *testing.T) {
+	requestedAt := defaultTime.Add(500 * time.Millisecond)
+	before := defaultTime.Add(-time.Minute)
+	truncated := defaultTime
+	after := defaultTime.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		resp *gitserverprotocol.RepoUpdateResponse
+		want bool
+	}{
+		{name: "no response"},
+		{name: "cloned", resp: &gitserverprotocol.RepoUpdateResponse{CloneInProgress: true}, want: true},
+		{name: "clone failed", resp: &gitserverprotocol.RepoUpdateResponse{CloneInProgress: true, Error: "oops"}},
+		{name: "unchanged", resp: &gitserverprotocol.RepoUpdateResponse{Cloned: true, LastChanged: &before}},
+		{name: "changed", resp: &gitserverprotocol.RepoUpdateResponse{Cloned: true, LastChanged: &after}, want: true},
+		{name: "changed truncated", resp: &gitserverprotocol.RepoUpdateResponse{Cloned: true, LastChanged: &truncated}, want: true},
+		{name: "update failed", resp: &gitserverprotocol.RepoUpdateResponse{Cloned: true, LastChanged: &after, Error: "oops"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if have := repoChanged(test.resp, requestedAt); have != test.want {
+				t.Errorf("unexpected result. want=%v have=%v", test.want, have)
+			}
+		})
+	}
+}
+
 func Test_updateQueue_Less(t *testing.T) {
 	q := &updateQueue{}
 	tests := []struct {
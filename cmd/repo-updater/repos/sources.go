@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
@@ -61,12 +62,20 @@ func NewSource(svc *ExternalService, cf *httpcli.Factory) (Source, error) {
 		return NewBitbucketServerSource(svc, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(svc, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(svc, cf)
 	case extsvc.KindGitolite:
 		return NewGitoliteSource(svc, cf)
 	case extsvc.KindPhabricator:
 		return NewPhabricatorSource(svc, cf)
 	case extsvc.KindAWSCodeCommit:
 		return NewAWSCodeCommitSource(svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(svc, cf)
+	case extsvc.KindPerforce:
+		return NewPerforceSource(svc)
 	case extsvc.KindOther:
 		return NewOtherSource(svc, cf)
 	default:
@@ -103,6 +112,20 @@ type ChangesetSource interface {
 	// ReopenChangeset will reopen the Changeset on the source, if it's closed.
 	// If not, it's a noop.
 	ReopenChangeset(context.Context, *Changeset) error
+	// MergeChangeset will merge the Changeset on the source with the given
+	// merge method. If the source doesn't support the method, a
+	// MergeMethodNotSupportedError is returned.
+	MergeChangeset(context.Context, *Changeset, campaigns.ChangesetMergeMethod) error
+}
+
+// MergeMethodNotSupportedError is returned by MergeChangeset if the codehost
+// doesn't support the requested merge method.
+type MergeMethodNotSupportedError struct {
+	Method campaigns.ChangesetMergeMethod
+}
+
+func (e MergeMethodNotSupportedError) Error() string {
+	return fmt.Sprintf("merge method %q is not supported by the codehost", e.Method)
 }
 
 // ChangesetsNotFoundError is returned by LoadChangesets if any of the passed
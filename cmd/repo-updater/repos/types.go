@@ -19,10 +19,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -142,10 +146,18 @@ func (e *ExternalService) Exclude(rs ...*Repo) error {
 		return e.excludeGitLabRepos(rs...)
 	case extsvc.KindBitbucketServer:
 		return e.excludeBitbucketServerRepos(rs...)
+	case extsvc.KindGitea:
+		return e.excludeGiteaRepos(rs...)
+	case extsvc.KindGerrit:
+		return e.excludeGerritRepos(rs...)
 	case extsvc.KindAWSCodeCommit:
 		return e.excludeAWSCodeCommitRepos(rs...)
+	case extsvc.KindAzureDevOps:
+		return e.excludeAzureDevOpsRepos(rs...)
 	case extsvc.KindGitolite:
 		return e.excludeGitoliteRepos(rs...)
+	case extsvc.KindPerforce:
+		return e.excludePerforceRepos(rs...)
 	case extsvc.KindOther:
 		return e.excludeOtherRepos(rs...)
 	default:
@@ -320,6 +332,152 @@ func (e *ExternalService) excludeBitbucketServerRepos(rs ...*Repo) error {
 	})
 }
 
+// excludeGerritRepos changes the configuration of a Gerrit external service to exclude the
+// given repos from being synced.
+func (e *ExternalService) excludeGerritRepos(rs ...*Repo) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	return e.config(extsvc.KindGerrit, func(v interface{}) (string, interface{}, error) {
+		c := v.(*schema.GerritConnection)
+		set := make(map[string]bool, len(c.Exclude))
+		for _, ex := range c.Exclude {
+			if ex.Name != "" {
+				set[ex.Name] = true
+			}
+		}
+
+		for _, r := range rs {
+			project, ok := r.Metadata.(*gerrit.Project)
+			if ok && project.Name != "" && !set[project.Name] {
+				c.Exclude = append(c.Exclude, &schema.ExcludedGerritProject{Name: project.Name})
+				set[project.Name] = true
+			}
+		}
+
+		return "exclude", c.Exclude, nil
+	})
+}
+
+// excludePerforceRepos changes the configuration of a Perforce external service to exclude the
+// given depots from being synced.
+func (e *ExternalService) excludePerforceRepos(rs ...*Repo) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	return e.config(extsvc.KindPerforce, func(v interface{}) (string, interface{}, error) {
+		c := v.(*schema.PerforceConnection)
+		set := make(map[string]bool, len(c.Exclude))
+		for _, ex := range c.Exclude {
+			if ex.Depot != "" {
+				set[ex.Depot] = true
+			}
+		}
+
+		for _, r := range rs {
+			depot, ok := r.Metadata.(*perforce.Depot)
+			if ok && depot.Path != "" && !set[depot.Path] {
+				c.Exclude = append(c.Exclude, &schema.ExcludedPerforceDepot{Depot: depot.Path})
+				set[depot.Path] = true
+			}
+		}
+
+		return "exclude", c.Exclude, nil
+	})
+}
+
+// excludeAzureDevOpsRepos changes the configuration of an Azure DevOps external service to
+// exclude the given repos from being synced.
+func (e *ExternalService) excludeAzureDevOpsRepos(rs ...*Repo) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	return e.config(extsvc.KindAzureDevOps, func(v interface{}) (string, interface{}, error) {
+		c := v.(*schema.AzureDevOpsConnection)
+		set := make(map[string]bool, len(c.Exclude)*2)
+		for _, ex := range c.Exclude {
+			if ex.Id != "" {
+				set[ex.Id] = true
+			}
+
+			if ex.Name != "" {
+				set[strings.ToLower(ex.Name)] = true
+			}
+		}
+
+		for _, r := range rs {
+			repo, ok := r.Metadata.(*azuredevops.Repository)
+			if !ok {
+				continue
+			}
+
+			name := strings.ToLower(repo.FullName())
+			if !set[name] && !set[repo.ID] {
+				c.Exclude = append(c.Exclude, &schema.ExcludedAzureDevOpsRepo{
+					Name: repo.FullName(),
+					Id:   repo.ID,
+				})
+				set[name] = true
+				set[repo.ID] = true
+			}
+		}
+
+		return "exclude", c.Exclude, nil
+	})
+}
+
+// excludeGiteaRepos changes the configuration of a Gitea external service to exclude the
+// given repos from being synced.
+func (e *ExternalService) excludeGiteaRepos(rs ...*Repo) error {
+	if len(rs) == 0 {
+		return nil
+	}
+
+	return e.config(extsvc.KindGitea, func(v interface{}) (string, interface{}, error) {
+		c := v.(*schema.GiteaConnection)
+		set := make(map[string]bool, len(c.Exclude)*2)
+		for _, ex := range c.Exclude {
+			if ex.Id != 0 {
+				set[strconv.Itoa(ex.Id)] = true
+			}
+
+			if ex.Name != "" {
+				set[strings.ToLower(ex.Name)] = true
+			}
+		}
+
+		for _, r := range rs {
+			repo, ok := r.Metadata.(*gitea.Repo)
+			if !ok {
+				continue
+			}
+
+			id := strconv.FormatInt(repo.ID, 10)
+			name := repo.FullName
+
+			if !set[strings.ToLower(name)] && !set[id] {
+				c.Exclude = append(c.Exclude, &schema.ExcludedGiteaRepo{
+					Name: name,
+					Id:   int(repo.ID),
+				})
+
+				if id != "" {
+					set[id] = true
+				}
+
+				if name != "" {
+					set[strings.ToLower(name)] = true
+				}
+			}
+		}
+
+		return "exclude", c.Exclude, nil
+	})
+}
+
 // excludeGitoliteRepos changes the configuration of a Gitolite external service to exclude the
 // given repos from being synced.
 func (e *ExternalService) excludeGitoliteRepos(rs ...*Repo) error {
@@ -484,14 +642,22 @@ func (e ExternalService) schema() string {
 	switch strings.ToUpper(e.Kind) {
 	case extsvc.KindAWSCodeCommit:
 		return schema.AWSCodeCommitSchemaJSON
+	case extsvc.KindAzureDevOps:
+		return schema.AzureDevOpsSchemaJSON
 	case extsvc.KindBitbucketServer:
 		return schema.BitbucketServerSchemaJSON
+	case extsvc.KindGerrit:
+		return schema.GerritSchemaJSON
+	case extsvc.KindGitea:
+		return schema.GiteaSchemaJSON
 	case extsvc.KindGitHub:
 		return schema.GitHubSchemaJSON
 	case extsvc.KindGitLab:
 		return schema.GitLabSchemaJSON
 	case extsvc.KindGitolite:
 		return schema.GitoliteSchemaJSON
+	case extsvc.KindPerforce:
+		return schema.PerforceSchemaJSON
 	case extsvc.KindPhabricator:
 		return schema.PhabricatorSchemaJSON
 	case extsvc.KindOther:
@@ -10,10 +10,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -70,6 +74,45 @@ func TestExternalService_Exclude(t *testing.T) {
 		UpdatedAt: now,
 	}
 
+	giteaService := ExternalService{
+		Kind:        extsvc.KindGitea,
+		DisplayName: "Gitea",
+		Config: `{
+			// Some comment
+			"url": "https://gitea.mycorp.com",
+			"token": "secret",
+			"repositoryQuery": ["none"]
+		}`,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	gerritService := ExternalService{
+		Kind:        extsvc.KindGerrit,
+		DisplayName: "Gerrit",
+		Config: `{
+			// Some comment
+			"url": "https://gerrit.mycorp.com",
+			"username": "admin",
+			"password": "secret"
+		}`,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	azureDevOpsService := ExternalService{
+		Kind:        extsvc.KindAzureDevOps,
+		DisplayName: "Azure DevOps",
+		Config: `{
+			// Some comment
+			"url": "https://dev.azure.com/mycorp",
+			"username": "admin",
+			"token": "secret"
+		}`,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
 	awsCodeCommitService := ExternalService{
 		ID:          9,
 		Kind:        extsvc.KindAWSCodeCommit,
@@ -96,6 +139,19 @@ func TestExternalService_Exclude(t *testing.T) {
 		UpdatedAt: now,
 	}
 
+	perforceService := ExternalService{
+		Kind:        extsvc.KindPerforce,
+		DisplayName: "Perforce",
+		Config: `{
+			// Some comment
+			"p4.port": "ssl:perforce.mycorp.com:1666",
+			"p4.user": "admin",
+			"p4.passwd": "secret"
+		}`,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
 	otherService := ExternalService{
 		Kind:        extsvc.KindOther,
 		DisplayName: "Other code hosts",
@@ -151,6 +207,30 @@ func TestExternalService_Exclude(t *testing.T) {
 				},
 			},
 		},
+		{
+			Metadata: &gitea.Repo{
+				ID:       1,
+				FullName: "org/foo",
+			},
+		},
+		{
+			Metadata: &gitea.Repo{
+				FullName: "org/baz",
+			},
+		},
+		{
+			Metadata: &gerrit.Project{
+				ID:   "org%2Ffoo",
+				Name: "org/foo",
+			},
+		},
+		{
+			Metadata: &azuredevops.Repository{
+				ID:      "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+				Name:    "foo",
+				Project: azuredevops.Project{Name: "org"},
+			},
+		},
 		{
 			Metadata: &awscodecommit.Repository{
 				ID:   "f001337a-3450-46fd-b7d2-650c0EXAMPLE",
@@ -181,6 +261,9 @@ func TestExternalService_Exclude(t *testing.T) {
 		{
 			Metadata: &gitolite.Repo{Name: "foo"},
 		},
+		{
+			Metadata: &perforce.Depot{Path: "//foo/"},
+		},
 	}
 
 	var testCases []testCase
@@ -226,6 +309,19 @@ func TestExternalService_Exclude(t *testing.T) {
 					]
 				}`)
 			}),
+			giteaService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://gitea.mycorp.com",
+					"token": "secret",
+					"repositoryQuery": ["none"],
+					"exclude": [
+						{"id": 1},
+						{"name": "org/BAZ"}
+					]
+				}`)
+			}),
 			awsCodeCommitService.With(func(e *ExternalService) {
 				e.Config = formatJSON(t, `
 				{
@@ -251,6 +347,42 @@ func TestExternalService_Exclude(t *testing.T) {
 					]
 				}`)
 			}),
+			perforceService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"p4.port": "ssl:perforce.mycorp.com:1666",
+					"p4.user": "admin",
+					"p4.passwd": "secret",
+					"exclude": [
+						{"depot": "//foo/"}
+					]
+				}`)
+			}),
+			gerritService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://gerrit.mycorp.com",
+					"username": "admin",
+					"password": "secret",
+					"exclude": [
+						{"name": "org/foo"}
+					]
+				}`)
+			}),
+			azureDevOpsService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://dev.azure.com/mycorp",
+					"username": "admin",
+					"token": "secret",
+					"exclude": [
+						{"name": "org/foo"}
+					]
+				}`)
+			}),
 			&otherService,
 		}
 
@@ -300,6 +432,18 @@ func TestExternalService_Exclude(t *testing.T) {
 					]
 				}`)
 			}),
+			giteaService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://gitea.mycorp.com",
+					"token": "secret",
+					"repositoryQuery": ["none"],
+					"exclude": [
+						{"name": "org/boo"},
+					]
+				}`)
+			}),
 			awsCodeCommitService.With(func(e *ExternalService) {
 				e.Config = formatJSON(t, `
 				{
@@ -324,6 +468,42 @@ func TestExternalService_Exclude(t *testing.T) {
 					]
 				}`)
 			}),
+			perforceService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"p4.port": "ssl:perforce.mycorp.com:1666",
+					"p4.user": "admin",
+					"p4.passwd": "secret",
+					"exclude": [
+						{"depot": "//boo/"}
+					]
+				}`)
+			}),
+			gerritService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://gerrit.mycorp.com",
+					"username": "admin",
+					"password": "secret",
+					"exclude": [
+						{"name": "org/boo"}
+					]
+				}`)
+			}),
+			azureDevOpsService.With(func(e *ExternalService) {
+				e.Config = formatJSON(t, `
+				{
+					// Some comment
+					"url": "https://dev.azure.com/mycorp",
+					"username": "admin",
+					"token": "secret",
+					"exclude": [
+						{"name": "org/boo"}
+					]
+				}`)
+			}),
 			otherService.With(func(e *ExternalService) {
 				e.Config = formatJSON(t, `
 				{
@@ -385,6 +565,20 @@ func TestExternalService_Exclude(t *testing.T) {
 						]
 					}`)
 				}),
+				giteaService.With(func(e *ExternalService) {
+					e.Config = formatJSON(t, `
+					{
+						// Some comment
+						"url": "https://gitea.mycorp.com",
+						"token": "secret",
+						"repositoryQuery": ["none"],
+						"exclude": [
+							{"name": "org/boo"},
+							{"id": 1, "name": "org/foo"},
+							{"name": "org/baz"}
+						]
+					}`)
+				}),
 				awsCodeCommitService.With(func(e *ExternalService) {
 					e.Config = formatJSON(t, `
 					{
@@ -412,6 +606,45 @@ func TestExternalService_Exclude(t *testing.T) {
 						]
 					}`)
 				}),
+				perforceService.With(func(e *ExternalService) {
+					e.Config = formatJSON(t, `
+					{
+						// Some comment
+						"p4.port": "ssl:perforce.mycorp.com:1666",
+						"p4.user": "admin",
+						"p4.passwd": "secret",
+						"exclude": [
+							{"depot": "//boo/"},
+							{"depot": "//foo/"}
+						]
+					}`)
+				}),
+				gerritService.With(func(e *ExternalService) {
+					e.Config = formatJSON(t, `
+					{
+						// Some comment
+						"url": "https://gerrit.mycorp.com",
+						"username": "admin",
+						"password": "secret",
+						"exclude": [
+							{"name": "org/boo"},
+							{"name": "org/foo"}
+						]
+					}`)
+				}),
+				azureDevOpsService.With(func(e *ExternalService) {
+					e.Config = formatJSON(t, `
+					{
+						// Some comment
+						"url": "https://dev.azure.com/mycorp",
+						"username": "admin",
+						"token": "secret",
+						"exclude": [
+							{"name": "org/boo"},
+							{"id": "5febef5a-833d-4e14-b9c0-14cb638f91e6", "name": "org/foo"}
+						]
+					}`)
+				}),
 				otherService.With(func(e *ExternalService) {
 					e.Config = formatJSON(t, `
 					{
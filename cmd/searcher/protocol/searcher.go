@@ -50,6 +50,16 @@ type PatternInfo struct {
 	// is true, otherwise a fixed string. eg "route variable"
 	Pattern string
 
+	// PatternExpression, if non-nil, is a boolean expression of patterns that
+	// is searched for instead of Pattern. Its patterns are interpreted like
+	// Pattern, according to IsRegExp, IsWordMatch and IsCaseSensitive. A file
+	// matches if its content matches the expression, or if PatternMatchesPath
+	// is true and its path does.
+	//
+	// Form decoding does not support nested structures, so it is sent as JSON
+	// in the form value "PatternExpression".
+	PatternExpression *PatternExpression `schema:"-"`
+
 	// IsNegated if true will invert the matching logic for regexp searches. IsNegated=true is
 	// not supported for structural searches.
 	IsNegated bool
@@ -109,6 +119,9 @@ type PatternInfo struct {
 
 func (p *PatternInfo) String() string {
 	args := []string{fmt.Sprintf("%q", p.Pattern)}
+	if p.PatternExpression != nil {
+		args[0] = p.PatternExpression.String()
+	}
 	if p.IsRegExp {
 		args = append(args, "re")
 	}
@@ -155,6 +168,52 @@ func (p *PatternInfo) String() string {
 	return fmt.Sprintf("PatternInfo{%s}", strings.Join(args, ","))
 }
 
+// PatternExpression is a node of a boolean expression of patterns. Exactly one
+// of Pattern, And and Or is set.
+type PatternExpression struct {
+	// Pattern is the pattern of a leaf node.
+	Pattern string `json:",omitempty"`
+
+	// And is the list of operands that must all match.
+	And []*PatternExpression `json:",omitempty"`
+
+	// Or is the list of operands of which at least one must match.
+	Or []*PatternExpression `json:",omitempty"`
+}
+
+// Patterns returns the patterns of the leaves of e in depth-first order. The
+// index of a pattern in this list is the PatternIndex of the line matches it
+// produces.
+func (e *PatternExpression) Patterns() []string {
+	operands := e.And
+	if e.Or != nil {
+		operands = e.Or
+	}
+	if operands == nil {
+		return []string{e.Pattern}
+	}
+	var patterns []string
+	for _, operand := range operands {
+		patterns = append(patterns, operand.Patterns()...)
+	}
+	return patterns
+}
+
+func (e *PatternExpression) String() string {
+	operator, operands := "and", e.And
+	if e.Or != nil {
+		operator, operands = "or", e.Or
+	}
+	if operands == nil {
+		return fmt.Sprintf("%q", e.Pattern)
+	}
+	args := make([]string, len(operands))
+	for i, operand := range operands {
+		args[i] = operand.String()
+	}
+	return "(" + strings.Join(args, " "+operator+" ") + ")"
+}
+
 // Response represents the response from a Search request.
 type Response struct {
 	Matches []FileMatch
@@ -191,6 +250,11 @@ type LineMatch struct {
 	// Offsets and lengths are measured in characters, not bytes.
 	OffsetAndLengths [][2]int
 
+	// PatternIndex is the index of the pattern that matched in the list
+	// returned by PatternExpression.Patterns. It is 0 for searches without a
+	// PatternExpression.
+	PatternIndex int
+
 	// LimitHit is true if OffsetAndLengths may not include all OffsetAndLengths.
 	LimitHit bool
 }
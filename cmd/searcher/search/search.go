@@ -80,6 +80,13 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "failed to decode form: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if expr := r.Form.Get("PatternExpression"); expr != "" {
+		p.PatternExpression = new(protocol.PatternExpression)
+		if err := json.Unmarshal([]byte(expr), p.PatternExpression); err != nil {
+			http.Error(w, "failed to decode pattern expression: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 	if p.Deadline != "" {
 		var deadline time.Time
 		if err := deadline.UnmarshalText([]byte(p.Deadline)); err != nil {
@@ -141,6 +148,9 @@ func (s *Service) search(ctx context.Context, p *protocol.Request) (matches []pr
 	span.SetTag("url", p.URL)
 	span.SetTag("commit", p.Commit)
 	span.SetTag("pattern", p.Pattern)
+	if p.PatternExpression != nil {
+		span.SetTag("patternExpression", p.PatternExpression.String())
+	}
 	span.SetTag("isRegExp", strconv.FormatBool(p.IsRegExp))
 	span.SetTag("isStructuralPat", strconv.FormatBool(p.IsStructuralPat))
 	span.SetTag("languages", p.Languages)
@@ -248,9 +258,15 @@ func validateParams(p *protocol.Request) error {
 	if len(p.Commit) != 40 {
 		return errors.Errorf("Commit must be resolved (Commit=%q)", p.Commit)
 	}
-	if p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 {
+	if p.Pattern == "" && p.PatternExpression == nil && p.ExcludePattern == "" && len(p.IncludePatterns) == 0 {
 		return errors.New("At least one of pattern and include/exclude pattners must be non-empty")
 	}
+	if p.PatternExpression != nil && p.Pattern != "" {
+		return errors.New("Pattern and PatternExpression are mutually exclusive")
+	}
+	if p.PatternExpression != nil && p.IsStructuralPat {
+		return errors.New("Pattern expressions are not supported for structural searches")
+	}
 	if p.IsNegated && p.IsStructuralPat {
 		return errors.New("Negated patterns are not supported for structural searches")
 	}
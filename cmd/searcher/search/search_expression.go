@@ -0,0 +1,170 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+// patternExpression is a compiled protocol.PatternExpression. It evaluates
+// the expression on a file in a single pass, searching for each of its
+// patterns at most once. It is safe for concurrent use, since the input is
+// transformed by the readerGrep using it.
+type patternExpression struct {
+	source *protocol.PatternExpression
+
+	// leaves are the compiled patterns of the leaves of the expression, in
+	// depth-first order. The index of a leaf is the PatternIndex of its line
+	// matches.
+	leaves []*readerGrep
+
+	root *patternNode
+}
+
+// patternNode is a node of a compiled pattern expression.
+type patternNode struct {
+	// leaf is the index of the pattern of a leaf node in
+	// patternExpression.leaves, or -1 for an operator.
+	leaf int
+
+	// and is true if all operands must match, and false if any operand must
+	// match.
+	and      bool
+	operands []*patternNode
+}
+
+// compileExpression compiles p.PatternExpression. Its patterns are
+// interpreted according to the options of p.
+func compileExpression(p *protocol.PatternInfo) (*patternExpression, error) {
+	e := &patternExpression{source: p.PatternExpression}
+	root, err := e.compileNode(p.PatternExpression, p)
+	if err != nil {
+		return nil, err
+	}
+	e.root = root
+	return e, nil
+}
+
+func (e *patternExpression) compileNode(node *protocol.PatternExpression, p *protocol.PatternInfo) (*patternNode, error) {
+	if node == nil {
+		return nil, errors.New("pattern expression has a nil operand")
+	}
+
+	switch {
+	case node.And != nil && (node.Or != nil || node.Pattern != ""),
+		node.Or != nil && node.Pattern != "":
+		return nil, errors.New("pattern expression node must have exactly one of Pattern, And and Or")
+
+	case node.And == nil && node.Or == nil:
+		if node.Pattern == "" {
+			return nil, errors.New("pattern expression has an empty pattern")
+		}
+		re, literalSubstring, err := compilePattern(node.Pattern, p)
+		if err != nil {
+			return nil, err
+		}
+		e.leaves = append(e.leaves, &readerGrep{
+			re:               re,
+			ignoreCase:       !p.IsCaseSensitive,
+			literalSubstring: literalSubstring,
+		})
+		return &patternNode{leaf: len(e.leaves) - 1}, nil
+	}
+
+	n := &patternNode{leaf: -1, and: node.And != nil}
+	operands := node.And
+	if !n.and {
+		operands = node.Or
+	}
+	if len(operands) == 0 {
+		return nil, errors.New("pattern expression has an operator without operands")
+	}
+	for _, operand := range operands {
+		o, err := e.compileNode(operand, p)
+		if err != nil {
+			return nil, err
+		}
+		n.operands = append(n.operands, o)
+	}
+	return n, nil
+}
+
+func (e *patternExpression) String() string {
+	return e.source.String()
+}
+
+// matchString returns whether the expression matches s. It is intended to be
+// used to match file paths.
+func (e *patternExpression) matchString(s string) bool {
+	_, match := e.root.eval(func(leaf int) bool {
+		return e.leaves[leaf].matchString(s)
+	}, nil)
+	return match
+}
+
+// find returns the line matches of the patterns that make the expression match
+// fileMatchBuf, or nil if it doesn't match. Line matches are sorted by line
+// number, and on the same line by the index of their pattern. LimitHit is true
+// if some matches may not have been included in the result.
+func (e *patternExpression) find(fileBuf, fileMatchBuf []byte) (matches []protocol.LineMatch, limitHit bool) {
+	found := make([][]protocol.LineMatch, len(e.leaves))
+	limitHits := make([]bool, len(e.leaves))
+	leaves, match := e.root.eval(func(leaf int) bool {
+		found[leaf], limitHits[leaf] = e.leaves[leaf].find(fileBuf, fileMatchBuf)
+		return len(found[leaf]) > 0
+	}, nil)
+	if !match {
+		return nil, false
+	}
+
+	for _, leaf := range leaves {
+		for _, m := range found[leaf] {
+			m.PatternIndex = leaf
+			matches = append(matches, m)
+		}
+		limitHit = limitHit || limitHits[leaf]
+	}
+
+	// leaves is in depth-first order, so a stable sort keeps the matches on
+	// the same line ordered by pattern.
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].LineNumber < matches[j].LineNumber
+	})
+	if len(matches) > maxLineMatches {
+		matches = matches[:maxLineMatches]
+		limitHit = true
+	}
+	return matches, limitHit
+}
+
+// eval returns whether n matches, where leafMatches reports whether the leaf
+// with the given index matches. It is called at most once per leaf. The
+// leaves that make n match are appended to leaves: all of them for And, and
+// those of the matching operands for Or. The operands of Or are all evaluated,
+// so that all of their matches are found.
+func (n *patternNode) eval(leafMatches func(leaf int) bool, leaves []int) ([]int, bool) {
+	if n.leaf >= 0 {
+		if !leafMatches(n.leaf) {
+			return leaves, false
+		}
+		return append(leaves, n.leaf), true
+	}
+
+	start := len(leaves)
+	match := n.and
+	for _, operand := range n.operands {
+		var ok bool
+		leaves, ok = operand.eval(leafMatches, leaves)
+		if n.and && !ok {
+			return leaves[:start], false
+		}
+		if !n.and && ok {
+			match = true
+		}
+	}
+	if !match {
+		return leaves[:start], false
+	}
+	return leaves, true
+}
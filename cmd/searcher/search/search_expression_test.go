@@ -0,0 +1,158 @@
+package search
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+)
+
+func TestPatternExpression(t *testing.T) {
+	zipData, err := testutil.CreateZip(map[string]string{
+		"a": "foo\nbar\nbaz\n",
+		"b": "foo\nqux\n",
+		"c": "bar\nbaz foo\n",
+		"d": "nothing\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := store.MockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf := func(pattern string) *protocol.PatternExpression {
+		return &protocol.PatternExpression{Pattern: pattern}
+	}
+
+	type lineMatch struct {
+		line, pattern int
+	}
+	cases := []struct {
+		name string
+		expr *protocol.PatternExpression
+		want map[string][]lineMatch
+	}{
+		{
+			name: "leaf",
+			expr: leaf("bar"),
+			want: map[string][]lineMatch{
+				"a": {{1, 0}},
+				"c": {{0, 0}},
+			},
+		},
+		{
+			name: "and",
+			expr: &protocol.PatternExpression{And: []*protocol.PatternExpression{leaf("foo"), leaf("baz")}},
+			want: map[string][]lineMatch{
+				"a": {{0, 0}, {2, 1}},
+				"c": {{1, 0}, {1, 1}},
+			},
+		},
+		{
+			name: "or",
+			expr: &protocol.PatternExpression{Or: []*protocol.PatternExpression{leaf("qux"), leaf("bar")}},
+			want: map[string][]lineMatch{
+				"a": {{1, 1}},
+				"b": {{1, 0}},
+				"c": {{0, 1}},
+			},
+		},
+		{
+			name: "nested",
+			expr: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+				leaf("foo"),
+				{Or: []*protocol.PatternExpression{leaf("qux"), leaf("nope")}},
+			}},
+			want: map[string][]lineMatch{
+				"b": {{0, 0}, {1, 1}},
+			},
+		},
+		{
+			name: "failing or operand",
+			expr: &protocol.PatternExpression{Or: []*protocol.PatternExpression{
+				{And: []*protocol.PatternExpression{leaf("foo"), leaf("nope")}},
+				leaf("qux"),
+			}},
+			want: map[string][]lineMatch{
+				"b": {{1, 2}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rg, err := compile(&protocol.PatternInfo{PatternExpression: tc.expr})
+			if err != nil {
+				t.Fatal(err)
+			}
+			fileMatches, _, err := regexSearch(context.Background(), rg, zf, 10, true, false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Sort(sortByPath(fileMatches))
+
+			got := map[string][]lineMatch{}
+			for _, fm := range fileMatches {
+				for _, lm := range fm.LineMatches {
+					got[fm.Path] = append(got[fm.Path], lineMatch{lm.LineNumber, lm.PatternIndex})
+				}
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got line matches %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPatternExpression_matchPath(t *testing.T) {
+	zipData, err := testutil.CreateZip(map[string]string{
+		"foo/bar.go": "",
+		"foo/baz.go": "",
+		"bar.go":     "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := store.MockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rg, err := compile(&protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+		{Pattern: "foo"},
+		{Pattern: "bar"},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileMatches, _, err := regexSearch(context.Background(), rg, zf, 10, false, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fileMatches) != 1 || fileMatches[0].Path != "foo/bar.go" {
+		t.Fatalf("got file matches %v, want foo/bar.go", fileMatches)
+	}
+}
+
+func TestCompileExpression_invalid(t *testing.T) {
+	for name, expr := range map[string]*protocol.PatternExpression{
+		"empty pattern":     {},
+		"no operands":       {And: []*protocol.PatternExpression{}},
+		"nil operand":       {Or: []*protocol.PatternExpression{nil}},
+		"pattern and and":   {Pattern: "a", And: []*protocol.PatternExpression{{Pattern: "b"}}},
+		"and and or":        {And: []*protocol.PatternExpression{{Pattern: "a"}}, Or: []*protocol.PatternExpression{{Pattern: "b"}}},
+		"bad nested regexp": {Or: []*protocol.PatternExpression{{Pattern: "a"}, {Pattern: `\F`}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := compile(&protocol.PatternInfo{PatternExpression: expr, IsRegExp: true}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -43,6 +43,10 @@ type readerGrep struct {
 	// re is the regexp to match, or nil if empty ("match all files' content").
 	re *regexp.Regexp
 
+	// expr is the boolean expression of patterns to match instead of re, or
+	// nil if the search has a single pattern.
+	expr *patternExpression
+
 	// ignoreCase if true means we need to do case insensitive matching.
 	ignoreCase bool
 
@@ -68,48 +72,16 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	var (
 		re               *regexp.Regexp
 		literalSubstring []byte
+		expr             *patternExpression
+		err              error
 	)
-	if p.Pattern != "" {
-		expr := p.Pattern
-		if !p.IsRegExp {
-			expr = regexp.QuoteMeta(expr)
-		}
-		if p.IsWordMatch {
-			expr = `\b` + expr + `\b`
-		}
-		if p.IsRegExp {
-			// We don't do the search line by line, therefore we want the
-			// regex engine to consider newlines for anchors (^$).
-			expr = "(?m:" + expr + ")"
-		}
-		if !p.IsCaseSensitive {
-			// We don't just use (?i) because regexp library doesn't seem
-			// to contain good optimizations for case insensitive
-			// search. Instead we lowercase the input and pattern.
-			re, err := syntax.Parse(expr, syntax.Perl)
-			if err != nil {
-				return nil, err
-			}
-			lowerRegexpASCII(re)
-			expr = re.String()
-		}
-
-		var err error
-		re, err = regexp.Compile(expr)
-		if err != nil {
-			return nil, err
-		}
-
-		// Only use literalSubstring optimization if the regex engine doesn't
-		// have a prefix to use.
-		if pre, _ := re.LiteralPrefix(); pre == "" {
-			ast, err := syntax.Parse(expr, syntax.Perl)
-			if err != nil {
-				return nil, err
-			}
-			ast = ast.Simplify()
-			literalSubstring = []byte(longestLiteral(ast))
-		}
+	if p.PatternExpression != nil {
+		expr, err = compileExpression(p)
+	} else if p.Pattern != "" {
+		re, literalSubstring, err = compilePattern(p.Pattern, p)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	pathOptions := pathmatch.CompileOptions{
@@ -123,17 +95,68 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 
 	return &readerGrep{
 		re:               re,
+		expr:             expr,
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
 		literalSubstring: literalSubstring,
 	}, nil
 }
 
+// compilePattern returns the regexp for matching pattern, which is
+// interpreted according to the options of p, and the literal substring that
+// is guaranteed to appear in its matches (if the regexp has no literal
+// prefix).
+func compilePattern(pattern string, p *protocol.PatternInfo) (*regexp.Regexp, []byte, error) {
+	expr := pattern
+	if !p.IsRegExp {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if p.IsWordMatch {
+		expr = `\b` + expr + `\b`
+	}
+	if p.IsRegExp {
+		// We don't do the search line by line, therefore we want the
+		// regex engine to consider newlines for anchors (^$).
+		expr = "(?m:" + expr + ")"
+	}
+	if !p.IsCaseSensitive {
+		// We don't just use (?i) because regexp library doesn't seem
+		// to contain good optimizations for case insensitive
+		// search. Instead we lowercase the input and pattern.
+		re, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, nil, err
+		}
+		lowerRegexpASCII(re)
+		expr = re.String()
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only use literalSubstring optimization if the regex engine doesn't
+	// have a prefix to use.
+	var literalSubstring []byte
+	if pre, _ := re.LiteralPrefix(); pre == "" {
+		ast, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, nil, err
+		}
+		ast = ast.Simplify()
+		literalSubstring = []byte(longestLiteral(ast))
+	}
+
+	return re, literalSubstring, nil
+}
+
 // Copy returns a copied version of rg that is safe to use from another
 // goroutine.
 func (rg *readerGrep) Copy() *readerGrep {
 	return &readerGrep{
 		re:               rg.re,
+		expr:             rg.expr,
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
 		literalSubstring: rg.literalSubstring,
@@ -143,6 +166,9 @@ func (rg *readerGrep) Copy() *readerGrep {
 // matchString returns whether rg's regexp pattern matches s. It is intended to be
 // used to match file paths.
 func (rg *readerGrep) matchString(s string) bool {
+	if rg.expr != nil {
+		return rg.expr.matchString(s)
+	}
 	if rg.re == nil {
 		return true
 	}
@@ -174,6 +200,18 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 		bytesToLowerASCII(fileMatchBuf, fileBuf)
 	}
 
+	if rg.expr != nil {
+		matches, limitHit = rg.expr.find(fileBuf, fileMatchBuf)
+	} else {
+		matches, limitHit = rg.find(fileBuf, fileMatchBuf)
+	}
+	return matches, limitHit, nil
+}
+
+// find returns a LineMatch for each line of fileBuf that re matches in
+// fileMatchBuf, which is fileBuf as transformed for matching. LimitHit is true
+// if some matches may not have been included in the result.
+func (rg *readerGrep) find(fileBuf, fileMatchBuf []byte) (matches []protocol.LineMatch, limitHit bool) {
 	// Most files will not have a match and we bound the number of matched
 	// files we return. So we can avoid the overhead of parsing out new lines
 	// and repeatedly running the regex engine by running a single match over
@@ -182,7 +220,7 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 	// per-line. Additionally if we have a non-empty literalSubstring, we use
 	// that to prune out files since doing bytes.Index is very fast.
 	if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
-		return nil, false, nil
+		return nil, false
 	}
 
 	locs := rg.re.FindAllIndex(fileMatchBuf, maxLineMatches+1)
@@ -224,7 +262,7 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 			break
 		}
 	}
-	return matches, limitHit, nil
+	return matches, limitHit
 }
 
 func hydrateLineNumbers(fileBuf []byte, lastLineNumber, lastMatchIndex, lineStart int, match []int) (lineNumber, matchIndex int) {
@@ -301,6 +339,9 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 	if rg.re != nil {
 		span.SetTag("re", rg.re.String())
 	}
+	if rg.expr != nil {
+		span.SetTag("expr", rg.expr.String())
+	}
 	span.SetTag("path", rg.matchPath.String())
 	defer func() {
 		if err != nil {
@@ -337,7 +378,7 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *store.ZipFile, fileMat
 		matches   = []protocol.FileMatch{}
 	)
 
-	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
+	if (rg.re == nil && rg.expr == nil) || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
 		// so is effectively matching only on file paths).
 		for _, f := range files {
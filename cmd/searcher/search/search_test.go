@@ -76,7 +76,7 @@ main.go:5:func main() {
 `},
 
 		// https://github.com/sourcegraph/sourcegraph/issues/8155
-		{protocol.PatternInfo{Pattern: "^func", IsRegExp: true}, `
+		{protocol.PatternInfo{Pattern: "^func"}, `
 main.go:5:func main() {
 `},
 		{protocol.PatternInfo{Pattern: "^FuNc", IsRegExp: true}, `
@@ -209,6 +209,41 @@ README.md
 abc.txt
 file++.plus
 milton.png
+`},
+
+		{protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+			{Pattern: "hello"},
+			{Pattern: "import"},
+		}}}, `
+main.go:3:import "fmt"
+main.go:6:	fmt.Println("Hello world")
+`},
+		{protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{Or: []*protocol.PatternExpression{
+			{Pattern: "example"},
+			{Pattern: "println"},
+		}}}, `
+README.md:3:Hello world example in go
+main.go:6:	fmt.Println("Hello world")
+`},
+		{protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+			{Pattern: "package"},
+			{Or: []*protocol.PatternExpression{{Pattern: "doesnotmatch"}, {Pattern: "^func"}}},
+		}}, IsRegExp: true}, `
+main.go:1:package main
+main.go:5:func main() {
+`},
+		{protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+			{Pattern: "package"},
+			{Pattern: "doesnotmatch"},
+		}}}, ""},
+		{protocol.PatternInfo{PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+			{Pattern: "hello"},
+			{Pattern: "import"},
+		}}, IsNegated: true}, `
+README.md
+abc.txt
+file++.plus
+milton.png
 `},
 	}
 
@@ -363,6 +398,44 @@ func TestSearch_badrequest(t *testing.T) {
 			},
 		},
 
+		// Both pattern and pattern expression
+		{
+			Repo:   "foo",
+			URL:    "u",
+			Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			PatternInfo: protocol.PatternInfo{
+				Pattern:           "test",
+				PatternExpression: &protocol.PatternExpression{Pattern: "test"},
+			},
+		},
+
+		// Empty pattern in pattern expression
+		{
+			Repo:   "foo",
+			URL:    "u",
+			Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			PatternInfo: protocol.PatternInfo{
+				PatternExpression: &protocol.PatternExpression{Or: []*protocol.PatternExpression{
+					{Pattern: "test"},
+					{},
+				}},
+			},
+		},
+
+		// Bad regexp in pattern expression
+		{
+			Repo:   "foo",
+			URL:    "u",
+			Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			PatternInfo: protocol.PatternInfo{
+				PatternExpression: &protocol.PatternExpression{And: []*protocol.PatternExpression{
+					{Pattern: "test"},
+					{Pattern: `\F`},
+				}},
+				IsRegExp: true,
+			},
+		},
+
 		// structural search with negated pattern
 		{
 			Repo:   "foo",
@@ -435,6 +508,13 @@ func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 	if p.IsNegated {
 		form.Set("IsNegated", "true")
 	}
+	if p.PatternExpression != nil {
+		expr, err := json.Marshal(p.PatternExpression)
+		if err != nil {
+			return nil, err
+		}
+		form.Set("PatternExpression", string(expr))
+	}
 	resp, err := http.PostForm(u, form)
 	if err != nil {
 		return nil, err
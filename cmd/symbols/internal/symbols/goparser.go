@@ -0,0 +1,187 @@
+package symbols
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+// GoParser is a Parser for Go files based on go/parser. Unlike ctags, it knows
+// the receiver of methods and the types that fields and interface methods
+// belong to.
+//
+// The kinds of the symbols it returns are package, func, method, const, var,
+// type, struct, interface and field.
+type GoParser struct{}
+
+var _ Parser = GoParser{}
+
+// Parse returns the top-level symbols of a Go file, and the fields and methods
+// of the types it declares. Files with syntax errors are parsed as far as
+// possible.
+func (GoParser) Parse(path string, content []byte) ([]protocol.Symbol, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, 0)
+	if file == nil {
+		return nil, err
+	}
+
+	g := &goSymbols{
+		path:  path,
+		fset:  fset,
+		lines: bytes.Split(content, []byte("\n")),
+		kinds: map[string]string{},
+	}
+
+	// Methods can be declared before the type of their receiver, so we need to
+	// know the kinds of all types first.
+	for _, decl := range file.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.TYPE {
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					g.kinds[ts.Name.Name] = goTypeKind(ts.Type)
+				}
+			}
+		}
+	}
+
+	g.add(file.Name, "package", "", "")
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				g.add(d.Name, "func", "", "")
+				continue
+			}
+			if recv := goTypeIdent(d.Recv.List[0].Type); recv != nil {
+				parentKind, ok := g.kinds[recv.Name]
+				if !ok {
+					// The receiver is declared in another file.
+					parentKind = "type"
+				}
+				g.add(d.Name, "method", recv.Name, parentKind)
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.ValueSpec:
+					kind := "var"
+					if d.Tok == token.CONST {
+						kind = "const"
+					}
+					for _, name := range s.Names {
+						g.add(name, kind, "", "")
+					}
+
+				case *ast.TypeSpec:
+					kind := g.kinds[s.Name.Name]
+					g.add(s.Name, kind, "", "")
+					g.addMembers(s.Name.Name, kind, s.Type)
+				}
+			}
+		}
+	}
+
+	return g.symbols, nil
+}
+
+// goSymbols accumulates the symbols of a Go file.
+type goSymbols struct {
+	path    string
+	fset    *token.FileSet
+	lines   [][]byte
+	kinds   map[string]string // kinds of the types declared in the file
+	symbols []protocol.Symbol
+}
+
+func (g *goSymbols) add(name *ast.Ident, kind, parent, parentKind string) {
+	if name == nil || name.Name == "_" {
+		return
+	}
+
+	line := g.fset.Position(name.Pos()).Line
+	g.symbols = append(g.symbols, protocol.Symbol{
+		Name:       name.Name,
+		Path:       g.path,
+		Line:       line,
+		Kind:       kind,
+		Language:   "Go",
+		Parent:     parent,
+		ParentKind: parentKind,
+		Pattern:    g.pattern(line),
+	})
+}
+
+// addMembers adds the fields of a struct type, and the methods of an interface
+// type.
+func (g *goSymbols) addMembers(parent, parentKind string, typ ast.Expr) {
+	switch t := typ.(type) {
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if len(field.Names) == 0 {
+				// Embedded fields are named after their type.
+				g.add(goTypeIdent(field.Type), "field", parent, parentKind)
+				continue
+			}
+			for _, name := range field.Names {
+				g.add(name, "field", parent, parentKind)
+			}
+		}
+
+	case *ast.InterfaceType:
+		for _, method := range t.Methods.List {
+			// Embedded interfaces have no names, and their methods are
+			// symbols of the embedded interface.
+			for _, name := range method.Names {
+				g.add(name, "method", parent, parentKind)
+			}
+		}
+	}
+}
+
+// pattern returns a ctags-style search pattern for the given line, which is
+// used to find the column of the symbol.
+func (g *goSymbols) pattern(line int) string {
+	if line < 1 || line > len(g.lines) {
+		return ""
+	}
+	text := strings.TrimSuffix(string(g.lines[line-1]), "\r")
+	text = strings.NewReplacer(`\`, `\\`, `/`, `\/`).Replace(text)
+	return "/^" + text + "$/"
+}
+
+// goTypeKind returns the kind of a type declared with the given type
+// expression.
+func goTypeKind(typ ast.Expr) string {
+	switch typ.(type) {
+	case *ast.StructType:
+		return "struct"
+	case *ast.InterfaceType:
+		return "interface"
+	default:
+		return "type"
+	}
+}
+
+// goTypeIdent returns the identifier of the named type in a receiver or
+// embedded field type expression, such as T, *T or pkg.T.
+func goTypeIdent(typ ast.Expr) *ast.Ident {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return goTypeIdent(t.X)
+	case *ast.ParenExpr:
+		return goTypeIdent(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	default:
+		return nil
+	}
+}
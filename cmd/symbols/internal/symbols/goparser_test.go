@@ -0,0 +1,102 @@
+package symbols
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestGoParser(t *testing.T) {
+	const data = `package foo
+
+import "io"
+
+const (
+	A = iota
+	_
+)
+
+var b, c = 1, 2
+
+// Method before its receiver type.
+func (s *S) M() {}
+
+type S struct {
+	io.Reader
+	*T
+	x, y int
+}
+
+type I interface {
+	io.Closer
+	N(a int) error
+}
+
+type F func(path string)
+
+func G(x int) (int, error) {
+	var local int
+	return local, nil
+}
+
+func (e external) Method() {}
+`
+
+	have, err := GoParser{}.Parse("foo/foo.go", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	symbol := func(name, kind string, line int, parent, parentKind string) protocol.Symbol {
+		return protocol.Symbol{
+			Name:       name,
+			Path:       "foo/foo.go",
+			Line:       line,
+			Kind:       kind,
+			Language:   "Go",
+			Parent:     parent,
+			ParentKind: parentKind,
+		}
+	}
+	want := []protocol.Symbol{
+		symbol("foo", "package", 1, "", ""),
+		symbol("A", "const", 6, "", ""),
+		symbol("b", "var", 10, "", ""),
+		symbol("c", "var", 10, "", ""),
+		symbol("M", "method", 13, "S", "struct"),
+		symbol("S", "struct", 15, "", ""),
+		symbol("Reader", "field", 16, "S", "struct"),
+		symbol("T", "field", 17, "S", "struct"),
+		symbol("x", "field", 18, "S", "struct"),
+		symbol("y", "field", 18, "S", "struct"),
+		symbol("I", "interface", 21, "", ""),
+		symbol("N", "method", 23, "I", "interface"),
+		symbol("F", "type", 26, "", ""),
+		symbol("G", "func", 28, "", ""),
+		symbol("Method", "method", 33, "external", "type"),
+	}
+	if diff := cmp.Diff(want, have, cmpopts.IgnoreFields(protocol.Symbol{}, "Pattern")); diff != "" {
+		t.Errorf("symbols mismatch (-want +have):\n%s", diff)
+	}
+
+	if want, have := `/^func (s *S) M() {}$/`, have[4].Pattern; want != have {
+		t.Errorf("pattern: want %q, have %q", want, have)
+	}
+}
+
+func TestGoParser_syntaxError(t *testing.T) {
+	have, err := GoParser{}.Parse("a.go", []byte("package a\n\nfunc A() {}\n\nfunc B( {\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range have {
+		names = append(names, s.Name)
+	}
+	if diff := cmp.Diff([]string{"a", "A"}, names[:2]); diff != "" {
+		t.Errorf("names mismatch (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,173 @@
+package symbols
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
+)
+
+// maxAncestorsToCheck is the number of ancestors of a commit that are checked
+// for an already indexed database to build its database from.
+const maxAncestorsToCheck = 100
+
+// maxIncrementalChanges is the maximum number of changed paths for which a
+// database is built incrementally. With more changes, parsing the whole
+// repository is about as fast, and the paths would make for a very long
+// archive request to gitserver.
+const maxIncrementalChanges = 1000
+
+// Changes are the paths that were added, modified or deleted between two
+// commits.
+type Changes struct {
+	Added    []string
+	Modified []string
+	Deleted  []string
+}
+
+// ParseGitDiffNameStatus parses the output of `git diff --name-status
+// --no-renames -z`.
+func ParseGitDiffNameStatus(out []byte) (Changes, error) {
+	var changes Changes
+
+	out = bytes.TrimRight(out, "\x00")
+	if len(out) == 0 {
+		return changes, nil
+	}
+
+	fields := bytes.Split(out, []byte{0})
+	if len(fields)%2 != 0 {
+		return Changes{}, errors.Errorf("uneven number of fields in git diff output: %d", len(fields))
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		status, path := string(fields[i]), string(fields[i+1])
+		switch status {
+		case "A":
+			changes.Added = append(changes.Added, path)
+		case "M", "T":
+			changes.Modified = append(changes.Modified, path)
+		case "D":
+			changes.Deleted = append(changes.Deleted, path)
+		default:
+			return Changes{}, errors.Errorf("unrecognized git diff status %q for path %q", status, path)
+		}
+	}
+
+	return changes, nil
+}
+
+// writeSymbolsIncrementally writes the symbols of the repo@commit to the blank
+// database file `dbFile` by copying the database of the nearest indexed
+// ancestor commit, and parsing only the files that changed since. It returns
+// false if there is no such ancestor, or if too many files changed, in which
+// case nothing was written.
+func (s *Service) writeSymbolsIncrementally(ctx context.Context, dbFile string, repoName api.RepoName, commitID api.CommitID) (bool, error) {
+	ancestor, ancestorDB, err := s.findIndexedAncestor(ctx, repoName, commitID)
+	if err != nil || ancestorDB == nil {
+		return false, err
+	}
+	defer ancestorDB.Close()
+
+	changes, err := s.GitDiff(ctx, repoName, ancestor, commitID)
+	if err != nil {
+		return false, errors.Wrap(err, "git diff")
+	}
+
+	var changed []string
+	changed = append(changed, changes.Added...)
+	changed = append(changed, changes.Modified...)
+	if len(changed)+len(changes.Deleted) > maxIncrementalChanges {
+		return false, nil
+	}
+
+	if err := copyToFile(dbFile, ancestorDB); err != nil {
+		return false, errors.Wrap(err, "copying ancestor database")
+	}
+
+	db, err := sqlx.Open("sqlite3_with_pcre", dbFile)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	// The connection of the transaction must be released even if it fails,
+	// since the caller rebuilds the database in the same file then. Rolling
+	// back a committed transaction is a no-op.
+	defer tx.Rollback()
+
+	// The symbols of all changed paths are removed, and those of the added and
+	// modified ones are parsed again.
+	deleteStatement, err := tx.Preparex(`DELETE FROM symbols WHERE path = ?`)
+	if err != nil {
+		return false, err
+	}
+	defer deleteStatement.Close()
+	for _, paths := range [][]string{changed, changes.Deleted} {
+		for _, path := range paths {
+			if _, err := deleteStatement.Exec(path); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	// An empty list of paths would fetch the whole repository.
+	if len(changed) > 0 {
+		if err := s.insertSymbols(ctx, tx, repoName, commitID, changed); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// findIndexedAncestor returns the nearest ancestor of the repo@commit whose
+// database is in the cache, along with the opened database file. It returns a
+// nil file if there is no such ancestor.
+func (s *Service) findIndexedAncestor(ctx context.Context, repoName api.RepoName, commitID api.CommitID) (api.CommitID, *diskcache.File, error) {
+	ancestors, err := s.Ancestors(ctx, repoName, commitID, maxAncestorsToCheck)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "listing ancestors")
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor == commitID {
+			continue
+		}
+		f, err := s.cache.OpenIfExists(symbolsDBKey(repoName, ancestor))
+		if err != nil {
+			return "", nil, err
+		}
+		if f != nil {
+			return ancestor, f, nil
+		}
+	}
+
+	return "", nil, nil
+}
+
+// copyToFile overwrites the file at path with the contents of src.
+func copyToFile(path string, src io.Reader) error {
+	dst, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
@@ -0,0 +1,193 @@
+package symbols
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	ctags "github.com/sourcegraph/go-ctags"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/sqliteutil"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestParseGitDiffNameStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		out  string
+		want Changes
+		err  string
+	}{
+		{name: "empty"},
+		{
+			name: "all statuses",
+			out:  "A\x00a.go\x00M\x00dir/m.go\x00T\x00link\x00D\x00with space.go\x00",
+			want: Changes{
+				Added:    []string{"a.go"},
+				Modified: []string{"dir/m.go", "link"},
+				Deleted:  []string{"with space.go"},
+			},
+		},
+		{
+			name: "rename",
+			out:  "R100\x00old.go\x00",
+			err:  `unrecognized git diff status "R100" for path "old.go"`,
+		},
+		{
+			name: "uneven",
+			out:  "A\x00a.go\x00M\x00",
+			err:  "uneven number of fields in git diff output: 3",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ParseGitDiffNameStatus([]byte(tc.out))
+			if have, want := errString(err), tc.err; have != want {
+				t.Fatalf("error: have %q, want %q", have, want)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("changes mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteSymbolsIncrementally(t *testing.T) {
+	sqliteutil.MustRegisterSqlite3WithPcre()
+
+	commits := map[api.CommitID]map[string]string{
+		"c1": {
+			"a.go":         "A1 A2",
+			"b.go":         "B1",
+			"deleted.go":   "D1",
+			"renamed.go":   "R1",
+			"unchanged.go": "U1",
+		},
+		"c2": {
+			"a.go":         "A1 A3",
+			"b.go":         "B1",
+			"new.go":       "N1",
+			"moved.go":     "R1",
+			"unchanged.go": "U1",
+		},
+	}
+
+	var fetchedPaths []string
+	newService := func(t *testing.T, incremental bool) *Service {
+		dir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.RemoveAll(dir) })
+
+		s := &Service{
+			FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
+				return createTar(commits[commit])
+			},
+			NewParser: func() (ctags.Parser, error) {
+				return fieldsParser{}, nil
+			},
+			Path: dir,
+		}
+		if incremental {
+			s.FetchTarPaths = func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+				fetchedPaths = append(fetchedPaths, paths...)
+				files := map[string]string{}
+				for _, p := range paths {
+					files[p] = commits[commit][p]
+				}
+				return createTar(files)
+			}
+			s.Ancestors = func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+				if commit == "c2" {
+					return []api.CommitID{"c1"}, nil
+				}
+				return nil, nil
+			}
+			s.GitDiff = func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (Changes, error) {
+				return ParseGitDiffNameStatus([]byte(strings.Join([]string{
+					"M", "a.go",
+					"D", "deleted.go",
+					"A", "moved.go",
+					"A", "new.go",
+					"D", "renamed.go",
+				}, "\x00")))
+			}
+		}
+		if err := s.Start(); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	search := func(t *testing.T, s *Service, commit api.CommitID) []protocol.Symbol {
+		res, err := s.search(context.Background(), protocol.SearchArgs{Repo: "r", CommitID: commit, First: 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Slice(res.Symbols, func(i, j int) bool {
+			return res.Symbols[i].Path+res.Symbols[i].Name < res.Symbols[j].Path+res.Symbols[j].Name
+		})
+		return res.Symbols
+	}
+
+	incremental := newService(t, true)
+	search(t, incremental, "c1")
+	if len(fetchedPaths) != 0 {
+		t.Fatalf("c1 has no indexed ancestor, but paths %v were fetched", fetchedPaths)
+	}
+
+	have := search(t, incremental, "c2")
+	want := search(t, newService(t, false), "c2")
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("incremental symbols mismatch (-full +incremental):\n%s", diff)
+	}
+
+	sort.Strings(fetchedPaths)
+	if diff := cmp.Diff([]string{"a.go", "moved.go", "new.go"}, fetchedPaths); diff != "" {
+		t.Errorf("fetched paths mismatch (-want +have):\n%s", diff)
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		// Fetching the changed paths fails after the symbols of the changed
+		// paths were deleted from the copied database, so all symbols are
+		// indexed again in the same database file.
+		s := newService(t, true)
+		search(t, s, "c1")
+		s.FetchTarPaths = func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			return nil, errors.New("archive unavailable")
+		}
+
+		have := search(t, s, "c2")
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("fallback symbols mismatch (-full +fallback):\n%s", diff)
+		}
+	})
+}
+
+// fieldsParser returns a symbol for every whitespace separated field of a
+// file.
+type fieldsParser struct{}
+
+func (fieldsParser) Parse(name string, content []byte) ([]*ctags.Entry, error) {
+	var entries []*ctags.Entry
+	for i, f := range strings.Fields(string(content)) {
+		entries = append(entries, &ctags.Entry{Name: f, Path: name, Line: i + 1})
+	}
+	return entries, nil
+}
+
+func (fieldsParser) Close() {}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -37,7 +37,10 @@ func (s *Service) startParsers() error {
 	return nil
 }
 
-func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID api.CommitID, callback func(symbol protocol.Symbol) error) (err error) {
+// parseUncached parses the symbols of the repo@commit, calling callback with
+// every symbol. If paths is non-nil, only the symbols of these paths are
+// parsed.
+func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID api.CommitID, paths []string, callback func(symbol protocol.Symbol) error) (err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "parseUncached")
 	defer func() {
 		if err != nil {
@@ -63,7 +66,7 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 	}()
 
 	tr.LazyPrintf("fetch")
-	parseRequests, errChan, err := s.fetchRepositoryArchive(ctx, repo, commitID)
+	parseRequests, errChan, err := s.fetchRepositoryArchive(ctx, repo, commitID, paths)
 	tr.LazyPrintf("fetch (returned chans)")
 	if err != nil {
 		return err
@@ -96,21 +99,21 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 				wg.Done()
 				<-sem
 			}()
-			entries, parseErr := s.parse(ctx, req)
+			symbols, parseErr := s.parse(ctx, req)
 			if parseErr != nil && parseErr != context.Canceled && parseErr != context.DeadlineExceeded {
 				log15.Error("Error parsing symbols.", "repo", repo, "commitID", commitID, "path", req.path, "dataSize", len(req.data), "error", parseErr)
 			}
-			if len(entries) > 0 {
+			if len(symbols) > 0 {
 				mu.Lock()
 				defer mu.Unlock()
-				for _, e := range entries {
-					if e.Name == "" || strings.HasPrefix(e.Name, "__anon") || strings.HasPrefix(e.Parent, "__anon") || strings.HasPrefix(e.Name, "AnonymousFunction") || strings.HasPrefix(e.Parent, "AnonymousFunction") {
+				for _, symbol := range symbols {
+					if symbol.Name == "" || strings.HasPrefix(symbol.Name, "__anon") || strings.HasPrefix(symbol.Parent, "__anon") || strings.HasPrefix(symbol.Name, "AnonymousFunction") || strings.HasPrefix(symbol.Parent, "AnonymousFunction") {
 						continue
 					}
 					totalSymbols++
-					err = callback(entryToSymbol(e))
+					err = callback(symbol)
 					if err != nil {
-						log15.Error("Failed to add symbol", "symbol", e, "error", err)
+						log15.Error("Failed to add symbol", "symbol", symbol, "error", err)
 						return
 					}
 				}
@@ -123,8 +126,26 @@ func (s *Service) parseUncached(ctx context.Context, repo api.RepoName, commitID
 	return <-errChan
 }
 
-// parse gets a parser from the pool and uses it to satisfy the parse request.
-func (s *Service) parse(ctx context.Context, req parseRequest) (entries []*ctags.Entry, err error) {
+// parse parses the symbols of the file in the parse request, using the
+// in-process parser for its language if there is one, and ctags otherwise.
+func (s *Service) parse(ctx context.Context, req parseRequest) ([]protocol.Symbol, error) {
+	if parser, ok := s.Parsers[languageOf(req.path)]; ok {
+		parsing.Inc()
+		defer parsing.Dec()
+		return parser.Parse(req.path, req.data)
+	}
+
+	entries, err := s.parseWithCtags(ctx, req)
+	symbols := make([]protocol.Symbol, 0, len(entries))
+	for _, e := range entries {
+		symbols = append(symbols, entryToSymbol(e))
+	}
+	return symbols, err
+}
+
+// parseWithCtags gets a ctags parser from the pool and uses it to satisfy the
+// parse request.
+func (s *Service) parseWithCtags(ctx context.Context, req parseRequest) (entries []*ctags.Entry, err error) {
 	parseQueueSize.Inc()
 
 	select {
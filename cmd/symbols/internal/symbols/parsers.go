@@ -0,0 +1,31 @@
+package symbols
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+	"github.com/src-d/enry/v2"
+)
+
+// Parser is an in-process symbols parser for a language that ctags handles
+// poorly. It must be safe for concurrent use.
+type Parser interface {
+	// Parse returns the symbols of the file at path with the given content.
+	Parse(path string, content []byte) ([]protocol.Symbol, error)
+}
+
+// DefaultParsers returns the in-process parsers used by the symbols service,
+// keyed by the language they parse as named by enry.
+func DefaultParsers() map[string]Parser {
+	return map[string]Parser{
+		"Go": GoParser{},
+	}
+}
+
+// languageOf returns the language of the file at path, or the empty string if
+// it can't be determined from its extension alone.
+func languageOf(path string) string {
+	language, safe := enry.GetLanguageByExtension(path)
+	if !safe {
+		return ""
+	}
+	return language
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"regexp/syntax"
 	"strings"
 	"time"
@@ -97,8 +98,8 @@ func (s *Service) search(ctx context.Context, args protocol.SearchArgs) (result
 // specified in `args`. If the database doesn't already exist in the disk cache,
 // it will create a new one and write all the symbols into it.
 func (s *Service) getDBFile(ctx context.Context, args protocol.SearchArgs) (string, error) {
-	diskcacheFile, err := s.cache.OpenWithPath(ctx, fmt.Sprintf("%d-%s@%s", symbolsDBVersion, args.Repo, args.CommitID), func(fetcherCtx context.Context, tempDBFile string) error {
-		err := s.writeAllSymbolsToNewDB(fetcherCtx, tempDBFile, args.Repo, args.CommitID)
+	diskcacheFile, err := s.cache.OpenWithPath(ctx, symbolsDBKey(args.Repo, args.CommitID), func(fetcherCtx context.Context, tempDBFile string) error {
+		err := s.writeSymbolsToNewDB(fetcherCtx, tempDBFile, args.Repo, args.CommitID)
 		if err != nil {
 			if err == context.Canceled {
 				log15.Error("Unable to parse repository symbols within the context", "repo", args.Repo, "commit", args.CommitID, "query", args.Query)
@@ -115,6 +116,12 @@ func (s *Service) getDBFile(ctx context.Context, args protocol.SearchArgs) (stri
 	return diskcacheFile.File.Name(), err
 }
 
+// symbolsDBKey returns the disk cache key of the sqlite3 database for the
+// repo@commit.
+func symbolsDBKey(repo api.RepoName, commitID api.CommitID) string {
+	return fmt.Sprintf("%d-%s@%s", symbolsDBVersion, repo, commitID)
+}
+
 // isLiteralEquality checks if the given regex matches literal strings exactly.
 // Returns whether or not the regex is exact, along with the literal string if
 // so.
@@ -214,8 +221,10 @@ func filterSymbols(ctx context.Context, db *sqlx.DB, args protocol.SearchArgs) (
 // The version of the symbols database schema. This is included in the database
 // filenames to prevent a newer version of the symbols service from attempting
 // to read from a database created by an older (and likely incompatible) symbols
-// service. Increment this when you change the database schema.
-const symbolsDBVersion = 3
+// service. Increment this when you change the database schema, or the way
+// symbols are parsed, since databases of ancestor commits are reused to build
+// new ones incrementally.
+const symbolsDBVersion = 4
 
 // symbolInDB is the same as `protocol.Symbol`, but with two additional columns:
 // namelowercase and pathlowercase, which enable indexed case insensitive
@@ -270,6 +279,31 @@ func symbolInDBToSymbol(symbolInDB symbolInDB) protocol.Symbol {
 	}
 }
 
+// writeSymbolsToNewDB writes all the symbols of the repo@commit to the blank
+// database file `dbFile`. If possible, the database is built incrementally from
+// the database of an ancestor commit.
+func (s *Service) writeSymbolsToNewDB(ctx context.Context, dbFile string, repoName api.RepoName, commitID api.CommitID) error {
+	if s.Ancestors != nil && s.GitDiff != nil && s.FetchTarPaths != nil {
+		ok, err := s.writeSymbolsIncrementally(ctx, dbFile, repoName, commitID)
+		if ok {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log15.Warn("Failed to index symbols incrementally, falling back to indexing all symbols.", "repo", repoName, "commit", commitID, "error", err)
+		}
+
+		// Start over with a blank database.
+		if err := os.Truncate(dbFile, 0); err != nil {
+			return err
+		}
+	}
+
+	return s.writeAllSymbolsToNewDB(ctx, dbFile, repoName, commitID)
+}
+
 // writeAllSymbolsToNewDB fetches the repo@commit from gitserver, parses all the
 // symbols, and writes them to the blank database file `dbFile`.
 func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, repoName api.RepoName, commitID api.CommitID) error {
@@ -285,10 +319,23 @@ func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, rep
 		return err
 	}
 
+	if err := createSymbolsTable(tx); err != nil {
+		return err
+	}
+
+	if err := s.insertSymbols(ctx, tx, repoName, commitID, nil); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// createSymbolsTable creates the symbols table and its indexes.
+func createSymbolsTable(tx *sqlx.Tx) error {
 	// The column names are the lowercase version of fields in `symbolInDB`
 	// because sqlx lowercases struct fields by default. See
 	// http://jmoiron.github.io/sqlx/#query
-	_, err = tx.Exec(
+	_, err := tx.Exec(
 		`CREATE TABLE IF NOT EXISTS symbols (
 			name VARCHAR(256) NOT NULL,
 			namelowercase VARCHAR(256) NOT NULL,
@@ -328,6 +375,13 @@ func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, rep
 		return err
 	}
 
+	return nil
+}
+
+// insertSymbols parses the symbols of the repo@commit and inserts them into the
+// symbols table. If paths is non-nil, only the symbols of these paths are
+// parsed.
+func (s *Service) insertSymbols(ctx context.Context, tx *sqlx.Tx, repoName api.RepoName, commitID api.CommitID, paths []string) error {
 	insertStatement, err := tx.PrepareNamed(
 		fmt.Sprintf(
 			"INSERT INTO symbols %s VALUES %s",
@@ -337,19 +391,9 @@ func (s *Service) writeAllSymbolsToNewDB(ctx context.Context, dbFile string, rep
 		return err
 	}
 
-	err = s.parseUncached(ctx, repoName, commitID, func(symbol protocol.Symbol) error {
+	return s.parseUncached(ctx, repoName, commitID, paths, func(symbol protocol.Symbol) error {
 		symbolInDBValue := symbolToSymbolInDB(symbol)
 		_, err := insertStatement.Exec(&symbolInDBValue)
 		return err
 	})
-	if err != nil {
-		return err
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
@@ -22,12 +22,30 @@ type Service struct {
 	// determine if the error is a bad request (eg invalid repo).
 	FetchTar func(context.Context, gitserver.Repo, api.CommitID) (io.ReadCloser, error)
 
+	// FetchTarPaths is like FetchTar, but the archive only contains the given paths. It is used
+	// to parse the files that changed since an ancestor commit when indexing incrementally.
+	FetchTarPaths func(context.Context, gitserver.Repo, api.CommitID, []string) (io.ReadCloser, error)
+
 	// MaxConcurrentFetchTar is the maximum number of concurrent calls allowed
-	// to FetchTar. It defaults to 15.
+	// to FetchTar and FetchTarPaths. It defaults to 15.
 	MaxConcurrentFetchTar int
 
+	// Ancestors returns up to n ancestors of the given commit, nearest first. If the symbols
+	// of one of them are already indexed, the symbols of the commit are indexed incrementally
+	// from them. Incremental indexing requires Ancestors, GitDiff and FetchTarPaths to be set.
+	Ancestors func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error)
+
+	// GitDiff returns the paths that changed between two commits.
+	GitDiff func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (Changes, error)
+
+	// NewParser returns a new ctags parser. It is used to parse the files of all languages
+	// without a parser in Parsers.
 	NewParser func() (ctags.Parser, error)
 
+	// Parsers are the in-process parsers to use instead of ctags, keyed by the language they
+	// parse. See DefaultParsers.
+	Parsers map[string]Parser
+
 	// NumParserProcesses is the maximum number of ctags parser child processes to run.
 	NumParserProcesses int
 
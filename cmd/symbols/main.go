@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -52,7 +53,37 @@ func main() {
 		FetchTar: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (io.ReadCloser, error) {
 			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar"})
 		},
+		FetchTarPaths: func(ctx context.Context, repo gitserver.Repo, commit api.CommitID, paths []string) (io.ReadCloser, error) {
+			pathspecs := make([]string, 0, len(paths))
+			for _, p := range paths {
+				pathspecs = append(pathspecs, ":(literal)"+p)
+			}
+			return gitserver.DefaultClient.Archive(ctx, repo, gitserver.ArchiveOptions{Treeish: string(commit), Format: "tar", Paths: pathspecs})
+		},
+		Ancestors: func(ctx context.Context, repo api.RepoName, commit api.CommitID, n int) ([]api.CommitID, error) {
+			cmd := gitserver.DefaultClient.Command("git", "rev-list", "--first-parent", "--skip=1", "--max-count="+strconv.Itoa(n), string(commit))
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var commits []api.CommitID
+			for _, line := range strings.Fields(string(out)) {
+				commits = append(commits, api.CommitID(line))
+			}
+			return commits, nil
+		},
+		GitDiff: func(ctx context.Context, repo api.RepoName, commitA, commitB api.CommitID) (symbols.Changes, error) {
+			cmd := gitserver.DefaultClient.Command("git", "diff", "-z", "--name-status", "--no-renames", string(commitA), string(commitB))
+			cmd.Repo = gitserver.Repo{Name: repo}
+			out, err := cmd.Output(ctx)
+			if err != nil {
+				return symbols.Changes{}, err
+			}
+			return symbols.ParseGitDiffNameStatus(out)
+		},
 		NewParser: symbols.NewParser,
+		Parsers:   symbols.DefaultParsers(),
 		Path:      cacheDir,
 	}
 	if mb, err := strconv.ParseInt(cacheSizeMB, 10, 64); err != nil {
@@ -0,0 +1,45 @@
+package executionmanager
+
+import "sync"
+
+// Manager is a synchronized set of campaign spec execution identifiers which are
+// currently being processed by the executor. This is read by the heartbeat process
+// to construct the payload to the API to prevent the records being processed
+// from being requeued.
+type Manager struct {
+	m            sync.RWMutex
+	executionIDs map[int]struct{}
+}
+
+// New creates a new Manager.
+func New() *Manager {
+	return &Manager{
+		executionIDs: map[int]struct{}{},
+	}
+}
+
+// GetIDs returns a slice of the identifiers in the set.
+func (i *Manager) GetIDs() (ids []int) {
+	i.m.RLock()
+	defer i.m.RUnlock()
+
+	for id := range i.executionIDs {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// AddID adds an identifier to the set.
+func (i *Manager) AddID(executionID int) {
+	i.m.Lock()
+	i.executionIDs[executionID] = struct{}{}
+	i.m.Unlock()
+}
+
+// RemoveID removes an identifier from the set.
+func (i *Manager) RemoveID(executionID int) {
+	i.m.Lock()
+	delete(i.executionIDs, executionID)
+	i.m.Unlock()
+}
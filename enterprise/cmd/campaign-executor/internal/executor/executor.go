@@ -0,0 +1,42 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	executionmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/execution_manager"
+	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type ExecutorOptions struct {
+	NumExecutors   int
+	Interval       time.Duration
+	Metrics        ExecutorMetrics
+	HandlerOptions HandlerOptions
+}
+
+// NewExecutor returns a worker that dequeues campaign spec executions from the queue API
+// and runs the steps of their campaign spec in containers.
+func NewExecutor(ctx context.Context, queueClient queue.Client, executionManager *executionmanager.Manager, options ExecutorOptions) *workerutil.Worker {
+	handler := &Handler{
+		queueClient:      queueClient,
+		executionManager: executionManager,
+		newCommander:     NewDefaultCommander,
+		options:          options.HandlerOptions,
+		uuidGenerator:    uuid.NewRandom,
+	}
+
+	workerMetrics := workerutil.WorkerMetrics{
+		HandleOperation: options.Metrics.ProcessOperation,
+	}
+
+	return workerutil.NewWorker(ctx, &storeShim{queueClient}, workerutil.WorkerOptions{
+		Name:        "campaign executor",
+		Handler:     handler,
+		NumHandlers: options.NumExecutors,
+		Interval:    options.Interval,
+		Metrics:     workerMetrics,
+	})
+}
@@ -0,0 +1,353 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+)
+
+// CommandFormatter turns commands into the invocations that run them in containers on the
+// host or in a virtual machine.
+type CommandFormatter interface {
+	// Setup prepares the environment in which the commands are run. The given images are made
+	// available to the commands.
+	Setup(ctx context.Context, commander Commander, images []string) error
+
+	// Teardown removes the environment in which the commands were run.
+	Teardown(ctx context.Context, commander Commander) error
+
+	// FormatCommand returns the invocation that runs the given command.
+	FormatCommand(cmd *Cmd) []string
+
+	// CopyOut copies the file with the given name from the repository directory that the
+	// commands see into the repository directory on the host.
+	CopyOut(ctx context.Context, commander Commander, name string) error
+}
+
+// Cmd is a command that is run in a container with the repository mounted as the working
+// directory.
+type Cmd struct {
+	image      string
+	entrypoint string
+	command    []string
+	envFile    string
+}
+
+func NewCmd(image string, command ...string) *Cmd {
+	return &Cmd{
+		image:   image,
+		command: command,
+	}
+}
+
+func (cmd *Cmd) SetEntrypoint(entrypoint string) *Cmd {
+	cmd.entrypoint = entrypoint
+	return cmd
+}
+
+// SetEnvFile sets the name of the file in the env directory that the environment of the
+// command is read from. Environment values are never put on the command line, which is
+// stored in the log of the execution.
+func (cmd *Cmd) SetEnvFile(envFile string) *Cmd {
+	cmd.envFile = envFile
+	return cmd
+}
+
+type dockerCommandFormatter struct {
+	repoDir string
+	envDir  string
+	options HandlerOptions
+}
+
+var _ CommandFormatter = &dockerCommandFormatter{}
+
+func NewDockerCommandFormatter(
+	repoDir string,
+	envDir string,
+	options HandlerOptions,
+) CommandFormatter {
+	return &dockerCommandFormatter{
+		repoDir: repoDir,
+		envDir:  envDir,
+		options: options,
+	}
+}
+
+func (r *dockerCommandFormatter) Setup(ctx context.Context, commander Commander, images []string) error {
+	return nil
+}
+
+func (r *dockerCommandFormatter) Teardown(ctx context.Context, commander Commander) error {
+	return nil
+}
+
+func (r *dockerCommandFormatter) FormatCommand(cmd *Cmd) []string {
+	return flatten(
+		"docker", "run", "--rm",
+		r.networkFlags(),
+		r.resourceFlags(),
+		r.volumeFlags(),
+		"-w", workDir,
+		r.envFlags(cmd.envFile),
+		r.entrypointFlags(cmd.entrypoint),
+		cmd.image,
+		cmd.command,
+	)
+}
+
+// CopyOut is a no-op as the repository directory is bind mounted from the host.
+func (r *dockerCommandFormatter) CopyOut(ctx context.Context, commander Commander, name string) error {
+	return nil
+}
+
+// networkFlags restricts the network that is reachable from the container. By default,
+// the containers have no network access at all.
+func (r *dockerCommandFormatter) networkFlags() []string {
+	return []string{"--network", r.options.StepNetwork}
+}
+
+func (r *dockerCommandFormatter) resourceFlags() []string {
+	return []string{
+		"--cpus", strconv.Itoa(r.options.FirecrackerNumCPUs),
+		"--memory", r.options.FirecrackerMemory,
+	}
+}
+
+func (r *dockerCommandFormatter) volumeFlags() []string {
+	return []string{"-v", fmt.Sprintf("%s:%s", r.repoDir, workDir)}
+}
+
+func (r *dockerCommandFormatter) envFlags(envFile string) []string {
+	if envFile == "" {
+		return nil
+	}
+
+	return []string{"--env-file", filepath.Join(r.envDir, envFile)}
+}
+
+func (r *dockerCommandFormatter) entrypointFlags(entrypoint string) []string {
+	if entrypoint == "" {
+		return nil
+	}
+
+	return []string{"--entrypoint", entrypoint}
+}
+
+type firecrackerCommandFormatter struct {
+	name      string
+	repoDir   string
+	envDir    string
+	options   HandlerOptions
+	formatter CommandFormatter
+}
+
+var _ CommandFormatter = &firecrackerCommandFormatter{}
+
+const (
+	FirecrackerRepoDir = "/repo-dir"
+	FirecrackerEnvDir  = "/env-dir"
+)
+
+func NewFirecrackerCommandFormatter(
+	name string,
+	repoDir string,
+	envDir string,
+	options HandlerOptions,
+) CommandFormatter {
+	return &firecrackerCommandFormatter{
+		name:    name,
+		repoDir: repoDir,
+		envDir:  envDir,
+		options: options,
+		formatter: NewDockerCommandFormatter(
+			FirecrackerRepoDir,
+			FirecrackerEnvDir,
+			options,
+		),
+	}
+}
+
+var commonFirecrackerFlags = []string{
+	"--runtime", "docker",
+	"--network-plugin", "docker-bridge",
+}
+
+func (r *firecrackerCommandFormatter) Setup(ctx context.Context, commander Commander, images []string) error {
+	imageMap := map[string]string{}
+	for i, image := range images {
+		imageMap[fmt.Sprintf("image%d", i)] = image
+	}
+
+	// The images of a campaign spec are chosen by its author, so they are saved into a
+	// directory owned by this VM and never reused by the VM of another execution.
+	if err := os.MkdirAll(r.imageArchiveDir(), os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, key := range orderedKeys(imageMap) {
+		if err := r.saveDockerImage(ctx, commander, key, imageMap[key]); err != nil {
+			return err
+		}
+	}
+
+	startCommand := flatten(
+		"ignite", "run",
+		commonFirecrackerFlags,
+		r.resourceFlags(),
+		r.copyfileFlags(imageMap),
+		"--ssh",
+		"--name", r.name,
+		sanitizeImage(r.options.FirecrackerImage),
+	)
+	if err := commander.Run(ctx, startCommand...); err != nil {
+		return errors.Wrap(err, "failed to start firecracker vm")
+	}
+
+	for _, key := range orderedKeys(imageMap) {
+		loadCommand := flatten(
+			"ignite", "exec", r.name, "--",
+			"docker", "load",
+			"-i", r.tarfilePathInVM(key),
+		)
+		if err := commander.Run(ctx, loadCommand...); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to load %s", imageMap[key]))
+		}
+	}
+
+	// Remove tar files inside of vm to clear scratch space
+	for _, key := range orderedKeys(imageMap) {
+		rmCommand := flatten(
+			"ignite", "exec", r.name, "--",
+			"rm", r.tarfilePathInVM(key),
+		)
+		if err := commander.Run(ctx, rmCommand...); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to remove tarfile for %s", imageMap[key]))
+		}
+	}
+
+	return nil
+}
+
+func (r *firecrackerCommandFormatter) Teardown(ctx context.Context, commander Commander) error {
+	stopCommand := flatten(
+		"ignite", "stop",
+		commonFirecrackerFlags,
+		r.name,
+	)
+	if err := commander.Run(ctx, stopCommand...); err != nil {
+		log15.Warn("Failed to stop firecracker vm", "name", r.name, "err", err)
+	}
+
+	removeCommand := flatten(
+		"ignite", "rm", "-f",
+		commonFirecrackerFlags,
+		r.name,
+	)
+	if err := commander.Run(ctx, removeCommand...); err != nil {
+		log15.Warn("Failed to remove firecracker vm", "name", r.name, "err", err)
+	}
+
+	if err := os.RemoveAll(r.imageArchiveDir()); err != nil {
+		log15.Warn("Failed to remove image archives", "name", r.name, "err", err)
+	}
+
+	return nil
+}
+
+func (r *firecrackerCommandFormatter) FormatCommand(cmd *Cmd) []string {
+	return flatten("ignite", "exec", r.name, "--", r.formatter.FormatCommand(cmd))
+}
+
+func (r *firecrackerCommandFormatter) CopyOut(ctx context.Context, commander Commander, name string) error {
+	copyCommand := flatten(
+		"ignite", "cp",
+		fmt.Sprintf("%s:%s", r.name, filepath.Join(FirecrackerRepoDir, name)),
+		filepath.Join(r.repoDir, name),
+	)
+	if err := commander.Run(ctx, copyCommand...); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to copy %s out of firecracker vm", name))
+	}
+
+	return nil
+}
+
+func (r *firecrackerCommandFormatter) saveDockerImage(ctx context.Context, commander Commander, key, image string) error {
+	pullCommand := flatten(
+		"docker", "pull",
+		image,
+	)
+	if err := commander.Run(ctx, pullCommand...); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to pull %s", image))
+	}
+
+	saveCommand := flatten(
+		"docker", "save",
+		"-o", r.tarfilePathOnHost(key),
+		image,
+	)
+	if err := commander.Run(ctx, saveCommand...); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to save %s", image))
+	}
+
+	return nil
+}
+
+func (r *firecrackerCommandFormatter) resourceFlags() []string {
+	return []string{
+		"--cpus", strconv.Itoa(r.options.FirecrackerNumCPUs),
+		"--memory", r.options.FirecrackerMemory,
+		"--size", r.options.FirecrackerDiskSpace,
+	}
+}
+
+func (r *firecrackerCommandFormatter) copyfileFlags(images map[string]string) (copyfiles []string) {
+	for _, key := range orderedKeys(images) {
+		copyfiles = append(copyfiles, fmt.Sprintf(
+			"%s:%s",
+			r.tarfilePathOnHost(key),
+			r.tarfilePathInVM(key),
+		))
+	}
+
+	return intersperse("--copy-files", append(
+		[]string{
+			fmt.Sprintf("%s:%s", r.repoDir, FirecrackerRepoDir),
+			fmt.Sprintf("%s:%s", r.envDir, FirecrackerEnvDir),
+		},
+		copyfiles...,
+	))
+}
+
+func (r *firecrackerCommandFormatter) imageArchiveDir() string {
+	return filepath.Join(r.options.ImageArchivePath, r.name)
+}
+
+func (r *firecrackerCommandFormatter) tarfilePathOnHost(key string) string {
+	return filepath.Join(r.imageArchiveDir(), fmt.Sprintf("%s.tar", key))
+}
+
+func (r *firecrackerCommandFormatter) tarfilePathInVM(key string) string {
+	return fmt.Sprintf("/%s.tar", key)
+}
+
+func orderedKeys(m map[string]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func intersperse(flag string, vs []string) (interspersed []string) {
+	for _, v := range vs {
+		interspersed = append(interspersed, flag, v)
+	}
+
+	return interspersed
+}
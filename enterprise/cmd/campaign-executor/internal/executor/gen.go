@@ -0,0 +1,4 @@
+package executor
+
+//go:generate env GOBIN=$PWD/.bin GO111MODULE=on go install github.com/efritz/go-mockgen
+//go:generate $PWD/.bin/go-mockgen -f github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/executor -i Commander -o mock_commander_test.go
@@ -0,0 +1,331 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	executionmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/execution_manager"
+	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+const (
+	// workDir is the directory in the step containers that the repository is mounted into.
+	workDir = "/work"
+
+	// diffFile is the name of the file in the repository directory the diff is written to.
+	diffFile = ".sourcegraph-campaign.diff"
+
+	// gitImage is the image used to compute the diff of the changes made by the steps.
+	gitImage = "alpine/git:v2.26.2"
+)
+
+type Handler struct {
+	queueClient      queue.Client
+	executionManager *executionmanager.Manager
+	newCommander     func(*JobLogger) Commander
+	options          HandlerOptions
+	uuidGenerator    func() (uuid.UUID, error)
+}
+
+var _ workerutil.Handler = &Handler{}
+
+type HandlerOptions struct {
+	FrontendURL          string
+	AuthToken            string
+	FirecrackerImage     string
+	UseFirecracker       bool
+	FirecrackerNumCPUs   int
+	FirecrackerMemory    string
+	FirecrackerDiskSpace string
+	ImageArchivePath     string
+	StepNetwork          string
+}
+
+// Handle clones the target repository into a temporary directory, runs the steps of the
+// campaign spec in fresh docker containers inside a virtual machine, and sends the resulting
+// diff to the queue API, which creates a changeset spec from it. The commands that were run
+// and their output are stored as the log of the campaign spec execution.
+func (h *Handler) Handle(ctx context.Context, s workerutil.Store, record workerutil.Record) (err error) {
+	job := record.(types.Job)
+
+	// 🚨 SECURITY: The job logger must be supplied with all sensitive values that may appear
+	// in a command constructed and run in the following function. Note that the command and
+	// its output may both contain sensitive values, but only values which we directly
+	// interpolate into the command. The environment of the steps is never interpolated into
+	// a command but read from env files, and the user-specified commands (which could leak
+	// their environment) are run in a clean VM.
+	logger := NewJobLogger(h.options.AuthToken)
+
+	defer func() {
+		type SetLogContents interface {
+			SetLogContents(ctx context.Context, id int, contents string) error
+		}
+		if setLogContents, ok := s.(SetLogContents); ok {
+			if err := setLogContents.SetLogContents(ctx, job.ID, logger.String()); err != nil {
+				log15.Warn("Failed to upload log for campaign spec execution", "id", job.ID, "err", err)
+			}
+		}
+	}()
+
+	commander := h.newCommander(logger)
+
+	h.executionManager.AddID(job.ID)
+	defer h.executionManager.RemoveID(job.ID)
+
+	repoDir, err := h.fetchRepository(ctx, commander, job.RepoName, job.Commit)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(repoDir)
+	}()
+
+	envDir, err := writeEnvFiles(job.Steps)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(envDir)
+	}()
+
+	commandFormatter, err := h.makeCommandFormatter(repoDir, envDir)
+	if err != nil {
+		return err
+	}
+
+	if err := commandFormatter.Setup(ctx, commander, stepImages(job.Steps)); err != nil {
+		return err
+	}
+	defer func() {
+		if teardownErr := commandFormatter.Teardown(ctx, commander); teardownErr != nil {
+			err = multierror.Append(err, teardownErr)
+		}
+	}()
+
+	for i, step := range job.Steps {
+		stepCommand := NewCmd(step.Container, "-c", step.Run).SetEntrypoint("/bin/sh")
+		if len(step.Env) > 0 {
+			stepCommand.SetEnvFile(envFileName(i))
+		}
+
+		if err := commander.Run(ctx, commandFormatter.FormatCommand(stepCommand)...); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to run step %d", i+1))
+		}
+	}
+
+	diff, err := h.computeDiff(ctx, commander, commandFormatter, repoDir)
+	if err != nil {
+		return err
+	}
+	if len(diff) == 0 {
+		// The steps didn't change anything in this repository
+		return nil
+	}
+
+	if err := h.queueClient.SetDiff(ctx, job.ID, string(diff)); err != nil {
+		return errors.Wrap(err, "failed to upload diff")
+	}
+
+	return nil
+}
+
+func (h *Handler) makeCommandFormatter(repoDir, envDir string) (CommandFormatter, error) {
+	if !h.options.UseFirecracker {
+		return NewDockerCommandFormatter(repoDir, envDir, h.options), nil
+	}
+
+	name, err := h.uuidGenerator()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFirecrackerCommandFormatter(name.String(), repoDir, envDir, h.options), nil
+}
+
+// makeTempDir is a wrapper around ioutil.TempDir that can be replaced during unit tests.
+var makeTempDir = func() (string, error) {
+	// TMPDIR is set in the dev Procfile to avoid requiring developers to explicitly
+	// allow bind mounts of the host's /tmp. If this directory doesn't exist, ioutil.TempDir
+	// below will fail.
+	if tmpdir := os.Getenv("TMPDIR"); tmpdir != "" {
+		if err := os.MkdirAll(tmpdir, os.ModePerm); err != nil {
+			return "", err
+		}
+	}
+
+	return ioutil.TempDir("", "")
+}
+
+// fetchRepository creates a temporary directory and performs a git checkout with the given repository
+// and commit. If there is an error, the temporary directory is removed.
+func (h *Handler) fetchRepository(ctx context.Context, commander Commander, repositoryName, commit string) (_ string, err error) {
+	tempDir, err := makeTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(tempDir)
+		}
+	}()
+
+	cloneURL, err := makeCloneURL(h.options.FrontendURL, h.options.AuthToken, repositoryName)
+	if err != nil {
+		return "", err
+	}
+
+	gitCommands := [][]string{
+		{"git", "-C", tempDir, "init"},
+		{"git", "-C", tempDir, "-c", "protocol.version=2", "fetch", cloneURL.String(), commit},
+		{"git", "-C", tempDir, "checkout", commit},
+	}
+	for _, gitCommand := range gitCommands {
+		if err := commander.Run(ctx, gitCommand...); err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed `%s`", strings.Join(gitCommand, " ")))
+		}
+	}
+
+	return tempDir, nil
+}
+
+// writeEnvFiles creates a temporary directory outside of the repository and writes the
+// environment of each step that has one into an env file in it. If there is an error, the
+// temporary directory is removed.
+func writeEnvFiles(steps []campaigns.CampaignSpecStep) (_ string, err error) {
+	tempDir, err := makeTempDir()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(tempDir)
+		}
+	}()
+
+	for i, step := range steps {
+		if len(step.Env) == 0 {
+			continue
+		}
+
+		contents, err := formatEnvFile(step.Env)
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("invalid environment of step %d", i+1))
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(tempDir, envFileName(i)), []byte(contents), 0600); err != nil {
+			return "", err
+		}
+	}
+
+	return tempDir, nil
+}
+
+// formatEnvFile returns the given environment in the format read by `docker run --env-file`.
+// Such files hold one variable per line and take values verbatim, so names and values that
+// contain a newline are rejected.
+func formatEnvFile(env map[string]string) (string, error) {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		if key == "" || strings.ContainsAny(key, "=\r\n") {
+			return "", fmt.Errorf("illegal environment variable name %q", key)
+		}
+		if strings.ContainsAny(env[key], "\r\n") {
+			return "", fmt.Errorf("value of environment variable %s contains a newline", key)
+		}
+
+		fmt.Fprintf(&b, "%s=%s\n", key, env[key])
+	}
+
+	return b.String(), nil
+}
+
+func envFileName(stepIndex int) string {
+	return fmt.Sprintf("step%d.env", stepIndex)
+}
+
+// stepImages returns the distinct images used by the given steps, along with the image
+// used to compute the diff.
+func stepImages(steps []campaigns.CampaignSpecStep) []string {
+	images := []string{gitImage}
+	seen := map[string]struct{}{gitImage: {}}
+
+	for _, step := range steps {
+		if _, ok := seen[step.Container]; ok {
+			continue
+		}
+
+		seen[step.Container] = struct{}{}
+		images = append(images, step.Container)
+	}
+
+	return images
+}
+
+// computeDiff stages all changes made to the given repository and returns their diff. The
+// diff is computed in the same environment the steps ran in, as the repository directory
+// on the host is not updated by the steps when they run in a virtual machine.
+func (h *Handler) computeDiff(ctx context.Context, commander Commander, commandFormatter CommandFormatter, repoDir string) ([]byte, error) {
+	gitCommands := []*Cmd{
+		NewCmd(gitImage, "add", "--all"),
+		NewCmd(gitImage, "diff", "--cached", "--no-prefix", "--binary", "--output="+diffFile),
+	}
+	for _, gitCommand := range gitCommands {
+		if err := commander.Run(ctx, commandFormatter.FormatCommand(gitCommand)...); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed `git %s`", strings.Join(gitCommand.command, " ")))
+		}
+	}
+
+	if err := commandFormatter.CopyOut(ctx, commander, diffFile); err != nil {
+		return nil, err
+	}
+
+	return readDiff(filepath.Join(repoDir, diffFile))
+}
+
+// readDiff is a wrapper around ioutil.ReadFile that can be replaced during unit tests.
+var readDiff = func(path string) ([]byte, error) {
+	return ioutil.ReadFile(path)
+}
+
+func makeCloneURL(baseURL, authToken, repositoryName string) (*url.URL, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	base.User = url.UserPassword("executor", authToken)
+
+	return base.ResolveReference(&url.URL{Path: path.Join(".internal-campaigns", "git", repositoryName)}), nil
+}
+
+func flatten(values ...interface{}) (union []string) {
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			union = append(union, v)
+		case []string:
+			union = append(union, v...)
+		}
+	}
+
+	return union
+}
@@ -0,0 +1,23 @@
+package executor
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var imagePattern = regexp.MustCompile(`([^:@]+)(?::([^@]+))?(?:@sha256:([a-z0-9]{64}))?`)
+
+// sanitizeImage sanitizes the given docker image for use by ignite. The ignite utility has
+// some issue parsing docker tags that include a sha256 hash, so we try to remove it from
+// any of the image references before passing it to the ignite command.
+func sanitizeImage(image string) string {
+	if matches := imagePattern.FindStringSubmatch(image); len(matches) == 4 {
+		if matches[2] == "" {
+			return matches[1]
+		}
+
+		return fmt.Sprintf("%s:%s", matches[1], matches[2])
+	}
+
+	return image
+}
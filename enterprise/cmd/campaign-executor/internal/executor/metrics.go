@@ -0,0 +1,27 @@
+package executor
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type ExecutorMetrics struct {
+	ProcessOperation *observation.Operation
+}
+
+func NewExecutorMetrics(observationContext *observation.Context) ExecutorMetrics {
+	metrics := metrics.NewOperationMetrics(
+		observationContext.Registerer,
+		"campaign_spec_execution_processor",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of records processed"),
+	)
+
+	return ExecutorMetrics{
+		ProcessOperation: observationContext.Operation(observation.Op{
+			Name:         "Executor.Process",
+			MetricLabels: []string{"process"},
+			Metrics:      metrics,
+		}),
+	}
+}
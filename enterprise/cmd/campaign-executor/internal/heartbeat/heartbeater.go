@@ -0,0 +1,37 @@
+package heartbeat
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	executionmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/execution_manager"
+	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type Heartbeater struct {
+	queueClient      queue.Client
+	executionManager *executionmanager.Manager
+}
+
+var _ goroutine.Handler = &Heartbeater{}
+
+type HeartbeaterOptions struct {
+	Interval time.Duration
+}
+
+func NewHeartbeater(ctx context.Context, queueClient queue.Client, executionManager *executionmanager.Manager, options HeartbeaterOptions) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), options.Interval, &Heartbeater{
+		queueClient:      queueClient,
+		executionManager: executionManager,
+	})
+}
+
+func (w *Heartbeater) Handle(ctx context.Context) error {
+	return w.queueClient.Heartbeat(ctx, w.executionManager.GetIDs())
+}
+
+func (w *Heartbeater) HandleError(err error) {
+	log15.Error("Failed to perform heartbeat", "err", err)
+}
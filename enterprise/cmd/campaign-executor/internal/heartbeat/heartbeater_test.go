@@ -0,0 +1,41 @@
+package heartbeat
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	executionmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/execution_manager"
+	queuemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client/mocks"
+)
+
+func TestHeartbeat(t *testing.T) {
+	queueClient := queuemocks.NewMockClient()
+	executionManager := executionmanager.New()
+
+	executionManager.AddID(1)
+	executionManager.AddID(2)
+	executionManager.AddID(4)
+	executionManager.AddID(5)
+
+	heartbeater := &Heartbeater{
+		queueClient:      queueClient,
+		executionManager: executionManager,
+	}
+
+	if err := heartbeater.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error performing heartbeat: %s", err)
+	}
+
+	if callCount := len(queueClient.HeartbeatFunc.History()); callCount < 1 {
+		t.Errorf("unexpected heartbeat call count. want>=%d have=%d", 1, callCount)
+	} else {
+		ids := queueClient.HeartbeatFunc.History()[0].Arg1
+		sort.Ints(ids)
+
+		if diff := cmp.Diff([]int{1, 2, 4, 5}, ids); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	}
+}
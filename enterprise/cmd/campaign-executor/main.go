@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	executionmanager "github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/execution_manager"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/executor"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/campaign-executor/internal/heartbeat"
+	queue "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client"
+	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpserver"
+	"github.com/sourcegraph/sourcegraph/internal/logging"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+const Port = 3191
+
+func main() {
+	env.Lock()
+	env.HandleHelpFlag()
+	logging.Init()
+	trace.Init(false)
+
+	var (
+		frontendURL               = mustGet(rawFrontendURL, "CAMPAIGN_EXECUTOR_EXTERNAL_URL")
+		internalProxyAuthToken    = mustGet(rawInternalProxyAuthToken, "CAMPAIGN_EXECUTOR_INTERNAL_PROXY_AUTH_TOKEN")
+		executorPollInterval      = mustParseInterval(rawExecutorPollInterval, "CAMPAIGN_EXECUTOR_POLL_INTERVAL")
+		executorHeartbeatInterval = mustParseInterval(rawExecutorHeartbeatInterval, "CAMPAIGN_EXECUTOR_HEARTBEAT_INTERVAL")
+		numContainers             = mustParseInt(rawMaxContainers, "CAMPAIGN_EXECUTOR_MAXIMUM_CONTAINERS")
+		firecrackerImage          = mustGet(rawFirecrackerImage, "CAMPAIGN_EXECUTOR_FIRECRACKER_IMAGE")
+		useFirecracker            = mustParseBool(rawUseFirecracker, "CAMPAIGN_EXECUTOR_USE_FIRECRACKER")
+		firecrackerNumCPUs        = mustParseInt(rawFirecrackerNumCPUs, "CAMPAIGN_EXECUTOR_FIRECRACKER_NUM_CPUS")
+		firecrackerMemory         = mustGet(rawFirecrackerMemory, "CAMPAIGN_EXECUTOR_FIRECRACKER_MEMORY")
+		firecrackerDiskSpace      = mustGet(rawFirecrackerDiskSpace, "CAMPAIGN_EXECUTOR_FIRECRACKER_DISK_SPACE")
+		stepNetwork               = mustGet(rawStepNetwork, "CAMPAIGN_EXECUTOR_STEP_NETWORK")
+	)
+
+	var imageArchivePath string
+	if useFirecracker {
+		imageArchivePath = mustGet(rawImageArchivePath, "CAMPAIGN_EXECUTOR_IMAGE_ARCHIVE_PATH")
+	}
+
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	executorName := uuid.New().String()
+
+	queueClient := queue.NewClient(
+		executorName,
+		frontendURL,
+		internalProxyAuthToken,
+	)
+	executionManager := executionmanager.New()
+
+	server := httpserver.New(Port, func(router *mux.Router) {})
+	heartbeater := heartbeat.NewHeartbeater(context.Background(), queueClient, executionManager, heartbeat.HeartbeaterOptions{
+		Interval: executorHeartbeatInterval,
+	})
+	executor := executor.NewExecutor(context.Background(), queueClient, executionManager, executor.ExecutorOptions{
+		NumExecutors: numContainers,
+		Interval:     executorPollInterval,
+		Metrics:      executor.NewExecutorMetrics(observationContext),
+		HandlerOptions: executor.HandlerOptions{
+			FrontendURL:          frontendURL,
+			AuthToken:            internalProxyAuthToken,
+			FirecrackerImage:     firecrackerImage,
+			UseFirecracker:       useFirecracker,
+			FirecrackerNumCPUs:   firecrackerNumCPUs,
+			FirecrackerMemory:    firecrackerMemory,
+			FirecrackerDiskSpace: firecrackerDiskSpace,
+			ImageArchivePath:     imageArchivePath,
+			StepNetwork:          stepNetwork,
+		},
+	})
+
+	go debugserver.Start()
+	goroutine.MonitorBackgroundRoutines(server, executor, heartbeater)
+}
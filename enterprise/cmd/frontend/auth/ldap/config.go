@@ -0,0 +1,96 @@
+package ldap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var mockGetProviderValue *provider
+
+// getProvider looks up the registered LDAP auth provider with the given ID.
+func getProvider(id string) *provider {
+	if mockGetProviderValue != nil {
+		return mockGetProviderValue
+	}
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+	return p
+}
+
+func handleGetProvider(ctx context.Context, w http.ResponseWriter, id string) (p *provider, handled bool) {
+	p = getProvider(id)
+	if p == nil {
+		log15.Error("No LDAP auth provider found with ID.", "id", id)
+		http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+		return nil, true
+	}
+	return p, false
+}
+
+func init() {
+	conf.ContributeValidator(validateConfig)
+}
+
+func validateConfig(c conf.Unified) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+
+		if p.Ldap.BindDN != "" && p.Ldap.BindPassword == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d sets `bindDN` but no `bindPassword`", i)))
+		}
+
+		id := providerConfigID(withConfigDefaults(p.Ldap))
+		if j, ok := seen[id]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+		} else {
+			seen[id] = i
+		}
+	}
+	return problems
+}
+
+// withConfigDefaults returns a copy of the given LDAP auth provider config
+// with the defaults of unset properties filled in.
+func withConfigDefaults(pc *schema.LDAPAuthProvider) *schema.LDAPAuthProvider {
+	c := *pc
+	if c.UserFilter == "" {
+		c.UserFilter = "(uid={username})"
+	}
+	if c.UsernameAttribute == "" {
+		c.UsernameAttribute = "uid"
+	}
+	if c.DisplayNameAttribute == "" {
+		c.DisplayNameAttribute = "cn"
+	}
+	if c.EmailAttribute == "" {
+		c.EmailAttribute = "mail"
+	}
+	if c.GroupMemberAttribute == "" {
+		c.GroupMemberAttribute = "member"
+	}
+	return &c
+}
+
+// providerConfigID produces a semi-stable identifier for an LDAP auth provider
+// config object. It is used to distinguish between multiple auth providers of
+// the same type on the sign-in form. Its value is never persisted, and it must
+// be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	if pc.ConfigID != "" {
+		return pc.ConfigID
+	}
+	// Only the properties that identify the directory and its users are used,
+	// so that the ID doesn't depend on the bind password.
+	b := sha256.Sum256([]byte(pc.Url + "\n" + pc.BaseDN + "\n" + pc.UserFilter))
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
@@ -0,0 +1,222 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+)
+
+// timeout is the timeout of every request to the LDAP server.
+const timeout = 10 * time.Second
+
+// defaultUserIDAttributes are the attributes that identify the entry of a user
+// if userIDAttribute is not set: entryUUID (RFC 4530) is supported by OpenLDAP
+// and most other directories, objectGUID by Active Directory.
+var defaultUserIDAttributes = []string{"entryUUID", "objectGUID"}
+
+// directoryUser is the entry of a user in the LDAP directory.
+type directoryUser struct {
+	// ID permanently identifies the entry. Unlike the DN, it does not change
+	// when the entry is renamed or moved.
+	ID          string
+	DN          string
+	Username    string
+	DisplayName string
+	Email       string
+}
+
+var (
+	// errInvalidCredentials is returned by authenticate if there's no user
+	// with the given username or if the password is wrong.
+	errInvalidCredentials = errors.New("invalid username or password")
+
+	// errNotGroupMember is returned by authenticate if the user isn't a
+	// member of the required group.
+	errNotGroupMember = errors.New("user is not a member of the required group")
+)
+
+// authenticate looks up the user with the given username in the directory
+// and verifies their password by binding as the user.
+//
+// 🚨 SECURITY: The returned user is authenticated only if the error is nil.
+func (p *provider) authenticate(username, password string) (*directoryUser, error) {
+	// 🚨 SECURITY: LDAP servers treat a simple bind with an empty password as
+	// an unauthenticated bind, which succeeds for any DN.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := p.dial()
+	if err != nil {
+		return nil, errors.Wrap(err, "connecting to LDAP server")
+	}
+	defer conn.Close()
+
+	if err := p.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+
+	filter := strings.ReplaceAll(p.config.UserFilter, "{username}", goldap.EscapeFilter(username))
+	res, err := conn.Search(goldap.NewSearchRequest(
+		p.config.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases,
+		2, int(timeout.Seconds()), false,
+		filter,
+		append([]string{p.config.UsernameAttribute, p.config.DisplayNameAttribute, p.config.EmailAttribute}, p.userIDAttributes()...),
+		nil,
+	))
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) || (err == nil && len(res.Entries) > 1) {
+		return nil, fmt.Errorf("user filter %q matches more than one entry", filter)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "searching for user")
+	}
+	if len(res.Entries) == 0 {
+		return nil, errInvalidCredentials
+	}
+	entry := res.Entries[0]
+
+	// 🚨 SECURITY: Verify the password by binding as the user.
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "binding as user")
+	}
+
+	if p.config.RequiredGroup != "" {
+		// The user might not be allowed to read the group, so we go back to
+		// the service account.
+		if err := p.bindServiceAccount(conn); err != nil {
+			return nil, err
+		}
+
+		member, err := p.isGroupMember(conn, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, errNotGroupMember
+		}
+	}
+
+	user := &directoryUser{
+		ID:          p.userID(entry),
+		DN:          entry.DN,
+		Username:    entry.GetAttributeValue(p.config.UsernameAttribute),
+		DisplayName: entry.GetAttributeValue(p.config.DisplayNameAttribute),
+		Email:       entry.GetAttributeValue(p.config.EmailAttribute),
+	}
+	if user.Username == "" {
+		return nil, fmt.Errorf("entry %q has no %q attribute", entry.DN, p.config.UsernameAttribute)
+	}
+	return user, nil
+}
+
+// userIDAttributes returns the attributes that may identify the entry of a
+// user, in order of preference.
+func (p *provider) userIDAttributes() []string {
+	if p.config.UserIDAttribute != "" {
+		return []string{p.config.UserIDAttribute}
+	}
+	return defaultUserIDAttributes
+}
+
+// userID returns the value of the first of the user ID attributes that the
+// given entry has. If it has none of them, the DN of the entry is returned.
+func (p *provider) userID(entry *goldap.Entry) string {
+	for _, attr := range p.userIDAttributes() {
+		value := entry.GetRawAttributeValue(attr)
+		if len(value) == 0 {
+			continue
+		}
+		// Active Directory stores GUIDs in binary, so we format them the same
+		// way as its tools do.
+		if strings.EqualFold(attr, "objectGUID") && len(value) == 16 {
+			return formatGUID(value)
+		}
+		return string(value)
+	}
+	return entry.DN
+}
+
+// formatGUID formats the binary GUID b in its string form, in which the first
+// three groups are little-endian.
+func formatGUID(b []byte) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%x-%x",
+		binary.LittleEndian.Uint32(b[0:4]),
+		binary.LittleEndian.Uint16(b[4:6]),
+		binary.LittleEndian.Uint16(b[6:8]),
+		b[8:10],
+		b[10:16],
+	)
+}
+
+// isGroupMember returns whether the entry with the given DN is a member of
+// the required group.
+func (p *provider) isGroupMember(conn *goldap.Conn, dn string) (bool, error) {
+	res, err := conn.Search(goldap.NewSearchRequest(
+		p.config.RequiredGroup, goldap.ScopeBaseObject, goldap.NeverDerefAliases,
+		1, int(timeout.Seconds()), false,
+		fmt.Sprintf("(%s=%s)", goldap.EscapeFilter(p.config.GroupMemberAttribute), goldap.EscapeFilter(dn)),
+		[]string{"dn"},
+		nil,
+	))
+	if err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultNoSuchObject) {
+			return false, fmt.Errorf("required group %q does not exist", p.config.RequiredGroup)
+		}
+		return false, errors.Wrap(err, "searching for required group")
+	}
+	return len(res.Entries) > 0, nil
+}
+
+// bindServiceAccount binds as the configured service account, or anonymously
+// if there is none.
+func (p *provider) bindServiceAccount(conn *goldap.Conn) error {
+	var err error
+	if p.config.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(p.config.BindDN, p.config.BindPassword)
+	}
+	return errors.Wrap(err, "binding as service account")
+}
+
+// dial connects to the LDAP server.
+func (p *provider) dial() (*goldap.Conn, error) {
+	u, err := url.Parse(p.config.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing URL")
+	}
+
+	tlsConfig := &tls.Config{ServerName: u.Hostname()}
+	if p.config.Certificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(p.config.Certificate)) {
+			return nil, errors.New("invalid certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	conn, err := goldap.DialURL(p.config.Url, goldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+
+	if p.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "upgrading connection with StartTLS")
+		}
+	}
+
+	return conn, nil
+}
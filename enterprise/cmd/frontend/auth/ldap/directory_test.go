@@ -0,0 +1,221 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	testBaseDN       = "dc=example,dc=com"
+	testBindDN       = "cn=admin,dc=example,dc=com"
+	testAliceDN      = "uid=alice,ou=people,dc=example,dc=com"
+	testBobDN        = "uid=bob,ou=people,dc=example,dc=com"
+	testGroupDN      = "cn=developers,ou=groups,dc=example,dc=com"
+	testBindPassword = "admin-password"
+
+	testAliceUUID = "5d2a4e2c-7a3f-4b8e-9c1d-2f6e8a0b4c3d"
+	testBobGUID   = "d9b6a2f1-3c4e-4a5b-8c7d-1e2f3a4b5c6d"
+)
+
+var testDirectory = map[string]testEntry{
+	testBindDN: {
+		password: testBindPassword,
+		attrs:    map[string][]string{"cn": {"admin"}},
+	},
+	testAliceDN: {
+		password: "alice-password",
+		attrs: map[string][]string{
+			"objectClass": {"person"},
+			"entryUUID":   {testAliceUUID},
+			"uid":         {"alice"},
+			"cn":          {"Alice Smith"},
+			"mail":        {"alice@example.com"},
+		},
+	},
+	testBobDN: {
+		password: "bob-password",
+		attrs: map[string][]string{
+			"objectClass": {"person"},
+			// The binary form of testBobGUID, as stored by Active Directory.
+			"objectGUID": {"\xf1\xa2\xb6\xd9\x4e\x3c\x5b\x4a\x8c\x7d\x1e\x2f\x3a\x4b\x5c\x6d"},
+			"uid":        {"bob"},
+			"cn":         {"Bob Jones"},
+		},
+	},
+	testGroupDN: {
+		attrs: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"developers"},
+			"member":      {testAliceDN},
+		},
+	},
+}
+
+func TestProviderAuthenticate(t *testing.T) {
+	url := newTestServer(t, testDirectory)
+
+	newProvider := func(modify func(*schema.LDAPAuthProvider)) *provider {
+		pc := &schema.LDAPAuthProvider{
+			Type:         providerType,
+			Url:          url,
+			BindDN:       testBindDN,
+			BindPassword: testBindPassword,
+			BaseDN:       testBaseDN,
+		}
+		if modify != nil {
+			modify(pc)
+		}
+		return &provider{config: *withConfigDefaults(pc)}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*schema.LDAPAuthProvider)
+		username string
+		password string
+		wantUser *directoryUser
+		wantErr  error
+	}{
+		{
+			name:     "valid credentials",
+			username: "alice",
+			password: "alice-password",
+			wantUser: &directoryUser{
+				ID:          testAliceUUID,
+				DN:          testAliceDN,
+				Username:    "alice",
+				DisplayName: "Alice Smith",
+				Email:       "alice@example.com",
+			},
+		},
+		{
+			name:     "user without email",
+			username: "bob",
+			password: "bob-password",
+			wantUser: &directoryUser{
+				ID:          testBobGUID,
+				DN:          testBobDN,
+				Username:    "bob",
+				DisplayName: "Bob Jones",
+			},
+		},
+		{
+			name:     "wrong password",
+			username: "alice",
+			password: "bob-password",
+			wantErr:  errInvalidCredentials,
+		},
+		{
+			name:     "empty password",
+			username: "alice",
+			password: "",
+			wantErr:  errInvalidCredentials,
+		},
+		{
+			name:     "unknown user",
+			username: "carol",
+			password: "carol-password",
+			wantErr:  errInvalidCredentials,
+		},
+		{
+			name:     "filter injection",
+			username: "*",
+			password: "alice-password",
+			wantErr:  errInvalidCredentials,
+		},
+		{
+			name:     "custom user filter",
+			modify:   func(pc *schema.LDAPAuthProvider) { pc.UserFilter = "(&(objectClass=person)(mail={username}))" },
+			username: "alice@example.com",
+			password: "alice-password",
+			wantUser: &directoryUser{
+				ID:          testAliceUUID,
+				DN:          testAliceDN,
+				Username:    "alice",
+				DisplayName: "Alice Smith",
+				Email:       "alice@example.com",
+			},
+		},
+		{
+			name:     "custom user ID attribute",
+			modify:   func(pc *schema.LDAPAuthProvider) { pc.UserIDAttribute = "uid" },
+			username: "alice",
+			password: "alice-password",
+			wantUser: &directoryUser{
+				ID:          "alice",
+				DN:          testAliceDN,
+				Username:    "alice",
+				DisplayName: "Alice Smith",
+				Email:       "alice@example.com",
+			},
+		},
+		{
+			name:     "missing user ID attribute",
+			modify:   func(pc *schema.LDAPAuthProvider) { pc.UserIDAttribute = "employeeNumber" },
+			username: "alice",
+			password: "alice-password",
+			wantUser: &directoryUser{
+				ID:          testAliceDN,
+				DN:          testAliceDN,
+				Username:    "alice",
+				DisplayName: "Alice Smith",
+				Email:       "alice@example.com",
+			},
+		},
+		{
+			name:     "member of required group",
+			modify:   func(pc *schema.LDAPAuthProvider) { pc.RequiredGroup = testGroupDN },
+			username: "alice",
+			password: "alice-password",
+			wantUser: &directoryUser{
+				ID:          testAliceUUID,
+				DN:          testAliceDN,
+				Username:    "alice",
+				DisplayName: "Alice Smith",
+				Email:       "alice@example.com",
+			},
+		},
+		{
+			name:     "not member of required group",
+			modify:   func(pc *schema.LDAPAuthProvider) { pc.RequiredGroup = testGroupDN },
+			username: "bob",
+			password: "bob-password",
+			wantErr:  errNotGroupMember,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := newProvider(tc.modify).authenticate(tc.username, tc.password)
+			if err != tc.wantErr {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantUser, user); diff != "" {
+				t.Errorf("user mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("wrong bind password", func(t *testing.T) {
+		p := newProvider(func(pc *schema.LDAPAuthProvider) { pc.BindPassword = "wrong" })
+		if _, err := p.authenticate("alice", "alice-password"); err == nil || err == errInvalidCredentials {
+			t.Fatalf("got error %v, want service account bind error", err)
+		}
+	})
+
+	t.Run("ambiguous user filter", func(t *testing.T) {
+		p := newProvider(func(pc *schema.LDAPAuthProvider) { pc.UserFilter = "(|(uid={username})(objectClass=person))" })
+		if _, err := p.authenticate("alice", "alice-password"); err == nil || err == errInvalidCredentials {
+			t.Fatalf("got error %v, want ambiguous filter error", err)
+		}
+	})
+
+	t.Run("missing required group", func(t *testing.T) {
+		p := newProvider(func(pc *schema.LDAPAuthProvider) { pc.RequiredGroup = "cn=missing,dc=other,dc=com" })
+		if _, err := p.authenticate("alice", "alice-password"); err == nil || err == errNotGroupMember {
+			t.Fatalf("got error %v, want missing group error", err)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+// Package ldap implements auth via LDAP.
+package ldap
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+const stateCookieName = "sg-ldap-state"
+
+const stateCookieTimeout = time.Minute * 15
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding endpoints under the auth path prefix
+// ("/.auth") to enable the login flow.
+//
+// Unlike the other SSO auth providers, LDAP doesn't have a login page of its own, so this
+// middleware serves a form that asks for the user's directory username and password. The
+// credentials are verified by searching for the user's entry and binding as it (see
+// (*provider).authenticate). Upon success, the user is looked up or created and a new session is
+// started.
+//
+// 🚨 SECURITY
+var Middleware = &auth.Middleware{
+	API: func(next http.Handler) http.Handler {
+		return next
+	},
+	App: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, authPrefix+"/") {
+				authHandler(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	},
+}
+
+// authHandler serves the LDAP login form and handles its submission.
+//
+// 🚨 SECURITY
+func authHandler(w http.ResponseWriter, r *http.Request) {
+	if strings.TrimPrefix(r.URL.Path, authPrefix) != "/login" {
+		http.Error(w, "", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		p, handled := handleGetProvider(r.Context(), w, r.URL.Query().Get("pc"))
+		if handled {
+			return
+		}
+		renderLoginForm(w, p, r.URL.Query().Get("redirect"), "", http.StatusOK)
+
+	case http.MethodPost:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "Authentication failed. The login form could not be parsed.", http.StatusBadRequest)
+			return
+		}
+
+		// 🚨 SECURITY: The auth middleware runs before the CSRF middleware, so we check that the
+		// form was submitted from the page we rendered by comparing the state in the form with the
+		// one in the cookie.
+		stateCookie, err := r.Cookie(stateCookieName)
+		if err != nil || stateCookie.Value == "" || subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(r.PostForm.Get("state"))) != 1 {
+			log15.Error("LDAP auth failed: state cookie mismatch (possible request forgery).")
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: LDAP state parameter did not match the expected value (possible request forgery).", http.StatusBadRequest)
+			return
+		}
+
+		p, handled := handleGetProvider(r.Context(), w, r.PostForm.Get("pc"))
+		if handled {
+			return
+		}
+		redirect := r.PostForm.Get("redirect")
+
+		user, err := p.authenticate(r.PostForm.Get("username"), r.PostForm.Get("password"))
+		switch {
+		case err == errInvalidCredentials:
+			renderLoginForm(w, p, redirect, "Invalid username or password.", http.StatusUnauthorized)
+			return
+		case err == errNotGroupMember:
+			log15.Warn("LDAP auth failed: user is not a member of the required group.", "username", r.PostForm.Get("username"), "requiredGroup", p.config.RequiredGroup)
+			http.Error(w, "Authentication failed. You are not a member of the group required to sign in.", http.StatusForbidden)
+			return
+		case err != nil:
+			log15.Error("LDAP auth failed: error authenticating user.", "error", err)
+			http.Error(w, "Authentication failed. An error occurred while contacting the LDAP server.", http.StatusInternalServerError)
+			return
+		}
+
+		actr, safeErrMsg, err := getOrCreateUser(r, p, user)
+		if err != nil {
+			log15.Error("LDAP auth failed: error looking up LDAP-authenticated user.", "error", err, "userErr", safeErrMsg)
+			http.Error(w, safeErrMsg, http.StatusInternalServerError)
+			return
+		}
+
+		if err := session.SetActor(w, r, actr, 0); err != nil {
+			log15.Error("LDAP auth failed: could not initiate session.", "error", err)
+			http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
+			return
+		}
+
+		// 🚨 SECURITY: Call auth.SafeRedirectURL to avoid an open-redirect vuln.
+		http.Redirect(w, r, auth.SafeRedirectURL(redirect), http.StatusFound)
+
+	default:
+		http.Error(w, "", http.StatusMethodNotAllowed)
+	}
+}
+
+// getOrCreateUser looks up or creates the Sourcegraph user of the given directory user.
+func getOrCreateUser(r *http.Request, p *provider, user *directoryUser) (_ *actor.Actor, safeErrMsg string, err error) {
+	username, err := auth.NormalizeUsername(user.Username)
+	if err != nil {
+		return nil, "Error normalizing the username from the LDAP directory.", err
+	}
+
+	userID, safeErrMsg, err := auth.GetAndSaveUser(r.Context(), auth.GetAndSaveUserOp{
+		UserProps: db.NewUser{
+			Username:    username,
+			Email:       user.Email,
+			DisplayName: user.DisplayName,
+			// The directory is managed by the site admin, so we trust the email address in it.
+			EmailIsVerified: user.Email != "",
+		},
+		ExternalAccount: extsvc.AccountSpec{
+			ServiceType: providerType,
+			ServiceID:   p.config.Url,
+			AccountID:   user.ID,
+		},
+		CreateIfNotExist: true,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// renderLoginForm renders the login form of the given provider, with a new
+// state that's also stored in the state cookie.
+func renderLoginForm(w http.ResponseWriter, p *provider, redirect, errorMessage string, status int) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log15.Error("LDAP auth failed: could not generate state.", "error", err)
+		http.Error(w, "Could not generate LDAP state.", http.StatusInternalServerError)
+		return
+	}
+	state := base64.RawURLEncoding.EncodeToString(b)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     authPrefix + "/",
+		Expires:  time.Now().Add(stateCookieTimeout),
+		HttpOnly: true,
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := loginFormTemplate.Execute(w, map[string]string{
+		"Action":      authPrefix + "/login",
+		"DisplayName": p.CachedInfo().DisplayName,
+		"ProviderID":  p.ConfigID().ID,
+		"Redirect":    redirect,
+		"State":       state,
+		"Error":       errorMessage,
+	}); err != nil {
+		log15.Error("Failed to render LDAP login form.", "error", err)
+	}
+}
+
+var loginFormTemplate = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in with {{.DisplayName}} - Sourcegraph</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; display: flex; justify-content: center; margin-top: 10vh; }
+form { display: flex; flex-direction: column; width: 20rem; }
+input { margin-bottom: 0.75rem; padding: 0.375rem; }
+.error { color: #cc0000; }
+</style>
+</head>
+<body>
+<form method="post" action="{{.Action}}">
+<h2>Sign in with {{.DisplayName}}</h2>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<label for="username">Username</label>
+<input id="username" name="username" type="text" autocomplete="username" autofocus required>
+<label for="password">Password</label>
+<input id="password" name="password" type="password" autocomplete="current-password" required>
+<input name="pc" type="hidden" value="{{.ProviderID}}">
+<input name="redirect" type="hidden" value="{{.Redirect}}">
+<input name="state" type="hidden" value="{{.State}}">
+<input type="submit" value="Sign in">
+</form>
+</body>
+</html>
+`))
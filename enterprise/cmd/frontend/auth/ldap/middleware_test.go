@@ -0,0 +1,156 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMiddleware(t *testing.T) {
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	mockGetProviderValue = &provider{
+		config: *withConfigDefaults(&schema.LDAPAuthProvider{
+			Type:         providerType,
+			Url:          newTestServer(t, testDirectory),
+			BindDN:       testBindDN,
+			BindPassword: testBindPassword,
+			BaseDN:       testBaseDN,
+		}),
+	}
+	defer func() { mockGetProviderValue = nil }()
+	providerID := mockGetProviderValue.ConfigID().ID
+
+	const mockUserID = 123
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		if op.ExternalAccount.ServiceType == providerType && op.ExternalAccount.ServiceID == mockGetProviderValue.config.Url && op.ExternalAccount.AccountID == testAliceUUID &&
+			op.UserProps.Username == "alice" && op.UserProps.Email == "alice@example.com" && op.UserProps.EmailIsVerified && op.CreateIfNotExist {
+			return mockUserID, "", nil
+		}
+		return 0, "safeErr", fmt.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	authedHandler := http.NewServeMux()
+	authedHandler.Handle("/.api/", Middleware.API(h))
+	authedHandler.Handle("/", Middleware.App(h))
+
+	doRequest := func(method, urlStr string, form url.Values, cookies []*http.Cookie) *http.Response {
+		req := httptest.NewRequest(method, urlStr, strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		respRecorder := httptest.NewRecorder()
+		authedHandler.ServeHTTP(respRecorder, req)
+		return respRecorder.Result()
+	}
+
+	getLoginForm := func(t *testing.T) *http.Cookie {
+		resp := doRequest("GET", "http://example.com/.auth/ldap/login?pc="+providerID+"&redirect=/page", nil, nil)
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == stateCookieName {
+				if !strings.Contains(string(body), `name="state" type="hidden" value="`+cookie.Value+`"`) {
+					t.Fatalf("login form doesn't contain state %q", cookie.Value)
+				}
+				return cookie
+			}
+		}
+		t.Fatal("no state cookie set")
+		return nil
+	}
+
+	loginForm := func(state, password string) url.Values {
+		return url.Values{
+			"pc":       {providerID},
+			"redirect": {"/page"},
+			"state":    {state},
+			"username": {"alice"},
+			"password": {password},
+		}
+	}
+
+	t.Run("unauthenticated homepage visit -> pass through", func(t *testing.T) {
+		resp := doRequest("GET", "http://example.com/", nil, nil)
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+	t.Run("unauthenticated API request -> pass through", func(t *testing.T) {
+		resp := doRequest("GET", "http://example.com/.api/foo", nil, nil)
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+	t.Run("login with unknown provider -> error", func(t *testing.T) {
+		mock := mockGetProviderValue
+		mockGetProviderValue = nil
+		defer func() { mockGetProviderValue = mock }()
+
+		resp := doRequest("GET", "http://example.com/.auth/ldap/login?pc=unknown", nil, nil)
+		if want := http.StatusInternalServerError; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+	t.Run("submit login form without state cookie -> error", func(t *testing.T) {
+		stateCookie := getLoginForm(t)
+		resp := doRequest("POST", "http://example.com/.auth/ldap/login", loginForm(stateCookie.Value, "alice-password"), nil)
+		if want := http.StatusBadRequest; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+	t.Run("submit login form with mismatched state -> error", func(t *testing.T) {
+		stateCookie := getLoginForm(t)
+		resp := doRequest("POST", "http://example.com/.auth/ldap/login", loginForm("bad", "alice-password"), []*http.Cookie{stateCookie})
+		if want := http.StatusBadRequest; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+	t.Run("submit login form with wrong password -> login form", func(t *testing.T) {
+		stateCookie := getLoginForm(t)
+		resp := doRequest("POST", "http://example.com/.auth/ldap/login", loginForm(stateCookie.Value, "wrong"), []*http.Cookie{stateCookie})
+		if want := http.StatusUnauthorized; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		if want := "Invalid username or password."; !strings.Contains(string(body), want) {
+			t.Errorf("got body %q, want contains %q", body, want)
+		}
+	})
+	t.Run("submit login form with valid credentials -> new session", func(t *testing.T) {
+		stateCookie := getLoginForm(t)
+		resp := doRequest("POST", "http://example.com/.auth/ldap/login", loginForm(stateCookie.Value, "alice-password"), []*http.Cookie{stateCookie})
+		if want := http.StatusFound; resp.StatusCode != want {
+			t.Errorf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		if got, want := resp.Header.Get("Location"), "/page"; got != want {
+			t.Errorf("got redirect URL %v, want %v", got, want)
+		}
+		sessionCookie := false
+		for _, cookie := range resp.Cookies() {
+			if cookie.Name == "sgs" {
+				sessionCookie = true
+			}
+		}
+		if !sessionCookie {
+			t.Error("no session cookie set")
+		}
+	})
+}
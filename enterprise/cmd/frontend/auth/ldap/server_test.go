@@ -0,0 +1,201 @@
+package ldap
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// testEntry is an entry in the directory of a test LDAP server.
+type testEntry struct {
+	password string
+	attrs    map[string][]string
+}
+
+// newTestServer starts an in-process LDAP server that serves the given
+// directory, keyed by DN, and returns its URL. It supports just enough of the
+// protocol for authenticate: simple binds and searches with and, or, not,
+// equality and presence filters.
+func newTestServer(t *testing.T, directory map[string]testEntry) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, directory)
+		}
+	}()
+
+	return "ldap://" + l.Addr().String()
+}
+
+func serveTestConn(conn net.Conn, directory map[string]testEntry) {
+	defer conn.Close()
+
+	for {
+		msg, err := ber.ReadPacket(conn)
+		if err != nil || len(msg.Children) < 2 {
+			return
+		}
+		id, _ := msg.Children[0].Value.(int64)
+		op := msg.Children[1]
+
+		var responses []*ber.Packet
+		switch op.Tag {
+		case ber.Tag(goldap.ApplicationBindRequest):
+			responses = append(responses, testBind(op, directory))
+		case ber.Tag(goldap.ApplicationSearchRequest):
+			responses = testSearch(op, directory)
+		case ber.Tag(goldap.ApplicationUnbindRequest):
+			return
+		default:
+			return
+		}
+
+		for _, response := range responses {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+			envelope.AppendChild(response)
+			if _, err := conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func testBind(op *ber.Packet, directory map[string]testEntry) *ber.Packet {
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+
+	code := goldap.LDAPResultInvalidCredentials
+	if dn == "" && password == "" {
+		code = goldap.LDAPResultSuccess
+	} else if e, ok := lookupTestEntry(directory, dn); ok && password != "" && e.password == password {
+		code = goldap.LDAPResultSuccess
+	}
+	return testResult(goldap.ApplicationBindResponse, int64(code))
+}
+
+func testSearch(op *ber.Packet, directory map[string]testEntry) []*ber.Packet {
+	base := strings.ToLower(op.Children[0].Data.String())
+	scope, _ := op.Children[1].Value.(int64)
+	sizeLimit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var dns []string
+	baseExists := false
+	for dn := range directory {
+		ldn := strings.ToLower(dn)
+		if ldn != base && !strings.HasSuffix(ldn, ","+base) {
+			continue
+		}
+		baseExists = true
+		if scope == int64(goldap.ScopeBaseObject) && ldn != base {
+			continue
+		}
+		dns = append(dns, dn)
+	}
+	if !baseExists {
+		return []*ber.Packet{testResult(goldap.ApplicationSearchResultDone, int64(goldap.LDAPResultNoSuchObject))}
+	}
+	sort.Strings(dns)
+
+	var responses []*ber.Packet
+	for _, dn := range dns {
+		e := directory[dn]
+		if !matchTestFilter(filter, e) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(responses)) == sizeLimit {
+			return append(responses, testResult(goldap.ApplicationSearchResultDone, int64(goldap.LDAPResultSizeLimitExceeded)))
+		}
+
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ber.Tag(goldap.ApplicationSearchResultEntry), nil, "Search Result Entry")
+		entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+		attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for name, values := range e.attrs {
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, v := range values {
+				vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+			}
+			attr.AppendChild(vals)
+			attrs.AppendChild(attr)
+		}
+		entry.AppendChild(attrs)
+		responses = append(responses, entry)
+	}
+
+	return append(responses, testResult(goldap.ApplicationSearchResultDone, int64(goldap.LDAPResultSuccess)))
+}
+
+func matchTestFilter(f *ber.Packet, e testEntry) bool {
+	switch f.Tag {
+	case ber.Tag(goldap.FilterAnd):
+		for _, c := range f.Children {
+			if !matchTestFilter(c, e) {
+				return false
+			}
+		}
+		return true
+	case ber.Tag(goldap.FilterOr):
+		for _, c := range f.Children {
+			if matchTestFilter(c, e) {
+				return true
+			}
+		}
+		return false
+	case ber.Tag(goldap.FilterNot):
+		return !matchTestFilter(f.Children[0], e)
+	case ber.Tag(goldap.FilterEqualityMatch):
+		want := f.Children[1].Data.String()
+		for _, v := range testAttr(e, f.Children[0].Data.String()) {
+			if strings.EqualFold(v, want) {
+				return true
+			}
+		}
+		return false
+	case ber.Tag(goldap.FilterPresent):
+		return len(testAttr(e, f.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+func testAttr(e testEntry, name string) []string {
+	for n, values := range e.attrs {
+		if strings.EqualFold(n, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+func lookupTestEntry(directory map[string]testEntry, dn string) (testEntry, bool) {
+	for d, e := range directory {
+		if strings.EqualFold(d, dn) {
+			return e, true
+		}
+	}
+	return testEntry{}, false
+}
+
+func testResult(tag int, code int64) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ber.Tag(tag), nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
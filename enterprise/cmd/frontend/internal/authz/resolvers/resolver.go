@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -127,6 +128,103 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) SetSubRepositoryPermissionsForUsers(ctx context.Context, args *graphqlbackend.SubRepoPermsArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the repo ID is valid.
+	if _, err = db.Repos.Get(ctx, repoID); err != nil {
+		return nil, err
+	}
+
+	permsByBindID := make(map[string]*authz.SubRepoPermissions, len(args.UserPermissions))
+	bindIDs := make([]string, 0, len(args.UserPermissions))
+	for _, perms := range args.UserPermissions {
+		bindID := strings.TrimSpace(perms.BindID)
+		if bindID == "" {
+			continue
+		} else if _, ok := permsByBindID[bindID]; ok {
+			return nil, fmt.Errorf("duplicate bind ID %q", bindID)
+		}
+
+		p := &authz.SubRepoPermissions{RepoID: int32(repoID)}
+		if perms.PathIncludes != nil {
+			p.PathIncludes = *perms.PathIncludes
+		}
+		if perms.PathExcludes != nil {
+			p.PathExcludes = *perms.PathExcludes
+		}
+		if invalid := p.Validate(); len(invalid) > 0 {
+			return nil, fmt.Errorf("invalid path patterns for %q: %q", bindID, invalid)
+		}
+
+		permsByBindID[bindID] = p
+		bindIDs = append(bindIDs, bindID)
+	}
+
+	ps := make([]*authz.SubRepoPermissions, 0, len(bindIDs))
+	cfg := globals.PermissionsUserMapping()
+	switch cfg.BindID {
+	case "email":
+		emails, err := db.UserEmails.GetVerifiedEmails(ctx, bindIDs...)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range emails {
+			p, ok := permsByBindID[emails[i].Email]
+			if !ok {
+				continue
+			}
+			p.UserID = emails[i].UserID
+			ps = append(ps, p)
+			delete(permsByBindID, emails[i].Email)
+		}
+
+	case "username":
+		users, err := db.Users.GetByUsernames(ctx, bindIDs...)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range users {
+			p, ok := permsByBindID[users[i].Username]
+			if !ok {
+				continue
+			}
+			p.UserID = users[i].ID
+			ps = append(ps, p)
+			delete(permsByBindID, users[i].Username)
+		}
+
+	default:
+		return nil, fmt.Errorf("unrecognized user mapping bind ID type %q", cfg.BindID)
+	}
+
+	// Unlike repository permissions, path-level permissions can't be kept pending until the user
+	// is created, because the user would be able to view all paths of the repository meanwhile.
+	if len(permsByBindID) > 0 {
+		unknown := make([]string, 0, len(permsByBindID))
+		for id := range permsByBindID {
+			unknown = append(unknown, id)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("users not found for bind IDs %q", unknown)
+	}
+
+	if err = r.store.SetRepoSubRepoPermissions(ctx, int32(repoID), ps); err != nil {
+		return nil, errors.Wrap(err, "set repository sub-repository permissions")
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func (r *Resolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *graphqlbackend.RepositoryIDArgs) (*graphqlbackend.EmptyResponse, error) {
 	// 🚨 SECURITY: Only site admins can query repository permissions.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
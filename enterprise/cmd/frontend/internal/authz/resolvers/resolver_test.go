@@ -209,6 +209,103 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 	}
 }
 
+func TestResolver_SetSubRepositoryPermissionsForUsers(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.Users = db.MockUsers{}
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{}).SetSubRepositoryPermissionsForUsers(ctx, &graphqlbackend.SubRepoPermsArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "username"})
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByUsernames = func(context.Context, ...string) ([]*types.User, error) {
+		return []*types.User{{ID: 1, Username: "alice"}}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+	var got []*authz.SubRepoPermissions
+	edb.Mocks.Perms.SetRepoSubRepoPermissions = func(_ context.Context, _ int32, ps []*authz.SubRepoPermissions) error {
+		got = ps
+		return nil
+	}
+	t.Cleanup(func() {
+		db.Mocks.Users = db.MockUsers{}
+		db.Mocks.Repos = db.MockRepos{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	})
+
+	tests := []struct {
+		name      string
+		args      *graphqlbackend.SubRepoPermsArgs
+		expErr    bool
+		expResult []*authz.SubRepoPermissions
+	}{
+		{
+			name: "set permissions via username",
+			args: subRepoPermsArgs(1, "alice", []string{"src/**"}, []string{"src/secret/**"}),
+			expResult: []*authz.SubRepoPermissions{
+				{
+					UserID:       1,
+					RepoID:       1,
+					PathIncludes: []string{"src/**"},
+					PathExcludes: []string{"src/secret/**"},
+				},
+			},
+		},
+		{
+			name:   "unknown user",
+			args:   subRepoPermsArgs(1, "bob", []string{"src/**"}, nil),
+			expErr: true,
+		},
+		{
+			name:   "invalid path pattern",
+			args:   subRepoPermsArgs(1, "alice", []string{"/src/**"}, nil),
+			expErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got = nil
+			_, err := (&Resolver{}).SetSubRepositoryPermissionsForUsers(context.Background(), test.args)
+			if gotErr := err != nil; gotErr != test.expErr {
+				t.Fatalf("err: want error %v but got %v", test.expErr, err)
+			}
+			if diff := cmp.Diff(test.expResult, got); diff != "" {
+				t.Fatalf("permissions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func subRepoPermsArgs(repoID api.RepoID, bindID string, includes, excludes []string) *graphqlbackend.SubRepoPermsArgs {
+	args := &graphqlbackend.SubRepoPermsArgs{Repository: graphqlbackend.MarshalRepositoryID(repoID)}
+	args.UserPermissions = append(args.UserPermissions, struct {
+		BindID       string
+		PathIncludes *[]string
+		PathExcludes *[]string
+	}{
+		BindID:       bindID,
+		PathIncludes: &includes,
+		PathExcludes: &excludes,
+	})
+	return args
+}
+
 func TestResolver_ScheduleRepositoryPermissionsSync(t *testing.T) {
 	t.Run("authenticated as non-admin", func(t *testing.T) {
 		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
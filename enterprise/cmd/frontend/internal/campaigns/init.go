@@ -32,6 +32,12 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 	)
 	enterpriseServices.GitLabWebhook = campaigns.NewGitLabWebhook(campaignsStore, repositories, msResolutionClock)
 
+	h, err := newInternalProxyHandler()
+	if err != nil {
+		return err
+	}
+
+	enterpriseServices.NewCampaignsInternalProxyHandler = h
 	return nil
 }
 
@@ -0,0 +1,64 @@
+package campaigns
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/internalproxy"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+var executorQueueURL = env.Get("CAMPAIGN_EXECUTOR_QUEUE_URL", "", "HTTP address of the campaign executor queue served by repo-updater.")
+var internalProxyAuthToken = env.Get("CAMPAIGN_EXECUTOR_INTERNAL_PROXY_AUTH_TOKEN", "", "The auth token used to secure communication between the campaign-executor service and the internal API provided by this proxy.")
+
+func newInternalProxyHandler() (func() http.Handler, error) {
+	if executorQueueURL == "" {
+		factory := func() http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})
+		}
+
+		return factory, nil
+	}
+
+	if internalProxyAuthToken == "" {
+		return nil, fmt.Errorf("invalid value for CAMPAIGN_EXECUTOR_INTERNAL_PROXY_AUTH_TOKEN: no value supplied")
+	}
+
+	host, port, err := net.SplitHostPort(envvar.HTTPAddrInternal)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse internal API address %q", envvar.HTTPAddrInternal))
+	}
+
+	frontendOrigin, err := url.Parse(fmt.Sprintf("http://%s:%s/.internal/git", host, port))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct the origin for the internal frontend")
+	}
+
+	queueOrigin, err := url.Parse(executorQueueURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct the origin for the campaign executor queue")
+	}
+
+	factory := func() http.Handler {
+		// 🚨 SECURITY: These routes are secured by checking a token shared between services.
+		base := mux.NewRouter().PathPrefix("/.internal-campaigns/").Subrouter()
+		base.StrictSlash(true)
+
+		// Proxy only info/refs and git-upload-pack for gitservice (git clone/fetch)
+		base.Path("/git/{rest:.*/(?:info/refs|git-upload-pack)}").Handler(internalproxy.ReverseProxy(frontendOrigin))
+
+		// Proxy only the known routes in the campaign executor queue API
+		base.Path("/queue/{rest:(?:dequeue|setlog|setdiff|complete|heartbeat)}").Handler(internalproxy.ReverseProxy(queueOrigin))
+
+		return internalproxy.AuthTokenMiddleware(internalProxyAuthToken, base)
+	}
+
+	return factory, nil
+}
@@ -1,4 +1,4 @@
-package codeintel
+package internalproxy
 
 import (
 	"bytes"
@@ -10,12 +10,9 @@ import (
 	"testing"
 )
 
-func init() {
-	internalProxyAuthToken = "hunter2"
-}
-
-func TestInternalProxyAuthTokenMiddleware(t *testing.T) {
-	ts := httptest.NewServer(internalProxyAuthTokenMiddleware(
+func TestAuthTokenMiddleware(t *testing.T) {
+	ts := httptest.NewServer(AuthTokenMiddleware(
+		"hunter2",
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusTeapot)
 		}),
@@ -71,7 +68,7 @@ func TestReverseProxySimple(t *testing.T) {
 		t.Fatalf("unexpected error parsing url: %s", err)
 	}
 
-	proxyServer := httptest.NewServer(reverseProxy(url))
+	proxyServer := httptest.NewServer(ReverseProxy(url))
 	defer proxyServer.Close()
 
 	req, err := http.NewRequest("GET", proxyServer.URL, nil)
@@ -107,7 +104,7 @@ func TestReverseProxyTargetPath(t *testing.T) {
 		t.Fatalf("unexpected error parsing url: %s", err)
 	}
 
-	proxyServer := httptest.NewServer(reverseProxy(url))
+	proxyServer := httptest.NewServer(ReverseProxy(url))
 	defer proxyServer.Close()
 
 	req, err := http.NewRequest("GET", proxyServer.URL, nil)
@@ -136,7 +133,7 @@ func TestReverseProxyHeaders(t *testing.T) {
 		t.Fatalf("unexpected error parsing url: %s", err)
 	}
 
-	proxyServer := httptest.NewServer(reverseProxy(url))
+	proxyServer := httptest.NewServer(ReverseProxy(url))
 	defer proxyServer.Close()
 
 	req, err := http.NewRequest("GET", proxyServer.URL, nil)
@@ -181,7 +178,7 @@ func TestReverseProxyRedirectWithPayload(t *testing.T) {
 		t.Fatalf("unexpected error parsing url: %s", err)
 	}
 
-	proxyServer := httptest.NewServer(reverseProxy(url))
+	proxyServer := httptest.NewServer(ReverseProxy(url))
 	defer proxyServer.Close()
 
 	req, err := http.NewRequest("POST", proxyServer.URL, bytes.NewReader([]byte("foobarbaz")))
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -15,6 +16,9 @@ var (
 	rawMaxUploadAge        = env.Get("PRECISE_CODE_INTEL_MAX_UPLOAD_AGE", "24h", "The maximum time an upload can sit on disk.")
 	rawMaxUploadPartAge    = env.Get("PRECISE_CODE_INTEL_MAX_UPLOAD_PART_AGE", "2h", "The maximum time an upload part file can sit on disk.")
 	rawMaxDataAge          = env.Get("PRECISE_CODE_INTEL_MAX_DATA_AGE", "720h", "The maximum time LSIF data not visible from the tip of the default branch can remain in the database.")
+	rawRetentionTagPattern = env.Get("PRECISE_CODE_INTEL_RETENTION_TAG_PATTERN", "", "A regular expression matching the names of tags whose LSIF data is kept for PRECISE_CODE_INTEL_MAX_TAGGED_DATA_AGE.")
+	rawMaxTaggedDataAge    = env.Get("PRECISE_CODE_INTEL_MAX_TAGGED_DATA_AGE", "8760h", "The maximum time LSIF data for tags matching PRECISE_CODE_INTEL_RETENTION_TAG_PATTERN can remain in the database.")
+	rawRetentionDryRun     = env.Get("PRECISE_CODE_INTEL_RETENTION_DRY_RUN", "false", "Set to true to report the LSIF data that retention policies would remove without removing it.")
 	rawDisableJanitor      = env.Get("PRECISE_CODE_INTEL_DISABLE_JANITOR", "false", "Set to true to disable the janitor process during system migrations.")
 )
 
@@ -67,3 +71,18 @@ func mustParseBool(rawValue, name string) bool {
 
 	return v
 }
+
+// mustParseRegexp returns the compiled version of the given raw value fatally logs on failure. An
+// empty value results in a nil regular expression.
+func mustParseRegexp(rawValue, name string) *regexp.Regexp {
+	if rawValue == "" {
+		return nil
+	}
+
+	re, err := regexp.Compile(rawValue)
+	if err != nil {
+		log.Fatalf("invalid regular expression %q for %s: %s", rawValue, name, err)
+	}
+
+	return re
+}
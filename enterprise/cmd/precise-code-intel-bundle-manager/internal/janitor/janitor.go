@@ -10,6 +10,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -18,32 +19,42 @@ import (
 type Janitor struct {
 	store            store.Store
 	lsifStore        lsifstore.Store
+	gitserverClient  gitserverClient
 	bundleDir        string
 	maxUploadAge     time.Duration
 	maxUploadPartAge time.Duration
-	maxDataAge       time.Duration
+	retentionPolicy  RetentionPolicy
+	retentionDryRun  bool
 	metrics          JanitorMetrics
 }
 
 var _ goroutine.Handler = &Janitor{}
 
+type gitserverClient interface {
+	ListTags(ctx context.Context, store store.Store, repositoryID int) ([]gitserver.Tag, error)
+}
+
 func New(
 	store store.Store,
 	lsifStore lsifstore.Store,
+	gitserverClient gitserverClient,
 	bundleDir string,
 	janitorInterval time.Duration,
 	maxUploadAge time.Duration,
 	maxUploadPartAge time.Duration,
-	maxDataAge time.Duration,
+	retentionPolicy RetentionPolicy,
+	retentionDryRun bool,
 	metrics JanitorMetrics,
 ) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), janitorInterval, &Janitor{
 		store:            store,
 		lsifStore:        lsifStore,
+		gitserverClient:  gitserverClient,
 		bundleDir:        bundleDir,
 		maxUploadAge:     maxUploadAge,
 		maxUploadPartAge: maxUploadPartAge,
-		maxDataAge:       maxDataAge,
+		retentionPolicy:  retentionPolicy,
+		retentionDryRun:  retentionDryRun,
 		metrics:          metrics,
 	})
 }
@@ -55,7 +66,7 @@ func (j *Janitor) Handle(ctx context.Context) error {
 		j.removeOldUploadPartFiles,
 		j.removeOldUploadingRecords,
 		j.removeRecordsForDeletedRepositories,
-		j.removeExpiredData,
+		j.enforceRetentionPolicies,
 		j.hardDeleteDeletedRecords,
 		j.removeOrphanedData,
 	}
@@ -149,21 +160,6 @@ func (j *Janitor) removeRecordsForDeletedRepositories(ctx context.Context) {
 	j.metrics.UploadRecordsRemoved.Add(float64(totalCount))
 }
 
-// removeExpiredData removes upload records that have exceeded a threshold age and
-// is not an index visible from the head of the default branch.
-func (j *Janitor) removeExpiredData(ctx context.Context) {
-	count, err := j.store.SoftDeleteOldDumps(ctx, j.maxDataAge, time.Now())
-	if err != nil {
-		j.error("Failed to delete old dumps", "error", err)
-		return
-	}
-
-	if count > 0 {
-		log15.Debug("Removed old records not visible to the tip of the default branch of their repository", "count", count)
-		j.metrics.DataRowsRemoved.Add(float64(count))
-	}
-}
-
 const uploadBatchSize = 100
 
 // hardDeleteDeletedRecords removes upload records in the deleted state.
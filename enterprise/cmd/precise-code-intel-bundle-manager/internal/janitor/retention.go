@@ -0,0 +1,188 @@
+package janitor
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// RetentionPolicy determines how long LSIF data that is not visible from the tip of the default
+// branch of its repository is kept. Data visible from the tip of the default branch is never expired.
+type RetentionPolicy struct {
+	// MaxAge is the age after which data is expired.
+	MaxAge time.Duration
+
+	// TagPattern matches the names of tags whose commits have their data kept for MaxTaggedAge
+	// instead of MaxAge. If nil, tags are not considered.
+	TagPattern *regexp.Regexp
+
+	// MaxTaggedAge is the age after which data for a tagged commit matching TagPattern is expired.
+	MaxTaggedAge time.Duration
+}
+
+// override returns a copy of this policy with the non-nil fields of the given repository
+// retention configuration applied. An empty tag pattern disables the tag policy.
+func (p RetentionPolicy) override(configuration store.RetentionConfiguration) (RetentionPolicy, error) {
+	if configuration.MaxAge != nil {
+		p.MaxAge = *configuration.MaxAge
+	}
+	if configuration.MaxTaggedAge != nil {
+		p.MaxTaggedAge = *configuration.MaxTaggedAge
+	}
+
+	if configuration.TagPattern != nil {
+		if *configuration.TagPattern == "" {
+			p.TagPattern = nil
+		} else {
+			tagPattern, err := regexp.Compile(*configuration.TagPattern)
+			if err != nil {
+				return p, errors.Wrap(err, "invalid tag pattern")
+			}
+
+			p.TagPattern = tagPattern
+		}
+	}
+
+	return p, nil
+}
+
+// expired returns the identifiers of the given expiration candidates that have exceeded the age
+// allowed by this policy. The given set contains the commits of tags matching the tag pattern.
+func (p RetentionPolicy) expired(candidates []store.ExpirationCandidate, taggedCommits map[string]struct{}, now time.Time) (ids []int) {
+	for _, candidate := range candidates {
+		age := now.Sub(candidate.FinishedAt)
+		if age <= p.MaxAge {
+			continue
+		}
+
+		if _, ok := taggedCommits[candidate.Commit]; ok && age <= p.MaxTaggedAge {
+			continue
+		}
+
+		ids = append(ids, candidate.ID)
+	}
+
+	return ids
+}
+
+// enforceRetentionPolicies soft deletes the upload records that are not visible from the tip of the
+// default branch of their repository and have exceeded the age allowed by the global retention policy
+// or the retention configuration of their repository. In dry-run mode, the uploads that would have been
+// removed are only reported.
+func (j *Janitor) enforceRetentionPolicies(ctx context.Context) {
+	policies, err := j.retentionPolicies(ctx)
+	if err != nil {
+		j.error("Failed to get retention configurations", "error", err)
+		return
+	}
+
+	now := time.Now().UTC()
+
+	minMaxAge := j.retentionPolicy.MaxAge
+	for _, policy := range policies {
+		if policy.MaxAge < minMaxAge {
+			minMaxAge = policy.MaxAge
+		}
+	}
+
+	repositoryIDs, err := j.store.GetRepositoriesWithExpirationCandidates(ctx, now.Add(-minMaxAge))
+	if err != nil {
+		j.error("Failed to get repositories with expiration candidates", "error", err)
+		return
+	}
+
+	totalCount := 0
+	for _, repositoryID := range repositoryIDs {
+		policy, ok := policies[repositoryID]
+		if !ok {
+			policy = j.retentionPolicy
+		}
+
+		ids, err := j.expiredUploads(ctx, repositoryID, policy, now)
+		if err != nil {
+			j.error("Failed to apply retention policy", "repository_id", repositoryID, "error", err)
+			continue
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		if j.retentionDryRun {
+			log15.Info("Retention policy dry run: would remove expired records", "repository_id", repositoryID, "upload_ids", ids)
+			totalCount += len(ids)
+			continue
+		}
+
+		count, err := j.store.SoftDeleteUploadsByIDs(ctx, ids...)
+		if err != nil {
+			j.error("Failed to delete expired records", "repository_id", repositoryID, "error", err)
+			continue
+		}
+
+		totalCount += count
+	}
+
+	if j.retentionDryRun {
+		log15.Info("Retention policy dry run", "count", totalCount, "repository_count", len(repositoryIDs))
+		return
+	}
+
+	if totalCount > 0 {
+		log15.Debug("Removed expired records not visible to the tip of the default branch of their repository", "count", totalCount)
+		j.metrics.DataRowsRemoved.Add(float64(totalCount))
+	}
+}
+
+// retentionPolicies returns the retention policies of repositories that override the global policy.
+// Repositories with an invalid retention configuration fall back to the global policy.
+func (j *Janitor) retentionPolicies(ctx context.Context) (map[int]RetentionPolicy, error) {
+	configurations, err := j.store.GetRetentionConfigurations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[int]RetentionPolicy, len(configurations))
+	for _, configuration := range configurations {
+		policy, err := j.retentionPolicy.override(configuration)
+		if err != nil {
+			log15.Warn("Ignoring invalid retention configuration", "repository_id", configuration.RepositoryID, "error", err)
+			continue
+		}
+
+		policies[configuration.RepositoryID] = policy
+	}
+
+	return policies, nil
+}
+
+// expiredUploads returns the identifiers of the uploads of the given repository that have exceeded
+// the age allowed by the given policy.
+func (j *Janitor) expiredUploads(ctx context.Context, repositoryID int, policy RetentionPolicy, now time.Time) ([]int, error) {
+	candidates, err := j.store.GetExpirationCandidates(ctx, repositoryID, now.Add(-policy.MaxAge))
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetExpirationCandidates")
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	taggedCommits := map[string]struct{}{}
+	if policy.TagPattern != nil && policy.MaxTaggedAge > policy.MaxAge {
+		tags, err := j.gitserverClient.ListTags(ctx, j.store, repositoryID)
+		if err != nil {
+			return nil, errors.Wrap(err, "gitserver.ListTags")
+		}
+
+		for _, tag := range tags {
+			if policy.TagPattern.MatchString(tag.Name) {
+				taggedCommits[tag.Commit] = struct{}{}
+			}
+		}
+	}
+
+	return policy.expired(candidates, taggedCommits, now), nil
+}
@@ -0,0 +1,125 @@
+package janitor
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+)
+
+func TestEnforceRetentionPolicies(t *testing.T) {
+	now := time.Now().UTC()
+	day := time.Hour * 24
+	maxAge := day * 30
+	maxTaggedAge := day * 365
+
+	mockStore := storemocks.NewMockStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockStore.GetRetentionConfigurationsFunc.SetDefaultReturn([]store.RetentionConfiguration{
+		{RepositoryID: 51, MaxAge: &day},
+	}, nil)
+	mockStore.GetRepositoriesWithExpirationCandidatesFunc.SetDefaultReturn([]int{50, 51}, nil)
+	mockStore.GetExpirationCandidatesFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, finishedBefore time.Time) ([]store.ExpirationCandidate, error) {
+		if repositoryID == 50 {
+			return []store.ExpirationCandidate{
+				{ID: 1, Commit: "deadbeef01", FinishedAt: now.Add(-day * 40)},  // expired
+				{ID: 2, Commit: "deadbeef02", FinishedAt: now.Add(-day * 40)},  // tagged
+				{ID: 3, Commit: "deadbeef03", FinishedAt: now.Add(-day * 400)}, // tagged, but expired
+				{ID: 4, Commit: "deadbeef04", FinishedAt: now.Add(-day * 40)},  // tag does not match
+			}, nil
+		}
+
+		return []store.ExpirationCandidate{
+			{ID: 5, Commit: "deadbeef05", FinishedAt: now.Add(-day * 2)}, // expired by repository policy
+		}, nil
+	})
+	mockGitserverClient.ListTagsFunc.SetDefaultReturn([]gitserver.Tag{
+		{Name: "v1.0.0", Commit: "deadbeef02"},
+		{Name: "v0.1.0", Commit: "deadbeef03"},
+		{Name: "nightly", Commit: "deadbeef04"},
+	}, nil)
+
+	j := &Janitor{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+		retentionPolicy: RetentionPolicy{
+			MaxAge:       maxAge,
+			TagPattern:   regexp.MustCompile(`^v\d`),
+			MaxTaggedAge: maxTaggedAge,
+		},
+		metrics: NewJanitorMetrics(metrics.TestRegisterer),
+	}
+	j.enforceRetentionPolicies(context.Background())
+
+	if len(mockStore.GetRepositoriesWithExpirationCandidatesFunc.History()) != 1 {
+		t.Fatalf("unexpected number of GetRepositoriesWithExpirationCandidates calls. want=%d have=%d", 1, len(mockStore.GetRepositoriesWithExpirationCandidatesFunc.History()))
+	}
+	if finishedBefore := mockStore.GetRepositoriesWithExpirationCandidatesFunc.History()[0].Arg1; now.Sub(finishedBefore) > day+time.Minute {
+		t.Errorf("expected the shortest max age to be used. have=%s", now.Sub(finishedBefore))
+	}
+
+	var ids [][]int
+	for _, call := range mockStore.SoftDeleteUploadsByIDsFunc.History() {
+		ids = append(ids, call.Arg1)
+	}
+	if diff := cmp.Diff([][]int{{1, 3, 4}, {5}}, ids); diff != "" {
+		t.Errorf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+
+	if len(mockGitserverClient.ListTagsFunc.History()) != 2 {
+		t.Errorf("unexpected number of ListTags calls. want=%d have=%d", 2, len(mockGitserverClient.ListTagsFunc.History()))
+	}
+}
+
+func TestEnforceRetentionPoliciesDryRun(t *testing.T) {
+	now := time.Now().UTC()
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetRepositoriesWithExpirationCandidatesFunc.SetDefaultReturn([]int{50}, nil)
+	mockStore.GetExpirationCandidatesFunc.SetDefaultReturn([]store.ExpirationCandidate{
+		{ID: 1, Commit: "deadbeef01", FinishedAt: now.Add(-time.Hour * 2)},
+	}, nil)
+
+	j := &Janitor{
+		store:           mockStore,
+		gitserverClient: NewMockGitserverClient(),
+		retentionPolicy: RetentionPolicy{MaxAge: time.Hour},
+		retentionDryRun: true,
+		metrics:         NewJanitorMetrics(metrics.TestRegisterer),
+	}
+	j.enforceRetentionPolicies(context.Background())
+
+	if len(mockStore.SoftDeleteUploadsByIDsFunc.History()) != 0 {
+		t.Errorf("unexpected number of SoftDeleteUploadsByIDs calls. want=%d have=%d", 0, len(mockStore.SoftDeleteUploadsByIDsFunc.History()))
+	}
+}
+
+func TestRetentionPolicyOverride(t *testing.T) {
+	maxAge := time.Hour
+	emptyPattern := ""
+	invalidPattern := "("
+
+	global := RetentionPolicy{
+		MaxAge:       time.Hour * 24,
+		TagPattern:   regexp.MustCompile(`^v`),
+		MaxTaggedAge: time.Hour * 48,
+	}
+
+	policy, err := global.override(store.RetentionConfiguration{MaxAge: &maxAge, TagPattern: &emptyPattern})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if policy.MaxAge != maxAge || policy.TagPattern != nil || policy.MaxTaggedAge != global.MaxTaggedAge {
+		t.Errorf("unexpected policy: %+v", policy)
+	}
+
+	if _, err := global.override(store.RetentionConfiguration{TagPattern: &invalidPattern}); err == nil {
+		t.Errorf("expected an error for an invalid tag pattern")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/readers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/server"
 	sqlitereader "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/persistence/sqlite"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/lsifstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -44,6 +45,9 @@ func main() {
 		maxUploadAge        = mustParseInterval(rawMaxUploadAge, "PRECISE_CODE_INTEL_MAX_UPLOAD_AGE")
 		maxUploadPartAge    = mustParseInterval(rawMaxUploadPartAge, "PRECISE_CODE_INTEL_MAX_UPLOAD_PART_AGE")
 		maxDataAge          = mustParseInterval(rawMaxDataAge, "PRECISE_CODE_INTEL_MAX_DATA_AGE")
+		retentionTagPattern = mustParseRegexp(rawRetentionTagPattern, "PRECISE_CODE_INTEL_RETENTION_TAG_PATTERN")
+		maxTaggedDataAge    = mustParseInterval(rawMaxTaggedDataAge, "PRECISE_CODE_INTEL_MAX_TAGGED_DATA_AGE")
+		retentionDryRun     = mustParseBool(rawRetentionDryRun, "PRECISE_CODE_INTEL_RETENTION_DRY_RUN")
 		disableJanitor      = mustParseBool(rawDisableJanitor, "PRECISE_CODE_INTEL_DISABLE_JANITOR")
 	)
 
@@ -79,7 +83,12 @@ func main() {
 
 	server := server.New(bundleDir, storeCache, codeIntelDB, observationContext)
 	janitorMetrics := janitor.NewJanitorMetrics(prometheus.DefaultRegisterer)
-	janitor := janitor.New(store, lsifstore.New(codeIntelDB), bundleDir, janitorInterval, maxUploadAge, maxUploadPartAge, maxDataAge, janitorMetrics)
+	retentionPolicy := janitor.RetentionPolicy{
+		MaxAge:       maxDataAge,
+		TagPattern:   retentionTagPattern,
+		MaxTaggedAge: maxTaggedDataAge,
+	}
+	janitor := janitor.New(store, lsifstore.New(codeIntelDB), gitserver.DefaultClient, bundleDir, janitorInterval, maxUploadAge, maxUploadPartAge, retentionPolicy, retentionDryRun, janitorMetrics)
 
 	routines := []goroutine.BackgroundRoutine{
 		server,
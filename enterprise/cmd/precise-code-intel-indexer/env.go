@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"regexp"
 	"strconv"
 	"time"
 
@@ -12,12 +13,14 @@ var (
 	rawResetInterval                    = env.Get("PRECISE_CODE_INTEL_RESET_INTERVAL", "1m", "How often to reset stalled indexes.")
 	rawIndexabilityUpdaterInterval      = env.Get("PRECISE_CODE_INTEL_INDEXABILITY_UPDATER_INTERVAL", "30m", "Interval between scheduled indexability updates.")
 	rawSchedulerInterval                = env.Get("PRECISE_CODE_INTEL_SCHEDULER_INTERVAL", "30m", "Interval between scheduled index updates.")
+	rawRequestSchedulerInterval         = env.Get("PRECISE_CODE_INTEL_REQUEST_SCHEDULER_INTERVAL", "10s", "Interval between checks for requested index updates.")
 	rawJanitorInterval                  = env.Get("PRECISE_CODE_INTEL_JANITOR_INTERVAL", "1m", "Interval between cleanup runs.")
 	rawIndexBatchSize                   = env.Get("PRECISE_CODE_INTEL_INDEX_BATCH_SIZE", "100", "Number of indexable repos to consider on each index scheduler update.")
 	rawIndexMinimumTimeSinceLastEnqueue = env.Get("PRECISE_CODE_INTEL_INDEX_MINIMUM_TIME_SINCE_LAST_ENQUEUE", "24h", "Interval between indexing runs of the same repo.")
 	rawIndexMinimumSearchCount          = env.Get("PRECISE_CODE_INTEL_INDEX_MINIMUM_SEARCH_COUNT", "50", "Minimum number of search events to trigger indexing for a repo.")
 	rawIndexMinimumSearchRatio          = env.Get("PRECISE_CODE_INTEL_INDEX_MINIMUM_SEARCH_RATIO", "50", "Minimum ratio of search events to total events to trigger indexing for a repo.")
 	rawIndexMinimumPreciseCount         = env.Get("PRECISE_CODE_INTEL_INDEX_MINIMUM_PRECISE_COUNT", "1", "Minimum number of precise events to trigger indexing for a repo.")
+	rawIndexTagPattern                  = env.Get("PRECISE_CODE_INTEL_INDEX_TAG_PATTERN", "", "Pattern matching the names of tags to index when a configured repo changes. Tags are ignored when empty.")
 	rawDisableJanitor                   = env.Get("PRECISE_CODE_INTEL_DISABLE_JANITOR", "false", "Set to true to disable the janitor process during system migrations.")
 	rawMaxTransactions                  = env.Get("PRECISE_CODE_INTEL_MAXIMUM_TRANSACTIONS", "10", "Number of index jobs that can be active at once.")
 	rawRequeueDelay                     = env.Get("PRECISE_CODE_INTEL_REQUEUE_DELAY", "1m", "The requeue delay of index jobs assigned to an unreachable indexer.")
@@ -65,3 +68,18 @@ func mustParseBool(rawValue, name string) bool {
 
 	return v
 }
+
+// mustParseRegexp returns the compiled version of the given raw pattern fatally logs on failure.
+// An empty pattern results in a nil value.
+func mustParseRegexp(rawValue, name string) *regexp.Regexp {
+	if rawValue == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(rawValue)
+	if err != nil {
+		log.Fatalf("invalid pattern %q for %s: %s", rawValue, name, err)
+	}
+
+	return pattern
+}
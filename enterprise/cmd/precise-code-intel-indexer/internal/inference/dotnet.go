@@ -0,0 +1,66 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+const lsifDotnetImage = "sourcegraph/lsif-dotnet:latest"
+
+type lsifDotnetJobRecognizer struct{}
+
+var _ IndexJobRecognizer = lsifDotnetJobRecognizer{}
+
+func (lsifDotnetJobRecognizer) CanIndex(paths []string) bool {
+	for _, path := range paths {
+		if isSolutionFile(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lsifDotnetJobRecognizer) InferIndexJobs(paths []string) (indexes []IndexJob) {
+	// Solutions nested within the directory of another solution generally
+	// only contain a subset of its projects.
+	roots := outermostDirs(paths, isSolutionFile)
+
+	for _, path := range paths {
+		if !isSolutionFile(path) {
+			continue
+		}
+
+		root := dirWithoutDot(path)
+		if !contains(roots, root) {
+			continue
+		}
+
+		solution := filepath.Base(path)
+		indexes = append(indexes, IndexJob{
+			DockerSteps: []DockerStep{
+				{
+					Root:     root,
+					Image:    lsifDotnetImage,
+					Commands: []string{"dotnet", "restore", solution},
+				},
+			},
+			Root:        root,
+			Indexer:     lsifDotnetImage,
+			IndexerArgs: []string{"lsif-dotnet", solution},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+func (lsifDotnetJobRecognizer) Patterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		suffixPattern(".sln"),
+	}
+}
+
+func isSolutionFile(path string) bool {
+	return filepath.Ext(path) == ".sln"
+}
@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLSIFDotnetJobRecognizerCanIndex(t *testing.T) {
+	recognizer := lsifDotnetJobRecognizer{}
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Foo.sln"}, expected: true},
+		{paths: []string{"a/Foo.sln"}, expected: true},
+		{paths: []string{"a/Foo.csproj"}, expected: false},
+		{paths: []string{"foo/Foo.sln.bak"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := recognizer.CanIndex(testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestLsifDotnetJobRecognizerInferIndexJobs(t *testing.T) {
+	recognizer := lsifDotnetJobRecognizer{}
+	paths := []string{
+		"Foo.sln",
+		"Foo.Tests.sln",
+		"src/Bar/Bar.sln",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "",
+					Image:    lsifDotnetImage,
+					Commands: []string{"dotnet", "restore", "Foo.sln"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifDotnetImage,
+			IndexerArgs: []string{"lsif-dotnet", "Foo.sln"},
+			Outfile:     "",
+		},
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "",
+					Image:    lsifDotnetImage,
+					Commands: []string{"dotnet", "restore", "Foo.Tests.sln"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifDotnetImage,
+			IndexerArgs: []string{"lsif-dotnet", "Foo.Tests.sln"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFDotnetJobRecognizerPatterns(t *testing.T) {
+	recognizer := lsifDotnetJobRecognizer{}
+	paths := []string{
+		"Foo.sln",
+		"subdir/Foo.sln",
+	}
+
+	for _, path := range paths {
+		match := false
+		for _, pattern := range recognizer.Patterns() {
+			if pattern.MatchString(path) {
+				match = true
+				break
+			}
+		}
+
+		if !match {
+			t.Error(fmt.Sprintf("failed to match %s", path))
+		}
+	}
+}
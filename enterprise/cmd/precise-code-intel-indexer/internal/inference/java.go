@@ -0,0 +1,63 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+const lsifJavaImage = "sourcegraph/lsif-java:latest"
+
+// javaBuildFiles are the files which denote the root of a Maven or Gradle project.
+var javaBuildFiles = []string{
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"settings.gradle",
+	"settings.gradle.kts",
+}
+
+type lsifJavaJobRecognizer struct{}
+
+var _ IndexJobRecognizer = lsifJavaJobRecognizer{}
+
+func (lsifJavaJobRecognizer) CanIndex(paths []string) bool {
+	for _, path := range paths {
+		if isJavaBuildFile(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lsifJavaJobRecognizer) InferIndexJobs(paths []string) (indexes []IndexJob) {
+	// Nested build files are the modules of a multi-module build, which
+	// lsif-java indexes along with the enclosing project.
+	for _, root := range outermostDirs(paths, isJavaBuildFile) {
+		// lsif-java runs the build itself, which fetches the dependencies
+		indexes = append(indexes, IndexJob{
+			DockerSteps: nil,
+			Root:        root,
+			Indexer:     lsifJavaImage,
+			IndexerArgs: []string{"lsif-java", "index"},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+func (lsifJavaJobRecognizer) Patterns() []*regexp.Regexp {
+	var patterns []*regexp.Regexp
+	for _, name := range javaBuildFiles {
+		patterns = append(patterns, suffixPattern(name))
+	}
+
+	return patterns
+}
+
+// isJavaBuildFile returns true if the given path is a Maven or Gradle build file. Build
+// files of the Android sources vendored by React Native packages are ignored.
+func isJavaBuildFile(path string) bool {
+	return contains(javaBuildFiles, filepath.Base(path)) && !containsSegment(path, "node_modules")
+}
@@ -0,0 +1,115 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLSIFJavaJobRecognizerCanIndex(t *testing.T) {
+	recognizer := lsifJavaJobRecognizer{}
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"pom.xml"}, expected: true},
+		{paths: []string{"a/build.gradle"}, expected: true},
+		{paths: []string{"a/build.gradle.kts"}, expected: true},
+		{paths: []string{"settings.gradle"}, expected: true},
+		{paths: []string{"package.json"}, expected: false},
+		{paths: []string{"node_modules/foo/android/build.gradle"}, expected: false},
+		{paths: []string{"foo/bar-pom.xml"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := recognizer.CanIndex(testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestLsifJavaJobRecognizerInferIndexJobsMultiModule(t *testing.T) {
+	recognizer := lsifJavaJobRecognizer{}
+	paths := []string{
+		"pom.xml",
+		"core/pom.xml",
+		"server/pom.xml",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: nil,
+			Root:        "",
+			Indexer:     lsifJavaImage,
+			IndexerArgs: []string{"lsif-java", "index"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLsifJavaJobRecognizerInferIndexJobsSubdirs(t *testing.T) {
+	recognizer := lsifJavaJobRecognizer{}
+	paths := []string{
+		"a/pom.xml",
+		"a/b/pom.xml",
+		"c/settings.gradle",
+		"c/build.gradle",
+		"c/d/build.gradle.kts",
+		"e/node_modules/f/android/build.gradle",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: nil,
+			Root:        "a",
+			Indexer:     lsifJavaImage,
+			IndexerArgs: []string{"lsif-java", "index"},
+			Outfile:     "",
+		},
+		{
+			DockerSteps: nil,
+			Root:        "c",
+			Indexer:     lsifJavaImage,
+			IndexerArgs: []string{"lsif-java", "index"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFJavaJobRecognizerPatterns(t *testing.T) {
+	recognizer := lsifJavaJobRecognizer{}
+	paths := []string{
+		"pom.xml",
+		"subdir/pom.xml",
+		"build.gradle",
+		"subdir/build.gradle.kts",
+		"settings.gradle",
+		"subdir/settings.gradle.kts",
+	}
+
+	for _, path := range paths {
+		match := false
+		for _, pattern := range recognizer.Patterns() {
+			if pattern.MatchString(path) {
+				match = true
+				break
+			}
+		}
+
+		if !match {
+			t.Error(fmt.Sprintf("failed to match %s", path))
+		}
+	}
+}
@@ -38,3 +38,40 @@ func containsSegment(path, segment string) bool {
 
 	return containsSegment(dir, segment)
 }
+
+// outermostDirs returns the directories of the given paths for which match returns true,
+// skipping directories nested within another returned directory. This is used to find the
+// roots of projects whose build tool covers all nested projects (e.g. multi-module builds).
+// Directories are returned in the order in which they first appear in paths.
+func outermostDirs(paths []string, match func(path string) bool) (roots []string) {
+	dirs := map[string]struct{}{}
+	for _, path := range paths {
+		if match(path) {
+			dirs[dirWithoutDot(path)] = struct{}{}
+		}
+	}
+
+outer:
+	for _, path := range paths {
+		if !match(path) {
+			continue
+		}
+
+		dir := dirWithoutDot(path)
+		if dir != "" {
+			// ancestorDirs returns the proper ancestors of a non-empty directory
+			for _, ancestor := range ancestorDirs(dir) {
+				if _, ok := dirs[ancestor]; ok {
+					continue outer
+				}
+			}
+		}
+		if contains(roots, dir) {
+			continue
+		}
+
+		roots = append(roots, dir)
+	}
+
+	return roots
+}
@@ -2,6 +2,7 @@ package inference
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -61,3 +62,32 @@ func TestContainsSegment(t *testing.T) {
 		})
 	}
 }
+
+func TestOutermostDirs(t *testing.T) {
+	paths := []string{
+		"a/pom.xml",
+		"a/b/pom.xml",
+		"a/b/c/pom.xml",
+		"d/e/pom.xml",
+		"d/e/f/pom.xml",
+		"d/pom.xml.bak",
+		"g/pom.xml",
+		"g/pom.xml",
+	}
+
+	isPom := func(path string) bool { return filepath.Base(path) == "pom.xml" }
+
+	expectedDirs := []string{
+		"a",
+		"d/e",
+		"g",
+	}
+	if diff := cmp.Diff(expectedDirs, outermostDirs(paths, isPom)); diff != "" {
+		t.Errorf("unexpected dirs (-want +got):\n%s", diff)
+	}
+
+	expectedDirs = []string{""}
+	if diff := cmp.Diff(expectedDirs, outermostDirs(append(paths, "pom.xml"), isPom)); diff != "" {
+		t.Errorf("unexpected dirs (-want +got):\n%s", diff)
+	}
+}
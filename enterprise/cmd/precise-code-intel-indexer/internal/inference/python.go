@@ -0,0 +1,78 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+const lsifPyImage = "sourcegraph/lsif-py:latest"
+
+// pythonProjectFiles are the files which denote the root of a Python project.
+var pythonProjectFiles = []string{
+	"setup.py",
+	"pyproject.toml",
+	"requirements.txt",
+}
+
+type lsifPyJobRecognizer struct{}
+
+var _ IndexJobRecognizer = lsifPyJobRecognizer{}
+
+func (lsifPyJobRecognizer) CanIndex(paths []string) bool {
+	for _, path := range paths {
+		if isPythonProjectFile(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lsifPyJobRecognizer) InferIndexJobs(paths []string) (indexes []IndexJob) {
+	// Nested projects are indexed along with the enclosing project
+	for _, root := range outermostDirs(paths, isPythonProjectFile) {
+		indexes = append(indexes, IndexJob{
+			DockerSteps: []DockerStep{
+				{
+					Root:     root,
+					Image:    lsifPyImage,
+					Commands: pythonInstallCommands(paths, root),
+				},
+			},
+			Root:        root,
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+func (lsifPyJobRecognizer) Patterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		suffixPattern("setup.py"),
+		suffixPattern("pyproject.toml"),
+		suffixPattern("requirements.txt"),
+		suffixPattern("poetry.lock"),
+	}
+}
+
+// pythonInstallCommands returns the command that installs the dependencies of the
+// project in the given directory, preferring the lockfile of poetry if one exists.
+func pythonInstallCommands(paths []string, dir string) []string {
+	if contains(paths, filepath.Join(dir, "poetry.lock")) {
+		return []string{"poetry", "install"}
+	}
+	if contains(paths, filepath.Join(dir, "requirements.txt")) {
+		return []string{"pip", "install", "-r", "requirements.txt"}
+	}
+
+	return []string{"pip", "install", "."}
+}
+
+func isPythonProjectFile(path string) bool {
+	return contains(pythonProjectFiles, filepath.Base(path)) &&
+		!containsSegment(path, "site-packages") &&
+		!containsSegment(path, "node_modules")
+}
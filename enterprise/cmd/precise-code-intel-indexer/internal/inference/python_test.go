@@ -0,0 +1,128 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLSIFPyJobRecognizerCanIndex(t *testing.T) {
+	recognizer := lsifPyJobRecognizer{}
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"setup.py"}, expected: true},
+		{paths: []string{"a/pyproject.toml"}, expected: true},
+		{paths: []string{"a/requirements.txt"}, expected: true},
+		{paths: []string{"poetry.lock"}, expected: false},
+		{paths: []string{"venv/lib/python3.8/site-packages/foo/setup.py"}, expected: false},
+		{paths: []string{"foo/bar-setup.py"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := recognizer.CanIndex(testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestLsifPyJobRecognizerInferIndexJobsRoot(t *testing.T) {
+	recognizer := lsifPyJobRecognizer{}
+	paths := []string{
+		"setup.py",
+		"requirements.txt",
+		"docs/requirements.txt",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "",
+					Image:    lsifPyImage,
+					Commands: []string{"pip", "install", "-r", "requirements.txt"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLsifPyJobRecognizerInferIndexJobsSubdirs(t *testing.T) {
+	recognizer := lsifPyJobRecognizer{}
+	paths := []string{
+		"a/pyproject.toml",
+		"a/poetry.lock",
+		"a/b/setup.py",
+		"c/setup.py",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "a",
+					Image:    lsifPyImage,
+					Commands: []string{"poetry", "install"},
+				},
+			},
+			Root:        "a",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "",
+		},
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "c",
+					Image:    lsifPyImage,
+					Commands: []string{"pip", "install", "."},
+				},
+			},
+			Root:        "c",
+			Indexer:     lsifPyImage,
+			IndexerArgs: []string{"lsif-py", ".", "--file", "dump.lsif"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFPyJobRecognizerPatterns(t *testing.T) {
+	recognizer := lsifPyJobRecognizer{}
+	paths := []string{
+		"setup.py",
+		"subdir/pyproject.toml",
+		"subdir/requirements.txt",
+		"subdir/poetry.lock",
+	}
+
+	for _, path := range paths {
+		match := false
+		for _, pattern := range recognizer.Patterns() {
+			if pattern.MatchString(path) {
+				match = true
+				break
+			}
+		}
+
+		if !match {
+			t.Error(fmt.Sprintf("failed to match %s", path))
+		}
+	}
+}
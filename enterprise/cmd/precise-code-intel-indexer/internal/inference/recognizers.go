@@ -37,4 +37,8 @@ type DockerStep struct {
 var Recognizers = []IndexJobRecognizer{
 	lsifGoJobRecognizer{},
 	lsifTscJobRecognizer{},
+	lsifJavaJobRecognizer{},
+	lsifPyJobRecognizer{},
+	lsifRustJobRecognizer{},
+	lsifDotnetJobRecognizer{},
 }
@@ -0,0 +1,55 @@
+package inference
+
+import (
+	"path/filepath"
+	"regexp"
+)
+
+const lsifRustImage = "sourcegraph/lsif-rust:latest"
+
+type lsifRustJobRecognizer struct{}
+
+var _ IndexJobRecognizer = lsifRustJobRecognizer{}
+
+func (lsifRustJobRecognizer) CanIndex(paths []string) bool {
+	for _, path := range paths {
+		if isCargoManifest(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lsifRustJobRecognizer) InferIndexJobs(paths []string) (indexes []IndexJob) {
+	// Nested manifests are the members of a cargo workspace, which
+	// rust-analyzer indexes along with the workspace root.
+	for _, root := range outermostDirs(paths, isCargoManifest) {
+		indexes = append(indexes, IndexJob{
+			DockerSteps: []DockerStep{
+				{
+					Root:     root,
+					Image:    lsifRustImage,
+					Commands: []string{"cargo", "fetch"},
+				},
+			},
+			Root:    root,
+			Indexer: lsifRustImage,
+			// rust-analyzer writes the dump to stdout
+			IndexerArgs: []string{"sh", "-c", "rust-analyzer lsif . > dump.lsif"},
+			Outfile:     "",
+		})
+	}
+
+	return indexes
+}
+
+func (lsifRustJobRecognizer) Patterns() []*regexp.Regexp {
+	return []*regexp.Regexp{
+		suffixPattern("Cargo.toml"),
+	}
+}
+
+func isCargoManifest(path string) bool {
+	return filepath.Base(path) == "Cargo.toml" && !containsSegment(path, "vendor") && !containsSegment(path, "target")
+}
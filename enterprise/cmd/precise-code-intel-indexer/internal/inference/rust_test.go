@@ -0,0 +1,124 @@
+package inference
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLSIFRustJobRecognizerCanIndex(t *testing.T) {
+	recognizer := lsifRustJobRecognizer{}
+	testCases := []struct {
+		paths    []string
+		expected bool
+	}{
+		{paths: []string{"Cargo.toml"}, expected: true},
+		{paths: []string{"a/Cargo.toml"}, expected: true},
+		{paths: []string{"Cargo.lock"}, expected: false},
+		{paths: []string{"vendor/foo/Cargo.toml"}, expected: false},
+		{paths: []string{"foo/bar-Cargo.toml"}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		name := strings.Join(testCase.paths, ", ")
+
+		t.Run(name, func(t *testing.T) {
+			if value := recognizer.CanIndex(testCase.paths); value != testCase.expected {
+				t.Errorf("unexpected result from CanIndex. want=%v have=%v", testCase.expected, value)
+			}
+		})
+	}
+}
+
+func TestLsifRustJobRecognizerInferIndexJobsWorkspace(t *testing.T) {
+	recognizer := lsifRustJobRecognizer{}
+	paths := []string{
+		"Cargo.toml",
+		"crates/a/Cargo.toml",
+		"crates/b/Cargo.toml",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo", "fetch"},
+				},
+			},
+			Root:        "",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"sh", "-c", "rust-analyzer lsif . > dump.lsif"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLsifRustJobRecognizerInferIndexJobsSubdirs(t *testing.T) {
+	recognizer := lsifRustJobRecognizer{}
+	paths := []string{
+		"a/Cargo.toml",
+		"b/Cargo.toml",
+		"b/vendor/c/Cargo.toml",
+	}
+
+	expectedIndexJobs := []IndexJob{
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "a",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo", "fetch"},
+				},
+			},
+			Root:        "a",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"sh", "-c", "rust-analyzer lsif . > dump.lsif"},
+			Outfile:     "",
+		},
+		{
+			DockerSteps: []DockerStep{
+				{
+					Root:     "b",
+					Image:    lsifRustImage,
+					Commands: []string{"cargo", "fetch"},
+				},
+			},
+			Root:        "b",
+			Indexer:     lsifRustImage,
+			IndexerArgs: []string{"sh", "-c", "rust-analyzer lsif . > dump.lsif"},
+			Outfile:     "",
+		},
+	}
+	if diff := cmp.Diff(expectedIndexJobs, recognizer.InferIndexJobs(paths)); diff != "" {
+		t.Errorf("unexpected index jobs (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFRustJobRecognizerPatterns(t *testing.T) {
+	recognizer := lsifRustJobRecognizer{}
+	paths := []string{
+		"Cargo.toml",
+		"subdir/Cargo.toml",
+	}
+
+	for _, path := range paths {
+		match := false
+		for _, pattern := range recognizer.Patterns() {
+			if pattern.MatchString(path) {
+				match = true
+				break
+			}
+		}
+
+		if !match {
+			t.Error(fmt.Sprintf("failed to match %s", path))
+		}
+	}
+}
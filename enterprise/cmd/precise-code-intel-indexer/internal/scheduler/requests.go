@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// MaximumTagsPerRepository is the maximum number of recent tags matching the tag pattern that
+// are considered each time a repository changes.
+const MaximumTagsPerRepository = 10
+
+type RequestScheduler struct {
+	scheduler  *Scheduler
+	batchSize  int
+	tagPattern *regexp.Regexp
+}
+
+var _ goroutine.Handler = &RequestScheduler{}
+
+// NewRequestScheduler returns a background routine that schedules index jobs for the index requests
+// made explicitly by a user for a particular commit and implicitly by repo-updater once it observes
+// that a repository has changed. If the given tag pattern is nil, tags are ignored.
+func NewRequestScheduler(
+	store store.Store,
+	gitserverClient gitserverClient,
+	interval time.Duration,
+	batchSize int,
+	tagPattern *regexp.Regexp,
+	metrics SchedulerMetrics,
+) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &RequestScheduler{
+		scheduler: &Scheduler{
+			store:           store,
+			gitserverClient: gitserverClient,
+			metrics:         metrics,
+		},
+		batchSize:  batchSize,
+		tagPattern: tagPattern,
+	})
+}
+
+func (s *RequestScheduler) Handle(ctx context.Context) error {
+	indexRequests, err := s.scheduler.store.IndexRequests(ctx, s.batchSize)
+	if err != nil {
+		return errors.Wrap(err, "store.IndexRequests")
+	}
+
+	for _, indexRequest := range indexRequests {
+		if err := s.handleIndexRequest(ctx, indexRequest); err != nil && !isRepoNotExist(err) {
+			if ctx.Err() != nil {
+				return err
+			}
+
+			// Drop the request rather than retrying it on the next run: requests are
+			// handled in order, so a request that keeps failing would otherwise block
+			// the requests behind it. Repository changes are picked up again by the
+			// periodic scheduler.
+			s.scheduler.metrics.Errors.Inc()
+			log15.Error("Failed to handle index request", "id", indexRequest.ID, "repositoryID", indexRequest.RepositoryID, "err", err)
+		}
+
+		if err := s.scheduler.store.DeleteIndexRequest(ctx, indexRequest.ID); err != nil {
+			return errors.Wrap(err, "store.DeleteIndexRequest")
+		}
+	}
+
+	return nil
+}
+
+func (s *RequestScheduler) HandleError(err error) {
+	s.scheduler.metrics.Errors.Inc()
+	log15.Error("Failed to handle index requests", "err", err)
+}
+
+func (s *RequestScheduler) handleIndexRequest(ctx context.Context, indexRequest store.IndexRequest) error {
+	if indexRequest.Commit != "" {
+		return s.scheduler.queueIndexForCommit(ctx, indexRequest.RepositoryID, indexRequest.Commit, false)
+	}
+
+	commit, err := s.scheduler.gitserverClient.Head(ctx, s.scheduler.store, indexRequest.RepositoryID)
+	if err != nil {
+		return errors.Wrap(err, "gitserver.Head")
+	}
+
+	// Only repositories that have explicitly opted into auto-indexing are indexed as soon
+	// as they change. All other repositories are picked up periodically by the scheduler.
+	isConfigured, err := s.isConfigured(ctx, indexRequest.RepositoryID, commit)
+	if err != nil || !isConfigured {
+		return err
+	}
+
+	commits := []string{commit}
+	if s.tagPattern != nil {
+		tags, err := s.scheduler.gitserverClient.ListTags(ctx, s.scheduler.store, indexRequest.RepositoryID)
+		if err != nil {
+			return errors.Wrap(err, "gitserver.ListTags")
+		}
+
+		commits = append(commits, matchingTagCommits(tags, s.tagPattern, MaximumTagsPerRepository)...)
+	}
+
+	for i, commit := range commits {
+		// Only the first commit is the tip of the default branch; the others are tagged commits.
+		if err := s.scheduler.queueIndexForCommit(ctx, indexRequest.RepositoryID, commit, i == 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isConfigured determines if the given repository has an index configuration in the database or
+// in the repository itself at the given commit.
+func (s *RequestScheduler) isConfigured(ctx context.Context, repositoryID int, commit string) (bool, error) {
+	_, ok, err := s.scheduler.store.GetIndexConfigurationByRepositoryID(ctx, repositoryID)
+	if err != nil {
+		return false, errors.Wrap(err, "store.GetIndexConfigurationByRepositoryID")
+	}
+	if ok {
+		return true, nil
+	}
+
+	ok, err = s.scheduler.gitserverClient.FileExists(ctx, s.scheduler.store, repositoryID, commit, "sourcegraph.yaml")
+	if err != nil {
+		return false, errors.Wrap(err, "gitserver.FileExists")
+	}
+
+	return ok, nil
+}
+
+// matchingTagCommits returns the distinct commits of (at most limit) tags whose names match the
+// given pattern. The tags are assumed to be ordered by recency.
+func matchingTagCommits(tags []gitserver.Tag, pattern *regexp.Regexp, limit int) (commits []string) {
+	seen := map[string]struct{}{}
+	for _, tag := range tags {
+		if len(seen) >= limit {
+			break
+		}
+		if !pattern.MatchString(tag.Name) {
+			continue
+		}
+		if _, ok := seen[tag.Commit]; ok {
+			continue
+		}
+
+		seen[tag.Commit] = struct{}{}
+		commits = append(commits, tag.Commit)
+	}
+
+	return commits
+}
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+)
+
+func TestRequestSchedulerCommit(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.IndexRequestsFunc.SetDefaultReturn([]store.IndexRequest{
+		{ID: 1, RepositoryID: 42, Commit: "deadbeef"},
+	}, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	scheduler := &RequestScheduler{
+		scheduler: &Scheduler{
+			store:           mockStore,
+			gitserverClient: mockGitserverClient,
+			metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+		},
+		tagPattern: regexp.MustCompile(`^v`),
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error handling index requests: %s", err)
+	}
+
+	if len(mockGitserverClient.HeadFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to Head. want=%d have=%d", 0, len(mockGitserverClient.HeadFunc.History()))
+	}
+
+	if len(mockStore.InsertIndexFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 1, len(mockStore.InsertIndexFunc.History()))
+	} else if commit := mockStore.InsertIndexFunc.History()[0].Arg1.Commit; commit != "deadbeef" {
+		t.Errorf("unexpected commit. want=%q have=%q", "deadbeef", commit)
+	}
+
+	if len(mockStore.DeleteIndexRequestFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to DeleteIndexRequest. want=%d have=%d", 1, len(mockStore.DeleteIndexRequestFunc.History()))
+	}
+}
+
+func TestRequestSchedulerCommitInvalidConfiguration(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.IndexRequestsFunc.SetDefaultReturn([]store.IndexRequest{
+		{ID: 1, RepositoryID: 42, Commit: "deadbeef"},
+	}, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.FileExistsFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error) {
+		return file == "sourcegraph.yaml", nil
+	})
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(`index_jobs: {`), nil)
+
+	scheduler := &RequestScheduler{
+		scheduler: &Scheduler{
+			store:           mockStore,
+			gitserverClient: mockGitserverClient,
+			metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+		},
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error handling index requests: %s", err)
+	}
+
+	if len(mockStore.InsertIndexFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 0, len(mockStore.InsertIndexFunc.History()))
+	}
+
+	// The configuration of a commit other than the tip of the default branch is not recorded.
+	if len(mockStore.UpdateIndexConfigurationErrorFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to UpdateIndexConfigurationError. want=%d have=%d", 0, len(mockStore.UpdateIndexConfigurationErrorFunc.History()))
+	}
+	if len(mockStore.DeleteIndexConfigurationErrorFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to DeleteIndexConfigurationError. want=%d have=%d", 0, len(mockStore.DeleteIndexConfigurationErrorFunc.History()))
+	}
+}
+
+func TestRequestSchedulerRepositoryChanged(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.IndexRequestsFunc.SetDefaultReturn([]store.IndexRequest{
+		{ID: 1, RepositoryID: 42},
+		{ID: 2, RepositoryID: 43},
+	}, nil)
+	mockStore.IsQueuedFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, commit string) (bool, error) {
+		return commit == "c2", nil
+	})
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.HeadFunc.SetDefaultReturn("c0", nil)
+	mockGitserverClient.FileExistsFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error) {
+		return repositoryID == 42 && file == "sourcegraph.yaml", nil
+	})
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn(yamlIndexConfiguration, nil)
+	mockGitserverClient.ListTagsFunc.SetDefaultReturn([]gitserver.Tag{
+		{Name: "v1.2.0", Commit: "c1"},
+		{Name: "nightly", Commit: "c3"},
+		{Name: "v1.1.0", Commit: "c2"},
+		{Name: "v1.0.0", Commit: "c4"},
+	}, nil)
+
+	scheduler := &RequestScheduler{
+		scheduler: &Scheduler{
+			store:           mockStore,
+			gitserverClient: mockGitserverClient,
+			metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+		},
+		tagPattern: regexp.MustCompile(`^v\d+`),
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error handling index requests: %s", err)
+	}
+
+	var commits []string
+	for _, call := range mockStore.IsQueuedFunc.History() {
+		if call.Arg1 != 42 {
+			t.Errorf("unexpected repository. want=%d have=%d", 42, call.Arg1)
+		}
+
+		commits = append(commits, call.Arg2)
+	}
+	if diff := cmp.Diff([]string{"c0", "c1", "c2", "c4"}, commits); diff != "" {
+		t.Errorf("unexpected commits (-want +got):\n%s", diff)
+	}
+
+	commitSet := map[string]struct{}{}
+	for _, call := range mockStore.InsertIndexFunc.History() {
+		commitSet[call.Arg1.Commit] = struct{}{}
+	}
+	var indexedCommits []string
+	for commit := range commitSet {
+		indexedCommits = append(indexedCommits, commit)
+	}
+	sort.Strings(indexedCommits)
+
+	if diff := cmp.Diff([]string{"c0", "c1", "c4"}, indexedCommits); diff != "" {
+		t.Errorf("unexpected indexed commits (-want +got):\n%s", diff)
+	}
+
+	if len(mockStore.DeleteIndexRequestFunc.History()) != 2 {
+		t.Errorf("unexpected number of calls to DeleteIndexRequest. want=%d have=%d", 2, len(mockStore.DeleteIndexRequestFunc.History()))
+	}
+}
+
+func TestRequestSchedulerFailedRequest(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.IndexRequestsFunc.SetDefaultReturn([]store.IndexRequest{
+		{ID: 1, RepositoryID: 42},
+		{ID: 2, RepositoryID: 43, Commit: "deadbeef"},
+	}, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.HeadFunc.SetDefaultReturn("", fmt.Errorf("uh-oh"))
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	scheduler := &RequestScheduler{
+		scheduler: &Scheduler{
+			store:           mockStore,
+			gitserverClient: mockGitserverClient,
+			metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+		},
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error handling index requests: %s", err)
+	}
+
+	if len(mockStore.InsertIndexFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 1, len(mockStore.InsertIndexFunc.History()))
+	} else if repositoryID := mockStore.InsertIndexFunc.History()[0].Arg1.RepositoryID; repositoryID != 43 {
+		t.Errorf("unexpected repository. want=%d have=%d", 43, repositoryID)
+	}
+
+	var ids []int
+	for _, call := range mockStore.DeleteIndexRequestFunc.History() {
+		ids = append(ids, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{1, 2}, ids); diff != "" {
+		t.Errorf("unexpected deleted index requests (-want +got):\n%s", diff)
+	}
+}
+
+func TestMatchingTagCommits(t *testing.T) {
+	tags := []gitserver.Tag{
+		{Name: "v3", Commit: "c3"},
+		{Name: "v3-alias", Commit: "c3"},
+		{Name: "latest", Commit: "c3"},
+		{Name: "v2", Commit: "c2"},
+		{Name: "v1", Commit: "c1"},
+	}
+
+	if diff := cmp.Diff([]string{"c3", "c2"}, matchingTagCommits(tags, regexp.MustCompile(`^v`), 2)); diff != "" {
+		t.Errorf("unexpected commits (-want +got):\n%s", diff)
+	}
+}
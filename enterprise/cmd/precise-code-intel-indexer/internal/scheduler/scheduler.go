@@ -2,12 +2,14 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-indexer/internal/inference"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/index"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -30,6 +32,7 @@ type gitserverClient interface {
 	FileExists(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error)
 	ListFiles(ctx context.Context, store store.Store, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error)
 	RawContents(ctx context.Context, store store.Store, repositoryID int, commit, file string) ([]byte, error)
+	ListTags(ctx context.Context, store store.Store, repositoryID int) ([]gitserver.Tag, error)
 }
 
 var _ goroutine.Handler = &Scheduler{}
@@ -97,12 +100,19 @@ func (s *Scheduler) HandleError(err error) {
 	log15.Error("Failed to update indexable repositories", "err", err)
 }
 
-func (s *Scheduler) queueIndex(ctx context.Context, repositoryID int) (err error) {
+func (s *Scheduler) queueIndex(ctx context.Context, repositoryID int) error {
 	commit, err := s.gitserverClient.Head(ctx, s.store, repositoryID)
 	if err != nil {
 		return errors.Wrap(err, "gitserver.Head")
 	}
 
+	return s.queueIndexForCommit(ctx, repositoryID, commit, true)
+}
+
+// queueIndexForCommit enqueues the index jobs for the given commit. The commit is the tip of the
+// default branch if isHead is true, in which case the configuration error stored for the repository
+// is updated as well.
+func (s *Scheduler) queueIndexForCommit(ctx context.Context, repositoryID int, commit string, isHead bool) (err error) {
 	isQueued, err := s.store.IsQueued(ctx, repositoryID, commit)
 	if err != nil {
 		return errors.Wrap(err, "store.IsQueued")
@@ -111,7 +121,7 @@ func (s *Scheduler) queueIndex(ctx context.Context, repositoryID int) (err error
 		return nil
 	}
 
-	indexes, err := s.getIndexJobs(ctx, repositoryID, commit)
+	indexes, err := s.getIndexJobs(ctx, repositoryID, commit, isHead)
 	if err != nil {
 		return err
 	}
@@ -156,22 +166,71 @@ func (s *Scheduler) queueIndex(ctx context.Context, repositoryID int) (err error
 	return nil
 }
 
-func (s *Scheduler) getIndexJobs(ctx context.Context, repositoryID int, commit string) ([]store.Index, error) {
+func (s *Scheduler) getIndexJobs(ctx context.Context, repositoryID int, commit string, isHead bool) ([]store.Index, error) {
+	indexJobs, ok, err := s.getIndexJobsFromConfiguration(ctx, repositoryID, commit)
+	if err != nil {
+		if configurationErr, isConfigurationErr := err.(*configurationError); isConfigurationErr {
+			// We failed here, but do not try to fall back on inference as having an explicit
+			// configuration should always take precedence, even if it's broken.
+			log15.Warn("Failed to unmarshal index configuration", "repository_id", repositoryID, "commit", commit, "err", configurationErr)
+
+			// Record the error so that it can be surfaced to the user. Only the configuration
+			// at the tip of the default branch is surfaced, as the configuration of other
+			// commits (e.g. tags) may have been fixed since.
+			if isHead {
+				if err := s.store.UpdateIndexConfigurationError(ctx, repositoryID, commit, configurationErr.Error(), time.Now().UTC()); err != nil {
+					return nil, errors.Wrap(err, "store.UpdateIndexConfigurationError")
+				}
+			}
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// The configuration at the tip of the default branch is either valid or absent, so a
+	// previously recorded error no longer applies.
+	if isHead {
+		if err := s.store.DeleteIndexConfigurationError(ctx, repositoryID); err != nil {
+			return nil, errors.Wrap(err, "store.DeleteIndexConfigurationError")
+		}
+	}
+
+	if ok {
+		return indexJobs, nil
+	}
+
+	indexJobs, _, err = s.inferIndexJobsFromRepositoryStructure(ctx, repositoryID, commit)
+	return indexJobs, err
+}
+
+// getIndexJobsFromConfiguration returns the index jobs of the explicit index configuration of the
+// given repository, which is stored either in the database or in the repository itself. The second
+// return value is false if there is no explicit index configuration.
+func (s *Scheduler) getIndexJobsFromConfiguration(ctx context.Context, repositoryID int, commit string) ([]store.Index, bool, error) {
 	fns := []func(ctx context.Context, repositoryID int, commit string) ([]store.Index, bool, error){
 		s.getIndexJobsFromConfigurationInDatabase,
 		s.getIndexJobsFromConfigurationInRepository,
-		s.inferIndexJobsFromRepositoryStructure,
 	}
 
 	for _, fn := range fns {
-		if indexJobs, ok, err := fn(ctx, repositoryID, commit); err != nil {
-			return nil, err
-		} else if ok {
-			return indexJobs, nil
+		if indexJobs, ok, err := fn(ctx, repositoryID, commit); err != nil || ok {
+			return indexJobs, ok, err
 		}
 	}
 
-	return nil, nil
+	return nil, false, nil
+}
+
+// configurationError occurs when an explicit index configuration cannot be parsed.
+type configurationError struct {
+	source string
+	err    error
+}
+
+func (e *configurationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.source, e.err)
 }
 
 func (s *Scheduler) getIndexJobsFromConfigurationInDatabase(ctx context.Context, repositoryID int, commit string) ([]store.Index, bool, error) {
@@ -185,11 +244,7 @@ func (s *Scheduler) getIndexJobsFromConfigurationInDatabase(ctx context.Context,
 
 	indexConfiguration, err := index.UnmarshalJSON(indexConfigurationRecord.Data)
 	if err != nil {
-		// We failed here, but do not try to fall back on another method as having
-		// an explicit config in the database should always take precedence, even
-		// if it's broken.
-		log15.Warn("Failed to unmarshal index configuration", "repository_id", repositoryID)
-		return nil, true, nil
+		return nil, false, &configurationError{source: "index configuration", err: err}
 	}
 
 	return convertIndexConfiguration(repositoryID, commit, indexConfiguration), true, nil
@@ -211,11 +266,7 @@ func (s *Scheduler) getIndexJobsFromConfigurationInRepository(ctx context.Contex
 
 	indexConfiguration, err := index.UnmarshalYAML(content)
 	if err != nil {
-		// We failed here, but do not try to fall back on another method as having
-		// an explicit config in the repository should always take precedence over
-		// an auto-inferred configuration, even if it's broken.
-		log15.Warn("Failed to unmarshal index configuration", "repository_id", repositoryID)
-		return nil, true, nil
+		return nil, false, &configurationError{source: "sourcegraph.yaml", err: err}
 	}
 
 	return convertIndexConfiguration(repositoryID, commit, indexConfiguration), true, nil
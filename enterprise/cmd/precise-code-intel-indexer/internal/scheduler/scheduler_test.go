@@ -7,6 +7,7 @@ import (
 	"os"
 	"regexp"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -342,3 +343,78 @@ func TestUpdateIndexConfigurationInferred(t *testing.T) {
 		t.Errorf("unexpected number of calls to UpdateIndexableRepository. want=%d have=%d", 2, len(mockStore.UpdateIndexableRepositoryFunc.History()))
 	}
 }
+
+func TestUpdateIndexConfigurationInvalid(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.GetRepositoriesWithIndexConfigurationFunc.SetDefaultReturn([]int{42}, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
+		return fmt.Sprintf("c%d", repositoryID), nil
+	})
+	mockGitserverClient.FileExistsFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int, commit, file string) (bool, error) {
+		return file == "sourcegraph.yaml", nil
+	})
+	mockGitserverClient.RawContentsFunc.SetDefaultReturn([]byte(`index_jobs: {`), nil)
+	mockGitserverClient.ListFilesFunc.SetDefaultReturn([]string{"go.mod"}, nil)
+
+	scheduler := &Scheduler{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+		metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(mockStore.InsertIndexFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 0, len(mockStore.InsertIndexFunc.History()))
+	}
+
+	if len(mockStore.UpdateIndexConfigurationErrorFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to UpdateIndexConfigurationError. want=%d have=%d", 1, len(mockStore.UpdateIndexConfigurationErrorFunc.History()))
+	} else {
+		call := mockStore.UpdateIndexConfigurationErrorFunc.History()[0]
+		if call.Arg1 != 42 || call.Arg2 != "c42" {
+			t.Errorf("unexpected repository and commit. want=%d@%s have=%d@%s", 42, "c42", call.Arg1, call.Arg2)
+		}
+		if !strings.HasPrefix(call.Arg3, "invalid sourcegraph.yaml: ") {
+			t.Errorf("unexpected error message: %s", call.Arg3)
+		}
+	}
+
+	if len(mockStore.DeleteIndexConfigurationErrorFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to DeleteIndexConfigurationError. want=%d have=%d", 0, len(mockStore.DeleteIndexConfigurationErrorFunc.History()))
+	}
+}
+
+func TestUpdateIndexConfigurationErrorCleared(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.IndexableRepositoriesFunc.SetDefaultReturn([]store.IndexableRepository{{RepositoryID: 42}}, nil)
+
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.HeadFunc.SetDefaultReturn("c42", nil)
+
+	scheduler := &Scheduler{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+		metrics:         NewSchedulerMetrics(metrics.TestRegisterer),
+	}
+
+	if err := scheduler.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error performing update: %s", err)
+	}
+
+	if len(mockStore.InsertIndexFunc.History()) != 0 {
+		t.Errorf("unexpected number of calls to InsertIndex. want=%d have=%d", 0, len(mockStore.InsertIndexFunc.History()))
+	}
+
+	if len(mockStore.DeleteIndexConfigurationErrorFunc.History()) != 1 {
+		t.Errorf("unexpected number of calls to DeleteIndexConfigurationError. want=%d have=%d", 1, len(mockStore.DeleteIndexConfigurationErrorFunc.History()))
+	} else if repositoryID := mockStore.DeleteIndexConfigurationErrorFunc.History()[0].Arg1; repositoryID != 42 {
+		t.Errorf("unexpected repository. want=%d have=%d", 42, repositoryID)
+	}
+}
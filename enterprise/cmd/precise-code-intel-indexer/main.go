@@ -36,6 +36,7 @@ func main() {
 	var (
 		resetInterval                    = mustParseInterval(rawResetInterval, "PRECISE_CODE_INTEL_RESET_INTERVAL")
 		schedulerInterval                = mustParseInterval(rawSchedulerInterval, "PRECISE_CODE_INTEL_SCHEDULER_INTERVAL")
+		requestSchedulerInterval         = mustParseInterval(rawRequestSchedulerInterval, "PRECISE_CODE_INTEL_REQUEST_SCHEDULER_INTERVAL")
 		indexabilityUpdaterInterval      = mustParseInterval(rawIndexabilityUpdaterInterval, "PRECISE_CODE_INTEL_INDEXABILITY_UPDATER_INTERVAL")
 		janitorInterval                  = mustParseInterval(rawJanitorInterval, "PRECISE_CODE_INTEL_JANITOR_INTERVAL")
 		indexBatchSize                   = mustParseInt(rawIndexBatchSize, "PRECISE_CODE_INTEL_INDEX_BATCH_SIZE")
@@ -43,6 +44,7 @@ func main() {
 		indexMinimumSearchCount          = mustParseInt(rawIndexMinimumSearchCount, "PRECISE_CODE_INTEL_INDEX_MINIMUM_SEARCH_COUNT")
 		indexMinimumSearchRatio          = mustParsePercent(rawIndexMinimumSearchRatio, "PRECISE_CODE_INTEL_INDEX_MINIMUM_SEARCH_RATIO")
 		indexMinimumPreciseCount         = mustParseInt(rawIndexMinimumPreciseCount, "PRECISE_CODE_INTEL_INDEX_MINIMUM_PRECISE_COUNT")
+		indexTagPattern                  = mustParseRegexp(rawIndexTagPattern, "PRECISE_CODE_INTEL_INDEX_TAG_PATTERN")
 		disableJanitor                   = mustParseBool(rawDisableJanitor, "PRECISE_CODE_INTEL_DISABLE_JANITOR")
 		maximumTransactions              = mustParseInt(rawMaxTransactions, "PRECISE_CODE_INTEL_MAXIMUM_TRANSACTIONS")
 		requeueDelay                     = mustParseInterval(rawRequeueDelay, "PRECISE_CODE_INTEL_REQUEUE_DELAY")
@@ -92,6 +94,15 @@ func main() {
 		schedulerMetrics,
 	)
 
+	requestScheduler := scheduler.NewRequestScheduler(
+		s,
+		gitserver.DefaultClient,
+		requestSchedulerInterval,
+		indexBatchSize,
+		indexTagPattern,
+		schedulerMetrics,
+	)
+
 	janitorMetrics := janitor.NewJanitorMetrics(prometheus.DefaultRegisterer)
 	janitor := janitor.New(s, janitorInterval, janitorMetrics)
 	managerRoutine := goroutine.NewPeriodicGoroutine(context.Background(), cleanupInterval, indexManager)
@@ -102,6 +113,7 @@ func main() {
 		indexResetter,
 		indexabilityUpdater,
 		scheduler,
+		requestScheduler,
 	}
 
 	if !disableJanitor {
@@ -25,9 +25,9 @@ func canonicalize(state *State) {
 // canonicalizeDocuments determines if multiple documents are defined with the same URI. This can
 // happen in some indexers (such as lsif-tsc) that index dependent projects into the same index
 // as the target project. For each set of documents that share a path, we choose one document to
-// be the canonical representative and merge the contains, definition, and reference data into the
-// unique canonical document. This function guarantees that duplicate document IDs are removed from
-// the correlation state.
+// be the canonical representative and merge the contains, definition, reference, implementation,
+// and type definition data into the unique canonical document. This function guarantees that
+// duplicate document IDs are removed from the correlation state.
 func canonicalizeDocuments(state *State) {
 	documentIDs := map[string][]int{}
 	for documentID, uri := range state.DocumentData {
@@ -40,17 +40,21 @@ func canonicalizeDocuments(state *State) {
 	for documentID, uri := range state.DocumentData {
 		// Choose canonical document alphabetically
 		if canonicalID := documentIDs[uri][0]; documentID != canonicalID {
-			// Move ranges and diagnostics into the canonical document
+			// Move ranges, diagnostics, and document symbols into the canonical document
 			state.Contains.SetUnion(canonicalID, state.Contains.Get(documentID))
 			state.Diagnostics.SetUnion(canonicalID, state.Diagnostics.Get(documentID))
+			state.DocumentSymbols.SetUnion(canonicalID, state.DocumentSymbols.Get(documentID))
 
 			canonicalizeDocumentsInDefinitionReferences(state, state.DefinitionData, documentID, canonicalID)
 			canonicalizeDocumentsInDefinitionReferences(state, state.ReferenceData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.ImplementationData, documentID, canonicalID)
+			canonicalizeDocumentsInDefinitionReferences(state, state.TypeDefinitionData, documentID, canonicalID)
 
 			// Remove non-canonical document
 			delete(state.DocumentData, documentID)
 			state.Contains.Delete(documentID)
 			state.Diagnostics.Delete(documentID)
+			state.DocumentSymbols.Delete(documentID)
 		}
 	}
 }
@@ -181,9 +185,9 @@ func canonicalizeResultSetData(state *State, id int, item lsif.ResultSet) lsif.R
 	return item
 }
 
-// mergeNextResultSetData merges the definition, reference, and hover result identifiers from
-// nextItem into item when not already defined. The moniker identifiers of nextItem are unioned
-// into the moniker identifiers of item.
+// mergeNextResultSetData merges the definition, reference, hover, implementation, and type
+// definition result identifiers from nextItem into item when not already defined. The moniker
+// identifiers of nextItem are unioned into the moniker identifiers of item.
 func mergeNextResultSetData(state *State, itemID int, item lsif.ResultSet, nextID int, nextItem lsif.ResultSet) lsif.ResultSet {
 	if item.DefinitionResultID == 0 {
 		item = item.SetDefinitionResultID(nextItem.DefinitionResultID)
@@ -194,14 +198,20 @@ func mergeNextResultSetData(state *State, itemID int, item lsif.ResultSet, nextI
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 
 	state.Monikers.SetUnion(itemID, state.Monikers.Get(nextID))
 	return item
 }
 
-// mergeNextRangeData merges the definition, reference, and hover result identifiers from nextItem
-// into item when not already defined. The moniker identifiers of nextItem are unioned into the
-// moniker identifiers of item.
+// mergeNextRangeData merges the definition, reference, hover, implementation, and type definition
+// result identifiers from nextItem into item when not already defined. The moniker identifiers of
+// nextItem are unioned into the moniker identifiers of item.
 func mergeNextRangeData(state *State, itemID int, item lsif.Range, nextID int, nextItem lsif.ResultSet) lsif.Range {
 	if item.DefinitionResultID == 0 {
 		item = item.SetDefinitionResultID(nextItem.DefinitionResultID)
@@ -212,6 +222,12 @@ func mergeNextRangeData(state *State, itemID int, item lsif.Range, nextID int, n
 	if item.HoverResultID == 0 {
 		item = item.SetHoverResultID(nextItem.HoverResultID)
 	}
+	if item.ImplementationResultID == 0 {
+		item = item.SetImplementationResultID(nextItem.ImplementationResultID)
+	}
+	if item.TypeDefinitionResultID == 0 {
+		item = item.SetTypeDefinitionResultID(nextItem.TypeDefinitionResultID)
+	}
 
 	state.Monikers.SetUnion(itemID, state.Monikers.Get(nextID))
 	return item
@@ -32,6 +32,10 @@ func TestCanonicalizeDocuments(t *testing.T) {
 		}),
 		Monikers:    datastructures.NewDefaultIDSetMap(),
 		Diagnostics: datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1001: datastructures.IDSetWith(6001),
+			1004: datastructures.IDSetWith(6002),
+		}),
 	}
 	canonicalizeDocuments(state)
 
@@ -56,6 +60,9 @@ func TestCanonicalizeDocuments(t *testing.T) {
 		}),
 		Monikers:    datastructures.NewDefaultIDSetMap(),
 		Diagnostics: datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1001: datastructures.IDSetWith(6001, 6002),
+		}),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
@@ -131,16 +131,19 @@ func correlateElement(state *wrappedState, element lsif.Element) error {
 }
 
 var vertexHandlers = map[string]func(state *wrappedState, element lsif.Element) error{
-	"metaData":           correlateMetaData,
-	"document":           correlateDocument,
-	"range":              correlateRange,
-	"resultSet":          correlateResultSet,
-	"definitionResult":   correlateDefinitionResult,
-	"referenceResult":    correlateReferenceResult,
-	"hoverResult":        correlateHoverResult,
-	"moniker":            correlateMoniker,
-	"packageInformation": correlatePackageInformation,
-	"diagnosticResult":   correlateDiagnosticResult,
+	"metaData":             correlateMetaData,
+	"document":             correlateDocument,
+	"range":                correlateRange,
+	"resultSet":            correlateResultSet,
+	"definitionResult":     correlateDefinitionResult,
+	"referenceResult":      correlateReferenceResult,
+	"implementationResult": correlateImplementationResult,
+	"typeDefinitionResult": correlateTypeDefinitionResult,
+	"hoverResult":          correlateHoverResult,
+	"moniker":              correlateMoniker,
+	"packageInformation":   correlatePackageInformation,
+	"diagnosticResult":     correlateDiagnosticResult,
+	"documentSymbolResult": correlateDocumentSymbolResult,
 }
 
 // correlateElement maps a single vertex element into the correlation state.
@@ -160,16 +163,19 @@ func correlateVertex(state *wrappedState, element lsif.Element) error {
 }
 
 var edgeHandlers = map[string]func(state *wrappedState, id int, edge lsif.Edge) error{
-	"contains":                correlateContainsEdge,
-	"next":                    correlateNextEdge,
-	"item":                    correlateItemEdge,
-	"textDocument/definition": correlateTextDocumentDefinitionEdge,
-	"textDocument/references": correlateTextDocumentReferencesEdge,
-	"textDocument/hover":      correlateTextDocumentHoverEdge,
-	"moniker":                 correlateMonikerEdge,
-	"nextMoniker":             correlateNextMonikerEdge,
-	"packageInformation":      correlatePackageInformationEdge,
-	"textDocument/diagnostic": correlateDiagnosticEdge,
+	"contains":                    correlateContainsEdge,
+	"next":                        correlateNextEdge,
+	"item":                        correlateItemEdge,
+	"textDocument/definition":     correlateTextDocumentDefinitionEdge,
+	"textDocument/references":     correlateTextDocumentReferencesEdge,
+	"textDocument/implementation": correlateTextDocumentImplementationEdge,
+	"textDocument/typeDefinition": correlateTextDocumentTypeDefinitionEdge,
+	"textDocument/hover":          correlateTextDocumentHoverEdge,
+	"moniker":                     correlateMonikerEdge,
+	"nextMoniker":                 correlateNextMonikerEdge,
+	"packageInformation":          correlatePackageInformationEdge,
+	"textDocument/diagnostic":     correlateDiagnosticEdge,
+	"textDocument/documentSymbol": correlateDocumentSymbolEdge,
 }
 
 // correlateElement maps a single edge element into the correlation state.
@@ -260,6 +266,16 @@ func correlateReferenceResult(state *wrappedState, element lsif.Element) error {
 	return nil
 }
 
+func correlateImplementationResult(state *wrappedState, element lsif.Element) error {
+	state.ImplementationData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
+func correlateTypeDefinitionResult(state *wrappedState, element lsif.Element) error {
+	state.TypeDefinitionData[element.ID] = datastructures.NewDefaultIDSetMap()
+	return nil
+}
+
 func correlateHoverResult(state *wrappedState, element lsif.Element) error {
 	payload, ok := element.Payload.(string)
 	if !ok {
@@ -300,6 +316,16 @@ func correlateDiagnosticResult(state *wrappedState, element lsif.Element) error
 	return nil
 }
 
+func correlateDocumentSymbolResult(state *wrappedState, element lsif.Element) error {
+	payload, ok := element.Payload.([]lsif.DocumentSymbol)
+	if !ok {
+		return ErrUnexpectedPayload
+	}
+
+	state.DocumentSymbolResults[element.ID] = payload
+	return nil
+}
+
 func correlateContainsEdge(state *wrappedState, id int, edge lsif.Edge) error {
 	if _, ok := state.DocumentData[edge.OutV]; !ok {
 		// Do not track this relation for project vertices
@@ -331,17 +357,19 @@ func correlateNextEdge(state *wrappedState, id int, edge lsif.Edge) error {
 }
 
 func correlateItemEdge(state *wrappedState, id int, edge lsif.Edge) error {
-	if documentMap, ok := state.DefinitionData[edge.OutV]; ok {
-		for _, inV := range edge.InVs {
-			if _, ok := state.RangeData[inV]; !ok {
-				return malformedDump(id, edge.InV, "range")
+	for _, data := range []map[int]*datastructures.DefaultIDSetMap{state.DefinitionData, state.ImplementationData, state.TypeDefinitionData} {
+		if documentMap, ok := data[edge.OutV]; ok {
+			for _, inV := range edge.InVs {
+				if _, ok := state.RangeData[inV]; !ok {
+					return malformedDump(id, edge.InV, "range")
+				}
+
+				// Link definition, implementation, or type definition data to its range
+				documentMap.SetAdd(edge.Document, inV)
 			}
 
-			// Link definition data to defining range
-			documentMap.SetAdd(edge.Document, inV)
+			return nil
 		}
-
-		return nil
 	}
 
 	if documentMap, ok := state.ReferenceData[edge.OutV]; ok {
@@ -400,6 +428,36 @@ func correlateTextDocumentReferencesEdge(state *wrappedState, id int, edge lsif.
 	return nil
 }
 
+func correlateTextDocumentImplementationEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	if _, ok := state.ImplementationData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "implementationResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetImplementationResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
+func correlateTextDocumentTypeDefinitionEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	if _, ok := state.TypeDefinitionData[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "typeDefinitionResult")
+	}
+
+	if source, ok := state.RangeData[edge.OutV]; ok {
+		state.RangeData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else if source, ok := state.ResultSetData[edge.OutV]; ok {
+		state.ResultSetData[edge.OutV] = source.SetTypeDefinitionResultID(edge.InV)
+	} else {
+		return malformedDump(id, edge.OutV, "range", "resultSet")
+	}
+	return nil
+}
+
 func correlateTextDocumentHoverEdge(state *wrappedState, id int, edge lsif.Edge) error {
 	if _, ok := state.HoverData[edge.InV]; !ok {
 		return malformedDump(id, edge.InV, "hoverResult")
@@ -477,3 +535,16 @@ func correlateDiagnosticEdge(state *wrappedState, id int, edge lsif.Edge) error
 	state.Diagnostics.SetAdd(edge.OutV, edge.InV)
 	return nil
 }
+
+func correlateDocumentSymbolEdge(state *wrappedState, id int, edge lsif.Edge) error {
+	if _, ok := state.DocumentData[edge.OutV]; !ok {
+		return malformedDump(id, edge.OutV, "document")
+	}
+
+	if _, ok := state.DocumentSymbolResults[edge.InV]; !ok {
+		return malformedDump(id, edge.InV, "documentSymbolResult")
+	}
+
+	state.DocumentSymbols.SetAdd(edge.OutV, edge.InV)
+	return nil
+}
@@ -35,13 +35,23 @@ func TestCorrelate(t *testing.T) {
 				EndLine:            3,
 				EndCharacter:       4,
 				DefinitionResultID: 13,
+				Tag: &lsif.RangeTag{
+					Type:               "definition",
+					Text:               "foo",
+					Kind:               12,
+					FullStartLine:      1,
+					FullStartCharacter: 0,
+					FullEndLine:        3,
+					FullEndCharacter:   5,
+				},
 			},
 			5: {
-				StartLine:         2,
-				StartCharacter:    3,
-				EndLine:           4,
-				EndCharacter:      5,
-				ReferenceResultID: 15,
+				StartLine:              2,
+				StartCharacter:         3,
+				EndLine:                4,
+				EndCharacter:           5,
+				ReferenceResultID:      15,
+				TypeDefinitionResultID: 52,
 			},
 			6: {
 				StartLine:          3,
@@ -78,7 +88,8 @@ func TestCorrelate(t *testing.T) {
 				ReferenceResultID:  14,
 			},
 			11: {
-				HoverResultID: 16,
+				HoverResultID:          16,
+				ImplementationResultID: 51,
 			},
 		},
 		DefinitionData: map[int]*datastructures.DefaultIDSetMap{
@@ -89,6 +100,12 @@ func TestCorrelate(t *testing.T) {
 			14: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(4, 5)}),
 			15: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{}),
 		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
+			51: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{3: datastructures.IDSetWith(8)}),
+		},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			52: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{2: datastructures.IDSetWith(6)}),
+		},
 		HoverData: map[int]string{
 			16: "```go\ntext A\n```",
 			17: "```go\ntext B\n```",
@@ -137,6 +154,14 @@ func TestCorrelate(t *testing.T) {
 				},
 			},
 		},
+		DocumentSymbolResults: map[int][]lsif.DocumentSymbol{
+			57: {
+				{
+					RangeID:  4,
+					Children: []lsif.DocumentSymbol{{RangeID: 5}},
+				},
+			},
+		},
 		NextData: map[int]int{
 			9:  10,
 			10: 11,
@@ -158,6 +183,9 @@ func TestCorrelate(t *testing.T) {
 		Diagnostics: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
 			2: datastructures.IDSetWith(49),
 		}),
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			2: datastructures.IDSetWith(57),
+		}),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
@@ -186,10 +214,13 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		DiagnosticResults:      map[int][]lsif.Diagnostic{},
+		DocumentSymbolResults:  map[int][]lsif.DocumentSymbol{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -198,6 +229,7 @@ func TestCorrelateMetaDataRoot(t *testing.T) {
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
@@ -226,10 +258,13 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		DiagnosticResults:      map[int][]lsif.Diagnostic{},
+		DocumentSymbolResults:  map[int][]lsif.DocumentSymbol{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -238,6 +273,7 @@ func TestCorrelateMetaDataRootX(t *testing.T) {
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 
 	if diff := cmp.Diff(expectedState, state, datastructures.Comparers...); diff != "" {
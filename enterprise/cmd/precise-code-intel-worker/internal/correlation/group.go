@@ -32,7 +32,7 @@ const ResultsPerResultChunk = 500
 
 // groupBundleData converts a raw (but canonicalized) correlation State into a GroupedBundleData.
 func groupBundleData(ctx context.Context, state *State, dumpID int) (*GroupedBundleData, error) {
-	numResults := len(state.DefinitionData) + len(state.ReferenceData)
+	numResults := len(state.DefinitionData) + len(state.ReferenceData) + len(state.ImplementationData) + len(state.TypeDefinitionData)
 	numResultChunks := int(math.Min(
 		MaxNumResultChunks,
 		math.Max(
@@ -124,14 +124,16 @@ func serializeDocument(state *State, documentID int) types.DocumentData {
 		})
 
 		document.Ranges[toID(rangeID)] = types.RangeData{
-			StartLine:          rangeData.StartLine,
-			StartCharacter:     rangeData.StartCharacter,
-			EndLine:            rangeData.EndLine,
-			EndCharacter:       rangeData.EndCharacter,
-			DefinitionResultID: toID(rangeData.DefinitionResultID),
-			ReferenceResultID:  toID(rangeData.ReferenceResultID),
-			HoverResultID:      toID(rangeData.HoverResultID),
-			MonikerIDs:         monikerIDs,
+			StartLine:              rangeData.StartLine,
+			StartCharacter:         rangeData.StartCharacter,
+			EndLine:                rangeData.EndLine,
+			EndCharacter:           rangeData.EndCharacter,
+			DefinitionResultID:     toID(rangeData.DefinitionResultID),
+			ReferenceResultID:      toID(rangeData.ReferenceResultID),
+			HoverResultID:          toID(rangeData.HoverResultID),
+			ImplementationResultID: toID(rangeData.ImplementationResultID),
+			TypeDefinitionResultID: toID(rangeData.TypeDefinitionResultID),
+			MonikerIDs:             monikerIDs,
 		}
 
 		if rangeData.HoverResultID != 0 {
@@ -155,16 +157,69 @@ func serializeDocument(state *State, documentID int) types.DocumentData {
 		}
 	})
 
+	state.DocumentSymbols.SetEach(documentID, func(documentSymbolResultID int) {
+		document.Symbols = append(document.Symbols, serializeDocumentSymbols(state, state.DocumentSymbolResults[documentSymbolResultID])...)
+	})
+
 	return document
 }
 
+// serializeDocumentSymbols converts a document symbol hierarchy into symbol data. Range-based
+// symbols are described by the tag of the range they refer to. A range-based symbol whose range
+// is missing or untagged is replaced by its children.
+func serializeDocumentSymbols(state *State, documentSymbols []lsif.DocumentSymbol) []types.SymbolData {
+	var symbols []types.SymbolData
+	for _, documentSymbol := range documentSymbols {
+		children := serializeDocumentSymbols(state, documentSymbol.Children)
+
+		if documentSymbol.RangeID == 0 {
+			symbols = append(symbols, types.SymbolData{
+				Name:               documentSymbol.Name,
+				Detail:             documentSymbol.Detail,
+				Kind:               documentSymbol.Kind,
+				StartLine:          documentSymbol.StartLine,
+				StartCharacter:     documentSymbol.StartCharacter,
+				EndLine:            documentSymbol.EndLine,
+				EndCharacter:       documentSymbol.EndCharacter,
+				FullStartLine:      documentSymbol.FullStartLine,
+				FullStartCharacter: documentSymbol.FullStartCharacter,
+				FullEndLine:        documentSymbol.FullEndLine,
+				FullEndCharacter:   documentSymbol.FullEndCharacter,
+				Children:           children,
+			})
+			continue
+		}
+
+		rangeData, ok := state.RangeData[documentSymbol.RangeID]
+		if !ok || rangeData.Tag == nil {
+			symbols = append(symbols, children...)
+			continue
+		}
+
+		symbols = append(symbols, types.SymbolData{
+			Name:               rangeData.Tag.Text,
+			Detail:             rangeData.Tag.Detail,
+			Kind:               rangeData.Tag.Kind,
+			StartLine:          rangeData.StartLine,
+			StartCharacter:     rangeData.StartCharacter,
+			EndLine:            rangeData.EndLine,
+			EndCharacter:       rangeData.EndCharacter,
+			FullStartLine:      rangeData.Tag.FullStartLine,
+			FullStartCharacter: rangeData.Tag.FullStartCharacter,
+			FullEndLine:        rangeData.Tag.FullEndLine,
+			FullEndCharacter:   rangeData.Tag.FullEndCharacter,
+			Children:           children,
+		})
+	}
+
+	return symbols
+}
+
 func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan persistence.IndexedResultChunkData {
+	resultData := resultDataByID(state)
+
 	chunkAssignments := make(map[int][]int, numResultChunks)
-	for id := range state.DefinitionData {
-		index := types.HashKey(toID(id), numResultChunks)
-		chunkAssignments[index] = append(chunkAssignments[index], id)
-	}
-	for id := range state.ReferenceData {
+	for id := range resultData {
 		index := types.HashKey(toID(id), numResultChunks)
 		chunkAssignments[index] = append(chunkAssignments[index], id)
 	}
@@ -183,10 +238,7 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 			documentIDRangeIDs := map[types.ID][]types.DocumentIDRangeID{}
 
 			for _, resultID := range resultIDs {
-				documentRanges, ok := state.DefinitionData[resultID]
-				if !ok {
-					documentRanges = state.ReferenceData[resultID]
-				}
+				documentRanges := resultData[resultID]
 
 				// Ensure we always make an assignment for every definition and reference result,
 				// even if we've pruned all of the referenced documents and ranges. This prevents
@@ -225,6 +277,19 @@ func serializeResultChunks(ctx context.Context, state *State, numResultChunks in
 	return ch
 }
 
+// resultDataByID returns a map from the identifiers of all definition, reference, implementation,
+// and type definition results to their data. These results share the same result chunks.
+func resultDataByID(state *State) map[int]*datastructures.DefaultIDSetMap {
+	resultData := map[int]*datastructures.DefaultIDSetMap{}
+	for _, data := range []map[int]*datastructures.DefaultIDSetMap{state.DefinitionData, state.ReferenceData, state.ImplementationData, state.TypeDefinitionData} {
+		for id, documentRanges := range data {
+			resultData[id] = documentRanges
+		}
+	}
+
+	return resultData
+}
+
 var (
 	getDefinitionResultID = func(r lsif.Range) int { return r.DefinitionResultID }
 	getReferenceResultID  = func(r lsif.Range) int { return r.ReferenceResultID }
@@ -28,14 +28,25 @@ func TestGroupBundleData(t *testing.T) {
 				EndCharacter:       4,
 				DefinitionResultID: 3001,
 				ReferenceResultID:  0,
+				Tag: &lsif.RangeTag{
+					Type:               "definition",
+					Text:               "foo",
+					Kind:               12,
+					Detail:             "func()",
+					FullStartLine:      1,
+					FullStartCharacter: 0,
+					FullEndLine:        9,
+					FullEndCharacter:   1,
+				},
 			},
 			2002: {
-				StartLine:          2,
-				StartCharacter:     3,
-				EndLine:            4,
-				EndCharacter:       5,
-				DefinitionResultID: 0,
-				ReferenceResultID:  3006,
+				StartLine:              2,
+				StartCharacter:         3,
+				EndLine:                4,
+				EndCharacter:           5,
+				DefinitionResultID:     0,
+				ReferenceResultID:      3006,
+				ImplementationResultID: 3010,
 			},
 			2003: {
 				StartLine:          3,
@@ -46,12 +57,13 @@ func TestGroupBundleData(t *testing.T) {
 				ReferenceResultID:  0,
 			},
 			2004: {
-				StartLine:          4,
-				StartCharacter:     5,
-				EndLine:            6,
-				EndCharacter:       7,
-				DefinitionResultID: 0,
-				ReferenceResultID:  3007,
+				StartLine:              4,
+				StartCharacter:         5,
+				EndLine:                6,
+				EndCharacter:           7,
+				DefinitionResultID:     0,
+				ReferenceResultID:      3007,
+				TypeDefinitionResultID: 3011,
 			},
 			2005: {
 				StartLine:          5,
@@ -131,6 +143,16 @@ func TestGroupBundleData(t *testing.T) {
 				1003: datastructures.IDSetWith(2007, 2009),
 			}),
 		},
+		ImplementationData: map[int]*datastructures.DefaultIDSetMap{
+			3010: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1002: datastructures.IDSetWith(2004),
+			}),
+		},
+		TypeDefinitionData: map[int]*datastructures.DefaultIDSetMap{
+			3011: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1001: datastructures.IDSetWith(2001),
+			}),
+		},
 		HoverData: map[int]string{
 			3008: "foo",
 			3009: "bar",
@@ -234,6 +256,48 @@ func TestGroupBundleData(t *testing.T) {
 			1001: datastructures.IDSetWith(1001, 1002),
 			1002: datastructures.IDSetWith(1003),
 		}),
+		DocumentSymbolResults: map[int][]lsif.DocumentSymbol{
+			6001: {
+				{
+					RangeID: 2001,
+					Children: []lsif.DocumentSymbol{
+						{
+							Name:               "bar",
+							Kind:               13,
+							StartLine:          2,
+							StartCharacter:     5,
+							EndLine:            2,
+							EndCharacter:       8,
+							FullStartLine:      2,
+							FullStartCharacter: 1,
+							FullEndLine:        2,
+							FullEndCharacter:   12,
+						},
+					},
+				},
+				{
+					// untagged range, replaced by its children
+					RangeID: 2002,
+					Children: []lsif.DocumentSymbol{
+						{
+							Name:               "baz",
+							Kind:               14,
+							StartLine:          12,
+							StartCharacter:     6,
+							EndLine:            12,
+							EndCharacter:       9,
+							FullStartLine:      12,
+							FullStartCharacter: 0,
+							FullEndLine:        12,
+							FullEndCharacter:   14,
+						},
+					},
+				},
+			},
+		},
+		DocumentSymbols: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1001: datastructures.IDSetWith(6001),
+		}),
 	}
 
 	actualBundleData, err := groupBundleData(context.Background(), state, 42)
@@ -292,14 +356,15 @@ func TestGroupBundleData(t *testing.T) {
 					MonikerIDs:         []types.ID{"4001", "4002"},
 				},
 				"2002": {
-					StartLine:          2,
-					StartCharacter:     3,
-					EndLine:            4,
-					EndCharacter:       5,
-					DefinitionResultID: "",
-					ReferenceResultID:  "3006",
-					HoverResultID:      "",
-					MonikerIDs:         []types.ID{"4003", "4004"},
+					StartLine:              2,
+					StartCharacter:         3,
+					EndLine:                4,
+					EndCharacter:           5,
+					DefinitionResultID:     "",
+					ReferenceResultID:      "3006",
+					ImplementationResultID: "3010",
+					HoverResultID:          "",
+					MonikerIDs:             []types.ID{"4003", "4004"},
 				},
 				"2003": {
 					StartLine:          3,
@@ -371,18 +436,60 @@ func TestGroupBundleData(t *testing.T) {
 					EndCharacter:   24,
 				},
 			},
+			Symbols: []types.SymbolData{
+				{
+					Name:               "foo",
+					Detail:             "func()",
+					Kind:               12,
+					StartLine:          1,
+					StartCharacter:     2,
+					EndLine:            3,
+					EndCharacter:       4,
+					FullStartLine:      1,
+					FullStartCharacter: 0,
+					FullEndLine:        9,
+					FullEndCharacter:   1,
+					Children: []types.SymbolData{
+						{
+							Name:               "bar",
+							Kind:               13,
+							StartLine:          2,
+							StartCharacter:     5,
+							EndLine:            2,
+							EndCharacter:       8,
+							FullStartLine:      2,
+							FullStartCharacter: 1,
+							FullEndLine:        2,
+							FullEndCharacter:   12,
+						},
+					},
+				},
+				{
+					Name:               "baz",
+					Kind:               14,
+					StartLine:          12,
+					StartCharacter:     6,
+					EndLine:            12,
+					EndCharacter:       9,
+					FullStartLine:      12,
+					FullStartCharacter: 0,
+					FullEndLine:        12,
+					FullEndCharacter:   14,
+				},
+			},
 		},
 		"bar.go": {
 			Ranges: map[types.ID]types.RangeData{
 				"2004": {
-					StartLine:          4,
-					StartCharacter:     5,
-					EndLine:            6,
-					EndCharacter:       7,
-					DefinitionResultID: "",
-					ReferenceResultID:  "3007",
-					HoverResultID:      "",
-					MonikerIDs:         []types.ID{},
+					StartLine:              4,
+					StartCharacter:         5,
+					EndLine:                6,
+					EndCharacter:           7,
+					DefinitionResultID:     "",
+					ReferenceResultID:      "3007",
+					TypeDefinitionResultID: "3011",
+					HoverResultID:          "",
+					MonikerIDs:             []types.ID{},
 				},
 				"2005": {
 					StartLine:          5,
@@ -527,6 +634,12 @@ func TestGroupBundleData(t *testing.T) {
 					{DocumentID: "1003", RangeID: "2007"},
 					{DocumentID: "1003", RangeID: "2009"},
 				},
+				"3010": {
+					{DocumentID: "1002", RangeID: "2004"},
+				},
+				"3011": {
+					{DocumentID: "1001", RangeID: "2001"},
+				},
 			},
 		},
 	}
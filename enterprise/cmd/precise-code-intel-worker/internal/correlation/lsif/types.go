@@ -20,78 +20,160 @@ type MetaData struct {
 }
 
 type Range struct {
-	StartLine          int
-	StartCharacter     int
-	EndLine            int
-	EndCharacter       int
-	DefinitionResultID int
-	ReferenceResultID  int
-	HoverResultID      int
+	StartLine              int
+	StartCharacter         int
+	EndLine                int
+	EndCharacter           int
+	DefinitionResultID     int
+	ReferenceResultID      int
+	HoverResultID          int
+	ImplementationResultID int
+	TypeDefinitionResultID int
+	Tag                    *RangeTag
 }
 
 func (d Range) SetDefinitionResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetReferenceResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		Tag:                    d.Tag,
 	}
 }
 
 func (d Range) SetHoverResultID(id int) Range {
 	return Range{
-		StartLine:          d.StartLine,
-		StartCharacter:     d.StartCharacter,
-		EndLine:            d.EndLine,
-		EndCharacter:       d.EndCharacter,
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          id,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		Tag:                    d.Tag,
+	}
+}
+
+func (d Range) SetImplementationResultID(id int) Range {
+	return Range{
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: id,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+		Tag:                    d.Tag,
 	}
 }
 
+func (d Range) SetTypeDefinitionResultID(id int) Range {
+	return Range{
+		StartLine:              d.StartLine,
+		StartCharacter:         d.StartCharacter,
+		EndLine:                d.EndLine,
+		EndCharacter:           d.EndCharacter,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: id,
+		Tag:                    d.Tag,
+	}
+}
+
+// RangeTag describes the symbol that a range declares or refers to.
+type RangeTag struct {
+	Type               string // definition, declaration, reference, or unknown
+	Text               string
+	Kind               int // LSP SymbolKind, absent from reference tags
+	Detail             string
+	FullStartLine      int
+	FullStartCharacter int
+	FullEndLine        int
+	FullEndCharacter   int
+}
+
 type ResultSet struct {
-	DefinitionResultID int
-	ReferenceResultID  int
-	HoverResultID      int
+	DefinitionResultID     int
+	ReferenceResultID      int
+	HoverResultID          int
+	ImplementationResultID int
+	TypeDefinitionResultID int
 }
 
 func (d ResultSet) SetDefinitionResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: id,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      d.HoverResultID,
+		DefinitionResultID:     id,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
 	}
 }
 
 func (d ResultSet) SetReferenceResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  id,
-		HoverResultID:      d.HoverResultID,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      id,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
 	}
 }
 
 func (d ResultSet) SetHoverResultID(id int) ResultSet {
 	return ResultSet{
-		DefinitionResultID: d.DefinitionResultID,
-		ReferenceResultID:  d.ReferenceResultID,
-		HoverResultID:      id,
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          id,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+	}
+}
+
+func (d ResultSet) SetImplementationResultID(id int) ResultSet {
+	return ResultSet{
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: id,
+		TypeDefinitionResultID: d.TypeDefinitionResultID,
+	}
+}
+
+func (d ResultSet) SetTypeDefinitionResultID(id int) ResultSet {
+	return ResultSet{
+		DefinitionResultID:     d.DefinitionResultID,
+		ReferenceResultID:      d.ReferenceResultID,
+		HoverResultID:          d.HoverResultID,
+		ImplementationResultID: d.ImplementationResultID,
+		TypeDefinitionResultID: id,
 	}
 }
 
@@ -116,6 +198,24 @@ type PackageInformation struct {
 	Version string
 }
 
+// DocumentSymbol is an entry of a document symbol result. Range-based symbols refer to
+// a range vertex whose tag describes the symbol. Other symbols are described inline.
+type DocumentSymbol struct {
+	RangeID            int // zero for inline symbols
+	Name               string
+	Detail             string
+	Kind               int
+	StartLine          int
+	StartCharacter     int
+	EndLine            int
+	EndCharacter       int
+	FullStartLine      int
+	FullStartCharacter int
+	FullEndLine        int
+	FullEndCharacter   int
+	Children           []DocumentSymbol
+}
+
 type Diagnostic struct {
 	Severity       int
 	Code           string
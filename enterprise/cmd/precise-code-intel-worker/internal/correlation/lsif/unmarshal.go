@@ -35,7 +35,11 @@ func unmarshalElement(interner *Interner, line []byte) (_ Element, err error) {
 	if element.Type == "edge" {
 		element.Payload, err = unmarshalEdge(interner, line)
 	} else if element.Type == "vertex" {
-		if unmarshaler, ok := vertexUnmarshalers[element.Label]; ok {
+		if element.Label == "documentSymbolResult" {
+			// Range-based document symbols refer to range identifiers,
+			// which must go through the interner like edges do.
+			element.Payload, err = unmarshalDocumentSymbolResult(interner, line)
+		} else if unmarshaler, ok := vertexUnmarshalers[element.Label]; ok {
 			element.Payload, err = unmarshaler(line)
 		}
 	}
@@ -156,19 +160,46 @@ func unmarshalRange(line []byte) (interface{}, error) {
 		Line      int `json:"line"`
 		Character int `json:"character"`
 	}
+	type _range struct {
+		Start _position `json:"start"`
+		End   _position `json:"end"`
+	}
+	type _tag struct {
+		Type      string `json:"type"`
+		Text      string `json:"text"`
+		Kind      int    `json:"kind"`
+		Detail    string `json:"detail"`
+		FullRange _range `json:"fullRange"`
+	}
 	var payload struct {
 		Start _position `json:"start"`
 		End   _position `json:"end"`
+		Tag   *_tag     `json:"tag"`
 	}
 	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
 		return nil, err
 	}
 
+	var tag *RangeTag
+	if payload.Tag != nil {
+		tag = &RangeTag{
+			Type:               payload.Tag.Type,
+			Text:               payload.Tag.Text,
+			Kind:               payload.Tag.Kind,
+			Detail:             payload.Tag.Detail,
+			FullStartLine:      payload.Tag.FullRange.Start.Line,
+			FullStartCharacter: payload.Tag.FullRange.Start.Character,
+			FullEndLine:        payload.Tag.FullRange.End.Line,
+			FullEndCharacter:   payload.Tag.FullRange.End.Character,
+		}
+	}
+
 	return Range{
 		StartLine:      payload.Start.Line,
 		StartCharacter: payload.Start.Character,
 		EndLine:        payload.End.Line,
 		EndCharacter:   payload.End.Character,
+		Tag:            tag,
 	}, nil
 }
 
@@ -316,6 +347,70 @@ func unmarshalDiagnosticResult(line []byte) (interface{}, error) {
 	return diagnostics, nil
 }
 
+func unmarshalDocumentSymbolResult(interner *Interner, line []byte) (interface{}, error) {
+	type _position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	}
+	type _range struct {
+		Start _position `json:"start"`
+		End   _position `json:"end"`
+	}
+	// A range-based document symbol has only an id and children. Otherwise,
+	// the symbol is an LSP document symbol, which has no id.
+	type _documentSymbol struct {
+		ID             json.RawMessage   `json:"id"`
+		Name           string            `json:"name"`
+		Detail         string            `json:"detail"`
+		Kind           int               `json:"kind"`
+		Range          _range            `json:"range"`
+		SelectionRange _range            `json:"selectionRange"`
+		Children       []_documentSymbol `json:"children"`
+	}
+	var payload struct {
+		Result []_documentSymbol `json:"result"`
+	}
+	if err := unmarshaller.Unmarshal(line, &payload); err != nil {
+		return nil, err
+	}
+
+	var convert func(symbols []_documentSymbol) ([]DocumentSymbol, error)
+	convert = func(symbols []_documentSymbol) ([]DocumentSymbol, error) {
+		var documentSymbols []DocumentSymbol
+		for _, symbol := range symbols {
+			rangeID, err := internRaw(interner, symbol.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			children, err := convert(symbol.Children)
+			if err != nil {
+				return nil, err
+			}
+
+			documentSymbols = append(documentSymbols, DocumentSymbol{
+				RangeID:            rangeID,
+				Name:               symbol.Name,
+				Detail:             symbol.Detail,
+				Kind:               symbol.Kind,
+				StartLine:          symbol.SelectionRange.Start.Line,
+				StartCharacter:     symbol.SelectionRange.Start.Character,
+				EndLine:            symbol.SelectionRange.End.Line,
+				EndCharacter:       symbol.SelectionRange.End.Character,
+				FullStartLine:      symbol.Range.Start.Line,
+				FullStartCharacter: symbol.Range.Start.Character,
+				FullEndLine:        symbol.Range.End.Line,
+				FullEndCharacter:   symbol.Range.End.Character,
+				Children:           children,
+			})
+		}
+
+		return documentSymbols, nil
+	}
+
+	return convert(payload.Result)
+}
+
 type StringOrInt string
 
 func (id *StringOrInt) UnmarshalJSON(raw []byte) error {
@@ -120,6 +120,33 @@ func TestUnmarshalRange(t *testing.T) {
 	}
 }
 
+func TestUnmarshalRangeTag(t *testing.T) {
+	r, err := unmarshalRange([]byte(`{"id": "04", "type": "vertex", "label": "range", "start": {"line": 1, "character": 2}, "end": {"line": 1, "character": 5}, "tag": {"type": "definition", "text": "foo", "kind": 12, "detail": "func()", "fullRange": {"start": {"line": 1, "character": 0}, "end": {"line": 3, "character": 1}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling range data: %s", err)
+	}
+
+	expectedRange := Range{
+		StartLine:      1,
+		StartCharacter: 2,
+		EndLine:        1,
+		EndCharacter:   5,
+		Tag: &RangeTag{
+			Type:               "definition",
+			Text:               "foo",
+			Kind:               12,
+			Detail:             "func()",
+			FullStartLine:      1,
+			FullStartCharacter: 0,
+			FullEndLine:        3,
+			FullEndCharacter:   1,
+		},
+	}
+	if diff := cmp.Diff(expectedRange, r, datastructures.Comparers...); diff != "" {
+		t.Errorf("unexpected range (-want +got):\n%s", diff)
+	}
+}
+
 func TestUnmarshalHover(t *testing.T) {
 	testCases := []struct {
 		contents      string
@@ -212,3 +239,33 @@ func TestUnmarshalDiagnosticResult(t *testing.T) {
 		t.Errorf("unexpected diagnostic result (-want +got):\n%s", diff)
 	}
 }
+
+func TestUnmarshalDocumentSymbolResult(t *testing.T) {
+	element, err := unmarshalElement(NewInterner(), []byte(`{"id": "19", "type": "vertex", "label": "documentSymbolResult", "result": [{"id": "04", "children": [{"id": "05"}]}, {"name": "bar", "detail": "int", "kind": 13, "range": {"start": {"line": 5, "character": 0}, "end": {"line": 5, "character": 11}}, "selectionRange": {"start": {"line": 5, "character": 4}, "end": {"line": 5, "character": 7}}}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error unmarshalling document symbol result data: %s", err)
+	}
+
+	expectedDocumentSymbols := []DocumentSymbol{
+		{
+			RangeID:  4,
+			Children: []DocumentSymbol{{RangeID: 5}},
+		},
+		{
+			Name:               "bar",
+			Detail:             "int",
+			Kind:               13,
+			StartLine:          5,
+			StartCharacter:     4,
+			EndLine:            5,
+			EndCharacter:       7,
+			FullStartLine:      5,
+			FullStartCharacter: 0,
+			FullEndLine:        5,
+			FullEndCharacter:   11,
+		},
+	}
+	if diff := cmp.Diff(expectedDocumentSymbols, element.Payload); diff != "" {
+		t.Errorf("unexpected document symbol result (-want +got):\n%s", diff)
+	}
+}
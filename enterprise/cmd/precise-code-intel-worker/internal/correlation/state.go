@@ -14,10 +14,13 @@ type State struct {
 	ResultSetData          map[int]lsif.ResultSet
 	DefinitionData         map[int]*datastructures.DefaultIDSetMap
 	ReferenceData          map[int]*datastructures.DefaultIDSetMap
+	ImplementationData     map[int]*datastructures.DefaultIDSetMap
+	TypeDefinitionData     map[int]*datastructures.DefaultIDSetMap
 	HoverData              map[int]string
 	MonikerData            map[int]lsif.Moniker
 	PackageInformationData map[int]lsif.PackageInformation
 	DiagnosticResults      map[int][]lsif.Diagnostic
+	DocumentSymbolResults  map[int][]lsif.DocumentSymbol
 	NextData               map[int]int                     // maps range/result sets related via next edges
 	ImportedMonikers       *datastructures.IDSet           // moniker ids that have kind "import"
 	ExportedMonikers       *datastructures.IDSet           // moniker ids that have kind "export"
@@ -26,6 +29,7 @@ type State struct {
 	Monikers               *datastructures.DefaultIDSetMap // maps items to their monikers
 	Contains               *datastructures.DefaultIDSetMap // maps ranges to containing documents
 	Diagnostics            *datastructures.DefaultIDSetMap // maps diagnostics to their documents
+	DocumentSymbols        *datastructures.DefaultIDSetMap // maps document symbol results to their documents
 }
 
 // newState create a new State with zero-valued map fields.
@@ -36,10 +40,13 @@ func newState() *State {
 		ResultSetData:          map[int]lsif.ResultSet{},
 		DefinitionData:         map[int]*datastructures.DefaultIDSetMap{},
 		ReferenceData:          map[int]*datastructures.DefaultIDSetMap{},
+		ImplementationData:     map[int]*datastructures.DefaultIDSetMap{},
+		TypeDefinitionData:     map[int]*datastructures.DefaultIDSetMap{},
 		HoverData:              map[int]string{},
 		MonikerData:            map[int]lsif.Moniker{},
 		PackageInformationData: map[int]lsif.PackageInformation{},
 		DiagnosticResults:      map[int][]lsif.Diagnostic{},
+		DocumentSymbolResults:  map[int][]lsif.DocumentSymbol{},
 		NextData:               map[int]int{},
 		ImportedMonikers:       datastructures.NewIDSet(),
 		ExportedMonikers:       datastructures.NewIDSet(),
@@ -48,5 +55,6 @@ func newState() *State {
 		Monikers:               datastructures.NewDefaultIDSetMap(),
 		Contains:               datastructures.NewDefaultIDSetMap(),
 		Diagnostics:            datastructures.NewDefaultIDSetMap(),
+		DocumentSymbols:        datastructures.NewDefaultIDSetMap(),
 	}
 }
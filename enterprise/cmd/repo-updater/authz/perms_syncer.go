@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -172,6 +173,10 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 	providers := s.providersByServiceID()
 
 	var repoSpecs []api.ExternalRepoSpec
+	// Sub-repository permissions are only synced when a provider of the user's external accounts
+	// supports them, so that the ones set via the explicit API aren't removed otherwise.
+	var syncSubRepoPerms bool
+	subRepoPerms := make(map[api.ExternalRepoSpec]*authz.SubRepoPermissions)
 	for _, acct := range accts {
 		provider := providers[acct.ServiceID]
 		if provider == nil {
@@ -199,6 +204,31 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 				ServiceID:   provider.ServiceID(),
 			})
 		}
+
+		subRepoProvider, ok := provider.(authz.SubRepoPermsProvider)
+		if !ok {
+			continue
+		}
+
+		if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
+			return errors.Wrap(err, "wait for rate limiter")
+		}
+
+		// 🚨 SECURITY: Partial results are never used, because missing sub-repository permissions
+		// would grant access to all paths of the repositories.
+		perms, err := subRepoProvider.FetchUserSubRepoPerms(ctx, acct)
+		if err != nil {
+			return errors.Wrap(err, "fetch user sub-repository permissions")
+		}
+		syncSubRepoPerms = true
+
+		for extID, p := range perms {
+			subRepoPerms[api.ExternalRepoSpec{
+				ID:          string(extID),
+				ServiceType: provider.ServiceType(),
+				ServiceID:   provider.ServiceID(),
+			}] = p
+		}
 	}
 
 	var rs []*repos.Repo
@@ -213,6 +243,23 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 		}
 	}
 
+	// Save sub-repository permissions first, so that repositories aren't readable without their
+	// path restrictions in between.
+	if syncSubRepoPerms {
+		ps := make([]*authz.SubRepoPermissions, 0, len(subRepoPerms))
+		for _, r := range rs {
+			if p, ok := subRepoPerms[r.ExternalRepo]; ok {
+				p.RepoID = int32(r.ID)
+				ps = append(ps, p)
+			}
+		}
+
+		err = s.permsStore.SetUserSubRepoPermissions(ctx, userID, ps)
+		if err != nil {
+			return errors.Wrap(err, "set user sub-repository permissions")
+		}
+	}
+
 	// Save permissions to database
 	p := &authz.UserPermissions{
 		UserID: userID,
@@ -305,6 +352,24 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		log15.Debug("PermsSyncer.syncRepoPerms.proceedWithPartialResults", "repoID", repo.ID, "err", err)
 	}
 
+	var subRepoPerms map[extsvc.AccountID]*authz.SubRepoPermissions
+	subRepoProvider, syncSubRepoPerms := provider.(authz.SubRepoPermsProvider)
+	if syncSubRepoPerms {
+		if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
+			return errors.Wrap(err, "wait for rate limiter")
+		}
+
+		// 🚨 SECURITY: Partial results are never used, because missing sub-repository permissions
+		// would grant access to all paths of the repository.
+		subRepoPerms, err = subRepoProvider.FetchRepoSubRepoPerms(ctx, &extsvc.Repository{
+			URI:              repo.URI,
+			ExternalRepoSpec: repo.ExternalRepo,
+		})
+		if err != nil {
+			return errors.Wrap(err, "fetch repository sub-repository permissions")
+		}
+	}
+
 	pendingAccountIDsSet := make(map[string]struct{})
 	var userIDs map[string]int32 // Account ID -> User ID
 	if len(extAccountIDs) > 0 {
@@ -347,9 +412,25 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 
 	pendingAccountIDs := make([]string, 0, len(pendingAccountIDsSet))
 	for aid := range pendingAccountIDsSet {
+		// 🚨 SECURITY: Pending permissions have no path restrictions, so accounts with sub-repository
+		// permissions are left to user-centric syncing once they are bound to a user.
+		if _, ok := subRepoPerms[extsvc.AccountID(aid)]; ok {
+			continue
+		}
 		pendingAccountIDs = append(pendingAccountIDs, aid)
 	}
 
+	ps := make([]*authz.SubRepoPermissions, 0, len(subRepoPerms))
+	for aid, sp := range subRepoPerms {
+		uid, ok := userIDs[string(aid)]
+		if !ok {
+			continue
+		}
+		sp.UserID = uid
+		ps = append(ps, sp)
+	}
+	sort.Slice(ps, func(i, j int) bool { return ps[i].UserID < ps[j].UserID })
+
 	txs, err := s.permsStore.Transact(ctx)
 	if err != nil {
 		return errors.Wrap(err, "start transaction")
@@ -362,6 +443,14 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		AccountIDs:  pendingAccountIDs,
 	}
 
+	// Save sub-repository permissions in the same transaction, so that the repository is never
+	// readable without its path restrictions.
+	if syncSubRepoPerms {
+		if err = txs.SetRepoSubRepoPermissions(ctx, int32(repoID), ps); err != nil {
+			return errors.Wrap(err, "set repository sub-repository permissions")
+		}
+	}
+
 	if err = txs.SetRepoPermissions(ctx, p); err != nil {
 		return errors.Wrap(err, "set repository permissions")
 	} else if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
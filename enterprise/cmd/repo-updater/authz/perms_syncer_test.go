@@ -204,6 +204,113 @@ func TestPermsSyncer_syncUserPerms(t *testing.T) {
 	}
 }
 
+type mockSubRepoPermsProvider struct {
+	*mockProvider
+
+	fetchUserSubRepoPerms func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error)
+	fetchRepoSubRepoPerms func(context.Context, *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error)
+}
+
+func (p *mockSubRepoPermsProvider) FetchUserSubRepoPerms(ctx context.Context, acct *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+	return p.fetchUserSubRepoPerms(ctx, acct)
+}
+
+func (p *mockSubRepoPermsProvider) FetchRepoSubRepoPerms(ctx context.Context, repo *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+	return p.fetchRepoSubRepoPerms(ctx, repo)
+}
+
+func TestPermsSyncer_syncUserPerms_subRepoPerms(t *testing.T) {
+	p := &mockSubRepoPermsProvider{
+		mockProvider: &mockProvider{
+			serviceType: extsvc.TypePerforce,
+			serviceID:   "ssl:perforce.example.com:1666",
+			fetchUserPerms: func(context.Context, *extsvc.Account) ([]extsvc.RepoID, error) {
+				return []extsvc.RepoID{"//Engine/", "//Tools/"}, nil
+			},
+		},
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	extAccount := extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+		},
+	}
+
+	var calls []string
+	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+		return []*extsvc.Account{&extAccount}, nil
+	}
+	edb.Mocks.Perms.SetUserSubRepoPermissions = func(_ context.Context, userID int32, ps []*authz.SubRepoPermissions) error {
+		calls = append(calls, "SetUserSubRepoPermissions")
+
+		want := []*authz.SubRepoPermissions{{RepoID: 1, PathExcludes: []string{"Secret/**"}}}
+		if diff := cmp.Diff(want, ps); diff != "" {
+			return fmt.Errorf("sub-repo permissions mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	edb.Mocks.Perms.SetUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
+		calls = append(calls, "SetUserPermissions")
+
+		wantIDs := []uint32{1, 2}
+		if diff := cmp.Diff(wantIDs, p.IDs.ToArray()); diff != "" {
+			return fmt.Errorf("IDs mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	defer func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	reposStore := &mockReposStore{
+		listRepos: func(_ context.Context, args repos.StoreListReposArgs) ([]*repos.Repo, error) {
+			rs := make([]*repos.Repo, len(args.ExternalRepos))
+			for i, spec := range args.ExternalRepos {
+				rs[i] = &repos.Repo{ID: api.RepoID(i + 1), ExternalRepo: spec}
+			}
+			return rs, nil
+		},
+	}
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	permsStore := edb.NewPermsStore(nil, clock)
+	s := NewPermsSyncer(reposStore, permsStore, clock, nil)
+
+	t.Run("sync", func(t *testing.T) {
+		calls = nil
+		p.fetchUserSubRepoPerms = func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+			return map[extsvc.RepoID]*authz.SubRepoPermissions{
+				"//Engine/": {PathExcludes: []string{"Secret/**"}},
+			}, nil
+		}
+
+		if err := s.syncUserPerms(context.Background(), 1, false); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"SetUserSubRepoPermissions", "SetUserPermissions"}, calls); diff != "" {
+			t.Fatalf("calls mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("partial results are not used", func(t *testing.T) {
+		calls = nil
+		p.fetchUserSubRepoPerms = func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+			return nil, errors.New("random error")
+		}
+
+		if err := s.syncUserPerms(context.Background(), 1, true); err == nil {
+			t.Fatal("want error but got nil")
+		}
+		if len(calls) > 0 {
+			t.Fatalf("want no permissions saved but got calls %v", calls)
+		}
+	})
+}
+
 func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 	clock := func() time.Time {
 		return time.Now().UTC().Truncate(time.Microsecond)
@@ -407,6 +514,115 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 	}
 }
 
+func TestPermsSyncer_syncRepoPerms_subRepoPerms(t *testing.T) {
+	p := &mockSubRepoPermsProvider{
+		mockProvider: &mockProvider{
+			serviceType: extsvc.TypePerforce,
+			serviceID:   "ssl:perforce.example.com:1666",
+			fetchRepoPerms: func(context.Context, *extsvc.Repository) ([]extsvc.AccountID, error) {
+				return []extsvc.AccountID{"alice", "bob", "pending_alice", "pending_bob"}, nil
+			},
+		},
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	var calls []string
+	edb.Mocks.Perms.Transact = func(context.Context) (*edb.PermsStore, error) {
+		return &edb.PermsStore{}, nil
+	}
+	edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(context.Context, *extsvc.Accounts) (map[string]int32, error) {
+		return map[string]int32{"alice": 1, "bob": 2}, nil
+	}
+	edb.Mocks.Perms.SetRepoSubRepoPermissions = func(_ context.Context, repoID int32, ps []*authz.SubRepoPermissions) error {
+		calls = append(calls, "SetRepoSubRepoPermissions")
+
+		if repoID != 1 {
+			return fmt.Errorf("RepoID: want 1 but got %d", repoID)
+		}
+		want := []*authz.SubRepoPermissions{{UserID: 1, PathExcludes: []string{"Secret/**"}}}
+		if diff := cmp.Diff(want, ps); diff != "" {
+			return fmt.Errorf("sub-repo permissions mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+		calls = append(calls, "SetRepoPermissions")
+
+		wantUserIDs := []uint32{1, 2}
+		if diff := cmp.Diff(wantUserIDs, p.UserIDs.ToArray()); diff != "" {
+			return fmt.Errorf("UserIDs mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	edb.Mocks.Perms.SetRepoPendingPermissions = func(_ context.Context, accounts *extsvc.Accounts, _ *authz.RepoPermissions) error {
+		calls = append(calls, "SetRepoPendingPermissions")
+
+		// Pending accounts with path restrictions must not be granted the whole repository.
+		wantAccountIDs := []string{"pending_bob"}
+		if diff := cmp.Diff(wantAccountIDs, accounts.AccountIDs); diff != "" {
+			return fmt.Errorf("AccountIDs mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	defer func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	reposStore := &mockReposStore{
+		listRepos: func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error) {
+			return []*repos.Repo{
+				{
+					ID:      1,
+					Private: true,
+					ExternalRepo: api.ExternalRepoSpec{
+						ServiceID: p.ServiceID(),
+					},
+					Sources: map[string]*repos.SourceInfo{
+						p.URN(): {},
+					},
+				},
+			}, nil
+		},
+	}
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	s := NewPermsSyncer(reposStore, edb.NewPermsStore(nil, clock), clock, nil)
+
+	t.Run("sync", func(t *testing.T) {
+		calls = nil
+		p.fetchRepoSubRepoPerms = func(context.Context, *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+			return map[extsvc.AccountID]*authz.SubRepoPermissions{
+				"alice":         {PathExcludes: []string{"Secret/**"}},
+				"pending_alice": {PathExcludes: []string{"Secret/**"}},
+			}, nil
+		}
+
+		if err := s.syncRepoPerms(context.Background(), 1, false); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"SetRepoSubRepoPermissions", "SetRepoPermissions", "SetRepoPendingPermissions"}
+		if diff := cmp.Diff(want, calls); diff != "" {
+			t.Fatalf("calls mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("partial results are not used", func(t *testing.T) {
+		calls = nil
+		p.fetchRepoSubRepoPerms = func(context.Context, *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+			return nil, errors.New("random error")
+		}
+
+		if err := s.syncRepoPerms(context.Background(), 1, true); err == nil {
+			t.Fatal("want error but got nil")
+		}
+		if len(calls) > 0 {
+			t.Fatalf("want no permissions saved but got calls %v", calls)
+		}
+	})
+}
+
 func TestPermsSyncer_waitForRateLimit(t *testing.T) {
 	ctx := context.Background()
 	t.Run("no rate limit registry", func(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
@@ -17,13 +18,19 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/repo-updater/authz"
 	frontendAuthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/manager"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/resetter"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/server"
+	codeintelstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	ossAuthz "github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	ossDB "github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 )
@@ -56,6 +63,7 @@ func enterpriseInit(
 
 	sourcer := repos.NewSourcer(cf)
 	go campaigns.RunWorkers(ctx, campaignsStore, gitserver.DefaultClient, sourcer)
+	startCampaignExecutorQueue(ctx, campaignsStore)
 
 	// Set up expired spec deletion
 	go func() {
@@ -84,9 +92,67 @@ func enterpriseInit(
 		server.PermsSyncer = permsSyncer
 	}
 
+	// Request that index jobs be scheduled for a repository as soon as repo-updater
+	// observes new commits or tags. The precise-code-intel-indexer determines which
+	// commits (if any) should be indexed.
+	codeIntelStore := codeintelstore.NewWithDB(db)
+	repos.RepoChangedHook = func(ctx context.Context, repo api.RepoID) {
+		if err := codeIntelStore.InsertIndexRequest(ctx, int(repo), ""); err != nil {
+			log15.Error("Failed to request index update", "repo", repo, "error", err)
+		}
+	}
+
 	return debugDumpers
 }
 
+const (
+	// campaignExecutorQueueMaximumTransactions is the number of campaign spec
+	// executions that can be processed by campaign executors at once.
+	campaignExecutorQueueMaximumTransactions = 10
+
+	// campaignExecutorQueueRequeueDelay is the requeue delay of campaign spec
+	// executions assigned to an unreachable campaign executor.
+	campaignExecutorQueueRequeueDelay = time.Minute
+
+	// campaignExecutorQueueCleanupInterval is the interval between checks for
+	// unreachable campaign executors.
+	campaignExecutorQueueCleanupInterval = 10 * time.Second
+
+	// campaignExecutorQueueMaximumMissedHeartbeats is the number of heartbeats
+	// a campaign executor must miss to be considered unreachable.
+	campaignExecutorQueueMaximumMissedHeartbeats = 5
+
+	// campaignExecutorQueueResetInterval is how often stalled campaign spec
+	// executions are reset.
+	campaignExecutorQueueResetInterval = time.Minute
+)
+
+// startCampaignExecutorQueue starts the queue API that the campaign executor
+// uses to dequeue campaign spec executions. The campaign executor has no
+// access to the database: its requests are proxied to this queue by the
+// frontend, and the queue holds the transactions locking the executions
+// that are being processed.
+func startCampaignExecutorQueue(ctx context.Context, campaignsStore *campaigns.Store) {
+	workerStore := campaigns.NewCampaignSpecExecutionWorkerStore(campaignsStore)
+	deathThreshold := campaignExecutorQueueCleanupInterval * campaignExecutorQueueMaximumMissedHeartbeats
+
+	executionManager := manager.New(manager.NewStore(campaignsStore), workerStore, manager.ManagerOptions{
+		MaximumTransactions: campaignExecutorQueueMaximumTransactions,
+		RequeueDelay:        campaignExecutorQueueRequeueDelay,
+		UnreportedMaxAge:    deathThreshold,
+		DeathThreshold:      deathThreshold,
+	})
+
+	routines := []goroutine.BackgroundRoutine{
+		server.New(executionManager),
+		goroutine.NewPeriodicGoroutine(ctx, campaignExecutorQueueCleanupInterval, executionManager),
+		resetter.NewExecutionResetter(workerStore, campaignExecutorQueueResetInterval, resetter.NewResetterMetrics(prometheus.DefaultRegisterer)),
+	}
+	for _, routine := range routines {
+		go routine.Start()
+	}
+}
+
 // startBackgroundPermsSync sets up background permissions syncing.
 func startBackgroundPermsSync(ctx context.Context, syncer *authz.PermsSyncer) {
 	globals.WatchPermissionsUserMapping()
@@ -11,9 +11,13 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/authz/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/authz/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -51,6 +55,10 @@ func ProvidersFromConfig(
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindAzureDevOps,
+			extsvc.KindPerforce,
+			extsvc.KindGitolite,
+			extsvc.KindBitbucketCloud,
 		},
 		LimitOffset: &db.LimitOffset{
 			Limit: 500, // The number is randomly chosen
@@ -61,6 +69,10 @@ func ProvidersFromConfig(
 		gitHubConns          []*types.GitHubConnection
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
+		azureDevOpsConns     []*types.AzureDevOpsConnection
+		perforceConns        []*types.PerforceConnection
+		gitoliteConns        []*types.GitoliteConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -96,6 +108,26 @@ func ProvidersFromConfig(
 					URN:                       svc.URN(),
 					BitbucketServerConnection: c,
 				})
+			case *schema.AzureDevOpsConnection:
+				azureDevOpsConns = append(azureDevOpsConns, &types.AzureDevOpsConnection{
+					URN:                   svc.URN(),
+					AzureDevOpsConnection: c,
+				})
+			case *schema.PerforceConnection:
+				perforceConns = append(perforceConns, &types.PerforceConnection{
+					URN:                svc.URN(),
+					PerforceConnection: c,
+				})
+			case *schema.GitoliteConnection:
+				gitoliteConns = append(gitoliteConns, &types.GitoliteConnection{
+					URN:                svc.URN(),
+					GitoliteConnection: c,
+				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
 			default:
 				log15.Error("ProvidersFromConfig", "error", errors.Errorf("unexpected connection type: %T", cfg))
 				continue
@@ -129,6 +161,34 @@ func ProvidersFromConfig(
 		warnings = append(warnings, bbsWarnings...)
 	}
 
+	if len(azureDevOpsConns) > 0 {
+		adoProviders, adoProblems, adoWarnings := azuredevops.NewAuthzProviders(azureDevOpsConns)
+		providers = append(providers, adoProviders...)
+		seriousProblems = append(seriousProblems, adoProblems...)
+		warnings = append(warnings, adoWarnings...)
+	}
+
+	if len(perforceConns) > 0 {
+		p4Providers, p4Problems, p4Warnings := perforce.NewAuthzProviders(perforceConns)
+		providers = append(providers, p4Providers...)
+		seriousProblems = append(seriousProblems, p4Problems...)
+		warnings = append(warnings, p4Warnings...)
+	}
+
+	if len(gitoliteConns) > 0 {
+		gitoliteProviders, gitoliteProblems, gitoliteWarnings := gitolite.NewAuthzProviders(gitoliteConns)
+		providers = append(providers, gitoliteProviders...)
+		seriousProblems = append(seriousProblems, gitoliteProblems...)
+		warnings = append(warnings, gitoliteWarnings...)
+	}
+
+	if len(bitbucketCloudConns) > 0 {
+		bbcProviders, bbcProblems, bbcWarnings := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns)
+		providers = append(providers, bbcProviders...)
+		seriousProblems = append(seriousProblems, bbcProblems...)
+		warnings = append(warnings, bbcWarnings...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfiguration.PermissionsUserMapping != nil &&
 		cfg.SiteConfiguration.PermissionsUserMapping.Enabled && len(providers) > 0 {
@@ -0,0 +1,139 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// autoMergeState is the part of a changeset's state that decides whether it
+// can be merged according to an auto-merge policy.
+type autoMergeState struct {
+	externalUpdatedAt   time.Time
+	externalCheckState  campaigns.ChangesetCheckState
+	externalReviewState campaigns.ChangesetReviewState
+}
+
+func autoMergeStateOf(c *campaigns.Changeset) autoMergeState {
+	return autoMergeState{
+		externalUpdatedAt:   c.ExternalUpdatedAt,
+		externalCheckState:  c.ExternalCheckState,
+		externalReviewState: c.ExternalReviewState,
+	}
+}
+
+// Equal returns whether s and o describe the same state.
+func (s autoMergeState) Equal(o autoMergeState) bool {
+	return s.externalUpdatedAt.Equal(o.externalUpdatedAt) &&
+		s.externalCheckState == o.externalCheckState &&
+		s.externalReviewState == o.externalReviewState
+}
+
+// enqueueAutoMerge marks the given changeset to be merged by the reconciler,
+// if it is open and owned by a campaign with an auto-merge policy. The
+// reconciler then checks whether the changeset satisfies the policy.
+func enqueueAutoMerge(ctx context.Context, tx *Store, c *campaigns.Changeset) error {
+	if c.OwnedByCampaignID == 0 || c.Closing {
+		return nil
+	}
+
+	// Changesets that are being processed by the reconciler are synced by
+	// it, and the reconciler decides itself whether to merge them.
+	if c.ReconcilerState != campaigns.ReconcilerStateCompleted {
+		return nil
+	}
+
+	if c.PublicationState != campaigns.ChangesetPublicationStatePublished ||
+		c.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return nil
+	}
+
+	policy, err := loadAutoMergePolicy(ctx, tx, c.OwnedByCampaignID)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+
+	c.Merging = true
+	c.ResetQueued()
+	return nil
+}
+
+// loadAutoMergePolicy returns the auto-merge policy of the campaign spec
+// that's applied to the campaign with the given ID, or nil if it doesn't have
+// one.
+func loadAutoMergePolicy(ctx context.Context, tx *Store, campaignID int64) (*campaigns.ChangesetAutoMerge, error) {
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load campaign")
+	}
+
+	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: campaign.CampaignSpecID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load campaign spec")
+	}
+
+	return campaignSpec.Spec.ChangesetTemplate.AutoMerge, nil
+}
+
+// autoMergeBlocker returns why the given changeset can't be merged according
+// to the given policy. If it can be merged, it returns an empty string.
+func autoMergeBlocker(c *campaigns.Changeset, policy *campaigns.ChangesetAutoMerge) (string, error) {
+	if c.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return fmt.Sprintf("changeset is %s", strings.ToLower(string(c.ExternalState))), nil
+	}
+
+	if policy.ChecksRequired() && c.ExternalCheckState != campaigns.ChangesetCheckStatePassed {
+		state := c.ExternalCheckState
+		if state == "" {
+			state = campaigns.ChangesetCheckStateUnknown
+		}
+		return fmt.Sprintf("checks have not passed (%s)", strings.ToLower(string(state))), nil
+	}
+
+	if c.ExternalReviewState == campaigns.ChangesetReviewStateChangesRequested {
+		return "changes were requested", nil
+	}
+
+	events := ChangesetEvents(c.Events())
+	sort.Sort(events)
+
+	reviews, err := lastReviewsByAuthor(events)
+	if err != nil {
+		return "", err
+	}
+
+	var approvals int
+	for _, s := range reviews {
+		switch s {
+		case campaigns.ChangesetReviewStateApproved:
+			approvals++
+		case campaigns.ChangesetReviewStateChangesRequested:
+			return "changes were requested", nil
+		}
+	}
+
+	if approvals < policy.RequiredApprovals {
+		return fmt.Sprintf("approved by %d of %d required reviewers", approvals, policy.RequiredApprovals), nil
+	}
+
+	return "", nil
+}
+
+// autoMergeChangesetEvent returns the ChangesetEvent that records the given
+// outcome of applying the auto-merge policy to the changeset.
+func autoMergeChangesetEvent(c *campaigns.Changeset, e *campaigns.AutoMergeEvent) *campaigns.ChangesetEvent {
+	return &campaigns.ChangesetEvent{
+		ChangesetID: c.ID,
+		Kind:        campaigns.ChangesetEventKindFor(e),
+		Key:         e.Key(),
+		Metadata:    e,
+	}
+}
@@ -0,0 +1,125 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestAutoMergeBlocker(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	review := func(id int64, login, state string, minutes int) github.TimelineItem {
+		return github.TimelineItem{
+			Type: "PullRequestReview",
+			Item: &github.PullRequestReview{
+				DatabaseID: id,
+				Author:     github.Actor{Login: login},
+				State:      state,
+				UpdatedAt:  now.Add(time.Duration(minutes) * time.Minute),
+			},
+		}
+	}
+
+	changeset := func(checkState campaigns.ChangesetCheckState, items ...github.TimelineItem) *campaigns.Changeset {
+		return &campaigns.Changeset{
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalState:       campaigns.ChangesetExternalStateOpen,
+			ExternalCheckState:  checkState,
+			Metadata: &github.PullRequest{
+				CreatedAt:     now,
+				TimelineItems: items,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		changeset *campaigns.Changeset
+		policy    campaigns.ChangesetAutoMerge
+		want      string
+	}{
+		{
+			name:      "passed checks without required approvals",
+			changeset: changeset(campaigns.ChangesetCheckStatePassed),
+			want:      "",
+		},
+		{
+			name:      "pending checks",
+			changeset: changeset(campaigns.ChangesetCheckStatePending),
+			want:      "checks have not passed (pending)",
+		},
+		{
+			name:      "no checks",
+			changeset: changeset(""),
+			want:      "checks have not passed (unknown)",
+		},
+		{
+			name:      "checks not required",
+			changeset: changeset(campaigns.ChangesetCheckStateFailed),
+			policy:    campaigns.ChangesetAutoMerge{RequiredChecks: campaigns.ChangesetRequiredChecksNone},
+			want:      "",
+		},
+		{
+			name: "enough approvals",
+			changeset: changeset(campaigns.ChangesetCheckStatePassed,
+				review(1, "alice", "APPROVED", 1),
+				review(2, "bob", "APPROVED", 2),
+			),
+			policy: campaigns.ChangesetAutoMerge{RequiredApprovals: 2},
+			want:   "",
+		},
+		{
+			name: "approvals by the same reviewer",
+			changeset: changeset(campaigns.ChangesetCheckStatePassed,
+				review(1, "alice", "APPROVED", 1),
+				review(2, "alice", "APPROVED", 2),
+			),
+			policy: campaigns.ChangesetAutoMerge{RequiredApprovals: 2},
+			want:   "approved by 1 of 2 required reviewers",
+		},
+		{
+			name: "approval followed by request for changes",
+			changeset: changeset(campaigns.ChangesetCheckStatePassed,
+				review(1, "alice", "APPROVED", 1),
+				review(2, "bob", "APPROVED", 2),
+				review(3, "alice", "CHANGES_REQUESTED", 3),
+			),
+			policy: campaigns.ChangesetAutoMerge{RequiredApprovals: 1},
+			want:   "changes were requested",
+		},
+		{
+			name: "request for changes followed by approval",
+			changeset: changeset(campaigns.ChangesetCheckStatePassed,
+				review(1, "alice", "CHANGES_REQUESTED", 1),
+				review(2, "alice", "APPROVED", 2),
+			),
+			policy: campaigns.ChangesetAutoMerge{RequiredApprovals: 1},
+			want:   "",
+		},
+		{
+			name: "closed changeset",
+			changeset: func() *campaigns.Changeset {
+				c := changeset(campaigns.ChangesetCheckStatePassed)
+				c.ExternalState = campaigns.ChangesetExternalStateClosed
+				return c
+			}(),
+			want: "changeset is closed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := autoMergeBlocker(tc.changeset, &tc.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("wrong reason. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+}
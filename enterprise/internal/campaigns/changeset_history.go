@@ -104,51 +104,6 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 				pushStates(et)
 			}
 
-		case campaigns.ChangesetEventKindGitHubReviewed,
-			campaigns.ChangesetEventKindBitbucketServerApproved,
-			campaigns.ChangesetEventKindBitbucketServerReviewed,
-			campaigns.ChangesetEventKindGitLabApproved:
-
-			s, err := e.ReviewState()
-			if err != nil {
-				return nil, err
-			}
-
-			// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
-			if s != campaigns.ChangesetReviewStateApproved &&
-				s != campaigns.ChangesetReviewStateChangesRequested &&
-				s != campaigns.ChangesetReviewStateDismissed {
-				continue
-			}
-
-			author, err := e.ReviewAuthor()
-			if err != nil {
-				return nil, err
-			}
-			if author == "" {
-				continue
-			}
-
-			// Save current review state, then insert new review or delete
-			// dismissed review, then recompute overall review state
-			oldReviewState := currentReviewState
-
-			if s == campaigns.ChangesetReviewStateDismissed {
-				// In case of a dismissed review we dismiss _all_ of the
-				// previous reviews by the author, since that is what GitHub
-				// does in its UI.
-				delete(lastReviewByAuthor, author)
-			} else {
-				lastReviewByAuthor[author] = s
-			}
-
-			newReviewState := reduceReviewStates(lastReviewByAuthor)
-
-			if newReviewState != oldReviewState {
-				currentReviewState = newReviewState
-				pushStates(et)
-			}
-
 		case campaigns.ChangesetEventKindGitHubReviewDismissed:
 			// We specifically ignore ChangesetEventKindGitHubReviewDismissed
 			// events since GitHub updates the original
@@ -157,41 +112,19 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 			// See: https://github.com/sourcegraph/sourcegraph/pull/9461
 			continue
 
-		case campaigns.ChangesetEventKindBitbucketServerUnapproved,
-			campaigns.ChangesetEventKindBitbucketServerDismissed,
-			campaigns.ChangesetEventKindGitLabUnapproved:
-			author, err := e.ReviewAuthor()
+		default:
+			// Save current review state, then apply the review event and
+			// recompute overall review state
+			oldReviewState := currentReviewState
+
+			applied, err := applyReviewEvent(lastReviewByAuthor, e)
 			if err != nil {
 				return nil, err
 			}
-			if author == "" {
+			if !applied {
 				continue
 			}
 
-			if e.Type() == campaigns.ChangesetEventKindBitbucketServerUnapproved {
-				// A BitbucketServer Unapproved can only follow a previous Approved by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != campaigns.ChangesetReviewStateApproved {
-					log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
-					continue
-				}
-			}
-
-			if e.Type() == campaigns.ChangesetEventKindBitbucketServerDismissed {
-				// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
-				// the same author.
-				lastReview, ok := lastReviewByAuthor[author]
-				if !ok || lastReview != campaigns.ChangesetReviewStateChangesRequested {
-					log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
-					continue
-				}
-			}
-
-			// Save current review state, then remove last approval and
-			// recompute overall review state
-			oldReviewState := currentReviewState
-			delete(lastReviewByAuthor, author)
 			newReviewState := reduceReviewStates(lastReviewByAuthor)
 
 			if newReviewState != oldReviewState {
@@ -212,6 +145,117 @@ func computeHistory(ch *campaigns.Changeset, ce ChangesetEvents) (changesetHisto
 	return states, nil
 }
 
+// applyReviewEvent updates the given map of the last review per author with
+// the review, dismissal or unapproval recorded in the given ChangesetEvent.
+// It returns false if the event is not related to a review or doesn't change
+// the reviews.
+func applyReviewEvent(lastReviewByAuthor map[string]campaigns.ChangesetReviewState, e *campaigns.ChangesetEvent) (bool, error) {
+	switch e.Kind {
+	case campaigns.ChangesetEventKindGitHubReviewed,
+		campaigns.ChangesetEventKindBitbucketServerApproved,
+		campaigns.ChangesetEventKindBitbucketServerReviewed,
+		campaigns.ChangesetEventKindGitLabApproved,
+		campaigns.ChangesetEventKindGerritApproved,
+		campaigns.ChangesetEventKindGerritChangesRequested,
+		campaigns.ChangesetEventKindAzureDevOpsApproved,
+		campaigns.ChangesetEventKindAzureDevOpsChangesRequested,
+		campaigns.ChangesetEventKindBitbucketCloudApproved,
+		campaigns.ChangesetEventKindBitbucketCloudChangesRequested,
+		campaigns.ChangesetEventKindAWSCodeCommitApproved:
+
+		s, err := e.ReviewState()
+		if err != nil {
+			return false, err
+		}
+
+		// We only care about "Approved", "ChangesRequested" or "Dismissed" reviews
+		if s != campaigns.ChangesetReviewStateApproved &&
+			s != campaigns.ChangesetReviewStateChangesRequested &&
+			s != campaigns.ChangesetReviewStateDismissed {
+			return false, nil
+		}
+
+		author, err := e.ReviewAuthor()
+		if err != nil {
+			return false, err
+		}
+		if author == "" {
+			return false, nil
+		}
+
+		if s == campaigns.ChangesetReviewStateDismissed {
+			// In case of a dismissed review we dismiss _all_ of the
+			// previous reviews by the author, since that is what GitHub
+			// does in its UI.
+			delete(lastReviewByAuthor, author)
+		} else {
+			lastReviewByAuthor[author] = s
+		}
+		return true, nil
+
+	case campaigns.ChangesetEventKindBitbucketServerUnapproved,
+		campaigns.ChangesetEventKindBitbucketServerDismissed,
+		campaigns.ChangesetEventKindGitLabUnapproved,
+		campaigns.ChangesetEventKindGerritUnapproved,
+		campaigns.ChangesetEventKindAzureDevOpsVoteReset,
+		campaigns.ChangesetEventKindBitbucketCloudUnapproved,
+		campaigns.ChangesetEventKindAWSCodeCommitApprovalRevoked:
+		author, err := e.ReviewAuthor()
+		if err != nil {
+			return false, err
+		}
+		if author == "" {
+			return false, nil
+		}
+
+		if e.Type() == campaigns.ChangesetEventKindBitbucketServerUnapproved {
+			// A BitbucketServer Unapproved can only follow a previous Approved by
+			// the same author.
+			lastReview, ok := lastReviewByAuthor[author]
+			if !ok || lastReview != campaigns.ChangesetReviewStateApproved {
+				log15.Warn("Bitbucket Server Unapproval not following an Approval", "event", e)
+				return false, nil
+			}
+		}
+
+		if e.Type() == campaigns.ChangesetEventKindBitbucketServerDismissed {
+			// A BitbucketServer Dismissed event can only follow a previous "Changes Requested" review by
+			// the same author.
+			lastReview, ok := lastReviewByAuthor[author]
+			if !ok || lastReview != campaigns.ChangesetReviewStateChangesRequested {
+				log15.Warn("Bitbucket Server Dismissal not following a Review", "event", e)
+				return false, nil
+			}
+		}
+
+		delete(lastReviewByAuthor, author)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// lastReviewsByAuthor returns the last review state of every author that
+// reviewed the changeset, as recorded in the given ChangesetEvents.
+// The ChangesetEvents MUST be sorted by their Timestamp.
+func lastReviewsByAuthor(ce ChangesetEvents) (map[string]campaigns.ChangesetReviewState, error) {
+	if !sort.IsSorted(ce) {
+		return nil, errors.New("changeset events not sorted")
+	}
+
+	lastReviewByAuthor := map[string]campaigns.ChangesetReviewState{}
+	for _, e := range ce {
+		if e.Kind == campaigns.ChangesetEventKindGitHubReviewDismissed {
+			// See computeHistory for why these are ignored.
+			continue
+		}
+		if _, err := applyReviewEvent(lastReviewByAuthor, e); err != nil {
+			return nil, err
+		}
+	}
+	return lastReviewByAuthor, nil
+}
+
 // reduceReviewStates reduces the given a map of review per author down to a
 // single overall ChangesetReviewState.
 func reduceReviewStates(statesByAuthor map[string]campaigns.ChangesetReviewState) campaigns.ChangesetReviewState {
@@ -29,6 +29,7 @@ func TestIntegration(t *testing.T) {
 		t.Run("ListChangesetSyncData", storeTest(db, testStoreListChangesetSyncData))
 		t.Run("CampaignSpecs", storeTest(db, testStoreCampaignSpecs))
 		t.Run("ChangesetSpecs", storeTest(db, testStoreChangesetSpecs))
+		t.Run("CampaignSpecExecutions", storeTest(db, testStoreCampaignSpecExecutions))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
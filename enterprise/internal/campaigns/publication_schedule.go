@@ -0,0 +1,86 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// maxOpenRecheckInterval is how long a changeset that can't be published,
+// because its campaign already has the maximum number of open changesets,
+// waits before the reconciler checks again whether it can be published.
+const maxOpenRecheckInterval = 15 * time.Minute
+
+// schedulePublication checks whether the given changeset can be published now
+// according to the given publication schedule of the campaign that owns it.
+//
+// Every changeset of the campaign is assigned a slot, at least
+// schedule.Interval() after the slot of the changeset before it and inside of
+// one of the schedule's windows, at which it will be published. The slot is
+// stored in the changeset's PublicationSlot, and the changeset's ProcessAfter
+// is set to it, so that the changeset isn't dequeued before its slot.
+//
+// If the changeset can't be published now, schedulePublication requeues it
+// and returns true.
+func schedulePublication(ctx context.Context, tx *Store, ch *campaigns.Changeset, schedule *campaigns.PublicationSchedule) (bool, error) {
+	// Lock the campaign, so that no two of its changesets are assigned the
+	// same slot.
+	if err := tx.LockCampaign(ctx, ch.OwnedByCampaignID); err != nil {
+		return false, errors.Wrap(err, "failed to lock campaign")
+	}
+
+	now := tx.Clock()()
+
+	slot := ch.PublicationSlot
+	if slot.IsZero() {
+		latest, err := tx.LatestPublicationSlot(ctx, ch.OwnedByCampaignID)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to load latest publication slot")
+		}
+
+		slot = now
+		if !latest.IsZero() {
+			if next := latest.Add(schedule.Interval()); next.After(slot) {
+				slot = next
+			}
+		}
+	}
+
+	// The windows might have changed since the slot was assigned.
+	slot = schedule.NextOpenAt(slot)
+	ch.PublicationSlot = slot
+	if slot.After(now) {
+		return true, requeuePublication(ctx, tx, ch, slot)
+	}
+
+	if schedule.MaxOpen > 0 {
+		openState := campaigns.ChangesetExternalStateOpen
+		open, err := tx.CountChangesets(ctx, CountChangesetsOpts{
+			OwnedByCampaignID: ch.OwnedByCampaignID,
+			ExternalState:     &openState,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to count open changesets")
+		}
+
+		if open >= schedule.MaxOpen {
+			return true, requeuePublication(ctx, tx, ch, schedule.NextOpenAt(now.Add(maxOpenRecheckInterval)))
+		}
+	}
+
+	// Keep the time at which the changeset was published, so that the next
+	// changeset's slot is computed from it.
+	ch.PublicationSlot = now
+	return false, nil
+}
+
+// requeuePublication enqueues the given changeset to be processed by the
+// reconciler again at the given time.
+func requeuePublication(ctx context.Context, tx *Store, ch *campaigns.Changeset, at time.Time) error {
+	ch.ReconcilerState = campaigns.ReconcilerStateQueued
+	ch.ProcessAfter = at
+	ch.FailureMessage = nil
+	return tx.UpdateChangeset(ctx, ch)
+}
@@ -0,0 +1,272 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// Client is the interface to the campaign executor queue.
+type Client interface {
+	// Dequeue returns a queued campaign spec execution for processing. This job can be marked as
+	// completed or failed by calling Complete with the same identifier. While processing, the
+	// identifier of the job must appear in all heartbeat requests.
+	Dequeue(ctx context.Context) (job types.Job, _ bool, _ error)
+
+	// SetLogContents updates a currently processing execution with the given log contents.
+	SetLogContents(ctx context.Context, executionID int, contents string) error
+
+	// SetDiff attaches a changeset spec with the given diff to the campaign spec of a currently
+	// processing execution.
+	SetDiff(ctx context.Context, executionID int, diff string) error
+
+	// Complete marks the target execution as complete or errored depending on the existence of an
+	// error message.
+	Complete(ctx context.Context, executionID int, executionErr error) error
+
+	// Heartbeat hints to the queue that the executor has not been lost and should not release any
+	// of the executions assigned to the executor.
+	Heartbeat(ctx context.Context, executionIDs []int) error
+}
+
+type client struct {
+	executorName string
+	frontendURL  string
+	authToken    string
+	httpClient   *http.Client
+	userAgent    string
+}
+
+var _ Client = &client{}
+
+var requestMeter = metrics.NewRequestMeter("campaign_executor_queue", "Total number of requests sent to the campaign executor queue.")
+
+// defaultTransport is the default transport for campaign executor queue clients.
+// ot.Transport will propagate opentracing spans.
+var defaultTransport = &ot.Transport{
+	RoundTripper: requestMeter.Transport(&http.Transport{}, func(u *url.URL) string {
+		// Extract the operation from a path like `/.internal-campaigns/queue/{operation}`
+		if segments := strings.Split(u.Path, "/"); len(segments) == 4 {
+			return segments[3]
+		}
+
+		return ""
+	}),
+}
+
+// NewClient creates a new Client with the given unique name targeting the given external frontend API.
+func NewClient(executorName, frontendURL, authToken string) Client {
+	return &client{
+		executorName: executorName,
+		httpClient:   &http.Client{Transport: defaultTransport},
+		frontendURL:  frontendURL,
+		authToken:    authToken,
+		userAgent:    filepath.Base(os.Args[0]),
+	}
+}
+
+// Dequeue returns a queued campaign spec execution for processing. This job can be marked as
+// completed or failed by calling Complete with the same identifier. While processing, the
+// identifier of the job must appear in all heartbeat requests.
+func (c *client) Dequeue(ctx context.Context) (job types.Job, _ bool, _ error) {
+	url, err := makeQueueURL(c.frontendURL, c.authToken, "dequeue")
+	if err != nil {
+		return types.Job{}, false, err
+	}
+
+	payload, err := marshalPayload(types.DequeueRequest{
+		ExecutorName: c.executorName,
+	})
+	if err != nil {
+		return types.Job{}, false, err
+	}
+
+	hasContent, body, err := c.do(ctx, "POST", url, payload)
+	if err != nil {
+		return types.Job{}, false, err
+	}
+	if !hasContent {
+		return types.Job{}, false, nil
+	}
+	defer body.Close()
+
+	if err := json.NewDecoder(body).Decode(&job); err != nil {
+		return types.Job{}, false, err
+	}
+
+	return job, true, nil
+}
+
+// SetLogContents updates a currently processing execution with the given log contents.
+func (c *client) SetLogContents(ctx context.Context, executionID int, contents string) error {
+	url, err := makeQueueURL(c.frontendURL, c.authToken, "setlog")
+	if err != nil {
+		return err
+	}
+
+	payload, err := marshalPayload(types.SetLogRequest{
+		ExecutorName: c.executorName,
+		ExecutionID:  executionID,
+		Contents:     contents,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.doAndDrop(ctx, "POST", url, payload)
+}
+
+// SetDiff attaches a changeset spec with the given diff to the campaign spec of a currently
+// processing execution.
+func (c *client) SetDiff(ctx context.Context, executionID int, diff string) error {
+	url, err := makeQueueURL(c.frontendURL, c.authToken, "setdiff")
+	if err != nil {
+		return err
+	}
+
+	payload, err := marshalPayload(types.SetDiffRequest{
+		ExecutorName: c.executorName,
+		ExecutionID:  executionID,
+		Diff:         diff,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.doAndDrop(ctx, "POST", url, payload)
+}
+
+// Complete marks the target execution as complete or errored depending on the existence of an
+// error message.
+func (c *client) Complete(ctx context.Context, executionID int, executionErr error) error {
+	url, err := makeQueueURL(c.frontendURL, c.authToken, "complete")
+	if err != nil {
+		return err
+	}
+
+	rawPayload := types.CompleteRequest{
+		ExecutorName: c.executorName,
+		ExecutionID:  executionID,
+	}
+	if executionErr != nil {
+		rawPayload.ErrorMessage = executionErr.Error()
+	}
+
+	payload, err := marshalPayload(rawPayload)
+	if err != nil {
+		return err
+	}
+
+	return c.doAndDrop(ctx, "POST", url, payload)
+}
+
+// Heartbeat hints to the queue that the executor has not been lost and should not release any
+// of the executions assigned to the executor.
+func (c *client) Heartbeat(ctx context.Context, executionIDs []int) error {
+	url, err := makeQueueURL(c.frontendURL, c.authToken, "heartbeat")
+	if err != nil {
+		return err
+	}
+
+	payload, err := marshalPayload(types.HeartbeatRequest{
+		ExecutorName: c.executorName,
+		ExecutionIDs: executionIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.doAndDrop(ctx, "POST", url, payload)
+}
+
+// doAndDrop performs an HTTP request to the frontend and ignores the body contents.
+func (c *client) doAndDrop(ctx context.Context, method string, url *url.URL, payload io.Reader) error {
+	hasContent, body, err := c.do(ctx, method, url, payload)
+	if err != nil {
+		return err
+	}
+	if hasContent {
+		body.Close()
+	}
+	return nil
+}
+
+// do performs an HTTP request to the frontend and returns the body content as a reader.
+func (c *client) do(ctx context.Context, method string, url *url.URL, body io.Reader) (hasContent bool, _ io.ReadCloser, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "do")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	req, err := http.NewRequest(method, url.String(), body)
+	if err != nil {
+		return false, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	req = req.WithContext(ctx)
+
+	req, ht := nethttp.TraceRequest(
+		span.Tracer(),
+		req,
+		nethttp.OperationName("Campaign Executor Queue Client"),
+		nethttp.ClientTrace(false),
+	)
+	defer ht.Finish()
+
+	resp, err := ctxhttp.Do(req.Context(), c.httpClient, req)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNoContent {
+			return false, nil, nil
+		}
+
+		return false, nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return true, resp.Body, nil
+}
+
+func makeQueueURL(baseURL, authToken, op string) (*url.URL, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	base.User = url.UserPassword("executor", authToken)
+
+	return base.ResolveReference(&url.URL{Path: path.Join(".internal-campaigns", "queue", op)}), nil
+}
+
+func marshalPayload(payload interface{}) (io.Reader, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(content), nil
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestDequeue(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkRequest(t, r, "/.internal-campaigns/queue/dequeue")
+		comparePayload(t, r.Body, []byte(`{
+			"executorName": "deadbeef"
+		}`))
+		w.Write([]byte(`{
+			"id": 42,
+			"repoName": "github.com/sourcegraph/sourcegraph",
+			"commit": "deadbeef",
+			"steps": [{"run": "echo hello", "container": "alpine:3", "env": {"A": "1"}}]
+		}`))
+	}))
+	defer ts.Close()
+
+	job, dequeued, err := testClient(ts.URL).Dequeue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if !dequeued {
+		t.Fatalf("expected record to be dequeued")
+	}
+
+	expectedJob := types.Job{
+		ID:       42,
+		RepoName: "github.com/sourcegraph/sourcegraph",
+		Commit:   "deadbeef",
+		Steps: []campaigns.CampaignSpecStep{
+			{Run: "echo hello", Container: "alpine:3", Env: map[string]string{"A": "1"}},
+		},
+	}
+	if diff := cmp.Diff(expectedJob, job); diff != "" {
+		t.Errorf("unexpected job (-want +got):\n%s", diff)
+	}
+}
+
+func TestDequeueNoRecord(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	_, dequeued, err := testClient(ts.URL).Dequeue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if dequeued {
+		t.Fatalf("unexpected record")
+	}
+}
+
+func TestSetLogContents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkRequest(t, r, "/.internal-campaigns/queue/setlog")
+		comparePayload(t, r.Body, []byte(`{
+			"executorName": "deadbeef",
+			"executionId": 42,
+			"payload": "test payload"
+		}`))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts.URL).SetLogContents(context.Background(), 42, "test payload"); err != nil {
+		t.Fatalf("unexpected error setting log contents: %s", err)
+	}
+}
+
+func TestSetDiff(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkRequest(t, r, "/.internal-campaigns/queue/setdiff")
+		comparePayload(t, r.Body, []byte(`{
+			"executorName": "deadbeef",
+			"executionId": 42,
+			"diff": "diff --git README.md README.md"
+		}`))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts.URL).SetDiff(context.Background(), 42, "diff --git README.md README.md"); err != nil {
+		t.Fatalf("unexpected error setting diff: %s", err)
+	}
+}
+
+func TestSetDiffBadResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts.URL).SetDiff(context.Background(), 42, "diff --git README.md README.md"); err == nil {
+		t.Fatalf("unexpected nil error setting diff")
+	}
+}
+
+func TestCompleteError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkRequest(t, r, "/.internal-campaigns/queue/complete")
+		comparePayload(t, r.Body, []byte(`{
+			"executorName": "deadbeef",
+			"executionId": 42,
+			"errorMessage": "oops"
+		}`))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts.URL).Complete(context.Background(), 42, fmt.Errorf("oops")); err != nil {
+		t.Fatalf("unexpected error marking record complete: %s", err)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checkRequest(t, r, "/.internal-campaigns/queue/heartbeat")
+		comparePayload(t, r.Body, []byte(`{
+			"executorName": "deadbeef",
+			"executionIds": [1, 2, 3]
+		}`))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	if err := testClient(ts.URL).Heartbeat(context.Background(), []int{1, 2, 3}); err != nil {
+		t.Fatalf("unexpected error performing heartbeat: %s", err)
+	}
+}
+
+func testClient(frontendURL string) *client {
+	return &client{
+		frontendURL:  frontendURL,
+		executorName: "deadbeef",
+		authToken:    "hunter2",
+	}
+}
+
+func checkRequest(t *testing.T, r *http.Request, expectedPath string) {
+	if r.Method != "POST" {
+		t.Errorf("unexpected method. want=%s have=%s", "POST", r.Method)
+	}
+	if r.URL.Path != expectedPath {
+		t.Errorf("unexpected path. want=%s have=%s", expectedPath, r.URL.Path)
+	}
+	if _, password, _ := r.BasicAuth(); password != "hunter2" {
+		t.Errorf("unexpected password. want=%s have=%s", "hunter2", password)
+	}
+}
+
+func comparePayload(t *testing.T, raw io.Reader, expected []byte) {
+	content, err := ioutil.ReadAll(raw)
+	if err != nil {
+		t.Fatalf("unexpected error reading payload: %s", err)
+	}
+
+	if diff := cmp.Diff(normalize(expected), normalize(content)); diff != "" {
+		t.Errorf("unexpected request payload (-want +got):\n%s", diff)
+	}
+}
+
+func normalize(v []byte) string {
+	temp := map[string]interface{}{}
+	_ = json.Unmarshal(v, &temp)
+	v, _ = json.Marshal(temp)
+	return string(v)
+}
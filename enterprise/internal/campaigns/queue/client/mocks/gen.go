@@ -0,0 +1,4 @@
+package mocks
+
+//go:generate env GOBIN=$PWD/.bin GO111MODULE=on go install github.com/efritz/go-mockgen
+//go:generate $PWD/.bin/go-mockgen -f github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/client -i Client -o mock_client.go
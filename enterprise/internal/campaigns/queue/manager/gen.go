@@ -0,0 +1,4 @@
+package manager
+
+//go:generate env GOBIN=$PWD/.bin GO111MODULE=on go install github.com/efritz/go-mockgen
+//go:generate $PWD/.bin/go-mockgen -f github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/manager -i Store -o mock_store_test.go
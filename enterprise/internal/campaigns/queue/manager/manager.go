@@ -0,0 +1,360 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/efritz/glock"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// Manager tracks which campaign spec executions are assigned to which executors.
+type Manager interface {
+	// Dequeue pulls an unprocessed campaign spec execution from the database and assigns the
+	// transaction that locks that record to the given executor.
+	Dequeue(ctx context.Context, executorName string) (types.Job, bool, error)
+
+	// SetLogContents updates a currently processing execution with the given log contents.
+	SetLogContents(ctx context.Context, executorName string, executionID int, contents string) error
+
+	// SetDiff attaches a changeset spec with the given diff to the campaign spec of a currently
+	// processing execution.
+	SetDiff(ctx context.Context, executorName string, executionID int, diff string) (bool, error)
+
+	// Complete marks the target execution as complete or errored depending on the existence of
+	// an error message, then finalizes the transaction that locks that record.
+	Complete(ctx context.Context, executorName string, executionID int, errorMessage string) (bool, error)
+
+	// Heartbeat bumps the last updated time of the executor and closes any transactions locking
+	// records whose identifiers were not supplied.
+	Heartbeat(ctx context.Context, executorName string, executionIDs []int) error
+}
+
+type ManagerOptions struct {
+	// MaximumTransactions is the maximum number of active records that can be given out to executors.
+	// The manager dequeue method will stop returning records while the number of outstanding
+	// transactions is at this threshold.
+	MaximumTransactions int
+
+	// RequeueDelay controls how far into the future to make an executor's records visible to another
+	// agent once it becomes unresponsive.
+	RequeueDelay time.Duration
+
+	// UnreportedMaxAge is the maximum time between a record being dequeued and it appearing in the
+	// executor's heartbeat requests before it being considered lost.
+	UnreportedMaxAge time.Duration
+
+	// DeathThreshold is the minimum time since the last executor heartbeat before the executor can be
+	// considered as unresponsive. This should be configured to be longer than the executor's heartbeat
+	// interval.
+	DeathThreshold time.Duration
+}
+
+// ManagerWithHandler combines a manager with a goroutine handler. This allows the manager's period
+// cleanup process to be wrapped in a periodic routine.
+type ManagerWithHandler interface {
+	Manager
+	goroutine.Handler
+}
+
+type manager struct {
+	store            Store
+	workerStore      dbworkerstore.Store
+	options          ManagerOptions
+	clock            glock.Clock
+	executors        map[string]*executorMeta
+	dequeueSemaphore chan struct{} // tracks available dequeue slots
+	m                sync.Mutex    // protects executors
+}
+
+var _ ManagerWithHandler = &manager{}
+
+// executorMeta tracks the last request time of an executor along with the set of executions which
+// it is currently processing.
+type executorMeta struct {
+	lastUpdate time.Time
+	metas      []executionMeta
+}
+
+// executionMeta wraps a campaign spec execution and the transaction that is currently locking it
+// for processing.
+type executionMeta struct {
+	execution *campaigns.CampaignSpecExecution
+	tx        dbworkerstore.Store
+	started   time.Time
+}
+
+// New creates a new manager with the given store and options.
+func New(store Store, workerStore dbworkerstore.Store, options ManagerOptions) ManagerWithHandler {
+	return newManager(store, workerStore, options, glock.NewRealClock())
+}
+
+func newManager(store Store, workerStore dbworkerstore.Store, options ManagerOptions, clock glock.Clock) ManagerWithHandler {
+	dequeueSemaphore := make(chan struct{}, options.MaximumTransactions)
+	for i := 0; i < options.MaximumTransactions; i++ {
+		dequeueSemaphore <- struct{}{}
+	}
+
+	return &manager{
+		store:            store,
+		workerStore:      workerStore,
+		options:          options,
+		clock:            clock,
+		dequeueSemaphore: dequeueSemaphore,
+		executors:        map[string]*executorMeta{},
+	}
+}
+
+// Handle requeues every locked execution assigned to executors which have not been updated for
+// longer than the death threshold.
+func (m *manager) Handle(ctx context.Context) error {
+	return m.requeueExecutions(ctx, m.pruneExecutors())
+}
+
+func (m *manager) HandleError(err error) {
+	log15.Error("Failed to requeue campaign spec executions", "err", err)
+}
+
+func (m *manager) OnShutdown() {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	shutdownErr := errors.New("service shutting down")
+
+	for _, executor := range m.executors {
+		for _, meta := range executor.metas {
+			if err := meta.tx.Done(shutdownErr); err != nil && err != shutdownErr {
+				log15.Error(fmt.Sprintf("Failed to close transaction holding campaign spec execution %d", meta.execution.ID), "err", err)
+			}
+		}
+	}
+}
+
+// pruneExecutors removes the data associated with executors which have not been updated for
+// longer than the death threshold and returns all execution meta values assigned to removed
+// executors.
+func (m *manager) pruneExecutors() (metas []executionMeta) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	for name, executor := range m.executors {
+		if m.clock.Now().Sub(executor.lastUpdate) <= m.options.DeathThreshold {
+			continue
+		}
+
+		metas = append(metas, executor.metas...)
+		delete(m.executors, name)
+	}
+
+	return metas
+}
+
+// Dequeue pulls an unprocessed campaign spec execution from the database and assigns the
+// transaction that locks that record to the given executor.
+func (m *manager) Dequeue(ctx context.Context, executorName string) (_ types.Job, dequeued bool, _ error) {
+	select {
+	case <-m.dequeueSemaphore:
+	default:
+		return types.Job{}, false, nil
+	}
+	defer func() {
+		if !dequeued {
+			// Ensure that if we do not dequeue a record successfully we do not
+			// leak from the semaphore. This will happen if the dequeue call fails
+			// or if there are no records to process
+			m.dequeueSemaphore <- struct{}{}
+		}
+	}()
+
+	record, tx, dequeued, err := m.workerStore.DequeueWithIndependentTransactionContext(ctx, nil)
+	if err != nil {
+		return types.Job{}, false, err
+	}
+	if !dequeued {
+		return types.Job{}, false, nil
+	}
+
+	execution := record.(*campaigns.CampaignSpecExecution)
+
+	steps, err := m.store.GetSteps(ctx, tx, execution)
+	if err != nil {
+		return types.Job{}, false, tx.Done(err)
+	}
+
+	m.addMeta(executorName, executionMeta{execution: execution, tx: tx, started: m.clock.Now()})
+
+	return types.Job{
+		ID:       int(execution.ID),
+		RepoName: string(execution.RepoName),
+		Commit:   execution.BaseRev,
+		Steps:    steps,
+	}, true, nil
+}
+
+// addMeta adds the given execution to the given executor. This method also updates the last
+// updated time of the executor.
+func (m *manager) addMeta(executorName string, meta executionMeta) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	executor, ok := m.executors[executorName]
+	if !ok {
+		executor = &executorMeta{}
+		m.executors[executorName] = executor
+	}
+
+	executor.metas = append(executor.metas, meta)
+	executor.lastUpdate = m.clock.Now()
+}
+
+// SetLogContents updates a currently processing execution with the given log contents.
+func (m *manager) SetLogContents(ctx context.Context, executorName string, executionID int, contents string) error {
+	meta, ok := m.findMeta(executorName, executionID, false)
+	if !ok {
+		return nil
+	}
+
+	// We're holding the execution in a transaction, so if we want to modify that record we
+	// need to do it in the same transaction.
+	return m.store.SetLogContents(ctx, meta.tx, executionID, contents)
+}
+
+// SetDiff attaches a changeset spec with the given diff to the campaign spec of a currently
+// processing execution.
+func (m *manager) SetDiff(ctx context.Context, executorName string, executionID int, diff string) (bool, error) {
+	meta, ok := m.findMeta(executorName, executionID, false)
+	if !ok {
+		return false, nil
+	}
+
+	if err := m.store.SetDiff(ctx, meta.tx, meta.execution, diff); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Complete marks the target execution as complete or errored depending on the existence of
+// an error message, then finalizes the transaction that locks that record.
+func (m *manager) Complete(ctx context.Context, executorName string, executionID int, errorMessage string) (bool, error) {
+	meta, ok := m.findMeta(executorName, executionID, true)
+	if !ok {
+		return false, nil
+	}
+
+	if err := m.completeExecution(ctx, meta, errorMessage); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// findMeta finds and returns an execution meta value matching the given execution identifier. If
+// remove is true and the meta value is found, it is removed from the manager.
+func (m *manager) findMeta(executorName string, executionID int, remove bool) (executionMeta, bool) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	executor, ok := m.executors[executorName]
+	if !ok {
+		return executionMeta{}, false
+	}
+
+	for i, meta := range executor.metas {
+		if meta.execution.RecordID() == executionID {
+			if remove {
+				l := len(executor.metas) - 1
+				executor.metas[i] = executor.metas[l]
+				executor.metas = executor.metas[:l]
+			}
+
+			return meta, true
+		}
+	}
+
+	return executionMeta{}, false
+}
+
+// completeExecution marks the target execution as complete or errored depending on the existence
+// of an error message, then finalizes the transaction that locks that record.
+func (m *manager) completeExecution(ctx context.Context, meta executionMeta, errorMessage string) (err error) {
+	defer func() { m.dequeueSemaphore <- struct{}{} }()
+
+	if errorMessage == "" {
+		_, err = meta.tx.MarkComplete(ctx, meta.execution.RecordID())
+	} else {
+		_, err = meta.tx.MarkErrored(ctx, meta.execution.RecordID(), errorMessage)
+	}
+
+	return meta.tx.Done(err)
+}
+
+// Heartbeat bumps the last updated time of the executor and closes any transactions locking
+// records whose identifiers were not supplied.
+func (m *manager) Heartbeat(ctx context.Context, executorName string, executionIDs []int) error {
+	return m.requeueExecutions(ctx, m.pruneExecutions(executorName, executionIDs))
+}
+
+// pruneExecutions removes the executions whose identifier is not in the given list from the given
+// executor. This method returns the execution meta values which were removed. Execution meta values
+// which were created very recently will be counted as live to account for the time between when the
+// record is dequeued in this service and when it is added to the heartbeat requests from the executor.
+// This method also updates the last updated time of the executor.
+func (m *manager) pruneExecutions(executorName string, ids []int) (dead []executionMeta) {
+	now := m.clock.Now()
+
+	idMap := map[int]struct{}{}
+	for _, id := range ids {
+		idMap[id] = struct{}{}
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	executor, ok := m.executors[executorName]
+	if !ok {
+		executor = &executorMeta{}
+		m.executors[executorName] = executor
+	}
+
+	var live []executionMeta
+	for _, meta := range executor.metas {
+		if _, ok := idMap[meta.execution.RecordID()]; ok || now.Sub(meta.started) < m.options.UnreportedMaxAge {
+			live = append(live, meta)
+		} else {
+			dead = append(dead, meta)
+		}
+	}
+
+	executor.metas = live
+	executor.lastUpdate = now
+	return dead
+}
+
+// requeueExecutions requeues the given executions.
+func (m *manager) requeueExecutions(ctx context.Context, metas []executionMeta) (errs error) {
+	for _, meta := range metas {
+		if err := m.requeueExecution(ctx, meta); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// requeueExecution requeues the given execution, then finalizes the transaction that locks that
+// record.
+func (m *manager) requeueExecution(ctx context.Context, meta executionMeta) error {
+	defer func() { m.dequeueSemaphore <- struct{}{} }()
+
+	err := meta.tx.Requeue(ctx, meta.execution.RecordID(), m.clock.Now().Add(m.options.RequeueDelay))
+	return meta.tx.Done(err)
+}
@@ -0,0 +1,300 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/efritz/glock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	workerstoremocks "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store/mocks"
+)
+
+func TestProcessSuccess(t *testing.T) {
+	execution := &campaigns.CampaignSpecExecution{
+		ID:             42,
+		CampaignSpecID: 7,
+		RepoName:       "github.com/sourcegraph/sourcegraph",
+		BaseRev:        "deadbeef",
+	}
+	steps := []campaigns.CampaignSpecStep{{Run: "echo hello", Container: "alpine:3"}}
+
+	mockStore := NewMockStore()
+	mockStore.GetStepsFunc.SetDefaultReturn(steps, nil)
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.PushReturn(execution, mockWorkerStore, true, nil)
+	mockWorkerStore.MarkCompleteFunc.SetDefaultReturn(true, nil)
+	clock := glock.NewMockClock()
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 10,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	job, dequeued, err := manager.Dequeue(context.Background(), "deadbeef")
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if !dequeued {
+		t.Fatalf("expected a record")
+	}
+
+	expectedJob := types.Job{
+		ID:       42,
+		RepoName: "github.com/sourcegraph/sourcegraph",
+		Commit:   "deadbeef",
+		Steps:    steps,
+	}
+	if diff := cmp.Diff(expectedJob, job); diff != "" {
+		t.Errorf("unexpected job (-want +got):\n%s", diff)
+	}
+
+	found, err := manager.SetDiff(context.Background(), "deadbeef", 42, "diff --git README.md README.md")
+	if err != nil {
+		t.Fatalf("unexpected error setting diff: %s", err)
+	}
+	if !found {
+		t.Fatalf("expected record to be tracked")
+	}
+
+	if callCount := len(mockStore.SetDiffFunc.History()); callCount != 1 {
+		t.Errorf("unexpected set diff call count. want=%d have=%d", 1, callCount)
+	} else if call := mockStore.SetDiffFunc.History()[0]; call.Arg2 != execution || call.Arg3 != "diff --git README.md README.md" {
+		t.Errorf("unexpected set diff arguments. have=%v %q", call.Arg2, call.Arg3)
+	}
+
+	found, err = manager.Complete(context.Background(), "deadbeef", 42, "")
+	if err != nil {
+		t.Fatalf("unexpected error marking record as complete: %s", err)
+	}
+	if !found {
+		t.Fatalf("expected record to be tracked")
+	}
+
+	if callCount := len(mockWorkerStore.MarkCompleteFunc.History()); callCount != 1 {
+		t.Errorf("unexpected mark complete call count. want=%d have=%d", 1, callCount)
+	} else if id := mockWorkerStore.MarkCompleteFunc.History()[0].Arg1; id != 42 {
+		t.Errorf("unexpected id argument to mark complete. want=%v have=%v", 42, id)
+	}
+
+	if callCount := len(mockWorkerStore.DoneFunc.History()); callCount != 1 {
+		t.Errorf("unexpected done call count. want=%d have=%d", 1, callCount)
+	} else if err := mockWorkerStore.DoneFunc.History()[0].Arg0; err != nil {
+		t.Errorf("unexpected error argument to done. want=%v have=%v", nil, err)
+	}
+}
+
+func TestProcessFailure(t *testing.T) {
+	mockStore := NewMockStore()
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.PushReturn(&campaigns.CampaignSpecExecution{ID: 42}, mockWorkerStore, true, nil)
+	mockWorkerStore.MarkErroredFunc.SetDefaultReturn(true, nil)
+	clock := glock.NewMockClock()
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 10,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	if _, dequeued, err := manager.Dequeue(context.Background(), "deadbeef"); err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	} else if !dequeued {
+		t.Fatalf("expected a record")
+	}
+
+	found, err := manager.Complete(context.Background(), "deadbeef", 42, "failed to run step 1")
+	if err != nil {
+		t.Fatalf("unexpected error marking record as errored: %s", err)
+	}
+	if !found {
+		t.Fatalf("expected record to be tracked")
+	}
+
+	if callCount := len(mockWorkerStore.MarkErroredFunc.History()); callCount != 1 {
+		t.Errorf("unexpected mark errored call count. want=%d have=%d", 1, callCount)
+	} else if message := mockWorkerStore.MarkErroredFunc.History()[0].Arg2; message != "failed to run step 1" {
+		t.Errorf("unexpected failure message. want=%q have=%q", "failed to run step 1", message)
+	}
+}
+
+func TestProcessExecutorMismatch(t *testing.T) {
+	mockStore := NewMockStore()
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.PushReturn(&campaigns.CampaignSpecExecution{ID: 42}, mockWorkerStore, true, nil)
+	clock := glock.NewMockClock()
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 10,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	if _, _, err := manager.Dequeue(context.Background(), "deadbeef"); err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+
+	found, err := manager.SetDiff(context.Background(), "livebeef", 42, "diff --git README.md README.md")
+	if err != nil {
+		t.Fatalf("unexpected error setting diff: %s", err)
+	}
+	if found {
+		t.Fatalf("expected record not to be tracked for another executor")
+	}
+	if callCount := len(mockStore.SetDiffFunc.History()); callCount != 0 {
+		t.Errorf("unexpected set diff call count. want=%d have=%d", 0, callCount)
+	}
+}
+
+func TestDequeueStepsError(t *testing.T) {
+	mockStore := NewMockStore()
+	mockStore.GetStepsFunc.SetDefaultReturn(nil, fmt.Errorf("oops"))
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.SetDefaultReturn(&campaigns.CampaignSpecExecution{ID: 42}, mockWorkerStore, true, nil)
+	mockWorkerStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	clock := glock.NewMockClock()
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 1,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	if _, _, err := manager.Dequeue(context.Background(), "deadbeef"); err == nil {
+		t.Fatalf("expected an error dequeueing record")
+	}
+	if callCount := len(mockWorkerStore.DoneFunc.History()); callCount != 1 {
+		t.Errorf("unexpected done call count. want=%d have=%d", 1, callCount)
+	}
+
+	// The failed dequeue must not hold on to the only slot
+	mockStore.GetStepsFunc.SetDefaultReturn(nil, nil)
+	if _, dequeued, err := manager.Dequeue(context.Background(), "deadbeef"); err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	} else if !dequeued {
+		t.Fatalf("expected a record")
+	}
+}
+
+func TestHeartbeatRemovesUnknownExecutions(t *testing.T) {
+	mockStore := NewMockStore()
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	mockWorkerStore.MarkCompleteFunc.SetDefaultReturn(true, nil)
+	clock := glock.NewMockClock()
+
+	calls := 0
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.SetDefaultHook(func(ctx context.Context, conds []*sqlf.Query) (workerutil.Record, dbworkerstore.Store, bool, error) {
+		calls++
+		return &campaigns.CampaignSpecExecution{ID: int64(calls + 10)}, mockWorkerStore, true, nil
+	})
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 10,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	for i := 0; i < 5; i++ {
+		_, dequeued, err := manager.Dequeue(context.Background(), "deadbeef")
+		if err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if !dequeued {
+			t.Fatalf("expected a record")
+		}
+	}
+
+	// Advance by UnreportedMaxAge
+	clock.Advance(time.Second)
+
+	if err := manager.Heartbeat(context.Background(), "deadbeef", []int{12, 14, 15}); err != nil {
+		t.Fatalf("unexpected error performing heartbeat: %s", err)
+	}
+
+	if callCount := len(mockWorkerStore.RequeueFunc.History()); callCount != 2 {
+		t.Errorf("unexpected requeue call count. want=%d have=%d", 2, callCount)
+	}
+	if callCount := len(mockWorkerStore.DoneFunc.History()); callCount != 2 {
+		t.Errorf("unexpected done call count. want=%d have=%d", 2, callCount)
+	}
+
+	testCases := map[int]bool{
+		11: false,
+		12: true,
+		13: false,
+		14: true,
+		15: true,
+	}
+
+	for id, expected := range testCases {
+		name := fmt.Sprintf("id=%d", id)
+
+		t.Run(name, func(t *testing.T) {
+			found, err := manager.Complete(context.Background(), "deadbeef", id, "")
+			if err != nil {
+				t.Fatalf("unexpected error marking record as complete: %s", err)
+			}
+			if found != expected {
+				t.Errorf("unexpected flag value. want=%v have=%v", expected, found)
+			}
+		})
+	}
+}
+
+func TestUnresponsiveExecutor(t *testing.T) {
+	mockStore := NewMockStore()
+	mockWorkerStore := workerstoremocks.NewMockStore()
+	clock := glock.NewMockClock()
+
+	calls := 0
+	mockWorkerStore.DequeueWithIndependentTransactionContextFunc.SetDefaultHook(func(ctx context.Context, conds []*sqlf.Query) (workerutil.Record, dbworkerstore.Store, bool, error) {
+		calls++
+		return &campaigns.CampaignSpecExecution{ID: int64(calls + 10)}, mockWorkerStore, true, nil
+	})
+
+	manager := newManager(mockStore, mockWorkerStore, ManagerOptions{
+		MaximumTransactions: 10,
+		RequeueDelay:        time.Second,
+		UnreportedMaxAge:    time.Second,
+		DeathThreshold:      time.Second,
+	}, clock)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := manager.Dequeue(context.Background(), "deadbeef"); err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+		if _, _, err := manager.Dequeue(context.Background(), "livebeef"); err != nil {
+			t.Fatalf("unexpected error dequeueing record: %s", err)
+		}
+	}
+
+	// Advance by 75% of DeathThreshold
+	clock.Advance(time.Second * 3 / 4)
+
+	// Keep one executor alive
+	if err := manager.Heartbeat(context.Background(), "livebeef", []int{12, 14, 16}); err != nil {
+		t.Fatalf("unexpected error performing heartbeat: %s", err)
+	}
+
+	// Advance by 75% of DeathThreshold
+	clock.Advance(time.Second * 3 / 4)
+
+	// Perform a cleanup
+	_ = manager.Handle(context.Background())
+
+	if callCount := len(mockWorkerStore.RequeueFunc.History()); callCount != 3 {
+		t.Errorf("unexpected requeue call count. want=%d have=%d", 3, callCount)
+	}
+}
@@ -0,0 +1,49 @@
+package manager
+
+import (
+	"context"
+
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// Store is the subset of the campaigns store used by the manager. Every method is invoked with
+// the transaction that locks the campaign spec execution.
+type Store interface {
+	// GetSteps returns the steps of the campaign spec of the given execution.
+	GetSteps(ctx context.Context, tx dbworkerstore.Store, execution *campaigns.CampaignSpecExecution) ([]campaigns.CampaignSpecStep, error)
+
+	// SetLogContents updates the given execution with the given log contents.
+	SetLogContents(ctx context.Context, tx dbworkerstore.Store, executionID int, contents string) error
+
+	// SetDiff attaches a changeset spec with the given diff to the campaign spec of the given
+	// execution.
+	SetDiff(ctx context.Context, tx dbworkerstore.Store, execution *campaigns.CampaignSpecExecution, diff string) error
+}
+
+type store struct {
+	store *ee.Store
+}
+
+// NewStore returns a Store backed by the given campaigns store.
+func NewStore(s *ee.Store) Store {
+	return &store{store: s}
+}
+
+func (s *store) GetSteps(ctx context.Context, tx dbworkerstore.Store, execution *campaigns.CampaignSpecExecution) ([]campaigns.CampaignSpecStep, error) {
+	campaignSpec, err := s.store.With(tx).GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{ID: execution.CampaignSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	return campaignSpec.Spec.Steps, nil
+}
+
+func (s *store) SetLogContents(ctx context.Context, tx dbworkerstore.Store, executionID int, contents string) error {
+	return s.store.With(tx).SetCampaignSpecExecutionLog(ctx, int64(executionID), contents)
+}
+
+func (s *store) SetDiff(ctx context.Context, tx dbworkerstore.Store, execution *campaigns.CampaignSpecExecution, diff string) error {
+	return ee.CreateCampaignSpecExecutionChangesetSpec(ctx, s.store.With(tx), execution, diff)
+}
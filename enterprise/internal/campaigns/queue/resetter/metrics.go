@@ -0,0 +1,32 @@
+package resetter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+)
+
+func NewResetterMetrics(r prometheus.Registerer) dbworker.ResetterMetrics {
+	executionResets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_execution_queue_resets_total",
+		Help: "Total number of campaign spec executions put back into queued state",
+	})
+	r.MustRegister(executionResets)
+
+	executionResetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_execution_queue_max_resets_total",
+		Help: "Total number of campaign spec executions that exceed the max number of resets",
+	})
+	r.MustRegister(executionResetFailures)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_campaign_spec_execution_queue_reset_errors_total",
+		Help: "Total number of errors when running the campaign spec execution resetter",
+	})
+	r.MustRegister(errors)
+
+	return dbworker.ResetterMetrics{
+		RecordResets:        executionResets,
+		RecordResetFailures: executionResetFailures,
+		Errors:              errors,
+	}
+}
@@ -0,0 +1,20 @@
+package resetter
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+func NewExecutionResetter(
+	s dbworkerstore.Store,
+	resetInterval time.Duration,
+	metrics dbworker.ResetterMetrics,
+) *dbworker.Resetter {
+	return dbworker.NewResetter(s, dbworker.ResetterOptions{
+		Name:     "campaign spec execution resetter",
+		Interval: resetInterval,
+		Metrics:  metrics,
+	})
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/types"
+)
+
+func (s *Server) setupRoutes(router *mux.Router) {
+	router.Path("/dequeue").Methods("POST").HandlerFunc(s.handleDequeue)
+	router.Path("/setlog").Methods("POST").HandlerFunc(s.handleSetLogContents)
+	router.Path("/setdiff").Methods("POST").HandlerFunc(s.handleSetDiff)
+	router.Path("/complete").Methods("POST").HandlerFunc(s.handleComplete)
+	router.Path("/heartbeat").Methods("POST").HandlerFunc(s.handleHeartbeat)
+}
+
+// POST /dequeue
+func (s *Server) handleDequeue(w http.ResponseWriter, r *http.Request) {
+	var payload types.DequeueRequest
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	job, dequeued, err := s.manager.Dequeue(r.Context(), payload.ExecutorName)
+	if err != nil {
+		log15.Error("Failed to dequeue campaign spec execution", "err", err)
+		http.Error(w, fmt.Sprintf("failed to dequeue campaign spec execution: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if !dequeued {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, job)
+}
+
+// POST /setlog
+func (s *Server) handleSetLogContents(w http.ResponseWriter, r *http.Request) {
+	var payload types.SetLogRequest
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	if err := s.manager.SetLogContents(r.Context(), payload.ExecutorName, payload.ExecutionID, payload.Contents); err != nil {
+		log15.Error("Failed to set log contents", "err", err)
+		http.Error(w, fmt.Sprintf("failed to set log contents: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /setdiff
+func (s *Server) handleSetDiff(w http.ResponseWriter, r *http.Request) {
+	var payload types.SetDiffRequest
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	found, err := s.manager.SetDiff(r.Context(), payload.ExecutorName, payload.ExecutionID, payload.Diff)
+	if err != nil {
+		log15.Error("Failed to set diff", "err", err)
+		http.Error(w, fmt.Sprintf("failed to set diff: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /complete
+func (s *Server) handleComplete(w http.ResponseWriter, r *http.Request) {
+	var payload types.CompleteRequest
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	found, err := s.manager.Complete(r.Context(), payload.ExecutorName, payload.ExecutionID, payload.ErrorMessage)
+	if err != nil {
+		log15.Error("Failed to complete campaign spec execution", "err", err)
+		http.Error(w, fmt.Sprintf("failed to complete campaign spec execution: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// POST /heartbeat
+func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	var payload types.HeartbeatRequest
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+
+	if err := s.manager.Heartbeat(r.Context(), payload.ExecutorName, payload.ExecutionIDs); err != nil {
+		log15.Error("Failed to acknowledge heartbeat", "err", err)
+		http.Error(w, fmt.Sprintf("failed to acknowledge heartbeat: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/queue/manager"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpserver"
+)
+
+const Port = 3192
+
+type Server struct {
+	manager manager.Manager
+}
+
+func New(manager manager.Manager) goroutine.BackgroundRoutine {
+	server := &Server{
+		manager: manager,
+	}
+
+	return httpserver.New(Port, server.setupRoutes)
+}
@@ -0,0 +1,85 @@
+package types
+
+import "github.com/sourcegraph/sourcegraph/internal/campaigns"
+
+// Job is returned by the campaign executor queue API when a campaign spec
+// execution is dequeued. It contains everything the campaign executor needs
+// to run the steps of the campaign spec in a single repository.
+type Job struct {
+	// ID is the identifier of the campaign spec execution.
+	ID int `json:"id"`
+
+	// RepoName is the name of the repository in which the steps are run.
+	RepoName string `json:"repoName"`
+
+	// Commit is the commit of the repository the steps are run against.
+	Commit string `json:"commit"`
+
+	// Steps are the steps of the campaign spec.
+	Steps []campaigns.CampaignSpecStep `json:"steps"`
+}
+
+// RecordID implements workerutil.Record.
+func (j Job) RecordID() int {
+	return j.ID
+}
+
+// DequeueRequest is sent to the campaign executor queue API to lock and
+// retrieve a queued campaign spec execution for processing.
+type DequeueRequest struct {
+	// ExecutorName is a unique name identifying the requesting executor.
+	ExecutorName string `json:"executorName"`
+}
+
+// SetLogRequest is sent to the campaign executor queue API to set the log
+// contents of a campaign spec execution that is currently being processed.
+type SetLogRequest struct {
+	// ExecutorName is a unique name identifying the requesting executor.
+	ExecutorName string `json:"executorName"`
+
+	// ExecutionID is the identifier of the execution that is being processed.
+	ExecutionID int `json:"executionId"`
+
+	// Payload the content of the execution logs.
+	Contents string `json:"payload"`
+}
+
+// SetDiffRequest is sent to the campaign executor queue API once the steps
+// of a campaign spec execution produced a non-empty diff. The diff is turned
+// into a changeset spec attached to the campaign spec.
+type SetDiffRequest struct {
+	// ExecutorName is a unique name identifying the requesting executor.
+	ExecutorName string `json:"executorName"`
+
+	// ExecutionID is the identifier of the execution that is being processed.
+	ExecutionID int `json:"executionId"`
+
+	// Diff is the diff produced by the steps.
+	Diff string `json:"diff"`
+}
+
+// CompleteRequest is sent to the campaign executor queue API once a campaign
+// spec execution has finished. This request is used both on success and
+// failure.
+type CompleteRequest struct {
+	// ExecutorName is a unique name identifying the requesting executor.
+	ExecutorName string `json:"executorName"`
+
+	// ExecutionID is the identifier of the execution that was processed.
+	ExecutionID int `json:"executionId"`
+
+	// ErrorMessage a description of the job failure, if the execution did
+	// not succeed.
+	ErrorMessage string `json:"errorMessage"`
+}
+
+// HeartbeatRequest is sent to the campaign executor queue API periodically
+// to keep the transactions held for a particular executor alive.
+type HeartbeatRequest struct {
+	// ExecutorName is a unique name identifying the requesting executor.
+	ExecutorName string `json:"executorName"`
+
+	// ExecutionIDs is a list of execution identifiers which are currently
+	// being processed by the executor.
+	ExecutionIDs []int `json:"executionIds"`
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -74,6 +76,12 @@ func (r *reconciler) process(ctx context.Context, tx *Store, ch *campaigns.Chang
 		return r.syncChangeset(ctx, tx, ch)
 
 	case actionPublish:
+		if action.publicationSchedule != nil {
+			scheduled, err := schedulePublication(ctx, tx, ch, action.publicationSchedule)
+			if err != nil || scheduled {
+				return err
+			}
+		}
 		return r.publishChangeset(ctx, tx, ch, action.spec)
 
 	case actionReopen:
@@ -88,6 +96,9 @@ func (r *reconciler) process(ctx context.Context, tx *Store, ch *campaigns.Chang
 	case actionClose:
 		return r.closeChangeset(ctx, tx, ch)
 
+	case actionMerge:
+		return r.mergeChangeset(ctx, tx, ch, action.autoMerge)
+
 	case actionNone:
 		return nil
 
@@ -348,6 +359,66 @@ func (r *reconciler) closeChangeset(ctx context.Context, tx *Store, ch *campaign
 	return r.syncChangeset(ctx, tx, ch)
 }
 
+// mergeChangeset merges the given changeset on its code host if it satisfies
+// the given auto-merge policy, and records the outcome as a ChangesetEvent.
+func (r *reconciler) mergeChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, policy *campaigns.ChangesetAutoMerge) (err error) {
+	ch.Merging = false
+	ch.FailureMessage = nil
+
+	// The changeset might have been closed or merged since it was enqueued.
+	if policy == nil || ch.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return tx.UpdateChangeset(ctx, ch)
+	}
+
+	reason, err := autoMergeBlocker(ch, policy)
+	if err != nil {
+		return errors.Wrap(err, "checking auto-merge policy")
+	}
+
+	event := &campaigns.AutoMergeEvent{
+		Method: policy.MergeMethod(),
+		Date:   tx.Clock()(),
+	}
+
+	if reason == "" {
+		repo, extSvc, _, err := loadAssociations(ctx, tx, ch)
+		if err != nil {
+			return errors.Wrap(err, "failed to load associations")
+		}
+
+		// Set up a source with which we can merge the changeset
+		ccs, err := r.buildChangesetSource(repo, extSvc)
+		if err != nil {
+			return err
+		}
+
+		cs := &repos.Changeset{Changeset: ch, Repo: repo}
+
+		// The code host can still refuse to merge the changeset, for example
+		// because of conflicts or branch protection rules. We record that as
+		// the reason it wasn't merged and try again after the next sync.
+		if err := ccs.MergeChangeset(ctx, cs, policy.MergeMethod()); err != nil {
+			log15.Warn("Merging changeset failed", "changeset", ch.ID, "err", err)
+			reason = err.Error()
+		} else {
+			event.Merged = true
+		}
+	}
+	event.Reason = reason
+
+	if err := tx.UpsertChangesetEvents(ctx, autoMergeChangesetEvent(ch, event)); err != nil {
+		log15.Error("UpsertChangesetEvents", "err", err)
+		return err
+	}
+
+	if !event.Merged {
+		return tx.UpdateChangeset(ctx, ch)
+	}
+
+	// syncChangeset updates the changeset in the same transaction
+	return r.syncChangeset(ctx, tx, ch)
+}
+
 func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) (string, error) {
 	ref, err := r.gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
@@ -435,6 +506,13 @@ func buildCommitOpts(repo *repos.Repo, spec *campaigns.ChangesetSpec) (protocol.
 		Push:         true,
 	}
 
+	// Gerrit creates changes from commits pushed to a magic ref, and links
+	// the commits to changes through the Change-Id trailer.
+	if repo.ExternalRepo.ServiceType == extsvc.TypeGerrit {
+		opts.CommitInfo.Message = gerrit.AddChangeIDTrailer(commitMessage, gerrit.ChangeID(repo.Name, desc.HeadRef))
+		opts.PushRef = gerrit.PushRef(desc.BaseRef, desc.HeadRef)
+	}
+
 	return opts, nil
 }
 
@@ -449,6 +527,7 @@ const (
 	actionClose        actionType = "close"
 	actionReopen       actionType = "reopen"
 	actionReopenUpdate actionType = "reopen-update"
+	actionMerge        actionType = "merge"
 )
 
 // reconcilerAction represents the possible actions the reconciler can take for
@@ -463,6 +542,14 @@ type reconcilerAction struct {
 	// The delta between a possible previous ChangesetSpec and the current
 	// ChangesetSpec.
 	delta *changesetSpecDelta
+
+	// The auto-merge policy of the campaign spec the current spec belongs
+	// to, if any.
+	autoMerge *campaigns.ChangesetAutoMerge
+
+	// The publication schedule of the campaign spec the current spec belongs
+	// to, if any.
+	publicationSchedule *campaigns.PublicationSchedule
 }
 
 // determineAction looks at the given changeset to determine what action the
@@ -494,9 +581,19 @@ func determineAction(ctx context.Context, tx *Store, ch *campaigns.Changeset) (r
 	}
 	action.spec = curr
 
-	if err := checkSpecAppliedToCampaign(ctx, tx, curr); err != nil {
+	campaignSpec, err := checkSpecAppliedToCampaign(ctx, tx, curr)
+	if err != nil {
 		return action, err
 	}
+	action.autoMerge = campaignSpec.Spec.ChangesetTemplate.AutoMerge
+	action.publicationSchedule = campaignSpec.Spec.PublicationSchedule
+
+	// If it's marked as merging, the syncer found that its state changed and
+	// we need to check whether it can be merged now.
+	if ch.Merging {
+		action.actionType = actionMerge
+		return action, nil
+	}
 
 	var prev *campaigns.ChangesetSpec
 	if ch.PreviousSpecID != 0 {
@@ -531,6 +628,12 @@ func determineAction(ctx context.Context, tx *Store, ch *campaigns.Changeset) (r
 				action.actionType = actionUpdate
 			}
 		}
+
+		// If nothing else needs to be done, an auto-merge policy that was
+		// added to the campaign may already be satisfied.
+		if action.actionType == actionNone && action.autoMerge != nil && ch.ExternalState == campaigns.ChangesetExternalStateOpen {
+			action.actionType = actionMerge
+		}
 	default:
 		return action, fmt.Errorf("unknown changeset publication state: %s", ch.PublicationState)
 	}
@@ -568,22 +671,22 @@ func reopenAfterDetach(ch *campaigns.Changeset) bool {
 	// TODO: What if somebody closed the changeset on purpose on the codehost?
 }
 
-func checkSpecAppliedToCampaign(ctx context.Context, tx *Store, spec *campaigns.ChangesetSpec) error {
+func checkSpecAppliedToCampaign(ctx context.Context, tx *Store, spec *campaigns.ChangesetSpec) (*campaigns.CampaignSpec, error) {
 	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: spec.CampaignSpecID})
 	if err != nil {
-		return errors.Wrap(err, "failed to load campaign spec")
+		return nil, errors.Wrap(err, "failed to load campaign spec")
 	}
 
 	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{CampaignSpecID: campaignSpec.ID})
 	if err != nil && err != ErrNoResults {
-		return errors.Wrap(err, "failed to load campaign")
+		return nil, errors.Wrap(err, "failed to load campaign")
 	}
 
 	if campaign == nil || err == ErrNoResults {
-		return errors.New("campaign spec is not applied to a campaign")
+		return nil, errors.New("campaign spec is not applied to a campaign")
 	}
 
-	return nil
+	return campaignSpec, nil
 }
 
 func loadAssociations(ctx context.Context, tx *Store, ch *campaigns.Changeset) (*repos.Repo, *repos.ExternalService, *campaigns.Campaign, error) {
@@ -749,6 +749,298 @@ func TestReconcilerProcess_PublishedChangesetDuplicateBranch(t *testing.T) {
 	}
 }
 
+func TestReconcilerProcess_AutoMerge(t *testing.T) {
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time {
+		return now.UTC().Truncate(time.Microsecond)
+	}
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	if !admin.SiteAdmin {
+		t.Fatalf("admin is not site admin")
+	}
+
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	approvedPR := buildGithubPR(clock(), "OPEN")
+	approvedPR.TimelineItems = append(approvedPR.TimelineItems, github.TimelineItem{
+		Type: "PullRequestReview",
+		Item: &github.PullRequestReview{
+			DatabaseID: 1,
+			Author:     github.Actor{Login: "reviewer"},
+			State:      "APPROVED",
+			UpdatedAt:  clock().Add(1 * time.Minute),
+		},
+	})
+
+	mergedPR := buildGithubPR(clock(), "MERGED")
+	mergedPR.TimelineItems = append(mergedPR.TimelineItems, github.TimelineItem{
+		Type: "MergedEvent",
+		Item: &github.MergedEvent{CreatedAt: clock().Add(1 * time.Hour)},
+	})
+	mergedPR.UpdatedAt = clock().Add(1 * time.Hour)
+
+	tests := map[string]struct {
+		policy campaigns.ChangesetAutoMerge
+
+		wantMergeOnCodeHost bool
+		wantEventKind       campaigns.ChangesetEventKind
+		wantReason          string
+		wantExternalState   campaigns.ChangesetExternalState
+	}{
+		"merges changeset satisfying the policy": {
+			policy: campaigns.ChangesetAutoMerge{
+				Method:            campaigns.ChangesetMergeMethodSquash,
+				RequiredApprovals: 1,
+			},
+			wantMergeOnCodeHost: true,
+			wantEventKind:       campaigns.ChangesetEventKindAutoMerged,
+			wantExternalState:   campaigns.ChangesetExternalStateMerged,
+		},
+		"records why changeset is not merged": {
+			policy: campaigns.ChangesetAutoMerge{
+				Method:            campaigns.ChangesetMergeMethodSquash,
+				RequiredApprovals: 2,
+			},
+			wantMergeOnCodeHost: false,
+			wantEventKind:       campaigns.ChangesetEventKindAutoMergeBlocked,
+			wantReason:          "approved by 1 of 2 required reviewers",
+			wantExternalState:   campaigns.ChangesetExternalStateOpen,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			truncateTables(t, dbconn.Global, "changeset_events", "changesets", "campaigns", "campaign_specs", "changeset_specs")
+
+			campaignSpec := createCampaignSpec(t, ctx, store, "reconciler-test-campaign", admin.ID)
+			policy := tc.policy
+			campaignSpec.Spec.ChangesetTemplate.AutoMerge = &policy
+			if err := store.UpdateCampaignSpec(ctx, campaignSpec); err != nil {
+				t.Fatal(err)
+			}
+			campaign := createCampaign(t, ctx, store, "reconciler-test-campaign", admin.ID, campaignSpec.ID)
+
+			changesetSpec := createChangesetSpec(t, ctx, store, testSpecOpts{
+				user:         admin.ID,
+				repo:         rs[0].ID,
+				campaignSpec: campaignSpec.ID,
+				headRef:      "refs/heads/head-ref-on-github",
+				published:    true,
+			})
+
+			changeset := createChangeset(t, ctx, store, testChangesetOpts{
+				repo:             rs[0].ID,
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				campaign:         campaign.ID,
+				ownedByCampaign:  campaign.ID,
+				currentSpec:      changesetSpec.ID,
+				externalID:       approvedPR.ID,
+				externalBranch:   approvedPR.HeadRefName,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+			})
+			changeset.Metadata = approvedPR
+			changeset.ExternalCheckState = campaigns.ChangesetCheckStatePassed
+			changeset.Merging = true
+			if err := store.UpdateChangeset(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			fakeSource := &ct.FakeChangesetSource{Svc: extSvc, FakeMetadata: mergedPR}
+			rec := reconciler{
+				noSleepBeforeSync: true,
+				gitserverClient:   &ct.FakeGitserverClient{},
+				sourcer:           repos.NewFakeSourcer(nil, fakeSource),
+				store:             store,
+			}
+			if err := rec.process(ctx, store, changeset); err != nil {
+				t.Fatalf("reconciler process failed: %s", err)
+			}
+
+			if have, want := fakeSource.MergeChangesetCalled, tc.wantMergeOnCodeHost; have != want {
+				t.Fatalf("wrong MergeChangeset call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+			if tc.wantMergeOnCodeHost {
+				if have, want := fakeSource.MergeMethod, tc.policy.Method; have != want {
+					t.Fatalf("wrong merge method. want=%q, have=%q", want, have)
+				}
+			}
+
+			reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reloaded.Merging {
+				t.Fatalf("changeset is still marked as merging")
+			}
+			if have, want := reloaded.ExternalState, tc.wantExternalState; have != want {
+				t.Fatalf("wrong external state. want=%q, have=%q", want, have)
+			}
+
+			events, _, err := store.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: []int64{changeset.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var event *campaigns.AutoMergeEvent
+			for _, e := range events {
+				if e.Kind == tc.wantEventKind {
+					event = e.Metadata.(*campaigns.AutoMergeEvent)
+				}
+			}
+			if event == nil {
+				t.Fatalf("no %q event recorded", tc.wantEventKind)
+			}
+			if have, want := event.Reason, tc.wantReason; have != want {
+				t.Fatalf("wrong reason. want=%q, have=%q", want, have)
+			}
+		})
+	}
+}
+
+func TestReconcilerProcess_PublicationSchedule(t *testing.T) {
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time {
+		return now.UTC().Truncate(time.Microsecond)
+	}
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	if !admin.SiteAdmin {
+		t.Fatalf("admin is not site admin")
+	}
+
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	internalClient = &mockInternalClient{externalURL: "https://sourcegraph.test"}
+	defer func() { internalClient = api.InternalClient }()
+
+	tests := map[string]struct {
+		schedule campaigns.PublicationSchedule
+
+		// The publication slot of the second changeset, after the first one
+		// has been published.
+		wantSlot time.Time
+		// The time at which the second changeset is dequeued again.
+		wantScheduledAt time.Time
+	}{
+		"changesets per hour": {
+			schedule:        campaigns.PublicationSchedule{PerHour: 2},
+			wantSlot:        clock().Add(30 * time.Minute),
+			wantScheduledAt: clock().Add(30 * time.Minute),
+		},
+		"max open changesets": {
+			schedule:        campaigns.PublicationSchedule{MaxOpen: 1},
+			wantSlot:        clock(),
+			wantScheduledAt: clock().Add(maxOpenRecheckInterval),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			truncateTables(t, dbconn.Global, "changeset_events", "changesets", "campaigns", "campaign_specs", "changeset_specs")
+
+			campaignSpec := createCampaignSpec(t, ctx, store, "reconciler-test-campaign", admin.ID)
+			schedule := tc.schedule
+			campaignSpec.Spec.PublicationSchedule = &schedule
+			if err := store.UpdateCampaignSpec(ctx, campaignSpec); err != nil {
+				t.Fatal(err)
+			}
+			campaign := createCampaign(t, ctx, store, "reconciler-test-campaign", admin.ID, campaignSpec.ID)
+
+			process := func(headRef string) (*campaigns.Changeset, *ct.FakeChangesetSource) {
+				changesetSpec := createChangesetSpec(t, ctx, store, testSpecOpts{
+					user:         admin.ID,
+					repo:         rs[0].ID,
+					campaignSpec: campaignSpec.ID,
+					headRef:      headRef,
+					published:    true,
+				})
+
+				changeset := createChangeset(t, ctx, store, testChangesetOpts{
+					repo:             rs[0].ID,
+					publicationState: campaigns.ChangesetPublicationStateUnpublished,
+					campaign:         campaign.ID,
+					ownedByCampaign:  campaign.ID,
+					currentSpec:      changesetSpec.ID,
+					reconcilerState:  campaigns.ReconcilerStateProcessing,
+				})
+
+				pr := buildGithubPR(clock(), "OPEN")
+				pr.HeadRefName = git.AbbreviateRef(headRef)
+				fakeSource := &ct.FakeChangesetSource{
+					Svc:          extSvc,
+					FakeMetadata: pr,
+					WantHeadRef:  changesetSpec.Spec.HeadRef,
+					WantBaseRef:  changesetSpec.Spec.BaseRef,
+				}
+
+				rec := reconciler{
+					noSleepBeforeSync: true,
+					gitserverClient:   &ct.FakeGitserverClient{Response: changesetSpec.Spec.HeadRef},
+					sourcer:           repos.NewFakeSourcer(nil, fakeSource),
+					store:             store,
+				}
+				if err := rec.process(ctx, store, changeset); err != nil {
+					t.Fatalf("reconciler process failed: %s", err)
+				}
+
+				reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return reloaded, fakeSource
+			}
+
+			first, fakeSource := process("refs/heads/head-ref-1")
+			if !fakeSource.CreateChangesetCalled {
+				t.Fatalf("first changeset was not published")
+			}
+			if have, want := first.PublicationState, campaigns.ChangesetPublicationStatePublished; have != want {
+				t.Fatalf("wrong publication state. want=%q, have=%q", want, have)
+			}
+			if have, want := first.PublicationSlot, clock(); !have.Equal(want) {
+				t.Fatalf("wrong publication time. want=%s, have=%s", want, have)
+			}
+
+			second, fakeSource := process("refs/heads/head-ref-2")
+			if fakeSource.CreateChangesetCalled {
+				t.Fatalf("second changeset was published")
+			}
+			if have, want := second.PublicationState, campaigns.ChangesetPublicationStateUnpublished; have != want {
+				t.Fatalf("wrong publication state. want=%q, have=%q", want, have)
+			}
+			if have, want := second.ReconcilerState, campaigns.ReconcilerStateQueued; have != want {
+				t.Fatalf("wrong reconciler state. want=%q, have=%q", want, have)
+			}
+			if have, want := second.PublicationSlot, tc.wantSlot; !have.Equal(want) {
+				t.Fatalf("wrong publication slot. want=%s, have=%s", want, have)
+			}
+			if have, want := second.ProcessAfter, tc.wantScheduledAt; !have.Equal(want) {
+				t.Fatalf("wrong scheduled time. want=%s, have=%s", want, have)
+			}
+		})
+	}
+}
+
 func buildGithubPR(now time.Time, state string) *github.PullRequest {
 	pr := &github.PullRequest{
 		ID:          "12345",
@@ -219,6 +219,27 @@ func (r *changesetResolver) NextSyncAt(ctx context.Context) (*graphqlbackend.Dat
 	return &graphqlbackend.DateTime{Time: nextSyncAt}, nil
 }
 
+func (r *changesetResolver) ScheduleEstimateAt() *graphqlbackend.DateTime {
+	at, ok := r.scheduledAt()
+	if !ok {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: at}
+}
+
+// scheduledAt returns the time at which the changeset will be processed, if
+// it's held back by the publication schedule of its campaign. Such changesets
+// are queued to be processed at the time they're scheduled for.
+func (r *changesetResolver) scheduledAt() (time.Time, bool) {
+	if !r.changeset.Unpublished() || r.changeset.ReconcilerState != campaigns.ReconcilerStateQueued {
+		return time.Time{}, false
+	}
+	if r.changeset.PublicationSlot.IsZero() || !r.changeset.ProcessAfter.After(r.store.Clock()()) {
+		return time.Time{}, false
+	}
+	return r.changeset.ProcessAfter, true
+}
+
 func (r *changesetResolver) Title(ctx context.Context) (*string, error) {
 	if r.changeset.PublishedAndSynced() {
 		t, err := r.changeset.Title()
@@ -281,6 +302,9 @@ func (r *changesetResolver) PublicationState() campaigns.ChangesetPublicationSta
 }
 
 func (r *changesetResolver) ReconcilerState() campaigns.ReconcilerState {
+	if _, ok := r.scheduledAt(); ok {
+		return campaigns.ReconcilerStateScheduled
+	}
 	return r.changeset.ReconcilerState
 }
 
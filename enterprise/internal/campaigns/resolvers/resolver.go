@@ -616,6 +616,34 @@ func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncC
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) ExecuteCampaignSpec(ctx context.Context, args *graphqlbackend.ExecuteCampaignSpecArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ExecuteCampaignSpec", fmt.Sprintf("CampaignSpec: %q", args.CampaignSpec))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	campaignSpecRandID, err := unmarshalCampaignSpecID(args.CampaignSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignSpecRandID == "" {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: ExecuteCampaignSpec checks whether current user is authorized.
+	svc := ee.NewService(r.store, r.httpFactory)
+	if _, err = svc.ExecuteCampaignSpec(ctx, campaignSpecRandID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
 func parseCampaignState(s *string) (campaigns.CampaignState, error) {
 	if s == nil {
 		return campaigns.CampaignStateAny, nil
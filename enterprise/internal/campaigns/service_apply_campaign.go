@@ -379,6 +379,10 @@ func (r *changesetRewirer) updateChangesetToNewSpec(c *campaigns.Changeset, spec
 	c.PreviousSpecID = c.CurrentSpecID
 	c.CurrentSpecID = spec.ID
 
+	// The new spec might need to be pushed first, so the changeset must not
+	// be merged before the reconciler looked at it.
+	c.Merging = false
+
 	// Ensure that the changeset is attached to the campaign
 	c.CampaignIDs = append(c.CampaignIDs, r.campaign.ID)
 
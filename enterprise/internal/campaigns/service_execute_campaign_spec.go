@@ -0,0 +1,309 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/inconshreveable/log15"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// ErrNoSteps is returned by ExecuteCampaignSpec if the campaign spec doesn't
+// have any steps that could be executed.
+var ErrNoSteps = errors.New("campaign spec has no steps to execute")
+
+// ErrNoRepositories is returned by ExecuteCampaignSpec if the campaign spec
+// doesn't apply to any supported repository.
+var ErrNoRepositories = errors.New("campaign spec doesn't match any supported repositories")
+
+// ErrCampaignSpecAlreadyExecuted is returned by ExecuteCampaignSpec if the
+// campaign spec has executions that are queued, processing or completed.
+var ErrCampaignSpecAlreadyExecuted = errors.New("campaign spec has already been executed")
+
+// campaignSpecExecutionsUniqueConstraint is the constraint that allows a
+// single execution per campaign spec and repository.
+const campaignSpecExecutionsUniqueConstraint = "campaign_spec_executions_campaign_spec_id_repo_id_unique"
+
+// ExecuteCampaignSpec enqueues the server-side execution of the steps of the
+// CampaignSpec with the given RandID in every repository it applies to. The
+// campaign executor turns the resulting diffs into ChangesetSpecs attached
+// to the CampaignSpec.
+func (s *Service) ExecuteCampaignSpec(ctx context.Context, campaignSpecRandID string) (executions []*campaigns.CampaignSpecExecution, err error) {
+	tr, ctx := trace.New(ctx, "Service.ExecuteCampaignSpec", fmt.Sprintf("CampaignSpec %s", campaignSpecRandID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignSpec, err := s.store.GetCampaignSpec(ctx, GetCampaignSpecOpts{
+		RandID: campaignSpecRandID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of campaignSpec can execute
+	// campaignSpec.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaignSpec.UserID); err != nil {
+		return nil, err
+	}
+
+	if len(campaignSpec.Spec.Steps) == 0 {
+		return nil, ErrNoSteps
+	}
+
+	// 🚨 SECURITY: The repositories are resolved with the actor in ctx, which
+	// filters out the repositories that the user doesn't have access to.
+	repos, err := resolveRepositoriesOn(ctx, campaignSpec.Spec.On)
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) == 0 {
+		return nil, ErrNoRepositories
+	}
+
+	for _, repo := range repos {
+		baseRef, baseRev, err := defaultBranch(ctx, repo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving default branch of %s", repo.Name)
+		}
+
+		executions = append(executions, &campaigns.CampaignSpecExecution{
+			CampaignSpecID: campaignSpec.ID,
+			RepoID:         repo.ID,
+			RepoName:       repo.Name,
+			BaseRef:        baseRef,
+			BaseRev:        string(baseRev),
+		})
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Executing campaignSpec again would attach a second set of changeset
+	// specs to it, so only executions that errored can be replaced.
+	existing, _, err := tx.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{
+		CampaignSpecID: campaignSpec.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.State != campaigns.CampaignSpecExecutionStateErrored {
+			return nil, ErrCampaignSpecAlreadyExecuted
+		}
+	}
+	if len(existing) > 0 {
+		if err := tx.DeleteCampaignSpecExecutions(ctx, campaignSpec.ID, campaigns.CampaignSpecExecutionStateErrored); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, execution := range executions {
+		if err := tx.CreateCampaignSpecExecution(ctx, execution); err != nil {
+			// A concurrent call enqueued the executions first.
+			if isUniqueConstraintViolation(err, campaignSpecExecutionsUniqueConstraint) {
+				return nil, ErrCampaignSpecAlreadyExecuted
+			}
+			return nil, err
+		}
+	}
+
+	return executions, nil
+}
+
+// isUniqueConstraintViolation returns true if err is a violation of the
+// unique constraint with the given name.
+func isUniqueConstraintViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == constraint
+}
+
+// resolveRepositoriesOn returns the unique repositories matched by the given
+// "on" entries of a campaign spec. Repositories that are not supported by
+// campaigns are skipped.
+func resolveRepositoriesOn(ctx context.Context, on []campaigns.CampaignSpecOn) ([]*types.Repo, error) {
+	var repoIDs []api.RepoID
+	for _, o := range on {
+		if o.Repository != "" {
+			repo, err := db.Repos.GetByName(ctx, api.RepoName(o.Repository))
+			if err != nil {
+				return nil, err
+			}
+			repoIDs = append(repoIDs, repo.ID)
+			continue
+		}
+
+		ids, err := resolveRepositoriesMatchingQuery(ctx, o.RepositoriesMatchingQuery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving repositories matching %q", o.RepositoriesMatchingQuery)
+		}
+		repoIDs = append(repoIDs, ids...)
+	}
+
+	reposByID, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]*types.Repo, 0, len(reposByID))
+	seen := make(map[api.RepoID]struct{}, len(reposByID))
+	for _, id := range repoIDs {
+		repo, ok := reposByID[id]
+		if !ok {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if err := checkRepoSupported(repo); err != nil {
+			log15.Debug("Skipping unsupported repository in campaign spec execution", "repo", repo.Name, "err", err)
+			continue
+		}
+
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// resolveRepositoriesMatchingQuery returns the IDs of the repositories that
+// have results for the given search query, like the repositoriesMatchingQuery
+// of a campaign spec is resolved by src-cli. It can be replaced in tests.
+var resolveRepositoriesMatchingQuery = func(ctx context.Context, query string) ([]api.RepoID, error) {
+	search, err := graphqlbackend.NewSearchImplementer(ctx, &graphqlbackend.SearchArgs{
+		Version: "V2",
+		Query:   query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []api.RepoID
+	for _, result := range results.Results() {
+		var repo *graphqlbackend.RepositoryResolver
+		if r, ok := result.ToRepository(); ok {
+			repo = r
+		} else if fm, ok := result.ToFileMatch(); ok {
+			repo = fm.Repository()
+		} else if c, ok := result.ToCommitSearchResult(); ok {
+			repo = c.Commit().Repository()
+		} else {
+			continue
+		}
+
+		id, err := graphqlbackend.UnmarshalRepositoryID(repo.ID())
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// defaultBranch returns the name of the default branch of the given
+// repository and the commit it currently points to.
+func defaultBranch(ctx context.Context, repo *types.Repo) (string, api.CommitID, error) {
+	refBytes, _, exitCode, err := git.ExecSafe(ctx, gitserver.Repo{Name: repo.Name}, []string{"symbolic-ref", "HEAD"})
+	if err != nil {
+		return "", "", err
+	}
+	if exitCode != 0 {
+		return "", "", errors.Errorf("git symbolic-ref HEAD exited with %d", exitCode)
+	}
+
+	commitID, err := backend.Repos.ResolveRev(ctx, repo, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(bytes.TrimSpace(refBytes)), commitID, nil
+}
+
+// CreateCampaignSpecExecutionChangesetSpec creates a changeset spec from the
+// given diff and the changeset template of the campaign spec of the given
+// execution, attaches it to the campaign spec, and records it as the result
+// of the execution. It's used by the campaign executor queue once the steps
+// of an execution produced a non-empty diff.
+func CreateCampaignSpecExecutionChangesetSpec(ctx context.Context, s *Store, execution *campaigns.CampaignSpecExecution, diff string) (err error) {
+	campaignSpec, err := s.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: execution.CampaignSpecID})
+	if err != nil {
+		return errors.Wrap(err, "store.GetCampaignSpec")
+	}
+
+	template := campaignSpec.Spec.ChangesetTemplate
+	repoID := graphqlbackend.MarshalRepositoryID(execution.RepoID)
+
+	rawSpec, err := json.Marshal(&campaigns.ChangesetSpecDescription{
+		BaseRepository: repoID,
+		BaseRef:        execution.BaseRef,
+		BaseRev:        execution.BaseRev,
+
+		HeadRepository: repoID,
+		HeadRef:        git.EnsureRefPrefix(template.Branch),
+
+		Title: template.Title,
+		Body:  template.Body,
+
+		Commits: []campaigns.GitCommitDescription{
+			{
+				Message: template.Commit.Message,
+				Diff:    diff,
+			},
+		},
+
+		Published: template.Published.Value(string(execution.RepoName)),
+	})
+	if err != nil {
+		return err
+	}
+
+	changesetSpec, err := campaigns.NewChangesetSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return errors.Wrap(err, "invalid changeset spec")
+	}
+	changesetSpec.CampaignSpecID = campaignSpec.ID
+	changesetSpec.RepoID = execution.RepoID
+	changesetSpec.UserID = campaignSpec.UserID
+
+	// Use a savepoint so that a failure here does not prevent the log from
+	// being stored.
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateChangesetSpec(ctx, changesetSpec); err != nil {
+		return errors.Wrap(err, "store.CreateChangesetSpec")
+	}
+
+	if err := tx.SetCampaignSpecExecutionChangesetSpec(ctx, execution.ID, changesetSpec.ID); err != nil {
+		return errors.Wrap(err, "store.SetCampaignSpecExecutionChangesetSpec")
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -754,6 +755,69 @@ func TestService(t *testing.T) {
 			t.Fatalf("wrong campaign was matched (-want +got):\n%s", diff)
 		}
 	})
+
+	t.Run("ExecuteCampaignSpec", func(t *testing.T) {
+		git.Mocks.ExecSafe = func(params []string) ([]byte, []byte, int, error) {
+			return []byte("refs/heads/master\n"), nil, 0, nil
+		}
+		backend.Mocks.Repos.ResolveRev = func(context.Context, *types.Repo, string) (api.CommitID, error) {
+			return "deadbeef", nil
+		}
+		t.Cleanup(func() {
+			git.ResetMocks()
+			backend.Mocks.Repos.ResolveRev = nil
+		})
+
+		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
+
+		spec := testCampaignSpec(admin.ID)
+		spec.Spec.On = []campaigns.CampaignSpecOn{{Repository: rs[0].Name}, {Repository: rs[1].Name}}
+		spec.Spec.Steps = []campaigns.CampaignSpecStep{{Run: "echo 1", Container: "alpine:3"}}
+		if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		executions, err := svc.ExecuteCampaignSpec(adminCtx, spec.RandID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(executions) != 2 {
+			t.Fatalf("wrong number of executions. want=%d, have=%d", 2, len(executions))
+		}
+
+		t.Run("executed twice", func(t *testing.T) {
+			if _, err := svc.ExecuteCampaignSpec(adminCtx, spec.RandID); err != ErrCampaignSpecAlreadyExecuted {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrCampaignSpecAlreadyExecuted, err)
+			}
+
+			have, _, err := store.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 2 {
+				t.Fatalf("wrong number of executions. want=%d, have=%d", 2, len(have))
+			}
+		})
+
+		t.Run("executed again after errors", func(t *testing.T) {
+			if _, err := dbconn.Global.Exec("UPDATE campaign_spec_executions SET state = 'errored' WHERE campaign_spec_id = $1", spec.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			retried, err := svc.ExecuteCampaignSpec(adminCtx, spec.RandID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have, _, err := store.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(retried, have); diff != "" {
+				t.Fatalf("wrong executions (-want +got):\n%s", diff)
+			}
+		})
+	})
 }
 
 var testUser = db.NewUser{
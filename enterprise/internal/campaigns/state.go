@@ -14,7 +14,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -104,6 +108,19 @@ func computeCheckState(c *campaigns.Changeset, events ChangesetEvents) campaigns
 
 	case *gitlab.MergeRequest:
 		return computeGitLabCheckState(c.UpdatedAt, m, events)
+
+	case *gerrit.Change:
+		return computeGerritCheckState(m)
+
+	case *azuredevops.PullRequest:
+		return computeAzureDevOpsCheckState(m, events)
+
+	case *bitbucketcloud.PullRequest:
+		return computeBitbucketCloudCheckState(m, events)
+
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit has no API for build statuses.
+		return campaigns.ChangesetCheckStateUnknown
 	}
 
 	return campaigns.ChangesetCheckStateUnknown
@@ -414,6 +431,115 @@ func parseGitLabPipelineStatus(status gitlab.PipelineStatus) campaigns.Changeset
 	}
 }
 
+// computeGerritCheckState computes the check state of a Gerrit change from the
+// votes on its Verified label, which is where CI systems report their results.
+// Projects that don't use the label have no check state.
+func computeGerritCheckState(c *gerrit.Change) campaigns.ChangesetCheckState {
+	label, ok := c.Labels[gerrit.LabelVerified]
+	if !ok {
+		return campaigns.ChangesetCheckStateUnknown
+	}
+
+	state := campaigns.ChangesetCheckStatePending
+	for _, a := range label.All {
+		switch {
+		case a.Value < 0:
+			return campaigns.ChangesetCheckStateFailed
+		case a.Value > 0:
+			state = campaigns.ChangesetCheckStatePassed
+		}
+	}
+	return state
+}
+
+// computeAzureDevOpsCheckState computes the check state of an Azure DevOps pull
+// request from the latest status posted by every context, taking status
+// events received since the last sync into account.
+func computeAzureDevOpsCheckState(pr *azuredevops.PullRequest, events []*campaigns.ChangesetEvent) campaigns.ChangesetCheckState {
+	latest := make(map[string]*azuredevops.Status)
+	add := func(s *azuredevops.Status) {
+		key := s.Context.String()
+		if l, ok := latest[key]; !ok || l.UpdatedDate.Before(s.UpdatedDate) {
+			latest[key] = s
+		}
+	}
+
+	for _, s := range pr.Statuses {
+		add(s)
+	}
+	for _, e := range events {
+		if s, ok := e.Metadata.(*azuredevops.Status); ok {
+			add(s)
+		}
+	}
+
+	states := make([]campaigns.ChangesetCheckState, 0, len(latest))
+	for _, s := range latest {
+		// Statuses that don't apply to the pull request don't count towards
+		// its check state.
+		if s.State == azuredevops.StatusStateNotApplicable {
+			continue
+		}
+		states = append(states, parseAzureDevOpsStatusState(s.State))
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseAzureDevOpsStatusState(state azuredevops.StatusState) campaigns.ChangesetCheckState {
+	switch state {
+	case azuredevops.StatusStateSucceeded:
+		return campaigns.ChangesetCheckStatePassed
+	case azuredevops.StatusStateFailed, azuredevops.StatusStateError:
+		return campaigns.ChangesetCheckStateFailed
+	case azuredevops.StatusStatePending, azuredevops.StatusStateNotSet:
+		return campaigns.ChangesetCheckStatePending
+	default:
+		return campaigns.ChangesetCheckStateUnknown
+	}
+}
+
+// computeBitbucketCloudCheckState computes the check state of a Bitbucket
+// Cloud pull request from the latest status reported for every build key,
+// taking status events received since the last sync into account.
+func computeBitbucketCloudCheckState(pr *bitbucketcloud.PullRequest, events []*campaigns.ChangesetEvent) campaigns.ChangesetCheckState {
+	latest := make(map[string]*bitbucketcloud.CommitStatus)
+	add := func(s *bitbucketcloud.CommitStatus) {
+		if l, ok := latest[s.BuildKey]; !ok || l.UpdatedOn.Before(s.UpdatedOn) {
+			latest[s.BuildKey] = s
+		}
+	}
+
+	for _, s := range pr.Statuses {
+		add(s)
+	}
+	for _, e := range events {
+		if s, ok := e.Metadata.(*bitbucketcloud.CommitStatus); ok {
+			add(s)
+		}
+	}
+
+	states := make([]campaigns.ChangesetCheckState, 0, len(latest))
+	for _, s := range latest {
+		states = append(states, parseBitbucketCloudStatusState(s.State))
+	}
+
+	return combineCheckStates(states)
+}
+
+func parseBitbucketCloudStatusState(state bitbucketcloud.CommitStatusState) campaigns.ChangesetCheckState {
+	switch state {
+	case bitbucketcloud.CommitStatusStateSuccessful:
+		return campaigns.ChangesetCheckStatePassed
+	case bitbucketcloud.CommitStatusStateFailed, bitbucketcloud.CommitStatusStateStopped:
+		return campaigns.ChangesetCheckStateFailed
+	case bitbucketcloud.CommitStatusStateInProgress:
+		return campaigns.ChangesetCheckStatePending
+	default:
+		return campaigns.ChangesetCheckStateUnknown
+	}
+}
+
 // computeSingleChangesetExternalState of a Changeset based on the metadata.
 // It does NOT reflect the final calculated state, use `ExternalState` instead.
 func computeSingleChangesetExternalState(c *campaigns.Changeset) (s campaigns.ChangesetExternalState, err error) {
@@ -441,6 +567,52 @@ func computeSingleChangesetExternalState(c *campaigns.Changeset) (s campaigns.Ch
 		default:
 			return "", errors.Errorf("unknown GitLab merge request state: %s", m.State)
 		}
+	case *gerrit.Change:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = campaigns.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = campaigns.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			s = campaigns.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *azuredevops.PullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusAbandoned:
+			s = campaigns.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = campaigns.ChangesetExternalStateMerged
+		case azuredevops.PullRequestStatusActive:
+			s = campaigns.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
+	case *bitbucketcloud.PullRequest:
+		switch m.State {
+		case bitbucketcloud.PullRequestStateDeclined, bitbucketcloud.PullRequestStateSuperseded:
+			s = campaigns.ChangesetExternalStateClosed
+		case bitbucketcloud.PullRequestStateMerged:
+			s = campaigns.ChangesetExternalStateMerged
+		case bitbucketcloud.PullRequestStateOpen:
+			s = campaigns.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
+		}
+	case *awscodecommit.PullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusClosed:
+			if m.IsMerged {
+				s = campaigns.ChangesetExternalStateMerged
+			} else {
+				s = campaigns.ChangesetExternalStateClosed
+			}
+		case awscodecommit.PullRequestStatusOpen:
+			s = campaigns.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -498,6 +670,66 @@ func computeSingleChangesetReviewState(c *campaigns.Changeset) (s campaigns.Chan
 		}
 		return campaigns.ChangesetReviewStatePending, nil
 
+	case *gerrit.Change:
+		// Gerrit only keeps the current vote of every reviewer, so negative
+		// Code-Review votes are requested changes and positive ones are
+		// approvals.
+		if label, ok := m.Labels[gerrit.LabelCodeReview]; ok {
+			for _, a := range label.All {
+				switch {
+				case a.Value < 0:
+					states[campaigns.ChangesetReviewStateChangesRequested] = true
+				case a.Value > 0:
+					states[campaigns.ChangesetReviewStateApproved] = true
+				}
+			}
+		}
+
+	case *azuredevops.PullRequest:
+		// Reviewers hold their current vote: "waiting for author" and
+		// "rejected" request changes, both approval votes approve.
+		for _, r := range m.Reviewers {
+			switch {
+			case r.Vote < azuredevops.VoteNone:
+				states[campaigns.ChangesetReviewStateChangesRequested] = true
+			case r.Vote > azuredevops.VoteNone:
+				states[campaigns.ChangesetReviewStateApproved] = true
+			default:
+				states[campaigns.ChangesetReviewStatePending] = true
+			}
+		}
+
+	case *bitbucketcloud.PullRequest:
+		for _, p := range m.Participants {
+			switch {
+			case p.State == bitbucketcloud.ParticipantStateChangesRequested:
+				states[campaigns.ChangesetReviewStateChangesRequested] = true
+			case p.Approved || p.State == bitbucketcloud.ParticipantStateApproved:
+				states[campaigns.ChangesetReviewStateApproved] = true
+			case p.Role == bitbucketcloud.ParticipantRoleReviewer:
+				states[campaigns.ChangesetReviewStatePending] = true
+			}
+		}
+
+	case *awscodecommit.PullRequest:
+		// AWS CodeCommit has no way to request changes, and approvals only
+		// apply to the revision they were given for, so only the latest
+		// approval event of every actor for the current revision counts.
+		latest := make(map[string]*awscodecommit.ApprovalEvent)
+		for _, e := range m.ApprovalEvents {
+			if e.RevisionID != m.RevisionID {
+				continue
+			}
+			if l, ok := latest[e.ActorARN]; !ok || l.Date.Before(e.Date) {
+				latest[e.ActorARN] = e
+			}
+		}
+		for _, e := range latest {
+			if e.State == awscodecommit.ApprovalStateApprove {
+				states[campaigns.ChangesetReviewStateApproved] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -9,6 +9,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -399,6 +402,171 @@ func TestComputeGitLabCheckState(t *testing.T) {
 	})
 }
 
+func TestComputeAzureDevOpsCheckState(t *testing.T) {
+	build := azuredevops.StatusContext{Name: "build", Genre: "continuous-integration"}
+	lint := azuredevops.StatusContext{Name: "lint", Genre: "continuous-integration"}
+
+	status := func(ctx azuredevops.StatusContext, state azuredevops.StatusState, updated int64) *azuredevops.Status {
+		return &azuredevops.Status{Context: ctx, State: state, UpdatedDate: time.Unix(updated, 0)}
+	}
+
+	for name, tc := range map[string]struct {
+		statuses []*azuredevops.Status
+		events   []*cmpgn.ChangesetEvent
+		want     cmpgn.ChangesetCheckState
+	}{
+		"no statuses": {
+			want: cmpgn.ChangesetCheckStateUnknown,
+		},
+		"latest status per context wins": {
+			statuses: []*azuredevops.Status{
+				status(build, azuredevops.StatusStateFailed, 10),
+				status(build, azuredevops.StatusStateSucceeded, 20),
+				status(lint, azuredevops.StatusStateSucceeded, 5),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		"failed and passed": {
+			statuses: []*azuredevops.Status{
+				status(build, azuredevops.StatusStateError, 10),
+				status(lint, azuredevops.StatusStateSucceeded, 10),
+			},
+			want: cmpgn.ChangesetCheckStateFailed,
+		},
+		"not applicable is ignored": {
+			statuses: []*azuredevops.Status{
+				status(build, azuredevops.StatusStateNotApplicable, 10),
+				status(lint, azuredevops.StatusStateSucceeded, 10),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		"newer event": {
+			statuses: []*azuredevops.Status{
+				status(build, azuredevops.StatusStateSucceeded, 10),
+			},
+			events: []*cmpgn.ChangesetEvent{
+				{Metadata: status(build, azuredevops.StatusStatePending, 20)},
+			},
+			want: cmpgn.ChangesetCheckStatePending,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr := &azuredevops.PullRequest{Statuses: tc.statuses}
+			have := computeAzureDevOpsCheckState(pr, tc.events)
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeBitbucketCloudCheckState(t *testing.T) {
+	status := func(key string, state bitbucketcloud.CommitStatusState, updated int64) *bitbucketcloud.CommitStatus {
+		return &bitbucketcloud.CommitStatus{BuildKey: key, State: state, UpdatedOn: time.Unix(updated, 0)}
+	}
+
+	for name, tc := range map[string]struct {
+		statuses []*bitbucketcloud.CommitStatus
+		events   []*cmpgn.ChangesetEvent
+		want     cmpgn.ChangesetCheckState
+	}{
+		"no statuses": {
+			want: cmpgn.ChangesetCheckStateUnknown,
+		},
+		"latest status per key wins": {
+			statuses: []*bitbucketcloud.CommitStatus{
+				status("build", bitbucketcloud.CommitStatusStateFailed, 10),
+				status("build", bitbucketcloud.CommitStatusStateSuccessful, 20),
+				status("lint", bitbucketcloud.CommitStatusStateSuccessful, 5),
+			},
+			want: cmpgn.ChangesetCheckStatePassed,
+		},
+		"stopped and passed": {
+			statuses: []*bitbucketcloud.CommitStatus{
+				status("build", bitbucketcloud.CommitStatusStateStopped, 10),
+				status("lint", bitbucketcloud.CommitStatusStateSuccessful, 10),
+			},
+			want: cmpgn.ChangesetCheckStateFailed,
+		},
+		"newer event": {
+			statuses: []*bitbucketcloud.CommitStatus{
+				status("build", bitbucketcloud.CommitStatusStateSuccessful, 10),
+			},
+			events: []*cmpgn.ChangesetEvent{
+				{Metadata: status("build", bitbucketcloud.CommitStatusStateInProgress, 20)},
+			},
+			want: cmpgn.ChangesetCheckStatePending,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr := &bitbucketcloud.PullRequest{Statuses: tc.statuses}
+			have := computeBitbucketCloudCheckState(pr, tc.events)
+			if have != tc.want {
+				t.Errorf("unexpected check state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestComputeAWSCodeCommitReviewState(t *testing.T) {
+	alice := "arn:aws:iam::123456789012:user/alice"
+	bob := "arn:aws:iam::123456789012:user/bob"
+
+	approval := func(actor, revision string, state awscodecommit.ApprovalState, date int64) *awscodecommit.ApprovalEvent {
+		return &awscodecommit.ApprovalEvent{ActorARN: actor, RevisionID: revision, State: state, Date: time.Unix(date, 0)}
+	}
+
+	for name, tc := range map[string]struct {
+		events []*awscodecommit.ApprovalEvent
+		want   cmpgn.ChangesetReviewState
+	}{
+		"no approvals": {
+			want: cmpgn.ChangesetReviewStatePending,
+		},
+		"approved": {
+			events: []*awscodecommit.ApprovalEvent{
+				approval(alice, "rev2", awscodecommit.ApprovalStateApprove, 10),
+			},
+			want: cmpgn.ChangesetReviewStateApproved,
+		},
+		"approval revoked": {
+			events: []*awscodecommit.ApprovalEvent{
+				approval(alice, "rev2", awscodecommit.ApprovalStateApprove, 10),
+				approval(alice, "rev2", awscodecommit.ApprovalStateRevoke, 20),
+			},
+			want: cmpgn.ChangesetReviewStatePending,
+		},
+		"approval of previous revision": {
+			events: []*awscodecommit.ApprovalEvent{
+				approval(alice, "rev1", awscodecommit.ApprovalStateApprove, 10),
+			},
+			want: cmpgn.ChangesetReviewStatePending,
+		},
+		"one revoked, one approved": {
+			events: []*awscodecommit.ApprovalEvent{
+				approval(alice, "rev2", awscodecommit.ApprovalStateApprove, 10),
+				approval(alice, "rev2", awscodecommit.ApprovalStateRevoke, 20),
+				approval(bob, "rev2", awscodecommit.ApprovalStateApprove, 15),
+			},
+			want: cmpgn.ChangesetReviewStateApproved,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &cmpgn.Changeset{Metadata: &awscodecommit.PullRequest{
+				RevisionID:     "rev2",
+				ApprovalEvents: tc.events,
+			}}
+			have, err := computeSingleChangesetReviewState(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("unexpected review state: have %s; want %s", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestComputeReviewState(t *testing.T) {
 	now := time.Now().UTC().Truncate(time.Microsecond)
 	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
@@ -0,0 +1,272 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignSpecExecutionInsertColumns is the list of campaign_spec_executions
+// columns that are set when enqueueing a campaign spec execution.
+var campaignSpecExecutionInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_id"),
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("base_ref"),
+	sqlf.Sprintf("base_rev"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// campaignSpecExecutionColumns are used by the campaign spec execution related
+// Store methods and by workerutil.Worker to load executions from the database
+// for processing by the campaign executor.
+var campaignSpecExecutionColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_executions.id"),
+	sqlf.Sprintf("campaign_spec_executions.campaign_spec_id"),
+	sqlf.Sprintf("campaign_spec_executions.repo_id"),
+	sqlf.Sprintf("(SELECT repo.name FROM repo WHERE repo.id = campaign_spec_executions.repo_id)"),
+	sqlf.Sprintf("campaign_spec_executions.base_ref"),
+	sqlf.Sprintf("campaign_spec_executions.base_rev"),
+	sqlf.Sprintf("campaign_spec_executions.changeset_spec_id"),
+	sqlf.Sprintf("campaign_spec_executions.log_contents"),
+	sqlf.Sprintf("campaign_spec_executions.state"),
+	sqlf.Sprintf("campaign_spec_executions.failure_message"),
+	sqlf.Sprintf("campaign_spec_executions.started_at"),
+	sqlf.Sprintf("campaign_spec_executions.finished_at"),
+	sqlf.Sprintf("campaign_spec_executions.process_after"),
+	sqlf.Sprintf("campaign_spec_executions.num_resets"),
+	sqlf.Sprintf("campaign_spec_executions.num_failures"),
+	sqlf.Sprintf("campaign_spec_executions.created_at"),
+	sqlf.Sprintf("campaign_spec_executions.updated_at"),
+}
+
+// CreateCampaignSpecExecution enqueues the given CampaignSpecExecution.
+func (s *Store) CreateCampaignSpecExecution(ctx context.Context, e *campaigns.CampaignSpecExecution) error {
+	q := s.createCampaignSpecExecutionQuery(e)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignSpecExecution(e, sc) })
+}
+
+var createCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:CreateCampaignSpecExecution
+INSERT INTO campaign_spec_executions (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING %s`
+
+func (s *Store) createCampaignSpecExecutionQuery(e *campaigns.CampaignSpecExecution) *sqlf.Query {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = e.CreatedAt
+	}
+
+	if e.State == "" {
+		e.State = campaigns.CampaignSpecExecutionStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionInsertColumns, ", "),
+		e.CampaignSpecID,
+		e.RepoID,
+		e.BaseRef,
+		e.BaseRev,
+		e.State.ToDB(),
+		e.CreatedAt,
+		e.UpdatedAt,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	)
+}
+
+// GetCampaignSpecExecution gets the campaign spec execution with the given ID.
+func (s *Store) GetCampaignSpecExecution(ctx context.Context, id int64) (*campaigns.CampaignSpecExecution, error) {
+	q := sqlf.Sprintf(
+		getCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+		id,
+	)
+
+	var e campaigns.CampaignSpecExecution
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignSpecExecution(&e, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:GetCampaignSpecExecution
+SELECT %s FROM campaign_spec_executions
+WHERE campaign_spec_executions.id = %s
+LIMIT 1
+`
+
+// ListCampaignSpecExecutionsOpts captures the query options needed for
+// listing campaign spec executions.
+type ListCampaignSpecExecutionsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	CampaignSpecID int64
+	State          campaigns.CampaignSpecExecutionState
+}
+
+// ListCampaignSpecExecutions lists CampaignSpecExecutions with the given filters.
+func (s *Store) ListCampaignSpecExecutions(ctx context.Context, opts ListCampaignSpecExecutionsOpts) (es []*campaigns.CampaignSpecExecution, next int64, err error) {
+	q := listCampaignSpecExecutionsQuery(&opts)
+
+	es = make([]*campaigns.CampaignSpecExecution, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var e campaigns.CampaignSpecExecution
+		if err := scanCampaignSpecExecution(&e, sc); err != nil {
+			return err
+		}
+		es = append(es, &e)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(es) == opts.DBLimit() {
+		next = es[len(es)-1].ID
+		es = es[:len(es)-1]
+	}
+
+	return es, next, err
+}
+
+var listCampaignSpecExecutionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:ListCampaignSpecExecutions
+SELECT %s FROM campaign_spec_executions
+WHERE %s
+ORDER BY campaign_spec_executions.id ASC
+`
+
+func listCampaignSpecExecutionsQuery(opts *ListCampaignSpecExecutionsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_spec_executions.id >= %s", opts.Cursor),
+	}
+
+	if opts.CampaignSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_spec_executions.campaign_spec_id = %s", opts.CampaignSpecID))
+	}
+
+	if opts.State != "" {
+		preds = append(preds, sqlf.Sprintf("campaign_spec_executions.state = %s", opts.State.ToDB()))
+	}
+
+	return sqlf.Sprintf(
+		listCampaignSpecExecutionsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// SetCampaignSpecExecutionLog stores the log of the commands run for the
+// campaign spec execution with the given ID.
+func (s *Store) SetCampaignSpecExecutionLog(ctx context.Context, id int64, log string) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(setCampaignSpecExecutionLogQueryFmtstr, log, s.now(), id))
+}
+
+var setCampaignSpecExecutionLogQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:SetCampaignSpecExecutionLog
+UPDATE campaign_spec_executions
+SET log_contents = %s, updated_at = %s
+WHERE id = %s
+`
+
+// SetCampaignSpecExecutionChangesetSpec records the ChangesetSpec produced by
+// the campaign spec execution with the given ID.
+func (s *Store) SetCampaignSpecExecutionChangesetSpec(ctx context.Context, id, changesetSpecID int64) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(setCampaignSpecExecutionChangesetSpecQueryFmtstr, changesetSpecID, s.now(), id))
+}
+
+var setCampaignSpecExecutionChangesetSpecQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:SetCampaignSpecExecutionChangesetSpec
+UPDATE campaign_spec_executions
+SET changeset_spec_id = %s, updated_at = %s
+WHERE id = %s
+`
+
+// DeleteCampaignSpecExecutions deletes the executions of the campaign spec
+// with the given ID that are in the given state.
+func (s *Store) DeleteCampaignSpecExecutions(ctx context.Context, campaignSpecID int64, state campaigns.CampaignSpecExecutionState) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(deleteCampaignSpecExecutionsQueryFmtstr, campaignSpecID, state.ToDB()))
+}
+
+var deleteCampaignSpecExecutionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:DeleteCampaignSpecExecutions
+DELETE FROM campaign_spec_executions
+WHERE campaign_spec_id = %s AND state = %s
+`
+
+func scanFirstCampaignSpecExecution(rows *sql.Rows, queryErr error) (*campaigns.CampaignSpecExecution, bool, error) {
+	if queryErr != nil {
+		return nil, false, queryErr
+	}
+
+	var es []*campaigns.CampaignSpecExecution
+	err := scanAll(rows, func(sc scanner) error {
+		var e campaigns.CampaignSpecExecution
+		if err := scanCampaignSpecExecution(&e, sc); err != nil {
+			return err
+		}
+		es = append(es, &e)
+		return nil
+	})
+	if err != nil || len(es) == 0 {
+		return &campaigns.CampaignSpecExecution{}, false, err
+	}
+
+	return es[0], true, nil
+}
+
+func scanCampaignSpecExecution(e *campaigns.CampaignSpecExecution, s scanner) error {
+	var (
+		state          string
+		failureMessage string
+	)
+
+	err := s.Scan(
+		&e.ID,
+		&e.CampaignSpecID,
+		&e.RepoID,
+		&dbutil.NullString{S: (*string)(&e.RepoName)},
+		&e.BaseRef,
+		&e.BaseRev,
+		&dbutil.NullInt64{N: &e.ChangesetSpecID},
+		&dbutil.NullString{S: &e.Log},
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &e.StartedAt},
+		&dbutil.NullTime{Time: &e.FinishedAt},
+		&dbutil.NullTime{Time: &e.ProcessAfter},
+		&e.NumResets,
+		&e.NumFailures,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign spec execution")
+	}
+
+	if failureMessage != "" {
+		e.FailureMessage = &failureMessage
+	}
+	e.State = campaigns.CampaignSpecExecutionState(strings.ToUpper(state))
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreCampaignSpecExecutions(t *testing.T, ctx context.Context, s *Store, rs repos.Store, clock clock) {
+	// A campaign spec is executed at most once per repository.
+	testRepos := []*repos.Repo{
+		testRepo(t, rs, extsvc.TypeGitHub),
+		testRepo(t, rs, extsvc.TypeGitHub),
+	}
+	if err := rs.InsertRepos(ctx, testRepos...); err != nil {
+		t.Fatal(err)
+	}
+
+	executions := make([]*cmpgn.CampaignSpecExecution, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(executions); i++ {
+			e := &cmpgn.CampaignSpecExecution{
+				CampaignSpecID: int64(i%2 + 1),
+				RepoID:         testRepos[i/2].ID,
+				BaseRef:        "refs/heads/master",
+				BaseRev:        "deadbeef",
+			}
+
+			want := e.Clone()
+			have := e
+
+			if err := s.CreateCampaignSpecExecution(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.RepoName = api.RepoName(testRepos[i/2].Name)
+			want.State = cmpgn.CampaignSpecExecutionStateQueued
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			executions = append(executions, e)
+		}
+	})
+
+	if len(executions) != cap(executions) {
+		t.Fatalf("executions is empty. creation failed")
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			for _, want := range executions {
+				have, err := s.GetCampaignSpecExecution(ctx, want.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetCampaignSpecExecution(ctx, 0xdeadbeef)
+			want := ErrNoResults
+
+			if have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("NoOptions", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, executions); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(executions); i++ {
+				cs, next, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{LimitOpts: LimitOpts{Limit: i}})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				{
+					have, want := next, int64(0)
+					if i < len(executions) {
+						want = executions[i].ID
+					}
+
+					if have != want {
+						t.Fatalf("limit: %v: have next %v, want %v", i, have, want)
+					}
+				}
+
+				if diff := cmp.Diff(cs, executions[:i]); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+
+		t.Run("CampaignSpecID", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []*cmpgn.CampaignSpecExecution{executions[0], executions[2]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("State", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{State: cmpgn.CampaignSpecExecutionStateProcessing})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(have) != 0 {
+				t.Fatalf("unexpected executions: %+v", have)
+			}
+		})
+	})
+
+	t.Run("SetCampaignSpecExecutionLog", func(t *testing.T) {
+		e := executions[0]
+		clock.add(1 * time.Second)
+
+		if err := s.SetCampaignSpecExecutionLog(ctx, e.ID, "ran some steps"); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetCampaignSpecExecution(ctx, e.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		e.Log = "ran some steps"
+		e.UpdatedAt = clock.now()
+
+		if diff := cmp.Diff(have, e); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("SetCampaignSpecExecutionChangesetSpec", func(t *testing.T) {
+		e := executions[1]
+		clock.add(1 * time.Second)
+
+		if err := s.SetCampaignSpecExecutionChangesetSpec(ctx, e.ID, 4242); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetCampaignSpecExecution(ctx, e.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		e.ChangesetSpecID = 4242
+		e.UpdatedAt = clock.now()
+
+		if diff := cmp.Diff(have, e); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("DeleteCampaignSpecExecutions", func(t *testing.T) {
+		if err := s.DeleteCampaignSpecExecutions(ctx, 1, cmpgn.CampaignSpecExecutionStateErrored); err != nil {
+			t.Fatal(err)
+		}
+
+		have, _, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 2 {
+			t.Fatalf("want queued executions to be kept, have %d executions", len(have))
+		}
+
+		if err := s.DeleteCampaignSpecExecutions(ctx, 1, cmpgn.CampaignSpecExecutionStateQueued); err != nil {
+			t.Fatal(err)
+		}
+
+		have, _, err = s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("want executions to be deleted, have %d executions", len(have))
+		}
+	})
+}
@@ -152,6 +152,17 @@ var deleteCampaignQueryFmtstr = `
 DELETE FROM campaigns WHERE id = %s
 `
 
+// LockCampaign locks the campaign with the given ID until the current
+// transaction ends, blocking until other transactions holding the lock end.
+func (s *Store) LockCampaign(ctx context.Context, id int64) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(lockCampaignQueryFmtstr, id))
+}
+
+var lockCampaignQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:LockCampaign
+SELECT id FROM campaigns WHERE id = %s FOR UPDATE
+`
+
 // CountCampaignsOpts captures the query options needed for
 // counting campaigns.
 type CountCampaignsOpts struct {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
@@ -13,7 +14,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
@@ -54,6 +59,8 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.unsynced"),
 	sqlf.Sprintf("changesets.closing"),
+	sqlf.Sprintf("changesets.merging"),
+	sqlf.Sprintf("changesets.publication_slot"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -90,6 +97,8 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("unsynced"),
 	sqlf.Sprintf("closing"),
+	sqlf.Sprintf("merging"),
+	sqlf.Sprintf("publication_slot"),
 }
 
 func (s *Store) changesetWriteQuery(q string, includeID bool, c *campaigns.Changeset) (*sqlf.Query, error) {
@@ -141,6 +150,8 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *campaigns.Chang
 		c.NumFailures,
 		c.Unsynced,
 		c.Closing,
+		c.Merging,
+		nullTimeColumn(c.PublicationSlot),
 	}
 
 	if includeID {
@@ -173,7 +184,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *campaigns.Changeset) err
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -228,11 +239,7 @@ func countChangesetsQuery(opts *CountChangesetsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.external_check_state = %s", *opts.ExternalCheckState))
 	}
 	if len(opts.ReconcilerStates) != 0 {
-		states := make([]*sqlf.Query, len(opts.ReconcilerStates))
-		for i, reconcilerState := range opts.ReconcilerStates {
-			states[i] = sqlf.Sprintf("%s", reconcilerState.ToDB())
-		}
-		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state IN (%s)", sqlf.Join(states, ",")))
+		preds = append(preds, reconcilerStatesPredicate(opts.ReconcilerStates))
 	}
 	if opts.OwnedByCampaignID != 0 {
 		preds = append(preds, sqlf.Sprintf("changesets.owned_by_campaign_id = %s", opts.OwnedByCampaignID))
@@ -241,6 +248,36 @@ func countChangesetsQuery(opts *CountChangesetsOpts) *sqlf.Query {
 	return sqlf.Sprintf(countChangesetsQueryFmtstr, sqlf.Join(preds, "\n AND "))
 }
 
+// reconcilerStatesPredicate returns a predicate that matches changesets in any
+// of the given reconciler states. ReconcilerStateScheduled isn't stored in the
+// database, so it matches queued changesets that wait for their publication
+// slot, and ReconcilerStateQueued matches the rest of the queued changesets.
+func reconcilerStatesPredicate(states []campaigns.ReconcilerState) *sqlf.Query {
+	scheduled := sqlf.Sprintf(
+		"(changesets.reconciler_state = %s AND changesets.publication_state = %s AND changesets.publication_slot IS NOT NULL AND changesets.process_after > NOW())",
+		campaigns.ReconcilerStateQueued.ToDB(),
+		campaigns.ChangesetPublicationStateUnpublished,
+	)
+
+	var stored []*sqlf.Query
+	var preds []*sqlf.Query
+	for _, state := range states {
+		switch state {
+		case campaigns.ReconcilerStateScheduled:
+			preds = append(preds, scheduled)
+		case campaigns.ReconcilerStateQueued:
+			preds = append(preds, sqlf.Sprintf("(changesets.reconciler_state = %s AND NOT %s)", state.ToDB(), scheduled))
+		default:
+			stored = append(stored, sqlf.Sprintf("%s", state.ToDB()))
+		}
+	}
+	if len(stored) != 0 {
+		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state IN (%s)", sqlf.Join(stored, ",")))
+	}
+
+	return sqlf.Sprintf("(%s)", sqlf.Join(preds, " OR "))
+}
+
 // GetChangesetOpts captures the query options needed for getting a Changeset
 type GetChangesetOpts struct {
 	ID                  int64
@@ -449,11 +486,7 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.publication_state = %s", *opts.PublicationState))
 	}
 	if len(opts.ReconcilerStates) != 0 {
-		states := make([]*sqlf.Query, len(opts.ReconcilerStates))
-		for i, reconcilerState := range opts.ReconcilerStates {
-			states[i] = sqlf.Sprintf("%s", reconcilerState.ToDB())
-		}
-		preds = append(preds, sqlf.Sprintf("changesets.reconciler_state IN (%s)", sqlf.Join(states, ",")))
+		preds = append(preds, reconcilerStatesPredicate(opts.ReconcilerStates))
 	}
 	if opts.ExternalState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.external_state = %s", *opts.ExternalState))
@@ -531,7 +564,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *campaigns.Changeset) er
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -607,6 +640,23 @@ SET
 WHERE id IN (SELECT id FROM changeset_ids);
 `
 
+// LatestPublicationSlot returns the latest time at which a changeset owned by
+// the given campaign was published or is scheduled to be published according
+// to the campaign's publication schedule. If there's none, it returns the zero
+// time.
+func (s *Store) LatestPublicationSlot(ctx context.Context, campaignID int64) (time.Time, error) {
+	var slot time.Time
+	err := s.query(ctx, sqlf.Sprintf(latestPublicationSlotQueryFmtstr, campaignID), func(sc scanner) error {
+		return sc.Scan(&dbutil.NullTime{Time: &slot})
+	})
+	return slot, err
+}
+
+const latestPublicationSlotQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:LatestPublicationSlot
+SELECT MAX(publication_slot) FROM changesets WHERE owned_by_campaign_id = %s
+`
+
 // EnqueueChangesetsToClose updates all changesets that are owned by the given
 // campaign to set their reconciler status to 'queued' and the Closing boolean
 // to true.
@@ -618,6 +668,7 @@ WHERE id IN (SELECT id FROM changeset_ids);
 func (s *Store) EnqueueChangesetsToClose(ctx context.Context, campaignID int64) error {
 	q := sqlf.Sprintf(
 		enqueueChangesetsToCloseFmtstr,
+		campaigns.ChangesetPublicationStateUnpublished,
 		campaignID,
 		campaigns.ChangesetExternalStateClosed,
 		campaigns.ChangesetExternalStateMerged,
@@ -633,7 +684,10 @@ SET
   reconciler_state = 'queued',
   failure_message = NULL,
   num_failures = 0,
-  closing = TRUE
+  closing = TRUE,
+  -- Unpublished changesets that are scheduled to be published don't need to
+  -- wait for their turn.
+  process_after = CASE WHEN publication_state = %s THEN NULL ELSE process_after END
 WHERE
   owned_by_campaign_id = %d
 AND
@@ -713,6 +767,8 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Unsynced,
 		&t.Closing,
+		&t.Merging,
+		&dbutil.NullTime{Time: &t.PublicationSlot},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -733,6 +789,14 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		t.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeGerrit:
+		t.Metadata = new(gerrit.Change)
+	case extsvc.TypeAzureDevOps:
+		t.Metadata = new(azuredevops.PullRequest)
+	case extsvc.TypeBitbucketCloud:
+		t.Metadata = new(bitbucketcloud.PullRequest)
+	case extsvc.TypeAWSCodeCommit:
+		t.Metadata = new(awscodecommit.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -109,6 +109,7 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 				th.StartedAt = clock.now()
 				th.FinishedAt = clock.now()
 				th.ProcessAfter = clock.now()
+				th.PublicationSlot = clock.now()
 			}
 
 			if err := s.CreateChangeset(ctx, th); err != nil {
@@ -711,6 +712,7 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 			c.StartedAt = clock.now()
 			c.FinishedAt = clock.now()
 			c.ProcessAfter = clock.now()
+			c.PublicationSlot = clock.now()
 			c.NumResets = 987
 			c.NumFailures = 789
 
@@ -547,11 +547,19 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 	var (
 		events []*campaigns.ChangesetEvent
 		cs     []*campaigns.Changeset
+
+		// The state before syncing of the changesets, to determine whether
+		// they need to be checked against auto-merge policies again.
+		autoMergeStates = map[int64]autoMergeState{}
 	)
 
 	for _, s := range bySource {
 		var notFound []*repos.Changeset
 
+		for _, c := range s.Changesets {
+			autoMergeStates[c.Changeset.ID] = autoMergeStateOf(c.Changeset)
+		}
+
 		err := s.LoadChangesets(ctx, s.Changesets...)
 		if err != nil {
 			notFoundErr, ok := err.(repos.ChangesetsNotFoundError)
@@ -600,6 +608,11 @@ func syncChangesetsWithSources(ctx context.Context, store SyncStore, bySource []
 
 	for _, c := range cs {
 		c.Unsynced = false
+		if !autoMergeStateOf(c).Equal(autoMergeStates[c.ID]) {
+			if err = enqueueAutoMerge(ctx, tx, c); err != nil {
+				return err
+			}
+		}
 		if err = tx.UpdateChangeset(ctx, c); err != nil {
 			return err
 		}
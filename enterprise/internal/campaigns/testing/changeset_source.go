@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 )
 
@@ -21,6 +22,7 @@ type FakeChangesetSource struct {
 	LoadChangesetsCalled   bool
 	CloseChangesetCalled   bool
 	ReopenChangesetCalled  bool
+	MergeChangesetCalled   bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -54,6 +56,12 @@ type FakeChangesetSource struct {
 
 	// ReopenedChangesets contains the changesets that were passed to ReopenedChangeset
 	ReopenedChangesets []*repos.Changeset
+
+	// MergedChangesets contains the changesets that were passed to
+	// MergeChangeset
+	MergedChangesets []*repos.Changeset
+	// MergeMethod is the merge method that was passed to MergeChangeset
+	MergeMethod campaigns.ChangesetMergeMethod
 }
 
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
@@ -169,6 +177,23 @@ func (s *FakeChangesetSource) ReopenChangeset(ctx context.Context, c *repos.Chan
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *repos.Changeset, method campaigns.ChangesetMergeMethod) error {
+	s.MergeChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.Repo == nil {
+		return NoReposErr
+	}
+
+	s.MergedChangesets = append(s.MergedChangesets, c)
+	s.MergeMethod = method
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
 // FakeGitserverClient is a test implementation of the GitserverClient
 // interface required by ExecChangesetJob.
 type FakeGitserverClient struct {
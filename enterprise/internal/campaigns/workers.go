@@ -72,6 +72,32 @@ func scanFirstChangesetRecord(rows *sql.Rows, err error) (workerutil.Record, boo
 	return scanFirstChangeset(rows, err)
 }
 
+// NewCampaignSpecExecutionWorkerStore returns a dbworkerstore.Store that
+// fetches enqueued campaign spec executions from the database. It's used by
+// the campaign executor.
+func NewCampaignSpecExecutionWorkerStore(s *Store) dbworkerstore.Store {
+	return dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "campaign_spec_executions",
+		ColumnExpressions: campaignSpecExecutionColumns,
+		Scan:              scanFirstCampaignSpecExecutionRecord,
+
+		// Process executions in the order they were enqueued.
+		OrderByExpression: sqlf.Sprintf("campaign_spec_executions.created_at, campaign_spec_executions.id"),
+
+		StalledMaxAge: 5 * time.Second,
+		MaxNumResets:  executorMaxNumResets,
+	})
+}
+
+// executorMaxNumResets is the maximum number of attempts the campaign executor
+// makes to process a campaign spec execution when it stalls (process crashes,
+// etc.).
+const executorMaxNumResets = 3
+
+func scanFirstCampaignSpecExecutionRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return scanFirstCampaignSpecExecution(rows, err)
+}
+
 func newObservationOperation() *observation.Operation {
 	observationContext := &observation.Context{
 		Logger:     log15.Root(),
@@ -29,11 +29,22 @@ type CodeIntelAPI interface {
 	// This may include references from other dumps and repositories.
 	References(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
 
+	// Implementations returns the list of source locations that implement the symbol at the given position.
+	// This may include remote implementations if the symbol is imported from another indexed repository.
+	Implementations(ctx context.Context, file string, line, character, uploadID int) ([]ResolvedLocation, error)
+
+	// TypeDefinitions returns the list of source locations that define the type of the symbol at the given
+	// position. This may include remote type definitions if the symbol is imported from another indexed repository.
+	TypeDefinitions(ctx context.Context, file string, line, character, uploadID int) ([]ResolvedLocation, error)
+
 	// Hover returns the hover text and range for the symbol at the given position.
 	Hover(ctx context.Context, file string, line, character, uploadID int) (string, bundles.Range, bool, error)
 
 	// Diagnostics returns the diagnostics for documents with the given path prefix.
 	Diagnostics(ctx context.Context, prefix string, uploadID, limit, offset int) ([]ResolvedDiagnostic, int, error)
+
+	// DocumentSymbols returns the hierarchy of symbols defined in the given file.
+	DocumentSymbols(ctx context.Context, file string, uploadID int) ([]bundles.DocumentSymbol, error)
 }
 
 type codeIntelAPI struct {
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+)
+
+// DocumentSymbols returns the hierarchy of symbols defined in the given file.
+func (api *codeIntelAPI) DocumentSymbols(ctx context.Context, file string, uploadID int) ([]bundles.DocumentSymbol, error) {
+	dump, exists, err := api.store.GetDumpByID(ctx, uploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetDumpByID")
+	}
+	if !exists {
+		return nil, ErrMissingDump
+	}
+
+	pathInBundle := strings.TrimPrefix(file, dump.Root)
+	bundleClient := api.bundleManagerClient.BundleClient(dump.ID)
+
+	symbols, err := bundleClient.DocumentSymbols(ctx, pathInBundle)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient.DocumentSymbols")
+	}
+
+	return symbols, nil
+}
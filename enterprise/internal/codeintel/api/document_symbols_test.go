@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+	mockGitserverClient := NewMockGitserverClient()
+
+	sourceSymbols := []bundles.DocumentSymbol{
+		{
+			Name:      "foo",
+			Detail:    "func()",
+			Kind:      12,
+			Range:     bundles.Range{Start: bundles.Position{1, 5}, End: bundles.Position{1, 8}},
+			FullRange: bundles.Range{Start: bundles.Position{1, 0}, End: bundles.Position{3, 1}},
+			Children: []bundles.DocumentSymbol{
+				{
+					Name:      "bar",
+					Kind:      13,
+					Range:     bundles.Range{Start: bundles.Position{2, 1}, End: bundles.Position{2, 4}},
+					FullRange: bundles.Range{Start: bundles.Position{2, 1}, End: bundles.Position{2, 10}},
+				},
+			},
+		},
+	}
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientDocumentSymbols(t, mockBundleClient, "main.go", sourceSymbols)
+
+	api := testAPI(mockStore, mockBundleManagerClient, mockGitserverClient)
+	symbols, err := api.DocumentSymbols(context.Background(), "sub1/main.go", 42)
+	if err != nil {
+		t.Fatalf("expected error getting document symbols: %s", err)
+	}
+
+	if diff := cmp.Diff(sourceSymbols, symbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestDocumentSymbolsUnknownDump(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockGitserverClient := NewMockGitserverClient()
+	setMockStoreGetDumpByID(t, mockStore, nil)
+
+	api := testAPI(mockStore, mockBundleManagerClient, mockGitserverClient)
+	if _, err := api.DocumentSymbols(context.Background(), "sub1/main.go", 42); err != ErrMissingDump {
+		t.Fatalf("unexpected error getting document symbols. want=%q have=%q", ErrMissingDump, err)
+	}
+}
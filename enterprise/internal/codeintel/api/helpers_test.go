@@ -247,6 +247,36 @@ func setMockBundleClientDefinitions(t *testing.T, mockBundleClient *bundlemocks.
 	})
 }
 
+func setMockBundleClientImplementations(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, locations []bundles.Location) {
+	mockBundleClient.ImplementationsFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+		if path != expectedPath {
+			t.Errorf("unexpected path for Implementations. want=%s have=%s", expectedPath, path)
+		}
+		if line != expectedLine {
+			t.Errorf("unexpected line for Implementations. want=%d have=%d", expectedLine, line)
+		}
+		if character != expectedCharacter {
+			t.Errorf("unexpected character for Implementations. want=%d have=%d", expectedCharacter, character)
+		}
+		return locations, nil
+	})
+}
+
+func setMockBundleClientTypeDefinitions(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, locations []bundles.Location) {
+	mockBundleClient.TypeDefinitionsFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+		if path != expectedPath {
+			t.Errorf("unexpected path for TypeDefinitions. want=%s have=%s", expectedPath, path)
+		}
+		if line != expectedLine {
+			t.Errorf("unexpected line for TypeDefinitions. want=%d have=%d", expectedLine, line)
+		}
+		if character != expectedCharacter {
+			t.Errorf("unexpected character for TypeDefinitions. want=%d have=%d", expectedCharacter, character)
+		}
+		return locations, nil
+	})
+}
+
 func setMockBundleClientReferences(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, locations []bundles.Location) {
 	mockBundleClient.ReferencesFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
 		if path != expectedPath {
@@ -292,6 +322,15 @@ func setMockBundleClientDiagnostics(t *testing.T, mockBundleClient *bundlemocks.
 	})
 }
 
+func setMockBundleClientDocumentSymbols(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, symbols []bundles.DocumentSymbol) {
+	mockBundleClient.DocumentSymbolsFunc.SetDefaultHook(func(ctx context.Context, path string) ([]bundles.DocumentSymbol, error) {
+		if path != expectedPath {
+			t.Errorf("unexpected path for DocumentSymbols. want=%s have=%s", expectedPath, path)
+		}
+		return symbols, nil
+	})
+}
+
 func setMockBundleClientMonikersByPosition(t *testing.T, mockBundleClient *bundlemocks.MockBundleClient, expectedPath string, expectedLine, expectedCharacter int, monikers [][]bundles.MonikerData) {
 	mockBundleClient.MonikersByPositionFunc.SetDefaultHook(func(ctx context.Context, path string, line, character int) ([][]bundles.MonikerData, error) {
 		if path != expectedPath {
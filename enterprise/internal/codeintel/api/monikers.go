@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -48,3 +49,68 @@ func lookupMoniker(
 
 	return resolveLocationsWithDump(dump, locations), count, nil
 }
+
+// positionQuery returns the locations of a result attached to the symbol at the given position of a bundle.
+type positionQuery func(bundleClient bundles.BundleClient, ctx context.Context, path string, line, character int) ([]bundles.Location, error)
+
+// definitionAttachedResultsRaw returns the locations of the result that query attaches to the symbol at the
+// given position. If the bundle has no such result and the symbol is imported from another bundle, then the
+// query is re-run against the symbol's definitions in that bundle, which is where an indexer attaches results
+// such as the implementations or the type definition of an imported interface.
+func (api *codeIntelAPI) definitionAttachedResultsRaw(ctx context.Context, dump store.Dump, bundleClient bundles.BundleClient, pathInBundle string, line, character int, queryName string, query positionQuery) ([]ResolvedLocation, error) {
+	locations, err := query(bundleClient, ctx, pathInBundle, line, character)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient."+queryName)
+	}
+	if len(locations) > 0 {
+		return resolveLocationsWithDump(dump, locations), nil
+	}
+
+	rangeMonikers, err := bundleClient.MonikersByPosition(ctx, pathInBundle, line, character)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "bundleClient.MonikersByPosition")
+	}
+
+	for _, monikers := range rangeMonikers {
+		for _, moniker := range monikers {
+			if moniker.Kind != "import" {
+				continue
+			}
+
+			definitions, _, err := lookupMoniker(api.store, api.bundleManagerClient, dump.ID, pathInBundle, "definition", moniker, 0, DefintionMonikersLimit)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, definition := range definitions {
+				locations, err := query(
+					api.bundleManagerClient.BundleClient(definition.Dump.ID),
+					ctx,
+					strings.TrimPrefix(definition.Path, definition.Dump.Root),
+					definition.Range.Start.Line,
+					definition.Range.Start.Character,
+				)
+				if err != nil {
+					if err == bundles.ErrNotFound {
+						log15.Warn("Bundle does not exist")
+						continue
+					}
+					return nil, errors.Wrap(err, "bundleClient."+queryName)
+				}
+				if len(locations) > 0 {
+					return resolveLocationsWithDump(definition.Dump, locations), nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+)
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the given
+// position. This may include remote type definitions if the symbol is imported from another indexed repository.
+func (api *codeIntelAPI) TypeDefinitions(ctx context.Context, file string, line, character, uploadID int) ([]ResolvedLocation, error) {
+	dump, exists, err := api.store.GetDumpByID(ctx, uploadID)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetDumpByID")
+	}
+	if !exists {
+		return nil, ErrMissingDump
+	}
+
+	pathInBundle := strings.TrimPrefix(file, dump.Root)
+	bundleClient := api.bundleManagerClient.BundleClient(dump.ID)
+	return api.definitionAttachedResultsRaw(ctx, dump, bundleClient, pathInBundle, line, character, "TypeDefinitions", bundles.BundleClient.TypeDefinitions)
+}
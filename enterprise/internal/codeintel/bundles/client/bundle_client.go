@@ -28,12 +28,21 @@ type BundleClient interface {
 	// Definitions retrieves a list of reference locations for the symbol under the given location.
 	References(ctx context.Context, path string, line, character int) ([]Location, error)
 
+	// Implementations retrieves a list of implementation locations for the symbol under the given location.
+	Implementations(ctx context.Context, path string, line, character int) ([]Location, error)
+
+	// TypeDefinitions retrieves a list of type definition locations for the symbol under the given location.
+	TypeDefinitions(ctx context.Context, path string, line, character int) ([]Location, error)
+
 	// Hover retrieves the hover text for the symbol under the given location.
 	Hover(ctx context.Context, path string, line, character int) (string, Range, bool, error)
 
 	// Diagnostics retrieves the diagnostics and total count of diagnostics for the documents that have the given path prefix.
 	Diagnostics(ctx context.Context, prefix string, skip, take int) ([]Diagnostic, int, error)
 
+	// DocumentSymbols retrieves the hierarchy of symbols defined in the given document.
+	DocumentSymbols(ctx context.Context, path string) ([]DocumentSymbol, error)
+
 	// MonikersByPosition retrieves a list of monikers attached to the symbol under the given location. There may
 	// be multiple ranges enclosing this point. The returned monikers are partitioned such that inner ranges occur
 	// first in the result, and outer ranges occur later.
@@ -116,6 +125,38 @@ func (c *bundleClientImpl) References(ctx context.Context, path string, line, ch
 	return locations, err
 }
 
+// Implementations retrieves a list of implementation locations for the symbol under the given location.
+func (c *bundleClientImpl) Implementations(ctx context.Context, path string, line, character int) (locations []Location, err error) {
+	args := map[string]interface{}{
+		"path":      path,
+		"line":      line,
+		"character": character,
+	}
+
+	err = c.request(ctx, "implementations", args, &locations, func(db database.Database) (err error) {
+		locations, err = db.Implementations(ctx, path, line, character)
+		return err
+	})
+	c.addBundleIDToLocations(locations)
+	return locations, err
+}
+
+// TypeDefinitions retrieves a list of type definition locations for the symbol under the given location.
+func (c *bundleClientImpl) TypeDefinitions(ctx context.Context, path string, line, character int) (locations []Location, err error) {
+	args := map[string]interface{}{
+		"path":      path,
+		"line":      line,
+		"character": character,
+	}
+
+	err = c.request(ctx, "typeDefinitions", args, &locations, func(db database.Database) (err error) {
+		locations, err = db.TypeDefinitions(ctx, path, line, character)
+		return err
+	})
+	c.addBundleIDToLocations(locations)
+	return locations, err
+}
+
 // Hover retrieves the hover text for the symbol under the given location.
 func (c *bundleClientImpl) Hover(ctx context.Context, path string, line, character int) (string, Range, bool, error) {
 	args := map[string]interface{}{
@@ -193,6 +234,15 @@ func (c *bundleClientImpl) Diagnostics(ctx context.Context, prefix string, skip,
 	return diagnostics, count, err
 }
 
+// DocumentSymbols retrieves the hierarchy of symbols defined in the given document.
+func (c *bundleClientImpl) DocumentSymbols(ctx context.Context, path string) (symbols []DocumentSymbol, err error) {
+	err = c.request(ctx, "documentSymbols", map[string]interface{}{"path": path}, &symbols, func(db database.Database) (err error) {
+		symbols, err = db.DocumentSymbols(ctx, path)
+		return err
+	})
+	return symbols, err
+}
+
 // MonikersByPosition retrieves a list of monikers attached to the symbol under the given location. There may
 // be multiple ranges enclosing this point. The returned monikers are partitioned such that inner ranges occur
 // first in the result, and outer ranges occur later.
@@ -278,6 +278,116 @@ func TestDefinitionsDB(t *testing.T) {
 	}
 }
 
+func TestImplementations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/implementations", map[string]string{
+			"path":      "main.go",
+			"line":      "10",
+			"character": "20",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "foo.go", "range": {"start": {"line": 1, "character": 2}, "end": {"line": 3, "character": 4}}},
+			{"path": "bar.go", "range": {"start": {"line": 5, "character": 6}, "end": {"line": 7, "character": 8}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, postgresreader.ErrNoMetadata)
+	base := &bundleManagerClientImpl{bundleManagerURL: ts.URL}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore}
+	implementations, err := client.Implementations(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	} else if diff := cmp.Diff(expected, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+}
+
+func TestImplementationsDB(t *testing.T) {
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, nil)
+	mockDatabase := databasemocks.NewMockDatabase()
+	mockDatabase.ImplementationsFunc.SetDefaultReturn(expected, nil)
+	databaseOpener := func(ctx context.Context, filename string, s persistence.Store) (database.Database, error) {
+		return mockDatabase, nil
+	}
+	base := &bundleManagerClientImpl{}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore, databaseOpener: databaseOpener}
+	implementations, err := client.Implementations(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying implementations: %s", err)
+	} else if diff := cmp.Diff(expected, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+}
+
+func TestTypeDefinitions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/typeDefinitions", map[string]string{
+			"path":      "main.go",
+			"line":      "10",
+			"character": "20",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{"path": "foo.go", "range": {"start": {"line": 1, "character": 2}, "end": {"line": 3, "character": 4}}},
+			{"path": "bar.go", "range": {"start": {"line": 5, "character": 6}, "end": {"line": 7, "character": 8}}}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, postgresreader.ErrNoMetadata)
+	base := &bundleManagerClientImpl{bundleManagerURL: ts.URL}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore}
+	typeDefinitions, err := client.TypeDefinitions(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	} else if diff := cmp.Diff(expected, typeDefinitions); diff != "" {
+		t.Errorf("unexpected type definitions (-want +got):\n%s", diff)
+	}
+}
+
+func TestTypeDefinitionsDB(t *testing.T) {
+	expected := []Location{
+		{DumpID: 42, Path: "foo.go", Range: Range{Start: Position{1, 2}, End: Position{3, 4}}},
+		{DumpID: 42, Path: "bar.go", Range: Range{Start: Position{5, 6}, End: Position{7, 8}}},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, nil)
+	mockDatabase := databasemocks.NewMockDatabase()
+	mockDatabase.TypeDefinitionsFunc.SetDefaultReturn(expected, nil)
+	databaseOpener := func(ctx context.Context, filename string, s persistence.Store) (database.Database, error) {
+		return mockDatabase, nil
+	}
+	base := &bundleManagerClientImpl{}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore, databaseOpener: databaseOpener}
+	typeDefinitions, err := client.TypeDefinitions(context.Background(), "main.go", 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error querying type definitions: %s", err)
+	} else if diff := cmp.Diff(expected, typeDefinitions); diff != "" {
+		t.Errorf("unexpected type definitions (-want +got):\n%s", diff)
+	}
+}
+
 func TestReferences(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/references", map[string]string{
@@ -568,6 +678,90 @@ func TestDiagnosticsDB(t *testing.T) {
 	}
 }
 
+func TestDocumentSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assertRequest(t, r, "GET", "/dbs/42/documentSymbols", map[string]string{
+			"path": "main.go",
+		})
+
+		_, _ = w.Write([]byte(`[
+			{
+				"name": "foo",
+				"detail": "func()",
+				"kind": 12,
+				"range": {"start": {"line": 1, "character": 5}, "end": {"line": 1, "character": 8}},
+				"fullRange": {"start": {"line": 1, "character": 0}, "end": {"line": 3, "character": 1}},
+				"children": [
+					{
+						"name": "bar",
+						"kind": 13,
+						"range": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 4}},
+						"fullRange": {"start": {"line": 2, "character": 1}, "end": {"line": 2, "character": 10}}
+					}
+				]
+			}
+		]`))
+	}))
+	defer ts.Close()
+
+	expected := []DocumentSymbol{
+		{
+			Name:      "foo",
+			Detail:    "func()",
+			Kind:      12,
+			Range:     Range{Start: Position{1, 5}, End: Position{1, 8}},
+			FullRange: Range{Start: Position{1, 0}, End: Position{3, 1}},
+			Children: []DocumentSymbol{
+				{
+					Name:      "bar",
+					Kind:      13,
+					Range:     Range{Start: Position{2, 1}, End: Position{2, 4}},
+					FullRange: Range{Start: Position{2, 1}, End: Position{2, 10}},
+				},
+			},
+		},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, postgresreader.ErrNoMetadata)
+	base := &bundleManagerClientImpl{bundleManagerURL: ts.URL}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore}
+	symbols, err := client.DocumentSymbols(context.Background(), "main.go")
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	} else if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestDocumentSymbolsDB(t *testing.T) {
+	expected := []DocumentSymbol{
+		{
+			Name:      "foo",
+			Detail:    "func()",
+			Kind:      12,
+			Range:     Range{Start: Position{1, 5}, End: Position{1, 8}},
+			FullRange: Range{Start: Position{1, 0}, End: Position{3, 1}},
+		},
+	}
+
+	mockStore := persistencemocks.NewMockStore()
+	mockStore.ReadMetaFunc.SetDefaultReturn(types.MetaData{}, nil)
+	mockDatabase := databasemocks.NewMockDatabase()
+	mockDatabase.DocumentSymbolsFunc.SetDefaultReturn(expected, nil)
+	databaseOpener := func(ctx context.Context, filename string, s persistence.Store) (database.Database, error) {
+		return mockDatabase, nil
+	}
+	base := &bundleManagerClientImpl{}
+	client := &bundleClientImpl{base: base, bundleID: 42, store: mockStore, databaseOpener: databaseOpener}
+	symbols, err := client.DocumentSymbols(context.Background(), "main.go")
+	if err != nil {
+		t.Fatalf("unexpected error querying document symbols: %s", err)
+	} else if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+}
+
 func TestMonikersByPosition(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assertRequest(t, r, "GET", "/dbs/42/monikersByPosition", map[string]string{
@@ -9,3 +9,4 @@ type MonikerData = clienttypes.MonikerData
 type PackageInformationData = clienttypes.PackageInformationData
 type Diagnostic = clienttypes.Diagnostic
 type CodeIntelligenceRange = clienttypes.CodeIntelligenceRange
+type DocumentSymbol = clienttypes.DocumentSymbol
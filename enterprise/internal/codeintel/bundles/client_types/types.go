@@ -55,3 +55,14 @@ type CodeIntelligenceRange struct {
 	References  []Location `json:"references"`
 	HoverText   string     `json:"hoverText"`
 }
+
+// DocumentSymbol describes a symbol declared within a document, along with the
+// symbols nested within it.
+type DocumentSymbol struct {
+	Name      string           `json:"name"`
+	Detail    string           `json:"detail"`
+	Kind      int              `json:"kind"`
+	Range     Range            `json:"range"`     // the range of the symbol's name
+	FullRange Range            `json:"fullRange"` // the range of the symbol's declaration
+	Children  []DocumentSymbol `json:"children"`
+}
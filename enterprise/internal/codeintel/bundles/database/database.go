@@ -31,6 +31,12 @@ type Database interface {
 	// References returns the set of locations referencing the symbol at the given position.
 	References(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
 
+	// Implementations returns the set of locations implementing the symbol at the given position.
+	Implementations(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
+
+	// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+	TypeDefinitions(ctx context.Context, path string, line, character int) ([]bundles.Location, error)
+
 	// Hover returns the hover text of the symbol at the given position.
 	Hover(ctx context.Context, path string, line, character int) (string, bundles.Range, bool, error)
 
@@ -38,6 +44,9 @@ type Database interface {
 	// also returns the size of the complete result set to aid in pagination (along with skip and take).
 	Diagnostics(ctx context.Context, prefix string, skip, take int) ([]bundles.Diagnostic, int, error)
 
+	// DocumentSymbols returns the hierarchy of symbols declared in the document with the given path.
+	DocumentSymbols(ctx context.Context, path string) ([]bundles.DocumentSymbol, error)
+
 	// MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 	// ranges contain the position, then this method will return multiple sets of monikers. Each slice
 	// of monikers are attached to a single range. The order of the output slice is "outside-in", so that
@@ -220,6 +229,41 @@ func (db *databaseImpl) References(ctx context.Context, path string, line, chara
 	return allLocations, nil
 }
 
+// Implementations returns the set of locations implementing the symbol at the given position.
+func (db *databaseImpl) Implementations(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+	return db.resultLocationsByPosition(ctx, path, line, character, func(r types.RangeData) types.ID { return r.ImplementationResultID })
+}
+
+// TypeDefinitions returns the set of locations defining the type of the symbol at the given position.
+func (db *databaseImpl) TypeDefinitions(ctx context.Context, path string, line, character int) ([]bundles.Location, error) {
+	return db.resultLocationsByPosition(ctx, path, line, character, func(r types.RangeData) types.ID { return r.TypeDefinitionResultID })
+}
+
+// resultLocationsByPosition returns the locations of the result attached to the first range containing
+// the given position that has a non-empty result identifier (as determined by getResultID).
+func (db *databaseImpl) resultLocationsByPosition(ctx context.Context, path string, line, character int, getResultID func(r types.RangeData) types.ID) ([]bundles.Location, error) {
+	_, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
+	if err != nil || !exists {
+		return nil, pkgerrors.Wrap(err, "db.getRangeByPosition")
+	}
+
+	for _, r := range ranges {
+		resultID := getResultID(r)
+		if resultID == "" {
+			continue
+		}
+
+		locations, err := db.locations(ctx, []types.ID{resultID})
+		if err != nil {
+			return nil, err
+		}
+
+		return locations[resultID], nil
+	}
+
+	return []bundles.Location{}, nil
+}
+
 // Hover returns the hover text of the symbol at the given position.
 func (db *databaseImpl) Hover(ctx context.Context, path string, line, character int) (string, bundles.Range, bool, error) {
 	documentData, ranges, exists, err := db.getRangeByPosition(ctx, path, line, character)
@@ -288,6 +332,36 @@ func (db *databaseImpl) Diagnostics(ctx context.Context, prefix string, skip, ta
 	return diagnostics, totalCount, nil
 }
 
+// DocumentSymbols returns the hierarchy of symbols declared in the document with the given path.
+func (db *databaseImpl) DocumentSymbols(ctx context.Context, path string) ([]bundles.DocumentSymbol, error) {
+	documentData, exists, err := db.getDocumentData(ctx, path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db.getDocumentData")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	return convertSymbols(documentData.Symbols), nil
+}
+
+// convertSymbols converts the given symbol data into document symbols.
+func convertSymbols(symbols []types.SymbolData) []bundles.DocumentSymbol {
+	documentSymbols := make([]bundles.DocumentSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		documentSymbols = append(documentSymbols, bundles.DocumentSymbol{
+			Name:      symbol.Name,
+			Detail:    symbol.Detail,
+			Kind:      symbol.Kind,
+			Range:     newRange(symbol.StartLine, symbol.StartCharacter, symbol.EndLine, symbol.EndCharacter),
+			FullRange: newRange(symbol.FullStartLine, symbol.FullStartCharacter, symbol.FullEndLine, symbol.FullEndCharacter),
+			Children:  convertSymbols(symbol.Children),
+		})
+	}
+
+	return documentSymbols
+}
+
 // MonikersByPosition returns all monikers attached ranges containing the given position. If multiple
 // ranges contain the position, then this method will return multiple sets of monikers. Each slice
 // of monikers are attached to a single range. The order of the output slice is "outside-in", so that
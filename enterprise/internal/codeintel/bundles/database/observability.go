@@ -17,8 +17,11 @@ type ObservedDatabase struct {
 	rangesOperation             *observation.Operation
 	definitionsOperation        *observation.Operation
 	referencesOperation         *observation.Operation
+	implementationsOperation    *observation.Operation
+	typeDefinitionsOperation    *observation.Operation
 	hoverOperation              *observation.Operation
 	diagnosticsOperation        *observation.Operation
+	documentSymbolsOperation    *observation.Operation
 	monikersByPositionOperation *observation.Operation
 	monikerResultsOperation     *observation.Operation
 	packageInformationOperation *observation.Operation
@@ -65,6 +68,16 @@ func NewObserved(database Database, filename string, observationContext *observa
 			MetricLabels: []string{"references"},
 			Metrics:      metrics,
 		}),
+		implementationsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.Implementations",
+			MetricLabels: []string{"implementations"},
+			Metrics:      metrics,
+		}),
+		typeDefinitionsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.TypeDefinitions",
+			MetricLabels: []string{"type_definitions"},
+			Metrics:      metrics,
+		}),
 		hoverOperation: observationContext.Operation(observation.Op{
 			Name:         "Database.Hover",
 			MetricLabels: []string{"hover"},
@@ -75,6 +88,11 @@ func NewObserved(database Database, filename string, observationContext *observa
 			MetricLabels: []string{"diagnostics"},
 			Metrics:      metrics,
 		}),
+		documentSymbolsOperation: observationContext.Operation(observation.Op{
+			Name:         "Database.DocumentSymbols",
+			MetricLabels: []string{"document_symbols"},
+			Metrics:      metrics,
+		}),
 		monikersByPositionOperation: observationContext.Operation(observation.Op{
 			Name:         "Database.MonikersByPosition",
 			MetricLabels: []string{"monikers_by_position"},
@@ -150,6 +168,34 @@ func (db *ObservedDatabase) References(ctx context.Context, path string, line, c
 	return db.database.References(ctx, path, line, character)
 }
 
+// Implementations calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) Implementations(ctx context.Context, path string, line, character int) (implementations []bundles.Location, err error) {
+	ctx, endObservation := db.implementationsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("path", path),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer func() { endObservation(float64(len(implementations)), observation.Args{}) }()
+	return db.database.Implementations(ctx, path, line, character)
+}
+
+// TypeDefinitions calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) TypeDefinitions(ctx context.Context, path string, line, character int) (typeDefinitions []bundles.Location, err error) {
+	ctx, endObservation := db.typeDefinitionsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("path", path),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	})
+	defer func() { endObservation(float64(len(typeDefinitions)), observation.Args{}) }()
+	return db.database.TypeDefinitions(ctx, path, line, character)
+}
+
 // Hover calls into the inner Database and registers the observed results.
 func (db *ObservedDatabase) Hover(ctx context.Context, path string, line, character int) (_ string, _ bundles.Range, _ bool, err error) {
 	ctx, endObservation := db.hoverOperation.With(ctx, &err, observation.Args{
@@ -176,6 +222,18 @@ func (db *ObservedDatabase) Diagnostics(ctx context.Context, prefix string, skip
 	return db.database.Diagnostics(ctx, prefix, skip, take)
 }
 
+// DocumentSymbols calls into the inner Database and registers the observed results.
+func (db *ObservedDatabase) DocumentSymbols(ctx context.Context, path string) (symbols []bundles.DocumentSymbol, err error) {
+	ctx, endObservation := db.documentSymbolsOperation.With(ctx, &err, observation.Args{
+		LogFields: []log.Field{
+			log.String("filename", db.filename),
+			log.String("path", path),
+		},
+	})
+	defer func() { endObservation(float64(len(symbols)), observation.Args{}) }()
+	return db.database.DocumentSymbols(ctx, path)
+}
+
 // MonikersByPosition calls into the inner Database and registers the observed results.
 func (db *ObservedDatabase) MonikersByPosition(ctx context.Context, path string, line, character int) (monikers [][]bundles.MonikerData, err error) {
 	ctx, endObservation := db.monikersByPositionOperation.With(ctx, &err, observation.Args{
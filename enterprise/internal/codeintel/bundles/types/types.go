@@ -8,14 +8,16 @@ type MetaData struct {
 }
 
 // DocumentData represents a single document within an index. The data here can answer
-// definitions, references, and hover queries if the results are all contained in the
-// same document.
+// definitions, references, implementations, type definitions, and hover queries if the
+// results are all contained in the same document. It also holds the symbol hierarchy
+// that outlines the document.
 type DocumentData struct {
 	Ranges             map[ID]RangeData
 	HoverResults       map[ID]string // hover text normalized to markdown string
 	Monikers           map[ID]MonikerData
 	PackageInformation map[ID]PackageInformationData
 	Diagnostics        []DiagnosticData
+	Symbols            []SymbolData // top-level symbols, possibly empty
 }
 
 // RangeData represents a range vertex within an index. It contains the same relevant
@@ -23,14 +25,16 @@ type DocumentData struct {
 // that was reachable via a result set has been collapsed into this object during
 // conversion.
 type RangeData struct {
-	StartLine          int  // 0-indexed, inclusive
-	StartCharacter     int  // 0-indexed, inclusive
-	EndLine            int  // 0-indexed, inclusive
-	EndCharacter       int  // 0-indexed, inclusive
-	DefinitionResultID ID   // possibly empty
-	ReferenceResultID  ID   // possibly empty
-	HoverResultID      ID   // possibly empty
-	MonikerIDs         []ID // possibly empty
+	StartLine              int  // 0-indexed, inclusive
+	StartCharacter         int  // 0-indexed, inclusive
+	EndLine                int  // 0-indexed, inclusive
+	EndCharacter           int  // 0-indexed, inclusive
+	DefinitionResultID     ID   // possibly empty
+	ReferenceResultID      ID   // possibly empty
+	HoverResultID          ID   // possibly empty
+	ImplementationResultID ID   // possibly empty
+	TypeDefinitionResultID ID   // possibly empty
+	MonikerIDs             []ID // possibly empty
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
@@ -63,19 +67,37 @@ type DiagnosticData struct {
 	EndCharacter   int // 0-indexed, inclusive
 }
 
+// SymbolData represents a symbol declared within a document, along with the symbols
+// nested within it (e.g., the methods of a class).
+type SymbolData struct {
+	Name               string
+	Detail             string // possibly empty
+	Kind               int    // LSP SymbolKind
+	StartLine          int    // 0-indexed, inclusive; the range of the symbol's name
+	StartCharacter     int    // 0-indexed, inclusive
+	EndLine            int    // 0-indexed, inclusive
+	EndCharacter       int    // 0-indexed, inclusive
+	FullStartLine      int    // 0-indexed, inclusive; the range of the symbol's declaration
+	FullStartCharacter int    // 0-indexed, inclusive
+	FullEndLine        int    // 0-indexed, inclusive
+	FullEndCharacter   int    // 0-indexed, inclusive
+	Children           []SymbolData
+}
+
 // ResultChunkData represents a row of the resultChunk table. Each row is a subset
-// of definition and reference result data in the index. Results are inserted into
-// chunks based on the hash of their identifier, thus every chunk has a roughly
-// proportional amount of data.
+// of definition, reference, implementation, and type definition result data in the
+// index. Results are inserted into chunks based on the hash of their identifier, thus
+// every chunk has a roughly proportional amount of data.
 type ResultChunkData struct {
 	// DocumentPaths is a mapping from document identifiers to their paths. This
 	// must be used to convert a document identifier in DocumentIDRangeIDs into
 	// a key that can be used to fetch document data.
 	DocumentPaths map[ID]string
 
-	// DocumentIDRangeIDs is a mapping from a definition or result reference
-	// identifier to the set of ranges that compose that result set. Each range
-	// is paired with the identifier of the document in which it can found.
+	// DocumentIDRangeIDs is a mapping from a definition, reference, implementation,
+	// or type definition result identifier to the set of ranges that compose that
+	// result set. Each range is paired with the identifier of the document in which
+	// it can found.
 	DocumentIDRangeIDs map[ID][]DocumentIDRangeID
 }
 
@@ -247,6 +247,40 @@ func (c *Client) Tags(ctx context.Context, store store.Store, repositoryID int,
 	return tag, false, nil
 }
 
+// Tag is a git tag along with the commit to which it points.
+type Tag struct {
+	Name   string
+	Commit string
+}
+
+// ListTags returns the tags of the given repository along with the commit to which each tag points.
+// The resulting tags are ordered from most to least recently created.
+func (c *Client) ListTags(ctx context.Context, store store.Store, repositoryID int) ([]Tag, error) {
+	out, err := execGitCommand(ctx, store, repositoryID, "for-each-ref", "--sort=-creatordate", "--format=%(refname:short) %(objectname) %(*objectname)", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTags(strings.Split(out, "\n")), nil
+}
+
+// parseTags converts the output of git for-each-ref into a list of tags. Annotated tags are listed
+// with the object name of the tag as well as the object name of the tagged commit; the latter is
+// used in the resulting list.
+func parseTags(lines []string) []Tag {
+	var tags []Tag
+	for _, line := range lines {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+
+		tags = append(tags, Tag{Name: parts[0], Commit: parts[len(parts)-1]})
+	}
+
+	return tags
+}
+
 // execGitCommand executes a git command for the given repository by identifier.
 func execGitCommand(ctx context.Context, store store.Store, repositoryID int, args ...string) (string, error) {
 	repo, err := repositoryIDToRepo(ctx, store, repositoryID)
@@ -151,3 +151,22 @@ func TestCleanDirectoriesForLsTree(t *testing.T) {
 		t.Errorf("unexpected ls-tree args (-want +got):\n%s", diff)
 	}
 }
+
+func TestParseTags(t *testing.T) {
+	lines := []string{
+		"v1.2.0 9ad62c7ec68e377b41a8b8dd846e573b76634172 683cafd122632142bda6e36563f5719e5b0fa37d",
+		"v1.1.0 1afa9c06d8bb8b2c5746e539ed4eb80c23b21db3 ",
+		"",
+		"v1.0.0 02f41985f46b400b7a673c3dfb6bab8fd1ac6a6d",
+	}
+
+	expected := []Tag{
+		{Name: "v1.2.0", Commit: "683cafd122632142bda6e36563f5719e5b0fa37d"},
+		{Name: "v1.1.0", Commit: "1afa9c06d8bb8b2c5746e539ed4eb80c23b21db3"},
+		{Name: "v1.0.0", Commit: "02f41985f46b400b7a673c3dfb6bab8fd1ac6a6d"},
+	}
+
+	if diff := cmp.Diff(expected, parseTags(lines)); diff != "" {
+		t.Errorf("unexpected tags (-want +got):\n%s", diff)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"strings"
 
 	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
 func isSiteAdmin(ctx context.Context) bool {
@@ -27,24 +29,41 @@ func isSiteAdmin(ctx context.Context) bool {
 	return user != nil && user.SiteAdmin
 }
 
-func enforceAuth(ctx context.Context, w http.ResponseWriter, r *http.Request, repoName string) bool {
-	validatorByCodeHost := map[string]func(context.Context, http.ResponseWriter, *http.Request, string) (int, error){
+type authValidator func(context.Context, http.ResponseWriter, *http.Request, *types.Repo) (int, error)
+
+func enforceAuth(ctx context.Context, w http.ResponseWriter, r *http.Request, repo *types.Repo) bool {
+	validatorByCodeHost := map[string]authValidator{
 		"github.com": enforceAuthGithub,
 	}
 
-	for codeHost, validator := range validatorByCodeHost {
-		if strings.HasPrefix(repoName, codeHost) {
-			if status, err := validator(ctx, w, r, repoName); err != nil {
-				http.Error(w, err.Error(), status)
-				return false
-			}
+	// GitLab and Bitbucket Server instances are commonly self-hosted, so we identify them by
+	// the type of the external service the repository was synced from instead of by its name.
+	validatorByServiceType := map[string]authValidator{
+		extsvc.TypeGitLab:          enforceAuthGitLab,
+		extsvc.TypeBitbucketServer: enforceAuthBitbucketServer,
+	}
 
-			return true
+	validator, ok := validatorByServiceType[repo.ExternalRepo.ServiceType]
+	if !ok {
+		for codeHost, v := range validatorByCodeHost {
+			if strings.HasPrefix(string(repo.Name), codeHost) {
+				validator, ok = v, true
+				break
+			}
 		}
 	}
 
-	http.Error(w, "verification not supported for code host - see https://github.com/sourcegraph/sourcegraph/issues/4967", http.StatusUnprocessableEntity)
-	return false
+	if !ok {
+		http.Error(w, "verification not supported for code host - see https://github.com/sourcegraph/sourcegraph/issues/4967", http.StatusUnprocessableEntity)
+		return false
+	}
+
+	if status, err := validator(ctx, w, r, repo); err != nil {
+		http.Error(w, err.Error(), status)
+		return false
+	}
+
+	return true
 }
 
 func makeUploadRequest(host string, q url.Values, body io.Reader) (*http.Request, error) {
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func enforceAuthBitbucketServer(ctx context.Context, w http.ResponseWriter, r *http.Request, repo *types.Repo) (int, error) {
+	bitbucketServerToken := r.URL.Query().Get("bitbucket_server_token")
+	if bitbucketServerToken == "" {
+		return http.StatusUnauthorized, errors.New("must provide bitbucket_server_token")
+	}
+
+	var metadata *bitbucketserver.Repo
+	if repo.RepoFields != nil {
+		metadata, _ = repo.Metadata.(*bitbucketserver.Repo)
+	}
+	if metadata == nil || metadata.Project == nil {
+		return http.StatusNotFound, errors.New("invalid Bitbucket Server repository: name=" + string(repo.Name))
+	}
+
+	client, err := bitbucketserver.NewClient(&schema.BitbucketServerConnection{
+		Url:   repo.ExternalRepo.ServiceID,
+		Token: bitbucketServerToken,
+	}, nil)
+	if err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, "invalid Bitbucket Server URL")
+	}
+
+	return authBitbucketServerRepo(ctx, client, metadata.Project.Key, metadata.Slug)
+}
+
+// authBitbucketServerRepo ensures that the user owning the token of the given client has write
+// permission to the given repository.
+func authBitbucketServerRepo(ctx context.Context, client *bitbucketserver.Client, projectKey, repoSlug string) (int, error) {
+	repo, err := client.Repo(ctx, projectKey, repoSlug)
+	if err != nil {
+		if bitbucketserver.IsNotFound(err) {
+			return http.StatusNotFound, fmt.Errorf("unknown Bitbucket Server repository: %s/%s", projectKey, repoSlug)
+		}
+
+		return http.StatusUnauthorized, errors.Wrap(err, "unable to get repository")
+	}
+
+	// The repos endpoint can filter by the permission of the current user, but it can't filter
+	// by project key or repository slug. We narrow the results down by name and then look for
+	// the repository with a matching identifier.
+	q := url.Values{}
+	q.Set("name", repo.Name)
+	if repo.Project != nil {
+		q.Set("projectname", repo.Project.Name)
+	}
+	q.Set("permission", string(bitbucketserver.PermRepoWrite))
+
+	repos, _, err := client.Repos(ctx, &bitbucketserver.PageToken{Limit: 100}, q.Encode())
+	if err != nil {
+		return http.StatusUnauthorized, errors.Wrap(err, "unable to get repository permissions")
+	}
+
+	for _, r := range repos {
+		if r.ID == repo.ID {
+			return 0, nil
+		}
+	}
+
+	return http.StatusForbidden, errors.New("you do not have write permission to the repository")
+}
@@ -0,0 +1,38 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+)
+
+func TestAuthBitbucketServerRepo(t *testing.T) {
+	testCases := []struct {
+		name       string
+		projectKey string
+		repoSlug   string
+		status     int
+	}{
+		{name: "allowed", projectKey: "SOUR", repoSlug: "automation-testing", status: 0},
+		{name: "forbidden", projectKey: "SOUR", repoSlug: "read-only", status: http.StatusForbidden},
+		{name: "unknown", projectKey: "SOUR", repoSlug: "does-not-exist", status: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Run the tests with -update and BITBUCKET_SERVER_TOKEN set to re-record them.
+			client, save := bitbucketserver.NewTestClient(t, "BitbucketServer-"+testCase.name, *update)
+			defer save()
+
+			status, err := authBitbucketServerRepo(context.Background(), client, testCase.projectKey, testCase.repoSlug)
+			if status != testCase.status {
+				t.Errorf("unexpected status. want=%d have=%d (err=%v)", testCase.status, status, err)
+			}
+			if (err == nil) != (testCase.status == 0) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
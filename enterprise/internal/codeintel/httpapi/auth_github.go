@@ -9,13 +9,14 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
 var githubURL = url.URL{Scheme: "https", Host: "api.github.com"}
 
-func enforceAuthGithub(ctx context.Context, w http.ResponseWriter, r *http.Request, repoName string) (int, error) {
-	nameWithOwner := strings.TrimPrefix(repoName, "github.com/")
+func enforceAuthGithub(ctx context.Context, w http.ResponseWriter, r *http.Request, repo *types.Repo) (int, error) {
+	nameWithOwner := strings.TrimPrefix(string(repo.Name), "github.com/")
 	owner, name, err := github.SplitRepositoryNameWithOwner(nameWithOwner)
 	if err != nil {
 		return http.StatusNotFound, errors.New("invalid GitHub repository: nameWithOwner=" + nameWithOwner)
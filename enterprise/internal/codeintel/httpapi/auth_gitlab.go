@@ -0,0 +1,55 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+func enforceAuthGitLab(ctx context.Context, w http.ResponseWriter, r *http.Request, repo *types.Repo) (int, error) {
+	gitlabToken := r.URL.Query().Get("gitlab_token")
+	if gitlabToken == "" {
+		return http.StatusUnauthorized, errors.New("must provide gitlab_token")
+	}
+
+	baseURL, err := url.Parse(repo.ExternalRepo.ServiceID)
+	if err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, "invalid GitLab URL")
+	}
+
+	projectID, err := strconv.Atoi(repo.ExternalRepo.ID)
+	if err != nil {
+		return http.StatusNotFound, errors.New("invalid GitLab project: id=" + repo.ExternalRepo.ID)
+	}
+
+	client := gitlab.NewClientProvider(baseURL, nil).GetPATClient(gitlabToken, "")
+	return authGitLabProject(ctx, client, projectID)
+}
+
+// authGitLabProject ensures that the user owning the token of the given client is at least a
+// developer of the given project, which is the access level required to push to the project.
+//
+// GitLab's CI_JOB_TOKEN cannot be used to read project permissions, so CI jobs should supply a
+// personal or project access token with the read_api scope instead.
+func authGitLabProject(ctx context.Context, client *gitlab.Client, projectID int) (int, error) {
+	accessLevel, err := client.GetProjectAccessLevel(ctx, projectID)
+	if err != nil {
+		if gitlab.IsNotFound(err) {
+			return http.StatusNotFound, fmt.Errorf("unknown GitLab project: id=%d", projectID)
+		}
+
+		return http.StatusUnauthorized, errors.Wrap(err, "unable to get project permissions")
+	}
+
+	if accessLevel < gitlab.AccessLevelDeveloper {
+		return http.StatusForbidden, errors.New("you do not have write permission to the project")
+	}
+
+	return 0, nil
+}
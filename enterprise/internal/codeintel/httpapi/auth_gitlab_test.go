@@ -0,0 +1,71 @@
+package httpapi
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+func TestAuthGitLabProject(t *testing.T) {
+	testCases := []struct {
+		name      string
+		projectID int
+		status    int
+	}{
+		{name: "allowed", projectID: 20478140, status: 0},
+		{name: "forbidden", projectID: 278964, status: http.StatusForbidden},
+		{name: "unknown", projectID: 99999999, status: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, save := newGitLabTestClient(t, "GitLab-"+testCase.name)
+			defer save()
+
+			status, err := authGitLabProject(context.Background(), client, testCase.projectID)
+			if status != testCase.status {
+				t.Errorf("unexpected status. want=%d have=%d (err=%v)", testCase.status, status, err)
+			}
+			if (err == nil) != (testCase.status == 0) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// newGitLabTestClient returns a GitLab client that records its interactions with gitlab.com
+// to testdata/vcr/. Run the tests with -update and GITLAB_TOKEN set to re-record them.
+func newGitLabTestClient(t *testing.T, name string) (*gitlab.Client, func()) {
+	rec, err := httptestutil.NewRecorder(filepath.Join("testdata/vcr", name), *update, func(i *cassette.Interaction) error {
+		delete(i.Request.Headers, "Private-Token")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doer, err := httpcli.NewFactory(nil, httptestutil.NewRecorderOpt(rec)).Doer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	baseURL := &url.URL{Scheme: "https", Host: "gitlab.com"}
+	client := gitlab.NewClientProvider(baseURL, doer).GetPATClient(os.Getenv("GITLAB_TOKEN"), "")
+
+	return client, func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("failed to update test data: %s", err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+)
+
+type DocumentSymbolConnectionResolver struct {
+	symbols          []resolvers.AdjustedDocumentSymbol
+	locationResolver *CachedLocationResolver
+}
+
+func NewDocumentSymbolConnectionResolver(symbols []resolvers.AdjustedDocumentSymbol, locationResolver *CachedLocationResolver) gql.DocumentSymbolConnectionResolver {
+	return &DocumentSymbolConnectionResolver{
+		symbols:          symbols,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *DocumentSymbolConnectionResolver) Nodes(ctx context.Context) ([]gql.DocumentSymbolResolver, error) {
+	return newDocumentSymbolResolvers(r.symbols, r.locationResolver), nil
+}
+
+type DocumentSymbolResolver struct {
+	symbol           resolvers.AdjustedDocumentSymbol
+	locationResolver *CachedLocationResolver
+}
+
+func newDocumentSymbolResolvers(symbols []resolvers.AdjustedDocumentSymbol, locationResolver *CachedLocationResolver) []gql.DocumentSymbolResolver {
+	resolvers := make([]gql.DocumentSymbolResolver, 0, len(symbols))
+	for _, symbol := range symbols {
+		resolvers = append(resolvers, &DocumentSymbolResolver{
+			symbol:           symbol,
+			locationResolver: locationResolver,
+		})
+	}
+
+	return resolvers
+}
+
+func (r *DocumentSymbolResolver) Name() string    { return r.symbol.Name }
+func (r *DocumentSymbolResolver) Detail() *string { return strPtr(r.symbol.Detail) }
+func (r *DocumentSymbolResolver) Kind() string    { return toSymbolKind(r.symbol.Kind) }
+
+func (r *DocumentSymbolResolver) Location(ctx context.Context) (gql.LocationResolver, error) {
+	return resolveLocation(
+		ctx,
+		r.locationResolver,
+		resolvers.AdjustedLocation{
+			Dump:           r.symbol.Dump,
+			Path:           r.symbol.Path,
+			AdjustedCommit: r.symbol.AdjustedCommit,
+			AdjustedRange:  r.symbol.AdjustedRange,
+		},
+	)
+}
+
+func (r *DocumentSymbolResolver) FullRange() gql.RangeResolver {
+	return gql.NewRangeResolver(convertRange(r.symbol.AdjustedFullRange))
+}
+
+func (r *DocumentSymbolResolver) Children() []gql.DocumentSymbolResolver {
+	return newDocumentSymbolResolvers(r.symbol.Children, r.locationResolver)
+}
+
+// toSymbolKind converts an LSP symbol kind into a value of the SymbolKind enum.
+func toSymbolKind(val int) string {
+	kind := lsp.SymbolKind(val)
+	if kind < lsp.SKFile || kind > lsp.SKTypeParameter {
+		return "UNKNOWN"
+	}
+
+	return strings.ToUpper(kind.String())
+}
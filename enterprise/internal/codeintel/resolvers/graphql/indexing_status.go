@@ -0,0 +1,34 @@
+package graphql
+
+import (
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+type IndexingStatusResolver struct {
+	configurationError *store.IndexConfigurationError
+}
+
+func NewIndexingStatusResolver(configurationError *store.IndexConfigurationError) gql.LSIFIndexingStatusResolver {
+	return &IndexingStatusResolver{
+		configurationError: configurationError,
+	}
+}
+
+func (r *IndexingStatusResolver) ConfigurationError() gql.LSIFIndexConfigurationErrorResolver {
+	if r.configurationError == nil {
+		return nil
+	}
+
+	return &IndexConfigurationErrorResolver{configurationError: *r.configurationError}
+}
+
+type IndexConfigurationErrorResolver struct {
+	configurationError store.IndexConfigurationError
+}
+
+func (r *IndexConfigurationErrorResolver) Commit() string  { return r.configurationError.Commit }
+func (r *IndexConfigurationErrorResolver) Message() string { return r.configurationError.Message }
+func (r *IndexConfigurationErrorResolver) UpdatedAt() gql.DateTime {
+	return gql.DateTime{Time: r.configurationError.UpdatedAt}
+}
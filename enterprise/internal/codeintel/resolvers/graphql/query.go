@@ -51,6 +51,10 @@ func (r *QueryResolver) Ranges(ctx context.Context, args *gql.LSIFRangesArgs) (g
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop definitions and references in paths that the current user can't read.
+	if ranges, err = filterRangesBySubRepoPerms(ctx, ranges); err != nil {
+		return nil, err
+	}
 
 	return &CodeIntelligenceRangeConnectionResolver{
 		ranges:           ranges,
@@ -63,6 +67,10 @@ func (r *QueryResolver) Definitions(ctx context.Context, args *gql.LSIFQueryPosi
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop locations in paths that the current user can't read.
+	if locations, err = filterLocationsBySubRepoPerms(ctx, locations); err != nil {
+		return nil, err
+	}
 
 	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
 }
@@ -81,16 +89,56 @@ func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQuery
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop locations in paths that the current user can't read.
+	if locations, err = filterLocationsBySubRepoPerms(ctx, locations); err != nil {
+		return nil, err
+	}
 
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	locations, err := r.resolver.Implementations(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Drop locations in paths that the current user can't read.
+	if locations, err = filterLocationsBySubRepoPerms(ctx, locations); err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
+func (r *QueryResolver) TypeDefinitions(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.LocationConnectionResolver, error) {
+	locations, err := r.resolver.TypeDefinitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Drop locations in paths that the current user can't read.
+	if locations, err = filterLocationsBySubRepoPerms(ctx, locations); err != nil {
+		return nil, err
+	}
+
+	return NewLocationConnectionResolver(locations, nil, r.locationResolver), nil
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.HoverResolver, error) {
 	text, rx, exists, err := r.resolver.Hover(ctx, int(args.Line), int(args.Character))
 	if err != nil || !exists {
 		return nil, err
 	}
 
+	// 🚨 SECURITY: The hover text is taken from the definition of the symbol, so it is not shown
+	// when the current user can't read one of the paths in which the symbol is defined.
+	definitions, err := r.resolver.Definitions(ctx, int(args.Line), int(args.Character))
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := canReadLocations(ctx, definitions); err != nil || !ok {
+		return nil, err
+	}
+
 	return NewHoverResolver(text, convertRange(rx)), nil
 }
 
@@ -104,6 +152,21 @@ func (r *QueryResolver) Diagnostics(ctx context.Context, args *gql.LSIFDiagnosti
 	if err != nil {
 		return nil, err
 	}
+	// 🚨 SECURITY: Drop diagnostics in paths that the current user can't read, and don't count them.
+	diagnostics, numRemoved, err := filterDiagnosticsBySubRepoPerms(ctx, diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	totalCount -= numRemoved
 
 	return NewDiagnosticConnectionResolver(diagnostics, totalCount, r.locationResolver), nil
 }
+
+func (r *QueryResolver) DocumentSymbols(ctx context.Context) (gql.DocumentSymbolConnectionResolver, error) {
+	symbols, err := r.resolver.DocumentSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDocumentSymbolConnectionResolver(symbols, r.locationResolver), nil
+}
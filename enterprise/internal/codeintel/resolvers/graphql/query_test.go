@@ -8,6 +8,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
 )
 
@@ -205,3 +206,33 @@ func TestDiagnosticsDefaultIllegalLimit(t *testing.T) {
 		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalLimit, err)
 	}
 }
+
+func TestDocumentSymbols(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.DocumentSymbolsFunc.SetDefaultReturn([]resolvers.AdjustedDocumentSymbol{
+		{Name: "foo", Kind: 12, Children: []resolvers.AdjustedDocumentSymbol{{Name: "bar", Kind: 0}}},
+	}, nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	connection, err := resolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	nodes, err := connection.Nodes(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(nodes) != 1 {
+		t.Fatalf("unexpected node count. want=%d have=%d", 1, len(nodes))
+	}
+	if val := nodes[0].Kind(); val != "FUNCTION" {
+		t.Errorf("unexpected kind. want=%s have=%s", "FUNCTION", val)
+	}
+	if len(nodes[0].Children()) != 1 {
+		t.Fatalf("unexpected child count. want=%d have=%d", 1, len(nodes[0].Children()))
+	}
+	if val := nodes[0].Children()[0].Kind(); val != "UNKNOWN" {
+		t.Errorf("unexpected kind. want=%s have=%s", "UNKNOWN", val)
+	}
+}
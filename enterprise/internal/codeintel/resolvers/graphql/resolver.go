@@ -2,9 +2,11 @@ package graphql
 
 import (
 	"context"
+	"regexp"
 	"strings"
 
 	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
@@ -123,6 +125,83 @@ func (r *Resolver) DeleteLSIFIndex(ctx context.Context, id graphql.ID) (*gql.Emp
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) QueueAutoIndexJobForRepo(ctx context.Context, args *gql.QueueAutoIndexJobForRepoArgs) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may queue index jobs for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryResolver, err := gql.RepositoryByID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	commitID, err := backend.Repos.ResolveRev(ctx, repositoryResolver.Type(), derefString(args.Rev, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.resolver.QueueAutoIndexJobForRepo(ctx, int(repositoryResolver.Type().ID), string(commitID)); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
+func (r *Resolver) UpdateLSIFRetentionConfigurationForRepo(ctx context.Context, args *gql.UpdateLSIFRetentionConfigurationForRepoArgs) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may change data retention policies
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := resolveRepositoryID(ctx, args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAge, err := parseDuration("maxAge", args.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	maxTaggedAge, err := parseDuration("maxTaggedAge", args.MaxTaggedAge)
+	if err != nil {
+		return nil, err
+	}
+	if args.TagPattern != nil {
+		if _, err := regexp.Compile(*args.TagPattern); err != nil {
+			return nil, errors.Wrap(err, "invalid tagPattern")
+		}
+	}
+
+	if err := r.resolver.UpdateRetentionConfiguration(ctx, store.RetentionConfiguration{
+		RepositoryID: repositoryID,
+		MaxAge:       maxAge,
+		TagPattern:   args.TagPattern,
+		MaxTaggedAge: maxTaggedAge,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
+func (r *Resolver) LSIFIndexingStatusByRepo(ctx context.Context, repositoryID graphql.ID) (gql.LSIFIndexingStatusResolver, error) {
+	id, err := resolveRepositoryID(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	configurationError, exists, err := r.resolver.GetIndexConfigurationError(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return NewIndexingStatusResolver(nil), nil
+	}
+
+	return NewIndexingStatusResolver(&configurationError), nil
+}
+
 func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (gql.GitBlobLSIFDataResolver, error) {
 	resolver, err := r.resolver.QueryResolver(ctx, args)
 	if err != nil || resolver == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/graph-gophers/graphql-go"
@@ -81,6 +82,143 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestQueueAutoIndexJobForRepo(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+		db.Mocks.Repos.Get = nil
+		backend.Mocks.Repos.ResolveRev = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		if rev != "v1.0.0" {
+			t.Errorf("unexpected rev. want=%q have=%q", "v1.0.0", rev)
+		}
+		return api.CommitID("deadbeef"), nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).QueueAutoIndexJobForRepo(context.Background(), &gql.QueueAutoIndexJobForRepoArgs{
+		Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
+		Rev:        strPtr("v1.0.0"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.QueueAutoIndexJobForRepoFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.QueueAutoIndexJobForRepoFunc.History()))
+	}
+	if val := mockResolver.QueueAutoIndexJobForRepoFunc.History()[0].Arg1; val != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, val)
+	}
+	if val := mockResolver.QueueAutoIndexJobForRepoFunc.History()[0].Arg2; val != "deadbeef" {
+		t.Fatalf("unexpected commit. want=%s have=%s", "deadbeef", val)
+	}
+}
+
+func TestQueueAutoIndexJobForRepoUnauthenticated(t *testing.T) {
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).QueueAutoIndexJobForRepo(context.Background(), &gql.QueueAutoIndexJobForRepoArgs{
+		Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
+	}); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
+func TestUpdateLSIFRetentionConfigurationForRepo(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).UpdateLSIFRetentionConfigurationForRepo(context.Background(), &gql.UpdateLSIFRetentionConfigurationForRepoArgs{
+		Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
+		MaxAge:     strPtr("720h"),
+		TagPattern: strPtr("^v"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.UpdateRetentionConfigurationFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.UpdateRetentionConfigurationFunc.History()))
+	}
+
+	maxAge := time.Hour * 720
+	expected := store.RetentionConfiguration{RepositoryID: 50, MaxAge: &maxAge, TagPattern: strPtr("^v")}
+	if diff := cmp.Diff(expected, mockResolver.UpdateRetentionConfigurationFunc.History()[0].Arg1); diff != "" {
+		t.Errorf("unexpected retention configuration (-want +got):\n%s", diff)
+	}
+
+	for _, args := range []*gql.UpdateLSIFRetentionConfigurationForRepoArgs{
+		{MaxAge: strPtr("a month")},
+		{MaxTaggedAge: strPtr("-1h")},
+		{TagPattern: strPtr("(")},
+	} {
+		args.Repository = graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50")))
+		if _, err := NewResolver(mockResolver).UpdateLSIFRetentionConfigurationForRepo(context.Background(), args); err == nil {
+			t.Errorf("expected error for %+v", args)
+		}
+	}
+	if len(mockResolver.UpdateRetentionConfigurationFunc.History()) != 1 {
+		t.Errorf("unexpected call count. want=%d have=%d", 1, len(mockResolver.UpdateRetentionConfigurationFunc.History()))
+	}
+}
+
+func TestUpdateLSIFRetentionConfigurationForRepoUnauthenticated(t *testing.T) {
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).UpdateLSIFRetentionConfigurationForRepo(context.Background(), &gql.UpdateLSIFRetentionConfigurationForRepoArgs{
+		Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
+	}); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
+func TestLSIFIndexingStatusByRepo(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.GetIndexConfigurationErrorFunc.PushReturn(store.IndexConfigurationError{RepositoryID: 50, Commit: "deadbeef", Message: "invalid sourcegraph.yaml"}, true, nil)
+	repositoryID := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50")))
+
+	status, err := NewResolver(mockResolver).LSIFIndexingStatusByRepo(context.Background(), repositoryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if configurationError := status.ConfigurationError(); configurationError == nil {
+		t.Errorf("expected a configuration error")
+	} else if configurationError.Commit() != "deadbeef" || configurationError.Message() != "invalid sourcegraph.yaml" {
+		t.Errorf("unexpected configuration error. have=%s: %s", configurationError.Commit(), configurationError.Message())
+	}
+
+	status, err = NewResolver(mockResolver).LSIFIndexingStatusByRepo(context.Background(), repositoryID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if configurationError := status.ConfigurationError(); configurationError != nil {
+		t.Errorf("unexpected configuration error")
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		db.Mocks.Repos.Get = nil
@@ -102,7 +240,7 @@ func TestMakeGetUploadsOptions(t *testing.T) {
 			IsLatestForRepo: boolPtr(true),
 			After:           encodeIntCursor(intPtr(25)).EndCursor(),
 		},
-		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50"))),
+		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
 	})
 	if err != nil {
 		t.Fatalf("unexpected error making options: %s", err)
@@ -162,7 +300,7 @@ func TestMakeGetIndexesOptions(t *testing.T) {
 			State: strPtr("s"),
 			After: encodeIntCursor(intPtr(25)).EndCursor(),
 		},
-		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50"))),
+		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
 	})
 	if err != nil {
 		t.Fatalf("unexpected error making options: %s", err)
@@ -0,0 +1,121 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// subRepoPermsForLocations returns the path-level permissions of the current user in the
+// repositories of the given locations. A nil map means that every location is readable.
+func subRepoPermsForLocations(ctx context.Context, locations []resolvers.AdjustedLocation) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(locations))
+	for _, location := range locations {
+		repoIDs = append(repoIDs, api.RepoID(location.Dump.RepositoryID))
+	}
+	return db.SubRepoPerms(ctx, repoIDs...)
+}
+
+// filterLocationsBySubRepoPerms removes the locations in paths that the current user can't read.
+// The locations are filtered in place and returned.
+func filterLocationsBySubRepoPerms(ctx context.Context, locations []resolvers.AdjustedLocation) ([]resolvers.AdjustedLocation, error) {
+	perms, err := subRepoPermsForLocations(ctx, locations)
+	if err != nil {
+		return nil, err
+	}
+	if len(perms) == 0 {
+		return locations, nil
+	}
+
+	return filterLocations(perms, locations), nil
+}
+
+// canReadLocations returns true if the current user can read the paths of all given locations.
+func canReadLocations(ctx context.Context, locations []resolvers.AdjustedLocation) (bool, error) {
+	perms, err := subRepoPermsForLocations(ctx, locations)
+	if err != nil {
+		return false, err
+	}
+
+	for _, location := range locations {
+		if !perms[api.RepoID(location.Dump.RepositoryID)].CanReadFile(location.Path) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// filterLocations removes the locations in paths that are not readable according to the given
+// permissions. The locations are filtered in place and returned.
+func filterLocations(perms map[api.RepoID]*authz.SubRepoPermissions, locations []resolvers.AdjustedLocation) []resolvers.AdjustedLocation {
+	filtered := locations[:0]
+	for _, location := range locations {
+		if perms[api.RepoID(location.Dump.RepositoryID)].CanReadFile(location.Path) {
+			filtered = append(filtered, location)
+		}
+	}
+	return filtered
+}
+
+// filterRangesBySubRepoPerms removes the definitions and references in paths that the current user
+// can't read from the given ranges. The hover text of a range is taken from its definition, so it is
+// cleared as well when one of the definitions is not readable. The ranges are modified in place.
+func filterRangesBySubRepoPerms(ctx context.Context, ranges []resolvers.AdjustedCodeIntelligenceRange) ([]resolvers.AdjustedCodeIntelligenceRange, error) {
+	var locations []resolvers.AdjustedLocation
+	for _, rn := range ranges {
+		locations = append(locations, rn.Definitions...)
+		locations = append(locations, rn.References...)
+	}
+	perms, err := subRepoPermsForLocations(ctx, locations)
+	if err != nil {
+		return nil, err
+	}
+	if len(perms) == 0 {
+		return ranges, nil
+	}
+
+	for i := range ranges {
+		numDefinitions := len(ranges[i].Definitions)
+		ranges[i].Definitions = filterLocations(perms, ranges[i].Definitions)
+		ranges[i].References = filterLocations(perms, ranges[i].References)
+		if len(ranges[i].Definitions) != numDefinitions {
+			ranges[i].HoverText = ""
+		}
+	}
+	return ranges, nil
+}
+
+// filterDiagnosticsBySubRepoPerms removes the diagnostics in paths that the current user can't read.
+// The diagnostics are filtered in place and returned along with the number of removed diagnostics.
+func filterDiagnosticsBySubRepoPerms(ctx context.Context, diagnostics []resolvers.AdjustedDiagnostic) ([]resolvers.AdjustedDiagnostic, int, error) {
+	if len(diagnostics) == 0 {
+		return diagnostics, 0, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		repoIDs = append(repoIDs, api.RepoID(diagnostic.Dump.RepositoryID))
+	}
+	perms, err := db.SubRepoPerms(ctx, repoIDs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(perms) == 0 {
+		return diagnostics, 0, nil
+	}
+
+	filtered := diagnostics[:0]
+	for _, diagnostic := range diagnostics {
+		if perms[api.RepoID(diagnostic.Dump.RepositoryID)].CanReadFile(diagnostic.Path) {
+			filtered = append(filtered, diagnostic)
+		}
+	}
+	return filtered, len(diagnostics) - len(filtered), nil
+}
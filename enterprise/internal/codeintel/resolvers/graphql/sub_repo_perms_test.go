@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func mockSubRepoPerms(t *testing.T) {
+	db.MockSubRepoPerms = func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+		return map[api.RepoID]*authz.SubRepoPermissions{
+			50: {PathExcludes: []string{"secret/**"}},
+		}, nil
+	}
+	t.Cleanup(func() { db.MockSubRepoPerms = nil })
+}
+
+func testLocations() []resolvers.AdjustedLocation {
+	return []resolvers.AdjustedLocation{
+		{Dump: store.Dump{RepositoryID: 50}, Path: "main.go"},
+		{Dump: store.Dump{RepositoryID: 50}, Path: "secret/key.go"},
+		{Dump: store.Dump{RepositoryID: 51}, Path: "secret/key.go"},
+	}
+}
+
+func TestDefinitionsSubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.DefinitionsFunc.SetDefaultReturn(testLocations(), nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	connection, err := resolver.Definitions(context.Background(), &gql.LSIFQueryPositionArgs{Line: 10, Character: 15})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []resolvers.AdjustedLocation{
+		{Dump: store.Dump{RepositoryID: 50}, Path: "main.go"},
+		{Dump: store.Dump{RepositoryID: 51}, Path: "secret/key.go"},
+	}
+	if diff := cmp.Diff(expected, connection.(*LocationConnectionResolver).locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestHoverSubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.HoverFunc.SetDefaultReturn("text", bundles.Range{}, true, nil)
+	mockResolver.DefinitionsFunc.SetDefaultReturn(testLocations()[1:2], nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	hover, err := resolver.Hover(context.Background(), &gql.LSIFQueryPositionArgs{Line: 10, Character: 15})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if hover != nil {
+		t.Errorf("expected no hover for a symbol defined in an unreadable path")
+	}
+}
+
+func TestRangesSubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.RangesFunc.SetDefaultReturn([]resolvers.AdjustedCodeIntelligenceRange{
+		{Definitions: testLocations()[0:1], References: testLocations(), HoverText: "visible"},
+		{Definitions: testLocations()[1:2], HoverText: "hidden"},
+	}, nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	connection, err := resolver.Ranges(context.Background(), &gql.LSIFRangesArgs{StartLine: 10, EndLine: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []resolvers.AdjustedCodeIntelligenceRange{
+		{
+			Definitions: []resolvers.AdjustedLocation{{Dump: store.Dump{RepositoryID: 50}, Path: "main.go"}},
+			References: []resolvers.AdjustedLocation{
+				{Dump: store.Dump{RepositoryID: 50}, Path: "main.go"},
+				{Dump: store.Dump{RepositoryID: 51}, Path: "secret/key.go"},
+			},
+			HoverText: "visible",
+		},
+		{Definitions: []resolvers.AdjustedLocation{}},
+	}
+	if diff := cmp.Diff(expected, connection.(*CodeIntelligenceRangeConnectionResolver).ranges); diff != "" {
+		t.Errorf("unexpected ranges (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiagnosticsSubRepoPerms(t *testing.T) {
+	mockSubRepoPerms(t)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.DiagnosticsFunc.SetDefaultReturn([]resolvers.AdjustedDiagnostic{
+		{Dump: store.Dump{RepositoryID: 50}, Diagnostic: bundles.Diagnostic{Path: "main.go"}},
+		{Dump: store.Dump{RepositoryID: 50}, Diagnostic: bundles.Diagnostic{Path: "secret/key.go"}},
+	}, 5, nil)
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	first := int32(2)
+	connection, err := resolver.Diagnostics(context.Background(), &gql.LSIFDiagnosticsArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &first},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diagnostics := connection.(*DiagnosticConnectionResolver)
+	if len(diagnostics.diagnostics) != 1 || diagnostics.diagnostics[0].Path != "main.go" {
+		t.Errorf("unexpected diagnostics: %+v", diagnostics.diagnostics)
+	}
+	if diagnostics.totalCount != 4 {
+		t.Errorf("unexpected total count. want=%d have=%d", 4, diagnostics.totalCount)
+	}
+}
@@ -1,6 +1,9 @@
 package graphql
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/go-lsp"
 
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
@@ -63,6 +66,23 @@ func derefBool(val *bool, defaultValue bool) bool {
 	return defaultValue
 }
 
+// parseDuration parses the given duration argument. If the pointer is nil, a nil
+// pointer is returned.
+func parseDuration(name string, val *string) (*time.Duration, error) {
+	if val == nil {
+		return nil, nil
+	}
+
+	duration, err := time.ParseDuration(*val)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", name)
+	}
+	if duration <= 0 {
+		return nil, errors.Errorf("invalid %s: must be positive", name)
+	}
+	return &duration, nil
+}
+
 // convertRange creates an LSP range from a bundle range.
 func convertRange(r bundles.Range) lsp.Range {
 	return lsp.Range{Start: convertPosition(r.Start.Line, r.Start.Character), End: convertPosition(r.End.Line, r.End.Character)}
@@ -24,6 +24,10 @@ type MockResolver struct {
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
+	// GetIndexConfigurationErrorFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetIndexConfigurationError.
+	GetIndexConfigurationErrorFunc *ResolverGetIndexConfigurationErrorFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *ResolverGetUploadByIDFunc
@@ -33,6 +37,13 @@ type MockResolver struct {
 	// QueryResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method QueryResolver.
 	QueryResolverFunc *ResolverQueryResolverFunc
+	// QueueAutoIndexJobForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method QueueAutoIndexJobForRepo.
+	QueueAutoIndexJobForRepoFunc *ResolverQueueAutoIndexJobForRepoFunc
+	// UpdateRetentionConfigurationFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateRetentionConfiguration.
+	UpdateRetentionConfigurationFunc *ResolverUpdateRetentionConfigurationFunc
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
@@ -57,6 +68,11 @@ func NewMockResolver() *MockResolver {
 				return store.Index{}, false, nil
 			},
 		},
+		GetIndexConfigurationErrorFunc: &ResolverGetIndexConfigurationErrorFunc{
+			defaultHook: func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+				return store.IndexConfigurationError{}, false, nil
+			},
+		},
 		GetUploadByIDFunc: &ResolverGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (store.Upload, bool, error) {
 				return store.Upload{}, false, nil
@@ -72,6 +88,16 @@ func NewMockResolver() *MockResolver {
 				return nil, nil
 			},
 		},
+		QueueAutoIndexJobForRepoFunc: &ResolverQueueAutoIndexJobForRepoFunc{
+			defaultHook: func(context.Context, int, string) error {
+				return nil
+			},
+		},
+		UpdateRetentionConfigurationFunc: &ResolverUpdateRetentionConfigurationFunc{
+			defaultHook: func(context.Context, store.RetentionConfiguration) error {
+				return nil
+			},
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: func(store.GetUploadsOptions) *resolvers.UploadsResolver {
 				return nil
@@ -93,6 +119,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
+		GetIndexConfigurationErrorFunc: &ResolverGetIndexConfigurationErrorFunc{
+			defaultHook: i.GetIndexConfigurationError,
+		},
 		GetUploadByIDFunc: &ResolverGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -102,6 +131,12 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		QueryResolverFunc: &ResolverQueryResolverFunc{
 			defaultHook: i.QueryResolver,
 		},
+		QueueAutoIndexJobForRepoFunc: &ResolverQueueAutoIndexJobForRepoFunc{
+			defaultHook: i.QueueAutoIndexJobForRepo,
+		},
+		UpdateRetentionConfigurationFunc: &ResolverUpdateRetentionConfigurationFunc{
+			defaultHook: i.UpdateRetentionConfiguration,
+		},
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
@@ -432,6 +467,121 @@ func (c ResolverGetIndexByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverGetIndexConfigurationErrorFunc describes the behavior when the
+// GetIndexConfigurationError method of the parent MockResolver instance is
+// invoked.
+type ResolverGetIndexConfigurationErrorFunc struct {
+	defaultHook func(context.Context, int) (store.IndexConfigurationError, bool, error)
+	hooks       []func(context.Context, int) (store.IndexConfigurationError, bool, error)
+	history     []ResolverGetIndexConfigurationErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIndexConfigurationError delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) GetIndexConfigurationError(v0 context.Context, v1 int) (store.IndexConfigurationError, bool, error) {
+	r0, r1, r2 := m.GetIndexConfigurationErrorFunc.nextHook()(v0, v1)
+	m.GetIndexConfigurationErrorFunc.appendCall(ResolverGetIndexConfigurationErrorFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetIndexConfigurationError method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverGetIndexConfigurationErrorFunc) SetDefaultHook(hook func(context.Context, int) (store.IndexConfigurationError, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIndexConfigurationError method of the parent MockResolver instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverGetIndexConfigurationErrorFunc) PushHook(hook func(context.Context, int) (store.IndexConfigurationError, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverGetIndexConfigurationErrorFunc) SetDefaultReturn(r0 store.IndexConfigurationError, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverGetIndexConfigurationErrorFunc) PushReturn(r0 store.IndexConfigurationError, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverGetIndexConfigurationErrorFunc) nextHook() func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetIndexConfigurationErrorFunc) appendCall(r0 ResolverGetIndexConfigurationErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetIndexConfigurationErrorFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverGetIndexConfigurationErrorFunc) History() []ResolverGetIndexConfigurationErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetIndexConfigurationErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetIndexConfigurationErrorFuncCall is an object that describes an
+// invocation of method GetIndexConfigurationError on an instance of
+// MockResolver.
+type ResolverGetIndexConfigurationErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store.IndexConfigurationError
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetIndexConfigurationErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetIndexConfigurationErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetUploadByIDFunc struct {
@@ -759,6 +909,228 @@ func (c ResolverQueryResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverQueueAutoIndexJobForRepoFunc describes the behavior when the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
+// invoked.
+type ResolverQueueAutoIndexJobForRepoFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []ResolverQueueAutoIndexJobForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// QueueAutoIndexJobForRepo delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) QueueAutoIndexJobForRepo(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.QueueAutoIndexJobForRepoFunc.nextHook()(v0, v1, v2)
+	m.QueueAutoIndexJobForRepoFunc.appendCall(ResolverQueueAutoIndexJobForRepoFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// QueueAutoIndexJobForRepo method of the parent MockResolver instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) PushHook(hook func(context.Context, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
+	})
+}
+
+func (f *ResolverQueueAutoIndexJobForRepoFunc) nextHook() func(context.Context, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverQueueAutoIndexJobForRepoFunc) appendCall(r0 ResolverQueueAutoIndexJobForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverQueueAutoIndexJobForRepoFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverQueueAutoIndexJobForRepoFunc) History() []ResolverQueueAutoIndexJobForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverQueueAutoIndexJobForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverQueueAutoIndexJobForRepoFuncCall is an object that describes an
+// invocation of method QueueAutoIndexJobForRepo on an instance of
+// MockResolver.
+type ResolverQueueAutoIndexJobForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverQueueAutoIndexJobForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverQueueAutoIndexJobForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ResolverUpdateRetentionConfigurationFunc describes the behavior when the
+// UpdateRetentionConfiguration method of the parent MockResolver instance
+// is invoked.
+type ResolverUpdateRetentionConfigurationFunc struct {
+	defaultHook func(context.Context, store.RetentionConfiguration) error
+	hooks       []func(context.Context, store.RetentionConfiguration) error
+	history     []ResolverUpdateRetentionConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateRetentionConfiguration delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) UpdateRetentionConfiguration(v0 context.Context, v1 store.RetentionConfiguration) error {
+	r0 := m.UpdateRetentionConfigurationFunc.nextHook()(v0, v1)
+	m.UpdateRetentionConfigurationFunc.appendCall(ResolverUpdateRetentionConfigurationFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateRetentionConfiguration method of the parent MockResolver instance
+// is invoked and the hook queue is empty.
+func (f *ResolverUpdateRetentionConfigurationFunc) SetDefaultHook(hook func(context.Context, store.RetentionConfiguration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateRetentionConfiguration method of the parent MockResolver instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverUpdateRetentionConfigurationFunc) PushHook(hook func(context.Context, store.RetentionConfiguration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUpdateRetentionConfigurationFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, store.RetentionConfiguration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUpdateRetentionConfigurationFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, store.RetentionConfiguration) error {
+		return r0
+	})
+}
+
+func (f *ResolverUpdateRetentionConfigurationFunc) nextHook() func(context.Context, store.RetentionConfiguration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUpdateRetentionConfigurationFunc) appendCall(r0 ResolverUpdateRetentionConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ResolverUpdateRetentionConfigurationFuncCall objects describing the
+// invocations of this function.
+func (f *ResolverUpdateRetentionConfigurationFunc) History() []ResolverUpdateRetentionConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUpdateRetentionConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUpdateRetentionConfigurationFuncCall is an object that describes
+// an invocation of method UpdateRetentionConfiguration on an instance of
+// MockResolver.
+type ResolverUpdateRetentionConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.RetentionConfiguration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUpdateRetentionConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUpdateRetentionConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverUploadConnectionResolverFunc describes the behavior when the
 // UploadConnectionResolver method of the parent MockResolver instance is
 // invoked.
@@ -36,6 +36,20 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText   string
 }
 
+// AdjustedDocumentSymbol is similar to a bundles.DocumentSymbol, but with fields denoting the
+// commit and ranges adjusted for the target commit (when the requested commit is not indexed).
+type AdjustedDocumentSymbol struct {
+	Name              string
+	Detail            string
+	Kind              int
+	Dump              store.Dump
+	Path              string
+	AdjustedCommit    string
+	AdjustedRange     bundles.Range
+	AdjustedFullRange bundles.Range
+	Children          []AdjustedDocumentSymbol
+}
+
 // QueryResolver is the main interface to bundle-related operations exposed to the GraphQL API. This
 // resolver consolidates the logic for bundle operations and is not itself concerned with GraphQL/API
 // specifics (auth, validation, marshaling, etc.). This resolver is wrapped by a symmetrics resolver
@@ -44,8 +58,11 @@ type QueryResolver interface {
 	Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error)
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	Implementations(ctx context.Context, line, character int) ([]AdjustedLocation, error)
+	TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	Hover(ctx context.Context, line, character int) (string, bundles.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
+	DocumentSymbols(ctx context.Context) ([]AdjustedDocumentSymbol, error)
 }
 
 type queryResolver struct {
@@ -137,6 +154,28 @@ func (r *queryResolver) Ranges(ctx context.Context, startLine, endLine int) ([]A
 // bundles associated with this resolver, the definitions from the first bundle with any results will
 // be returned.
 func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error) {
+	return r.firstLocations(ctx, line, character, r.codeIntelAPI.Definitions)
+}
+
+// Implementations returns the list of source locations that implement the symbol at the given position.
+// This may include remote implementations if the symbol is imported from another indexed repository. If
+// there are multiple bundles associated with this resolver, the implementations from the first bundle
+// with any results will be returned.
+func (r *queryResolver) Implementations(ctx context.Context, line, character int) ([]AdjustedLocation, error) {
+	return r.firstLocations(ctx, line, character, r.codeIntelAPI.Implementations)
+}
+
+// TypeDefinitions returns the list of source locations that define the type of the symbol at the given
+// position. This may include remote type definitions if the symbol is imported from another indexed
+// repository. If there are multiple bundles associated with this resolver, the type definitions from
+// the first bundle with any results will be returned.
+func (r *queryResolver) TypeDefinitions(ctx context.Context, line, character int) ([]AdjustedLocation, error) {
+	return r.firstLocations(ctx, line, character, r.codeIntelAPI.TypeDefinitions)
+}
+
+// firstLocations adjusts the given position for each bundle associated with this resolver and returns
+// the adjusted locations from the first bundle for which query returns any results.
+func (r *queryResolver) firstLocations(ctx context.Context, line, character int, query func(ctx context.Context, file string, line, character, uploadID int) ([]codeintelapi.ResolvedLocation, error)) ([]AdjustedLocation, error) {
 	position := bundles.Position{Line: line, Character: character}
 
 	for i := range r.uploads {
@@ -148,7 +187,7 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 			continue
 		}
 
-		locations, err := r.codeIntelAPI.Definitions(ctx, adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID)
+		locations, err := query(ctx, adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID)
 		if err != nil {
 			return nil, err
 		}
@@ -323,6 +362,69 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedD
 	return adjustedDiagnostics, totalCount, nil
 }
 
+// DocumentSymbols returns the hierarchy of symbols defined in the document. If there are multiple
+// bundles associated with this resolver, the symbols from the first bundle with any results will
+// be returned.
+func (r *queryResolver) DocumentSymbols(ctx context.Context) ([]AdjustedDocumentSymbol, error) {
+	for i := range r.uploads {
+		adjustedPath, ok, err := r.positionAdjuster.AdjustPath(ctx, r.uploads[i].Commit, r.path, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		symbols, err := r.codeIntelAPI.DocumentSymbols(ctx, adjustedPath, r.uploads[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+
+		return r.adjustDocumentSymbols(ctx, r.uploads[i], adjustedPath, symbols)
+	}
+
+	return nil, nil
+}
+
+// adjustDocumentSymbols translates a hierarchy of document symbols (relative to the indexed commit) into
+// a hierarchy of equivalent symbols in the requested commit.
+func (r *queryResolver) adjustDocumentSymbols(ctx context.Context, dump store.Dump, path string, symbols []bundles.DocumentSymbol) ([]AdjustedDocumentSymbol, error) {
+	adjustedSymbols := make([]AdjustedDocumentSymbol, 0, len(symbols))
+	for i := range symbols {
+		adjustedCommit, adjustedRange, err := r.adjustRange(ctx, dump.RepositoryID, dump.Commit, path, symbols[i].Range)
+		if err != nil {
+			return nil, err
+		}
+
+		_, adjustedFullRange, err := r.adjustRange(ctx, dump.RepositoryID, dump.Commit, path, symbols[i].FullRange)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := r.adjustDocumentSymbols(ctx, dump, path, symbols[i].Children)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustedSymbols = append(adjustedSymbols, AdjustedDocumentSymbol{
+			Name:              symbols[i].Name,
+			Detail:            symbols[i].Detail,
+			Kind:              symbols[i].Kind,
+			Dump:              dump,
+			Path:              path,
+			AdjustedCommit:    adjustedCommit,
+			AdjustedRange:     adjustedRange,
+			AdjustedFullRange: adjustedFullRange,
+			Children:          children,
+		})
+	}
+
+	return adjustedSymbols, nil
+}
+
 // adjustLocations translates a list of resolved locations (relative to the indexed commit) into a list of
 // equivalent locations in the requested commit.
 func (r *queryResolver) adjustLocations(ctx context.Context, locations []codeintelapi.ResolvedLocation) ([]AdjustedLocation, error) {
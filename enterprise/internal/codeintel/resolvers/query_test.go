@@ -662,3 +662,99 @@ func TestDiagnostics(t *testing.T) {
 		t.Errorf("unexpected limit. want=%d have=%d", 0, val)
 	}
 }
+
+func TestDocumentSymbols(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// path can be translated for subsequent dumps
+	mockPositionAdjuster.AdjustPathFunc.SetDefaultReturn("/foo/bar.go", true, nil)
+
+	// first requested dump (dump 42) has no equivalent path
+	mockPositionAdjuster.AdjustPathFunc.PushReturn("", false, nil)
+
+	// second requested dump (dump 43) has no symbols
+	mockCodeIntelAPI.DocumentSymbolsFunc.PushReturn(nil, nil)
+
+	// third requested dump (dump 44) has symbols
+	mockCodeIntelAPI.DocumentSymbolsFunc.PushReturn([]bundles.DocumentSymbol{
+		{
+			Name:      "foo",
+			Detail:    "func()",
+			Kind:      12,
+			Range:     bundles.Range{Start: bundles.Position{Line: 1, Character: 5}, End: bundles.Position{Line: 1, Character: 8}},
+			FullRange: bundles.Range{Start: bundles.Position{Line: 1, Character: 0}, End: bundles.Position{Line: 3, Character: 1}},
+			Children: []bundles.DocumentSymbol{
+				{
+					Name:      "bar",
+					Kind:      13,
+					Range:     bundles.Range{Start: bundles.Position{Line: 2, Character: 1}, End: bundles.Position{Line: 2, Character: 4}},
+					FullRange: bundles.Range{Start: bundles.Position{Line: 2, Character: 1}, End: bundles.Position{Line: 2, Character: 10}},
+				},
+			},
+		},
+	}, nil)
+
+	mockPositionAdjuster.AdjustRangeFunc.SetDefaultHook(func(ctx context.Context, path, commit string, r bundles.Range, reverse bool) (string, bundles.Range, bool, error) {
+		return path, bundles.Range{
+			Start: bundles.Position{Line: r.Start.Line * 10, Character: r.Start.Character * 10},
+			End:   bundles.Position{Line: r.End.Line * 10, Character: r.End.Character * 10},
+		}, true, nil
+	})
+
+	dump := store.Dump{ID: 44, RepositoryID: 50, Commit: "deadbeef1"}
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			dump,
+			{ID: 45, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	symbols, err := queryResolver.DocumentSymbols(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error resolving document symbols: %s", err)
+	}
+
+	expectedSymbols := []AdjustedDocumentSymbol{
+		{
+			Name:              "foo",
+			Detail:            "func()",
+			Kind:              12,
+			Dump:              dump,
+			Path:              "/foo/bar.go",
+			AdjustedCommit:    "deadbeef2",
+			AdjustedRange:     bundles.Range{Start: bundles.Position{Line: 10, Character: 50}, End: bundles.Position{Line: 10, Character: 80}},
+			AdjustedFullRange: bundles.Range{Start: bundles.Position{Line: 10, Character: 0}, End: bundles.Position{Line: 30, Character: 10}},
+			Children: []AdjustedDocumentSymbol{
+				{
+					Name:              "bar",
+					Kind:              13,
+					Dump:              dump,
+					Path:              "/foo/bar.go",
+					AdjustedCommit:    "deadbeef2",
+					AdjustedRange:     bundles.Range{Start: bundles.Position{Line: 20, Character: 10}, End: bundles.Position{Line: 20, Character: 40}},
+					AdjustedFullRange: bundles.Range{Start: bundles.Position{Line: 20, Character: 10}, End: bundles.Position{Line: 20, Character: 100}},
+					Children:          []AdjustedDocumentSymbol{},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedSymbols, symbols); diff != "" {
+		t.Errorf("unexpected document symbols (-want +got):\n%s", diff)
+	}
+
+	if history := mockCodeIntelAPI.DocumentSymbolsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of calls to DocumentSymbols. want=%d have=%d", 2, len(history))
+	}
+}
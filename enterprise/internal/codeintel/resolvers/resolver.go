@@ -21,6 +21,9 @@ type Resolver interface {
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
 	DeleteUploadByID(ctx context.Context, uploadID int) error
 	DeleteIndexByID(ctx context.Context, id int) error
+	QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int, commit string) error
+	UpdateRetentionConfiguration(ctx context.Context, configuration store.RetentionConfiguration) error
+	GetIndexConfigurationError(ctx context.Context, repositoryID int) (store.IndexConfigurationError, bool, error)
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
 }
 
@@ -67,6 +70,22 @@ func (r *resolver) DeleteIndexByID(ctx context.Context, id int) error {
 	return err
 }
 
+// QueueAutoIndexJobForRepo requests that index jobs be scheduled for the given repository and commit.
+// The jobs are scheduled asynchronously by the precise-code-intel-indexer.
+func (r *resolver) QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int, commit string) error {
+	return r.store.InsertIndexRequest(ctx, repositoryID, commit)
+}
+
+// UpdateRetentionConfiguration replaces the data retention policy override of a repository. The
+// policy is enforced by the precise-code-intel-bundle-manager janitor.
+func (r *resolver) UpdateRetentionConfiguration(ctx context.Context, configuration store.RetentionConfiguration) error {
+	return r.store.UpdateRetentionConfiguration(ctx, configuration)
+}
+
+func (r *resolver) GetIndexConfigurationError(ctx context.Context, repositoryID int) (store.IndexConfigurationError, bool, error) {
+	return r.store.GetIndexConfigurationError(ctx, repositoryID)
+}
+
 // QueryResolver determines the set of dumps that can answer code intel queries for the
 // given repository, commit, and path, then constructs a new query resolver instance which
 // can be used to answer subsequent queries.
@@ -573,69 +573,6 @@ func TestDeleteOldestDump(t *testing.T) {
 	}
 }
 
-func TestSoftDeleteOldDumps(t *testing.T) {
-	if testing.Short() {
-		t.Skip()
-	}
-	dbtesting.SetupGlobalTestDB(t)
-	store := testStore()
-
-	t1 := time.Unix(1587396557, 0).UTC()
-	t2 := t1.Add(time.Minute)
-	t3 := t1.Add(time.Minute * 4)
-	t4 := t1.Add(time.Minute * 6)
-
-	insertUploads(t, dbconn.Global,
-		Upload{ID: 1, FinishedAt: &t1},
-		Upload{ID: 2, FinishedAt: &t2}, // visible
-		Upload{ID: 3, FinishedAt: &t2},
-		Upload{ID: 4, FinishedAt: &t3}, // visible
-		Upload{ID: 5, FinishedAt: &t3},
-		Upload{ID: 6, FinishedAt: &t4}, // too new
-		Upload{ID: 7, FinishedAt: &t4}, // too new
-	)
-	insertVisibleAtTip(t, dbconn.Global, 50, 2, 4)
-
-	if count, err := store.SoftDeleteOldDumps(context.Background(), time.Minute, t1.Add(time.Minute*6)); err != nil {
-		t.Fatalf("unexpected error pruning dumps: %s", err)
-	} else if count != 3 {
-		t.Fatalf("unexpected number of uploads deleted: want=%d have=%d", 3, count)
-	}
-
-	expectedStates := map[int]string{
-		1: "deleted",
-		2: "completed",
-		3: "deleted",
-		4: "completed",
-		5: "deleted",
-		6: "completed",
-		7: "completed",
-	}
-
-	// Ensure record was deleted
-	if states, err := store.GetStates(context.Background(), []int{1, 2, 3, 4, 5, 6, 7}); err != nil {
-		t.Fatalf("unexpected error getting states: %s", err)
-	} else if diff := cmp.Diff(expectedStates, states); diff != "" {
-		t.Errorf("unexpected dump (-want +got):\n%s", diff)
-	}
-
-	// Ensure repository was marked as dirty
-	repositoryIDs, err := store.DirtyRepositories(context.Background())
-	if err != nil {
-		t.Fatalf("unexpected error listing dirty repositories: %s", err)
-	}
-
-	var keys []int
-	for repositoryID := range repositoryIDs {
-		keys = append(keys, repositoryID)
-	}
-	sort.Ints(keys)
-
-	if len(keys) != 1 || keys[0] != 50 {
-		t.Errorf("expected repository to be marked dirty")
-	}
-}
-
 type FindClosestDumpsTestCase struct {
 	commit              string
 	file                string
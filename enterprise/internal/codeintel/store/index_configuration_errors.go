@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+)
+
+// IndexConfigurationError describes an explicit index configuration that could not be parsed
+// by the index scheduler.
+type IndexConfigurationError struct {
+	RepositoryID int       `json:"repositoryId"`
+	Commit       string    `json:"commit"`
+	Message      string    `json:"message"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// scanIndexConfigurationErrors scans a slice of index configuration errors from the return value of `*store.query`.
+func scanIndexConfigurationErrors(rows *sql.Rows, queryErr error) (_ []IndexConfigurationError, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var indexConfigurationErrors []IndexConfigurationError
+	for rows.Next() {
+		var indexConfigurationError IndexConfigurationError
+		if err := rows.Scan(
+			&indexConfigurationError.RepositoryID,
+			&indexConfigurationError.Commit,
+			&indexConfigurationError.Message,
+			&indexConfigurationError.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		indexConfigurationErrors = append(indexConfigurationErrors, indexConfigurationError)
+	}
+
+	return indexConfigurationErrors, nil
+}
+
+// scanFirstIndexConfigurationError scans a slice of index configuration errors from the return value of
+// `*store.query` and returns the first.
+func scanFirstIndexConfigurationError(rows *sql.Rows, err error) (IndexConfigurationError, bool, error) {
+	indexConfigurationErrors, err := scanIndexConfigurationErrors(rows, err)
+	if err != nil || len(indexConfigurationErrors) == 0 {
+		return IndexConfigurationError{}, false, err
+	}
+	return indexConfigurationErrors[0], true, nil
+}
+
+// GetIndexConfigurationError returns the most recent index configuration error for a repository.
+func (s *store) GetIndexConfigurationError(ctx context.Context, repositoryID int) (IndexConfigurationError, bool, error) {
+	return scanFirstIndexConfigurationError(s.Store.Query(ctx, sqlf.Sprintf(`
+		SELECT
+			e.repository_id,
+			e.commit,
+			e.message,
+			e.updated_at
+		FROM lsif_index_configuration_errors e WHERE e.repository_id = %s
+	`, repositoryID)))
+}
+
+// UpdateIndexConfigurationError records that the index configuration of the given repository could not
+// be parsed at the given commit. This replaces any previous error recorded for the repository.
+func (s *store) UpdateIndexConfigurationError(ctx context.Context, repositoryID int, commit, message string, now time.Time) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_index_configuration_errors (repository_id, commit, message, updated_at)
+		VALUES (%s, %s, %s, %s)
+		ON CONFLICT (repository_id) DO UPDATE
+		SET commit = EXCLUDED.commit, message = EXCLUDED.message, updated_at = EXCLUDED.updated_at
+	`, repositoryID, commit, message, now))
+}
+
+// DeleteIndexConfigurationError clears the index configuration error for the given repository.
+func (s *store) DeleteIndexConfigurationError(ctx context.Context, repositoryID int) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(`DELETE FROM lsif_index_configuration_errors WHERE repository_id = %s`, repositoryID))
+}
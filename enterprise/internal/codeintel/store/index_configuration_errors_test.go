@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestIndexConfigurationErrors(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertRepo(t, dbconn.Global, 50, "")
+
+	if _, ok, err := store.GetIndexConfigurationError(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error getting index configuration error: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected index configuration error")
+	}
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+
+	if err := store.UpdateIndexConfigurationError(context.Background(), 50, makeCommit(1), "bad yaml", t1); err != nil {
+		t.Fatalf("unexpected error updating index configuration error: %s", err)
+	}
+	if err := store.UpdateIndexConfigurationError(context.Background(), 50, makeCommit(2), "worse yaml", t2); err != nil {
+		t.Fatalf("unexpected error updating index configuration error: %s", err)
+	}
+
+	indexConfigurationError, ok, err := store.GetIndexConfigurationError(context.Background(), 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting index configuration error: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected an index configuration error")
+	}
+
+	expectedError := IndexConfigurationError{
+		RepositoryID: 50,
+		Commit:       makeCommit(2),
+		Message:      "worse yaml",
+		UpdatedAt:    t2,
+	}
+	if diff := cmp.Diff(expectedError, indexConfigurationError); diff != "" {
+		t.Errorf("unexpected index configuration error (-want +got):\n%s", diff)
+	}
+
+	if err := store.DeleteIndexConfigurationError(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error deleting index configuration error: %s", err)
+	}
+
+	if _, ok, err := store.GetIndexConfigurationError(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error getting index configuration error: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected index configuration error")
+	}
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+)
+
+// IndexRequest is a request to schedule index jobs for a repository. If the commit is empty, the
+// request was made because the repository has changed, and the index scheduler should consider
+// the tip of the default branch as well as any recent tags. Otherwise, the request was made for
+// a specific commit of the repository.
+type IndexRequest struct {
+	ID           int       `json:"id"`
+	RepositoryID int       `json:"repositoryId"`
+	Commit       string    `json:"commit"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// scanIndexRequests scans a slice of index requests from the return value of `*store.query`.
+func scanIndexRequests(rows *sql.Rows, queryErr error) (_ []IndexRequest, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var indexRequests []IndexRequest
+	for rows.Next() {
+		var indexRequest IndexRequest
+		var commit *string
+		if err := rows.Scan(
+			&indexRequest.ID,
+			&indexRequest.RepositoryID,
+			&commit,
+			&indexRequest.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if commit != nil {
+			indexRequest.Commit = *commit
+		}
+
+		indexRequests = append(indexRequests, indexRequest)
+	}
+
+	return indexRequests, nil
+}
+
+// InsertIndexRequest requests that index jobs be scheduled for the given repository. If the given commit
+// is empty, the index scheduler will consider the tip of the default branch and recent tags. This method
+// does nothing if an identical request has not yet been handled.
+func (s *store) InsertIndexRequest(ctx context.Context, repositoryID int, commit string) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_index_requests (repository_id, commit)
+		SELECT %s, NULLIF(%s, '')
+		WHERE NOT EXISTS (
+			SELECT 1 FROM lsif_index_requests r
+			WHERE r.repository_id = %s AND r.commit IS NOT DISTINCT FROM NULLIF(%s, '')
+		)
+	`, repositoryID, commit, repositoryID, commit))
+}
+
+// IndexRequests returns the oldest index requests that have not yet been handled.
+func (s *store) IndexRequests(ctx context.Context, limit int) ([]IndexRequest, error) {
+	return scanIndexRequests(s.Store.Query(ctx, sqlf.Sprintf(`
+		SELECT r.id, r.repository_id, r.commit, r.created_at
+		FROM lsif_index_requests r
+		ORDER BY r.id
+		LIMIT %s
+	`, limit)))
+}
+
+// DeleteIndexRequest deletes an index request by its identifier.
+func (s *store) DeleteIndexRequest(ctx context.Context, id int) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(`DELETE FROM lsif_index_requests WHERE id = %s`, id))
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestIndexRequests(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertRepo(t, dbconn.Global, 50, "")
+	insertRepo(t, dbconn.Global, 51, "")
+
+	for _, request := range []struct {
+		repositoryID int
+		commit       string
+	}{
+		{50, ""},
+		{51, makeCommit(1)},
+		{50, makeCommit(2)},
+		{50, ""}, // duplicate of a pending request
+	} {
+		if err := store.InsertIndexRequest(context.Background(), request.repositoryID, request.commit); err != nil {
+			t.Fatalf("unexpected error inserting index request: %s", err)
+		}
+	}
+
+	indexRequests, err := store.IndexRequests(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error getting index requests: %s", err)
+	}
+
+	type request struct {
+		RepositoryID int
+		Commit       string
+	}
+	var requests []request
+	for _, indexRequest := range indexRequests {
+		requests = append(requests, request{indexRequest.RepositoryID, indexRequest.Commit})
+	}
+
+	expectedRequests := []request{
+		{50, ""},
+		{51, makeCommit(1)},
+	}
+	if diff := cmp.Diff(expectedRequests, requests); diff != "" {
+		t.Errorf("unexpected index requests (-want +got):\n%s", diff)
+	}
+
+	if err := store.DeleteIndexRequest(context.Background(), indexRequests[0].ID); err != nil {
+		t.Fatalf("unexpected error deleting index request: %s", err)
+	}
+
+	indexRequests, err = store.IndexRequests(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error getting index requests: %s", err)
+	}
+	if len(indexRequests) != 2 {
+		t.Errorf("unexpected number of index requests. want=%d have=%d", 2, len(indexRequests))
+	}
+}
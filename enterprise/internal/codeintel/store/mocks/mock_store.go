@@ -25,6 +25,13 @@ type MockStore struct {
 	// DeleteIndexByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexByID.
 	DeleteIndexByIDFunc *StoreDeleteIndexByIDFunc
+	// DeleteIndexConfigurationErrorFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteIndexConfigurationError.
+	DeleteIndexConfigurationErrorFunc *StoreDeleteIndexConfigurationErrorFunc
+	// DeleteIndexRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIndexRequest.
+	DeleteIndexRequestFunc *StoreDeleteIndexRequestFunc
 	// DeleteIndexesWithoutRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// DeleteIndexesWithoutRepository.
@@ -68,6 +75,9 @@ type MockStore struct {
 	// GetDumpByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetDumpByID.
 	GetDumpByIDFunc *StoreGetDumpByIDFunc
+	// GetExpirationCandidatesFunc is an instance of a mock function object
+	// controlling the behavior of the method GetExpirationCandidates.
+	GetExpirationCandidatesFunc *StoreGetExpirationCandidatesFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *StoreGetIndexByIDFunc
@@ -75,16 +85,28 @@ type MockStore struct {
 	// function object controlling the behavior of the method
 	// GetIndexConfigurationByRepositoryID.
 	GetIndexConfigurationByRepositoryIDFunc *StoreGetIndexConfigurationByRepositoryIDFunc
+	// GetIndexConfigurationErrorFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetIndexConfigurationError.
+	GetIndexConfigurationErrorFunc *StoreGetIndexConfigurationErrorFunc
 	// GetIndexesFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexes.
 	GetIndexesFunc *StoreGetIndexesFunc
 	// GetPackageFunc is an instance of a mock function object controlling
 	// the behavior of the method GetPackage.
 	GetPackageFunc *StoreGetPackageFunc
+	// GetRepositoriesWithExpirationCandidatesFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// GetRepositoriesWithExpirationCandidates.
+	GetRepositoriesWithExpirationCandidatesFunc *StoreGetRepositoriesWithExpirationCandidatesFunc
 	// GetRepositoriesWithIndexConfigurationFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetRepositoriesWithIndexConfiguration.
 	GetRepositoriesWithIndexConfigurationFunc *StoreGetRepositoriesWithIndexConfigurationFunc
+	// GetRetentionConfigurationsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetRetentionConfigurations.
+	GetRetentionConfigurationsFunc *StoreGetRetentionConfigurationsFunc
 	// GetStatesFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStates.
 	GetStatesFunc *StoreGetStatesFunc
@@ -109,12 +131,18 @@ type MockStore struct {
 	// IndexQueueSizeFunc is an instance of a mock function object
 	// controlling the behavior of the method IndexQueueSize.
 	IndexQueueSizeFunc *StoreIndexQueueSizeFunc
+	// IndexRequestsFunc is an instance of a mock function object
+	// controlling the behavior of the method IndexRequests.
+	IndexRequestsFunc *StoreIndexRequestsFunc
 	// IndexableRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method IndexableRepositories.
 	IndexableRepositoriesFunc *StoreIndexableRepositoriesFunc
 	// InsertIndexFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertIndex.
 	InsertIndexFunc *StoreInsertIndexFunc
+	// InsertIndexRequestFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertIndexRequest.
+	InsertIndexRequestFunc *StoreInsertIndexRequestFunc
 	// InsertUploadFunc is an instance of a mock function object controlling
 	// the behavior of the method InsertUpload.
 	InsertUploadFunc *StoreInsertUploadFunc
@@ -176,12 +204,16 @@ type MockStore struct {
 	// SetIndexLogContentsFunc is an instance of a mock function object
 	// controlling the behavior of the method SetIndexLogContents.
 	SetIndexLogContentsFunc *StoreSetIndexLogContentsFunc
-	// SoftDeleteOldDumpsFunc is an instance of a mock function object
-	// controlling the behavior of the method SoftDeleteOldDumps.
-	SoftDeleteOldDumpsFunc *StoreSoftDeleteOldDumpsFunc
+	// SoftDeleteUploadsByIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method SoftDeleteUploadsByIDs.
+	SoftDeleteUploadsByIDsFunc *StoreSoftDeleteUploadsByIDsFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
+	// UpdateIndexConfigurationErrorFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateIndexConfigurationError.
+	UpdateIndexConfigurationErrorFunc *StoreUpdateIndexConfigurationErrorFunc
 	// UpdateIndexableRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateIndexableRepository.
@@ -192,6 +224,10 @@ type MockStore struct {
 	// UpdatePackagesFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePackages.
 	UpdatePackagesFunc *StoreUpdatePackagesFunc
+	// UpdateRetentionConfigurationFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateRetentionConfiguration.
+	UpdateRetentionConfigurationFunc *StoreUpdateRetentionConfigurationFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *StoreWithFunc
@@ -216,6 +252,16 @@ func NewMockStore() *MockStore {
 				return false, nil
 			},
 		},
+		DeleteIndexConfigurationErrorFunc: &StoreDeleteIndexConfigurationErrorFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
+		DeleteIndexRequestFunc: &StoreDeleteIndexRequestFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
 		DeleteIndexesWithoutRepositoryFunc: &StoreDeleteIndexesWithoutRepositoryFunc{
 			defaultHook: func(context.Context, time.Time) (map[int]int, error) {
 				return nil, nil
@@ -281,6 +327,11 @@ func NewMockStore() *MockStore {
 				return store.Dump{}, false, nil
 			},
 		},
+		GetExpirationCandidatesFunc: &StoreGetExpirationCandidatesFunc{
+			defaultHook: func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error) {
+				return nil, nil
+			},
+		},
 		GetIndexByIDFunc: &StoreGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (store.Index, bool, error) {
 				return store.Index{}, false, nil
@@ -291,6 +342,11 @@ func NewMockStore() *MockStore {
 				return store.IndexConfiguration{}, false, nil
 			},
 		},
+		GetIndexConfigurationErrorFunc: &StoreGetIndexConfigurationErrorFunc{
+			defaultHook: func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+				return store.IndexConfigurationError{}, false, nil
+			},
+		},
 		GetIndexesFunc: &StoreGetIndexesFunc{
 			defaultHook: func(context.Context, store.GetIndexesOptions) ([]store.Index, int, error) {
 				return nil, 0, nil
@@ -301,11 +357,21 @@ func NewMockStore() *MockStore {
 				return store.Dump{}, false, nil
 			},
 		},
+		GetRepositoriesWithExpirationCandidatesFunc: &StoreGetRepositoriesWithExpirationCandidatesFunc{
+			defaultHook: func(context.Context, time.Time) ([]int, error) {
+				return nil, nil
+			},
+		},
 		GetRepositoriesWithIndexConfigurationFunc: &StoreGetRepositoriesWithIndexConfigurationFunc{
 			defaultHook: func(context.Context) ([]int, error) {
 				return nil, nil
 			},
 		},
+		GetRetentionConfigurationsFunc: &StoreGetRetentionConfigurationsFunc{
+			defaultHook: func(context.Context) ([]store.RetentionConfiguration, error) {
+				return nil, nil
+			},
+		},
 		GetStatesFunc: &StoreGetStatesFunc{
 			defaultHook: func(context.Context, []int) (map[int]string, error) {
 				return nil, nil
@@ -346,6 +412,11 @@ func NewMockStore() *MockStore {
 				return 0, nil
 			},
 		},
+		IndexRequestsFunc: &StoreIndexRequestsFunc{
+			defaultHook: func(context.Context, int) ([]store.IndexRequest, error) {
+				return nil, nil
+			},
+		},
 		IndexableRepositoriesFunc: &StoreIndexableRepositoriesFunc{
 			defaultHook: func(context.Context, store.IndexableRepositoryQueryOptions) ([]store.IndexableRepository, error) {
 				return nil, nil
@@ -356,6 +427,11 @@ func NewMockStore() *MockStore {
 				return 0, nil
 			},
 		},
+		InsertIndexRequestFunc: &StoreInsertIndexRequestFunc{
+			defaultHook: func(context.Context, int, string) error {
+				return nil
+			},
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: func(context.Context, store.Upload) (int, error) {
 				return 0, nil
@@ -456,8 +532,8 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
-		SoftDeleteOldDumpsFunc: &StoreSoftDeleteOldDumpsFunc{
-			defaultHook: func(context.Context, time.Duration, time.Time) (int, error) {
+		SoftDeleteUploadsByIDsFunc: &StoreSoftDeleteUploadsByIDsFunc{
+			defaultHook: func(context.Context, ...int) (int, error) {
 				return 0, nil
 			},
 		},
@@ -466,6 +542,11 @@ func NewMockStore() *MockStore {
 				return nil, nil
 			},
 		},
+		UpdateIndexConfigurationErrorFunc: &StoreUpdateIndexConfigurationErrorFunc{
+			defaultHook: func(context.Context, int, string, string, time.Time) error {
+				return nil
+			},
+		},
 		UpdateIndexableRepositoryFunc: &StoreUpdateIndexableRepositoryFunc{
 			defaultHook: func(context.Context, store.UpdateableIndexableRepository, time.Time) error {
 				return nil
@@ -481,6 +562,11 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
+		UpdateRetentionConfigurationFunc: &StoreUpdateRetentionConfigurationFunc{
+			defaultHook: func(context.Context, store.RetentionConfiguration) error {
+				return nil
+			},
+		},
 		WithFunc: &StoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) store.Store {
 				return nil
@@ -502,6 +588,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		DeleteIndexByIDFunc: &StoreDeleteIndexByIDFunc{
 			defaultHook: i.DeleteIndexByID,
 		},
+		DeleteIndexConfigurationErrorFunc: &StoreDeleteIndexConfigurationErrorFunc{
+			defaultHook: i.DeleteIndexConfigurationError,
+		},
+		DeleteIndexRequestFunc: &StoreDeleteIndexRequestFunc{
+			defaultHook: i.DeleteIndexRequest,
+		},
 		DeleteIndexesWithoutRepositoryFunc: &StoreDeleteIndexesWithoutRepositoryFunc{
 			defaultHook: i.DeleteIndexesWithoutRepository,
 		},
@@ -541,21 +633,33 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetDumpByIDFunc: &StoreGetDumpByIDFunc{
 			defaultHook: i.GetDumpByID,
 		},
+		GetExpirationCandidatesFunc: &StoreGetExpirationCandidatesFunc{
+			defaultHook: i.GetExpirationCandidates,
+		},
 		GetIndexByIDFunc: &StoreGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
 		GetIndexConfigurationByRepositoryIDFunc: &StoreGetIndexConfigurationByRepositoryIDFunc{
 			defaultHook: i.GetIndexConfigurationByRepositoryID,
 		},
+		GetIndexConfigurationErrorFunc: &StoreGetIndexConfigurationErrorFunc{
+			defaultHook: i.GetIndexConfigurationError,
+		},
 		GetIndexesFunc: &StoreGetIndexesFunc{
 			defaultHook: i.GetIndexes,
 		},
 		GetPackageFunc: &StoreGetPackageFunc{
 			defaultHook: i.GetPackage,
 		},
+		GetRepositoriesWithExpirationCandidatesFunc: &StoreGetRepositoriesWithExpirationCandidatesFunc{
+			defaultHook: i.GetRepositoriesWithExpirationCandidates,
+		},
 		GetRepositoriesWithIndexConfigurationFunc: &StoreGetRepositoriesWithIndexConfigurationFunc{
 			defaultHook: i.GetRepositoriesWithIndexConfiguration,
 		},
+		GetRetentionConfigurationsFunc: &StoreGetRetentionConfigurationsFunc{
+			defaultHook: i.GetRetentionConfigurations,
+		},
 		GetStatesFunc: &StoreGetStatesFunc{
 			defaultHook: i.GetStates,
 		},
@@ -580,12 +684,18 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		IndexQueueSizeFunc: &StoreIndexQueueSizeFunc{
 			defaultHook: i.IndexQueueSize,
 		},
+		IndexRequestsFunc: &StoreIndexRequestsFunc{
+			defaultHook: i.IndexRequests,
+		},
 		IndexableRepositoriesFunc: &StoreIndexableRepositoriesFunc{
 			defaultHook: i.IndexableRepositories,
 		},
 		InsertIndexFunc: &StoreInsertIndexFunc{
 			defaultHook: i.InsertIndex,
 		},
+		InsertIndexRequestFunc: &StoreInsertIndexRequestFunc{
+			defaultHook: i.InsertIndexRequest,
+		},
 		InsertUploadFunc: &StoreInsertUploadFunc{
 			defaultHook: i.InsertUpload,
 		},
@@ -646,12 +756,15 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		SetIndexLogContentsFunc: &StoreSetIndexLogContentsFunc{
 			defaultHook: i.SetIndexLogContents,
 		},
-		SoftDeleteOldDumpsFunc: &StoreSoftDeleteOldDumpsFunc{
-			defaultHook: i.SoftDeleteOldDumps,
+		SoftDeleteUploadsByIDsFunc: &StoreSoftDeleteUploadsByIDsFunc{
+			defaultHook: i.SoftDeleteUploadsByIDs,
 		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UpdateIndexConfigurationErrorFunc: &StoreUpdateIndexConfigurationErrorFunc{
+			defaultHook: i.UpdateIndexConfigurationError,
+		},
 		UpdateIndexableRepositoryFunc: &StoreUpdateIndexableRepositoryFunc{
 			defaultHook: i.UpdateIndexableRepository,
 		},
@@ -661,6 +774,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdatePackagesFunc: &StoreUpdatePackagesFunc{
 			defaultHook: i.UpdatePackages,
 		},
+		UpdateRetentionConfigurationFunc: &StoreUpdateRetentionConfigurationFunc{
+			defaultHook: i.UpdateRetentionConfiguration,
+		},
 		WithFunc: &StoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -1000,6 +1116,221 @@ func (c StoreDeleteIndexByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreDeleteIndexConfigurationErrorFunc describes the behavior when the
+// DeleteIndexConfigurationError method of the parent MockStore instance is
+// invoked.
+type StoreDeleteIndexConfigurationErrorFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []StoreDeleteIndexConfigurationErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIndexConfigurationError delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteIndexConfigurationError(v0 context.Context, v1 int) error {
+	r0 := m.DeleteIndexConfigurationErrorFunc.nextHook()(v0, v1)
+	m.DeleteIndexConfigurationErrorFunc.appendCall(StoreDeleteIndexConfigurationErrorFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteIndexConfigurationError method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreDeleteIndexConfigurationErrorFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIndexConfigurationError method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreDeleteIndexConfigurationErrorFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreDeleteIndexConfigurationErrorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreDeleteIndexConfigurationErrorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *StoreDeleteIndexConfigurationErrorFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeleteIndexConfigurationErrorFunc) appendCall(r0 StoreDeleteIndexConfigurationErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeleteIndexConfigurationErrorFuncCall
+// objects describing the invocations of this function.
+func (f *StoreDeleteIndexConfigurationErrorFunc) History() []StoreDeleteIndexConfigurationErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeleteIndexConfigurationErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeleteIndexConfigurationErrorFuncCall is an object that describes an
+// invocation of method DeleteIndexConfigurationError on an instance of
+// MockStore.
+type StoreDeleteIndexConfigurationErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeleteIndexConfigurationErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeleteIndexConfigurationErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreDeleteIndexRequestFunc describes the behavior when the
+// DeleteIndexRequest method of the parent MockStore instance is invoked.
+type StoreDeleteIndexRequestFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []StoreDeleteIndexRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIndexRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteIndexRequest(v0 context.Context, v1 int) error {
+	r0 := m.DeleteIndexRequestFunc.nextHook()(v0, v1)
+	m.DeleteIndexRequestFunc.appendCall(StoreDeleteIndexRequestFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIndexRequest
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreDeleteIndexRequestFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIndexRequest method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreDeleteIndexRequestFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreDeleteIndexRequestFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreDeleteIndexRequestFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *StoreDeleteIndexRequestFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDeleteIndexRequestFunc) appendCall(r0 StoreDeleteIndexRequestFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDeleteIndexRequestFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDeleteIndexRequestFunc) History() []StoreDeleteIndexRequestFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDeleteIndexRequestFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDeleteIndexRequestFuncCall is an object that describes an invocation
+// of method DeleteIndexRequest on an instance of MockStore.
+type StoreDeleteIndexRequestFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDeleteIndexRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDeleteIndexRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreDeleteIndexesWithoutRepositoryFunc describes the behavior when the
 // DeleteIndexesWithoutRepository method of the parent MockStore instance is
 // invoked.
@@ -2463,34 +2794,36 @@ func (c StoreGetDumpByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// StoreGetIndexByIDFunc describes the behavior when the GetIndexByID method
-// of the parent MockStore instance is invoked.
-type StoreGetIndexByIDFunc struct {
-	defaultHook func(context.Context, int) (store.Index, bool, error)
-	hooks       []func(context.Context, int) (store.Index, bool, error)
-	history     []StoreGetIndexByIDFuncCall
+// StoreGetExpirationCandidatesFunc describes the behavior when the
+// GetExpirationCandidates method of the parent MockStore instance is
+// invoked.
+type StoreGetExpirationCandidatesFunc struct {
+	defaultHook func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error)
+	hooks       []func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error)
+	history     []StoreGetExpirationCandidatesFuncCall
 	mutex       sync.Mutex
 }
 
-// GetIndexByID delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockStore) GetIndexByID(v0 context.Context, v1 int) (store.Index, bool, error) {
-	r0, r1, r2 := m.GetIndexByIDFunc.nextHook()(v0, v1)
-	m.GetIndexByIDFunc.appendCall(StoreGetIndexByIDFuncCall{v0, v1, r0, r1, r2})
-	return r0, r1, r2
+// GetExpirationCandidates delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) GetExpirationCandidates(v0 context.Context, v1 int, v2 time.Time) ([]store.ExpirationCandidate, error) {
+	r0, r1 := m.GetExpirationCandidatesFunc.nextHook()(v0, v1, v2)
+	m.GetExpirationCandidatesFunc.appendCall(StoreGetExpirationCandidatesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetIndexByID method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetIndexByIDFunc) SetDefaultHook(hook func(context.Context, int) (store.Index, bool, error)) {
+// SetDefaultHook sets function that is called when the
+// GetExpirationCandidates method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetExpirationCandidatesFunc) SetDefaultHook(hook func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetIndexByID method of the parent MockStore instance inovkes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreGetIndexByIDFunc) PushHook(hook func(context.Context, int) (store.Index, bool, error)) {
+// GetExpirationCandidates method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetExpirationCandidatesFunc) PushHook(hook func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2498,21 +2831,21 @@ func (f *StoreGetIndexByIDFunc) PushHook(hook func(context.Context, int) (store.
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StoreGetIndexByIDFunc) SetDefaultReturn(r0 store.Index, r1 bool, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) (store.Index, bool, error) {
-		return r0, r1, r2
+func (f *StoreGetExpirationCandidatesFunc) SetDefaultReturn(r0 []store.ExpirationCandidate, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StoreGetIndexByIDFunc) PushReturn(r0 store.Index, r1 bool, r2 error) {
-	f.PushHook(func(context.Context, int) (store.Index, bool, error) {
-		return r0, r1, r2
+func (f *StoreGetExpirationCandidatesFunc) PushReturn(r0 []store.ExpirationCandidate, r1 error) {
+	f.PushHook(func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreGetIndexByIDFunc) nextHook() func(context.Context, int) (store.Index, bool, error) {
+func (f *StoreGetExpirationCandidatesFunc) nextHook() func(context.Context, int, time.Time) ([]store.ExpirationCandidate, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2525,17 +2858,128 @@ func (f *StoreGetIndexByIDFunc) nextHook() func(context.Context, int) (store.Ind
 	return hook
 }
 
-func (f *StoreGetIndexByIDFunc) appendCall(r0 StoreGetIndexByIDFuncCall) {
+func (f *StoreGetExpirationCandidatesFunc) appendCall(r0 StoreGetExpirationCandidatesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetIndexByIDFuncCall objects
-// describing the invocations of this function.
-func (f *StoreGetIndexByIDFunc) History() []StoreGetIndexByIDFuncCall {
+// History returns a sequence of StoreGetExpirationCandidatesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetExpirationCandidatesFunc) History() []StoreGetExpirationCandidatesFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetIndexByIDFuncCall, len(f.history))
+	history := make([]StoreGetExpirationCandidatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetExpirationCandidatesFuncCall is an object that describes an
+// invocation of method GetExpirationCandidates on an instance of MockStore.
+type StoreGetExpirationCandidatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.ExpirationCandidate
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetExpirationCandidatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetExpirationCandidatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetIndexByIDFunc describes the behavior when the GetIndexByID method
+// of the parent MockStore instance is invoked.
+type StoreGetIndexByIDFunc struct {
+	defaultHook func(context.Context, int) (store.Index, bool, error)
+	hooks       []func(context.Context, int) (store.Index, bool, error)
+	history     []StoreGetIndexByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIndexByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetIndexByID(v0 context.Context, v1 int) (store.Index, bool, error) {
+	r0, r1, r2 := m.GetIndexByIDFunc.nextHook()(v0, v1)
+	m.GetIndexByIDFunc.appendCall(StoreGetIndexByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetIndexByID method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetIndexByIDFunc) SetDefaultHook(hook func(context.Context, int) (store.Index, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIndexByID method of the parent MockStore instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetIndexByIDFunc) PushHook(hook func(context.Context, int) (store.Index, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetIndexByIDFunc) SetDefaultReturn(r0 store.Index, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (store.Index, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetIndexByIDFunc) PushReturn(r0 store.Index, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (store.Index, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetIndexByIDFunc) nextHook() func(context.Context, int) (store.Index, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetIndexByIDFunc) appendCall(r0 StoreGetIndexByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetIndexByIDFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetIndexByIDFunc) History() []StoreGetIndexByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetIndexByIDFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
@@ -2691,6 +3135,121 @@ func (c StoreGetIndexConfigurationByRepositoryIDFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetIndexConfigurationErrorFunc describes the behavior when the
+// GetIndexConfigurationError method of the parent MockStore instance is
+// invoked.
+type StoreGetIndexConfigurationErrorFunc struct {
+	defaultHook func(context.Context, int) (store.IndexConfigurationError, bool, error)
+	hooks       []func(context.Context, int) (store.IndexConfigurationError, bool, error)
+	history     []StoreGetIndexConfigurationErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIndexConfigurationError delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetIndexConfigurationError(v0 context.Context, v1 int) (store.IndexConfigurationError, bool, error) {
+	r0, r1, r2 := m.GetIndexConfigurationErrorFunc.nextHook()(v0, v1)
+	m.GetIndexConfigurationErrorFunc.appendCall(StoreGetIndexConfigurationErrorFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetIndexConfigurationError method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetIndexConfigurationErrorFunc) SetDefaultHook(hook func(context.Context, int) (store.IndexConfigurationError, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIndexConfigurationError method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetIndexConfigurationErrorFunc) PushHook(hook func(context.Context, int) (store.IndexConfigurationError, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetIndexConfigurationErrorFunc) SetDefaultReturn(r0 store.IndexConfigurationError, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetIndexConfigurationErrorFunc) PushReturn(r0 store.IndexConfigurationError, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetIndexConfigurationErrorFunc) nextHook() func(context.Context, int) (store.IndexConfigurationError, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetIndexConfigurationErrorFunc) appendCall(r0 StoreGetIndexConfigurationErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetIndexConfigurationErrorFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetIndexConfigurationErrorFunc) History() []StoreGetIndexConfigurationErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetIndexConfigurationErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetIndexConfigurationErrorFuncCall is an object that describes an
+// invocation of method GetIndexConfigurationError on an instance of
+// MockStore.
+type StoreGetIndexConfigurationErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store.IndexConfigurationError
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetIndexConfigurationErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetIndexConfigurationErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetIndexesFunc describes the behavior when the GetIndexes method of
 // the parent MockStore instance is invoked.
 type StoreGetIndexesFunc struct {
@@ -2887,70 +3446,294 @@ type StoreGetPackageFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 string
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetPackageFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetPackageFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetRepositoriesWithExpirationCandidatesFunc describes the behavior
+// when the GetRepositoriesWithExpirationCandidates method of the parent
+// MockStore instance is invoked.
+type StoreGetRepositoriesWithExpirationCandidatesFunc struct {
+	defaultHook func(context.Context, time.Time) ([]int, error)
+	hooks       []func(context.Context, time.Time) ([]int, error)
+	history     []StoreGetRepositoriesWithExpirationCandidatesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepositoriesWithExpirationCandidates delegates to the next hook
+// function in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) GetRepositoriesWithExpirationCandidates(v0 context.Context, v1 time.Time) ([]int, error) {
+	r0, r1 := m.GetRepositoriesWithExpirationCandidatesFunc.nextHook()(v0, v1)
+	m.GetRepositoriesWithExpirationCandidatesFunc.appendCall(StoreGetRepositoriesWithExpirationCandidatesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRepositoriesWithExpirationCandidates method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) SetDefaultHook(hook func(context.Context, time.Time) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepositoriesWithExpirationCandidates method of the parent MockStore
+// instance inovkes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) PushHook(hook func(context.Context, time.Time) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) nextHook() func(context.Context, time.Time) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) appendCall(r0 StoreGetRepositoriesWithExpirationCandidatesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetRepositoriesWithExpirationCandidatesFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetRepositoriesWithExpirationCandidatesFunc) History() []StoreGetRepositoriesWithExpirationCandidatesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRepositoriesWithExpirationCandidatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRepositoriesWithExpirationCandidatesFuncCall is an object that
+// describes an invocation of method GetRepositoriesWithExpirationCandidates
+// on an instance of MockStore.
+type StoreGetRepositoriesWithExpirationCandidatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetRepositoriesWithExpirationCandidatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetRepositoriesWithExpirationCandidatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetRepositoriesWithIndexConfigurationFunc describes the behavior
+// when the GetRepositoriesWithIndexConfiguration method of the parent
+// MockStore instance is invoked.
+type StoreGetRepositoriesWithIndexConfigurationFunc struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []StoreGetRepositoriesWithIndexConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// GetRepositoriesWithIndexConfiguration delegates to the next hook function
+// in the queue and stores the parameter and result values of this
+// invocation.
+func (m *MockStore) GetRepositoriesWithIndexConfiguration(v0 context.Context) ([]int, error) {
+	r0, r1 := m.GetRepositoriesWithIndexConfigurationFunc.nextHook()(v0)
+	m.GetRepositoriesWithIndexConfigurationFunc.appendCall(StoreGetRepositoriesWithIndexConfigurationFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetRepositoriesWithIndexConfiguration method of the parent MockStore
+// instance is invoked and the hook queue is empty.
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetRepositoriesWithIndexConfiguration method of the parent MockStore
+// instance inovkes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) appendCall(r0 StoreGetRepositoriesWithIndexConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetRepositoriesWithIndexConfigurationFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetRepositoriesWithIndexConfigurationFunc) History() []StoreGetRepositoriesWithIndexConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetRepositoriesWithIndexConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetRepositoriesWithIndexConfigurationFuncCall is an object that
+// describes an invocation of method GetRepositoriesWithIndexConfiguration
+// on an instance of MockStore.
+type StoreGetRepositoriesWithIndexConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 store.Dump
+	Result0 []int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 bool
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetPackageFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+func (c StoreGetRepositoriesWithIndexConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetPackageFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1, c.Result2}
+func (c StoreGetRepositoriesWithIndexConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetRepositoriesWithIndexConfigurationFunc describes the behavior
-// when the GetRepositoriesWithIndexConfiguration method of the parent
-// MockStore instance is invoked.
-type StoreGetRepositoriesWithIndexConfigurationFunc struct {
-	defaultHook func(context.Context) ([]int, error)
-	hooks       []func(context.Context) ([]int, error)
-	history     []StoreGetRepositoriesWithIndexConfigurationFuncCall
+// StoreGetRetentionConfigurationsFunc describes the behavior when the
+// GetRetentionConfigurations method of the parent MockStore instance is
+// invoked.
+type StoreGetRetentionConfigurationsFunc struct {
+	defaultHook func(context.Context) ([]store.RetentionConfiguration, error)
+	hooks       []func(context.Context) ([]store.RetentionConfiguration, error)
+	history     []StoreGetRetentionConfigurationsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetRepositoriesWithIndexConfiguration delegates to the next hook function
-// in the queue and stores the parameter and result values of this
-// invocation.
-func (m *MockStore) GetRepositoriesWithIndexConfiguration(v0 context.Context) ([]int, error) {
-	r0, r1 := m.GetRepositoriesWithIndexConfigurationFunc.nextHook()(v0)
-	m.GetRepositoriesWithIndexConfigurationFunc.appendCall(StoreGetRepositoriesWithIndexConfigurationFuncCall{v0, r0, r1})
+// GetRetentionConfigurations delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetRetentionConfigurations(v0 context.Context) ([]store.RetentionConfiguration, error) {
+	r0, r1 := m.GetRetentionConfigurationsFunc.nextHook()(v0)
+	m.GetRetentionConfigurationsFunc.appendCall(StoreGetRetentionConfigurationsFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// GetRepositoriesWithIndexConfiguration method of the parent MockStore
-// instance is invoked and the hook queue is empty.
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+// GetRetentionConfigurations method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetRetentionConfigurationsFunc) SetDefaultHook(hook func(context.Context) ([]store.RetentionConfiguration, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetRepositoriesWithIndexConfiguration method of the parent MockStore
-// instance inovkes the hook at the front of the queue and discards it.
-// After the queue is empty, the default hook function is invoked for any
-// future action.
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook func(context.Context) ([]int, error)) {
+// GetRetentionConfigurations method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetRetentionConfigurationsFunc) PushHook(hook func(context.Context) ([]store.RetentionConfiguration, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2958,21 +3741,21 @@ func (f *StoreGetRepositoriesWithIndexConfigurationFunc) PushHook(hook func(cont
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) SetDefaultReturn(r0 []int, r1 error) {
-	f.SetDefaultHook(func(context.Context) ([]int, error) {
+func (f *StoreGetRetentionConfigurationsFunc) SetDefaultReturn(r0 []store.RetentionConfiguration, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]store.RetentionConfiguration, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) PushReturn(r0 []int, r1 error) {
-	f.PushHook(func(context.Context) ([]int, error) {
+func (f *StoreGetRetentionConfigurationsFunc) PushReturn(r0 []store.RetentionConfiguration, r1 error) {
+	f.PushHook(func(context.Context) ([]store.RetentionConfiguration, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) nextHook() func(context.Context) ([]int, error) {
+func (f *StoreGetRetentionConfigurationsFunc) nextHook() func(context.Context) ([]store.RetentionConfiguration, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2985,34 +3768,33 @@ func (f *StoreGetRepositoriesWithIndexConfigurationFunc) nextHook() func(context
 	return hook
 }
 
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) appendCall(r0 StoreGetRepositoriesWithIndexConfigurationFuncCall) {
+func (f *StoreGetRetentionConfigurationsFunc) appendCall(r0 StoreGetRetentionConfigurationsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// StoreGetRepositoriesWithIndexConfigurationFuncCall objects describing the
-// invocations of this function.
-func (f *StoreGetRepositoriesWithIndexConfigurationFunc) History() []StoreGetRepositoriesWithIndexConfigurationFuncCall {
+// History returns a sequence of StoreGetRetentionConfigurationsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetRetentionConfigurationsFunc) History() []StoreGetRetentionConfigurationsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetRepositoriesWithIndexConfigurationFuncCall, len(f.history))
+	history := make([]StoreGetRetentionConfigurationsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetRepositoriesWithIndexConfigurationFuncCall is an object that
-// describes an invocation of method GetRepositoriesWithIndexConfiguration
-// on an instance of MockStore.
-type StoreGetRepositoriesWithIndexConfigurationFuncCall struct {
+// StoreGetRetentionConfigurationsFuncCall is an object that describes an
+// invocation of method GetRetentionConfigurations on an instance of
+// MockStore.
+type StoreGetRetentionConfigurationsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []int
+	Result0 []store.RetentionConfiguration
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3020,13 +3802,13 @@ type StoreGetRepositoriesWithIndexConfigurationFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetRepositoriesWithIndexConfigurationFuncCall) Args() []interface{} {
+func (c StoreGetRetentionConfigurationsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetRepositoriesWithIndexConfigurationFuncCall) Results() []interface{} {
+func (c StoreGetRetentionConfigurationsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -3897,6 +4679,114 @@ func (c StoreIndexQueueSizeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreIndexRequestsFunc describes the behavior when the IndexRequests
+// method of the parent MockStore instance is invoked.
+type StoreIndexRequestsFunc struct {
+	defaultHook func(context.Context, int) ([]store.IndexRequest, error)
+	hooks       []func(context.Context, int) ([]store.IndexRequest, error)
+	history     []StoreIndexRequestsFuncCall
+	mutex       sync.Mutex
+}
+
+// IndexRequests delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) IndexRequests(v0 context.Context, v1 int) ([]store.IndexRequest, error) {
+	r0, r1 := m.IndexRequestsFunc.nextHook()(v0, v1)
+	m.IndexRequestsFunc.appendCall(StoreIndexRequestsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the IndexRequests method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreIndexRequestsFunc) SetDefaultHook(hook func(context.Context, int) ([]store.IndexRequest, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IndexRequests method of the parent MockStore instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreIndexRequestsFunc) PushHook(hook func(context.Context, int) ([]store.IndexRequest, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreIndexRequestsFunc) SetDefaultReturn(r0 []store.IndexRequest, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]store.IndexRequest, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreIndexRequestsFunc) PushReturn(r0 []store.IndexRequest, r1 error) {
+	f.PushHook(func(context.Context, int) ([]store.IndexRequest, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreIndexRequestsFunc) nextHook() func(context.Context, int) ([]store.IndexRequest, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreIndexRequestsFunc) appendCall(r0 StoreIndexRequestsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreIndexRequestsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreIndexRequestsFunc) History() []StoreIndexRequestsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreIndexRequestsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreIndexRequestsFuncCall is an object that describes an invocation of
+// method IndexRequests on an instance of MockStore.
+type StoreIndexRequestsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.IndexRequest
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreIndexRequestsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreIndexRequestsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreIndexableRepositoriesFunc describes the behavior when the
 // IndexableRepositories method of the parent MockStore instance is invoked.
 type StoreIndexableRepositoriesFunc struct {
@@ -4015,25 +4905,134 @@ type StoreInsertIndexFunc struct {
 	mutex       sync.Mutex
 }
 
-// InsertIndex delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockStore) InsertIndex(v0 context.Context, v1 store.Index) (int, error) {
-	r0, r1 := m.InsertIndexFunc.nextHook()(v0, v1)
-	m.InsertIndexFunc.appendCall(StoreInsertIndexFuncCall{v0, v1, r0, r1})
-	return r0, r1
+// InsertIndex delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) InsertIndex(v0 context.Context, v1 store.Index) (int, error) {
+	r0, r1 := m.InsertIndexFunc.nextHook()(v0, v1)
+	m.InsertIndexFunc.appendCall(StoreInsertIndexFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the InsertIndex method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreInsertIndexFunc) SetDefaultHook(hook func(context.Context, store.Index) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertIndex method of the parent MockStore instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreInsertIndexFunc) PushHook(hook func(context.Context, store.Index) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreInsertIndexFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.Index) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreInsertIndexFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, store.Index) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreInsertIndexFunc) nextHook() func(context.Context, store.Index) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertIndexFunc) appendCall(r0 StoreInsertIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertIndexFuncCall objects describing
+// the invocations of this function.
+func (f *StoreInsertIndexFunc) History() []StoreInsertIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertIndexFuncCall is an object that describes an invocation of
+// method InsertIndex on an instance of MockStore.
+type StoreInsertIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.Index
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreInsertIndexRequestFunc describes the behavior when the
+// InsertIndexRequest method of the parent MockStore instance is invoked.
+type StoreInsertIndexRequestFunc struct {
+	defaultHook func(context.Context, int, string) error
+	hooks       []func(context.Context, int, string) error
+	history     []StoreInsertIndexRequestFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertIndexRequest delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) InsertIndexRequest(v0 context.Context, v1 int, v2 string) error {
+	r0 := m.InsertIndexRequestFunc.nextHook()(v0, v1, v2)
+	m.InsertIndexRequestFunc.appendCall(StoreInsertIndexRequestFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the InsertIndex method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreInsertIndexFunc) SetDefaultHook(hook func(context.Context, store.Index) (int, error)) {
+// SetDefaultHook sets function that is called when the InsertIndexRequest
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreInsertIndexRequestFunc) SetDefaultHook(hook func(context.Context, int, string) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// InsertIndex method of the parent MockStore instance inovkes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreInsertIndexFunc) PushHook(hook func(context.Context, store.Index) (int, error)) {
+// InsertIndexRequest method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreInsertIndexRequestFunc) PushHook(hook func(context.Context, int, string) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4041,21 +5040,21 @@ func (f *StoreInsertIndexFunc) PushHook(hook func(context.Context, store.Index)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StoreInsertIndexFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, store.Index) (int, error) {
-		return r0, r1
+func (f *StoreInsertIndexRequestFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StoreInsertIndexFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, store.Index) (int, error) {
-		return r0, r1
+func (f *StoreInsertIndexRequestFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string) error {
+		return r0
 	})
 }
 
-func (f *StoreInsertIndexFunc) nextHook() func(context.Context, store.Index) (int, error) {
+func (f *StoreInsertIndexRequestFunc) nextHook() func(context.Context, int, string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4068,50 +5067,50 @@ func (f *StoreInsertIndexFunc) nextHook() func(context.Context, store.Index) (in
 	return hook
 }
 
-func (f *StoreInsertIndexFunc) appendCall(r0 StoreInsertIndexFuncCall) {
+func (f *StoreInsertIndexRequestFunc) appendCall(r0 StoreInsertIndexRequestFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreInsertIndexFuncCall objects describing
-// the invocations of this function.
-func (f *StoreInsertIndexFunc) History() []StoreInsertIndexFuncCall {
+// History returns a sequence of StoreInsertIndexRequestFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertIndexRequestFunc) History() []StoreInsertIndexRequestFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreInsertIndexFuncCall, len(f.history))
+	history := make([]StoreInsertIndexRequestFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreInsertIndexFuncCall is an object that describes an invocation of
-// method InsertIndex on an instance of MockStore.
-type StoreInsertIndexFuncCall struct {
+// StoreInsertIndexRequestFuncCall is an object that describes an invocation
+// of method InsertIndexRequest on an instance of MockStore.
+type StoreInsertIndexRequestFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 store.Index
-	// Result0 is the value of the 1st result returned from this method
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreInsertIndexFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c StoreInsertIndexRequestFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreInsertIndexFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c StoreInsertIndexRequestFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // StoreInsertUploadFunc describes the behavior when the InsertUpload method
@@ -6315,35 +7314,36 @@ func (c StoreSetIndexLogContentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// StoreSoftDeleteOldDumpsFunc describes the behavior when the
-// SoftDeleteOldDumps method of the parent MockStore instance is invoked.
-type StoreSoftDeleteOldDumpsFunc struct {
-	defaultHook func(context.Context, time.Duration, time.Time) (int, error)
-	hooks       []func(context.Context, time.Duration, time.Time) (int, error)
-	history     []StoreSoftDeleteOldDumpsFuncCall
+// StoreSoftDeleteUploadsByIDsFunc describes the behavior when the
+// SoftDeleteUploadsByIDs method of the parent MockStore instance is
+// invoked.
+type StoreSoftDeleteUploadsByIDsFunc struct {
+	defaultHook func(context.Context, ...int) (int, error)
+	hooks       []func(context.Context, ...int) (int, error)
+	history     []StoreSoftDeleteUploadsByIDsFuncCall
 	mutex       sync.Mutex
 }
 
-// SoftDeleteOldDumps delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockStore) SoftDeleteOldDumps(v0 context.Context, v1 time.Duration, v2 time.Time) (int, error) {
-	r0, r1 := m.SoftDeleteOldDumpsFunc.nextHook()(v0, v1, v2)
-	m.SoftDeleteOldDumpsFunc.appendCall(StoreSoftDeleteOldDumpsFuncCall{v0, v1, v2, r0, r1})
+// SoftDeleteUploadsByIDs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) SoftDeleteUploadsByIDs(v0 context.Context, v1 ...int) (int, error) {
+	r0, r1 := m.SoftDeleteUploadsByIDsFunc.nextHook()(v0, v1...)
+	m.SoftDeleteUploadsByIDsFunc.appendCall(StoreSoftDeleteUploadsByIDsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the SoftDeleteOldDumps
-// method of the parent MockStore instance is invoked and the hook queue is
-// empty.
-func (f *StoreSoftDeleteOldDumpsFunc) SetDefaultHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+// SetDefaultHook sets function that is called when the
+// SoftDeleteUploadsByIDs method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreSoftDeleteUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// SoftDeleteOldDumps method of the parent MockStore instance inovkes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *StoreSoftDeleteOldDumpsFunc) PushHook(hook func(context.Context, time.Duration, time.Time) (int, error)) {
+// SoftDeleteUploadsByIDs method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreSoftDeleteUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6351,21 +7351,21 @@ func (f *StoreSoftDeleteOldDumpsFunc) PushHook(hook func(context.Context, time.D
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StoreSoftDeleteOldDumpsFunc) SetDefaultReturn(r0 int, r1 error) {
-	f.SetDefaultHook(func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *StoreSoftDeleteUploadsByIDsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...int) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StoreSoftDeleteOldDumpsFunc) PushReturn(r0 int, r1 error) {
-	f.PushHook(func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *StoreSoftDeleteUploadsByIDsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, ...int) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreSoftDeleteOldDumpsFunc) nextHook() func(context.Context, time.Duration, time.Time) (int, error) {
+func (f *StoreSoftDeleteUploadsByIDsFunc) nextHook() func(context.Context, ...int) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6378,35 +7378,32 @@ func (f *StoreSoftDeleteOldDumpsFunc) nextHook() func(context.Context, time.Dura
 	return hook
 }
 
-func (f *StoreSoftDeleteOldDumpsFunc) appendCall(r0 StoreSoftDeleteOldDumpsFuncCall) {
+func (f *StoreSoftDeleteUploadsByIDsFunc) appendCall(r0 StoreSoftDeleteUploadsByIDsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreSoftDeleteOldDumpsFuncCall objects
+// History returns a sequence of StoreSoftDeleteUploadsByIDsFuncCall objects
 // describing the invocations of this function.
-func (f *StoreSoftDeleteOldDumpsFunc) History() []StoreSoftDeleteOldDumpsFuncCall {
+func (f *StoreSoftDeleteUploadsByIDsFunc) History() []StoreSoftDeleteUploadsByIDsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreSoftDeleteOldDumpsFuncCall, len(f.history))
+	history := make([]StoreSoftDeleteUploadsByIDsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreSoftDeleteOldDumpsFuncCall is an object that describes an invocation
-// of method SoftDeleteOldDumps on an instance of MockStore.
-type StoreSoftDeleteOldDumpsFuncCall struct {
+// StoreSoftDeleteUploadsByIDsFuncCall is an object that describes an
+// invocation of method SoftDeleteUploadsByIDs on an instance of MockStore.
+type StoreSoftDeleteUploadsByIDsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 time.Duration
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 time.Time
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 int
@@ -6416,14 +7413,21 @@ type StoreSoftDeleteOldDumpsFuncCall struct {
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
-func (c StoreSoftDeleteOldDumpsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c StoreSoftDeleteUploadsByIDsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreSoftDeleteOldDumpsFuncCall) Results() []interface{} {
+func (c StoreSoftDeleteUploadsByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -6532,6 +7536,124 @@ func (c StoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreUpdateIndexConfigurationErrorFunc describes the behavior when the
+// UpdateIndexConfigurationError method of the parent MockStore instance is
+// invoked.
+type StoreUpdateIndexConfigurationErrorFunc struct {
+	defaultHook func(context.Context, int, string, string, time.Time) error
+	hooks       []func(context.Context, int, string, string, time.Time) error
+	history     []StoreUpdateIndexConfigurationErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIndexConfigurationError delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateIndexConfigurationError(v0 context.Context, v1 int, v2 string, v3 string, v4 time.Time) error {
+	r0 := m.UpdateIndexConfigurationErrorFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIndexConfigurationErrorFunc.appendCall(StoreUpdateIndexConfigurationErrorFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateIndexConfigurationError method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateIndexConfigurationErrorFunc) SetDefaultHook(hook func(context.Context, int, string, string, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIndexConfigurationError method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreUpdateIndexConfigurationErrorFunc) PushHook(hook func(context.Context, int, string, string, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreUpdateIndexConfigurationErrorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreUpdateIndexConfigurationErrorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, string, string, time.Time) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateIndexConfigurationErrorFunc) nextHook() func(context.Context, int, string, string, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateIndexConfigurationErrorFunc) appendCall(r0 StoreUpdateIndexConfigurationErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateIndexConfigurationErrorFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateIndexConfigurationErrorFunc) History() []StoreUpdateIndexConfigurationErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateIndexConfigurationErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateIndexConfigurationErrorFuncCall is an object that describes an
+// invocation of method UpdateIndexConfigurationError on an instance of
+// MockStore.
+type StoreUpdateIndexConfigurationErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateIndexConfigurationErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateIndexConfigurationErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdateIndexableRepositoryFunc describes the behavior when the
 // UpdateIndexableRepository method of the parent MockStore instance is
 // invoked.
@@ -6856,6 +7978,115 @@ func (c StoreUpdatePackagesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateRetentionConfigurationFunc describes the behavior when the
+// UpdateRetentionConfiguration method of the parent MockStore instance is
+// invoked.
+type StoreUpdateRetentionConfigurationFunc struct {
+	defaultHook func(context.Context, store.RetentionConfiguration) error
+	hooks       []func(context.Context, store.RetentionConfiguration) error
+	history     []StoreUpdateRetentionConfigurationFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateRetentionConfiguration delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateRetentionConfiguration(v0 context.Context, v1 store.RetentionConfiguration) error {
+	r0 := m.UpdateRetentionConfigurationFunc.nextHook()(v0, v1)
+	m.UpdateRetentionConfigurationFunc.appendCall(StoreUpdateRetentionConfigurationFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateRetentionConfiguration method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateRetentionConfigurationFunc) SetDefaultHook(hook func(context.Context, store.RetentionConfiguration) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateRetentionConfiguration method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreUpdateRetentionConfigurationFunc) PushHook(hook func(context.Context, store.RetentionConfiguration) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreUpdateRetentionConfigurationFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, store.RetentionConfiguration) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreUpdateRetentionConfigurationFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, store.RetentionConfiguration) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateRetentionConfigurationFunc) nextHook() func(context.Context, store.RetentionConfiguration) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateRetentionConfigurationFunc) appendCall(r0 StoreUpdateRetentionConfigurationFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateRetentionConfigurationFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateRetentionConfigurationFunc) History() []StoreUpdateRetentionConfigurationFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateRetentionConfigurationFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateRetentionConfigurationFuncCall is an object that describes an
+// invocation of method UpdateRetentionConfiguration on an instance of
+// MockStore.
+type StoreUpdateRetentionConfigurationFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.RetentionConfiguration
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateRetentionConfigurationFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateRetentionConfigurationFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreWithFunc describes the behavior when the With method of the parent
 // MockStore instance is invoked.
 type StoreWithFunc struct {
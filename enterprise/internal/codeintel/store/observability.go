@@ -12,61 +12,71 @@ import (
 
 // An ObservedStore wraps another store with error logging, Prometheus metrics, and tracing.
 type ObservedStore struct {
-	store                                          Store
-	doneOperation                                  *observation.Operation
-	lockOperation                                  *observation.Operation
-	getUploadByIDOperation                         *observation.Operation
-	getUploadsOperation                            *observation.Operation
-	queueSizeOperation                             *observation.Operation
-	insertUploadOperation                          *observation.Operation
-	addUploadPartOperation                         *observation.Operation
-	markQueuedOperation                            *observation.Operation
-	markCompleteOperation                          *observation.Operation
-	markErroredOperation                           *observation.Operation
-	dequeueOperation                               *observation.Operation
-	requeueOperation                               *observation.Operation
-	getStatesOperation                             *observation.Operation
-	deleteUploadByIDOperation                      *observation.Operation
-	deleteUploadsWithoutRepositoryOperation        *observation.Operation
-	hardDeleteUploadByIDOperation                  *observation.Operation
-	resetStalledOperation                          *observation.Operation
-	getDumpByIDOperation                           *observation.Operation
-	findClosestDumpsOperation                      *observation.Operation
-	findClosestDumpsFromGraphFragmentOperation     *observation.Operation
-	deleteOldestDumpOperation                      *observation.Operation
-	softDeleteOldDumpsOperation                    *observation.Operation
-	deleteOverlappingDumpsOperation                *observation.Operation
-	getPackageOperation                            *observation.Operation
-	updatePackagesOperation                        *observation.Operation
-	sameRepoPagerOperation                         *observation.Operation
-	updatePackageReferencesOperation               *observation.Operation
-	packageReferencePagerOperation                 *observation.Operation
-	hasRepositoryOperation                         *observation.Operation
-	hasCommitOperation                             *observation.Operation
-	markRepositoryAsDirtyOperation                 *observation.Operation
-	dirtyRepositoriesOperation                     *observation.Operation
-	fixCommitsOperation                            *observation.Operation
-	indexableRepositoriesOperation                 *observation.Operation
-	updateIndexableRepositoryOperation             *observation.Operation
-	resetIndexableRepositoriesOperation            *observation.Operation
-	getIndexByIDOperation                          *observation.Operation
-	getIndexesOperation                            *observation.Operation
-	indexQueueSizeOperation                        *observation.Operation
-	isQueuedOperation                              *observation.Operation
-	insertIndexOperation                           *observation.Operation
-	markIndexCompleteOperation                     *observation.Operation
-	markIndexErroredOperation                      *observation.Operation
-	setIndexLogContentsOperation                   *observation.Operation
-	dequeueIndexOperation                          *observation.Operation
-	requeueIndexOperation                          *observation.Operation
-	deleteIndexByIdOperation                       *observation.Operation
-	deleteIndexesWithoutRepositoryOperation        *observation.Operation
-	resetStalledIndexesOperation                   *observation.Operation
-	repoUsageStatisticsOperation                   *observation.Operation
-	repoNameOperation                              *observation.Operation
-	getRepositoriesWithIndexConfigurationOperation *observation.Operation
-	getIndexConfigurationByRepositoryIDOperation   *observation.Operation
-	deleteUploadsStuckUploadingOperation           *observation.Operation
+	store                                            Store
+	doneOperation                                    *observation.Operation
+	lockOperation                                    *observation.Operation
+	getUploadByIDOperation                           *observation.Operation
+	getUploadsOperation                              *observation.Operation
+	queueSizeOperation                               *observation.Operation
+	insertUploadOperation                            *observation.Operation
+	addUploadPartOperation                           *observation.Operation
+	markQueuedOperation                              *observation.Operation
+	markCompleteOperation                            *observation.Operation
+	markErroredOperation                             *observation.Operation
+	dequeueOperation                                 *observation.Operation
+	requeueOperation                                 *observation.Operation
+	getStatesOperation                               *observation.Operation
+	deleteUploadByIDOperation                        *observation.Operation
+	deleteUploadsWithoutRepositoryOperation          *observation.Operation
+	hardDeleteUploadByIDOperation                    *observation.Operation
+	resetStalledOperation                            *observation.Operation
+	getDumpByIDOperation                             *observation.Operation
+	findClosestDumpsOperation                        *observation.Operation
+	findClosestDumpsFromGraphFragmentOperation       *observation.Operation
+	deleteOldestDumpOperation                        *observation.Operation
+	deleteOverlappingDumpsOperation                  *observation.Operation
+	getPackageOperation                              *observation.Operation
+	updatePackagesOperation                          *observation.Operation
+	sameRepoPagerOperation                           *observation.Operation
+	updatePackageReferencesOperation                 *observation.Operation
+	packageReferencePagerOperation                   *observation.Operation
+	hasRepositoryOperation                           *observation.Operation
+	hasCommitOperation                               *observation.Operation
+	markRepositoryAsDirtyOperation                   *observation.Operation
+	dirtyRepositoriesOperation                       *observation.Operation
+	fixCommitsOperation                              *observation.Operation
+	indexableRepositoriesOperation                   *observation.Operation
+	updateIndexableRepositoryOperation               *observation.Operation
+	resetIndexableRepositoriesOperation              *observation.Operation
+	getIndexByIDOperation                            *observation.Operation
+	getIndexesOperation                              *observation.Operation
+	indexQueueSizeOperation                          *observation.Operation
+	isQueuedOperation                                *observation.Operation
+	insertIndexOperation                             *observation.Operation
+	markIndexCompleteOperation                       *observation.Operation
+	markIndexErroredOperation                        *observation.Operation
+	setIndexLogContentsOperation                     *observation.Operation
+	dequeueIndexOperation                            *observation.Operation
+	requeueIndexOperation                            *observation.Operation
+	deleteIndexByIdOperation                         *observation.Operation
+	deleteIndexesWithoutRepositoryOperation          *observation.Operation
+	resetStalledIndexesOperation                     *observation.Operation
+	repoUsageStatisticsOperation                     *observation.Operation
+	repoNameOperation                                *observation.Operation
+	getRepositoriesWithIndexConfigurationOperation   *observation.Operation
+	getIndexConfigurationByRepositoryIDOperation     *observation.Operation
+	getIndexConfigurationErrorOperation              *observation.Operation
+	updateIndexConfigurationErrorOperation           *observation.Operation
+	deleteIndexConfigurationErrorOperation           *observation.Operation
+	insertIndexRequestOperation                      *observation.Operation
+	indexRequestsOperation                           *observation.Operation
+	deleteIndexRequestOperation                      *observation.Operation
+	getRetentionConfigurationsOperation              *observation.Operation
+	updateRetentionConfigurationOperation            *observation.Operation
+	getRepositoriesWithExpirationCandidatesOperation *observation.Operation
+	getExpirationCandidatesOperation                 *observation.Operation
+	softDeleteUploadsByIDsOperation                  *observation.Operation
+	deleteUploadsStuckUploadingOperation             *observation.Operation
 }
 
 var _ Store = &ObservedStore{}
@@ -187,11 +197,6 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"delete_oldest_dump"},
 			Metrics:      metrics,
 		}),
-		softDeleteOldDumpsOperation: observationContext.Operation(observation.Op{
-			Name:         "store.SoftDeleteOldDumps",
-			MetricLabels: []string{"soft_delete_old_dumps"},
-			Metrics:      metrics,
-		}),
 		deleteOverlappingDumpsOperation: observationContext.Operation(observation.Op{
 			Name:         "store.DeleteOverlappingDumps",
 			MetricLabels: []string{"delete_overlapping_dumps"},
@@ -347,6 +352,61 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"get_index_configuration_by_repository_id"},
 			Metrics:      metrics,
 		}),
+		getIndexConfigurationErrorOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetIndexConfigurationError",
+			MetricLabels: []string{"get_index_configuration_error"},
+			Metrics:      metrics,
+		}),
+		updateIndexConfigurationErrorOperation: observationContext.Operation(observation.Op{
+			Name:         "store.UpdateIndexConfigurationError",
+			MetricLabels: []string{"update_index_configuration_error"},
+			Metrics:      metrics,
+		}),
+		deleteIndexConfigurationErrorOperation: observationContext.Operation(observation.Op{
+			Name:         "store.DeleteIndexConfigurationError",
+			MetricLabels: []string{"delete_index_configuration_error"},
+			Metrics:      metrics,
+		}),
+		insertIndexRequestOperation: observationContext.Operation(observation.Op{
+			Name:         "store.InsertIndexRequest",
+			MetricLabels: []string{"insert_index_request"},
+			Metrics:      metrics,
+		}),
+		indexRequestsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.IndexRequests",
+			MetricLabels: []string{"index_requests"},
+			Metrics:      metrics,
+		}),
+		deleteIndexRequestOperation: observationContext.Operation(observation.Op{
+			Name:         "store.DeleteIndexRequest",
+			MetricLabels: []string{"delete_index_request"},
+			Metrics:      metrics,
+		}),
+		getRetentionConfigurationsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetRetentionConfigurations",
+			MetricLabels: []string{"get_retention_configurations"},
+			Metrics:      metrics,
+		}),
+		updateRetentionConfigurationOperation: observationContext.Operation(observation.Op{
+			Name:         "store.UpdateRetentionConfiguration",
+			MetricLabels: []string{"update_retention_configuration"},
+			Metrics:      metrics,
+		}),
+		getRepositoriesWithExpirationCandidatesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetRepositoriesWithExpirationCandidates",
+			MetricLabels: []string{"get_repositories_with_expiration_candidates"},
+			Metrics:      metrics,
+		}),
+		getExpirationCandidatesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetExpirationCandidates",
+			MetricLabels: []string{"get_expiration_candidates"},
+			Metrics:      metrics,
+		}),
+		softDeleteUploadsByIDsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.SoftDeleteUploadsByIDs",
+			MetricLabels: []string{"soft_delete_uploads_by_ids"},
+			Metrics:      metrics,
+		}),
 		deleteUploadsStuckUploadingOperation: observationContext.Operation(observation.Op{
 			Name:         "store.DeleteUploadsStuckUploading",
 			MetricLabels: []string{"delete_uploads_stuck_uploading"},
@@ -362,61 +422,71 @@ func (s *ObservedStore) wrap(other Store) Store {
 	}
 
 	return &ObservedStore{
-		store:                                          other,
-		doneOperation:                                  s.doneOperation,
-		lockOperation:                                  s.lockOperation,
-		getUploadByIDOperation:                         s.getUploadByIDOperation,
-		deleteUploadsWithoutRepositoryOperation:        s.deleteUploadsWithoutRepositoryOperation,
-		hardDeleteUploadByIDOperation:                  s.hardDeleteUploadByIDOperation,
-		getUploadsOperation:                            s.getUploadsOperation,
-		queueSizeOperation:                             s.queueSizeOperation,
-		insertUploadOperation:                          s.insertUploadOperation,
-		addUploadPartOperation:                         s.addUploadPartOperation,
-		markQueuedOperation:                            s.markQueuedOperation,
-		markCompleteOperation:                          s.markCompleteOperation,
-		markErroredOperation:                           s.markErroredOperation,
-		dequeueOperation:                               s.dequeueOperation,
-		requeueOperation:                               s.requeueOperation,
-		getStatesOperation:                             s.getStatesOperation,
-		deleteUploadByIDOperation:                      s.deleteUploadByIDOperation,
-		resetStalledOperation:                          s.resetStalledOperation,
-		getDumpByIDOperation:                           s.getDumpByIDOperation,
-		findClosestDumpsOperation:                      s.findClosestDumpsOperation,
-		findClosestDumpsFromGraphFragmentOperation:     s.findClosestDumpsFromGraphFragmentOperation,
-		deleteOldestDumpOperation:                      s.deleteOldestDumpOperation,
-		softDeleteOldDumpsOperation:                    s.softDeleteOldDumpsOperation,
-		deleteOverlappingDumpsOperation:                s.deleteOverlappingDumpsOperation,
-		getPackageOperation:                            s.getPackageOperation,
-		updatePackagesOperation:                        s.updatePackagesOperation,
-		sameRepoPagerOperation:                         s.sameRepoPagerOperation,
-		updatePackageReferencesOperation:               s.updatePackageReferencesOperation,
-		packageReferencePagerOperation:                 s.packageReferencePagerOperation,
-		hasRepositoryOperation:                         s.hasRepositoryOperation,
-		hasCommitOperation:                             s.hasCommitOperation,
-		markRepositoryAsDirtyOperation:                 s.markRepositoryAsDirtyOperation,
-		dirtyRepositoriesOperation:                     s.dirtyRepositoriesOperation,
-		fixCommitsOperation:                            s.fixCommitsOperation,
-		indexableRepositoriesOperation:                 s.indexableRepositoriesOperation,
-		updateIndexableRepositoryOperation:             s.updateIndexableRepositoryOperation,
-		resetIndexableRepositoriesOperation:            s.resetIndexableRepositoriesOperation,
-		getIndexByIDOperation:                          s.getIndexByIDOperation,
-		getIndexesOperation:                            s.getIndexesOperation,
-		indexQueueSizeOperation:                        s.indexQueueSizeOperation,
-		isQueuedOperation:                              s.isQueuedOperation,
-		insertIndexOperation:                           s.insertIndexOperation,
-		markIndexCompleteOperation:                     s.markIndexCompleteOperation,
-		markIndexErroredOperation:                      s.markIndexErroredOperation,
-		setIndexLogContentsOperation:                   s.setIndexLogContentsOperation,
-		dequeueIndexOperation:                          s.dequeueIndexOperation,
-		requeueIndexOperation:                          s.requeueIndexOperation,
-		deleteIndexByIdOperation:                       s.deleteIndexByIdOperation,
-		deleteIndexesWithoutRepositoryOperation:        s.deleteIndexesWithoutRepositoryOperation,
-		resetStalledIndexesOperation:                   s.resetStalledIndexesOperation,
-		repoUsageStatisticsOperation:                   s.repoUsageStatisticsOperation,
-		repoNameOperation:                              s.repoNameOperation,
-		getRepositoriesWithIndexConfigurationOperation: s.getRepositoriesWithIndexConfigurationOperation,
-		getIndexConfigurationByRepositoryIDOperation:   s.getIndexConfigurationByRepositoryIDOperation,
-		deleteUploadsStuckUploadingOperation:           s.deleteUploadsStuckUploadingOperation,
+		store:                                            other,
+		doneOperation:                                    s.doneOperation,
+		lockOperation:                                    s.lockOperation,
+		getUploadByIDOperation:                           s.getUploadByIDOperation,
+		deleteUploadsWithoutRepositoryOperation:          s.deleteUploadsWithoutRepositoryOperation,
+		hardDeleteUploadByIDOperation:                    s.hardDeleteUploadByIDOperation,
+		getUploadsOperation:                              s.getUploadsOperation,
+		queueSizeOperation:                               s.queueSizeOperation,
+		insertUploadOperation:                            s.insertUploadOperation,
+		addUploadPartOperation:                           s.addUploadPartOperation,
+		markQueuedOperation:                              s.markQueuedOperation,
+		markCompleteOperation:                            s.markCompleteOperation,
+		markErroredOperation:                             s.markErroredOperation,
+		dequeueOperation:                                 s.dequeueOperation,
+		requeueOperation:                                 s.requeueOperation,
+		getStatesOperation:                               s.getStatesOperation,
+		deleteUploadByIDOperation:                        s.deleteUploadByIDOperation,
+		resetStalledOperation:                            s.resetStalledOperation,
+		getDumpByIDOperation:                             s.getDumpByIDOperation,
+		findClosestDumpsOperation:                        s.findClosestDumpsOperation,
+		findClosestDumpsFromGraphFragmentOperation:       s.findClosestDumpsFromGraphFragmentOperation,
+		deleteOldestDumpOperation:                        s.deleteOldestDumpOperation,
+		deleteOverlappingDumpsOperation:                  s.deleteOverlappingDumpsOperation,
+		getPackageOperation:                              s.getPackageOperation,
+		updatePackagesOperation:                          s.updatePackagesOperation,
+		sameRepoPagerOperation:                           s.sameRepoPagerOperation,
+		updatePackageReferencesOperation:                 s.updatePackageReferencesOperation,
+		packageReferencePagerOperation:                   s.packageReferencePagerOperation,
+		hasRepositoryOperation:                           s.hasRepositoryOperation,
+		hasCommitOperation:                               s.hasCommitOperation,
+		markRepositoryAsDirtyOperation:                   s.markRepositoryAsDirtyOperation,
+		dirtyRepositoriesOperation:                       s.dirtyRepositoriesOperation,
+		fixCommitsOperation:                              s.fixCommitsOperation,
+		indexableRepositoriesOperation:                   s.indexableRepositoriesOperation,
+		updateIndexableRepositoryOperation:               s.updateIndexableRepositoryOperation,
+		resetIndexableRepositoriesOperation:              s.resetIndexableRepositoriesOperation,
+		getIndexByIDOperation:                            s.getIndexByIDOperation,
+		getIndexesOperation:                              s.getIndexesOperation,
+		indexQueueSizeOperation:                          s.indexQueueSizeOperation,
+		isQueuedOperation:                                s.isQueuedOperation,
+		insertIndexOperation:                             s.insertIndexOperation,
+		markIndexCompleteOperation:                       s.markIndexCompleteOperation,
+		markIndexErroredOperation:                        s.markIndexErroredOperation,
+		setIndexLogContentsOperation:                     s.setIndexLogContentsOperation,
+		dequeueIndexOperation:                            s.dequeueIndexOperation,
+		requeueIndexOperation:                            s.requeueIndexOperation,
+		deleteIndexByIdOperation:                         s.deleteIndexByIdOperation,
+		deleteIndexesWithoutRepositoryOperation:          s.deleteIndexesWithoutRepositoryOperation,
+		resetStalledIndexesOperation:                     s.resetStalledIndexesOperation,
+		repoUsageStatisticsOperation:                     s.repoUsageStatisticsOperation,
+		repoNameOperation:                                s.repoNameOperation,
+		getRepositoriesWithIndexConfigurationOperation:   s.getRepositoriesWithIndexConfigurationOperation,
+		getIndexConfigurationByRepositoryIDOperation:     s.getIndexConfigurationByRepositoryIDOperation,
+		getIndexConfigurationErrorOperation:              s.getIndexConfigurationErrorOperation,
+		updateIndexConfigurationErrorOperation:           s.updateIndexConfigurationErrorOperation,
+		deleteIndexConfigurationErrorOperation:           s.deleteIndexConfigurationErrorOperation,
+		insertIndexRequestOperation:                      s.insertIndexRequestOperation,
+		indexRequestsOperation:                           s.indexRequestsOperation,
+		deleteIndexRequestOperation:                      s.deleteIndexRequestOperation,
+		getRetentionConfigurationsOperation:              s.getRetentionConfigurationsOperation,
+		updateRetentionConfigurationOperation:            s.updateRetentionConfigurationOperation,
+		getRepositoriesWithExpirationCandidatesOperation: s.getRepositoriesWithExpirationCandidatesOperation,
+		getExpirationCandidatesOperation:                 s.getExpirationCandidatesOperation,
+		softDeleteUploadsByIDsOperation:                  s.softDeleteUploadsByIDsOperation,
+		deleteUploadsStuckUploadingOperation:             s.deleteUploadsStuckUploadingOperation,
 	}
 }
 
@@ -603,13 +673,6 @@ func (s *ObservedStore) DeleteOldestDump(ctx context.Context) (_ int, _ bool, er
 	return s.store.DeleteOldestDump(ctx)
 }
 
-// SoftDeleteOldDumps calls into the inner store and registers the observed results.
-func (s *ObservedStore) SoftDeleteOldDumps(ctx context.Context, maxAge time.Duration, now time.Time) (count int, err error) {
-	ctx, endObservation := s.softDeleteOldDumpsOperation.With(ctx, &err, observation.Args{})
-	defer func() { endObservation(float64(count), observation.Args{}) }()
-	return s.store.SoftDeleteOldDumps(ctx, maxAge, now)
-}
-
 // DeleteOverlappingDumps calls into the inner store and registers the observed results.
 func (s *ObservedStore) DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) (err error) {
 	ctx, endObservation := s.deleteOverlappingDumpsOperation.With(ctx, &err, observation.Args{})
@@ -834,6 +897,83 @@ func (s *ObservedStore) GetIndexConfigurationByRepositoryID(ctx context.Context,
 	return s.store.GetIndexConfigurationByRepositoryID(ctx, repositoryID)
 }
 
+// GetIndexConfigurationError calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetIndexConfigurationError(ctx context.Context, repositoryID int) (_ IndexConfigurationError, _ bool, err error) {
+	ctx, endObservation := s.getIndexConfigurationErrorOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.GetIndexConfigurationError(ctx, repositoryID)
+}
+
+// UpdateIndexConfigurationError calls into the inner store and registers the observed results.
+func (s *ObservedStore) UpdateIndexConfigurationError(ctx context.Context, repositoryID int, commit, message string, now time.Time) (err error) {
+	ctx, endObservation := s.updateIndexConfigurationErrorOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.UpdateIndexConfigurationError(ctx, repositoryID, commit, message, now)
+}
+
+// DeleteIndexConfigurationError calls into the inner store and registers the observed results.
+func (s *ObservedStore) DeleteIndexConfigurationError(ctx context.Context, repositoryID int) (err error) {
+	ctx, endObservation := s.deleteIndexConfigurationErrorOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.DeleteIndexConfigurationError(ctx, repositoryID)
+}
+
+// InsertIndexRequest calls into the inner store and registers the observed results.
+func (s *ObservedStore) InsertIndexRequest(ctx context.Context, repositoryID int, commit string) (err error) {
+	ctx, endObservation := s.insertIndexRequestOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.InsertIndexRequest(ctx, repositoryID, commit)
+}
+
+// IndexRequests calls into the inner store and registers the observed results.
+func (s *ObservedStore) IndexRequests(ctx context.Context, limit int) (indexRequests []IndexRequest, err error) {
+	ctx, endObservation := s.indexRequestsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(indexRequests)), observation.Args{}) }()
+	return s.store.IndexRequests(ctx, limit)
+}
+
+// DeleteIndexRequest calls into the inner store and registers the observed results.
+func (s *ObservedStore) DeleteIndexRequest(ctx context.Context, id int) (err error) {
+	ctx, endObservation := s.deleteIndexRequestOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.DeleteIndexRequest(ctx, id)
+}
+
+// GetRetentionConfigurations calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetRetentionConfigurations(ctx context.Context) (retentionConfigurations []RetentionConfiguration, err error) {
+	ctx, endObservation := s.getRetentionConfigurationsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(retentionConfigurations)), observation.Args{}) }()
+	return s.store.GetRetentionConfigurations(ctx)
+}
+
+// UpdateRetentionConfiguration calls into the inner store and registers the observed results.
+func (s *ObservedStore) UpdateRetentionConfiguration(ctx context.Context, configuration RetentionConfiguration) (err error) {
+	ctx, endObservation := s.updateRetentionConfigurationOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.UpdateRetentionConfiguration(ctx, configuration)
+}
+
+// GetRepositoriesWithExpirationCandidates calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetRepositoriesWithExpirationCandidates(ctx context.Context, finishedBefore time.Time) (repositoryIDs []int, err error) {
+	ctx, endObservation := s.getRepositoriesWithExpirationCandidatesOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(repositoryIDs)), observation.Args{}) }()
+	return s.store.GetRepositoriesWithExpirationCandidates(ctx, finishedBefore)
+}
+
+// GetExpirationCandidates calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetExpirationCandidates(ctx context.Context, repositoryID int, finishedBefore time.Time) (expirationCandidates []ExpirationCandidate, err error) {
+	ctx, endObservation := s.getExpirationCandidatesOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(expirationCandidates)), observation.Args{}) }()
+	return s.store.GetExpirationCandidates(ctx, repositoryID, finishedBefore)
+}
+
+// SoftDeleteUploadsByIDs calls into the inner store and registers the observed results.
+func (s *ObservedStore) SoftDeleteUploadsByIDs(ctx context.Context, ids ...int) (count int, err error) {
+	ctx, endObservation := s.softDeleteUploadsByIDsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(count), observation.Args{}) }()
+	return s.store.SoftDeleteUploadsByIDs(ctx, ids...)
+}
+
 // DeleteUploadsStuckUploading calls into the inner store and registers the observed results.
 func (s *ObservedStore) DeleteUploadsStuckUploading(ctx context.Context, uploadedBefore time.Time) (_ int, err error) {
 	ctx, endObservation := s.deleteUploadsStuckUploadingOperation.With(ctx, &err, observation.Args{})
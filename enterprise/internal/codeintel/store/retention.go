@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/db/basestore"
+)
+
+// RetentionConfiguration overrides the global data retention policy for a repository. Nil
+// fields fall back to the value of the global policy.
+type RetentionConfiguration struct {
+	ID           int            `json:"id"`
+	RepositoryID int            `json:"repositoryId"`
+	MaxAge       *time.Duration `json:"maxAge"`
+	TagPattern   *string        `json:"tagPattern"`
+	MaxTaggedAge *time.Duration `json:"maxTaggedAge"`
+}
+
+// scanRetentionConfigurations scans a slice of retention configurations from the return value of `*store.query`.
+func scanRetentionConfigurations(rows *sql.Rows, queryErr error) (_ []RetentionConfiguration, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var retentionConfigurations []RetentionConfiguration
+	for rows.Next() {
+		var retentionConfiguration RetentionConfiguration
+		var maxAgeSeconds, maxTaggedAgeSeconds *int
+		if err := rows.Scan(
+			&retentionConfiguration.ID,
+			&retentionConfiguration.RepositoryID,
+			&maxAgeSeconds,
+			&retentionConfiguration.TagPattern,
+			&maxTaggedAgeSeconds,
+		); err != nil {
+			return nil, err
+		}
+
+		retentionConfiguration.MaxAge = secondsToDuration(maxAgeSeconds)
+		retentionConfiguration.MaxTaggedAge = secondsToDuration(maxTaggedAgeSeconds)
+		retentionConfigurations = append(retentionConfigurations, retentionConfiguration)
+	}
+
+	return retentionConfigurations, nil
+}
+
+func secondsToDuration(seconds *int) *time.Duration {
+	if seconds == nil {
+		return nil
+	}
+
+	duration := time.Duration(*seconds) * time.Second
+	return &duration
+}
+
+func durationToSeconds(duration *time.Duration) *int {
+	if duration == nil {
+		return nil
+	}
+
+	seconds := int(*duration / time.Second)
+	return &seconds
+}
+
+// GetRetentionConfigurations returns the retention configuration of every repository that overrides
+// the global data retention policy.
+func (s *store) GetRetentionConfigurations(ctx context.Context) ([]RetentionConfiguration, error) {
+	return scanRetentionConfigurations(s.Store.Query(ctx, sqlf.Sprintf(`
+		SELECT
+			c.id,
+			c.repository_id,
+			c.max_age_seconds,
+			c.tag_pattern,
+			c.max_tagged_age_seconds
+		FROM lsif_retention_configuration c
+		ORDER BY c.repository_id
+	`)))
+}
+
+// UpdateRetentionConfiguration replaces the retention configuration of the given repository. The
+// repository falls back to the global data retention policy if every field of the configuration is nil.
+func (s *store) UpdateRetentionConfiguration(ctx context.Context, configuration RetentionConfiguration) error {
+	if configuration.MaxAge == nil && configuration.TagPattern == nil && configuration.MaxTaggedAge == nil {
+		return s.Store.Exec(ctx, sqlf.Sprintf(`DELETE FROM lsif_retention_configuration WHERE repository_id = %s`, configuration.RepositoryID))
+	}
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_retention_configuration (repository_id, max_age_seconds, tag_pattern, max_tagged_age_seconds)
+		VALUES (%s, %s, %s, %s)
+		ON CONFLICT (repository_id) DO UPDATE
+		SET max_age_seconds = EXCLUDED.max_age_seconds, tag_pattern = EXCLUDED.tag_pattern, max_tagged_age_seconds = EXCLUDED.max_tagged_age_seconds
+	`,
+		configuration.RepositoryID,
+		durationToSeconds(configuration.MaxAge),
+		configuration.TagPattern,
+		durationToSeconds(configuration.MaxTaggedAge),
+	))
+}
+
+// ExpirationCandidate is a completed upload that is not visible from the tip of the default branch
+// of its repository, and therefore may be expired by a data retention policy.
+type ExpirationCandidate struct {
+	ID         int       `json:"id"`
+	Commit     string    `json:"commit"`
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// scanExpirationCandidates scans a slice of expiration candidates from the return value of `*store.query`.
+func scanExpirationCandidates(rows *sql.Rows, queryErr error) (_ []ExpirationCandidate, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var expirationCandidates []ExpirationCandidate
+	for rows.Next() {
+		var expirationCandidate ExpirationCandidate
+		if err := rows.Scan(
+			&expirationCandidate.ID,
+			&expirationCandidate.Commit,
+			&expirationCandidate.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		expirationCandidates = append(expirationCandidates, expirationCandidate)
+	}
+
+	return expirationCandidates, nil
+}
+
+// GetRepositoriesWithExpirationCandidates returns the identifiers of repositories with a completed upload
+// that finished before the given time and is not visible from the tip of the default branch.
+func (s *store) GetRepositoriesWithExpirationCandidates(ctx context.Context, finishedBefore time.Time) ([]int, error) {
+	return basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(`
+		SELECT DISTINCT u.repository_id
+		FROM lsif_uploads u
+		WHERE
+			u.state = 'completed' AND
+			u.finished_at < %s AND
+			u.id NOT IN (SELECT uv.upload_id FROM lsif_uploads_visible_at_tip uv WHERE uv.repository_id = u.repository_id)
+		ORDER BY u.repository_id
+	`, finishedBefore)))
+}
+
+// GetExpirationCandidates returns the completed uploads of the given repository that finished before
+// the given time and are not visible from the tip of the default branch.
+func (s *store) GetExpirationCandidates(ctx context.Context, repositoryID int, finishedBefore time.Time) ([]ExpirationCandidate, error) {
+	return scanExpirationCandidates(s.Store.Query(ctx, sqlf.Sprintf(`
+		SELECT u.id, u.commit, u.finished_at
+		FROM lsif_uploads u
+		WHERE
+			u.repository_id = %s AND
+			u.state = 'completed' AND
+			u.finished_at < %s AND
+			u.id NOT IN (SELECT uv.upload_id FROM lsif_uploads_visible_at_tip uv WHERE uv.repository_id = u.repository_id)
+		ORDER BY u.finished_at, u.id
+	`, repositoryID, finishedBefore)))
+}
+
+// SoftDeleteUploadsByIDs marks the given completed uploads as deleted and returns the number of uploads
+// that were marked. The associated repositories will be marked as dirty so that their commit graphs are
+// updated in the background.
+func (s *store) SoftDeleteUploadsByIDs(ctx context.Context, ids ...int) (count int, err error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var qs []*sqlf.Query
+	for _, id := range ids {
+		qs = append(qs, sqlf.Sprintf("%d", id))
+	}
+
+	repositoryIDs, err := scanCounts(tx.Store.Query(ctx, sqlf.Sprintf(`
+		WITH u AS (
+			UPDATE lsif_uploads u
+				SET state = 'deleted'
+				WHERE u.id IN (%s) AND u.state = 'completed'
+				RETURNING id, repository_id
+		)
+		SELECT u.repository_id, count(*) FROM u GROUP BY u.repository_id
+	`, sqlf.Join(qs, ","))))
+	if err != nil {
+		return 0, err
+	}
+
+	for repositoryID, numUpdated := range repositoryIDs {
+		if err := tx.MarkRepositoryAsDirty(ctx, repositoryID); err != nil {
+			return 0, err
+		}
+
+		count += numUpdated
+	}
+
+	return count, nil
+}
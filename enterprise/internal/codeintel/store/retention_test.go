@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestGetRetentionConfigurations(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertRepo(t, dbconn.Global, 50, "")
+	insertRepo(t, dbconn.Global, 51, "")
+
+	query := sqlf.Sprintf(`
+		INSERT INTO lsif_retention_configuration (id, repository_id, max_age_seconds, tag_pattern, max_tagged_age_seconds)
+		VALUES (1, 51, 3600, NULL, NULL), (2, 50, NULL, '^v', 86400)
+	`)
+	if _, err := dbconn.Global.Exec(query.Query(sqlf.PostgresBindVar), query.Args()...); err != nil {
+		t.Fatalf("unexpected error inserting retention configuration: %s", err)
+	}
+
+	retentionConfigurations, err := store.GetRetentionConfigurations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting retention configurations: %s", err)
+	}
+
+	maxAge := time.Hour
+	maxTaggedAge := time.Hour * 24
+	tagPattern := "^v"
+
+	expected := []RetentionConfiguration{
+		{ID: 2, RepositoryID: 50, TagPattern: &tagPattern, MaxTaggedAge: &maxTaggedAge},
+		{ID: 1, RepositoryID: 51, MaxAge: &maxAge},
+	}
+	if diff := cmp.Diff(expected, retentionConfigurations); diff != "" {
+		t.Errorf("unexpected retention configurations (-want +got):\n%s", diff)
+	}
+}
+
+func TestUpdateRetentionConfiguration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertRepo(t, dbconn.Global, 50, "")
+
+	maxAge := time.Hour
+	tagPattern := "^v"
+	if err := store.UpdateRetentionConfiguration(context.Background(), RetentionConfiguration{RepositoryID: 50, MaxAge: &maxAge}); err != nil {
+		t.Fatalf("unexpected error updating retention configuration: %s", err)
+	}
+	if err := store.UpdateRetentionConfiguration(context.Background(), RetentionConfiguration{RepositoryID: 50, TagPattern: &tagPattern}); err != nil {
+		t.Fatalf("unexpected error updating retention configuration: %s", err)
+	}
+
+	retentionConfigurations, err := store.GetRetentionConfigurations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting retention configurations: %s", err)
+	}
+	for i := range retentionConfigurations {
+		retentionConfigurations[i].ID = 0
+	}
+
+	expected := []RetentionConfiguration{{RepositoryID: 50, TagPattern: &tagPattern}}
+	if diff := cmp.Diff(expected, retentionConfigurations); diff != "" {
+		t.Errorf("unexpected retention configurations (-want +got):\n%s", diff)
+	}
+
+	if err := store.UpdateRetentionConfiguration(context.Background(), RetentionConfiguration{RepositoryID: 50}); err != nil {
+		t.Fatalf("unexpected error updating retention configuration: %s", err)
+	}
+
+	retentionConfigurations, err = store.GetRetentionConfigurations(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting retention configurations: %s", err)
+	}
+	if len(retentionConfigurations) != 0 {
+		t.Errorf("unexpected retention configurations. want=%d have=%d", 0, len(retentionConfigurations))
+	}
+}
+
+func TestGetExpirationCandidates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute)
+	t3 := t1.Add(time.Minute * 4)
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, FinishedAt: &t1},
+		Upload{ID: 2, FinishedAt: &t1}, // visible
+		Upload{ID: 3, FinishedAt: &t2},
+		Upload{ID: 4, FinishedAt: &t3}, // too new
+		Upload{ID: 5, FinishedAt: &t1, State: "errored"},
+		Upload{ID: 6, FinishedAt: &t1, RepositoryID: 51},
+		Upload{ID: 7, FinishedAt: &t3, RepositoryID: 52}, // too new
+	)
+	insertVisibleAtTip(t, dbconn.Global, 50, 2)
+
+	repositoryIDs, err := store.GetRepositoriesWithExpirationCandidates(context.Background(), t3)
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories with expiration candidates: %s", err)
+	}
+	if diff := cmp.Diff([]int{50, 51}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repository ids (-want +got):\n%s", diff)
+	}
+
+	expirationCandidates, err := store.GetExpirationCandidates(context.Background(), 50, t3)
+	if err != nil {
+		t.Fatalf("unexpected error getting expiration candidates: %s", err)
+	}
+
+	expected := []ExpirationCandidate{
+		{ID: 1, Commit: makeCommit(1), FinishedAt: t1},
+		{ID: 3, Commit: makeCommit(3), FinishedAt: t2},
+	}
+	if diff := cmp.Diff(expected, expirationCandidates); diff != "" {
+		t.Errorf("unexpected expiration candidates (-want +got):\n%s", diff)
+	}
+}
+
+func TestSoftDeleteUploadsByIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1},
+		Upload{ID: 2},
+		Upload{ID: 3, State: "errored"},
+		Upload{ID: 4, RepositoryID: 51},
+	)
+
+	if count, err := store.SoftDeleteUploadsByIDs(context.Background(), 1, 3, 4); err != nil {
+		t.Fatalf("unexpected error deleting uploads: %s", err)
+	} else if count != 2 {
+		t.Fatalf("unexpected number of uploads deleted: want=%d have=%d", 2, count)
+	}
+
+	expectedStates := map[int]string{
+		1: "deleted",
+		2: "completed",
+		3: "errored",
+		4: "deleted",
+	}
+	if states, err := store.GetStates(context.Background(), []int{1, 2, 3, 4}); err != nil {
+		t.Fatalf("unexpected error getting states: %s", err)
+	} else if diff := cmp.Diff(expectedStates, states); diff != "" {
+		t.Errorf("unexpected states (-want +got):\n%s", diff)
+	}
+
+	repositoryIDs, err := store.DirtyRepositories(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error listing dirty repositories: %s", err)
+	}
+	if _, ok := repositoryIDs[50]; !ok {
+		t.Errorf("expected repository 50 to be marked dirty")
+	}
+	if _, ok := repositoryIDs[51]; !ok {
+		t.Errorf("expected repository 51 to be marked dirty")
+	}
+}
@@ -107,11 +107,6 @@ type Store interface {
 	// will be marked as dirty so that its commit graph will be updated in the background.
 	DeleteOldestDump(ctx context.Context) (int, bool, error)
 
-	// SoftDeleteOldDumps marks dumps older than the given age that are not visible at the tip of the default branch
-	// as deleted. The associated repositories will be marked as dirty so that their commit graphs are updated in the
-	// background.
-	SoftDeleteOldDumps(ctx context.Context, maxAge time.Duration, now time.Time) (int, error)
-
 	// DeleteOverlapapingDumps deletes all completed uploads for the given repository with the same
 	// commit, root, and indexer. This is necessary to perform during conversions before changing
 	// the state of a processing upload to completed as there is a unique index on these four columns.
@@ -229,6 +224,48 @@ type Store interface {
 	// GetIndexConfigurationByRepositoryID returns the index configuration for a repository.
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (IndexConfiguration, bool, error)
 
+	// GetIndexConfigurationError returns the most recent index configuration error for a repository.
+	GetIndexConfigurationError(ctx context.Context, repositoryID int) (IndexConfigurationError, bool, error)
+
+	// UpdateIndexConfigurationError records that the index configuration of the given repository could not
+	// be parsed at the given commit. This replaces any previous error recorded for the repository.
+	UpdateIndexConfigurationError(ctx context.Context, repositoryID int, commit, message string, now time.Time) error
+
+	// DeleteIndexConfigurationError clears the index configuration error for the given repository.
+	DeleteIndexConfigurationError(ctx context.Context, repositoryID int) error
+
+	// InsertIndexRequest requests that index jobs be scheduled for the given repository. If the given commit
+	// is empty, the index scheduler will consider the tip of the default branch and recent tags. This method
+	// does nothing if an identical request has not yet been handled.
+	InsertIndexRequest(ctx context.Context, repositoryID int, commit string) error
+
+	// IndexRequests returns the oldest index requests that have not yet been handled.
+	IndexRequests(ctx context.Context, limit int) ([]IndexRequest, error)
+
+	// DeleteIndexRequest deletes an index request by its identifier.
+	DeleteIndexRequest(ctx context.Context, id int) error
+
+	// GetRetentionConfigurations returns the retention configuration of every repository that overrides
+	// the global data retention policy.
+	GetRetentionConfigurations(ctx context.Context) ([]RetentionConfiguration, error)
+
+	// UpdateRetentionConfiguration replaces the retention configuration of the given repository. The
+	// repository falls back to the global data retention policy if every field of the configuration is nil.
+	UpdateRetentionConfiguration(ctx context.Context, configuration RetentionConfiguration) error
+
+	// GetRepositoriesWithExpirationCandidates returns the identifiers of repositories with a completed upload
+	// that finished before the given time and is not visible from the tip of the default branch.
+	GetRepositoriesWithExpirationCandidates(ctx context.Context, finishedBefore time.Time) ([]int, error)
+
+	// GetExpirationCandidates returns the completed uploads of the given repository that finished before
+	// the given time and are not visible from the tip of the default branch.
+	GetExpirationCandidates(ctx context.Context, repositoryID int, finishedBefore time.Time) ([]ExpirationCandidate, error)
+
+	// SoftDeleteUploadsByIDs marks the given completed uploads as deleted and returns the number of uploads
+	// that were marked. The associated repositories will be marked as dirty so that their commit graphs are
+	// updated in the background.
+	SoftDeleteUploadsByIDs(ctx context.Context, ids ...int) (int, error)
+
 	// DeleteUploadsStuckUploading soft deletes any upload record that has been uploading since the given time.
 	DeleteUploadsStuckUploading(ctx context.Context, uploadedBefore time.Time) (_ int, err error)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
@@ -158,3 +159,24 @@ func (s *authzStore) RevokeUserPermissions(ctx context.Context, args *db.RevokeU
 	}
 	return nil
 }
+
+// SubRepoPermissions returns the path-level permissions of a user in the given repositories, which
+// implements the db.AuthzStore interface. Repositories in which the user has no path restrictions
+// are omitted.
+func (s *authzStore) SubRepoPermissions(ctx context.Context, args *db.SubRepoPermissionsArgs) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+	repoIDs := make([]int32, len(args.RepoIDs))
+	for i, id := range args.RepoIDs {
+		repoIDs[i] = int32(id)
+	}
+
+	ps, err := s.store.LoadUserSubRepoPermissions(ctx, args.UserID, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := make(map[api.RepoID]*authz.SubRepoPermissions, len(ps))
+	for _, p := range ps {
+		perms[api.RepoID(p.RepoID)] = p
+	}
+	return perms, nil
+}
@@ -1,9 +1,13 @@
 package db
 
 import (
+	"github.com/sourcegraph/sourcegraph/internal/authz/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/authz/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/authz/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -21,5 +25,17 @@ func NewExternalServicesStore() *db.ExternalServicesStore {
 		BitbucketServerValidators: []func(*schema.BitbucketServerConnection) error{
 			bitbucketserver.ValidateAuthz,
 		},
+		AzureDevOpsValidators: []func(*schema.AzureDevOpsConnection) error{
+			azuredevops.ValidateAuthz,
+		},
+		PerforceValidators: []func(*schema.PerforceConnection) error{
+			perforce.ValidateAuthz,
+		},
+		GitoliteValidators: []func(*schema.GitoliteConnection) error{
+			gitolite.ValidateAuthz,
+		},
+		BitbucketCloudValidators: []func(*schema.BitbucketCloudConnection) error{
+			bitbucketcloud.ValidateAuthz,
+		},
 	}
 }
@@ -32,6 +32,7 @@ func TestIntegration_PermsStore(t *testing.T) {
 		{"GrantPendingPermissions", testPermsStore_GrantPendingPermissions(db)},
 		{"SetPendingPermissionsAfterGrant", testPermsStore_SetPendingPermissionsAfterGrant(db)},
 		{"DeleteAllUserPermissions", testPermsStore_DeleteAllUserPermissions(db)},
+		{"SubRepoPermissions", testPermsStore_SubRepoPermissions(db)},
 		{"DeleteAllUserPendingPermissions", testPermsStore_DeleteAllUserPendingPermissions(db)},
 		{"DatabaseDeadlocks", testPermsStore_DatabaseDeadlocks(db)},
 
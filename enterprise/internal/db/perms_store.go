@@ -1090,8 +1090,8 @@ AND service_id = %s
 	return bindIDs, nil
 }
 
-// DeleteAllUserPermissions deletes all rows with given user ID from the "user_permissions" table,
-// which effectively removes access to all repositories for the user.
+// DeleteAllUserPermissions deletes all rows with given user ID from the "user_permissions" and
+// "sub_repo_permissions" tables, which effectively removes access to all repositories for the user.
 func (s *PermsStore) DeleteAllUserPermissions(ctx context.Context, userID int32) (err error) {
 	ctx, save := s.observe(ctx, "DeleteAllUserPermissions", "")
 	defer func() { save(&err, otlog.Int32("userID", userID)) }()
@@ -1102,6 +1102,10 @@ func (s *PermsStore) DeleteAllUserPermissions(ctx context.Context, userID int32)
 		return errors.Wrap(err, "execute delete user permissions query")
 	}
 
+	if err = s.execute(ctx, sqlf.Sprintf(`DELETE FROM sub_repo_permissions WHERE user_id = %s`, userID)); err != nil {
+		return errors.Wrap(err, "execute delete sub-repo permissions query")
+	}
+
 	return nil
 }
 
@@ -1131,6 +1135,144 @@ AND bind_id IN (%s)`,
 	return nil
 }
 
+// LoadUserSubRepoPermissions loads stored sub-repository permissions of the user in the given
+// repositories. Repositories in which the user has no path restrictions are omitted.
+func (s *PermsStore) LoadUserSubRepoPermissions(ctx context.Context, userID int32, repoIDs []int32) (ps []*authz.SubRepoPermissions, err error) {
+	if Mocks.Perms.LoadUserSubRepoPermissions != nil {
+		return Mocks.Perms.LoadUserSubRepoPermissions(ctx, userID, repoIDs)
+	}
+
+	ctx, save := s.observe(ctx, "LoadUserSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("userID", userID), otlog.Int("count", len(repoIDs))) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.LoadUserSubRepoPermissions
+SELECT repo_id, path_includes, path_excludes, updated_at
+FROM sub_repo_permissions
+WHERE user_id = %s
+AND repo_id = ANY(%s)
+`, userID, pq.Array(repoIDs))
+
+	var rows *sql.Rows
+	rows, err = s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := &authz.SubRepoPermissions{UserID: userID}
+		if err = rows.Scan(&p.RepoID, pq.Array(&p.PathIncludes), pq.Array(&p.PathExcludes), &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// SetUserSubRepoPermissions replaces all stored sub-repository permissions of the user with
+// the given ones. The UserID of the given permissions is ignored, and the RepoID must be set.
+// Repositories without given permissions have no path restrictions afterwards.
+func (s *PermsStore) SetUserSubRepoPermissions(ctx context.Context, userID int32, ps []*authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.SetUserSubRepoPermissions != nil {
+		return Mocks.Perms.SetUserSubRepoPermissions(ctx, userID, ps)
+	}
+
+	ctx, save := s.observe(ctx, "SetUserSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("userID", userID), otlog.Int("count", len(ps))) }()
+
+	for _, p := range ps {
+		p.UserID = userID
+	}
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetUserSubRepoPermissions
+DELETE FROM sub_repo_permissions WHERE user_id = %s
+`, userID)
+	return s.replaceSubRepoPermissions(ctx, q, ps)
+}
+
+// SetRepoSubRepoPermissions replaces all stored sub-repository permissions in the repository
+// with the given ones. The RepoID of the given permissions is ignored, and the UserID must be
+// set. Users without given permissions have no path restrictions in the repository afterwards.
+func (s *PermsStore) SetRepoSubRepoPermissions(ctx context.Context, repoID int32, ps []*authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.SetRepoSubRepoPermissions != nil {
+		return Mocks.Perms.SetRepoSubRepoPermissions(ctx, repoID, ps)
+	}
+
+	ctx, save := s.observe(ctx, "SetRepoSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("repoID", repoID), otlog.Int("count", len(ps))) }()
+
+	for _, p := range ps {
+		p.RepoID = repoID
+	}
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetRepoSubRepoPermissions
+DELETE FROM sub_repo_permissions WHERE repo_id = %s
+`, repoID)
+	return s.replaceSubRepoPermissions(ctx, q, ps)
+}
+
+// replaceSubRepoPermissions deletes the sub-repository permissions with given delete query, and
+// inserts the given permissions in the same transaction.
+func (s *PermsStore) replaceSubRepoPermissions(ctx context.Context, deleteQuery *sqlf.Query, ps []*authz.SubRepoPermissions) (err error) {
+	txs, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer txs.Done(&err)
+
+	if err = txs.execute(ctx, deleteQuery); err != nil {
+		return errors.Wrap(err, "execute delete sub-repo permissions query")
+	}
+
+	if len(ps) == 0 {
+		return nil
+	}
+
+	updatedAt := txs.clock()
+	items := make([]*sqlf.Query, len(ps))
+	for i, p := range ps {
+		p.UpdatedAt = updatedAt
+		items[i] = sqlf.Sprintf("(%s, %s, %s, %s, %s)",
+			p.UserID,
+			p.RepoID,
+			pq.Array(nonNilStrings(p.PathIncludes)),
+			pq.Array(nonNilStrings(p.PathExcludes)),
+			p.UpdatedAt,
+		)
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.replaceSubRepoPermissions
+INSERT INTO sub_repo_permissions
+  (user_id, repo_id, path_includes, path_excludes, updated_at)
+VALUES
+  %s
+ON CONFLICT (user_id, repo_id)
+DO UPDATE SET
+  path_includes = excluded.path_includes,
+  path_excludes = excluded.path_excludes,
+  updated_at = excluded.updated_at
+`, sqlf.Join(items, ",\n"))
+	if err = txs.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute insert sub-repo permissions query")
+	}
+
+	return nil
+}
+
+// nonNilStrings returns an empty slice if ss is nil, because the array columns are not nullable.
+func nonNilStrings(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
+
 func (s *PermsStore) execute(ctx context.Context, q *sqlf.Query, vs ...interface{}) (err error) {
 	ctx, save := s.observe(ctx, "execute", "")
 	defer func() { save(&err, otlog.Object("q", q)) }()
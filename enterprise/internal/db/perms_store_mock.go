@@ -18,4 +18,7 @@ type MockPerms struct {
 	ListPendingUsers             func(ctx context.Context) ([]string, error)
 	ListExternalAccounts         func(ctx context.Context, userID int32) ([]*extsvc.Account, error)
 	GetUserIDsByExternalAccounts func(ctx context.Context, accounts *extsvc.Accounts) (map[string]int32, error)
+	LoadUserSubRepoPermissions   func(ctx context.Context, userID int32, repoIDs []int32) ([]*authz.SubRepoPermissions, error)
+	SetUserSubRepoPermissions    func(ctx context.Context, userID int32, ps []*authz.SubRepoPermissions) error
+	SetRepoSubRepoPermissions    func(ctx context.Context, repoID int32, ps []*authz.SubRepoPermissions) error
 }
@@ -27,7 +27,7 @@ func cleanupPermsTables(t *testing.T, s *PermsStore) {
 		return
 	}
 
-	q := `TRUNCATE TABLE user_permissions, repo_permissions, user_pending_permissions, repo_pending_permissions;`
+	q := `TRUNCATE TABLE user_permissions, repo_permissions, user_pending_permissions, repo_pending_permissions, sub_repo_permissions;`
 	if err := s.execute(context.Background(), sqlf.Sprintf(q)); err != nil {
 		t.Fatal(err)
 	}
@@ -1712,6 +1712,122 @@ func testPermsStore_DeleteAllUserPermissions(db *sql.DB) func(*testing.T) {
 	}
 }
 
+func testPermsStore_SubRepoPermissions(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := NewPermsStore(db, clock)
+		t.Cleanup(func() {
+			cleanupUsersTable(t, s)
+			cleanupReposTable(t, s)
+			cleanupPermsTables(t, s)
+		})
+
+		ctx := context.Background()
+
+		// Create test users "alice" and "bob", and two test repositories
+		qs := []*sqlf.Query{
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('alice')`),            // ID=1
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('bob')`),              // ID=2
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('repo_1', TRUE)`), // ID=1
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('repo_2', TRUE)`), // ID=2
+		}
+		for _, q := range qs {
+			if err := s.execute(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		load := func(userID, repoID int32) *authz.SubRepoPermissions {
+			t.Helper()
+
+			ps, err := s.LoadUserSubRepoPermissions(ctx, userID, []int32{repoID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ps) == 0 {
+				return nil
+			}
+			return ps[0]
+		}
+
+		if p := load(1, 1); p != nil {
+			t.Fatalf("want no permissions but got %+v", p)
+		}
+
+		// Restrict "alice" in both repositories
+		err := s.SetUserSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{RepoID: 1, PathIncludes: []string{"src/**"}, PathExcludes: []string{"src/secret/**"}},
+			{RepoID: 2, PathExcludes: []string{"internal/**"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := load(1, 1)
+		if p == nil {
+			t.Fatal("want permissions but got none")
+		}
+		equal(t, "PathIncludes", []string{"src/**"}, p.PathIncludes)
+		equal(t, "PathExcludes", []string{"src/secret/**"}, p.PathExcludes)
+		equal(t, "UpdatedAt", now, p.UpdatedAt.UnixNano())
+
+		p = load(1, 2)
+		if p == nil {
+			t.Fatal("want permissions but got none")
+		}
+		equal(t, "PathIncludes", []string{}, p.PathIncludes)
+		equal(t, "PathExcludes", []string{"internal/**"}, p.PathExcludes)
+
+		// Permissions in several repositories are loaded at once
+		ps, err := s.LoadUserSubRepoPermissions(ctx, 1, []int32{1, 2, 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		repoIDs := make([]int32, len(ps))
+		for i, p := range ps {
+			repoIDs[i] = p.RepoID
+		}
+		sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+		equal(t, "repoIDs", []int32{1, 2}, repoIDs)
+
+		// Replacing the permissions of "alice" lifts the restrictions in repository 2
+		err = s.SetUserSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{RepoID: 1, PathIncludes: []string{"docs/**"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p = load(1, 1); p == nil {
+			t.Fatal("want permissions but got none")
+		}
+		equal(t, "PathIncludes", []string{"docs/**"}, p.PathIncludes)
+		if p := load(1, 2); p != nil {
+			t.Fatalf("want no permissions but got %+v", p)
+		}
+
+		// Replacing the permissions in repository 1 restricts "bob", and lifts the restrictions of "alice"
+		err = s.SetRepoSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{UserID: 2, PathExcludes: []string{"**/*.key"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p := load(1, 1); p != nil {
+			t.Fatalf("want no permissions but got %+v", p)
+		}
+		if p = load(2, 1); p == nil {
+			t.Fatal("want permissions but got none")
+		}
+		equal(t, "PathExcludes", []string{"**/*.key"}, p.PathExcludes)
+
+		// Deleting all permissions of "bob" deletes the restrictions as well
+		if err = s.DeleteAllUserPermissions(ctx, 2); err != nil {
+			t.Fatal(err)
+		}
+		if p := load(2, 1); p != nil {
+			t.Fatalf("want no permissions but got %+v", p)
+		}
+	}
+}
+
 func testPermsStore_DeleteAllUserPendingPermissions(db *sql.DB) func(*testing.T) {
 	return func(t *testing.T) {
 		s := NewPermsStore(db, clock)
@@ -47,7 +47,9 @@ require (
 	github.com/gitchander/permutation v0.0.0-20181107151852-9e56b92e9909
 	github.com/gliderlabs/ssh v0.3.0 // indirect
 	github.com/glycerine/go-unsnap-stream v0.0.0-20190901134440-81cf024a9e0a // indirect
+	github.com/go-asn1-ber/asn1-ber v1.5.1
 	github.com/go-git/go-git/v5 v5.1.0 // indirect
+	github.com/go-ldap/ldap/v3 v3.2.4
 	github.com/go-openapi/runtime v0.19.21 // indirect
 	github.com/go-openapi/spec v0.19.9 // indirect
 	github.com/go-openapi/strfmt v0.19.5
@@ -0,0 +1,68 @@
+package azuredevops
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Azure DevOps authz providers derived from the connections.
+// It also returns any validation problems with the config, separating these into "serious problems" and
+// "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(
+	conns []*types.AzureDevOpsConnection,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	for _, p := range ps {
+		for _, problem := range p.Validate() {
+			warnings = append(warnings, fmt.Sprintf("Azure DevOps config for %s was invalid: %s", p.ServiceID(), problem))
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(c *types.AzureDevOpsConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if t := c.Authorization.IdentityProvider.Type; t != "username" {
+		return nil, errors.Errorf("Azure DevOps identityProvider type %q is not supported", t)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse URL for Azure DevOps instance %q: %s", c.Url, err)
+	}
+
+	cli := azuredevops.NewClient(extsvc.NormalizeBaseURL(baseURL), nil)
+	cli.Username = c.Username
+	cli.Token = c.Token
+
+	return NewProvider(c.URN, cli), nil
+}
+
+// ValidateAuthz validates the authorization fields of the given Azure DevOps
+// external service config.
+func ValidateAuthz(c *schema.AzureDevOpsConnection) error {
+	_, err := newAuthzProvider(&types.AzureDevOpsConnection{AzureDevOpsConnection: c})
+	return err
+}
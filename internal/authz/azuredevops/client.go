@@ -0,0 +1,56 @@
+package azuredevops
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+// client defines the set of Azure DevOps API client methods used by the authz provider.
+//
+// NOTE: All methods are sorted in alphabetical order.
+type client interface {
+	GetConnectionData(ctx context.Context) (*azuredevops.ConnectionData, error)
+	GetRepository(ctx context.Context, id string) (*azuredevops.Repository, error)
+	ListAccessControlLists(ctx context.Context, namespace, token string, recurse bool) ([]*azuredevops.AccessControlList, error)
+	ListRepositories(ctx context.Context, project string) ([]*azuredevops.Repository, error)
+	ReadIdentities(ctx context.Context, descriptors []string, expandMembership bool) ([]*azuredevops.Identity, error)
+	SearchIdentities(ctx context.Context, name string) ([]*azuredevops.Identity, error)
+}
+
+var _ client = (*azuredevops.Client)(nil)
+
+var _ client = (*mockClient)(nil)
+
+type mockClient struct {
+	MockGetConnectionData      func(ctx context.Context) (*azuredevops.ConnectionData, error)
+	MockGetRepository          func(ctx context.Context, id string) (*azuredevops.Repository, error)
+	MockListAccessControlLists func(ctx context.Context, namespace, token string, recurse bool) ([]*azuredevops.AccessControlList, error)
+	MockListRepositories       func(ctx context.Context, project string) ([]*azuredevops.Repository, error)
+	MockReadIdentities         func(ctx context.Context, descriptors []string, expandMembership bool) ([]*azuredevops.Identity, error)
+	MockSearchIdentities       func(ctx context.Context, name string) ([]*azuredevops.Identity, error)
+}
+
+func (m *mockClient) GetConnectionData(ctx context.Context) (*azuredevops.ConnectionData, error) {
+	return m.MockGetConnectionData(ctx)
+}
+
+func (m *mockClient) GetRepository(ctx context.Context, id string) (*azuredevops.Repository, error) {
+	return m.MockGetRepository(ctx, id)
+}
+
+func (m *mockClient) ListAccessControlLists(ctx context.Context, namespace, token string, recurse bool) ([]*azuredevops.AccessControlList, error) {
+	return m.MockListAccessControlLists(ctx, namespace, token, recurse)
+}
+
+func (m *mockClient) ListRepositories(ctx context.Context, project string) ([]*azuredevops.Repository, error) {
+	return m.MockListRepositories(ctx, project)
+}
+
+func (m *mockClient) ReadIdentities(ctx context.Context, descriptors []string, expandMembership bool) ([]*azuredevops.Identity, error) {
+	return m.MockReadIdentities(ctx, descriptors, expandMembership)
+}
+
+func (m *mockClient) SearchIdentities(ctx context.Context, name string) ([]*azuredevops.Identity, error) {
+	return m.MockSearchIdentities(ctx, name)
+}
@@ -0,0 +1,304 @@
+// Package azuredevops contains an authorization provider for Azure DevOps.
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the access control lists of the Git repositories security namespace of an
+// Azure DevOps organization or collection.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Azure DevOps authorization provider that uses the given
+// azuredevops.Client to talk to the Azure DevOps API that is the source of truth for
+// permissions. It assumes usernames of Sourcegraph accounts match 1-1 with account names of
+// Azure DevOps identities without their domain.
+func NewProvider(urn string, cli *azuredevops.Client) *Provider {
+	return &Provider{
+		urn:      urn,
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(cli.URL, extsvc.TypeAzureDevOps),
+	}
+}
+
+// Validate validates that the Provider has access to the Azure DevOps API with the
+// credentials it was configured with.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.client.GetConnectionData(ctx); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the URL of the Azure DevOps organization or collection this provider is
+// configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "azuredevops".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Azure DevOps identity whose account name without its domain is the
+// username of the given user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	identities, err := p.client.SearchIdentities(ctx, user.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "searching identities")
+	}
+
+	var identity *azuredevops.Identity
+	for _, id := range identities {
+		if id.IsActive && !id.IsContainer && strings.EqualFold(id.Username(), user.Username) {
+			identity = id
+			break
+		}
+	}
+	if identity == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   identity.ID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID. The returned list only includes repositories of private
+// projects.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var identity azuredevops.Identity
+	if err := json.Unmarshal(*account.Data, &identity); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	// The account data might be stale, so we read the groups the identity is
+	// a member of from the API.
+	identities, err := p.client.ReadIdentities(ctx, []string{identity.Descriptor}, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading identity")
+	}
+	if len(identities) == 0 {
+		return nil, errors.Errorf("identity %q not found", identity.Descriptor)
+	}
+	descriptors := descriptorsOf(identities[0])
+
+	acls, err := p.accessControlLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := p.client.ListRepositories(ctx, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repositories")
+	}
+
+	var ids []extsvc.RepoID
+	for _, r := range repos {
+		if r.IsPrivate() && acls.canRead(r.Project.ID, r.ID, descriptors) {
+			ids = append(ids, extsvc.RepoID(r.ID))
+		}
+	}
+
+	return ids, nil
+}
+
+// FetchRepoPerms returns a list of user IDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both direct access
+// and inherited from the group membership.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	r, err := p.client.GetRepository(ctx, repo.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repository")
+	}
+
+	acls, err := p.accessControlLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only identities that are allowed to read somewhere along the hierarchy
+	// of the repository, and their members, can possibly read it.
+	var candidates []string
+	acls.walk(r.Project.ID, r.ID, func(acl *azuredevops.AccessControlList) {
+		for _, ace := range acl.AcesDictionary {
+			if ace.Allow&azuredevops.GitPermissionRead != 0 {
+				candidates = append(candidates, ace.Descriptor)
+			}
+		}
+	})
+
+	var ids []extsvc.AccountID
+	seen := make(map[string]bool)
+	for len(candidates) > 0 {
+		var batch []string
+		for _, d := range candidates {
+			if !seen[strings.ToLower(d)] {
+				seen[strings.ToLower(d)] = true
+				batch = append(batch, d)
+			}
+		}
+		candidates = nil
+
+		if len(batch) == 0 {
+			break
+		}
+
+		identities, err := p.client.ReadIdentities(ctx, batch, true)
+		if err != nil {
+			return ids, errors.Wrap(err, "reading identities")
+		}
+
+		for _, id := range identities {
+			if id.IsContainer {
+				candidates = append(candidates, id.Members...)
+				continue
+			}
+			if id.IsActive && acls.canRead(r.Project.ID, r.ID, descriptorsOf(id)) {
+				ids = append(ids, extsvc.AccountID(id.ID))
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// accessControlLists returns the access control lists of all Git repositories
+// security tokens of the organization, keyed by token.
+func (p *Provider) accessControlLists(ctx context.Context) (accessControlLists, error) {
+	list, err := p.client.ListAccessControlLists(ctx, azuredevops.GitSecurityNamespace, azuredevops.GitSecurityToken("", ""), true)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing access control lists")
+	}
+
+	acls := make(accessControlLists, len(list))
+	for _, acl := range list {
+		acls[strings.ToLower(acl.Token)] = acl
+	}
+	return acls, nil
+}
+
+// descriptorsOf returns the lowercased descriptors of the identity and all the
+// groups it's a member of.
+func descriptorsOf(id *azuredevops.Identity) map[string]bool {
+	ds := make(map[string]bool, len(id.MemberOf)+1)
+	ds[strings.ToLower(id.Descriptor)] = true
+	for _, d := range id.MemberOf {
+		ds[strings.ToLower(d)] = true
+	}
+	return ds
+}
+
+type accessControlLists map[string]*azuredevops.AccessControlList
+
+// walk calls fn with the access control lists that apply to the repository,
+// from the most to the least specific one, stopping at the first one that
+// doesn't inherit permissions.
+func (acls accessControlLists) walk(projectID, repoID string, fn func(*azuredevops.AccessControlList)) {
+	for _, token := range []string{
+		azuredevops.GitSecurityToken(projectID, repoID),
+		azuredevops.GitSecurityToken(projectID, ""),
+		azuredevops.GitSecurityToken("", ""),
+	} {
+		acl, ok := acls[strings.ToLower(token)]
+		if !ok {
+			continue
+		}
+		fn(acl)
+		if !acl.InheritPermissions {
+			return
+		}
+	}
+}
+
+// canRead returns true if the given set of descriptors is allowed to read the
+// repository. An explicit deny takes precedence over an allow on the same
+// token, and both take precedence over the permissions of less specific
+// tokens.
+func (acls accessControlLists) canRead(projectID, repoID string, descriptors map[string]bool) bool {
+	var decided, allowed bool
+	acls.walk(projectID, repoID, func(acl *azuredevops.AccessControlList) {
+		if decided {
+			return
+		}
+		var allow, deny bool
+		for _, ace := range acl.AcesDictionary {
+			if !descriptors[strings.ToLower(ace.Descriptor)] {
+				continue
+			}
+			allow = allow || ace.Allow&azuredevops.GitPermissionRead != 0
+			deny = deny || ace.Deny&azuredevops.GitPermissionRead != 0
+		}
+		if deny || allow {
+			decided, allowed = true, !deny
+		}
+	})
+	return allowed
+}
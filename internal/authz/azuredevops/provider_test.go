@@ -0,0 +1,269 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+)
+
+const (
+	infraProject   = "8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b"
+	toolsRepo      = "5febef5a-833d-4e14-b9c0-14cb638f91e6"
+	secretsRepo    = "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	publicProject  = "4c2e6a8b-1d3f-4b5c-8e7a-9f1b3d5c7e9a"
+	docsRepo       = "9f8e7d6c-5b4a-4392-8170-6f5e4d3c2b1a"
+	aliceID        = "d6245f20-2af8-44f4-9451-8107cb2767db"
+	bobID          = "e7356a31-3b09-4505-a562-9218dc3878ec"
+	aliceDesc      = "Microsoft.IdentityModel.Claims.ClaimsIdentity;sourcegraph.com\\alice@sourcegraph.com"
+	bobDesc        = "Microsoft.IdentityModel.Claims.ClaimsIdentity;sourcegraph.com\\bob@sourcegraph.com"
+	contributors   = "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1204400969-2402986413-2179408616-0-0-0-0-1"
+	readers        = "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1204400969-2402986413-2179408616-0-0-0-0-2"
+	serviceBaseURL = "https://dev.azure.com/sgtest/"
+)
+
+func newTestProvider(cli client) *Provider {
+	u, _ := url.Parse(serviceBaseURL)
+	return &Provider{
+		urn:      "extsvc:azuredevops:1",
+		client:   cli,
+		codeHost: extsvc.NewCodeHost(u, extsvc.TypeAzureDevOps),
+	}
+}
+
+// testClient returns a mockClient for an organization where:
+//
+//   - Contributors (alice) can read all repositories of the infra project.
+//   - Readers (bob) can read the infra project, but are denied reading the
+//     secrets repository, which doesn't inherit permissions from it.
+//   - The docs project is public, so its repositories are never returned.
+func testClient() *mockClient {
+	identities := map[string]*azuredevops.Identity{
+		aliceDesc: {
+			ID:         aliceID,
+			Descriptor: aliceDesc,
+			IsActive:   true,
+			MemberOf:   []string{contributors},
+		},
+		bobDesc: {
+			ID:         bobID,
+			Descriptor: bobDesc,
+			IsActive:   true,
+			MemberOf:   []string{readers},
+		},
+		contributors: {
+			Descriptor:  contributors,
+			IsActive:    true,
+			IsContainer: true,
+			Members:     []string{aliceDesc},
+		},
+		readers: {
+			Descriptor:  readers,
+			IsActive:    true,
+			IsContainer: true,
+			Members:     []string{bobDesc},
+		},
+	}
+
+	repos := []*azuredevops.Repository{
+		{ID: toolsRepo, Project: azuredevops.Project{ID: infraProject, Visibility: azuredevops.ProjectVisibilityPrivate}},
+		{ID: secretsRepo, Project: azuredevops.Project{ID: infraProject, Visibility: azuredevops.ProjectVisibilityPrivate}},
+		{ID: docsRepo, Project: azuredevops.Project{ID: publicProject, Visibility: azuredevops.ProjectVisibilityPublic}},
+	}
+
+	ace := func(descriptor string, allow, deny int) map[string]*azuredevops.AccessControlEntry {
+		return map[string]*azuredevops.AccessControlEntry{
+			descriptor: {Descriptor: descriptor, Allow: allow, Deny: deny},
+		}
+	}
+
+	return &mockClient{
+		MockGetRepository: func(ctx context.Context, id string) (*azuredevops.Repository, error) {
+			for _, r := range repos {
+				if r.ID == id {
+					return r, nil
+				}
+			}
+			return nil, fmt.Errorf("repository %s not found", id)
+		},
+		MockListAccessControlLists: func(ctx context.Context, namespace, token string, recurse bool) ([]*azuredevops.AccessControlList, error) {
+			return []*azuredevops.AccessControlList{
+				{
+					Token:              azuredevops.GitSecurityToken(infraProject, ""),
+					InheritPermissions: true,
+					AcesDictionary: map[string]*azuredevops.AccessControlEntry{
+						contributors: {Descriptor: contributors, Allow: azuredevops.GitPermissionRead | 4},
+						readers:      {Descriptor: readers, Allow: azuredevops.GitPermissionRead},
+					},
+				},
+				{
+					Token:              azuredevops.GitSecurityToken(infraProject, secretsRepo),
+					InheritPermissions: false,
+					AcesDictionary: map[string]*azuredevops.AccessControlEntry{
+						contributors: {Descriptor: contributors, Allow: azuredevops.GitPermissionRead},
+						readers:      {Descriptor: readers, Deny: azuredevops.GitPermissionRead},
+					},
+				},
+				{
+					Token:              azuredevops.GitSecurityToken(infraProject, toolsRepo),
+					InheritPermissions: true,
+					AcesDictionary:     ace(bobDesc, 4, 0),
+				},
+			}, nil
+		},
+		MockListRepositories: func(ctx context.Context, project string) ([]*azuredevops.Repository, error) {
+			return repos, nil
+		},
+		MockReadIdentities: func(ctx context.Context, descriptors []string, expandMembership bool) ([]*azuredevops.Identity, error) {
+			var ids []*azuredevops.Identity
+			for _, d := range descriptors {
+				if id, ok := identities[d]; ok {
+					ids = append(ids, id)
+				}
+			}
+			return ids, nil
+		},
+		MockSearchIdentities: func(ctx context.Context, name string) ([]*azuredevops.Identity, error) {
+			var ids []*azuredevops.Identity
+			err := json.Unmarshal([]byte(`[
+				{"id": "group", "isActive": true, "isContainer": true, "properties": {"Account": {"$value": "`+name+`"}}},
+				{"id": "inactive", "properties": {"Account": {"$value": "`+name+`@sourcegraph.com"}}},
+				{"id": "`+aliceID+`", "descriptor": "Microsoft.IdentityModel.Claims.ClaimsIdentity;sourcegraph.com\\alice@sourcegraph.com", "isActive": true, "properties": {"Account": {"$value": "Alice@sourcegraph.com"}}}
+			]`), &ids)
+			return ids, err
+		},
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "alice"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct == nil {
+		t.Fatal("no account returned")
+	}
+
+	want := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeAzureDevOps,
+		ServiceID:   serviceBaseURL,
+		AccountID:   aliceID,
+	}
+	if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+		t.Errorf("account spec mismatch (-want +have):\n%s", diff)
+	}
+	if acct.UserID != 42 {
+		t.Errorf("user ID: want 42, have %d", acct.UserID)
+	}
+
+	var identity azuredevops.Identity
+	if err := json.Unmarshal(*acct.Data, &identity); err != nil {
+		t.Fatal(err)
+	}
+	if identity.Descriptor != aliceDesc {
+		t.Errorf("descriptor: want %q, have %q", aliceDesc, identity.Descriptor)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	t.Run("nil account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), nil)
+		want := "no account provided"
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		data := json.RawMessage(`{}`)
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: "gitlab",
+				ServiceID:   "https://gitlab.com/",
+			},
+			AccountData: extsvc.AccountData{Data: &data},
+		})
+		want := `not a code host of the account: want "https://dev.azure.com/sgtest/" but have "https://gitlab.com/"`
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		descriptor string
+		want       []extsvc.RepoID
+	}{
+		{descriptor: aliceDesc, want: []extsvc.RepoID{secretsRepo, toolsRepo}},
+		{descriptor: bobDesc, want: []extsvc.RepoID{toolsRepo}},
+	} {
+		t.Run(tc.descriptor, func(t *testing.T) {
+			data := json.RawMessage(fmt.Sprintf(`{"descriptor": %q}`, tc.descriptor))
+			have, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeAzureDevOps,
+					ServiceID:   serviceBaseURL,
+				},
+				AccountData: extsvc.AccountData{Data: &data},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Slice(have, func(i, j int) bool { return have[i] < have[j] })
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("repo IDs mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	t.Run("nil repo", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(context.Background(), nil)
+		want := "no repo provided"
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		repoID string
+		want   []extsvc.AccountID
+	}{
+		{repoID: toolsRepo, want: []extsvc.AccountID{aliceID, bobID}},
+		{repoID: secretsRepo, want: []extsvc.AccountID{aliceID}},
+	} {
+		t.Run(tc.repoID, func(t *testing.T) {
+			have, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ID:          tc.repoID,
+					ServiceType: extsvc.TypeAzureDevOps,
+					ServiceID:   serviceBaseURL,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Slice(have, func(i, j int) bool { return have[i] < have[j] })
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("account IDs mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package bitbucketcloud
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Bitbucket Cloud authz providers derived from the connections.
+// It also returns any validation problems with the config, separating these into "serious problems" and
+// "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(
+	conns []*types.BitbucketCloudConnection,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	for _, p := range ps {
+		for _, problem := range p.Validate() {
+			warnings = append(warnings, fmt.Sprintf("Bitbucket Cloud config for %s was invalid: %s", p.ServiceID(), problem))
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(c *types.BitbucketCloudConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if t := c.Authorization.IdentityProvider.Type; t != "username" {
+		return nil, errors.Errorf("Bitbucket Cloud identityProvider type %q is not supported", t)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse URL for Bitbucket Cloud instance %q: %s", c.Url, err)
+	}
+
+	apiURL := c.ApiURL
+	if apiURL == "" {
+		apiURL = "https://api.bitbucket.org"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse API URL for Bitbucket Cloud instance %q: %s", apiURL, err)
+	}
+
+	cli := bitbucketcloud.NewClient(extsvc.NormalizeBaseURL(u), nil)
+	cli.Username = c.Username
+	cli.AppPassword = c.AppPassword
+
+	workspaces := append([]string{c.Username}, c.Teams...)
+	return NewProvider(c.URN, baseURL, workspaces, cli), nil
+}
+
+// ValidateAuthz validates the authorization fields of the given Bitbucket Cloud
+// external service config.
+func ValidateAuthz(c *schema.BitbucketCloudConnection) error {
+	_, err := newAuthzProvider(&types.BitbucketCloudConnection{BitbucketCloudConnection: c})
+	return err
+}
@@ -0,0 +1,38 @@
+package bitbucketcloud
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+// client defines the set of Bitbucket Cloud API client methods used by the authz provider.
+//
+// NOTE: All methods are sorted in alphabetical order.
+type client interface {
+	RepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	WorkspaceMembers(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error)
+	WorkspaceRepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+}
+
+var _ client = (*bitbucketcloud.Client)(nil)
+
+var _ client = (*mockClient)(nil)
+
+type mockClient struct {
+	MockRepoPermissions          func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+	MockWorkspaceMembers         func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error)
+	MockWorkspaceRepoPermissions func(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error)
+}
+
+func (m *mockClient) RepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+	return m.MockRepoPermissions(ctx, pageToken, workspace, repoSlug)
+}
+
+func (m *mockClient) WorkspaceMembers(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error) {
+	return m.MockWorkspaceMembers(ctx, pageToken, workspace)
+}
+
+func (m *mockClient) WorkspaceRepoPermissions(ctx context.Context, pageToken *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+	return m.MockWorkspaceRepoPermissions(ctx, pageToken, workspace)
+}
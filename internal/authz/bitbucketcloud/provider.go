@@ -0,0 +1,237 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the effective repository permissions of the members of a set of Bitbucket
+// Cloud workspaces.
+type Provider struct {
+	urn        string
+	workspaces []string
+	client     client
+	codeHost   *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses the given
+// bitbucketcloud.Client to read the repository permissions of the given workspaces. The
+// user of the client must be an administrator of all of these workspaces. It assumes
+// usernames of Sourcegraph accounts match 1-1 with nicknames of Bitbucket Cloud users.
+func NewProvider(urn string, baseURL *url.URL, workspaces []string, cli client) *Provider {
+	seen := make(map[string]bool, len(workspaces))
+	ws := make([]string, 0, len(workspaces))
+	for _, w := range workspaces {
+		if w == "" || seen[w] {
+			continue
+		}
+		seen[w] = true
+		ws = append(ws, w)
+	}
+
+	return &Provider{
+		urn:        urn,
+		workspaces: ws,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+	}
+}
+
+// Validate validates that the Provider can read the repository permissions of all of its
+// workspaces with the credentials it was configured with.
+func (p *Provider) Validate() (problems []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, w := range p.workspaces {
+		_, _, err := p.client.WorkspaceRepoPermissions(ctx, &bitbucketcloud.PageToken{Pagelen: 1}, w)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("reading repository permissions of workspace %q: %s", w, err))
+		}
+	}
+
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the URL of Bitbucket Cloud this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the member of one of the workspaces whose nickname is the username of
+// the given user. Nicknames are matched exactly, and no account is returned if more than one
+// member has the nickname, since nicknames aren't unique on Bitbucket Cloud.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	// A user can be a member of more than one workspace, so members are keyed by UUID.
+	members := make(map[string]*bitbucketcloud.User)
+	for _, w := range p.workspaces {
+		err := forEachPage(func(page *bitbucketcloud.PageToken) (*bitbucketcloud.PageToken, error) {
+			users, next, err := p.client.WorkspaceMembers(ctx, page, w)
+			for _, u := range users {
+				if u.Nickname == user.Username {
+					members[u.UUID] = u
+				}
+			}
+			return next, err
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing members of workspace %q", w)
+		}
+	}
+
+	var member *bitbucketcloud.User
+	switch len(members) {
+	case 0:
+		return nil, nil
+	case 1:
+		for _, u := range members {
+			member = u
+		}
+	default:
+		log15.Warn("bitbucketcloud.Provider.FetchAccount: more than one workspace member has the nickname of the user, skipping", "userID", user.ID, "nickname", user.Username, "members", len(members))
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(member)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   member.UUID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs that the given account can read in the
+// workspaces of the provider. The repository UUID has the same value as it would be used as
+// api.ExternalRepoSpec.ID.
+//
+// Bitbucket Cloud doesn't support filtering the repository permissions of a workspace by
+// user, so the permissions of all users are read and filtered. This method may return
+// partial but valid results in case of error, and it is up to callers to decide whether to
+// discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var ids []extsvc.RepoID
+	for _, w := range p.workspaces {
+		err := forEachPage(func(page *bitbucketcloud.PageToken) (*bitbucketcloud.PageToken, error) {
+			perms, next, err := p.client.WorkspaceRepoPermissions(ctx, page, w)
+			for _, perm := range perms {
+				if canRead(perm) && perm.User.UUID == account.AccountID {
+					ids = append(ids, extsvc.RepoID(perm.Repository.UUID))
+				}
+			}
+			return next, err
+		})
+		if err != nil {
+			return ids, errors.Wrapf(err, "listing repository permissions of workspace %q", w)
+		}
+	}
+
+	return ids, nil
+}
+
+// FetchRepoPerms returns a list of user UUIDs who can read the given repository. The user
+// UUID has the same value as it would be used as extsvc.Account.AccountID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// The URI of a Bitbucket Cloud repository always ends with its full name,
+	// e.g. "bitbucket.org/workspace/slug".
+	parts := strings.Split(repo.URI, "/")
+	if len(parts) < 3 {
+		return nil, errors.Errorf("invalid repository URI %q", repo.URI)
+	}
+	workspace, slug := parts[len(parts)-2], parts[len(parts)-1]
+
+	var ids []extsvc.AccountID
+	err := forEachPage(func(page *bitbucketcloud.PageToken) (*bitbucketcloud.PageToken, error) {
+		perms, next, err := p.client.RepoPermissions(ctx, page, workspace, slug)
+		for _, perm := range perms {
+			// The slug might have been reused by another repository since the
+			// repository was last synced.
+			if perm.Repository != nil && perm.Repository.UUID != repo.ID {
+				return nil, errors.Errorf("repository %s/%s is not %q", workspace, slug, repo.ID)
+			}
+			if canRead(perm) {
+				ids = append(ids, extsvc.AccountID(perm.User.UUID))
+			}
+		}
+		return next, err
+	})
+	if err != nil {
+		return ids, errors.Wrap(err, "listing repository permissions")
+	}
+
+	return ids, nil
+}
+
+// forEachPage calls fetch with the first page token and then with the returned page token
+// until fetch returns an error or a page token without more results.
+func forEachPage(fetch func(page *bitbucketcloud.PageToken) (*bitbucketcloud.PageToken, error)) error {
+	page := &bitbucketcloud.PageToken{Pagelen: 100}
+	for {
+		var err error
+		if page, err = fetch(page); err != nil {
+			return err
+		}
+		if !page.HasMore() {
+			return nil
+		}
+	}
+}
+
+// canRead reports whether the repository permission grants at least read access on a
+// repository to a user. Permissions are one of "read", "write" or "admin".
+func canRead(perm *bitbucketcloud.RepoPermission) bool {
+	return perm.User != nil && perm.Repository != nil && perm.Permission != "" && perm.Permission != "none"
+}
@@ -0,0 +1,306 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+)
+
+const (
+	aliceUUID      = "{d6245f20-2af8-44f4-9451-8107cb2767db}"
+	bobUUID        = "{e7356a31-3b09-4505-a562-9218dc3878ec}"
+	muxUUID        = "{e1e75436-05e6-4c38-8543-9c36ec26fad1}"
+	secretsUUID    = "{421b93e9-1f00-4054-8156-4d821d4a768b}"
+	serviceBaseURL = "https://bitbucket.org/"
+)
+
+var (
+	alice   = &bitbucketcloud.User{UUID: aliceUUID, Nickname: "alice"}
+	bob     = &bitbucketcloud.User{UUID: bobUUID, Nickname: "bob"}
+	mux     = &bitbucketcloud.Repo{UUID: muxUUID, Slug: "mux", FullName: "sglocal/mux"}
+	secrets = &bitbucketcloud.Repo{UUID: secretsUUID, Slug: "secrets", FullName: "sglocal/secrets"}
+)
+
+func newTestProvider(cli client) *Provider {
+	u, _ := url.Parse(serviceBaseURL)
+	return NewProvider("extsvc:bitbucketcloud:1", u, []string{"sgadmin", "sglocal", "sgadmin"}, cli)
+}
+
+// paginate returns the page of the given values that the page token points to, two values
+// per page.
+func paginate(page *bitbucketcloud.PageToken, n int) (start, end int, next *bitbucketcloud.PageToken) {
+	if page.HasMore() {
+		fmt.Sscanf(page.Next, "page=%d", &start)
+	}
+	end = start + 2
+	next = &bitbucketcloud.PageToken{Pagelen: 2}
+	if end < n {
+		next.Next = fmt.Sprintf("page=%d", end)
+	} else {
+		end = n
+	}
+	return start, end, next
+}
+
+// testClient returns a mockClient for a sglocal workspace where alice is an administrator of
+// all repositories, and bob can only read the mux repository. The sgadmin workspace has no
+// members or repositories.
+func testClient() *mockClient {
+	perms := []*bitbucketcloud.RepoPermission{
+		{Permission: "admin", User: alice, Repository: mux},
+		{Permission: "admin", User: alice, Repository: secrets},
+		{Permission: "read", User: bob, Repository: mux},
+	}
+	members := []*bitbucketcloud.User{alice, bob}
+
+	return &mockClient{
+		MockRepoPermissions: func(ctx context.Context, page *bitbucketcloud.PageToken, workspace, repoSlug string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if workspace != "sglocal" {
+				return nil, nil, fmt.Errorf("workspace %q not found", workspace)
+			}
+			var ps []*bitbucketcloud.RepoPermission
+			for _, p := range perms {
+				if p.Repository.Slug == repoSlug {
+					ps = append(ps, p)
+				}
+			}
+			start, end, next := paginate(page, len(ps))
+			return ps[start:end], next, nil
+		},
+		MockWorkspaceMembers: func(ctx context.Context, page *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error) {
+			if workspace != "sglocal" {
+				return nil, &bitbucketcloud.PageToken{}, nil
+			}
+			start, end, next := paginate(page, len(members))
+			return members[start:end], next, nil
+		},
+		MockWorkspaceRepoPermissions: func(ctx context.Context, page *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.RepoPermission, *bitbucketcloud.PageToken, error) {
+			if workspace != "sglocal" {
+				return nil, &bitbucketcloud.PageToken{}, nil
+			}
+			start, end, next := paginate(page, len(perms))
+			return perms[start:end], next, nil
+		},
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	t.Run("nil user", func(t *testing.T) {
+		got, err := p.FetchAccount(context.Background(), nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("want nil account, got %+v", got)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		got, err := p.FetchAccount(context.Background(), &types.User{Username: "carol"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("want nil account, got %+v", got)
+		}
+	})
+
+	t.Run("nickname with different case", func(t *testing.T) {
+		got, err := p.FetchAccount(context.Background(), &types.User{Username: "Alice"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("want nil account, got %+v", got)
+		}
+	})
+
+	t.Run("nickname of more than one member", func(t *testing.T) {
+		cli := testClient()
+		members := cli.MockWorkspaceMembers
+		cli.MockWorkspaceMembers = func(ctx context.Context, page *bitbucketcloud.PageToken, workspace string) ([]*bitbucketcloud.User, *bitbucketcloud.PageToken, error) {
+			if workspace == "sgadmin" {
+				return []*bitbucketcloud.User{{UUID: "{b1c7a2f0-6d4e-4f0b-9a35-3e2f8c1d5a76}", Nickname: "alice"}}, &bitbucketcloud.PageToken{}, nil
+			}
+			return members(ctx, page, workspace)
+		}
+
+		got, err := newTestProvider(cli).FetchAccount(context.Background(), &types.User{Username: "alice"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("want nil account, got %+v", got)
+		}
+	})
+
+	t.Run("matches nickname", func(t *testing.T) {
+		got, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := json.Marshal(alice)
+		want := &extsvc.Account{
+			UserID: 1,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   serviceBaseURL,
+				AccountID:   aliceUUID,
+			},
+			AccountData: extsvc.AccountData{
+				Data: (*json.RawMessage)(&data),
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("account mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	t.Run("nil account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), nil)
+		want := "no account provided"
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(),
+			&extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitLab,
+					ServiceID:   "https://gitlab.com/",
+				},
+			},
+		)
+		want := `not a code host of the account: want "https://bitbucket.org/" but have "https://gitlab.com/"`
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		accountID string
+		want      []extsvc.RepoID
+	}{
+		{accountID: aliceUUID, want: []extsvc.RepoID{muxUUID, secretsUUID}},
+		{accountID: bobUUID, want: []extsvc.RepoID{muxUUID}},
+	} {
+		t.Run(tc.accountID, func(t *testing.T) {
+			got, err := p.FetchUserPerms(context.Background(),
+				&extsvc.Account{
+					AccountSpec: extsvc.AccountSpec{
+						ServiceType: extsvc.TypeBitbucketCloud,
+						ServiceID:   serviceBaseURL,
+						AccountID:   tc.accountID,
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("repo IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(testClient())
+
+	t.Run("nil repo", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(context.Background(), nil)
+		want := "no repo provided"
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the repo", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(context.Background(),
+			&extsvc.Repository{
+				URI: "gitlab.com/user/repo",
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ServiceType: extsvc.TypeGitLab,
+					ServiceID:   "https://gitlab.com/",
+				},
+			},
+		)
+		want := `not a code host of the repo: want "https://bitbucket.org/" but have "https://gitlab.com/"`
+		got := fmt.Sprintf("%v", err)
+		if got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("slug reused by another repository", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(context.Background(),
+			&extsvc.Repository{
+				URI: "bitbucket.org/sglocal/mux",
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ID:          secretsUUID,
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   serviceBaseURL,
+				},
+			},
+		)
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+	})
+
+	for _, tc := range []struct {
+		uri  string
+		id   string
+		want []extsvc.AccountID
+	}{
+		{uri: "bitbucket.org/sglocal/mux", id: muxUUID, want: []extsvc.AccountID{aliceUUID, bobUUID}},
+		{uri: "bitbucket.org/sglocal/secrets", id: secretsUUID, want: []extsvc.AccountID{aliceUUID}},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			got, err := p.FetchRepoPerms(context.Background(),
+				&extsvc.Repository{
+					URI: tc.uri,
+					ExternalRepoSpec: api.ExternalRepoSpec{
+						ID:          tc.id,
+						ServiceType: extsvc.TypeBitbucketCloud,
+						ServiceID:   serviceBaseURL,
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("account IDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	p := newTestProvider(testClient())
+	if diff := cmp.Diff([]string{"sgadmin", "sglocal"}, p.workspaces); diff != "" {
+		t.Fatalf("workspaces mismatch (-want +got):\n%s", diff)
+	}
+}
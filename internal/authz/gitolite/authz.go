@@ -0,0 +1,55 @@
+package gitolite
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewAuthzProviders returns the set of Gitolite authz providers derived from the connections.
+// It also returns any validation problems with the config, separating these into "serious problems" and
+// "warnings". "Serious problems" are those that should make Sourcegraph set authz.allowAccessByDefault
+// to false. "Warnings" are all other validation problems.
+func NewAuthzProviders(
+	conns []*types.GitoliteConnection,
+) (ps []authz.Provider, problems []string, warnings []string) {
+	// Authorization (i.e., permissions) providers
+	for _, c := range conns {
+		p, err := newAuthzProvider(c)
+		if err != nil {
+			problems = append(problems, err.Error())
+		} else if p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	for _, p := range ps {
+		for _, problem := range p.Validate() {
+			warnings = append(warnings, fmt.Sprintf("Gitolite config for %s was invalid: %s", p.ServiceID(), problem))
+		}
+	}
+
+	return ps, problems, warnings
+}
+
+func newAuthzProvider(c *types.GitoliteConnection) (authz.Provider, error) {
+	if c.Authorization == nil {
+		return nil, nil
+	}
+
+	if c.Host == "" {
+		return nil, fmt.Errorf("Gitolite authorization requires the host to be set")
+	}
+
+	return NewProvider(c.URN, c.Host, gitserver.DefaultClient), nil
+}
+
+// ValidateAuthz validates the authorization fields of the given Gitolite
+// external service config.
+func ValidateAuthz(c *schema.GitoliteConnection) error {
+	_, err := newAuthzProvider(&types.GitoliteConnection{GitoliteConnection: c})
+	return err
+}
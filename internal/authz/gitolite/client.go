@@ -0,0 +1,34 @@
+package gitolite
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+// client defines the set of gitserver client methods used by the authz provider to talk to
+// the Gitolite host, since only gitserver holds the SSH key to authenticate to it.
+//
+// NOTE: All methods are sorted in alphabetical order.
+type client interface {
+	ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+	ListGitoliteUsers(ctx context.Context, gitoliteHost string) ([]string, error)
+}
+
+var _ client = (*gitserver.Client)(nil)
+
+var _ client = (*mockClient)(nil)
+
+type mockClient struct {
+	MockListGitoliteForUser func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+	MockListGitoliteUsers   func(ctx context.Context, gitoliteHost string) ([]string, error)
+}
+
+func (m *mockClient) ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+	return m.MockListGitoliteForUser(ctx, gitoliteHost, user)
+}
+
+func (m *mockClient) ListGitoliteUsers(ctx context.Context, gitoliteHost string) ([]string, error) {
+	return m.MockListGitoliteUsers(ctx, gitoliteHost)
+}
@@ -0,0 +1,190 @@
+// Package gitolite contains an authorization provider for Gitolite.
+package gitolite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// reported by Gitolite for each of its users. Because only gitserver holds the SSH key to
+// authenticate to the Gitolite host, all requests go through gitserver.
+type Provider struct {
+	urn      string
+	host     string
+	client   client
+	codeHost *extsvc.CodeHost
+	clock    func() time.Time
+
+	mu          sync.Mutex
+	readers     map[string][]extsvc.AccountID // Repository name -> usernames
+	readersTime time.Time
+}
+
+// readersTTL is how long the readers of all repositories listed by FetchRepoPerms are reused,
+// so that syncing the permissions of every repository lists the repositories of every user
+// only once.
+const readersTTL = 10 * time.Minute
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitolite authorization provider for the given Gitolite host that
+// uses the given client to run commands on the host on behalf of its users. It assumes
+// usernames of Sourcegraph accounts match 1-1 with usernames of Gitolite users.
+func NewProvider(urn, host string, cli client) *Provider {
+	return &Provider{
+		urn:    urn,
+		host:   host,
+		client: cli,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   gitolite.ServiceID(host),
+			ServiceType: extsvc.TypeGitolite,
+		},
+		clock: time.Now,
+	}
+}
+
+// Validate validates that the Provider can list the users of the Gitolite host, and run
+// commands on behalf of them, which requires super-user access.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	users, err := p.client.ListGitoliteUsers(ctx, p.host)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if len(users) == 0 {
+		return []string{"no Gitolite users found"}
+	}
+
+	if _, err = p.client.ListGitoliteForUser(ctx, p.host, users[0]); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the Gitolite host this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "gitolite".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Gitolite user whose username is the username of the given user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (*extsvc.Account, error) {
+	if user == nil || !gitolite.IsValidUsername(user.Username) {
+		return nil, nil
+	}
+
+	users, err := p.client.ListGitoliteUsers(ctx, p.host)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing users")
+	}
+
+	for _, u := range users {
+		// Gitolite usernames are case-sensitive.
+		if u == user.Username {
+			return &extsvc.Account{
+				UserID: user.ID,
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: p.codeHost.ServiceType,
+					ServiceID:   p.codeHost.ServiceID,
+					AccountID:   u,
+				},
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of names of the Gitolite repositories that the given account
+// can read. The names have the same value as they would be used as api.ExternalRepoSpec.ID.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	repos, err := p.client.ListGitoliteForUser(ctx, p.host, account.AccountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repositories of user")
+	}
+
+	ids := make([]extsvc.RepoID, 0, len(repos))
+	for _, r := range repos {
+		ids = append(ids, extsvc.RepoID(r.Name))
+	}
+
+	return ids, nil
+}
+
+// FetchRepoPerms returns a list of usernames of the Gitolite users who can read the given
+// repository. The usernames have the same value as they would be used as
+// extsvc.Account.AccountID.
+//
+// Gitolite can only report the repositories a user can read, so this method lists the
+// repositories of every user once, and reuses the listing for other repositories for
+// readersTTL. It may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	readers, err := p.listReaders(ctx)
+	return readers[repo.ID], err
+}
+
+// listReaders returns the usernames of the Gitolite users who can read each repository. The
+// result is cached for readersTTL, unless an error occurs, in which case the readers found
+// so far are returned.
+func (p *Provider) listReaders(ctx context.Context) (map[string][]extsvc.AccountID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.readers != nil && p.clock().Sub(p.readersTime) < readersTTL {
+		return p.readers, nil
+	}
+
+	users, err := p.client.ListGitoliteUsers(ctx, p.host)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing users")
+	}
+
+	readers := make(map[string][]extsvc.AccountID)
+	for _, u := range users {
+		repos, err := p.client.ListGitoliteForUser(ctx, p.host, u)
+		if err != nil {
+			return readers, errors.Wrapf(err, "listing repositories of user %q", u)
+		}
+
+		for _, r := range repos {
+			readers[r.Name] = append(readers[r.Name], extsvc.AccountID(u))
+		}
+	}
+
+	p.readers, p.readersTime = readers, p.clock()
+	return readers, nil
+}
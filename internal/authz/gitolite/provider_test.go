@@ -0,0 +1,234 @@
+package gitolite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+)
+
+const testHost = "git@gitolite.example.com"
+
+// testClient returns a mockClient for a Gitolite host where:
+//
+//   - admin can read all repositories.
+//   - alice can read the infra and tools repositories.
+//   - bob can only read the tools repository.
+func testClient() *mockClient {
+	repos := map[string][]string{
+		"admin": {"gitolite-admin", "infra", "tools"},
+		"alice": {"infra", "tools"},
+		"bob":   {"tools"},
+	}
+
+	return &mockClient{
+		MockListGitoliteForUser: func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+			if gitoliteHost != testHost {
+				return nil, fmt.Errorf("unexpected host %q", gitoliteHost)
+			}
+
+			var rs []*gitolite.Repo
+			for _, name := range repos[user] {
+				rs = append(rs, &gitolite.Repo{Name: name, URL: testHost + ":" + name})
+			}
+			return rs, nil
+		},
+		MockListGitoliteUsers: func(ctx context.Context, gitoliteHost string) ([]string, error) {
+			return []string{"admin", "alice", "bob"}, nil
+		},
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", testHost, testClient())
+
+	for _, tc := range []struct {
+		username string
+		want     *extsvc.Account
+	}{
+		{
+			username: "alice",
+			want: &extsvc.Account{
+				UserID: 42,
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitolite,
+					ServiceID:   testHost,
+					AccountID:   "alice",
+				},
+			},
+		},
+		{username: "Alice"},
+		{username: "carol"},
+	} {
+		t.Run(tc.username, func(t *testing.T) {
+			have, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: tc.username}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("account mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", testHost, testClient())
+
+	t.Run("nil account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), nil)
+		want := "no account provided"
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitolite,
+				ServiceID:   "git@other.example.com",
+				AccountID:   "alice",
+			},
+		})
+		want := `not a code host of the account: want "git@gitolite.example.com" but have "git@other.example.com"`
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		user string
+		want []extsvc.RepoID
+	}{
+		{user: "alice", want: []extsvc.RepoID{"infra", "tools"}},
+		{user: "bob", want: []extsvc.RepoID{"tools"}},
+		{user: "carol", want: []extsvc.RepoID{}},
+	} {
+		t.Run(tc.user, func(t *testing.T) {
+			have, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeGitolite,
+					ServiceID:   testHost,
+					AccountID:   tc.user,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("repo IDs mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", testHost, testClient())
+
+	t.Run("nil repo", func(t *testing.T) {
+		_, err := p.FetchRepoPerms(context.Background(), nil)
+		want := "no repo provided"
+		if got := fmt.Sprintf("%v", err); got != want {
+			t.Fatalf("err: want %q but got %q", want, got)
+		}
+	})
+
+	for _, tc := range []struct {
+		repoID string
+		want   []extsvc.AccountID
+	}{
+		{repoID: "infra", want: []extsvc.AccountID{"admin", "alice"}},
+		{repoID: "tools", want: []extsvc.AccountID{"admin", "alice", "bob"}},
+		{repoID: "gitolite-admin", want: []extsvc.AccountID{"admin"}},
+	} {
+		t.Run(tc.repoID, func(t *testing.T) {
+			have, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ID:          tc.repoID,
+					ServiceType: extsvc.TypeGitolite,
+					ServiceID:   testHost,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("account IDs mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("partial results", func(t *testing.T) {
+		cli := testClient()
+		listForUser := cli.MockListGitoliteForUser
+		cli.MockListGitoliteForUser = func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+			if user == "bob" {
+				return nil, fmt.Errorf("sudo is not enabled")
+			}
+			return listForUser(ctx, gitoliteHost, user)
+		}
+
+		p := NewProvider("extsvc:gitolite:1", testHost, cli)
+		have, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "tools",
+				ServiceType: extsvc.TypeGitolite,
+				ServiceID:   testHost,
+			},
+		})
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+		if diff := cmp.Diff([]extsvc.AccountID{"admin", "alice"}, have); diff != "" {
+			t.Errorf("account IDs mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("repositories of users are listed once", func(t *testing.T) {
+		cli := testClient()
+		calls := 0
+		listForUser := cli.MockListGitoliteForUser
+		cli.MockListGitoliteForUser = func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+			calls++
+			return listForUser(ctx, gitoliteHost, user)
+		}
+
+		now := time.Now()
+		p := NewProvider("extsvc:gitolite:1", testHost, cli)
+		p.clock = func() time.Time { return now }
+
+		fetch := func(repoID string) {
+			t.Helper()
+			_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ID:          repoID,
+					ServiceType: extsvc.TypeGitolite,
+					ServiceID:   testHost,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		fetch("infra")
+		fetch("tools")
+		if calls != 3 {
+			t.Fatalf("calls: want 3 but got %d", calls)
+		}
+
+		now = now.Add(readersTTL)
+		fetch("tools")
+		if calls != 6 {
+			t.Fatalf("calls: want 6 after the listing expired but got %d", calls)
+		}
+	})
+}
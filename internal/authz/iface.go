@@ -68,3 +68,20 @@ type Provider interface {
 	// problems.
 	Validate() (problems []string)
 }
+
+// SubRepoPermsProvider is implemented by authz providers that also know which paths a user
+// can read in the repositories they can read, such as Perforce whose protections table
+// grants access to individual files and directories of depots.
+type SubRepoPermsProvider interface {
+	// FetchUserSubRepoPerms returns the path-level permissions of the given account in the
+	// repositories it can read, keyed by the same repository IDs FetchUserPerms returns.
+	// Repositories without restrictions on paths are omitted. The UserID and RepoID of the
+	// returned permissions are left unset.
+	FetchUserSubRepoPerms(ctx context.Context, account *extsvc.Account) (map[extsvc.RepoID]*SubRepoPermissions, error)
+
+	// FetchRepoSubRepoPerms returns the path-level permissions of the accounts that can read
+	// the given repository, keyed by the same account IDs FetchRepoPerms returns. Accounts
+	// without restrictions on paths are omitted. The UserID and RepoID of the returned
+	// permissions are left unset.
+	FetchRepoSubRepoPerms(ctx context.Context, repo *extsvc.Repository) (map[extsvc.AccountID]*SubRepoPermissions, error)
+}
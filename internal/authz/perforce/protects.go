@@ -0,0 +1,265 @@
+package perforce
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
+)
+
+// grantsRead are the access levels and rights that grant read access to files when they are
+// granted, see https://www.perforce.com/manuals/p4sag/Content/P4SAG/protections.set.html.
+var grantsRead = map[string]bool{
+	"read":   true,
+	"=read":  true,
+	"open":   true,
+	"write":  true,
+	"review": true,
+	"owner":  true,
+	"admin":  true,
+	"super":  true,
+}
+
+// revokesRead are the access levels and rights that revoke read access to files when they are
+// excluded. Excluding a level revokes it and all the levels above it, so for example excluding
+// "open" still lets users read files.
+var revokesRead = map[string]bool{
+	"list":  true,
+	"read":  true,
+	"=read": true,
+}
+
+// canRead returns true if the given protections, as returned by `p4 protects -u`, grant read
+// access to any file under the given depot path.
+//
+// Lines later in the protections table take precedence over earlier lines, so a depot is
+// readable if a line grants read access to a path in it, and no later exclusion revokes read
+// access to the whole depot. Inclusions restricted to some hosts are ignored, because users
+// don't access Sourcegraph from these hosts, but exclusions apply regardless of their host.
+func canRead(protections []*perforce.Protection, depot string) bool {
+	readable := false
+	for _, p := range protections {
+		pattern := parsePathPattern(p.Path)
+		switch {
+		case p.Exclusion:
+			if revokesRead[p.Level] && pattern.covers(depot) {
+				readable = false
+			}
+		case grantsRead[p.Level] && p.Host == "*":
+			if pattern.overlaps(depot) {
+				readable = true
+			}
+		}
+	}
+	return readable
+}
+
+// subRepoPerms returns the paths of the given depot that the given protections grant read access
+// to, as patterns relative to the depot, or nil if all files of the depot are readable. It must
+// only be called for depots that canRead returns true for.
+//
+// Lines are applied in order like in canRead. Since sub-repository permissions don't have an
+// order, an exclusion is dropped when a later inclusion grants access again to all the paths it
+// could match, and is kept otherwise, which may deny access to more files than Perforce does.
+func subRepoPerms(protections []*perforce.Protection, depot string) *authz.SubRepoPermissions {
+	var includes, excludes []string
+	for _, p := range protections {
+		pattern := parsePathPattern(p.Path)
+		switch {
+		case p.Exclusion:
+			if !revokesRead[p.Level] || !pattern.overlaps(depot) {
+				continue
+			}
+			if pattern.covers(depot) {
+				includes, excludes = nil, nil
+				continue
+			}
+			excludes = appendNew(excludes, pattern.relativeTo(depot)...)
+
+		case grantsRead[p.Level] && p.Host == "*":
+			if !pattern.overlaps(depot) {
+				continue
+			}
+			if pattern.covers(depot) {
+				includes, excludes = []string{"**"}, nil
+				continue
+			}
+			includes = appendNew(includes, pattern.relativeTo(depot)...)
+
+			kept := excludes[:0]
+			for _, e := range excludes {
+				if !pattern.covers(depot + literalPrefix(e)) {
+					kept = append(kept, e)
+				}
+			}
+			excludes = kept
+		}
+	}
+
+	switch {
+	case len(includes) == 0:
+		// The depot is only readable because a line matches the depot path itself, which isn't a
+		// file, so no file is readable.
+		return &authz.SubRepoPermissions{PathExcludes: []string{"**"}}
+	case len(includes) == 1 && includes[0] == "**" && len(excludes) == 0:
+		return nil
+	}
+	return &authz.SubRepoPermissions{PathIncludes: includes, PathExcludes: excludes}
+}
+
+// appendNew appends the elements of vs that aren't in ss yet to ss.
+func appendNew(ss []string, vs ...string) []string {
+	for _, v := range vs {
+		found := false
+		for _, s := range ss {
+			if s == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ss = append(ss, v)
+		}
+	}
+	return ss
+}
+
+// literalPrefix returns the part of the sub-repository permissions pattern before its first
+// wildcard.
+func literalPrefix(pattern string) string {
+	if i := strings.IndexByte(pattern, '*'); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// pathPattern is a depot path pattern of the protections table, in which "..." matches any
+// sequence of characters, and "*" and positional specifiers like "%%1" match any sequence of
+// characters except "/".
+type pathPattern []patternToken
+
+type patternToken struct {
+	// wildcard is "..." or "*", or empty for literal characters.
+	wildcard string
+	char     byte
+}
+
+func parsePathPattern(s string) pathPattern {
+	var p pathPattern
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '.' && i+2 < len(s) && s[i+1] == '.' && s[i+2] == '.':
+			p = append(p, patternToken{wildcard: "..."})
+			i += 2
+		case s[i] == '*':
+			p = append(p, patternToken{wildcard: "*"})
+		case s[i] == '%' && i+2 < len(s) && s[i+1] == '%' && s[i+2] >= '0' && s[i+2] <= '9':
+			p = append(p, patternToken{wildcard: "*"})
+			i += 2
+		default:
+			p = append(p, patternToken{char: s[i]})
+		}
+	}
+	return p
+}
+
+// states returns the positions in the pattern that can be reached after matching the given
+// prefix of a path, as a set indexed by position. Position len(p) is the end of the pattern.
+func (p pathPattern) states(prefix string) []bool {
+	states := make([]bool, len(p)+1)
+	states[0] = true
+	p.skipWildcards(states)
+
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		next := make([]bool, len(p)+1)
+		for j, ok := range states {
+			if !ok || j == len(p) {
+				continue
+			}
+			switch t := p[j]; {
+			case t.wildcard == "...":
+				next[j] = true
+			case t.wildcard == "*":
+				if c != '/' {
+					next[j] = true
+				}
+			case t.char == c:
+				next[j+1] = true
+			}
+		}
+		p.skipWildcards(next)
+		states = next
+	}
+
+	return states
+}
+
+// skipWildcards adds the positions after wildcards to the given set of positions, since
+// wildcards can match empty strings.
+func (p pathPattern) skipWildcards(states []bool) {
+	for j := range p {
+		if states[j] && p[j].wildcard != "" {
+			states[j+1] = true
+		}
+	}
+}
+
+// overlaps returns true if the pattern matches any path that starts with the given prefix.
+func (p pathPattern) overlaps(prefix string) bool {
+	for _, ok := range p.states(prefix) {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true if the pattern matches all paths that start with the given prefix.
+func (p pathPattern) covers(prefix string) bool {
+	for j, ok := range p.states(prefix) {
+		if ok && j < len(p) && p.onlyDotsFrom(j) {
+			return true
+		}
+	}
+	return false
+}
+
+// relativeTo returns the patterns of sub-repository permissions that match the paths this pattern
+// matches in the directory at the given prefix, relative to the directory.
+func (p pathPattern) relativeTo(prefix string) []string {
+	var patterns []string
+	states := p.states(prefix)
+	for j, ok := range states {
+		// The patterns from positions right after reached wildcards are redundant, because
+		// wildcards can match empty strings.
+		if !ok || j == len(p) || j > 0 && states[j-1] && p[j-1].wildcard != "" {
+			continue
+		}
+
+		var b strings.Builder
+		for _, t := range p[j:] {
+			switch t.wildcard {
+			case "...":
+				b.WriteString("**")
+			case "*":
+				b.WriteString("*")
+			default:
+				b.WriteByte(t.char)
+			}
+		}
+		patterns = appendNew(patterns, b.String())
+	}
+	return patterns
+}
+
+// onlyDotsFrom returns true if the pattern from the given position only consists of "..."
+// wildcards.
+func (p pathPattern) onlyDotsFrom(j int) bool {
+	for _, t := range p[j:] {
+		if t.wildcard != "..." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,269 @@
+// Package perforce contains an authorization provider for Perforce.
+package perforce
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the protections table of a Perforce server. Each depot that is mirrored as a
+// repository is readable by the users that can read any file in it.
+type Provider struct {
+	urn      string
+	client   *perforce.Client
+	codeHost *extsvc.CodeHost
+
+	// depots are the depot paths of the repositories of the Perforce server. If empty, all
+	// depots of the server are mirrored.
+	depots []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+var _ authz.SubRepoPermsProvider = (*Provider)(nil)
+
+// NewProvider returns a new Perforce authorization provider that uses the given client to read
+// the protections table of the Perforce server, for the given mirrored depots. It assumes
+// usernames of Sourcegraph accounts match 1-1 with usernames of Perforce users.
+func NewProvider(urn string, cli *perforce.Client, depots []string) *Provider {
+	return &Provider{
+		urn:    urn,
+		client: cli,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   cli.Port,
+			ServiceType: extsvc.TypePerforce,
+		},
+		depots: depots,
+	}
+}
+
+// Validate validates that the Provider can read the protections table of the Perforce server
+// with the credentials it was configured with, which requires super access.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.client.Protects(ctx, p.client.User); err != nil {
+		return []string{err.Error()}
+	}
+
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the address of the Perforce server this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "perforce".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Perforce user whose username is the username of the given user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	users, err := p.client.Users(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing users")
+	}
+
+	var pu *perforce.User
+	for _, u := range users {
+		if strings.EqualFold(u.Username, user.Username) {
+			pu = u
+			break
+		}
+	}
+	if pu == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(pu)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   pu.Username,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of depot paths that the given account can read any file in,
+// according to the protections table. The depot paths have the same value as they would be
+// used as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	protections, err := p.client.Protects(ctx, account.AccountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading protections")
+	}
+
+	depots, err := p.listDepots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []extsvc.RepoID
+	for _, depot := range depots {
+		if canRead(protections, depot) {
+			ids = append(ids, extsvc.RepoID(depot))
+		}
+	}
+
+	return ids, nil
+}
+
+// FetchUserSubRepoPerms returns the files that the given account can read in the depots it can
+// read any file in, according to the protections table. Depots in which all files are readable
+// are omitted.
+func (p *Provider) FetchUserSubRepoPerms(ctx context.Context, account *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	protections, err := p.client.Protects(ctx, account.AccountID)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading protections")
+	}
+
+	depots, err := p.listDepots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := make(map[extsvc.RepoID]*authz.SubRepoPermissions)
+	for _, depot := range depots {
+		if !canRead(protections, depot) {
+			continue
+		}
+		if sp := subRepoPerms(protections, depot); sp != nil {
+			perms[extsvc.RepoID(depot)] = sp
+		}
+	}
+
+	return perms, nil
+}
+
+// FetchRepoPerms returns a list of usernames of the Perforce users who can read any file in
+// the given depot, according to the protections table. The usernames have the same value as
+// they would be used as extsvc.Account.AccountID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	ids, _, err := p.fetchRepoReaders(ctx, repo.ID)
+	return ids, err
+}
+
+// FetchRepoSubRepoPerms returns the files that the Perforce users who can read any file in the
+// given depot can read in it, according to the protections table. Users who can read all files
+// of the depot are omitted.
+func (p *Provider) FetchRepoSubRepoPerms(ctx context.Context, repo *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	_, perms, err := p.fetchRepoReaders(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// fetchRepoReaders returns the usernames of the Perforce users who can read any file in the
+// given depot, and the files they can read in it for those who can't read all of them. It
+// returns the users read so far in case of error.
+func (p *Provider) fetchRepoReaders(ctx context.Context, depot string) ([]extsvc.AccountID, map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+	users, err := p.client.Users(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "listing users")
+	}
+
+	// The protections of users can only be read one user at a time, since they depend on the
+	// groups users are members of.
+	var ids []extsvc.AccountID
+	perms := make(map[extsvc.AccountID]*authz.SubRepoPermissions)
+	for _, u := range users {
+		protections, err := p.client.Protects(ctx, u.Username)
+		if err != nil {
+			return ids, perms, errors.Wrapf(err, "reading protections of user %q", u.Username)
+		}
+		if !canRead(protections, depot) {
+			continue
+		}
+
+		id := extsvc.AccountID(u.Username)
+		ids = append(ids, id)
+		if sp := subRepoPerms(protections, depot); sp != nil {
+			perms[id] = sp
+		}
+	}
+
+	return ids, perms, nil
+}
+
+// listDepots returns the depot paths of the repositories of the Perforce server.
+func (p *Provider) listDepots(ctx context.Context) ([]string, error) {
+	if len(p.depots) > 0 {
+		return p.depots, nil
+	}
+
+	depots, err := p.client.Depots(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing depots")
+	}
+
+	paths := make([]string, 0, len(depots))
+	for _, d := range depots {
+		paths = append(paths, d.Path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,320 @@
+package perforce
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
+)
+
+const testPort = "ssl:perforce.example.com:1666"
+
+// setupTestServer installs a fake p4 for a Perforce server where:
+//
+//   - Everyone can list all depots, and the engine group can read the //Engine/ depot.
+//   - alice is a member of the engine group, but can't read //Engine/Secret/.
+//   - bob can only read //Tools/ from the build host, and //Docs/.
+//   - mallory could read //Engine/, but was excluded from it afterwards.
+func setupTestServer(t *testing.T, cli *perforce.Client) func() {
+	protections := func(lines ...string) string {
+		out := `
+... perm list
+... host *
+... user *
+... line 1
+... depotFile //...
+`
+		for i, l := range lines {
+			out += fmt.Sprintf("\n... line %d\n%s\n", i+2, l)
+		}
+		return out
+	}
+
+	return perforce.SetupFakeP4(t, cli, map[string]string{
+		"-ztag depots": `
+... name Engine
+... type stream
+
+... name Tools
+... type local
+
+... name Docs
+... type local
+
+... name spec
+... type spec
+`,
+		"-ztag users": `
+... User admin
+
+... User alice
+
+... User bob
+
+... User mallory
+`,
+		"-ztag protects -u admin": protections("... perm super\n... host *\n... user admin\n... depotFile //..."),
+		"-ztag protects -u alice": protections(
+			"... perm read\n... host *\n... user engine\n... isgroup\n... depotFile //Engine/...",
+			"... perm read\n... host *\n... user alice\n... unmap\n... depotFile -//Engine/Secret/...",
+		),
+		"-ztag protects -u bob": protections(
+			"... perm write\n... host 10.0.0.1\n... user bob\n... depotFile //Tools/...",
+			"... perm read\n... host *\n... user bob\n... depotFile //Docs/*.md",
+		),
+		"-ztag protects -u mallory": protections(
+			"... perm read\n... host *\n... user engine\n... isgroup\n... depotFile //Engine/...",
+			"... perm list\n... host *\n... user mallory\n... unmap\n... depotFile -//Engine/...",
+		),
+	})
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	cli := perforce.NewClient(testPort, "admin", "secret")
+	defer setupTestServer(t, cli)()
+
+	p := NewProvider("extsvc:perforce:1", cli, nil)
+
+	if problems := p.Validate(); len(problems) > 0 {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+
+	t.Run("FetchAccount", func(t *testing.T) {
+		account, err := p.FetchAccount(ctx, &types.User{ID: 1, Username: "Alice"}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := extsvc.AccountSpec{ServiceType: extsvc.TypePerforce, ServiceID: testPort, AccountID: "alice"}
+		if account == nil || account.UserID != 1 || account.AccountSpec != want {
+			t.Errorf("got account %+v, want %+v", account, want)
+		}
+
+		account, err = p.FetchAccount(ctx, &types.User{ID: 2, Username: "eve"}, nil)
+		if err != nil || account != nil {
+			t.Errorf("got account %+v and error %v, want none", account, err)
+		}
+	})
+
+	t.Run("FetchUserPerms", func(t *testing.T) {
+		for _, tc := range []struct {
+			user string
+			want []extsvc.RepoID
+		}{
+			{user: "admin", want: []extsvc.RepoID{"//Engine/", "//Tools/", "//Docs/"}},
+			{user: "alice", want: []extsvc.RepoID{"//Engine/"}},
+			{user: "bob", want: []extsvc.RepoID{"//Docs/"}},
+			{user: "mallory", want: nil},
+		} {
+			account := &extsvc.Account{AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypePerforce,
+				ServiceID:   testPort,
+				AccountID:   tc.user,
+			}}
+			ids, err := p.FetchUserPerms(ctx, account)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, ids); diff != "" {
+				t.Errorf("%s: repo IDs mismatch (-want +got):\n%s", tc.user, diff)
+			}
+		}
+
+		// Only the configured depots are returned.
+		p := NewProvider("extsvc:perforce:1", cli, []string{"//Engine/Secret/", "//Tools/"})
+		ids, err := p.FetchUserPerms(ctx, &extsvc.Account{AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypePerforce,
+			ServiceID:   testPort,
+			AccountID:   "alice",
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 0 {
+			t.Errorf("got repo IDs %v, want none", ids)
+		}
+	})
+
+	t.Run("FetchUserSubRepoPerms", func(t *testing.T) {
+		for _, tc := range []struct {
+			user string
+			want map[extsvc.RepoID]*authz.SubRepoPermissions
+		}{
+			{user: "admin", want: map[extsvc.RepoID]*authz.SubRepoPermissions{}},
+			{user: "alice", want: map[extsvc.RepoID]*authz.SubRepoPermissions{
+				"//Engine/": {PathIncludes: []string{"**"}, PathExcludes: []string{"Secret/**"}},
+			}},
+			{user: "bob", want: map[extsvc.RepoID]*authz.SubRepoPermissions{
+				"//Docs/": {PathIncludes: []string{"*.md"}},
+			}},
+			{user: "mallory", want: map[extsvc.RepoID]*authz.SubRepoPermissions{}},
+		} {
+			account := &extsvc.Account{AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypePerforce,
+				ServiceID:   testPort,
+				AccountID:   tc.user,
+			}}
+			perms, err := p.FetchUserSubRepoPerms(ctx, account)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, perms); diff != "" {
+				t.Errorf("%s: sub-repo permissions mismatch (-want +got):\n%s", tc.user, diff)
+			}
+		}
+	})
+
+	t.Run("FetchRepoPerms", func(t *testing.T) {
+		ids, err := p.FetchRepoPerms(ctx, &extsvc.Repository{
+			URI: "Engine",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "//Engine/",
+				ServiceType: extsvc.TypePerforce,
+				ServiceID:   testPort,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]extsvc.AccountID{"admin", "alice"}, ids); diff != "" {
+			t.Errorf("account IDs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("FetchRepoSubRepoPerms", func(t *testing.T) {
+		for _, tc := range []struct {
+			depot string
+			want  map[extsvc.AccountID]*authz.SubRepoPermissions
+		}{
+			{depot: "//Engine/", want: map[extsvc.AccountID]*authz.SubRepoPermissions{
+				"alice": {PathIncludes: []string{"**"}, PathExcludes: []string{"Secret/**"}},
+			}},
+			{depot: "//Docs/", want: map[extsvc.AccountID]*authz.SubRepoPermissions{
+				"bob": {PathIncludes: []string{"*.md"}},
+			}},
+			{depot: "//Tools/", want: map[extsvc.AccountID]*authz.SubRepoPermissions{}},
+		} {
+			perms, err := p.FetchRepoSubRepoPerms(ctx, &extsvc.Repository{
+				ExternalRepoSpec: api.ExternalRepoSpec{
+					ID:          tc.depot,
+					ServiceType: extsvc.TypePerforce,
+					ServiceID:   testPort,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, perms); diff != "" {
+				t.Errorf("%s: sub-repo permissions mismatch (-want +got):\n%s", tc.depot, diff)
+			}
+		}
+	})
+}
+
+func TestPathPattern(t *testing.T) {
+	for _, tc := range []struct {
+		pattern  string
+		prefix   string
+		overlaps bool
+		covers   bool
+	}{
+		{pattern: "//...", prefix: "//Engine/", overlaps: true, covers: true},
+		{pattern: "//Engine/...", prefix: "//Engine/", overlaps: true, covers: true},
+		{pattern: "//Engine/...", prefix: "//Engine/Cloud/", overlaps: true, covers: true},
+		{pattern: "//Engine/...", prefix: "//Tools/", overlaps: false, covers: false},
+		{pattern: "//Engine/Secret/...", prefix: "//Engine/", overlaps: true, covers: false},
+		{pattern: "//Engine/*", prefix: "//Engine/", overlaps: true, covers: false},
+		{pattern: "//Engine/*", prefix: "//Engine/Cloud/", overlaps: false, covers: false},
+		{pattern: "//*/...", prefix: "//Engine/", overlaps: true, covers: true},
+		{pattern: "//Eng*/...", prefix: "//Engine/Cloud/", overlaps: true, covers: true},
+		{pattern: "//Engine/.../*.h", prefix: "//Engine/", overlaps: true, covers: false},
+		{pattern: "//Engine/%%1/...", prefix: "//Engine/Cloud/", overlaps: true, covers: true},
+		{pattern: "//Engine/%%1/...", prefix: "//Engine/", overlaps: true, covers: false},
+		{pattern: "//Engine/README", prefix: "//Engine/", overlaps: true, covers: false},
+	} {
+		p := parsePathPattern(tc.pattern)
+		if got := p.overlaps(tc.prefix); got != tc.overlaps {
+			t.Errorf("pattern %q overlaps %q: got %v, want %v", tc.pattern, tc.prefix, got, tc.overlaps)
+		}
+		if got := p.covers(tc.prefix); got != tc.covers {
+			t.Errorf("pattern %q covers %q: got %v, want %v", tc.pattern, tc.prefix, got, tc.covers)
+		}
+	}
+}
+
+func TestSubRepoPerms(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		protections []*perforce.Protection
+		want        *authz.SubRepoPermissions
+	}{
+		{
+			name: "whole depot",
+			protections: []*perforce.Protection{
+				{Level: "read", Host: "*", Path: "//Engine/..."},
+			},
+			want: nil,
+		},
+		{
+			name: "directories and files",
+			protections: []*perforce.Protection{
+				{Level: "read", Host: "*", Path: "//Engine/Cloud/..."},
+				{Level: "write", Host: "*", Path: "//Engine/*.md"},
+				{Level: "read", Host: "*", Path: "//Engine/Tools/..."},
+				{Level: "read", Host: "10.0.0.1", Path: "//Engine/Build/..."},
+				{Level: "read", Host: "*", Path: "//Tools/..."},
+			},
+			want: &authz.SubRepoPermissions{PathIncludes: []string{"Cloud/**", "*.md", "Tools/**"}},
+		},
+		{
+			name: "exclusions",
+			protections: []*perforce.Protection{
+				{Level: "read", Host: "*", Path: "//Engine/..."},
+				{Level: "read", Host: "*", Path: "//Engine/Secret/...", Exclusion: true},
+				{Level: "open", Host: "*", Path: "//Engine/Keys/...", Exclusion: true},
+				{Level: "list", Host: "*", Path: "//.../*.key", Exclusion: true},
+			},
+			want: &authz.SubRepoPermissions{
+				PathIncludes: []string{"**"},
+				PathExcludes: []string{"Secret/**", "**/*.key", "*.key"},
+			},
+		},
+		{
+			name: "exclusion lifted by later inclusion",
+			protections: []*perforce.Protection{
+				{Level: "read", Host: "*", Path: "//Engine/..."},
+				{Level: "read", Host: "*", Path: "//Engine/Secret/...", Exclusion: true},
+				{Level: "read", Host: "*", Path: "//Engine/Plans/*.md", Exclusion: true},
+				{Level: "read", Host: "*", Path: "//Engine/Secret/..."},
+				{Level: "read", Host: "*", Path: "//Engine/Plans/2020.md"},
+			},
+			want: &authz.SubRepoPermissions{
+				PathIncludes: []string{"**", "Secret/**", "Plans/2020.md"},
+				PathExcludes: []string{"Plans/*.md"},
+			},
+		},
+		{
+			name: "exclusion of whole depot",
+			protections: []*perforce.Protection{
+				{Level: "read", Host: "*", Path: "//Engine/Secret/..."},
+				{Level: "read", Host: "*", Path: "//Engine/...", Exclusion: true},
+				{Level: "read", Host: "*", Path: "//Engine/Cloud/..."},
+			},
+			want: &authz.SubRepoPermissions{PathIncludes: []string{"Cloud/**"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := subRepoPerms(tc.protections, "//Engine/")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("sub-repo permissions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package authz
+
+import (
+	"strings"
+	"time"
+)
+
+// SubRepoPermissions are the path-level permissions a user has in a repository, on top
+// of being able to read the repository. A path is readable if it matches any of the
+// included patterns, or there are none, and it matches none of the excluded patterns.
+//
+// Patterns are relative to the root of the repository and are matched against whole
+// paths, where "*" matches any sequence of characters except "/", and "**" matches any
+// sequence of characters. For example, "src/**" matches all files in the src directory,
+// and "**/*.key" matches all files with the .key extension.
+//
+// A nil *SubRepoPermissions grants read access to all paths.
+type SubRepoPermissions struct {
+	UserID       int32
+	RepoID       int32
+	PathIncludes []string
+	PathExcludes []string
+	UpdatedAt    time.Time
+}
+
+// CanReadFile returns true if the file at the given path is readable.
+func (p *SubRepoPermissions) CanReadFile(path string) bool {
+	if p == nil {
+		return true
+	}
+
+	path = strings.TrimPrefix(path, "/")
+	included := len(p.PathIncludes) == 0
+	for _, pattern := range p.PathIncludes {
+		if parseGlob(pattern).matches(path) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, pattern := range p.PathExcludes {
+		if parseGlob(pattern).matches(path) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanReadDir returns true if the directory at the given path is readable, i.e. if it
+// may contain readable files. The root directory is the empty path.
+func (p *SubRepoPermissions) CanReadDir(dir string) bool {
+	if p == nil {
+		return true
+	}
+
+	prefix := strings.Trim(dir, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	included := len(p.PathIncludes) == 0
+	for _, pattern := range p.PathIncludes {
+		if parseGlob(pattern).overlaps(prefix) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, pattern := range p.PathExcludes {
+		if parseGlob(pattern).covers(prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns the patterns of the permissions that are invalid, because they are
+// absolute or empty.
+func (p *SubRepoPermissions) Validate() []string {
+	var invalid []string
+	for _, patterns := range [][]string{p.PathIncludes, p.PathExcludes} {
+		for _, pattern := range patterns {
+			if pattern == "" || strings.HasPrefix(pattern, "/") {
+				invalid = append(invalid, pattern)
+			}
+		}
+	}
+	return invalid
+}
+
+// glob is a parsed path pattern of sub-repository permissions.
+type glob []globToken
+
+type globToken struct {
+	// wildcard is "**" or "*", or empty for literal characters.
+	wildcard string
+	char     byte
+}
+
+func parseGlob(s string) glob {
+	var g glob
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '*' && i+1 < len(s) && s[i+1] == '*':
+			g = append(g, globToken{wildcard: "**"})
+			i++
+		case s[i] == '*':
+			g = append(g, globToken{wildcard: "*"})
+		default:
+			g = append(g, globToken{char: s[i]})
+		}
+	}
+	return g
+}
+
+// states returns the positions in the pattern that can be reached after matching the
+// given prefix of a path, as a set indexed by position. Position len(g) is the end of
+// the pattern.
+func (g glob) states(prefix string) []bool {
+	states := make([]bool, len(g)+1)
+	states[0] = true
+	g.skipWildcards(states)
+
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		next := make([]bool, len(g)+1)
+		for j, ok := range states {
+			if !ok || j == len(g) {
+				continue
+			}
+			switch t := g[j]; {
+			case t.wildcard == "**":
+				next[j] = true
+			case t.wildcard == "*":
+				if c != '/' {
+					next[j] = true
+				}
+			case t.char == c:
+				next[j+1] = true
+			}
+		}
+		g.skipWildcards(next)
+		states = next
+	}
+
+	return states
+}
+
+// skipWildcards adds the positions after wildcards to the given set of positions, since
+// wildcards can match empty strings.
+func (g glob) skipWildcards(states []bool) {
+	for j := range g {
+		if states[j] && g[j].wildcard != "" {
+			states[j+1] = true
+		}
+	}
+}
+
+// matches returns true if the pattern matches the given path.
+func (g glob) matches(path string) bool {
+	return g.states(path)[len(g)]
+}
+
+// overlaps returns true if the pattern matches any path that starts with the given
+// prefix.
+func (g glob) overlaps(prefix string) bool {
+	for _, ok := range g.states(prefix) {
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// covers returns true if the pattern matches all paths that start with the given prefix.
+func (g glob) covers(prefix string) bool {
+	for j, ok := range g.states(prefix) {
+		if ok && j < len(g) && g.onlyWildcardsFrom(j) {
+			return true
+		}
+	}
+	return false
+}
+
+// onlyWildcardsFrom returns true if the pattern from the given position only consists of
+// "**" wildcards.
+func (g glob) onlyWildcardsFrom(j int) bool {
+	for _, t := range g[j:] {
+		if t.wildcard != "**" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSubRepoPermissions(t *testing.T) {
+	perms := &SubRepoPermissions{
+		PathIncludes: []string{"src/**", "README.md", "docs/*.md"},
+		PathExcludes: []string{"src/secret/**", "**/*.key"},
+	}
+
+	for _, tc := range []struct {
+		path string
+		file bool
+		dir  bool
+	}{
+		{path: "", file: false, dir: true},
+		{path: "src", file: false, dir: true},
+		{path: "src/main.go", file: true, dir: true},
+		{path: "/src/main.go", file: true, dir: true},
+		{path: "src/server/server.go", file: true, dir: true},
+		{path: "src/server/tls.key", file: false, dir: true},
+		{path: "src/secret", file: true, dir: false},
+		{path: "src/secret/token", file: false, dir: false},
+		{path: "README.md", file: true, dir: false},
+		{path: "docs", file: false, dir: true},
+		{path: "docs/index.md", file: true, dir: false},
+		{path: "docs/api/index.md", file: false, dir: false},
+		{path: "docs/api", file: false, dir: false},
+		{path: "vendor/lib.go", file: false, dir: false},
+	} {
+		if got := perms.CanReadFile(tc.path); got != tc.file {
+			t.Errorf("CanReadFile(%q): got %v, want %v", tc.path, got, tc.file)
+		}
+		if got := perms.CanReadDir(tc.path); got != tc.dir {
+			t.Errorf("CanReadDir(%q): got %v, want %v", tc.path, got, tc.dir)
+		}
+	}
+
+	t.Run("no includes", func(t *testing.T) {
+		perms := &SubRepoPermissions{PathExcludes: []string{"internal/**"}}
+		if !perms.CanReadFile("main.go") || !perms.CanReadDir("cmd") {
+			t.Error("want paths outside of exclusions to be readable")
+		}
+		if perms.CanReadFile("internal/db/db.go") || perms.CanReadDir("internal") {
+			t.Error("want excluded paths to be unreadable")
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var perms *SubRepoPermissions
+		if !perms.CanReadFile("src/secret/token") || !perms.CanReadDir("src/secret") {
+			t.Error("want all paths to be readable")
+		}
+	})
+}
+
+func TestSubRepoPermissions_Validate(t *testing.T) {
+	perms := &SubRepoPermissions{
+		PathIncludes: []string{"src/**", ""},
+		PathExcludes: []string{"/etc/**", "**/*.key"},
+	}
+	if diff := cmp.Diff([]string{"", "/etc/**"}, perms.Validate()); diff != "" {
+		t.Errorf("invalid patterns mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,6 +1,8 @@
 package campaigns
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/campaignutils/overridable"
@@ -38,7 +40,20 @@ func (cs *CampaignSpec) Clone() *CampaignSpec {
 // UnmarshalValidate unmarshals the RawSpec into Spec and validates it against
 // the CampaignSpec schema and does additional semantic validation.
 func (cs *CampaignSpec) UnmarshalValidate() error {
-	return unmarshalValidate(schema.CampaignSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
+	err := unmarshalValidate(schema.CampaignSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
+	if err != nil {
+		return err
+	}
+
+	if s := cs.Spec.PublicationSchedule; s != nil {
+		for i, w := range s.Windows {
+			if w.End <= w.Start {
+				return fmt.Errorf("publicationSchedule.windows.%d: end must be after start", i)
+			}
+		}
+	}
+
+	return nil
 }
 
 // CampaignSpecTTL specifies the TTL of CampaignSpecs that haven't been applied
@@ -58,6 +73,8 @@ type CampaignSpecFields struct {
 	Steps             []CampaignSpecStep        `json:"steps"`
 	ImportChangeset   []CampaignImportChangeset `json:"importChangesets,omitempty"`
 	ChangesetTemplate ChangesetTemplate         `json:"changesetTemplate"`
+
+	PublicationSchedule *PublicationSchedule `json:"publicationSchedule,omitempty"`
 }
 
 type CampaignSpecOn struct {
@@ -82,6 +99,129 @@ type ChangesetTemplate struct {
 	Branch    string           `json:"branch"`
 	Commit    CommitTemplate   `json:"commit"`
 	Published overridable.Bool `json:"published"`
+
+	AutoMerge *ChangesetAutoMerge `json:"autoMerge,omitempty"`
+}
+
+// ChangesetAutoMerge is the policy that decides whether the published
+// changesets of a campaign are merged on the code host.
+type ChangesetAutoMerge struct {
+	Method            ChangesetMergeMethod    `json:"method,omitempty"`
+	RequiredApprovals int                     `json:"requiredApprovals,omitempty"`
+	RequiredChecks    ChangesetRequiredChecks `json:"requiredChecks,omitempty"`
+}
+
+// MergeMethod returns the method with which changesets should be merged,
+// defaulting to ChangesetMergeMethodMerge.
+func (p *ChangesetAutoMerge) MergeMethod() ChangesetMergeMethod {
+	if p.Method == "" {
+		return ChangesetMergeMethodMerge
+	}
+	return p.Method
+}
+
+// ChecksRequired returns whether the checks of a changeset need to have
+// passed before it is merged.
+func (p *ChangesetAutoMerge) ChecksRequired() bool {
+	return p.RequiredChecks != ChangesetRequiredChecksNone
+}
+
+// ChangesetMergeMethod defines the possible ways to merge a changeset.
+type ChangesetMergeMethod string
+
+// ChangesetMergeMethod constants.
+const (
+	ChangesetMergeMethodMerge  ChangesetMergeMethod = "merge"
+	ChangesetMergeMethodSquash ChangesetMergeMethod = "squash"
+	ChangesetMergeMethodRebase ChangesetMergeMethod = "rebase"
+)
+
+// ChangesetRequiredChecks defines which checks need to pass before a
+// changeset is merged.
+type ChangesetRequiredChecks string
+
+// ChangesetRequiredChecks constants.
+const (
+	ChangesetRequiredChecksPassed ChangesetRequiredChecks = "passed"
+	ChangesetRequiredChecksNone   ChangesetRequiredChecks = "none"
+)
+
+// PublicationSchedule limits how fast the changesets of a campaign are
+// published on the code host.
+type PublicationSchedule struct {
+	MaxOpen int                 `json:"maxOpen,omitempty"`
+	PerHour int                 `json:"perHour,omitempty"`
+	Windows []PublicationWindow `json:"windows,omitempty"`
+}
+
+// Interval returns the minimum duration between two publications, or 0 if
+// the number of publications per hour is not limited.
+func (s *PublicationSchedule) Interval() time.Duration {
+	if s.PerHour <= 0 {
+		return 0
+	}
+	return time.Hour / time.Duration(s.PerHour)
+}
+
+// NextOpenAt returns the earliest time at or after t at which one of the
+// publication windows is open. If there are no windows, it returns t.
+func (s *PublicationSchedule) NextOpenAt(t time.Time) time.Time {
+	if len(s.Windows) == 0 {
+		return t
+	}
+
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	var next time.Time
+	// Every window is open at least once a week, so we only need to look at
+	// the next 8 days to find the earliest one.
+	for i := 0; i <= 7; i++ {
+		day := midnight.AddDate(0, 0, i)
+		for _, w := range s.Windows {
+			if !w.OpenOn(day.Weekday()) {
+				continue
+			}
+
+			start := day.Add(time.Duration(w.Start) * time.Hour)
+			end := day.Add(time.Duration(w.End) * time.Hour)
+			if !end.After(t) {
+				continue
+			}
+			if start.Before(t) {
+				start = t
+			}
+			if next.IsZero() || start.Before(next) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+
+	return t
+}
+
+// PublicationWindow is a time span, given as hours of the day in UTC, in
+// which changesets may be published.
+type PublicationWindow struct {
+	Days  []string `json:"days,omitempty"`
+	Start int      `json:"start"`
+	End   int      `json:"end"`
+}
+
+// OpenOn returns whether the window is open on the given day of the week.
+func (w PublicationWindow) OpenOn(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if strings.EqualFold(day, d.String()) {
+			return true
+		}
+	}
+	return false
 }
 
 type CommitTemplate struct {
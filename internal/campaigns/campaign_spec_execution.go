@@ -0,0 +1,79 @@
+package campaigns
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// CampaignSpecExecutionState defines the possible states of a CampaignSpecExecution.
+type CampaignSpecExecutionState string
+
+// CampaignSpecExecutionState constants.
+const (
+	CampaignSpecExecutionStateQueued     CampaignSpecExecutionState = "QUEUED"
+	CampaignSpecExecutionStateProcessing CampaignSpecExecutionState = "PROCESSING"
+	CampaignSpecExecutionStateErrored    CampaignSpecExecutionState = "ERRORED"
+	CampaignSpecExecutionStateCompleted  CampaignSpecExecutionState = "COMPLETED"
+)
+
+// Valid returns true if the given CampaignSpecExecutionState is valid.
+func (s CampaignSpecExecutionState) Valid() bool {
+	switch s {
+	case CampaignSpecExecutionStateQueued,
+		CampaignSpecExecutionStateProcessing,
+		CampaignSpecExecutionStateErrored,
+		CampaignSpecExecutionStateCompleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToDB returns the database representation of the execution state. See
+// ReconcilerState.ToDB for why this is needed.
+func (s CampaignSpecExecutionState) ToDB() string { return strings.ToLower(string(s)) }
+
+// CampaignSpecExecution is the server-side execution of the steps of a
+// CampaignSpec in a single repository. A successful execution that produces
+// a non-empty diff results in a ChangesetSpec attached to the CampaignSpec.
+type CampaignSpecExecution struct {
+	ID int64
+
+	CampaignSpecID int64
+	RepoID         api.RepoID
+	RepoName       api.RepoName
+
+	// BaseRef and BaseRev are the default branch of the repository and the
+	// commit it pointed to when the execution was enqueued.
+	BaseRef string
+	BaseRev string
+
+	// ChangesetSpecID is 0 until the execution completed with a non-empty diff.
+	ChangesetSpecID int64
+
+	// Log contains the commands run by the executor and their output.
+	Log string
+
+	// All of the following fields are used by workerutil.Worker.
+	State          CampaignSpecExecutionState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+	NumFailures    int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (e *CampaignSpecExecution) RecordID() int { return int(e.ID) }
+
+// Clone returns a clone of a CampaignSpecExecution.
+func (e *CampaignSpecExecution) Clone() *CampaignSpecExecution {
+	ee := *e
+	return &ee
+}
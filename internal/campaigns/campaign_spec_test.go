@@ -3,6 +3,7 @@ package campaigns
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -94,6 +95,26 @@ changesetTemplate:
 			}`,
 			err: "1 error occurred:\n\t* name: Does not match pattern '^[\\w.-]+$'\n\n",
 		},
+		{
+			name: "publication window ends before it starts",
+			rawSpec: `
+name: my-unique-name
+changesetTemplate:
+  title: Hello World
+  body: My first campaign!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: true
+publicationSchedule:
+  perHour: 10
+  windows:
+  - days: [monday]
+    start: 17
+    end: 9
+`,
+			err: "publicationSchedule.windows.0: end must be after start",
+		},
 	}
 
 	for _, tc := range tests {
@@ -109,3 +130,68 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestPublicationScheduleNextOpenAt(t *testing.T) {
+	// 2020-10-05 is a Monday.
+	monday := time.Date(2020, 10, 5, 0, 0, 0, 0, time.UTC)
+
+	schedule := &PublicationSchedule{
+		Windows: []PublicationWindow{
+			{Days: []string{"monday", "wednesday"}, Start: 9, End: 12},
+			{Days: []string{"monday"}, Start: 14, End: 17},
+			{Days: []string{"saturday"}, Start: 22, End: 24},
+		},
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "before window",
+			now:  monday.Add(7 * time.Hour),
+			want: monday.Add(9 * time.Hour),
+		},
+		{
+			name: "in window",
+			now:  monday.Add(10*time.Hour + 30*time.Minute),
+			want: monday.Add(10*time.Hour + 30*time.Minute),
+		},
+		{
+			name: "between windows",
+			now:  monday.Add(12 * time.Hour),
+			want: monday.Add(14 * time.Hour),
+		},
+		{
+			name: "after last window of the day",
+			now:  monday.Add(17 * time.Hour),
+			want: monday.AddDate(0, 0, 2).Add(9 * time.Hour),
+		},
+		{
+			name: "until midnight",
+			now:  monday.AddDate(0, 0, 5).Add(23 * time.Hour),
+			want: monday.AddDate(0, 0, 5).Add(23 * time.Hour),
+		},
+		{
+			name: "next week",
+			now:  monday.AddDate(0, 0, 6),
+			want: monday.AddDate(0, 0, 7).Add(9 * time.Hour),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := schedule.NextOpenAt(tc.now); !have.Equal(tc.want) {
+				t.Fatalf("wrong time. want=%s, have=%s", tc.want, have)
+			}
+		})
+	}
+
+	t.Run("no windows", func(t *testing.T) {
+		now := monday.Add(3 * time.Hour)
+		if have := (&PublicationSchedule{}).NextOpenAt(now); !have.Equal(now) {
+			t.Fatalf("wrong time. want=%s, have=%s", now, have)
+		}
+	})
+}
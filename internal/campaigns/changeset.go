@@ -9,7 +9,11 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -52,6 +56,11 @@ const (
 	ReconcilerStateProcessing ReconcilerState = "PROCESSING"
 	ReconcilerStateErrored    ReconcilerState = "ERRORED"
 	ReconcilerStateCompleted  ReconcilerState = "COMPLETED"
+
+	// ReconcilerStateScheduled is never stored in the database. It's the
+	// state of queued changesets that are held back by the publication
+	// schedule of their campaign until their publication slot.
+	ReconcilerStateScheduled ReconcilerState = "SCHEDULED"
 )
 
 // Valid returns true if the given ReconcilerState is valid.
@@ -60,7 +69,8 @@ func (s ReconcilerState) Valid() bool {
 	case ReconcilerStateQueued,
 		ReconcilerStateProcessing,
 		ReconcilerStateErrored,
-		ReconcilerStateCompleted:
+		ReconcilerStateCompleted,
+		ReconcilerStateScheduled:
 		return true
 	default:
 		return false
@@ -202,6 +212,16 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// Merging is set to true (along with the ReconcilerState) when the
+	// reconciler should apply the auto-merge policy of the owning campaign
+	// to the changeset.
+	Merging bool
+
+	// PublicationSlot is the time at which the changeset was published or is
+	// scheduled to be published according to the publication schedule of the
+	// campaign that owns it. It's zero if the campaign has no schedule.
+	PublicationSlot time.Time
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -279,6 +299,30 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeGitLab
 		c.ExternalBranch = pr.SourceBranch
 		c.ExternalUpdatedAt = pr.UpdatedAt.Time
+	case *gerrit.Change:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.Number)
+		c.ExternalServiceType = extsvc.TypeGerrit
+		c.ExternalBranch = pr.Topic
+		c.ExternalUpdatedAt = pr.Updated.Time
+	case *azuredevops.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.PullRequestID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = git.AbbreviateRef(pr.SourceRefName)
+		c.ExternalUpdatedAt = pr.UpdatedAt()
+	case *bitbucketcloud.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.FormatInt(pr.ID, 10)
+		c.ExternalServiceType = extsvc.TypeBitbucketCloud
+		c.ExternalBranch = pr.Source.Branch.Name
+		c.ExternalUpdatedAt = pr.UpdatedOn
+	case *awscodecommit.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = pr.ID
+		c.ExternalServiceType = extsvc.TypeAWSCodeCommit
+		c.ExternalBranch = git.AbbreviateRef(pr.SourceReference)
+		c.ExternalUpdatedAt = pr.LastActivityDate
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -304,6 +348,14 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *gitlab.MergeRequest:
 		return m.Title, nil
+	case *gerrit.Change:
+		return m.Subject, nil
+	case *azuredevops.PullRequest:
+		return m.Title, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Title, nil
+	case *awscodecommit.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -320,6 +372,14 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return unixMilliToTime(int64(m.CreatedDate))
 	case *gitlab.MergeRequest:
 		return m.CreatedAt.Time
+	case *gerrit.Change:
+		return m.Created.Time
+	case *azuredevops.PullRequest:
+		return m.CreationDate
+	case *bitbucketcloud.PullRequest:
+		return m.CreatedOn
+	case *awscodecommit.PullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -334,6 +394,14 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *gitlab.MergeRequest:
 		return m.Description, nil
+	case *gerrit.Change:
+		return m.Body(), nil
+	case *azuredevops.PullRequest:
+		return m.Description, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Description, nil
+	case *awscodecommit.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -378,6 +446,52 @@ func (c *Changeset) externalState() (s ChangesetExternalState, err error) {
 		default:
 			return "", errors.Errorf("unknown merge request state: %s", m.State)
 		}
+	case *gerrit.Change:
+		switch m.Status {
+		case gerrit.ChangeStatusNew:
+			s = ChangesetExternalStateOpen
+		case gerrit.ChangeStatusAbandoned:
+			s = ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = ChangesetExternalStateMerged
+		default:
+			return "", errors.Errorf("unknown change status: %s", m.Status)
+		}
+	case *azuredevops.PullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusActive:
+			s = ChangesetExternalStateOpen
+		case azuredevops.PullRequestStatusAbandoned:
+			s = ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = ChangesetExternalStateMerged
+		default:
+			return "", errors.Errorf("unknown pull request status: %s", m.Status)
+		}
+	case *bitbucketcloud.PullRequest:
+		switch m.State {
+		case bitbucketcloud.PullRequestStateOpen:
+			s = ChangesetExternalStateOpen
+		case bitbucketcloud.PullRequestStateDeclined, bitbucketcloud.PullRequestStateSuperseded:
+			s = ChangesetExternalStateClosed
+		case bitbucketcloud.PullRequestStateMerged:
+			s = ChangesetExternalStateMerged
+		default:
+			return "", errors.Errorf("unknown pull request state: %s", m.State)
+		}
+	case *awscodecommit.PullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusOpen:
+			s = ChangesetExternalStateOpen
+		case awscodecommit.PullRequestStatusClosed:
+			if m.IsMerged {
+				s = ChangesetExternalStateMerged
+			} else {
+				s = ChangesetExternalStateClosed
+			}
+		default:
+			return "", errors.Errorf("unknown pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -402,6 +516,14 @@ func (c *Changeset) URL() (s string, err error) {
 		return selfLink.Href, nil
 	case *gitlab.MergeRequest:
 		return m.WebURL, nil
+	case *gerrit.Change:
+		return m.WebURL, nil
+	case *azuredevops.PullRequest:
+		return m.WebURL, nil
+	case *bitbucketcloud.PullRequest:
+		return m.Links.HTML.Href, nil
+	case *awscodecommit.PullRequest:
+		return m.WebURL, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -484,6 +606,68 @@ func (c *Changeset) Events() (events []*ChangesetEvent) {
 				Metadata:    pipeline,
 			})
 		}
+
+	case *gerrit.Change:
+		votes := m.Votes()
+		events = make([]*ChangesetEvent, 0, len(votes))
+		for _, v := range votes {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         v.Key(),
+				Kind:        ChangesetEventKindFor(v),
+				Metadata:    v,
+			})
+		}
+
+	case *azuredevops.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Votes)+len(m.Statuses))
+		addEvent := func(e Keyer) {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        ChangesetEventKindFor(e),
+				Metadata:    e,
+			})
+		}
+		for _, v := range m.Votes {
+			addEvent(v)
+		}
+		for _, s := range m.Statuses {
+			addEvent(s)
+		}
+
+	case *bitbucketcloud.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.Participants)+len(m.Statuses))
+		addEvent := func(e Keyer) {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        ChangesetEventKindFor(e),
+				Metadata:    e,
+			})
+		}
+		for _, p := range m.Participants {
+			// Participants that only commented on the pull request are not
+			// reviews.
+			if p.Role != bitbucketcloud.ParticipantRoleReviewer && p.State == "" {
+				continue
+			}
+			addEvent(p)
+		}
+		for _, s := range m.Statuses {
+			addEvent(s)
+		}
+
+	case *awscodecommit.PullRequest:
+		events = make([]*ChangesetEvent, 0, len(m.ApprovalEvents))
+		for _, e := range m.ApprovalEvents {
+			events = append(events, &ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         e.Key(),
+				Kind:        ChangesetEventKindFor(e),
+				Metadata:    e,
+			})
+		}
 	}
 	return events
 }
@@ -499,6 +683,21 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.HeadSHA, nil
+	case *gerrit.Change:
+		return m.CurrentRevision, nil
+	case *azuredevops.PullRequest:
+		if m.LastMergeSourceCommit != nil {
+			return m.LastMergeSourceCommit.CommitID, nil
+		}
+		return "", nil
+	case *bitbucketcloud.PullRequest:
+		// Bitbucket Cloud only includes abbreviated commit hashes.
+		if m.Source.Commit != nil {
+			return m.Source.Commit.Hash, nil
+		}
+		return "", nil
+	case *awscodecommit.PullRequest:
+		return m.SourceCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -514,6 +713,19 @@ func (c *Changeset) HeadRef() (string, error) {
 		return m.FromRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.SourceBranch, nil
+	case *gerrit.Change:
+		// Changes have no head branch, only the patch set refs below
+		// refs/changes.
+		if rev := m.Current(); rev != nil {
+			return rev.Ref, nil
+		}
+		return "", nil
+	case *azuredevops.PullRequest:
+		return m.SourceRefName, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *awscodecommit.PullRequest:
+		return git.EnsureRefPrefix(m.SourceReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -530,6 +742,23 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return "", nil
 	case *gitlab.MergeRequest:
 		return m.DiffRefs.BaseSHA, nil
+	case *gerrit.Change:
+		if rev := m.Current(); rev != nil && rev.Commit != nil && len(rev.Commit.Parents) > 0 {
+			return rev.Commit.Parents[0].Commit, nil
+		}
+		return "", nil
+	case *azuredevops.PullRequest:
+		if m.LastMergeTargetCommit != nil {
+			return m.LastMergeTargetCommit.CommitID, nil
+		}
+		return "", nil
+	case *bitbucketcloud.PullRequest:
+		if m.Destination.Commit != nil {
+			return m.Destination.Commit.Hash, nil
+		}
+		return "", nil
+	case *awscodecommit.PullRequest:
+		return m.DestinationCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -545,6 +774,14 @@ func (c *Changeset) BaseRef() (string, error) {
 		return m.ToRef.ID, nil
 	case *gitlab.MergeRequest:
 		return "refs/heads/" + m.TargetBranch, nil
+	case *gerrit.Change:
+		return "refs/heads/" + m.Branch, nil
+	case *azuredevops.PullRequest:
+		return m.TargetRefName, nil
+	case *bitbucketcloud.PullRequest:
+		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *awscodecommit.PullRequest:
+		return git.EnsureRefPrefix(m.DestinationReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -721,6 +958,49 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitLabMerged
 	case *gitlabwebhooks.MergeRequestReopenEvent:
 		return ChangesetEventKindGitLabReopened
+	case *gerrit.Vote:
+		switch {
+		case e.Label == gerrit.LabelVerified:
+			return ChangesetEventKindGerritVerified
+		case e.Value > 0:
+			return ChangesetEventKindGerritApproved
+		case e.Value < 0:
+			return ChangesetEventKindGerritChangesRequested
+		default:
+			return ChangesetEventKindGerritUnapproved
+		}
+	case *azuredevops.Vote:
+		switch {
+		case e.Value > 0:
+			return ChangesetEventKindAzureDevOpsApproved
+		case e.Value < 0:
+			return ChangesetEventKindAzureDevOpsChangesRequested
+		default:
+			return ChangesetEventKindAzureDevOpsVoteReset
+		}
+	case *azuredevops.Status:
+		return ChangesetEventKindAzureDevOpsStatus
+	case *bitbucketcloud.Participant:
+		switch {
+		case e.State == bitbucketcloud.ParticipantStateChangesRequested:
+			return ChangesetEventKindBitbucketCloudChangesRequested
+		case e.Approved || e.State == bitbucketcloud.ParticipantStateApproved:
+			return ChangesetEventKindBitbucketCloudApproved
+		default:
+			return ChangesetEventKindBitbucketCloudUnapproved
+		}
+	case *bitbucketcloud.CommitStatus:
+		return ChangesetEventKindBitbucketCloudCommitStatus
+	case *awscodecommit.ApprovalEvent:
+		if e.State == awscodecommit.ApprovalStateApprove {
+			return ChangesetEventKindAWSCodeCommitApproved
+		}
+		return ChangesetEventKindAWSCodeCommitApprovalRevoked
+	case *AutoMergeEvent:
+		if e.Merged {
+			return ChangesetEventKindAutoMerged
+		}
+		return ChangesetEventKindAutoMergeBlocked
 	default:
 		panic(errors.Errorf("unknown changeset event kind for %T", e))
 	}
@@ -793,6 +1073,22 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 		case ChangesetEventKindGitLabReopened:
 			return new(gitlabwebhooks.MergeRequestReopenEvent), nil
 		}
+	case strings.HasPrefix(string(k), "gerrit"):
+		return new(gerrit.Vote), nil
+	case strings.HasPrefix(string(k), "azuredevops"):
+		if k == ChangesetEventKindAzureDevOpsStatus {
+			return new(azuredevops.Status), nil
+		}
+		return new(azuredevops.Vote), nil
+	case strings.HasPrefix(string(k), "bitbucketcloud"):
+		if k == ChangesetEventKindBitbucketCloudCommitStatus {
+			return new(bitbucketcloud.CommitStatus), nil
+		}
+		return new(bitbucketcloud.Participant), nil
+	case strings.HasPrefix(string(k), "awscodecommit"):
+		return new(awscodecommit.ApprovalEvent), nil
+	case strings.HasPrefix(string(k), "sourcegraph"):
+		return new(AutoMergeEvent), nil
 	}
 	return nil, errors.Errorf("unknown changeset event kind %q", k)
 }
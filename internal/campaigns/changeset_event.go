@@ -3,12 +3,17 @@ package campaigns
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
@@ -72,8 +77,56 @@ const (
 	ChangesetEventKindGitLabPipeline   ChangesetEventKind = "gitlab:pipeline"
 	ChangesetEventKindGitLabReopened   ChangesetEventKind = "gitlab:reopened"
 	ChangesetEventKindGitLabUnapproved ChangesetEventKind = "gitlab:unapproved"
+
+	// Gerrit has no event log for changes, so these are derived from the
+	// votes on the Code-Review and Verified labels.
+	ChangesetEventKindGerritApproved         ChangesetEventKind = "gerrit:approved"
+	ChangesetEventKindGerritChangesRequested ChangesetEventKind = "gerrit:changes_requested"
+	ChangesetEventKindGerritUnapproved       ChangesetEventKind = "gerrit:unapproved"
+	ChangesetEventKindGerritVerified         ChangesetEventKind = "gerrit:verified"
+
+	// Azure DevOps records every vote on a pull request as a system thread,
+	// which is where these are derived from.
+	ChangesetEventKindAzureDevOpsApproved         ChangesetEventKind = "azuredevops:approved"
+	ChangesetEventKindAzureDevOpsChangesRequested ChangesetEventKind = "azuredevops:changes_requested"
+	ChangesetEventKindAzureDevOpsStatus           ChangesetEventKind = "azuredevops:status"
+	ChangesetEventKindAzureDevOpsVoteReset        ChangesetEventKind = "azuredevops:vote_reset"
+
+	// Bitbucket Cloud only keeps the current review state of every
+	// participant, which is where the review events are derived from.
+	ChangesetEventKindBitbucketCloudApproved         ChangesetEventKind = "bitbucketcloud:approved"
+	ChangesetEventKindBitbucketCloudChangesRequested ChangesetEventKind = "bitbucketcloud:changes_requested"
+	ChangesetEventKindBitbucketCloudCommitStatus     ChangesetEventKind = "bitbucketcloud:commit_status"
+	ChangesetEventKindBitbucketCloudUnapproved       ChangesetEventKind = "bitbucketcloud:unapproved"
+
+	ChangesetEventKindAWSCodeCommitApprovalRevoked ChangesetEventKind = "awscodecommit:approval_revoked"
+	ChangesetEventKindAWSCodeCommitApproved        ChangesetEventKind = "awscodecommit:approved"
+
+	// Sourcegraph records whether it merged a changeset according to the
+	// auto-merge policy of its campaign, or why it didn't.
+	ChangesetEventKindAutoMerged       ChangesetEventKind = "sourcegraph:auto_merged"
+	ChangesetEventKindAutoMergeBlocked ChangesetEventKind = "sourcegraph:auto_merge_blocked"
 )
 
+// AutoMergeEvent is the metadata of the ChangesetEvents recorded when the
+// auto-merge policy of a campaign is applied to one of its changesets.
+type AutoMergeEvent struct {
+	Merged bool                 `json:"merged"`
+	Method ChangesetMergeMethod `json:"method"`
+	// Reason is set if the changeset was not merged and explains why.
+	Reason string    `json:"reason,omitempty"`
+	Date   time.Time `json:"date"`
+}
+
+// Key is a unique key that identifies the AutoMergeEvent of a changeset.
+// Only the latest outcome of applying the policy is kept.
+func (e *AutoMergeEvent) Key() string {
+	if e.Merged {
+		return "auto-merged"
+	}
+	return "auto-merge-blocked"
+}
+
 // A ChangesetEvent is an event that happened in the lifetime
 // and context of a Changeset.
 type ChangesetEvent struct {
@@ -137,6 +190,40 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 		}
 		return username, nil
 
+	case *gerrit.Vote:
+		// Usernames are optional in Gerrit, account IDs are not.
+		if username := meta.Account.Username; username != "" {
+			return username, nil
+		}
+		if meta.Account.ID == 0 {
+			return "", errors.New("vote account is blank")
+		}
+		return strconv.Itoa(meta.Account.ID), nil
+
+	case *azuredevops.Vote:
+		if name := meta.Reviewer.UniqueName; name != "" {
+			return name, nil
+		}
+		if meta.Reviewer.ID == "" {
+			return "", errors.New("vote reviewer is blank")
+		}
+		return meta.Reviewer.ID, nil
+
+	case *bitbucketcloud.Participant:
+		if id := meta.User.AccountID; id != "" {
+			return id, nil
+		}
+		if meta.User.UUID == "" {
+			return "", errors.New("participant user is blank")
+		}
+		return meta.User.UUID, nil
+
+	case *awscodecommit.ApprovalEvent:
+		if meta.ActorARN == "" {
+			return "", errors.New("approval actor is blank")
+		}
+		return meta.ActorARN, nil
+
 	default:
 		return "", nil
 	}
@@ -146,12 +233,19 @@ func (e *ChangesetEvent) ReviewAuthor() (string, error) {
 func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	switch e.Kind {
 	case ChangesetEventKindBitbucketServerApproved,
-		ChangesetEventKindGitLabApproved:
+		ChangesetEventKindGitLabApproved,
+		ChangesetEventKindGerritApproved,
+		ChangesetEventKindAzureDevOpsApproved,
+		ChangesetEventKindBitbucketCloudApproved,
+		ChangesetEventKindAWSCodeCommitApproved:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
-	case ChangesetEventKindBitbucketServerReviewed:
+	case ChangesetEventKindBitbucketServerReviewed,
+		ChangesetEventKindGerritChangesRequested,
+		ChangesetEventKindAzureDevOpsChangesRequested,
+		ChangesetEventKindBitbucketCloudChangesRequested:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -171,7 +265,11 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindGitHubReviewDismissed,
 		ChangesetEventKindBitbucketServerUnapproved,
 		ChangesetEventKindBitbucketServerDismissed,
-		ChangesetEventKindGitLabUnapproved:
+		ChangesetEventKindGitLabUnapproved,
+		ChangesetEventKindGerritUnapproved,
+		ChangesetEventKindAzureDevOpsVoteReset,
+		ChangesetEventKindBitbucketCloudUnapproved,
+		ChangesetEventKindAWSCodeCommitApprovalRevoked:
 		return ChangesetReviewStateDismissed, nil
 
 	default:
@@ -237,6 +335,22 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt.Time
 	case *gitlab.ReviewUnapproved:
 		t = ev.CreatedAt.Time
+	case *gerrit.Vote:
+		t = ev.Date.Time
+	case *azuredevops.Vote:
+		t = ev.Date
+	case *azuredevops.Status:
+		t = ev.UpdatedDate
+	case *bitbucketcloud.Participant:
+		if ev.ParticipatedOn != nil {
+			t = *ev.ParticipatedOn
+		}
+	case *bitbucketcloud.CommitStatus:
+		t = ev.UpdatedOn
+	case *awscodecommit.ApprovalEvent:
+		t = ev.Date
+	case *AutoMergeEvent:
+		t = ev.Date
 	case *gitlabwebhooks.MergeRequestCloseEvent,
 		*gitlabwebhooks.MergeRequestMergeEvent,
 		*gitlabwebhooks.MergeRequestReopenEvent,
@@ -634,6 +748,40 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *gerrit.Vote:
+		o := o.Metadata.(*gerrit.Vote)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *azuredevops.Vote:
+		o := o.Metadata.(*azuredevops.Vote)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *azuredevops.Status:
+		o := o.Metadata.(*azuredevops.Status)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.Participant:
+		o := o.Metadata.(*bitbucketcloud.Participant)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *bitbucketcloud.CommitStatus:
+		o := o.Metadata.(*bitbucketcloud.CommitStatus)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *awscodecommit.ApprovalEvent:
+		o := o.Metadata.(*awscodecommit.ApprovalEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *AutoMergeEvent:
+		o := o.Metadata.(*AutoMergeEvent)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -5,7 +5,11 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
@@ -265,6 +269,235 @@ func TestChangesetEvents(t *testing.T) {
 		})
 	}
 
+	{ // Gerrit
+		alice := gerrit.Account{ID: 1000001, Username: "alice"}
+		bob := gerrit.Account{ID: 1000002, Username: "bob"}
+		ci := gerrit.Account{ID: 1000003, Username: "ci"}
+
+		t1 := gerrit.Timestamp{Time: time.Date(2020, 10, 15, 10, 0, 0, 0, time.UTC)}
+		t2 := gerrit.Timestamp{Time: time.Date(2020, 10, 15, 10, 30, 0, 0, time.UTC)}
+		t3 := gerrit.Timestamp{Time: time.Date(2020, 10, 15, 11, 0, 0, 0, time.UTC)}
+
+		change := &gerrit.Change{
+			Labels: map[string]*gerrit.Label{
+				gerrit.LabelCodeReview: {
+					All: []*gerrit.Approval{
+						{Account: alice, Value: 2, Date: &t1},
+						{Account: bob, Value: -1, Date: &t3},
+						// Reviewers that haven't voted yet are ignored.
+						{Account: gerrit.Account{ID: 1000004}},
+					},
+				},
+				gerrit.LabelVerified: {
+					All: []*gerrit.Approval{{Account: ci, Value: 1, Date: &t2}},
+				},
+			},
+		}
+		votes := change.Votes()
+
+		cases = append(cases, testCase{
+			name: "gerrit",
+			changeset: Changeset{
+				ID:       4321,
+				Metadata: change,
+			},
+			events: []*ChangesetEvent{
+				{
+					ChangesetID: 4321,
+					Kind:        ChangesetEventKindGerritApproved,
+					Key:         votes[0].Key(),
+					Metadata:    votes[0],
+				},
+				{
+					ChangesetID: 4321,
+					Kind:        ChangesetEventKindGerritVerified,
+					Key:         votes[1].Key(),
+					Metadata:    votes[1],
+				},
+				{
+					ChangesetID: 4321,
+					Kind:        ChangesetEventKindGerritChangesRequested,
+					Key:         votes[2].Key(),
+					Metadata:    votes[2],
+				},
+			},
+		})
+	}
+
+	{ // Azure DevOps
+		alice := azuredevops.IdentityRef{ID: "d6245f20-2af8-44f4-9451-8107cb2767db", UniqueName: "alice@sourcegraph.com"}
+
+		t1 := time.Date(2020, 10, 15, 10, 0, 0, 0, time.UTC)
+		t2 := time.Date(2020, 10, 15, 10, 30, 0, 0, time.UTC)
+		t3 := time.Date(2020, 10, 15, 11, 0, 0, 0, time.UTC)
+
+		votes := []*azuredevops.Vote{
+			{ThreadID: 12, Reviewer: alice, Value: azuredevops.VoteWaitingForAuthor, Date: t1},
+			{ThreadID: 13, Reviewer: alice, Value: azuredevops.VoteNone, Date: t2},
+			{ThreadID: 14, Reviewer: alice, Value: azuredevops.VoteApproved, Date: t3},
+		}
+		statuses := []*azuredevops.Status{{
+			ID:          1,
+			State:       azuredevops.StatusStateSucceeded,
+			Context:     azuredevops.StatusContext{Name: "build", Genre: "continuous-integration"},
+			UpdatedDate: t2,
+		}}
+
+		cases = append(cases, testCase{
+			name: "azuredevops",
+			changeset: Changeset{
+				ID: 5678,
+				Metadata: &azuredevops.PullRequest{
+					Votes:    votes,
+					Statuses: statuses,
+				},
+			},
+			events: []*ChangesetEvent{
+				{
+					ChangesetID: 5678,
+					Kind:        ChangesetEventKindAzureDevOpsChangesRequested,
+					Key:         votes[0].Key(),
+					Metadata:    votes[0],
+				},
+				{
+					ChangesetID: 5678,
+					Kind:        ChangesetEventKindAzureDevOpsVoteReset,
+					Key:         votes[1].Key(),
+					Metadata:    votes[1],
+				},
+				{
+					ChangesetID: 5678,
+					Kind:        ChangesetEventKindAzureDevOpsApproved,
+					Key:         votes[2].Key(),
+					Metadata:    votes[2],
+				},
+				{
+					ChangesetID: 5678,
+					Kind:        ChangesetEventKindAzureDevOpsStatus,
+					Key:         statuses[0].Key(),
+					Metadata:    statuses[0],
+				},
+			},
+		})
+	}
+
+	{ // Bitbucket Cloud
+		t1 := time.Date(2020, 10, 15, 10, 0, 0, 0, time.UTC)
+		t2 := time.Date(2020, 10, 15, 10, 30, 0, 0, time.UTC)
+
+		participants := []*bitbucketcloud.Participant{
+			{
+				User:           bitbucketcloud.User{UUID: "{alice}", AccountID: "alice"},
+				Role:           bitbucketcloud.ParticipantRoleReviewer,
+				Approved:       true,
+				State:          bitbucketcloud.ParticipantStateApproved,
+				ParticipatedOn: &t1,
+			},
+			{
+				User:           bitbucketcloud.User{UUID: "{bob}", AccountID: "bob"},
+				Role:           bitbucketcloud.ParticipantRoleParticipant,
+				State:          bitbucketcloud.ParticipantStateChangesRequested,
+				ParticipatedOn: &t2,
+			},
+			{
+				User: bitbucketcloud.User{UUID: "{carol}", AccountID: "carol"},
+				Role: bitbucketcloud.ParticipantRoleReviewer,
+			},
+			{
+				// Only commented, so not a review.
+				User:           bitbucketcloud.User{UUID: "{dave}", AccountID: "dave"},
+				Role:           bitbucketcloud.ParticipantRoleParticipant,
+				ParticipatedOn: &t2,
+			},
+		}
+		statuses := []*bitbucketcloud.CommitStatus{{
+			UUID:      "{status}",
+			BuildKey:  "build",
+			State:     bitbucketcloud.CommitStatusStateSuccessful,
+			UpdatedOn: t2,
+		}}
+
+		cases = append(cases, testCase{
+			name: "bitbucketcloud",
+			changeset: Changeset{
+				ID: 6789,
+				Metadata: &bitbucketcloud.PullRequest{
+					Participants: participants,
+					Statuses:     statuses,
+				},
+			},
+			events: []*ChangesetEvent{
+				{
+					ChangesetID: 6789,
+					Kind:        ChangesetEventKindBitbucketCloudApproved,
+					Key:         participants[0].Key(),
+					Metadata:    participants[0],
+				},
+				{
+					ChangesetID: 6789,
+					Kind:        ChangesetEventKindBitbucketCloudChangesRequested,
+					Key:         participants[1].Key(),
+					Metadata:    participants[1],
+				},
+				{
+					ChangesetID: 6789,
+					Kind:        ChangesetEventKindBitbucketCloudUnapproved,
+					Key:         participants[2].Key(),
+					Metadata:    participants[2],
+				},
+				{
+					ChangesetID: 6789,
+					Kind:        ChangesetEventKindBitbucketCloudCommitStatus,
+					Key:         statuses[0].Key(),
+					Metadata:    statuses[0],
+				},
+			},
+		})
+	}
+
+	{ // AWS CodeCommit
+		arn := "arn:aws:iam::123456789012:user/alice"
+
+		approvals := []*awscodecommit.ApprovalEvent{
+			{
+				ActorARN:   arn,
+				RevisionID: "rev1",
+				State:      awscodecommit.ApprovalStateApprove,
+				Date:       time.Date(2020, 10, 15, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				ActorARN:   arn,
+				RevisionID: "rev1",
+				State:      awscodecommit.ApprovalStateRevoke,
+				Date:       time.Date(2020, 10, 15, 10, 30, 0, 0, time.UTC),
+			},
+		}
+
+		cases = append(cases, testCase{
+			name: "awscodecommit",
+			changeset: Changeset{
+				ID: 7890,
+				Metadata: &awscodecommit.PullRequest{
+					ApprovalEvents: approvals,
+				},
+			},
+			events: []*ChangesetEvent{
+				{
+					ChangesetID: 7890,
+					Kind:        ChangesetEventKindAWSCodeCommitApproved,
+					Key:         approvals[0].Key(),
+					Metadata:    approvals[0],
+				},
+				{
+					ChangesetID: 7890,
+					Kind:        ChangesetEventKindAWSCodeCommitApprovalRevoked,
+					Key:         approvals[1].Key(),
+					Metadata:    approvals[1],
+				},
+			},
+		})
+	}
+
 	for _, tc := range cases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -7,7 +7,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
@@ -117,6 +121,58 @@ func TestChangeset_SetMetadata(t *testing.T) {
 				ExternalUpdatedAt:   time.Unix(10, 0),
 			},
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				Number:  12345,
+				Topic:   "branch",
+				Updated: gerrit.Timestamp{Time: time.Unix(10, 0)},
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeGerrit,
+				ExternalBranch:      "branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
+		"AzureDevOps": {
+			meta: &azuredevops.PullRequest{
+				PullRequestID: 12345,
+				SourceRefName: "refs/heads/branch",
+				CreationDate:  time.Unix(10, 0),
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeAzureDevOps,
+				ExternalBranch:      "branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
+		"BitbucketCloud": {
+			meta: &bitbucketcloud.PullRequest{
+				ID:        12345,
+				Source:    bitbucketcloud.PullRequestEndpoint{Branch: bitbucketcloud.Branch{Name: "branch"}},
+				UpdatedOn: time.Unix(10, 0),
+			},
+			want: &Changeset{
+				ExternalID:          "12345",
+				ExternalServiceType: extsvc.TypeBitbucketCloud,
+				ExternalBranch:      "branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
+		"AWSCodeCommit": {
+			meta: &awscodecommit.PullRequest{
+				ID:               "12",
+				SourceReference:  "refs/heads/branch",
+				LastActivityDate: time.Unix(10, 0),
+			},
+			want: &Changeset{
+				ExternalID:          "12",
+				ExternalServiceType: extsvc.TypeAWSCodeCommit,
+				ExternalBranch:      "branch",
+				ExternalUpdatedAt:   time.Unix(10, 0),
+			},
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			have := &Changeset{}
@@ -145,6 +201,18 @@ func TestChangeset_Title(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			Title: want,
 		},
+		"Gerrit": &gerrit.Change{
+			Subject: want,
+		},
+		"AzureDevOps": &azuredevops.PullRequest{
+			Title: want,
+		},
+		"BitbucketCloud": &bitbucketcloud.PullRequest{
+			Title: want,
+		},
+		"AWSCodeCommit": &awscodecommit.PullRequest{
+			Title: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -178,6 +246,18 @@ func TestChangeset_ExternalCreatedAt(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			CreatedAt: gitlab.Time{Time: want},
 		},
+		"Gerrit": &gerrit.Change{
+			Created: gerrit.Timestamp{Time: want},
+		},
+		"AzureDevOps": &azuredevops.PullRequest{
+			CreationDate: want,
+		},
+		"BitbucketCloud": &bitbucketcloud.PullRequest{
+			CreatedOn: want,
+		},
+		"AWSCodeCommit": &awscodecommit.PullRequest{
+			CreationDate: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -208,6 +288,21 @@ func TestChangeset_Body(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			Description: want,
 		},
+		"Gerrit": &gerrit.Change{
+			CurrentRevision: "abc",
+			Revisions: map[string]*gerrit.Revision{
+				"abc": {Commit: &gerrit.Commit{Message: "Title\n\n" + want + "\n\nChange-Id: I0123\n"}},
+			},
+		},
+		"AzureDevOps": &azuredevops.PullRequest{
+			Description: want,
+		},
+		"BitbucketCloud": &bitbucketcloud.PullRequest{
+			Description: want,
+		},
+		"AWSCodeCommit": &awscodecommit.PullRequest{
+			Description: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -276,6 +371,85 @@ func TestChangeset_externalState(t *testing.T) {
 			},
 			want: ChangesetExternalStateMerged,
 		},
+		"Gerrit: new": {
+			meta: &gerrit.Change{
+				Status: gerrit.ChangeStatusNew,
+			},
+			want: ChangesetExternalStateOpen,
+		},
+		"Gerrit: abandoned": {
+			meta: &gerrit.Change{
+				Status: gerrit.ChangeStatusAbandoned,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"Gerrit: merged": {
+			meta: &gerrit.Change{
+				Status: gerrit.ChangeStatusMerged,
+			},
+			want: ChangesetExternalStateMerged,
+		},
+		"AzureDevOps: active": {
+			meta: &azuredevops.PullRequest{
+				Status: azuredevops.PullRequestStatusActive,
+			},
+			want: ChangesetExternalStateOpen,
+		},
+		"AzureDevOps: abandoned": {
+			meta: &azuredevops.PullRequest{
+				Status: azuredevops.PullRequestStatusAbandoned,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"AzureDevOps: completed": {
+			meta: &azuredevops.PullRequest{
+				Status: azuredevops.PullRequestStatusCompleted,
+			},
+			want: ChangesetExternalStateMerged,
+		},
+		"BitbucketCloud: open": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateOpen,
+			},
+			want: ChangesetExternalStateOpen,
+		},
+		"BitbucketCloud: declined": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateDeclined,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"BitbucketCloud: superseded": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateSuperseded,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"BitbucketCloud: merged": {
+			meta: &bitbucketcloud.PullRequest{
+				State: bitbucketcloud.PullRequestStateMerged,
+			},
+			want: ChangesetExternalStateMerged,
+		},
+		"AWSCodeCommit: open": {
+			meta: &awscodecommit.PullRequest{
+				Status: awscodecommit.PullRequestStatusOpen,
+			},
+			want: ChangesetExternalStateOpen,
+		},
+		"AWSCodeCommit: closed": {
+			meta: &awscodecommit.PullRequest{
+				Status: awscodecommit.PullRequestStatusClosed,
+			},
+			want: ChangesetExternalStateClosed,
+		},
+		"AWSCodeCommit: merged": {
+			meta: &awscodecommit.PullRequest{
+				Status:   awscodecommit.PullRequestStatusClosed,
+				IsMerged: true,
+			},
+			want: ChangesetExternalStateMerged,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -337,6 +511,18 @@ func TestChangeset_URL(t *testing.T) {
 		"GitLab": &gitlab.MergeRequest{
 			WebURL: want,
 		},
+		"Gerrit": &gerrit.Change{
+			WebURL: want,
+		},
+		"AzureDevOps": &azuredevops.PullRequest{
+			WebURL: want,
+		},
+		"BitbucketCloud": &bitbucketcloud.PullRequest{
+			Links: bitbucketcloud.Links{HTML: bitbucketcloud.Link{Href: want}},
+		},
+		"AWSCodeCommit": &awscodecommit.PullRequest{
+			WebURL: want,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: meta}
@@ -377,6 +563,26 @@ func TestChangeset_HeadRefOid(t *testing.T) {
 			},
 			want: "foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{CurrentRevision: "foo"},
+			want: "foo",
+		},
+		"AzureDevOps": {
+			meta: &azuredevops.PullRequest{
+				LastMergeSourceCommit: &azuredevops.CommitRef{CommitID: "foo"},
+			},
+			want: "foo",
+		},
+		"BitbucketCloud": {
+			meta: &bitbucketcloud.PullRequest{
+				Source: bitbucketcloud.PullRequestEndpoint{Commit: &bitbucketcloud.Commit{Hash: "foo"}},
+			},
+			want: "foo",
+		},
+		"AWSCodeCommit": {
+			meta: &awscodecommit.PullRequest{SourceCommit: "foo"},
+			want: "foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -419,6 +625,27 @@ func TestChangeset_HeadRef(t *testing.T) {
 			},
 			want: "refs/heads/foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				CurrentRevision: "abc",
+				Revisions:       map[string]*gerrit.Revision{"abc": {Ref: "refs/changes/42/42/2"}},
+			},
+			want: "refs/changes/42/42/2",
+		},
+		"AzureDevOps": {
+			meta: &azuredevops.PullRequest{SourceRefName: "refs/heads/foo"},
+			want: "refs/heads/foo",
+		},
+		"BitbucketCloud": {
+			meta: &bitbucketcloud.PullRequest{
+				Source: bitbucketcloud.PullRequestEndpoint{Branch: bitbucketcloud.Branch{Name: "foo"}},
+			},
+			want: "refs/heads/foo",
+		},
+		"AWSCodeCommit": {
+			meta: &awscodecommit.PullRequest{SourceReference: "refs/heads/foo"},
+			want: "refs/heads/foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -459,6 +686,31 @@ func TestChangeset_BaseRefOid(t *testing.T) {
 			},
 			want: "foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{
+				CurrentRevision: "abc",
+				Revisions: map[string]*gerrit.Revision{
+					"abc": {Commit: &gerrit.Commit{Parents: []*gerrit.ParentCommit{{Commit: "foo"}}}},
+				},
+			},
+			want: "foo",
+		},
+		"AzureDevOps": {
+			meta: &azuredevops.PullRequest{
+				LastMergeTargetCommit: &azuredevops.CommitRef{CommitID: "foo"},
+			},
+			want: "foo",
+		},
+		"BitbucketCloud": {
+			meta: &bitbucketcloud.PullRequest{
+				Destination: bitbucketcloud.PullRequestEndpoint{Commit: &bitbucketcloud.Commit{Hash: "foo"}},
+			},
+			want: "foo",
+		},
+		"AWSCodeCommit": {
+			meta: &awscodecommit.PullRequest{DestinationCommit: "foo"},
+			want: "foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -501,6 +753,24 @@ func TestChangeset_BaseRef(t *testing.T) {
 			},
 			want: "refs/heads/foo",
 		},
+		"Gerrit": {
+			meta: &gerrit.Change{Branch: "foo"},
+			want: "refs/heads/foo",
+		},
+		"AzureDevOps": {
+			meta: &azuredevops.PullRequest{TargetRefName: "refs/heads/foo"},
+			want: "refs/heads/foo",
+		},
+		"BitbucketCloud": {
+			meta: &bitbucketcloud.PullRequest{
+				Destination: bitbucketcloud.PullRequestEndpoint{Branch: bitbucketcloud.Branch{Name: "foo"}},
+			},
+			want: "refs/heads/foo",
+		},
+		"AWSCodeCommit": {
+			meta: &awscodecommit.PullRequest{DestinationReference: "foo"},
+			want: "refs/heads/foo",
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			c := &Changeset{Metadata: tc.meta}
@@ -23,6 +23,10 @@ var SupportedExternalServices = map[string]struct{}{
 	extsvc.TypeGitHub:          {},
 	extsvc.TypeBitbucketServer: {},
 	extsvc.TypeGitLab:          {},
+	extsvc.TypeGerrit:          {},
+	extsvc.TypeAzureDevOps:     {},
+	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeAWSCodeCommit:   {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
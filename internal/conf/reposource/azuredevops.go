@@ -0,0 +1,60 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOps struct {
+	*schema.AzureDevOpsConnection
+}
+
+var _ RepoSource = AzureDevOps{}
+
+func (c AzureDevOps) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, baseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", nil
+	}
+
+	// Clone URLs have the form {org}/{project}/_git/{repo}, where {org} is
+	// the path of the organization or collection URL.
+	org := strings.Trim(baseURL.Path, "/")
+	name := strings.TrimPrefix(parsedCloneURL.Path, "/")
+	if org != "" {
+		if !strings.HasPrefix(name, org+"/") {
+			return "", nil
+		}
+		name = strings.TrimPrefix(name, org+"/")
+	}
+
+	parts := strings.Split(strings.TrimSuffix(name, ".git"), "/")
+	if len(parts) != 3 || parts[1] != "_git" {
+		return "", nil
+	}
+
+	return AzureDevOpsRepoName(c.RepositoryPathPattern, baseURL.Hostname(), org, parts[0], parts[2]), nil
+}
+
+func AzureDevOpsRepoName(repositoryPathPattern, host, org, project, repo string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{org}/{project}/{repo}"
+	}
+
+	// Organizations on *.visualstudio.com have no path.
+	if org == "" {
+		repositoryPathPattern = strings.Replace(repositoryPathPattern, "{org}/", "", -1)
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{org}", org,
+		"{project}", project,
+		"{repo}", repo,
+	).Replace(repositoryPathPattern))
+}
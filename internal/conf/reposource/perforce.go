@@ -0,0 +1,19 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// PerforceRepoName returns the name of the repository of the given Perforce depot path (such as
+// "//Engine/Cloud/"), which is converted into a Git repository.
+func PerforceRepoName(repositoryPathPattern, depot string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{depot}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{depot}", strings.Trim(depot, "/"),
+	).Replace(repositoryPathPattern))
+}
@@ -0,0 +1,25 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestPerforceRepoName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		depot   string
+		want    api.RepoName
+	}{
+		{depot: "//Engine/", want: "Engine"},
+		{depot: "//Engine/Cloud/", want: "Engine/Cloud"},
+		{pattern: "perforce.example.com/{depot}", depot: "//Engine/Cloud/", want: "perforce.example.com/Engine/Cloud"},
+	}
+
+	for _, test := range tests {
+		if got := PerforceRepoName(test.pattern, test.depot); got != test.want {
+			t.Errorf("PerforceRepoName(%q, %q) = %q, want %q", test.pattern, test.depot, got, test.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
@@ -42,6 +43,15 @@ type RevokeUserPermissionsArgs struct {
 	Accounts []*extsvc.Accounts
 }
 
+// SubRepoPermissionsArgs contains required arguments to load the path-level permissions of a user
+// in a list of repositories.
+type SubRepoPermissionsArgs struct {
+	// The user whose path-level permissions are being loaded.
+	UserID int32
+	// The repositories in which the path-level permissions apply.
+	RepoIDs []api.RepoID
+}
+
 // AuthzStore contains methods for manipulating user permissions.
 type AuthzStore interface {
 	// GrantPendingPermissions grants pending permissions for a user. It is a no-op in the OSS version.
@@ -53,6 +63,10 @@ type AuthzStore interface {
 	// RevokeUserPermissions deletes both effective and pending permissions that could be related to a user.
 	// It is a no-op in the OSS version.
 	RevokeUserPermissions(ctx context.Context, args *RevokeUserPermissionsArgs) error
+	// SubRepoPermissions returns the path-level permissions of a user in the given repositories.
+	// Repositories whose paths can all be read by the user are omitted. It is a no-op in the OSS
+	// version.
+	SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (map[api.RepoID]*authz.SubRepoPermissions, error)
 }
 
 // authzStore is a no-op placeholder for the OSS version.
@@ -78,3 +92,10 @@ func (*authzStore) RevokeUserPermissions(ctx context.Context, args *RevokeUserPe
 	}
 	return nil
 }
+
+func (*authzStore) SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+	if Mocks.Authz.SubRepoPermissions != nil {
+		return Mocks.Authz.SubRepoPermissions(ctx, args)
+	}
+	return nil, nil
+}
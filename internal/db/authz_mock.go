@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 )
 
 type MockAuthz struct {
 	GrantPendingPermissions func(ctx context.Context, args *GrantPendingPermissionsArgs) error
 	AuthorizedRepos         func(ctx context.Context, args *AuthorizedReposArgs) ([]*types.Repo, error)
 	RevokeUserPermissions   func(ctx context.Context, args *RevokeUserPermissionsArgs) error
+	SubRepoPermissions      func(ctx context.Context, args *SubRepoPermissionsArgs) (map[api.RepoID]*authz.SubRepoPermissions, error)
 }
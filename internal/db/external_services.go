@@ -34,17 +34,25 @@ type ExternalServicesStore struct {
 	GitHubValidators          []func(*schema.GitHubConnection) error
 	GitLabValidators          []func(*schema.GitLabConnection, []schema.AuthProviders) error
 	BitbucketServerValidators []func(*schema.BitbucketServerConnection) error
+	BitbucketCloudValidators  []func(*schema.BitbucketCloudConnection) error
+	GitoliteValidators        []func(*schema.GitoliteConnection) error
+	AzureDevOpsValidators     []func(*schema.AzureDevOpsConnection) error
+	PerforceValidators        []func(*schema.PerforceConnection) error
 }
 
 // ExternalServiceKinds contains a map of all supported kinds of
 // external services.
 var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindAWSCodeCommit:   {CodeHost: true, JSONSchema: schema.AWSCodeCommitSchemaJSON},
+	extsvc.KindAzureDevOps:     {CodeHost: true, JSONSchema: schema.AzureDevOpsSchemaJSON},
 	extsvc.KindBitbucketCloud:  {CodeHost: true, JSONSchema: schema.BitbucketCloudSchemaJSON},
 	extsvc.KindBitbucketServer: {CodeHost: true, JSONSchema: schema.BitbucketServerSchemaJSON},
+	extsvc.KindGerrit:          {CodeHost: true, JSONSchema: schema.GerritSchemaJSON},
+	extsvc.KindGitea:           {CodeHost: true, JSONSchema: schema.GiteaSchemaJSON},
 	extsvc.KindGitHub:          {CodeHost: true, JSONSchema: schema.GitHubSchemaJSON},
 	extsvc.KindGitLab:          {CodeHost: true, JSONSchema: schema.GitLabSchemaJSON},
 	extsvc.KindGitolite:        {CodeHost: true, JSONSchema: schema.GitoliteSchemaJSON},
+	extsvc.KindPerforce:        {CodeHost: true, JSONSchema: schema.PerforceSchemaJSON},
 	extsvc.KindPhabricator:     {CodeHost: true, JSONSchema: schema.PhabricatorSchemaJSON},
 	extsvc.KindOther:           {CodeHost: true, JSONSchema: schema.OtherExternalServiceSchemaJSON},
 }
@@ -196,6 +204,41 @@ func (e *ExternalServicesStore) ValidateConfig(ctx context.Context, opt Validate
 		}
 		err = e.validateBitbucketCloudConnection(ctx, opt.ID, &c)
 
+	case extsvc.KindGitea:
+		var c schema.GiteaConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validateGiteaConnection(ctx, opt.ID, &c)
+
+	case extsvc.KindGerrit:
+		var c schema.GerritConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validateGerritConnection(ctx, opt.ID, &c)
+
+	case extsvc.KindAzureDevOps:
+		var c schema.AzureDevOpsConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validateAzureDevOpsConnection(ctx, opt.ID, &c)
+
+	case extsvc.KindGitolite:
+		var c schema.GitoliteConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validateGitoliteConnection(&c)
+
+	case extsvc.KindPerforce:
+		var c schema.PerforceConnection
+		if err = json.Unmarshal(normalized, &c); err != nil {
+			return err
+		}
+		err = e.validatePerforceConnection(&c)
+
 	case extsvc.KindOther:
 		var c schema.OtherExternalServiceConnection
 		if err = json.Unmarshal(normalized, &c); err != nil {
@@ -278,7 +321,59 @@ func (e *ExternalServicesStore) validateBitbucketServerConnection(ctx context.Co
 }
 
 func (e *ExternalServicesStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection) error {
-	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
+	err := new(multierror.Error)
+	for _, validate := range e.BitbucketCloudValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c))
+
+	return err.ErrorOrNil()
+}
+
+func (e *ExternalServicesStore) validateGiteaConnection(ctx context.Context, id int64, c *schema.GiteaConnection) error {
+	err := new(multierror.Error)
+
+	if c.Orgs == nil && c.Users == nil && c.RepositoryQuery == nil {
+		err = multierror.Append(err, errors.New("at least one of orgs, users or repositoryQuery must be set"))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindGitea, c))
+
+	return err.ErrorOrNil()
+}
+
+func (e *ExternalServicesStore) validateGerritConnection(ctx context.Context, id int64, c *schema.GerritConnection) error {
+	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindGerrit, c)
+}
+
+func (e *ExternalServicesStore) validateAzureDevOpsConnection(ctx context.Context, id int64, c *schema.AzureDevOpsConnection) error {
+	err := new(multierror.Error)
+	for _, validate := range e.AzureDevOpsValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindAzureDevOps, c))
+
+	return err.ErrorOrNil()
+}
+
+func (e *ExternalServicesStore) validateGitoliteConnection(c *schema.GitoliteConnection) error {
+	err := new(multierror.Error)
+	for _, validate := range e.GitoliteValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	return err.ErrorOrNil()
+}
+
+func (e *ExternalServicesStore) validatePerforceConnection(c *schema.PerforceConnection) error {
+	err := new(multierror.Error)
+	for _, validate := range e.PerforceValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	return err.ErrorOrNil()
 }
 
 // validateDuplicateRateLimits returns an error if given config has duplicated non-default rate limit
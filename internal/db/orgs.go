@@ -103,6 +103,8 @@ func (o *orgs) Count(ctx context.Context, opt OrgsListOptions) (int, error) {
 type OrgsListOptions struct {
 	// Query specifies a search query for organizations.
 	Query string
+	// OnlySCIMProvisioned restricts the list to organizations created via SCIM.
+	OnlySCIMProvisioned bool
 
 	*LimitOffset
 }
@@ -125,11 +127,14 @@ func (*orgs) listSQL(opt OrgsListOptions) *sqlf.Query {
 		query := "%" + opt.Query + "%"
 		conds = append(conds, sqlf.Sprintf("name ILIKE %s OR display_name ILIKE %s", query, query))
 	}
+	if opt.OnlySCIMProvisioned {
+		conds = append(conds, sqlf.Sprintf("scim_provisioned"))
+	}
 	return sqlf.Sprintf("(%s)", sqlf.Join(conds, ") AND ("))
 }
 
 func (*orgs) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.Org, error) {
-	rows, err := dbconn.Global.QueryContext(ctx, "SELECT id, name, display_name, created_at, updated_at FROM orgs "+query, args...)
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT id, name, display_name, created_at, updated_at, scim_provisioned FROM orgs "+query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +143,7 @@ func (*orgs) getBySQL(ctx context.Context, query string, args ...interface{}) ([
 	defer rows.Close()
 	for rows.Next() {
 		org := types.Org{}
-		err := rows.Scan(&org.ID, &org.Name, &org.DisplayName, &org.CreatedAt, &org.UpdatedAt)
+		err := rows.Scan(&org.ID, &org.Name, &org.DisplayName, &org.CreatedAt, &org.UpdatedAt, &org.SCIMProvisioned)
 		if err != nil {
 			return nil, err
 		}
@@ -151,7 +156,17 @@ func (*orgs) getBySQL(ctx context.Context, query string, args ...interface{}) ([
 	return orgs, nil
 }
 
-func (*orgs) Create(ctx context.Context, name string, displayName *string) (*types.Org, error) {
+func (o *orgs) Create(ctx context.Context, name string, displayName *string) (*types.Org, error) {
+	return o.create(ctx, name, displayName, false)
+}
+
+// CreateSCIMProvisioned creates an organization for a group provisioned via SCIM. Only these
+// organizations can be managed by the SCIM API.
+func (o *orgs) CreateSCIMProvisioned(ctx context.Context, name string, displayName *string) (*types.Org, error) {
+	return o.create(ctx, name, displayName, true)
+}
+
+func (*orgs) create(ctx context.Context, name string, displayName *string, scimProvisioned bool) (*types.Org, error) {
 	tx, err := dbconn.Global.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -168,15 +183,16 @@ func (*orgs) Create(ctx context.Context, name string, displayName *string) (*typ
 	}()
 
 	newOrg := types.Org{
-		Name:        name,
-		DisplayName: displayName,
+		Name:            name,
+		DisplayName:     displayName,
+		SCIMProvisioned: scimProvisioned,
 	}
 	newOrg.CreatedAt = time.Now()
 	newOrg.UpdatedAt = newOrg.CreatedAt
 	err = tx.QueryRowContext(
 		ctx,
-		"INSERT INTO orgs(name, display_name, created_at, updated_at) VALUES($1, $2, $3, $4) RETURNING id",
-		newOrg.Name, newOrg.DisplayName, newOrg.CreatedAt, newOrg.UpdatedAt).Scan(&newOrg.ID)
+		"INSERT INTO orgs(name, display_name, created_at, updated_at, scim_provisioned) VALUES($1, $2, $3, $4, $5) RETURNING id",
+		newOrg.Name, newOrg.DisplayName, newOrg.CreatedAt, newOrg.UpdatedAt, newOrg.SCIMProvisioned).Scan(&newOrg.ID)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Constraint {
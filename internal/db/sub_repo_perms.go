@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+var MockSubRepoPerms func(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]*authz.SubRepoPermissions, error)
+
+// SubRepoPerms returns the path-level permissions of the currently authenticated user in the given
+// repositories. Repositories whose paths are all readable by the user are omitted, so an empty map
+// means there is nothing to filter.
+//
+// 🚨 SECURITY: Callers must drop the files and directories of the repositories that are not readable
+// according to the returned permissions. Access to the repositories themselves must have been checked
+// before with authzFilter, because path-level permissions only restrict what can be read further.
+func SubRepoPerms(ctx context.Context, repoIDs ...api.RepoID) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+	if MockSubRepoPerms != nil {
+		return MockSubRepoPerms(ctx, repoIDs)
+	}
+
+	if len(repoIDs) == 0 || isInternalActor(ctx) || !actor.FromContext(ctx).IsAuthenticated() {
+		return nil, nil
+	}
+
+	currentUser, err := Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser.SiteAdmin {
+		return nil, nil
+	}
+
+	ids := make([]api.RepoID, 0, len(repoIDs))
+	seen := make(map[api.RepoID]bool, len(repoIDs))
+	for _, id := range repoIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return Authz.SubRepoPermissions(ctx, &SubRepoPermissionsArgs{
+		UserID:  currentUser.ID,
+		RepoIDs: ids,
+	})
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func TestSubRepoPerms(t *testing.T) {
+	user := &types.User{ID: 1, Username: "alice"}
+	Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return user, nil
+	}
+	defer func() { Mocks.Users.GetByCurrentAuthUser = nil }()
+
+	restricted := &authz.SubRepoPermissions{UserID: 1, RepoID: 1, PathIncludes: []string{"src/**"}}
+	calls := 0
+	Mocks.Authz.SubRepoPermissions = func(ctx context.Context, args *SubRepoPermissionsArgs) (map[api.RepoID]*authz.SubRepoPermissions, error) {
+		calls++
+		if diff := cmp.Diff([]api.RepoID{1, 2}, args.RepoIDs); diff != "" {
+			t.Errorf("repo IDs mismatch (-want +got):\n%s", diff)
+		}
+		if args.UserID != 1 {
+			return nil, nil
+		}
+		return map[api.RepoID]*authz.SubRepoPermissions{1: restricted}, nil
+	}
+	defer func() { Mocks.Authz.SubRepoPermissions = nil }()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	perms, err := SubRepoPerms(ctx, 1, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[api.RepoID]*authz.SubRepoPermissions{1: restricted}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Errorf("perms mismatch (-want +got):\n%s", diff)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+
+	for name, ctx := range map[string]context.Context{
+		"internal actor": actor.WithActor(context.Background(), &actor.Actor{UID: 1, Internal: true}),
+		"anonymous":      context.Background(),
+	} {
+		perms, err := SubRepoPerms(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(perms) != 0 {
+			t.Errorf("%s: got perms %v, want none", name, perms)
+		}
+	}
+
+	user.SiteAdmin = true
+	perms, err = SubRepoPerms(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(perms) != 0 {
+		t.Errorf("site admin: got perms %v, want none", perms)
+	}
+}
@@ -62,13 +62,13 @@ func (*userEmails) GetInitialSiteAdminEmail(ctx context.Context) (email string,
 }
 
 // GetPrimaryEmail gets the oldest email associated with the user, preferring a verified email to an
-// unverified email.
+// unverified email, and an email that was set with SetPrimaryEmail to other verified emails.
 func (*userEmails) GetPrimaryEmail(ctx context.Context, id int32) (email string, verified bool, err error) {
 	if Mocks.UserEmails.GetPrimaryEmail != nil {
 		return Mocks.UserEmails.GetPrimaryEmail(ctx, id)
 	}
 
-	if err := dbconn.Global.QueryRowContext(ctx, "SELECT email, verified_at IS NOT NULL AS verified FROM user_emails WHERE user_id=$1 ORDER BY (verified_at IS NOT NULL) DESC, created_at ASC, email ASC LIMIT 1",
+	if err := dbconn.Global.QueryRowContext(ctx, "SELECT email, verified_at IS NOT NULL AS verified FROM user_emails WHERE user_id=$1 ORDER BY (verified_at IS NOT NULL) DESC, is_primary DESC, created_at ASC, email ASC LIMIT 1",
 		id,
 	).Scan(&email, &verified); err != nil {
 		return "", false, userEmailNotFoundError{[]interface{}{fmt.Sprintf("id %d", id)}}
@@ -163,6 +163,27 @@ func (*userEmails) SetVerified(ctx context.Context, userID int32, email string,
 	return nil
 }
 
+// SetPrimaryEmail sets the given verified email of the user as their primary email. It returns an
+// error if the user has no such verified email.
+func (*userEmails) SetPrimaryEmail(ctx context.Context, userID int32, email string) error {
+	if Mocks.UserEmails.SetPrimaryEmail != nil {
+		return Mocks.UserEmails.SetPrimaryEmail(ctx, userID, email)
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, "UPDATE user_emails SET is_primary=(email=$2) WHERE user_id=$1 AND EXISTS (SELECT 1 FROM user_emails WHERE user_id=$1 AND email=$2 AND verified_at IS NOT NULL)", userID, email)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return userEmailNotFoundError{[]interface{}{fmt.Sprintf("userID %d verified email %q", userID, email)}}
+	}
+	return nil
+}
+
 // SetLastVerificationSentAt sets the "last_verification_sent_at" column to now() for given email of the user.
 func (*userEmails) SetLastVerificationSentAt(ctx context.Context, userID int32, email string) error {
 	res, err := dbconn.Global.ExecContext(ctx, "UPDATE user_emails SET last_verification_sent_at=now() WHERE user_id=$1 AND email=$2", userID, email)
@@ -151,6 +151,19 @@ func TestUserEmails_GetPrimary(t *testing.T) {
 		t.Fatal(err)
 	}
 	checkPrimaryEmail(t, "b1@example.com", true)
+
+	if err := UserEmails.SetPrimaryEmail(ctx, user.ID, "a@example.com"); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want unverified email not to be set as primary", err)
+	}
+	if err := UserEmails.SetPrimaryEmail(ctx, user.ID, "b2@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	checkPrimaryEmail(t, "b2@example.com", true)
+
+	if err := UserEmails.SetVerified(ctx, user.ID, "b2@example.com", false); err != nil {
+		t.Fatal(err)
+	}
+	checkPrimaryEmail(t, "b1@example.com", true)
 }
 
 func TestUserEmails_ListByUser(t *testing.T) {
@@ -409,7 +409,8 @@ func (u *users) Delete(ctx context.Context, id int32) error {
 		err = tx.Commit()
 	}()
 
-	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
+	// Deactivated users are soft-deleted already, but still hold on to their resources.
+	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=now(), deactivated_at=NULL WHERE id=$1 AND (deleted_at IS NULL OR deactivated_at IS NOT NULL)", id)
 	if err != nil {
 		return err
 	}
@@ -445,6 +446,121 @@ func (u *users) Delete(ctx context.Context, id int32) error {
 	return nil
 }
 
+// ErrCannotDeactivateLastSiteAdmin is returned by Deactivate if the user is the only
+// remaining site admin.
+var ErrCannotDeactivateLastSiteAdmin = errors.New("the last site admin can't be deactivated")
+
+// Deactivate soft-deletes the user, invalidates their sessions and revokes their access
+// tokens. Unlike Delete, it keeps the user's username, emails and external accounts, so that
+// Reactivate can restore the user.
+//
+// 🚨 SECURITY: Deactivated users are excluded wherever deleted users are, so they can't sign in
+// until they are reactivated.
+func (u *users) Deactivate(ctx context.Context, id int32) (err error) {
+	if Mocks.Users.Deactivate != nil {
+		return Mocks.Users.Deactivate(ctx, id)
+	}
+
+	tx, err := dbconn.Global.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			rollErr := tx.Rollback()
+			if rollErr != nil {
+				err = multierror.Append(err, rollErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// Lock the site admins, so that two of them can't be deactivated concurrently.
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM users WHERE site_admin AND deleted_at IS NULL FOR UPDATE")
+	if err != nil {
+		return err
+	}
+	var siteAdmins []int32
+	for rows.Next() {
+		var siteAdmin int32
+		if err := rows.Scan(&siteAdmin); err != nil {
+			rows.Close()
+			return err
+		}
+		siteAdmins = append(siteAdmins, siteAdmin)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if len(siteAdmins) == 1 && siteAdmins[0] == id {
+		return ErrCannotDeactivateLastSiteAdmin
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=now(), deactivated_at=now(), invalidated_sessions_at=now(), updated_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE access_tokens SET deleted_at=now() WHERE (subject_user_id=$1 OR creator_user_id=$1) AND deleted_at IS NULL", id)
+	return err
+}
+
+// Reactivate restores a user that was deactivated with Deactivate, and the external services in
+// their namespace that were deleted with them. Their access tokens remain revoked. If another
+// user took the username in the meantime, it returns an error for which IsUsernameExists is
+// true.
+func (u *users) Reactivate(ctx context.Context, id int32) (err error) {
+	if Mocks.Users.Reactivate != nil {
+		return Mocks.Users.Reactivate(ctx, id)
+	}
+
+	tx, err := dbconn.Global.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			rollErr := tx.Rollback()
+			if rollErr != nil {
+				err = multierror.Append(err, rollErr)
+			}
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// The trig_soft_delete_user_reference_on_external_service trigger deleted the external
+	// services in the same transaction that deactivated the user, so they were deleted at the
+	// time the user was deactivated.
+	if _, err := tx.ExecContext(ctx, "UPDATE external_services SET deleted_at=NULL WHERE namespace_user_id=$1 AND deleted_at=(SELECT deactivated_at FROM users WHERE id=$1)", id); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, "UPDATE users SET deleted_at=NULL, deactivated_at=NULL, updated_at=now() WHERE id=$1 AND deactivated_at IS NOT NULL", id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Constraint == "users_username" {
+			return errCannotCreateUser{errorCodeUsernameExists}
+		}
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+	return nil
+}
+
 // HardDelete removes the user and all resources associated with this user.
 func (u *users) HardDelete(ctx context.Context, id int32) error {
 	if Mocks.Users.HardDelete != nil {
@@ -560,6 +676,11 @@ func (u *users) GetByID(ctx context.Context, id int32) (*types.User, error) {
 	return u.getOneBySQL(ctx, "WHERE id=$1 AND deleted_at IS NULL LIMIT 1", id)
 }
 
+// GetByIDIncludingDeactivated returns the user with the given ID, even if it was deactivated.
+func (u *users) GetByIDIncludingDeactivated(ctx context.Context, id int32) (*types.User, error) {
+	return u.getOneBySQL(ctx, "WHERE id=$1 AND (deleted_at IS NULL OR deactivated_at IS NOT NULL) LIMIT 1", id)
+}
+
 // GetByVerifiedEmail returns the user (if any) with the specified verified email address. If a user
 // has a matching *unverified* email address, they will not be returned by this method. At most one
 // user may have any given verified email address.
@@ -578,6 +699,12 @@ func (u *users) GetByUsername(ctx context.Context, username string) (*types.User
 	return u.getOneBySQL(ctx, "WHERE username=$1 AND deleted_at IS NULL LIMIT 1", username)
 }
 
+// GetByUsernameIncludingDeactivated returns the user with the given username, even if it was
+// deactivated.
+func (u *users) GetByUsernameIncludingDeactivated(ctx context.Context, username string) (*types.User, error) {
+	return u.getOneBySQL(ctx, "WHERE username=$1 AND (deleted_at IS NULL OR deactivated_at IS NOT NULL) LIMIT 1", username)
+}
+
 // GetByUsernames returns a list of users by given usernames. The number of results list could be less
 // than the candidate list due to no user is associated with some usernames.
 func (u *users) GetByUsernames(ctx context.Context, usernames ...string) ([]*types.User, error) {
@@ -778,7 +905,7 @@ func (u *users) getOneBySQL(ctx context.Context, query string, args ...interface
 
 // getBySQL returns users matching the SQL query, if any exist.
 func (*users) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.User, error) {
-	rows, err := dbconn.Global.QueryContext(ctx, "SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at FROM users u "+query, args...)
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at, u.deactivated_at IS NOT NULL FROM users u "+query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -788,7 +915,7 @@ func (*users) getBySQL(ctx context.Context, query string, args ...interface{}) (
 	for rows.Next() {
 		var u types.User
 		var displayName, avatarURL sql.NullString
-		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt)
+		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt, &u.Deactivated)
 		if err != nil {
 			return nil, err
 		}
@@ -623,6 +623,111 @@ func TestUsers_Delete(t *testing.T) {
 	}
 }
 
+func TestUsers_Deactivate(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	admin, err := Users.Create(ctx, NewUser{Username: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Users.SetIsSiteAdmin(ctx, admin.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	user, err := Users.Create(ctx, NewUser{Username: "u", Email: "u@example.com", EmailIsVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, token, err := AccessTokens.Create(ctx, user.ID, []string{"a"}, "n", user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &types.ExternalService{
+		Kind:            extsvc.KindGitHub,
+		DisplayName:     "GITHUB #1",
+		Config:          `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+		NamespaceUserID: &user.ID,
+	}
+	if err := ExternalServices.Create(ctx, func() *conf.Unified { return &conf.Unified{} }, es); err != nil {
+		t.Fatal(err)
+	}
+
+	// The last site admin can't be deactivated.
+	if err := Users.Deactivate(ctx, admin.ID); err != ErrCannotDeactivateLastSiteAdmin {
+		t.Fatalf("got error %v, want %v", err, ErrCannotDeactivateLastSiteAdmin)
+	}
+
+	if err := Users.Deactivate(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Users.GetByID(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+	if _, err := Users.GetByVerifiedEmail(ctx, "u@example.com"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+	if _, err := AccessTokens.Lookup(ctx, token, "a"); err == nil {
+		t.Error("access token was not revoked")
+	}
+	deactivated, err := Users.GetByUsernameIncludingDeactivated(ctx, "u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deactivated.Deactivated {
+		t.Error("user is not marked as deactivated")
+	}
+	if _, err := ExternalServices.GetByID(ctx, es.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want external service not found", err)
+	}
+
+	// The username is still taken.
+	if _, err := Users.Create(ctx, NewUser{Username: "u"}); !IsUsernameExists(err) {
+		t.Errorf("got error %v, want username exists", err)
+	}
+
+	if err := Users.Reactivate(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	reactivated, err := Users.GetByVerifiedEmail(ctx, "u@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reactivated.ID != user.ID || reactivated.Deactivated {
+		t.Errorf("unexpected reactivated user %+v", reactivated)
+	}
+	if _, err := AccessTokens.Lookup(ctx, token, "a"); err == nil {
+		t.Error("access token was restored")
+	}
+	if _, err := ExternalServices.GetByID(ctx, es.ID); err != nil {
+		t.Errorf("external service was not restored: %v", err)
+	}
+
+	// A user that took the username in the meantime keeps it.
+	if err := Users.Deactivate(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbconn.Global.ExecContext(ctx, "INSERT INTO users(username) VALUES('u')"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Users.Reactivate(ctx, user.ID); !IsUsernameExists(err) {
+		t.Errorf("got error %v, want username exists", err)
+	}
+	if _, err := ExternalServices.GetByID(ctx, es.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want external service not found", err)
+	}
+
+	// Deleted users can't be reactivated.
+	if err := Users.Delete(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := Users.Reactivate(ctx, user.ID); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want ErrUserNotFound", err)
+	}
+}
+
 func TestUsers_InvalidateSessions(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
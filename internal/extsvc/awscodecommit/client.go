@@ -46,14 +46,15 @@ func (c *Client) cacheKeyPrefix(ctx context.Context) (string, error) {
 // ErrNotFound is when the requested AWS CodeCommit repository is not found.
 var ErrNotFound = errors.New("AWS CodeCommit repository not found")
 
-// IsNotFound reports whether err is a AWS CodeCommit API not-found error or the
-// equivalent cached response error.
+// IsNotFound reports whether err is a AWS CodeCommit API not-found error for a
+// repository or pull request, or the equivalent cached response error.
 func IsNotFound(err error) bool {
 	if err == ErrNotFound || errors.Cause(err) == ErrNotFound {
 		return true
 	}
 	if e, ok := err.(awserr.Error); ok {
-		return e.Code() == codecommit.ErrCodeRepositoryDoesNotExistException
+		return e.Code() == codecommit.ErrCodeRepositoryDoesNotExistException ||
+			e.Code() == codecommit.ErrCodePullRequestDoesNotExistException
 	}
 	return false
 }
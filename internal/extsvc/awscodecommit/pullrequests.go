@@ -0,0 +1,311 @@
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+)
+
+// PullRequestStatus is the status of a pull request.
+type PullRequestStatus string
+
+// Possible values of PullRequest.Status. Merged pull requests are closed and
+// have IsMerged set.
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// PullRequest is an AWS CodeCommit pull request, together with the approval
+// events we sync for it. Campaigns only create pull requests with a single
+// target, which is what the target fields describe.
+type PullRequest struct {
+	ID               string            `json:"id"`
+	Title            string            `json:"title"`
+	Description      string            `json:"description,omitempty"`
+	Status           PullRequestStatus `json:"status"`
+	AuthorARN        string            `json:"authorArn,omitempty"`
+	RevisionID       string            `json:"revisionId,omitempty"`
+	CreationDate     time.Time         `json:"creationDate"`
+	LastActivityDate time.Time         `json:"lastActivityDate"`
+
+	RepositoryName       string `json:"repositoryName"`
+	SourceReference      string `json:"sourceReference"`
+	DestinationReference string `json:"destinationReference"`
+	SourceCommit         string `json:"sourceCommit,omitempty"`
+	DestinationCommit    string `json:"destinationCommit,omitempty"`
+	MergeBase            string `json:"mergeBase,omitempty"`
+	IsMerged             bool   `json:"isMerged,omitempty"`
+	MergeCommitID        string `json:"mergeCommitId,omitempty"`
+
+	// ApprovalEvents and WebURL are not part of the pull request resource of
+	// the API, but loaded or computed by the Client.
+	ApprovalEvents []*ApprovalEvent `json:"approvalEvents,omitempty"`
+	WebURL         string           `json:"webUrl,omitempty"`
+}
+
+// ApprovalState is the state of an approval.
+type ApprovalState string
+
+// Possible values of ApprovalEvent.State.
+const (
+	ApprovalStateApprove ApprovalState = "APPROVE"
+	ApprovalStateRevoke  ApprovalState = "REVOKE"
+)
+
+// ApprovalEvent is an approval of a pull request revision, or the revocation
+// of one.
+type ApprovalEvent struct {
+	ActorARN   string        `json:"actorArn"`
+	RevisionID string        `json:"revisionId"`
+	State      ApprovalState `json:"state"`
+	Date       time.Time     `json:"date"`
+}
+
+// Key is a unique key identifying this approval event in the context of its
+// pull request. AWS CodeCommit doesn't assign IDs to events.
+func (e *ApprovalEvent) Key() string {
+	return fmt.Sprintf("approval:%s:%d", e.ActorARN, e.Date.UnixNano())
+}
+
+// CreatePullRequestInput contains the fields of a new pull request.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	Title                string
+	Description          string
+	SourceReference      string
+	DestinationReference string
+}
+
+// CreatePullRequest creates a pull request.
+func (c *Client) CreatePullRequest(ctx context.Context, input *CreatePullRequestInput) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+	req := svc.CreatePullRequestRequest(&codecommit.CreatePullRequestInput{
+		Title:       aws.String(input.Title),
+		Description: aws.String(input.Description),
+		Targets: []codecommit.Target{{
+			RepositoryName:       aws.String(input.RepositoryName),
+			SourceReference:      aws.String(input.SourceReference),
+			DestinationReference: aws.String(input.DestinationReference),
+		}},
+	})
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// FindOpenPullRequest returns the open pull request from the given source
+// reference into the given destination reference of the repository, or nil
+// if there is none.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repositoryName, sourceReference, destinationReference string) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+
+	var nextToken *string
+	for {
+		req := svc.ListPullRequestsRequest(&codecommit.ListPullRequestsInput{
+			RepositoryName:    aws.String(repositoryName),
+			PullRequestStatus: codecommit.PullRequestStatusEnumOpen,
+			NextToken:         nextToken,
+		})
+		req.SetContext(ctx)
+		result, err := req.Send(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// The list only contains the IDs of the pull requests, so we have to
+		// get them one by one to compare their references.
+		for _, id := range result.PullRequestIds {
+			pr, err := c.GetPullRequest(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if pr.SourceReference == sourceReference && pr.DestinationReference == destinationReference {
+				return pr, nil
+			}
+		}
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			return nil, nil
+		}
+		nextToken = result.NextToken
+	}
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+	req := svc.GetPullRequestRequest(&codecommit.GetPullRequestInput{PullRequestId: aws.String(id)})
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// UpdatePullRequestTitleAndDescription updates the title and description of
+// the pull request with the given ID. AWS CodeCommit doesn't support changing
+// the destination of a pull request.
+func (c *Client) UpdatePullRequestTitleAndDescription(ctx context.Context, id, title, description string) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+
+	titleReq := svc.UpdatePullRequestTitleRequest(&codecommit.UpdatePullRequestTitleInput{
+		PullRequestId: aws.String(id),
+		Title:         aws.String(title),
+	})
+	titleReq.SetContext(ctx)
+	if _, err := titleReq.Send(ctx); err != nil {
+		return nil, err
+	}
+
+	descriptionReq := svc.UpdatePullRequestDescriptionRequest(&codecommit.UpdatePullRequestDescriptionInput{
+		PullRequestId: aws.String(id),
+		Description:   aws.String(description),
+	})
+	descriptionReq.SetContext(ctx)
+	result, err := descriptionReq.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// ClosePullRequest closes the pull request with the given ID. Closed pull
+// requests can't be reopened in AWS CodeCommit.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+	req := svc.UpdatePullRequestStatusRequest(&codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     aws.String(id),
+		PullRequestStatus: codecommit.PullRequestStatusEnumClosed,
+	})
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// MergePullRequest merges the given pull request into its destination with a
+// merge commit or, if squash is true, a single squashed commit. The pull
+// request is only merged if its source is still at pr.SourceCommit.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (*PullRequest, error) {
+	svc := codecommit.New(c.aws)
+
+	if squash {
+		req := svc.MergePullRequestBySquashRequest(&codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		req.SetContext(ctx)
+		result, err := req.Send(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return c.fromPullRequest(result.PullRequest), nil
+	}
+
+	req := svc.MergePullRequestByThreeWayRequest(&codecommit.MergePullRequestByThreeWayInput{
+		PullRequestId:  aws.String(pr.ID),
+		RepositoryName: aws.String(pr.RepositoryName),
+		SourceCommitId: aws.String(pr.SourceCommit),
+	})
+	req.SetContext(ctx)
+	result, err := req.Send(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromPullRequest(result.PullRequest), nil
+}
+
+// LoadPullRequestApprovalEvents loads the approval state changes of the given
+// pull request into its ApprovalEvents field.
+func (c *Client) LoadPullRequestApprovalEvents(ctx context.Context, pr *PullRequest) error {
+	svc := codecommit.New(c.aws)
+
+	var events []*ApprovalEvent
+	var nextToken *string
+	for {
+		req := svc.DescribePullRequestEventsRequest(&codecommit.DescribePullRequestEventsInput{
+			PullRequestId:        aws.String(pr.ID),
+			PullRequestEventType: codecommit.PullRequestEventTypePullRequestApprovalStateChanged,
+			NextToken:            nextToken,
+		})
+		req.SetContext(ctx)
+		result, err := req.Send(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range result.PullRequestEvents {
+			m := e.ApprovalStateChangedEventMetadata
+			if m == nil {
+				continue
+			}
+			events = append(events, &ApprovalEvent{
+				ActorARN:   aws.StringValue(e.ActorArn),
+				RevisionID: aws.StringValue(m.RevisionId),
+				State:      ApprovalState(m.ApprovalStatus),
+				Date:       aws.TimeValue(e.EventDate),
+			})
+		}
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			break
+		}
+		nextToken = result.NextToken
+	}
+
+	pr.ApprovalEvents = events
+	return nil
+}
+
+func (c *Client) fromPullRequest(p *codecommit.PullRequest) *PullRequest {
+	pr := PullRequest{
+		ID:               aws.StringValue(p.PullRequestId),
+		Title:            aws.StringValue(p.Title),
+		Description:      aws.StringValue(p.Description),
+		Status:           PullRequestStatus(p.PullRequestStatus),
+		AuthorARN:        aws.StringValue(p.AuthorArn),
+		RevisionID:       aws.StringValue(p.RevisionId),
+		CreationDate:     aws.TimeValue(p.CreationDate),
+		LastActivityDate: aws.TimeValue(p.LastActivityDate),
+	}
+
+	if len(p.PullRequestTargets) > 0 {
+		t := p.PullRequestTargets[0]
+		pr.RepositoryName = aws.StringValue(t.RepositoryName)
+		pr.SourceReference = aws.StringValue(t.SourceReference)
+		pr.DestinationReference = aws.StringValue(t.DestinationReference)
+		pr.SourceCommit = aws.StringValue(t.SourceCommit)
+		pr.DestinationCommit = aws.StringValue(t.DestinationCommit)
+		pr.MergeBase = aws.StringValue(t.MergeBase)
+		if t.MergeMetadata != nil {
+			pr.IsMerged = aws.BoolValue(t.MergeMetadata.IsMerged)
+			pr.MergeCommitID = aws.StringValue(t.MergeMetadata.MergeCommitId)
+		}
+	}
+
+	pr.WebURL = PullRequestURL(c.aws.Region, pr.RepositoryName, pr.ID)
+	return &pr
+}
+
+// PullRequestURL returns the URL of the pull request in the AWS console.
+func PullRequestURL(region, repositoryName, id string) string {
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%s/details?region=%s",
+		region,
+		url.PathEscape(repositoryName),
+		url.PathEscape(id),
+		url.QueryEscape(region),
+	)
+}
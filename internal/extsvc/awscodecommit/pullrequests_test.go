@@ -0,0 +1,290 @@
+package awscodecommit
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/defaults"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/httptestutil"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+const (
+	testSourceReference      = "refs/heads/campaigns/update-readme"
+	testDestinationReference = "refs/heads/master"
+	testSourceCommit         = "8a1f4c7b6d2e0f1a2b3c4d5e6f708192a3b4c5d6"
+	testMergeCommit          = "c0ffee5a1b2c3d4e5f60718293a4b5c6d7e8f901"
+)
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli, save := newTestClient(t, "CreatePullRequest")
+	defer save()
+
+	pr, err := cli.CreatePullRequest(context.Background(), &CreatePullRequestInput{
+		RepositoryName:       "test",
+		Title:                "Update README",
+		Description:          "Fixes the installation instructions.",
+		SourceReference:      testSourceReference,
+		DestinationReference: testDestinationReference,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(testPullRequest("7"), pr); diff != "" {
+		t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_FindOpenPullRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		wantID string
+	}{
+		// The matching pull request is on the second page of open pull
+		// requests.
+		{name: "found", wantID: "5"},
+		{name: "not-found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli, save := newTestClient(t, "FindOpenPullRequest-"+tc.name)
+			defer save()
+
+			pr, err := cli.FindOpenPullRequest(context.Background(), "test", testSourceReference, testDestinationReference)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.wantID == "" {
+				if pr != nil {
+					t.Errorf("unexpected pull request %q", pr.ID)
+				}
+				return
+			}
+
+			if pr == nil {
+				t.Fatal("expected a pull request")
+			}
+			if diff := cmp.Diff(testPullRequest(tc.wantID), pr); diff != "" {
+				t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetPullRequest(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		cli, save := newTestClient(t, "GetPullRequest-found")
+		defer save()
+
+		pr, err := cli.GetPullRequest(context.Background(), "5")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := testPullRequest("5")
+		want.Status = PullRequestStatusClosed
+		want.IsMerged = true
+		want.MergeCommitID = testMergeCommit
+		if diff := cmp.Diff(want, pr); diff != "" {
+			t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("not-found", func(t *testing.T) {
+		cli, save := newTestClient(t, "GetPullRequest-not-found")
+		defer save()
+
+		_, err := cli.GetPullRequest(context.Background(), "4242")
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !IsNotFound(err) {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+}
+
+func TestClient_UpdatePullRequestTitleAndDescription(t *testing.T) {
+	cli, save := newTestClient(t, "UpdatePullRequestTitleAndDescription")
+	defer save()
+
+	pr, err := cli.UpdatePullRequestTitleAndDescription(context.Background(), "5", "Update README (v2)", "Fixes the installation instructions and badges.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := testPullRequest("5")
+	want.Title = "Update README (v2)"
+	want.Description = "Fixes the installation instructions and badges."
+	if diff := cmp.Diff(want, pr); diff != "" {
+		t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ClosePullRequest(t *testing.T) {
+	cli, save := newTestClient(t, "ClosePullRequest")
+	defer save()
+
+	pr, err := cli.ClosePullRequest(context.Background(), "5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := testPullRequest("5")
+	want.Status = PullRequestStatusClosed
+	if diff := cmp.Diff(want, pr); diff != "" {
+		t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_MergePullRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		squash bool
+	}{
+		{name: "squash", squash: true},
+		{name: "three-way", squash: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cli, save := newTestClient(t, "MergePullRequest-"+tc.name)
+			defer save()
+
+			pr, err := cli.MergePullRequest(context.Background(), testPullRequest("5"), tc.squash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := testPullRequest("5")
+			want.Status = PullRequestStatusClosed
+			want.IsMerged = true
+			want.MergeCommitID = testMergeCommit
+			if diff := cmp.Diff(want, pr); diff != "" {
+				t.Errorf("unexpected pull request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_LoadPullRequestApprovalEvents(t *testing.T) {
+	cli, save := newTestClient(t, "LoadPullRequestApprovalEvents")
+	defer save()
+
+	pr := testPullRequest("5")
+	if err := cli.LoadPullRequestApprovalEvents(context.Background(), pr); err != nil {
+		t.Fatal(err)
+	}
+
+	// The events are spread over two pages.
+	want := []*ApprovalEvent{
+		{
+			ActorARN:   "arn:aws:iam::185007729374:user/alice",
+			RevisionID: pr.RevisionID,
+			State:      ApprovalStateApprove,
+			Date:       time.Unix(1602759000, 0).UTC(),
+		},
+		{
+			ActorARN:   "arn:aws:iam::185007729374:user/alice",
+			RevisionID: pr.RevisionID,
+			State:      ApprovalStateRevoke,
+			Date:       time.Unix(1602759500, 0).UTC(),
+		},
+	}
+	if diff := cmp.Diff(want, pr.ApprovalEvents); diff != "" {
+		t.Errorf("unexpected approval events (-want +got):\n%s", diff)
+	}
+}
+
+func TestApprovalEvent_Key(t *testing.T) {
+	date := time.Unix(1602759000, 0)
+	approve := &ApprovalEvent{ActorARN: "arn:aws:iam::185007729374:user/alice", State: ApprovalStateApprove, Date: date}
+	revoke := &ApprovalEvent{ActorARN: "arn:aws:iam::185007729374:user/alice", State: ApprovalStateRevoke, Date: date.Add(time.Second)}
+
+	if approve.Key() == revoke.Key() {
+		t.Errorf("expected events at different times to have different keys, both have %q", approve.Key())
+	}
+}
+
+func TestPullRequestURL(t *testing.T) {
+	have := PullRequestURL("us-west-1", "my repo", "5")
+	want := "https://us-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/my%20repo/pull-requests/5/details?region=us-west-1"
+	if have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
+
+// testPullRequest returns the pull request with the given ID as it is
+// returned by the recorded API responses, before any approval events are
+// loaded.
+func testPullRequest(id string) *PullRequest {
+	revisions := map[string]string{
+		"5": "d7a522cb8f35efee7a83cbfba49f7e33fb0e02d925445897f4a0461b9a8413c3",
+		"7": "766bf8758e1ca18bbbcf477eff1bf58e59bb4c3f296a3b57e9d30cb3faa7a638",
+	}
+
+	return &PullRequest{
+		ID:                   id,
+		Title:                "Update README",
+		Description:          "Fixes the installation instructions.",
+		Status:               PullRequestStatusOpen,
+		AuthorARN:            "arn:aws:iam::185007729374:user/sourcegraph-bot",
+		RevisionID:           revisions[id],
+		CreationDate:         time.Unix(1602666000, 0).UTC(),
+		LastActivityDate:     time.Unix(1602759733, 0).UTC(),
+		RepositoryName:       "test",
+		SourceReference:      testSourceReference,
+		DestinationReference: testDestinationReference,
+		SourceCommit:         testSourceCommit,
+		DestinationCommit:    "b3c4d5e6f7089a1b2c3d4e5f60718293a4b5c6d7",
+		MergeBase:            "b3c4d5e6f7089a1b2c3d4e5f60718293a4b5c6d7",
+		WebURL:               PullRequestURL("us-west-1", "test", id),
+	}
+}
+
+// newTestClient returns a Client that records its interactions to
+// testdata/vcr/.
+func newTestClient(t testing.TB, name string) (*Client, func()) {
+	t.Helper()
+
+	rec, err := httptestutil.NewRecorder(filepath.Join("testdata/vcr/", name), *update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hc, err := httpcli.NewFactory(nil, httptestutil.NewRecorderOpt(rec)).Doer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := defaults.Config()
+	config.Region = "us-west-1"
+	config.Credentials = aws.StaticCredentialsProvider{
+		Value: aws.Credentials{
+			AccessKeyID:     getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: getenv("AWS_SECRET_ACCESS_KEY"),
+			Source:          "sourcegraph-test",
+		},
+	}
+	config.HTTPClient = hc
+
+	return NewClient(config), func() {
+		if err := rec.Stop(); err != nil {
+			t.Errorf("failed to update test data: %s", err)
+		}
+	}
+}
+
+func getenv(name string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return "BOGUS-" + name
+}
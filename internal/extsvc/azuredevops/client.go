@@ -0,0 +1,569 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestMeter("azuredevops_requests_count", "Total number of requests sent to the Azure DevOps API.")
+
+// These fields define the self-imposed Azure DevOps rate limit. Azure DevOps
+// only reports rate limits in HTTP response headers once a client is already
+// being delayed, so like for Bitbucket we always enforce a limit of our own.
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// apiVersion is the version of the REST API we request. 6.0 is supported by
+// Azure DevOps Services and Azure DevOps Server 2020.
+const apiVersion = "6.0"
+
+// PerPage is the number of projects requested per page.
+const PerPage = 100
+
+// identitiesBatchSize is the maximum number of identities we read at once.
+const identitiesBatchSize = 50
+
+// Client access an Azure DevOps organization (or Azure DevOps Server
+// collection) via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the URL of the organization or collection.
+	URL *url.URL
+
+	// Username and Token are the username and personal access token used to
+	// authenticate requests.
+	Username string
+	Token    string
+
+	// RateLimit is the self-imposed rate limiter.
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Azure DevOps API client for the organization or
+// collection at baseURL. If a nil httpClient is provided, http.DefaultClient
+// will be used. The Username and Token fields should be set before calling
+// any APIs.
+func NewClient(baseURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The component of the path after "_apis/" maps to the type of API
+		// request we are making.
+		p := u.Path
+		if i := strings.Index(p, "_apis/"); i >= 0 {
+			p = p[i+len("_apis/"):]
+		}
+		return strings.SplitN(p, "/", 2)[0]
+	})
+
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		RateLimit:  l,
+	}
+}
+
+// ListProjects returns the page of projects visible to the user starting at
+// the given continuation token. An empty token requests the first page. The
+// returned token is empty if there are no more pages.
+func (c *Client) ListProjects(ctx context.Context, continuationToken string) (projects []*Project, next string, err error) {
+	qry := url.Values{"$top": []string{strconv.Itoa(PerPage)}}
+	if continuationToken != "" {
+		qry.Set("continuationToken", continuationToken)
+	}
+
+	u := url.URL{Path: "_apis/projects", RawQuery: qry.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var result struct {
+		Value []*Project `json:"value"`
+	}
+	resp, err := c.do(ctx, req, &result)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return result.Value, resp.Header.Get("X-MS-ContinuationToken"), nil
+}
+
+// GetProject returns the project with the given name or ID.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	req, err := http.NewRequest("GET", "_apis/projects/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var project Project
+	if _, err := c.do(ctx, req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// ListRepositories returns the Git repositories of the given project, or of
+// all projects if project is empty.
+func (c *Client) ListRepositories(ctx context.Context, project string) ([]*Repository, error) {
+	path := "_apis/git/repositories"
+	if project != "" {
+		path = url.PathEscape(project) + "/" + path
+	}
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Value []*Repository `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// GetRepository returns the Git repository with the given ID.
+func (c *Client) GetRepository(ctx context.Context, id string) (*Repository, error) {
+	req, err := http.NewRequest("GET", "_apis/git/repositories/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var repo Repository
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// CreatePullRequestInput contains the fields of a pull request to be created.
+type CreatePullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+}
+
+// ErrAlreadyExists is returned by CreatePullRequest when an active pull
+// request for the same source and target refs already exists.
+type ErrAlreadyExists struct {
+	Existing *PullRequest
+}
+
+func (e ErrAlreadyExists) Error() string {
+	return fmt.Sprintf("An active pull request already exists: %d", e.Existing.PullRequestID)
+}
+
+// activePullRequestExists is the error code returned by Azure DevOps when an
+// active pull request for the same refs already exists.
+const activePullRequestExists = "TF401179"
+
+// CreatePullRequest creates a pull request in the given repository. If an
+// active pull request for the same refs already exists, an *ErrAlreadyExists
+// error is returned that contains the existing pull request.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repository, input *CreatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", pullRequestsPath(repo), input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		if e, ok := errors.Cause(err).(*httpError); !ok || e.StatusCode != http.StatusConflict || !bytes.Contains(e.Body, []byte(activePullRequestExists)) {
+			return nil, err
+		}
+
+		existing, err := c.findActivePullRequest(ctx, repo, input.SourceRefName, input.TargetRefName)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetching existing pull request")
+		}
+		return nil, &ErrAlreadyExists{Existing: existing}
+	}
+
+	c.setWebURL(&pr)
+	return &pr, nil
+}
+
+func (c *Client) findActivePullRequest(ctx context.Context, repo *Repository, sourceRefName, targetRefName string) (*PullRequest, error) {
+	qry := url.Values{
+		"searchCriteria.sourceRefName": []string{sourceRefName},
+		"searchCriteria.targetRefName": []string{targetRefName},
+		"searchCriteria.status":        []string{string(PullRequestStatusActive)},
+	}
+	req, err := http.NewRequest("GET", pullRequestsPath(repo)+"?"+qry.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Value []*PullRequest `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Value) == 0 {
+		return nil, errors.Errorf("no active pull request from %s to %s found", sourceRefName, targetRefName)
+	}
+
+	pr := result.Value[0]
+	c.setWebURL(pr)
+	return pr, nil
+}
+
+// GetPullRequest returns the pull request with the given ID in the given
+// repository.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	req, err := http.NewRequest("GET", pullRequestsPath(repo)+"/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var pr PullRequest
+	if _, err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	c.setWebURL(&pr)
+	return &pr, nil
+}
+
+// UpdatePullRequestInput contains the fields of a pull request to be updated.
+// Empty fields are left unchanged.
+type UpdatePullRequestInput struct {
+	Status        PullRequestStatus `json:"status,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	TargetRefName string            `json:"targetRefName,omitempty"`
+
+	// LastMergeSourceCommit and CompletionOptions are required to complete
+	// a pull request.
+	LastMergeSourceCommit *CommitRef         `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// CompletionOptions control how a pull request is merged when it is
+// completed.
+type CompletionOptions struct {
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+}
+
+// MergeStrategy is the strategy with which a pull request is merged into its
+// target branch.
+type MergeStrategy string
+
+// Possible values of CompletionOptions.MergeStrategy.
+const (
+	MergeStrategyNoFastForward MergeStrategy = "noFastForward"
+	MergeStrategySquash        MergeStrategy = "squash"
+	MergeStrategyRebase        MergeStrategy = "rebase"
+)
+
+// UpdatePullRequest updates the given pull request and returns the updated
+// pull request. Changing the status abandons, reactivates or completes it.
+func (c *Client) UpdatePullRequest(ctx context.Context, pr *PullRequest, input *UpdatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("PATCH", pullRequestsPath(&pr.Repository)+"/"+strconv.Itoa(pr.PullRequestID), input)
+	if err != nil {
+		return nil, err
+	}
+	var updated PullRequest
+	if _, err := c.do(ctx, req, &updated); err != nil {
+		return nil, err
+	}
+	c.setWebURL(&updated)
+	return &updated, nil
+}
+
+// LoadPullRequestVotes loads the votes cast on the given pull request into
+// its Votes field, sorted by date.
+func (c *Client) LoadPullRequestVotes(ctx context.Context, pr *PullRequest) error {
+	req, err := http.NewRequest("GET", pullRequestsPath(&pr.Repository)+"/"+strconv.Itoa(pr.PullRequestID)+"/threads", nil)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Value []*thread `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return err
+	}
+
+	pr.Votes = pr.Votes[:0]
+	for _, t := range result.Value {
+		if t.Properties["CodeReviewThreadType"].String() != "VoteUpdate" || len(t.Comments) == 0 {
+			continue
+		}
+		value, err := strconv.Atoi(t.Properties["CodeReviewVoteResult"].String())
+		if err != nil {
+			return errors.Wrapf(err, "parsing vote of thread %d", t.ID)
+		}
+		pr.Votes = append(pr.Votes, &Vote{
+			ThreadID: t.ID,
+			Reviewer: t.Comments[0].Author,
+			Value:    VoteValue(value),
+			Date:     t.PublishedDate,
+		})
+	}
+	return nil
+}
+
+// LoadPullRequestStatuses loads the statuses posted to the given pull request
+// into its Statuses field.
+func (c *Client) LoadPullRequestStatuses(ctx context.Context, pr *PullRequest) error {
+	req, err := http.NewRequest("GET", pullRequestsPath(&pr.Repository)+"/"+strconv.Itoa(pr.PullRequestID)+"/statuses", nil)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Value []*Status `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return err
+	}
+	pr.Statuses = result.Value
+	return nil
+}
+
+// GetConnectionData returns information about the authenticated user. It's
+// the cheapest request that verifies the configured credentials.
+func (c *Client) GetConnectionData(ctx context.Context) (*ConnectionData, error) {
+	u := url.URL{Path: "_apis/connectionData", RawQuery: url.Values{"api-version": []string{apiVersion + "-preview"}}.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data ConnectionData
+	if _, err := c.do(ctx, req, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ListAccessControlLists returns the access control lists of the given
+// security token in the given security namespace. If recurse is true, the
+// lists of all tokens below it are returned too.
+func (c *Client) ListAccessControlLists(ctx context.Context, namespace, token string, recurse bool) ([]*AccessControlList, error) {
+	u := url.URL{
+		Path: "_apis/accesscontrollists/" + namespace,
+		RawQuery: url.Values{
+			"token":   []string{token},
+			"recurse": []string{strconv.FormatBool(recurse)},
+		}.Encode(),
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Value []*AccessControlList `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// SearchIdentities returns the identities whose display or account name
+// matches the given name.
+func (c *Client) SearchIdentities(ctx context.Context, name string) ([]*Identity, error) {
+	return c.listIdentities(ctx, url.Values{
+		"searchFilter": []string{"General"},
+		"filterValue":  []string{name},
+	})
+}
+
+// ReadIdentities returns the identities with the given descriptors. If
+// expandMembership is true, the Members and MemberOf fields of the returned
+// identities contain the descriptors of all direct and indirect members and
+// groups.
+func (c *Client) ReadIdentities(ctx context.Context, descriptors []string, expandMembership bool) ([]*Identity, error) {
+	membership := "None"
+	if expandMembership {
+		membership = "Expanded"
+	}
+
+	identities := make([]*Identity, 0, len(descriptors))
+	for len(descriptors) > 0 {
+		batch := descriptors
+		if len(batch) > identitiesBatchSize {
+			batch = batch[:identitiesBatchSize]
+		}
+		descriptors = descriptors[len(batch):]
+
+		ids, err := c.listIdentities(ctx, url.Values{
+			"descriptors":     []string{strings.Join(batch, ",")},
+			"queryMembership": []string{membership},
+		})
+		if err != nil {
+			return identities, err
+		}
+		for _, id := range ids {
+			// Descriptors that don't resolve to an identity are returned as
+			// null.
+			if id != nil {
+				identities = append(identities, id)
+			}
+		}
+	}
+	return identities, nil
+}
+
+func (c *Client) listIdentities(ctx context.Context, qry url.Values) ([]*Identity, error) {
+	u := c.identityURL().ResolveReference(&url.URL{Path: "_apis/identities", RawQuery: qry.Encode()})
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Value []*Identity `json:"value"`
+	}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Value, nil
+}
+
+// identityURL returns the URL of the identity service of the organization.
+// Azure DevOps Services serves it from a separate host, while Azure DevOps
+// Server serves it from the collection URL.
+func (c *Client) identityURL() *url.URL {
+	u := *c.URL
+	switch {
+	case u.Host == "dev.azure.com":
+		u.Host = "vssps.dev.azure.com"
+	case strings.HasSuffix(u.Host, ".visualstudio.com"):
+		u.Host = strings.TrimSuffix(u.Host, ".visualstudio.com") + ".vssps.visualstudio.com"
+	}
+	return &u
+}
+
+// setWebURL sets the URL under which the pull request can be viewed in the
+// Azure DevOps web UI.
+func (c *Client) setWebURL(pr *PullRequest) {
+	pr.WebURL = c.URL.ResolveReference(&url.URL{
+		Path: pr.Repository.Project.Name + "/_git/" + pr.Repository.Name + "/pullrequest/" + strconv.Itoa(pr.PullRequestID),
+	}).String()
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(req.URL)
+	if qry := req.URL.Query(); qry.Get("api-version") == "" {
+		qry.Set("api-version", apiVersion)
+		req.URL.RawQuery = qry.Encode()
+	}
+	req.SetBasicAuth(c.Username, c.Token)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Azure DevOps"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Azure DevOps self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure DevOps answers some requests with invalid credentials with a
+	// redirect to its sign-in page, so we treat those as errors too.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return resp, json.Unmarshal(bs, result)
+	}
+
+	return resp, nil
+}
+
+func newJSONRequest(method, path string, payload interface{}) (*http.Request, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// pullRequestsPath returns the path of the pull requests of the given
+// repository.
+func pullRequestsPath(repo *Repository) string {
+	return url.PathEscape(repo.Project.ID) + "/_apis/git/repositories/" + url.PathEscape(repo.ID) + "/pullrequests"
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is an Azure DevOps API not found error.
+func IsNotFound(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case *httpError:
+		return e.NotFound()
+	}
+	return false
+}
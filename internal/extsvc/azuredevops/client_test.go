@@ -0,0 +1,262 @@
+package azuredevops
+
+import (
+	"context"
+	"flag"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+var testURL = &url.URL{Scheme: "https", Host: "dev.azure.com", Path: "/sgtest/"}
+
+var testRepo = &Repository{
+	ID:   "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+	Name: "tools",
+	Project: Project{
+		ID:         "8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b",
+		Name:       "infra",
+		Visibility: ProjectVisibilityPrivate,
+	},
+}
+
+func timestamp(t testing.TB, s string) time.Time {
+	t.Helper()
+
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	cli, save := NewTestClient(t, "ListProjects", *update, testURL)
+	defer save()
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		ids   []string
+		next  string
+	}{
+		{
+			name: "first page",
+			ids:  []string{"8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b"},
+			next: "1",
+		},
+		{
+			name:  "last page",
+			token: "1",
+			ids:   []string{"4c2e6a8b-1d3f-4b5c-8e7a-9f1b3d5c7e9a"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			projects, next, err := cli.ListProjects(context.Background(), tc.token)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, 0, len(projects))
+			for _, p := range projects {
+				ids = append(ids, p.ID)
+			}
+			if diff := cmp.Diff(tc.ids, ids); diff != "" {
+				t.Errorf("projects mismatch (-want +have):\n%s", diff)
+			}
+			if next != tc.next {
+				t.Errorf("next: have %q, want %q", next, tc.next)
+			}
+		})
+	}
+}
+
+func TestClient_ListRepositories(t *testing.T) {
+	cli, save := NewTestClient(t, "ListRepositories", *update, testURL)
+	defer save()
+
+	repos, err := cli.ListRepositories(context.Background(), "infra")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Repository{
+		{
+			ID:            "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+			Name:          "tools",
+			Project:       Project{ID: "8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b", Name: "infra", State: "wellFormed", Visibility: ProjectVisibilityPrivate},
+			DefaultBranch: "refs/heads/main",
+			RemoteURL:     "https://sgtest@dev.azure.com/sgtest/infra/_git/tools",
+			WebURL:        "https://dev.azure.com/sgtest/infra/_git/tools",
+		},
+		{
+			ID:         "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+			Name:       "legacy",
+			Project:    Project{ID: "8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b", Name: "infra", State: "wellFormed", Visibility: ProjectVisibilityPrivate},
+			RemoteURL:  "https://sgtest@dev.azure.com/sgtest/infra/_git/legacy",
+			WebURL:     "https://dev.azure.com/sgtest/infra/_git/legacy",
+			IsDisabled: true,
+		},
+	}
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Errorf("repositories mismatch (-want +have):\n%s", diff)
+	}
+
+	_, err = cli.ListRepositories(context.Background(), "does-not-exist")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_GetPullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "GetPullRequest", *update, testURL)
+	defer save()
+
+	ctx := context.Background()
+
+	pr, err := cli.GetPullRequest(ctx, testRepo, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.LoadPullRequestVotes(ctx, pr); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.LoadPullRequestStatuses(ctx, pr); err != nil {
+		t.Fatal(err)
+	}
+
+	alice := IdentityRef{ID: "d6245f20-2af8-44f4-9451-8107cb2767db", DisplayName: "Alice", UniqueName: "alice@sourcegraph.com"}
+	bot := IdentityRef{ID: "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d", DisplayName: "Sourcegraph Bot", UniqueName: "bot@sourcegraph.com"}
+
+	want := &PullRequest{
+		PullRequestID: 7,
+		Repository: Repository{
+			ID:      "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+			Name:    "tools",
+			Project: Project{ID: "8e9a1f3c-2b4d-4f6e-9a1b-3c5d7e9f1a2b", Name: "infra", State: "wellFormed", Visibility: ProjectVisibilityPrivate},
+		},
+		Status:                PullRequestStatusActive,
+		CreatedBy:             bot,
+		CreationDate:          timestamp(t, "2020-10-14T09:00:00Z"),
+		Title:                 "Update README",
+		Description:           "Fixes the installation instructions.",
+		SourceRefName:         "refs/heads/campaigns/update-readme",
+		TargetRefName:         "refs/heads/main",
+		MergeStatus:           "succeeded",
+		LastMergeSourceCommit: &CommitRef{CommitID: "8a1f4c7b6d2e3f40a5b6c7d8e9f0a1b2c3d4e5f6"},
+		LastMergeTargetCommit: &CommitRef{CommitID: "b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6"},
+		Reviewers:             []*Reviewer{{IdentityRef: alice, Vote: VoteApproved}},
+		Votes: []*Vote{
+			{ThreadID: 12, Reviewer: alice, Value: VoteWaitingForAuthor, Date: timestamp(t, "2020-10-15T10:00:00Z")},
+			{ThreadID: 14, Reviewer: alice, Value: VoteApproved, Date: timestamp(t, "2020-10-15T11:00:00Z")},
+		},
+		Statuses: []*Status{
+			{
+				ID:           1,
+				State:        StatusStateSucceeded,
+				Description:  "Build succeeded",
+				Context:      StatusContext{Name: "build", Genre: "continuous-integration"},
+				CreationDate: timestamp(t, "2020-10-14T09:05:00Z"),
+				UpdatedDate:  timestamp(t, "2020-10-14T09:15:00Z"),
+				TargetURL:    "https://dev.azure.com/sgtest/infra/_build/results?buildId=101",
+			},
+		},
+		WebURL: "https://dev.azure.com/sgtest/infra/_git/tools/pullrequest/7",
+	}
+	if diff := cmp.Diff(want, pr); diff != "" {
+		t.Errorf("pull request mismatch (-want +have):\n%s", diff)
+	}
+
+	if have, want := pr.UpdatedAt(), timestamp(t, "2020-10-15T11:00:00Z"); !have.Equal(want) {
+		t.Errorf("updated at: have %s, want %s", have, want)
+	}
+
+	_, err = cli.GetPullRequest(ctx, testRepo, 4242)
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	cli, save := NewTestClient(t, "CreatePullRequest", *update, testURL)
+	defer save()
+
+	_, err := cli.CreatePullRequest(context.Background(), testRepo, &CreatePullRequestInput{
+		SourceRefName: "refs/heads/campaigns/update-readme",
+		TargetRefName: "refs/heads/main",
+		Title:         "Update README",
+		Description:   "Fixes the installation instructions.",
+	})
+
+	ae, ok := err.(*ErrAlreadyExists)
+	if !ok {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if have, want := ae.Existing.PullRequestID, 7; have != want {
+		t.Errorf("existing pull request: have %d, want %d", have, want)
+	}
+}
+
+func TestClient_ReadIdentities(t *testing.T) {
+	cli, save := NewTestClient(t, "ReadIdentities", *update, testURL)
+	defer save()
+
+	identities, err := cli.ReadIdentities(context.Background(), []string{
+		"Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1204400969-2402986413-2179408616-0-0-0-0-1",
+		"Microsoft.TeamFoundation.Identity;S-1-9-1551374245-0000000000-0000000000-0000000000-0-0-0-0-0",
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Identity{{
+		ID:                  "6f4b7c1e-0d2a-4e3b-9c8d-7a6b5c4d3e2f",
+		Descriptor:          "Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1204400969-2402986413-2179408616-0-0-0-0-1",
+		ProviderDisplayName: "[infra]\\Contributors",
+		IsActive:            true,
+		IsContainer:         true,
+		Members:             []string{"Microsoft.IdentityModel.Claims.ClaimsIdentity;sourcegraph.com\\alice@sourcegraph.com"},
+		MemberOf:            []string{"Microsoft.TeamFoundation.Identity;S-1-9-1551374245-1204400969-2402986413-2179408616-0-0-0-0-3"},
+		Properties: map[string]*property{
+			"Account": {Value: "Contributors"},
+		},
+	}}
+	if diff := cmp.Diff(want, identities); diff != "" {
+		t.Errorf("identities mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestClient_identityURL(t *testing.T) {
+	for rawURL, want := range map[string]string{
+		"https://dev.azure.com/sgtest/":                    "https://vssps.dev.azure.com/sgtest/",
+		"https://sgtest.visualstudio.com/":                 "https://sgtest.vssps.visualstudio.com/",
+		"https://tfs.sgdev.org/tfs/DefaultCollection/":     "https://tfs.sgdev.org/tfs/DefaultCollection/",
+		"https://tfs.sgdev.org:8080/tfs/DefaultCollection": "https://tfs.sgdev.org:8080/tfs/DefaultCollection",
+	} {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := NewClient(u, nil).identityURL().String(); have != want {
+			t.Errorf("%s: have %q, want %q", rawURL, have, want)
+		}
+	}
+}
+
+func TestIdentity_Username(t *testing.T) {
+	for account, want := range map[string]string{
+		"alice@sourcegraph.com": "alice",
+		`CORP\alice`:            "alice",
+		"alice":                 "alice",
+		"":                      "",
+	} {
+		i := &Identity{Properties: map[string]*property{"Account": {Value: account}}}
+		if have := i.Username(); have != want {
+			t.Errorf("%q: have %q, want %q", account, have, want)
+		}
+	}
+}
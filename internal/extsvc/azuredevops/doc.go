@@ -0,0 +1,3 @@
+// Package azuredevops implements an Azure DevOps (and Azure DevOps Server)
+// REST API client.
+package azuredevops
@@ -0,0 +1,296 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Project is an Azure DevOps project.
+type Project struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	State       string            `json:"state,omitempty"`
+	Visibility  ProjectVisibility `json:"visibility,omitempty"`
+}
+
+// ProjectVisibility is the visibility of a project.
+type ProjectVisibility string
+
+// Possible values of Project.Visibility.
+const (
+	ProjectVisibilityPrivate ProjectVisibility = "private"
+	ProjectVisibilityPublic  ProjectVisibility = "public"
+)
+
+// Repository is a Git repository in an Azure DevOps project.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	RemoteURL     string  `json:"remoteUrl,omitempty"`
+	WebURL        string  `json:"webUrl,omitempty"`
+	IsDisabled    bool    `json:"isDisabled,omitempty"`
+}
+
+// FullName returns the name of the repository including its project, in the
+// "project/repo" form used in the exclude configuration.
+func (r *Repository) FullName() string {
+	return r.Project.Name + "/" + r.Name
+}
+
+// IsPrivate returns true if the repository's project is private.
+func (r *Repository) IsPrivate() bool {
+	return r.Project.Visibility != ProjectVisibilityPublic
+}
+
+// PullRequestStatus is the status of a pull request.
+type PullRequestStatus string
+
+// Possible values of PullRequest.Status.
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+)
+
+// PullRequest is an Azure DevOps pull request, together with the votes and
+// statuses we sync for it.
+type PullRequest struct {
+	PullRequestID         int               `json:"pullRequestId"`
+	Repository            Repository        `json:"repository"`
+	Status                PullRequestStatus `json:"status"`
+	CreatedBy             IdentityRef       `json:"createdBy"`
+	CreationDate          time.Time         `json:"creationDate"`
+	ClosedDate            *time.Time        `json:"closedDate,omitempty"`
+	Title                 string            `json:"title"`
+	Description           string            `json:"description,omitempty"`
+	SourceRefName         string            `json:"sourceRefName"`
+	TargetRefName         string            `json:"targetRefName"`
+	MergeStatus           string            `json:"mergeStatus,omitempty"`
+	IsDraft               bool              `json:"isDraft,omitempty"`
+	LastMergeSourceCommit *CommitRef        `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *CommitRef        `json:"lastMergeTargetCommit,omitempty"`
+	Reviewers             []*Reviewer       `json:"reviewers,omitempty"`
+
+	// Votes, Statuses and WebURL are not part of the pull request resource of
+	// the API, but loaded or computed by the Client.
+	Votes    []*Vote   `json:"votes,omitempty"`
+	Statuses []*Status `json:"statuses,omitempty"`
+	WebURL   string    `json:"webUrl,omitempty"`
+}
+
+// UpdatedAt returns the time of the most recent change to the pull request we
+// know about.
+func (pr *PullRequest) UpdatedAt() time.Time {
+	t := pr.CreationDate
+	if pr.ClosedDate != nil && pr.ClosedDate.After(t) {
+		t = *pr.ClosedDate
+	}
+	for _, v := range pr.Votes {
+		if v.Date.After(t) {
+			t = v.Date
+		}
+	}
+	for _, s := range pr.Statuses {
+		if s.UpdatedDate.After(t) {
+			t = s.UpdatedDate
+		}
+	}
+	return t
+}
+
+// CommitRef references a commit.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// IdentityRef references an Azure DevOps identity.
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+	IsContainer bool   `json:"isContainer,omitempty"`
+}
+
+// Reviewer is a reviewer of a pull request and their current vote.
+type Reviewer struct {
+	IdentityRef
+	Vote       VoteValue `json:"vote"`
+	IsRequired bool      `json:"isRequired,omitempty"`
+}
+
+// VoteValue is the value of a reviewer's vote on a pull request.
+type VoteValue int
+
+// Possible values of a VoteValue.
+const (
+	VoteApproved                VoteValue = 10
+	VoteApprovedWithSuggestions VoteValue = 5
+	VoteNone                    VoteValue = 0
+	VoteWaitingForAuthor        VoteValue = -5
+	VoteRejected                VoteValue = -10
+)
+
+// Vote is a vote cast by a reviewer on a pull request. Azure DevOps records
+// every vote as a system thread on the pull request, which is what we sync
+// as changeset events.
+type Vote struct {
+	ThreadID int         `json:"threadId"`
+	Reviewer IdentityRef `json:"reviewer"`
+	Value    VoteValue   `json:"value"`
+	Date     time.Time   `json:"date"`
+}
+
+// Key is a unique key identifying this vote in the context of its pull
+// request.
+func (v *Vote) Key() string {
+	return fmt.Sprintf("vote:%d", v.ThreadID)
+}
+
+// StatusState is the state of a pull request status.
+type StatusState string
+
+// Possible values of Status.State.
+const (
+	StatusStateError         StatusState = "error"
+	StatusStateFailed        StatusState = "failed"
+	StatusStateNotApplicable StatusState = "notApplicable"
+	StatusStateNotSet        StatusState = "notSet"
+	StatusStatePending       StatusState = "pending"
+	StatusStateSucceeded     StatusState = "succeeded"
+)
+
+// Status is a status posted to a pull request, usually by a build pipeline.
+type Status struct {
+	ID           int           `json:"id"`
+	State        StatusState   `json:"state"`
+	Description  string        `json:"description,omitempty"`
+	Context      StatusContext `json:"context"`
+	CreationDate time.Time     `json:"creationDate"`
+	UpdatedDate  time.Time     `json:"updatedDate"`
+	TargetURL    string        `json:"targetUrl,omitempty"`
+}
+
+// Key is a unique key identifying this status in the context of its pull
+// request.
+func (s *Status) Key() string {
+	return "status:" + strconv.Itoa(s.ID)
+}
+
+// StatusContext identifies the service that posted a status.
+type StatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre,omitempty"`
+}
+
+// String returns the genre and name of the context, which is what the Azure
+// DevOps web UI uses to group statuses.
+func (c StatusContext) String() string {
+	if c.Genre == "" {
+		return c.Name
+	}
+	return c.Genre + "/" + c.Name
+}
+
+// thread is a comment thread on a pull request.
+type thread struct {
+	ID            int                  `json:"id"`
+	PublishedDate time.Time            `json:"publishedDate"`
+	Comments      []*comment           `json:"comments"`
+	Properties    map[string]*property `json:"properties"`
+}
+
+type comment struct {
+	Author IdentityRef `json:"author"`
+}
+
+// property is an entry of a property collection in the API.
+type property struct {
+	Value interface{} `json:"$value"`
+}
+
+func (p *property) String() string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprint(p.Value)
+}
+
+// Identity is an Azure DevOps identity, which is either a user or a group.
+type Identity struct {
+	ID                  string               `json:"id"`
+	Descriptor          string               `json:"descriptor"`
+	SubjectDescriptor   string               `json:"subjectDescriptor,omitempty"`
+	ProviderDisplayName string               `json:"providerDisplayName,omitempty"`
+	IsActive            bool                 `json:"isActive"`
+	IsContainer         bool                 `json:"isContainer"`
+	Members             []string             `json:"members,omitempty"`
+	MemberOf            []string             `json:"memberOf,omitempty"`
+	Properties          map[string]*property `json:"properties,omitempty"`
+}
+
+// AccountName returns the account name of the identity, such as
+// "alice@example.com" or "CORP\alice".
+func (i *Identity) AccountName() string {
+	return i.Properties["Account"].String()
+}
+
+// Username returns the account name of the identity without its domain.
+func (i *Identity) Username() string {
+	name := i.AccountName()
+	if j := strings.LastIndex(name, `\`); j >= 0 {
+		name = name[j+1:]
+	}
+	if j := strings.Index(name, "@"); j >= 0 {
+		name = name[:j]
+	}
+	return name
+}
+
+// GitSecurityNamespace is the ID of the security namespace of Git
+// repositories.
+const GitSecurityNamespace = "2e9eb7ed-3c0a-47d4-87c1-0ffdcd5b4a9b"
+
+// GitPermissionRead is the bit of the Read permission in the Git
+// repositories security namespace.
+const GitPermissionRead = 2
+
+// GitSecurityToken returns the security token of the Git repositories
+// security namespace for the given project and repository IDs. An empty
+// repoID returns the token of the project, and an empty projectID the one of
+// all repositories of the organization.
+func GitSecurityToken(projectID, repoID string) string {
+	token := "repoV2"
+	if projectID != "" {
+		token += "/" + projectID
+		if repoID != "" {
+			token += "/" + repoID
+		}
+	}
+	return token
+}
+
+// AccessControlList is the list of access control entries of a security
+// token.
+type AccessControlList struct {
+	Token              string                         `json:"token"`
+	InheritPermissions bool                           `json:"inheritPermissions"`
+	AcesDictionary     map[string]*AccessControlEntry `json:"acesDictionary"`
+}
+
+// AccessControlEntry are the permissions explicitly allowed and denied for a
+// descriptor.
+type AccessControlEntry struct {
+	Descriptor string `json:"descriptor"`
+	Allow      int    `json:"allow"`
+	Deny       int    `json:"deny"`
+}
+
+// ConnectionData describes the authenticated user of the client.
+type ConnectionData struct {
+	AuthenticatedUser Identity `json:"authenticatedUser"`
+}
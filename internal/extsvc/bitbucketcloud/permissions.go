@@ -0,0 +1,74 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+)
+
+// RepoPermission is the effective permission of a user on a repository of a
+// workspace, taking into account the groups the user belongs to.
+type RepoPermission struct {
+	Permission string `json:"permission"`
+	User       *User  `json:"user"`
+	Repository *Repo  `json:"repository"`
+}
+
+// WorkspaceMembership is the membership of a user in a workspace.
+type WorkspaceMembership struct {
+	User *User `json:"user"`
+}
+
+// WorkspaceRepoPermissions returns a page of the effective permissions of all
+// users on all repositories of the given workspace. It requires the app
+// password's user to be an administrator of the workspace.
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+func (c *Client) WorkspaceRepoPermissions(ctx context.Context, pageToken *PageToken, workspace string) ([]*RepoPermission, *PageToken, error) {
+	return c.repoPermissions(ctx, pageToken, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace))
+}
+
+// RepoPermissions returns a page of the effective permissions of all users on
+// the given repository of the workspace. It requires the app password's user to
+// be an administrator of the workspace.
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+func (c *Client) RepoPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	return c.repoPermissions(ctx, pageToken, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, repoSlug))
+}
+
+func (c *Client) repoPermissions(ctx context.Context, pageToken *PageToken, path string) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, path, nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
+// WorkspaceMembers returns a page of the members of the given workspace.
+// If the argument pageToken.Next is not empty, it will be used directly as the
+// URL to make the request.
+func (c *Client) WorkspaceMembers(ctx context.Context, pageToken *PageToken, workspace string) ([]*User, *PageToken, error) {
+	var members []*WorkspaceMembership
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &members)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/members", workspace), nil, pageToken, &members)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := make([]*User, 0, len(members))
+	for _, m := range members {
+		if m.User != nil {
+			users = append(users, m.User)
+		}
+	}
+	return users, next, nil
+}
@@ -0,0 +1,323 @@
+package bitbucketcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// PullRequestState is the state of a pull request.
+type PullRequestState string
+
+// Possible values of PullRequest.State.
+const (
+	PullRequestStateOpen       PullRequestState = "OPEN"
+	PullRequestStateMerged     PullRequestState = "MERGED"
+	PullRequestStateDeclined   PullRequestState = "DECLINED"
+	PullRequestStateSuperseded PullRequestState = "SUPERSEDED"
+)
+
+// PullRequest is a Bitbucket Cloud pull request, together with the commit
+// statuses we sync for it.
+type PullRequest struct {
+	ID           int64               `json:"id"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	State        PullRequestState    `json:"state"`
+	Author       User                `json:"author"`
+	Source       PullRequestEndpoint `json:"source"`
+	Destination  PullRequestEndpoint `json:"destination"`
+	MergeCommit  *Commit             `json:"merge_commit,omitempty"`
+	Participants []*Participant      `json:"participants"`
+	Links        Links               `json:"links"`
+	CreatedOn    time.Time           `json:"created_on"`
+	UpdatedOn    time.Time           `json:"updated_on"`
+
+	// Statuses is not part of the pull request resource of the API, but
+	// loaded by the Client.
+	Statuses []*CommitStatus `json:"statuses,omitempty"`
+}
+
+// RepoFullName returns the full name of the repository the pull request
+// belongs to.
+func (pr *PullRequest) RepoFullName() string {
+	if pr.Destination.Repository == nil {
+		return ""
+	}
+	return pr.Destination.Repository.FullName
+}
+
+// PullRequestEndpoint is the source or destination of a pull request.
+type PullRequestEndpoint struct {
+	Branch     Branch  `json:"branch"`
+	Commit     *Commit `json:"commit,omitempty"`
+	Repository *Repo   `json:"repository,omitempty"`
+}
+
+// Branch is a branch of a repository.
+type Branch struct {
+	Name string `json:"name"`
+}
+
+// Commit references a commit. Bitbucket Cloud abbreviates the hashes of the
+// commits referenced by pull requests.
+type Commit struct {
+	Hash string `json:"hash"`
+}
+
+// User is a Bitbucket Cloud account.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id,omitempty"`
+	Nickname    string `json:"nickname,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// ParticipantRole is the role of a participant in a pull request.
+type ParticipantRole string
+
+// Possible values of Participant.Role.
+const (
+	ParticipantRoleParticipant ParticipantRole = "PARTICIPANT"
+	ParticipantRoleReviewer    ParticipantRole = "REVIEWER"
+)
+
+// ParticipantState is the review state of a participant.
+type ParticipantState string
+
+// Possible values of Participant.State. Participants that neither approved
+// nor requested changes have no state.
+const (
+	ParticipantStateApproved         ParticipantState = "approved"
+	ParticipantStateChangesRequested ParticipantState = "changes_requested"
+)
+
+// Participant is a reviewer of a pull request or a user who commented on it,
+// together with their current review state.
+type Participant struct {
+	User           User             `json:"user"`
+	Role           ParticipantRole  `json:"role"`
+	Approved       bool             `json:"approved"`
+	State          ParticipantState `json:"state,omitempty"`
+	ParticipatedOn *time.Time       `json:"participated_on,omitempty"`
+}
+
+// Key is a unique key identifying this participant in the context of its pull
+// request.
+func (p *Participant) Key() string {
+	return "participant:" + p.User.UUID
+}
+
+// CommitStatusState is the state of a commit status.
+type CommitStatusState string
+
+// Possible values of CommitStatus.State.
+const (
+	CommitStatusStateSuccessful CommitStatusState = "SUCCESSFUL"
+	CommitStatusStateFailed     CommitStatusState = "FAILED"
+	CommitStatusStateInProgress CommitStatusState = "INPROGRESS"
+	CommitStatusStateStopped    CommitStatusState = "STOPPED"
+)
+
+// CommitStatus is a build status reported for a commit of a pull request.
+type CommitStatus struct {
+	UUID        string            `json:"uuid"`
+	BuildKey    string            `json:"key"`
+	Name        string            `json:"name,omitempty"`
+	State       CommitStatusState `json:"state"`
+	Description string            `json:"description,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	CreatedOn   time.Time         `json:"created_on"`
+	UpdatedOn   time.Time         `json:"updated_on"`
+}
+
+// Key is a unique key identifying this status in the context of its pull
+// request.
+func (s *CommitStatus) Key() string {
+	return "status:" + s.UUID
+}
+
+// CreatePullRequestInput contains the fields of a new pull request.
+type CreatePullRequestInput struct {
+	Title             string              `json:"title"`
+	Description       string              `json:"description"`
+	Source            PullRequestEndpoint `json:"source"`
+	Destination       PullRequestEndpoint `json:"destination"`
+	CloseSourceBranch bool                `json:"close_source_branch"`
+}
+
+// CreatePullRequest creates a pull request in the given repository.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repo, input *CreatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", pullRequestsPath(repo.FullName), input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// FindOpenPullRequest returns the open pull request from the given source
+// branch into the given destination branch of the repository, or nil if
+// there is none.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repo *Repo, source, destination string) (*PullRequest, error) {
+	qry := url.Values{"q": []string{fmt.Sprintf(
+		"source.branch.name = %s AND destination.branch.name = %s AND state = %q",
+		strconv.Quote(source),
+		strconv.Quote(destination),
+		PullRequestStateOpen,
+	)}}
+
+	var prs []*PullRequest
+	if _, err := c.page(ctx, pullRequestsPath(repo.FullName), qry, nil, &prs); err != nil {
+		return nil, err
+	}
+
+	for _, pr := range prs {
+		// The source branch of a pull request can also belong to a fork.
+		if pr.Source.Repository == nil || pr.Source.Repository.UUID == repo.UUID {
+			return pr, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetPullRequest returns the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repo, id int64) (*PullRequest, error) {
+	req, err := http.NewRequest("GET", pullRequestPath(repo.FullName, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequestInput contains the fields of a pull request that can be
+// updated.
+type UpdatePullRequestInput struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Destination PullRequestEndpoint `json:"destination"`
+}
+
+// UpdatePullRequest updates the title, description and destination branch of
+// the given pull request.
+func (c *Client) UpdatePullRequest(ctx context.Context, pr *PullRequest, input *UpdatePullRequestInput) (*PullRequest, error) {
+	req, err := newJSONRequest("PUT", pullRequestPath(pr.RepoFullName(), pr.ID), input)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated PullRequest
+	if err := c.do(ctx, req, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeclinePullRequest declines the given pull request. Declined pull requests
+// can't be reopened in Bitbucket Cloud.
+func (c *Client) DeclinePullRequest(ctx context.Context, pr *PullRequest) (*PullRequest, error) {
+	req, err := http.NewRequest("POST", pullRequestPath(pr.RepoFullName(), pr.ID)+"/decline", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var declined PullRequest
+	if err := c.do(ctx, req, &declined); err != nil {
+		return nil, err
+	}
+	return &declined, nil
+}
+
+// Merge strategies supported by Bitbucket Cloud, as passed to
+// MergePullRequest.
+const (
+	MergeStrategyMergeCommit = "merge_commit"
+	MergeStrategySquash      = "squash"
+)
+
+// MergePullRequest merges the given pull request with the given merge
+// strategy and returns the merged pull request.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, strategy string) (*PullRequest, error) {
+	req, err := newJSONRequest("POST", pullRequestPath(pr.RepoFullName(), pr.ID)+"/merge", struct {
+		MergeStrategy string `json:"merge_strategy,omitempty"`
+	}{strategy})
+	if err != nil {
+		return nil, err
+	}
+
+	var merged PullRequest
+	if err := c.do(ctx, req, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// LoadPullRequestStatuses loads the statuses reported for the commits of the
+// given pull request into its Statuses field.
+func (c *Client) LoadPullRequestStatuses(ctx context.Context, pr *PullRequest) error {
+	var statuses []*CommitStatus
+
+	page := &PageToken{Pagelen: 100}
+	for {
+		var batch []*CommitStatus
+
+		var err error
+		if page.HasMore() {
+			page, err = c.reqPage(ctx, page.Next, &batch)
+		} else {
+			page, err = c.page(ctx, pullRequestPath(pr.RepoFullName(), pr.ID)+"/statuses", nil, page, &batch)
+		}
+		if err != nil {
+			return err
+		}
+
+		statuses = append(statuses, batch...)
+		if !page.HasMore() {
+			break
+		}
+	}
+
+	pr.Statuses = statuses
+	return nil
+}
+
+func pullRequestsPath(repoFullName string) string {
+	return fmt.Sprintf("/2.0/repositories/%s/pullrequests", repoFullName)
+}
+
+func pullRequestPath(repoFullName string, id int64) string {
+	return fmt.Sprintf("%s/%d", pullRequestsPath(repoFullName), id)
+}
+
+func newJSONRequest(method, path string, body interface{}) (*http.Request, error) {
+	bs, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling request body")
+	}
+	return http.NewRequest(method, path, bytes.NewReader(bs))
+}
+
+// IsNotFound reports whether err is a Bitbucket Cloud API not-found error.
+func IsNotFound(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case *httpError:
+		return e.NotFound()
+	}
+	return false
+}
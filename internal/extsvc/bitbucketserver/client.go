@@ -573,6 +573,42 @@ func (c *Client) ReopenPullRequest(ctx context.Context, pr *PullRequest) error {
 	return c.send(ctx, "POST", path, qry, nil, pr)
 }
 
+// Merge strategies supported by Bitbucket Server, as passed to
+// MergePullRequest.
+const (
+	MergeStrategyNoFastForward       = "no-ff"
+	MergeStrategySquash              = "squash"
+	MergeStrategyRebaseNoFastForward = "rebase-no-ff"
+)
+
+// MergePullRequest merges the given PullRequest with the given merge
+// strategy, returning an error in case of failure. If strategy is empty, the
+// repository's default merge strategy is used.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, strategy string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	payload := struct {
+		StrategyID string `json:"strategyId,omitempty"`
+	}{StrategyID: strategy}
+
+	return c.send(ctx, "POST", path, qry, &payload, pr)
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
@@ -0,0 +1,61 @@
+package gerrit
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// ChangeID returns the Change-Id used for the change that is created from the
+// given head ref in the given repository.
+//
+// Gerrit identifies the change a pushed commit belongs to by the Change-Id
+// trailer in its commit message. Deriving the ID from the repository and head
+// ref means that every push of the same changeset results in a new patch set
+// of the same change, instead of a new change.
+func ChangeID(repo, headRef string) string {
+	sum := sha1.Sum([]byte(repo + "\x00" + strings.TrimPrefix(headRef, "refs/heads/")))
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+var (
+	changeIDTrailer = lazyregexp.New(`(?m)^Change-Id: I[0-9a-f]+\n?`)
+	trailerLine     = lazyregexp.New(`^[A-Za-z0-9-]+: `)
+)
+
+// AddChangeIDTrailer returns the commit message with a Change-Id trailer for
+// the given ID. An existing Change-Id trailer is replaced, and the trailer is
+// appended to an existing trailer block, since Gerrit only considers the last
+// paragraph of a commit message.
+func AddChangeIDTrailer(message, changeID string) string {
+	message = strings.TrimRight(changeIDTrailer.ReplaceAllString(message, ""), "\n")
+
+	paragraphs := strings.Split(message, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+
+	sep := "\n\n"
+	if len(paragraphs) > 1 {
+		isTrailerBlock := true
+		for _, line := range strings.Split(last, "\n") {
+			if !trailerLine.MatchString(line) {
+				isTrailerBlock = false
+				break
+			}
+		}
+		if isTrailerBlock {
+			sep = "\n"
+		}
+	}
+
+	return message + sep + "Change-Id: " + changeID + "\n"
+}
+
+// PushRef returns the magic ref that a commit has to be pushed to in order to
+// create a change (or a new patch set of an existing change) against the
+// given base branch. The change's topic is set to the abbreviated head ref, so
+// that it can be recognized in Gerrit.
+func PushRef(baseRef, headRef string) string {
+	return "refs/for/" + strings.TrimPrefix(baseRef, "refs/heads/") + "%topic=" + strings.TrimPrefix(headRef, "refs/heads/")
+}
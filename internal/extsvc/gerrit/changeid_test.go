@@ -0,0 +1,61 @@
+package gerrit
+
+import "testing"
+
+func TestChangeID(t *testing.T) {
+	want := "I2679defd71cf82bbf8bd90845e8176da9a8e4baa"
+	for _, ref := range []string{"campaigns/update-readme", "refs/heads/campaigns/update-readme"} {
+		if have := ChangeID("gerrit.sgdev.org/infra/tools", ref); have != want {
+			t.Errorf("ChangeID(%q): have %q, want %q", ref, have, want)
+		}
+	}
+
+	if ChangeID("gerrit.sgdev.org/infra/other", "campaigns/update-readme") == want {
+		t.Error("expected different Change-Id for different repository")
+	}
+}
+
+func TestAddChangeIDTrailer(t *testing.T) {
+	const id = "I2679defd71cf82bbf8bd90845e8176da9a8e4baa"
+
+	for _, tc := range []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{
+			name:    "subject only",
+			message: "Update README",
+			want:    "Update README\n\nChange-Id: " + id + "\n",
+		},
+		{
+			name:    "subject and body",
+			message: "Update README\n\nFixes the installation instructions.\n",
+			want:    "Update README\n\nFixes the installation instructions.\n\nChange-Id: " + id + "\n",
+		},
+		{
+			name:    "existing trailers",
+			message: "Update README\n\nSigned-off-by: Alice <alice@example.com>\n",
+			want:    "Update README\n\nSigned-off-by: Alice <alice@example.com>\nChange-Id: " + id + "\n",
+		},
+		{
+			name:    "existing Change-Id",
+			message: "Update README\n\nChange-Id: I0123456789abcdef0123456789abcdef01234567\n",
+			want:    "Update README\n\nChange-Id: " + id + "\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := AddChangeIDTrailer(tc.message, id); have != tc.want {
+				t.Errorf("have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestPushRef(t *testing.T) {
+	have := PushRef("refs/heads/master", "refs/heads/campaigns/update-readme")
+	want := "refs/for/master%topic=campaigns/update-readme"
+	if have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
@@ -0,0 +1,316 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestMeter("gerrit_requests_count", "Total number of requests sent to the Gerrit API.")
+
+// These fields define the self-imposed Gerrit rate limit. Gerrit does not
+// report rate limits in HTTP response headers, so like for Bitbucket we
+// always enforce a limit of our own.
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// PerPage is the number of projects requested per page.
+const PerPage = 100
+
+// changeOptions are the additional fields requested for every change. They
+// give us the votes of every reviewer, and the commit of the current patch
+// set.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#query-options.
+var changeOptions = []string{"DETAILED_LABELS", "DETAILED_ACCOUNTS", "CURRENT_REVISION", "CURRENT_COMMIT"}
+
+// xssiPrefix is prepended by Gerrit to every JSON response body to prevent
+// cross-site script inclusion.
+var xssiPrefix = []byte(")]}'")
+
+// Client access a Gerrit instance via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the Gerrit instance.
+	URL *url.URL
+
+	// Username and Password are the HTTP credentials used to authenticate
+	// requests. If Username is empty, requests are made anonymously.
+	Username string
+	Password string
+
+	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a
+	// concept of rate limiting in HTTP response headers).
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Gerrit API client for the instance at baseURL. If a
+// nil httpClient is provided, http.DefaultClient will be used. The Username
+// and Password fields should be set before calling APIs that require
+// authentication.
+func NewClient(baseURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The first component of the Path below the base URL (and the "a/"
+		// prefix of authenticated requests) maps to the type of API request
+		// we are making.
+		p := strings.TrimPrefix(u.Path, baseURL.Path)
+		p = strings.TrimPrefix(p, "a/")
+		return strings.SplitN(p, "/", 2)[0]
+	})
+
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		RateLimit:  l,
+	}
+}
+
+// ListProjects returns the page of projects visible to the user starting at
+// the given offset, sorted by name. hasNextPage is true if there are more
+// pages.
+func (c *Client) ListProjects(ctx context.Context, start int) (projects []*Project, hasNextPage bool, err error) {
+	qry := url.Values{
+		"d": []string{""}, // include descriptions
+		"n": []string{strconv.Itoa(PerPage)},
+		"S": []string{strconv.Itoa(start)},
+	}
+
+	u := url.URL{Path: "projects/", RawQuery: qry.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Projects are returned as a map from project name to project.
+	var result map[string]*Project
+	if err := c.do(ctx, req, &result); err != nil {
+		return nil, false, err
+	}
+
+	projects = make([]*Project, 0, len(result))
+	for name, p := range result {
+		p.Name = name
+		if p.MoreProjects {
+			hasNextPage = true
+		}
+		projects = append(projects, p)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	return projects, hasNextPage, nil
+}
+
+// GetProject returns the project with the given name.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	req, err := http.NewRequest("GET", "projects/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	var project Project
+	if err := c.do(ctx, req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// GetChange returns the change with the given number in the given project,
+// including the votes of all reviewers and the current patch set.
+func (c *Client) GetChange(ctx context.Context, project string, number int) (*Change, error) {
+	u := url.URL{
+		Path:     "changes/" + changeIdentifier(project, number),
+		RawQuery: url.Values{"o": changeOptions}.Encode(),
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var change Change
+	if err := c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	c.setWebURL(&change)
+	return &change, nil
+}
+
+// QueryChanges returns the changes matching the given search query, including
+// the votes of all reviewers and the current patch set.
+//
+// See https://gerrit-review.googlesource.com/Documentation/user-search.html
+// for the query syntax.
+func (c *Client) QueryChanges(ctx context.Context, query string) ([]*Change, error) {
+	u := url.URL{
+		Path:     "changes/",
+		RawQuery: url.Values{"q": []string{query}, "o": changeOptions}.Encode(),
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var changes []*Change
+	if err := c.do(ctx, req, &changes); err != nil {
+		return nil, err
+	}
+	for _, ch := range changes {
+		c.setWebURL(ch)
+	}
+	return changes, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, change *Change) error {
+	return c.changeAction(ctx, change, "abandon", struct{}{})
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, change *Change) error {
+	return c.changeAction(ctx, change, "restore", struct{}{})
+}
+
+// MoveChange moves the given change to the destination branch.
+func (c *Client) MoveChange(ctx context.Context, change *Change, branch string) error {
+	return c.changeAction(ctx, change, "move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{branch})
+}
+
+// SubmitChange submits the given change, merging it into its destination
+// branch using the submit type configured for the project.
+func (c *Client) SubmitChange(ctx context.Context, change *Change) error {
+	return c.changeAction(ctx, change, "submit", struct{}{})
+}
+
+func (c *Client) changeAction(ctx context.Context, change *Change, action string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	path := "changes/" + changeIdentifier(change.Project, change.Number) + "/" + action
+	req, err := http.NewRequest("POST", path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(ctx, req, nil)
+}
+
+// setWebURL sets the URL under which the change can be viewed in the Gerrit
+// web UI.
+func (c *Client) setWebURL(change *Change) {
+	change.WebURL = c.URL.ResolveReference(&url.URL{
+		Path: "c/" + change.Project + "/+/" + strconv.Itoa(change.Number),
+	}).String()
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	// Authenticated requests are served below the "/a/" prefix.
+	prefix := ""
+	if c.Username != "" {
+		prefix = "a/"
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	req.URL = c.URL.ResolveReference(&url.URL{Path: prefix}).ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gerrit"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gerrit self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bytes.TrimPrefix(bs, xssiPrefix), result)
+	}
+
+	return nil
+}
+
+// changeIdentifier returns the identifier of a change in the REST API.
+func changeIdentifier(project string, number int) string {
+	return url.PathEscape(project) + "~" + strconv.Itoa(number)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gerrit API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is a Gerrit API not found error.
+func IsNotFound(err error) bool {
+	switch e := errors.Cause(err).(type) {
+	case *httpError:
+		return e.NotFound()
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package gerrit
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+var testURL = &url.URL{Scheme: "https", Host: "gerrit.sgdev.org", Path: "/"}
+
+func names(projects []*Project) []string {
+	names := make([]string, 0, len(projects))
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func timestamp(t testing.TB, s string) Timestamp {
+	t.Helper()
+
+	ts, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Timestamp{ts}
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	cli, save := NewTestClient(t, "ListProjects", *update, testURL)
+	defer save()
+
+	for _, tc := range []struct {
+		name        string
+		start       int
+		projects    []string
+		hasNextPage bool
+	}{
+		{
+			name:        "first page",
+			start:       0,
+			projects:    []string{"All-Projects", "All-Users", "infra/tools"},
+			hasNextPage: true,
+		},
+		{
+			name:     "last page",
+			start:    PerPage,
+			projects: []string{"attic/legacy", "secret/hidden", "sourcegraph/src-cli"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			projects, hasNextPage, err := cli.ListProjects(context.Background(), tc.start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.projects, names(projects)); diff != "" {
+				t.Errorf("projects mismatch (-want +have):\n%s", diff)
+			}
+			if hasNextPage != tc.hasNextPage {
+				t.Errorf("hasNextPage: have %v, want %v", hasNextPage, tc.hasNextPage)
+			}
+		})
+	}
+}
+
+func TestClient_GetProject(t *testing.T) {
+	cli, save := NewTestClient(t, "GetProject", *update, testURL)
+	defer save()
+
+	project, err := cli.GetProject(context.Background(), "infra/tools")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Project{
+		ID:          "infra%2Ftools",
+		Name:        "infra/tools",
+		Parent:      "All-Projects",
+		Description: "Internal tooling",
+		State:       ProjectStateActive,
+	}
+	if diff := cmp.Diff(want, project); diff != "" {
+		t.Errorf("project mismatch (-want +have):\n%s", diff)
+	}
+
+	_, err = cli.GetProject(context.Background(), "does-not-exist")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_GetChange(t *testing.T) {
+	cli, save := NewTestClient(t, "GetChange", *update, testURL)
+	defer save()
+
+	change, err := cli.GetChange(context.Background(), "infra/tools", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice := Account{ID: 1000001, Name: "Alice", Email: "alice@sourcegraph.com", Username: "alice"}
+	bob := Account{ID: 1000002, Name: "Bob", Email: "bob@sourcegraph.com", Username: "bob"}
+	ci := Account{ID: 1000003, Name: "CI", Username: "ci"}
+	reviewed := timestamp(t, "2020-10-15 10:00:00.000000000")
+	verified := timestamp(t, "2020-10-15 10:30:00.000000000")
+
+	want := &Change{
+		ID:       "infra%2Ftools~master~I2679defd71cf82bbf8bd90845e8176da9a8e4baa",
+		Project:  "infra/tools",
+		Branch:   "master",
+		Topic:    "campaigns/update-readme",
+		ChangeID: "I2679defd71cf82bbf8bd90845e8176da9a8e4baa",
+		Subject:  "Update README",
+		Status:   ChangeStatusNew,
+		Created:  timestamp(t, "2020-10-14 09:00:00.000000000"),
+		Updated:  timestamp(t, "2020-10-15 10:30:00.000000000"),
+		Number:   42,
+		Owner:    &Account{ID: 1000000, Name: "Sourcegraph Bot", Email: "bot@sourcegraph.com", Username: "sourcegraph-bot"},
+		Labels: map[string]*Label{
+			LabelCodeReview: {
+				Approved: &alice,
+				All: []*Approval{
+					{Account: alice, Value: 2, Date: &reviewed},
+					{Account: bob},
+				},
+			},
+			LabelVerified: {
+				Approved: &ci,
+				All:      []*Approval{{Account: ci, Value: 1, Date: &verified}},
+			},
+		},
+		CurrentRevision: "8a1f4c7b6d2e3f40a5b6c7d8e9f0a1b2c3d4e5f6",
+		Revisions: map[string]*Revision{
+			"8a1f4c7b6d2e3f40a5b6c7d8e9f0a1b2c3d4e5f6": {
+				Number: 2,
+				Ref:    "refs/changes/42/42/2",
+				Commit: &Commit{
+					Parents: []*ParentCommit{{Commit: "b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6", Subject: "Initial commit"}},
+					Subject: "Update README",
+					Message: "Update README\n\nFixes the installation instructions.\n\nChange-Id: I2679defd71cf82bbf8bd90845e8176da9a8e4baa\n",
+				},
+			},
+		},
+		WebURL: "https://gerrit.sgdev.org/c/infra/tools/+/42",
+	}
+	if diff := cmp.Diff(want, change); diff != "" {
+		t.Errorf("change mismatch (-want +have):\n%s", diff)
+	}
+
+	wantVotes := []*Vote{
+		{Label: LabelCodeReview, Account: alice, Value: 2, Date: reviewed},
+		{Label: LabelVerified, Account: ci, Value: 1, Date: verified},
+	}
+	if diff := cmp.Diff(wantVotes, change.Votes()); diff != "" {
+		t.Errorf("votes mismatch (-want +have):\n%s", diff)
+	}
+
+	_, err = cli.GetChange(context.Background(), "infra/tools", 4242)
+	if have, want := fmt.Sprint(err), `Gerrit API HTTP error: code=404 url="https://gerrit.sgdev.org/a/changes/infra%2Ftools~4242?o=DETAILED_LABELS&o=DETAILED_ACCOUNTS&o=CURRENT_REVISION&o=CURRENT_COMMIT" body="Not found: infra/tools~4242\n"`; have != want {
+		t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+	}
+	if !IsNotFound(err) {
+		t.Error("expected not found error")
+	}
+}
+
+func TestClient_QueryChanges(t *testing.T) {
+	cli, save := NewTestClient(t, "QueryChanges", *update, testURL)
+	defer save()
+
+	changes, err := cli.QueryChanges(context.Background(), "change:I2679defd71cf82bbf8bd90845e8176da9a8e4baa project:infra/tools branch:master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+	if have, want := changes[0].Number, 42; have != want {
+		t.Errorf("number: have %d, want %d", have, want)
+	}
+	if have, want := changes[0].WebURL, "https://gerrit.sgdev.org/c/infra/tools/+/42"; have != want {
+		t.Errorf("web URL: have %q, want %q", have, want)
+	}
+}
+
+func TestClient_AbandonChange(t *testing.T) {
+	cli, save := NewTestClient(t, "AbandonChange", *update, testURL)
+	defer save()
+
+	err := cli.AbandonChange(context.Background(), &Change{Project: "infra/tools", Number: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2020, 10, 15, 10, 30, 0, 123000000, time.UTC)
+
+	for _, data := range []string{
+		`"2020-10-15 10:30:00.123000000"`,
+		`"2020-10-15T10:30:00.123Z"`,
+	} {
+		var ts Timestamp
+		if err := ts.UnmarshalJSON([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+		if !ts.Equal(want) {
+			t.Errorf("%s: have %s, want %s", data, ts, want)
+		}
+	}
+}
+
+func TestChange_Body(t *testing.T) {
+	for _, tc := range []struct {
+		message string
+		want    string
+	}{
+		{"Update README\n\nChange-Id: I2679defd71cf82bbf8bd90845e8176da9a8e4baa\n", ""},
+		{"Update README\n\nFixes the installation instructions.\n\nChange-Id: I2679defd71cf82bbf8bd90845e8176da9a8e4baa\n", "Fixes the installation instructions."},
+	} {
+		c := &Change{
+			CurrentRevision: "abc",
+			Revisions:       map[string]*Revision{"abc": {Commit: &Commit{Message: tc.message}}},
+		}
+		if have := c.Body(); have != tc.want {
+			t.Errorf("have %q, want %q", have, tc.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package gerrit
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// ExternalRepoSpec returns an api.ExternalRepoSpec that refers to the specified Gerrit project.
+func ExternalRepoSpec(project *Project, baseURL url.URL) api.ExternalRepoSpec {
+	return api.ExternalRepoSpec{
+		ID:          project.ID,
+		ServiceType: extsvc.TypeGerrit,
+		ServiceID:   extsvc.NormalizeBaseURL(&baseURL).String(),
+	}
+}
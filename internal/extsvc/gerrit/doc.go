@@ -0,0 +1,2 @@
+// Package gerrit implements a Gerrit REST API client.
+package gerrit
@@ -0,0 +1,200 @@
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Project is a Gerrit project.
+type Project struct {
+	// ID is the URL encoded project name.
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Parent      string `json:"parent,omitempty"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+
+	// MoreProjects is set on the last project of a listing if the listing
+	// was truncated.
+	MoreProjects bool `json:"_more_projects,omitempty"`
+}
+
+// Possible values of Project.State.
+const (
+	ProjectStateActive   = "ACTIVE"
+	ProjectStateReadOnly = "READ_ONLY"
+	ProjectStateHidden   = "HIDDEN"
+)
+
+// ChangeStatus is the status of a change.
+type ChangeStatus string
+
+// Possible values of Change.Status.
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// The labels whose votes are synced into changeset events.
+const (
+	LabelCodeReview = "Code-Review"
+	LabelVerified   = "Verified"
+)
+
+// Change is a Gerrit change, as returned with the options of GetChange.
+type Change struct {
+	ID              string               `json:"id"`
+	Project         string               `json:"project"`
+	Branch          string               `json:"branch"`
+	Topic           string               `json:"topic,omitempty"`
+	ChangeID        string               `json:"change_id"`
+	Subject         string               `json:"subject"`
+	Status          ChangeStatus         `json:"status"`
+	Created         Timestamp            `json:"created"`
+	Updated         Timestamp            `json:"updated"`
+	Number          int                  `json:"_number"`
+	Owner           *Account             `json:"owner,omitempty"`
+	Labels          map[string]*Label    `json:"labels,omitempty"`
+	CurrentRevision string               `json:"current_revision,omitempty"`
+	Revisions       map[string]*Revision `json:"revisions,omitempty"`
+
+	// WebURL is not part of the Gerrit API, but set by the Client to the URL
+	// of the change in the Gerrit web UI.
+	WebURL string `json:"web_url,omitempty"`
+}
+
+// Current returns the current revision (patch set) of the change, or nil if it
+// wasn't requested.
+func (c *Change) Current() *Revision {
+	return c.Revisions[c.CurrentRevision]
+}
+
+// Body returns the commit message of the current revision without its subject
+// line and Change-Id trailer, which is what other code hosts call the
+// description of a pull request.
+func (c *Change) Body() string {
+	rev := c.Current()
+	if rev == nil || rev.Commit == nil {
+		return ""
+	}
+
+	msg := changeIDTrailer.ReplaceAllString(rev.Commit.Message, "")
+	if i := strings.Index(msg, "\n\n"); i >= 0 {
+		return strings.TrimSpace(msg[i+2:])
+	}
+	return ""
+}
+
+// Votes returns the dated votes on the Code-Review and Verified labels of the
+// change, sorted by date.
+func (c *Change) Votes() []*Vote {
+	var votes []*Vote
+	for _, name := range []string{LabelCodeReview, LabelVerified} {
+		label, ok := c.Labels[name]
+		if !ok {
+			continue
+		}
+		for _, a := range label.All {
+			// Reviewers that haven't voted yet have no date.
+			if a.Date == nil {
+				continue
+			}
+			votes = append(votes, &Vote{
+				Label:   name,
+				Account: a.Account,
+				Value:   a.Value,
+				Date:    *a.Date,
+			})
+		}
+	}
+	sort.SliceStable(votes, func(i, j int) bool { return votes[i].Date.Before(votes[j].Date.Time) })
+	return votes
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int     `json:"_number"`
+	Ref    string  `json:"ref"`
+	Commit *Commit `json:"commit,omitempty"`
+}
+
+// Commit is the commit of a patch set.
+type Commit struct {
+	Parents []*ParentCommit `json:"parents"`
+	Subject string          `json:"subject"`
+	Message string          `json:"message"`
+}
+
+// ParentCommit is a parent of a patch set's commit.
+type ParentCommit struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// Label is a review label of a change, such as Code-Review or Verified.
+type Label struct {
+	Optional bool        `json:"optional,omitempty"`
+	Approved *Account    `json:"approved,omitempty"`
+	Rejected *Account    `json:"rejected,omitempty"`
+	All      []*Approval `json:"all,omitempty"`
+}
+
+// Approval is the vote of a reviewer on a label.
+type Approval struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// Account is a Gerrit user account.
+type Account struct {
+	ID       int    `json:"_account_id"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Vote is a single vote of a reviewer on a label of a change. Gerrit has no
+// event log for changes, so votes are what we sync as changeset events.
+type Vote struct {
+	Label   string    `json:"label"`
+	Account Account   `json:"account"`
+	Value   int       `json:"value"`
+	Date    Timestamp `json:"date"`
+}
+
+// Key is a unique key identifying this vote in the context of its change.
+func (v *Vote) Key() string {
+	return fmt.Sprintf("%s:%d:%d", v.Label, v.Account.ID, v.Date.UnixNano())
+}
+
+// timestampLayout is the format of timestamps in the Gerrit REST API. They're
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a type that can unmarshal the timestamps of the Gerrit REST
+// API. It marshals as an RFC 3339 timestamp, so that metadata we stored can be
+// read back.
+type Timestamp struct{ time.Time }
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	// First, try the RFC 3339 encoding we marshal to.
+	if err := t.Time.UnmarshalJSON(data); err == nil {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	dec, err := time.Parse(timestampLayout, s)
+	if err == nil {
+		t.Time = dec
+	}
+	return err
+}
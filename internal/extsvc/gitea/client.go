@@ -0,0 +1,259 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/linkheader"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestMeter("gitea_requests_count", "Total number of requests sent to the Gitea API.")
+
+// These fields define the self-imposed Gitea rate limit. Gitea does not
+// report rate limits in HTTP response headers, so like for Bitbucket we
+// always enforce a limit of our own.
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// PerPage is the number of repositories requested per page. Gitea caps it at
+// the instance's MAX_RESPONSE_ITEMS setting, which defaults to 50.
+const PerPage = 50
+
+// Client access a Gitea instance via the REST API v1.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the Gitea instance.
+	URL *url.URL
+
+	// Token is the access token used to authenticate requests.
+	Token string
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a
+	// concept of rate limiting in HTTP response headers).
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Gitea API client for the instance at baseURL. If a
+// nil httpClient is provided, http.DefaultClient will be used. The Token field
+// should be set before calling any APIs.
+func NewClient(baseURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	// API paths are resolved relative to the base URL, so its path must end
+	// in a slash for instances served under a subpath such as
+	// https://example.com/gitea. Otherwise its last segment would be dropped.
+	u := *baseURL
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	baseURL = &u
+
+	apiPath := baseURL.Path + "api/v1/"
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The first component of the Path after {base}/api/v1/ mostly maps to
+		// the type of API request we are making.
+		return strings.SplitN(strings.TrimPrefix(u.Path, apiPath), "/", 2)[0]
+	})
+
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		RateLimit:  l,
+	}
+}
+
+// ListOrgRepos returns the given page (starting at 1) of repositories owned by
+// the organization org that are visible to the token's user. hasNextPage is
+// true if there are more pages.
+func (c *Client) ListOrgRepos(ctx context.Context, org string, page int) (repos []*Repo, hasNextPage bool, err error) {
+	hasNextPage, err = c.page(ctx, "orgs/"+url.PathEscape(org)+"/repos", nil, page, &repos)
+	return repos, hasNextPage, err
+}
+
+// ListUserRepos returns the given page (starting at 1) of repositories owned
+// by the user with the given login that are visible to the token's user.
+// hasNextPage is true if there are more pages.
+func (c *Client) ListUserRepos(ctx context.Context, user string, page int) (repos []*Repo, hasNextPage bool, err error) {
+	hasNextPage, err = c.page(ctx, "users/"+url.PathEscape(user)+"/repos", nil, page, &repos)
+	return repos, hasNextPage, err
+}
+
+// ListAffiliatedRepos returns the given page (starting at 1) of repositories
+// the token's user has access to. hasNextPage is true if there are more pages.
+func (c *Client) ListAffiliatedRepos(ctx context.Context, page int) (repos []*Repo, hasNextPage bool, err error) {
+	hasNextPage, err = c.page(ctx, "user/repos", nil, page, &repos)
+	return repos, hasNextPage, err
+}
+
+// SearchRepos returns the given page (starting at 1) of repositories matching
+// the keyword query that are visible to the token's user. hasNextPage is true
+// if there are more pages.
+func (c *Client) SearchRepos(ctx context.Context, query string, page int) (repos []*Repo, hasNextPage bool, err error) {
+	var result struct {
+		OK   bool    `json:"ok"`
+		Data []*Repo `json:"data"`
+	}
+	hasNextPage, err = c.page(ctx, "repos/search", url.Values{"q": []string{query}}, page, &result)
+	if err != nil {
+		return nil, false, err
+	}
+	if !result.OK {
+		return nil, false, errors.Errorf("gitea repository search for %q failed", query)
+	}
+	return result.Data, hasNextPage, nil
+}
+
+// GetRepo returns the repository with the given "owner/name".
+func (c *Client) GetRepo(ctx context.Context, nameWithOwner string) (*Repo, error) {
+	req, err := http.NewRequest("GET", "repos/"+nameWithOwner, nil)
+	if err != nil {
+		return nil, err
+	}
+	var repo Repo
+	if _, err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+func (c *Client) page(ctx context.Context, path string, qry url.Values, page int, result interface{}) (hasNextPage bool, err error) {
+	if qry == nil {
+		qry = make(url.Values)
+	}
+	if page < 1 {
+		page = 1
+	}
+	qry.Set("page", strconv.Itoa(page))
+	qry.Set("limit", strconv.Itoa(PerPage))
+
+	u := url.URL{Path: path, RawQuery: qry.Encode()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.do(ctx, req, result)
+	if err != nil {
+		return false, err
+	}
+
+	_, hasNextPage = linkheader.ExtractNextURL(resp)
+	return hasNextPage, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) (*http.Response, error) {
+	req.URL = c.URL.ResolveReference(&url.URL{Path: "api/v1/"}).ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gitea"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gitea self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return resp, json.Unmarshal(bs, result)
+	}
+
+	return resp, nil
+}
+
+// Repo is a Gitea repository.
+type Repo struct {
+	ID            int64  `json:"id"`
+	Owner         *User  `json:"owner"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Description   string `json:"description"`
+	Private       bool   `json:"private"`
+	Fork          bool   `json:"fork"`
+	Mirror        bool   `json:"mirror"`
+	Archived      bool   `json:"archived"`
+	Empty         bool   `json:"empty"`
+	HTMLURL       string `json:"html_url"`
+	CloneURL      string `json:"clone_url"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// User is a Gitea user or organization.
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,194 @@
+package gitea
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+var update = flag.Bool("update", false, "update testdata")
+
+var testURL = &url.URL{Scheme: "https", Host: "gitea.sgdev.org", Path: "/"}
+
+func names(repos []*Repo) []string {
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.FullName)
+	}
+	return names
+}
+
+func TestClient_ListOrgRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListOrgRepos", *update, testURL)
+	defer save()
+
+	for _, tc := range []struct {
+		name        string
+		org         string
+		page        int
+		repos       []string
+		hasNextPage bool
+		err         string
+	}{
+		{
+			name:        "first page",
+			org:         "sourcegraph",
+			page:        1,
+			repos:       []string{"sourcegraph/go-diff", "sourcegraph/jsonrpc2"},
+			hasNextPage: true,
+			err:         "<nil>",
+		},
+		{
+			name:  "last page",
+			org:   "sourcegraph",
+			page:  2,
+			repos: []string{"sourcegraph/src-cli"},
+			err:   "<nil>",
+		},
+		{
+			name:  "not found",
+			org:   "does-not-exist",
+			page:  1,
+			repos: []string{},
+			err:   `Gitea API HTTP error: code=404 url="https://gitea.sgdev.org/api/v1/orgs/does-not-exist/repos?limit=50&page=1" body="{\"errors\":null,\"message\":\"The target couldn't be found.\",\"url\":\"https://gitea.sgdev.org/api/swagger\"}"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repos, hasNextPage, err := cli.ListOrgRepos(context.Background(), tc.org, tc.page)
+			if have, want := fmt.Sprint(err), tc.err; have != want {
+				t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+			}
+			if diff := cmp.Diff(tc.repos, names(repos)); diff != "" {
+				t.Errorf("repos mismatch (-want +have):\n%s", diff)
+			}
+			if hasNextPage != tc.hasNextPage {
+				t.Errorf("hasNextPage: have %v, want %v", hasNextPage, tc.hasNextPage)
+			}
+		})
+	}
+}
+
+func TestClient_ListUserRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListUserRepos", *update, testURL)
+	defer save()
+
+	repos, hasNextPage, err := cli.ListUserRepos(context.Background(), "sgtest", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasNextPage {
+		t.Error("unexpected next page")
+	}
+
+	want := []*Repo{
+		{
+			ID:            4,
+			Owner:         &User{ID: 3, Login: "sgtest"},
+			Name:          "go-diff",
+			FullName:      "sgtest/go-diff",
+			Description:   "Unified diff parser and printer for Go",
+			Fork:          true,
+			HTMLURL:       "https://gitea.sgdev.org/sgtest/go-diff",
+			CloneURL:      "https://gitea.sgdev.org/sgtest/go-diff.git",
+			SSHURL:        "git@gitea.sgdev.org:sgtest/go-diff.git",
+			DefaultBranch: "main",
+		},
+		{
+			ID:            5,
+			Owner:         &User{ID: 3, Login: "sgtest"},
+			Name:          "old",
+			FullName:      "sgtest/old",
+			Description:   "An archived repository",
+			Archived:      true,
+			HTMLURL:       "https://gitea.sgdev.org/sgtest/old",
+			CloneURL:      "https://gitea.sgdev.org/sgtest/old.git",
+			SSHURL:        "git@gitea.sgdev.org:sgtest/old.git",
+			DefaultBranch: "main",
+		},
+	}
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Errorf("repos mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestClient_ListAffiliatedRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "ListAffiliatedRepos", *update, testURL)
+	defer save()
+
+	repos, hasNextPage, err := cli.ListAffiliatedRepos(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasNextPage {
+		t.Error("unexpected next page")
+	}
+
+	want := []string{
+		"sourcegraph/go-diff",
+		"sourcegraph/jsonrpc2",
+		"sourcegraph/src-cli",
+		"sgtest/go-diff",
+		"sgtest/old",
+	}
+	if diff := cmp.Diff(want, names(repos)); diff != "" {
+		t.Errorf("repos mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestClient_SearchRepos(t *testing.T) {
+	cli, save := NewTestClient(t, "SearchRepos", *update, testURL)
+	defer save()
+
+	repos, hasNextPage, err := cli.SearchRepos(context.Background(), "jsonrpc2", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasNextPage {
+		t.Error("unexpected next page")
+	}
+	if diff := cmp.Diff([]string{"sourcegraph/jsonrpc2"}, names(repos)); diff != "" {
+		t.Errorf("repos mismatch (-want +have):\n%s", diff)
+	}
+	if !repos[0].Private {
+		t.Error("expected sourcegraph/jsonrpc2 to be private")
+	}
+}
+
+func TestClient_BaseURLWithSubpath(t *testing.T) {
+	for _, baseURL := range []string{
+		"https://example.com/gitea",
+		"https://example.com/gitea/",
+	} {
+		t.Run(baseURL, func(t *testing.T) {
+			u, err := url.Parse(baseURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have string
+			cli := NewClient(u, httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				have = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"full_name":"sourcegraph/go-diff"}`)),
+				}, nil
+			}))
+
+			if _, err := cli.GetRepo(context.Background(), "sourcegraph/go-diff"); err != nil {
+				t.Fatal(err)
+			}
+			if want := "https://example.com/gitea/api/v1/repos/sourcegraph/go-diff"; have != want {
+				t.Errorf("request URL: have %q, want %q", have, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package gitea
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// ExternalRepoSpec returns an api.ExternalRepoSpec that refers to the specified Gitea repository.
+func ExternalRepoSpec(repo *Repo, baseURL url.URL) api.ExternalRepoSpec {
+	return api.ExternalRepoSpec{
+		ID:          strconv.FormatInt(repo.ID, 10),
+		ServiceType: extsvc.TypeGitea,
+		ServiceID:   extsvc.NormalizeBaseURL(&baseURL).String(),
+	}
+}
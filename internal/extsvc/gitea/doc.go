@@ -0,0 +1,3 @@
+// Package gitea implements a Gitea API client. Forgejo serves the same API,
+// so it is supported as well.
+package gitea
@@ -676,6 +676,63 @@ func (c *Client) ReopenPullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// PullRequestMergeMethod is the method with which a PullRequest is merged on
+// GitHub.
+type PullRequestMergeMethod string
+
+// PullRequestMergeMethod constants.
+const (
+	PullRequestMergeMethodMerge  PullRequestMergeMethod = "MERGE"
+	PullRequestMergeMethodSquash PullRequestMergeMethod = "SQUASH"
+	PullRequestMergeMethodRebase PullRequestMergeMethod = "REBASE"
+)
+
+// MergePullRequest merges the PullRequest on GitHub with the given method.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, method PullRequestMergeMethod) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MergePullRequest($input:MergePullRequestInput!) {
+  mergePullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MergePullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems TimelineItemConnection
+			} `json:"pullRequest"`
+		} `json:"mergePullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID              string                 `json:"pullRequestId"`
+		ExpectedHeadOid string                 `json:"expectedHeadOid,omitempty"`
+		MergeMethod     PullRequestMergeMethod `json:"mergeMethod"`
+	}{ID: pr.ID, ExpectedHeadOid: pr.HeadRefOid, MergeMethod: method}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	ti := result.MergePullRequest.PullRequest.TimelineItems
+	*pr = result.MergePullRequest.PullRequest.PullRequest
+	pr.TimelineItems = ti.Nodes
+	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+
+	items, err := c.loadRemainingTimelineItems(ctx, pr.ID, ti.PageInfo)
+	if err != nil {
+		return err
+	}
+	pr.TimelineItems = append(pr.TimelineItems, items...)
+
+	return nil
+}
+
 // LoadPullRequests loads a list of PullRequests from Github.
 func (c *Client) LoadPullRequests(ctx context.Context, prs ...*PullRequest) error {
 	const batchSize = 15
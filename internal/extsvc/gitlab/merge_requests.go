@@ -201,3 +201,35 @@ func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *M
 
 	return resp, nil
 }
+
+type MergeMergeRequestOpts struct {
+	// SHA is the expected head commit of the merge request. If it doesn't
+	// match, the merge request is not merged.
+	SHA    string `json:"sha,omitempty"`
+	Squash bool   `json:"squash,omitempty"`
+}
+
+// MergeMergeRequest accepts the merge request and merges it into its target
+// branch.
+func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest, opts MergeMergeRequestOpts) (*MergeRequest, error) {
+	if MockMergeMergeRequest != nil {
+		return MockMergeMergeRequest(c, ctx, project, mr, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/merge", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to merge a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to merge a merge request")
+	}
+
+	return resp, nil
+}
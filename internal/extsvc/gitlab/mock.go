@@ -40,3 +40,7 @@ var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *
 // MockUpdateMergeRequest, if non-nil, will be called instead of
 // Client.UpdateMergeRequest
 var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error)
+
+// MockMergeMergeRequest, if non-nil, will be called instead of
+// Client.MergeMergeRequest
+var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts MergeMergeRequestOpts) (*MergeRequest, error)
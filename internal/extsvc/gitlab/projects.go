@@ -182,6 +182,52 @@ func (c *Client) getProjectFromAPI(ctx context.Context, id int, pathWithNamespac
 	return proj, err
 }
 
+// AccessLevel is the level of access a user has to a GitLab project or group. See
+// https://docs.gitlab.com/ee/api/members.html#valid-access-levels.
+type AccessLevel int
+
+const (
+	AccessLevelNone       AccessLevel = 0
+	AccessLevelGuest      AccessLevel = 10
+	AccessLevelReporter   AccessLevel = 20
+	AccessLevelDeveloper  AccessLevel = 30
+	AccessLevelMaintainer AccessLevel = 40
+	AccessLevelOwner      AccessLevel = 50
+)
+
+type projectAccess struct {
+	AccessLevel AccessLevel `json:"access_level"`
+}
+
+// GetProjectAccessLevel returns the access level the authenticated user has to the project with
+// the given ID. This is the greater of the access levels granted by project and by group membership.
+// Unlike GetProject, the result is never cached as it depends on the user's current memberships.
+func (c *Client) GetProjectAccessLevel(ctx context.Context, id int) (AccessLevel, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d", id), nil)
+	if err != nil {
+		return AccessLevelNone, err
+	}
+
+	var proj struct {
+		Permissions struct {
+			ProjectAccess *projectAccess `json:"project_access"`
+			GroupAccess   *projectAccess `json:"group_access"`
+		} `json:"permissions"`
+	}
+	if _, _, err := c.do(ctx, req, &proj); err != nil {
+		return AccessLevelNone, err
+	}
+
+	accessLevel := AccessLevelNone
+	for _, access := range []*projectAccess{proj.Permissions.ProjectAccess, proj.Permissions.GroupAccess} {
+		if access != nil && access.AccessLevel > accessLevel {
+			accessLevel = access.AccessLevel
+		}
+	}
+
+	return accessLevel, nil
+}
+
 // ListProjects lists GitLab projects.
 func (c *Client) ListProjects(ctx context.Context, urlStr string) (projs []*Project, nextPageURL *string, err error) {
 	if MockListProjects != nil {
@@ -96,3 +96,38 @@ func TestClient_GetProject_nonexistent(t *testing.T) {
 		t.Error("proj != nil")
 	}
 }
+
+func TestClient_GetProjectAccessLevel(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		want         AccessLevel
+	}{
+		"no membership": {
+			responseBody: `{"id": 1, "permissions": {"project_access": null, "group_access": null}}`,
+			want:         AccessLevelNone,
+		},
+		"project membership": {
+			responseBody: `{"id": 1, "permissions": {"project_access": {"access_level": 30}, "group_access": null}}`,
+			want:         AccessLevelDeveloper,
+		},
+		"greater group membership": {
+			responseBody: `{"id": 1, "permissions": {"project_access": {"access_level": 20}, "group_access": {"access_level": 50}}}`,
+			want:         AccessLevelOwner,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(t)
+			c.httpClient = &mockHTTPResponseBody{responseBody: test.responseBody}
+
+			accessLevel, err := c.GetProjectAccessLevel(context.Background(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if accessLevel != test.want {
+				t.Errorf("got access level %d, want %d", accessLevel, test.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,20 @@
 package gitolite
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"io"
 	"net/url"
 	"os/exec"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 )
 
 // Repo is the repository metadata returned by the Gitolite API.
@@ -43,6 +51,87 @@ func (c *Client) ListRepos(ctx context.Context) ([]*Repo, error) {
 	return decodeRepos(c.Host, string(out)), nil
 }
 
+// ListReposForUser returns the repositories the given Gitolite user can read, as reported by
+// running `info` on behalf of the user with the Gitolite `sudo` command. This requires the
+// client to authenticate as a Gitolite super-user, and the `sudo` command to be enabled.
+func (c *Client) ListReposForUser(ctx context.Context, user string) ([]*Repo, error) {
+	if !IsValidUsername(user) {
+		return nil, errors.Errorf("invalid Gitolite username %q", user)
+	}
+
+	out, err := exec.CommandContext(ctx, "ssh", c.Host, "sudo", user, "info").Output()
+	if err != nil {
+		log15.Error("listing gitolite for user failed", "user", user, "error", err, "out", string(out))
+		return nil, err
+	}
+	return decodeRepos(c.Host, string(out)), nil
+}
+
+// ListUsers returns the names of the Gitolite users, as derived from the public keys in the
+// keydir directory of the gitolite-admin repository. This requires the client to be able to
+// read the gitolite-admin repository.
+func (c *Client) ListUsers(ctx context.Context) ([]string, error) {
+	remote, err := cloneURL(c.Host, "gitolite-admin")
+	if err != nil {
+		return nil, err
+	} else if remote == "" {
+		return nil, errors.Errorf("unsupported Gitolite host %q", c.Host)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "archive", "--format=tar", "--remote="+remote, "HEAD", "keydir")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		log15.Error("listing gitolite users failed", "error", err, "stderr", stderr.String())
+		return nil, err
+	}
+	return decodeUsers(bytes.NewReader(out))
+}
+
+// IsValidUsername returns true if the given name is a valid Gitolite username.
+func IsValidUsername(name string) bool {
+	return usernamePattern.MatchString(name)
+}
+
+// usernamePattern is the same pattern Gitolite validates usernames with, without the leading
+// "@" of group names.
+var usernamePattern = lazyregexp.New(`^[0-9a-zA-Z][-0-9a-zA-Z._@+]*$`)
+
+// keyFilePattern matches the suffix of the public key files of the keydir directory that
+// isn't part of the username, i.e. the ".pub" extension and, like Gitolite, an optional
+// "@suffix" without a dot to distinguish multiple keys of the same user.
+var keyFilePattern = lazyregexp.New(`(@[^.]+)?\.pub$`)
+
+func decodeUsers(archive io.Reader) ([]string, error) {
+	seen := make(map[string]bool)
+	var users []string
+
+	tr := tar.NewReader(archive)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "reading keydir archive")
+		}
+
+		name := path.Base(hdr.Name)
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(name, ".pub") {
+			continue
+		}
+
+		user := keyFilePattern.ReplaceAllString(name, "")
+		if IsValidUsername(user) && !seen[user] {
+			seen[user] = true
+			users = append(users, user)
+		}
+	}
+
+	sort.Strings(users)
+	return users, nil
+}
+
 func decodeRepos(host, gitoliteInfo string) []*Repo {
 	lines := strings.Split(gitoliteInfo, "\n")
 	var repos []*Repo
@@ -55,25 +144,36 @@ func decodeRepos(host, gitoliteInfo string) []*Repo {
 		if len(fields) >= 2 && fields[0] == "R" {
 			repo := &Repo{Name: name}
 
-			u, err := url.Parse(host)
+			var err error
 			// see https://github.com/sourcegraph/security-issues/issues/97
-			if err != nil {
+			if repo.URL, err = cloneURL(host, name); err != nil {
 				continue
 			}
 
-			// We support both URL and SCP formats
-			// url: ssh://git@github.com:22/tsenart/vegeta
-			// scp: git@github.com:tsenart/vegeta
-			if u == nil || u.Scheme == "" {
-				repo.URL = host + ":" + name
-			} else if u.Scheme == "ssh" {
-				u.Path = name
-				repo.URL = u.String()
-			}
-
 			repos = append(repos, repo)
 		}
 	}
 
 	return repos
 }
+
+// cloneURL returns the clone URL of the named repository of the Gitolite host. It returns an
+// empty string if the host is neither in the URL format with the ssh scheme nor in the SCP
+// format.
+func cloneURL(host, name string) (string, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+
+	// We support both URL and SCP formats
+	// url: ssh://git@github.com:22/tsenart/vegeta
+	// scp: git@github.com:tsenart/vegeta
+	if u == nil || u.Scheme == "" {
+		return host + ":" + name, nil
+	} else if u.Scheme == "ssh" {
+		u.Path = name
+		return u.String(), nil
+	}
+	return "", nil
+}
@@ -1,6 +1,8 @@
 package gitolite
 
 import (
+	"archive/tar"
+	"bytes"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -60,3 +62,42 @@ func TestDecodeRepos(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeUsers(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		dir  bool
+	}{
+		{name: "keydir/", dir: true},
+		{name: "keydir/admin.pub"},
+		{name: "keydir/alice.pub"},
+		{name: "keydir/alice@laptop.pub"},
+		{name: "keydir/bob@example.com.pub"},
+		{name: "keydir/team/carol.pub"},
+		{name: "keydir/README"},
+		{name: "keydir/-evil.pub"},
+	} {
+		hdr := &tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0644}
+		if f.dir {
+			hdr.Typeflag = tar.TypeDir
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := decodeUsers(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"admin", "alice", "bob@example.com", "carol"}
+	if diff := cmp.Diff(want, users); diff != "" {
+		t.Errorf("users mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,183 @@
+package perforce
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Client is a client for a Perforce server that runs the p4 command-line client, which must be
+// in the PATH.
+type Client struct {
+	// Port is the address of the Perforce server, in the format of P4PORT.
+	Port string
+	// User is the Perforce user that commands are run as.
+	User string
+	// Password is the password or ticket of User.
+	Password string
+}
+
+// NewClient returns a new client for the Perforce server at the given address.
+func NewClient(port, user, password string) *Client {
+	return &Client{Port: port, User: user, Password: password}
+}
+
+// Env returns the environment that p4 (and git p4) commands must be run with to connect to
+// the Perforce server.
+//
+// 🚨 SECURITY: The returned environment contains the password of the user.
+func (c *Client) Env() []string {
+	return append(os.Environ(),
+		"P4PORT="+c.Port,
+		"P4USER="+c.User,
+		"P4PASSWD="+c.Password,
+		// Prevent P4CONFIG files in the working directory from overriding the above.
+		"P4CONFIG=",
+	)
+}
+
+// Depots returns the local and stream depots of the server.
+func (c *Client) Depots(ctx context.Context) ([]*Depot, error) {
+	records, err := c.run(ctx, "depots")
+	if err != nil {
+		return nil, err
+	}
+
+	var depots []*Depot
+	for _, r := range records {
+		switch r["type"] {
+		case "local", "stream":
+		default:
+			// Remote, spec, archive, unload and other special depots don't contain files that
+			// can be converted into Git repositories.
+			continue
+		}
+		depots = append(depots, &Depot{
+			Path:        "//" + r["name"] + "/",
+			Type:        r["type"],
+			Description: strings.TrimSpace(r["desc"]),
+		})
+	}
+	return depots, nil
+}
+
+// Changes returns the numbers of the most recent changes submitted to the given depot path,
+// at most max of them. It can be used to check that the depot path exists and can be read.
+func (c *Client) Changes(ctx context.Context, depot string, max int) ([]string, error) {
+	records, err := c.run(ctx, "changes", "-s", "submitted", "-m", strconv.Itoa(max), depot+"...")
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]string, 0, len(records))
+	for _, r := range records {
+		changes = append(changes, r["change"])
+	}
+	return changes, nil
+}
+
+// Users returns all the users of the server.
+func (c *Client) Users(ctx context.Context) ([]*User, error) {
+	records, err := c.run(ctx, "users")
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*User, 0, len(records))
+	for _, r := range records {
+		users = append(users, &User{
+			Username: r["User"],
+			Email:    r["Email"],
+			FullName: r["FullName"],
+		})
+	}
+	return users, nil
+}
+
+// Protects returns the lines of the protections table that apply to the given user, in the
+// order they appear in the table. Reading the protections of another user requires super
+// access.
+func (c *Client) Protects(ctx context.Context, user string) ([]*Protection, error) {
+	records, err := c.run(ctx, "protects", "-u", user)
+	if err != nil {
+		return nil, err
+	}
+
+	protections := make([]*Protection, 0, len(records))
+	for _, r := range records {
+		path := r["depotFile"]
+		_, isGroup := r["isgroup"]
+		_, unmap := r["unmap"]
+		protections = append(protections, &Protection{
+			Level:     r["perm"],
+			IsGroup:   isGroup,
+			Name:      r["user"],
+			Host:      r["host"],
+			Path:      strings.TrimPrefix(path, "-"),
+			Exclusion: unmap || strings.HasPrefix(path, "-"),
+		})
+	}
+	return protections, nil
+}
+
+// run runs the given p4 command with tagged output and returns its records.
+func (c *Client) run(ctx context.Context, args ...string) ([]map[string]string, error) {
+	cmd := exec.CommandContext(ctx, "p4", append([]string{"-ztag"}, args...)...)
+	cmd.Env = c.Env()
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.Errorf("p4 %s: %s", args[0], msg)
+		}
+		return nil, errors.Wrapf(err, "p4 %s", args[0])
+	}
+	return parseTagged(out), nil
+}
+
+// parseTagged parses the tagged output (-ztag) of a p4 command, where each record is a block of
+// lines of the form "... field value", and blocks are separated by empty lines. Lines that
+// don't start with "... " continue the value of the previous field.
+func parseTagged(out []byte) []map[string]string {
+	var (
+		records []map[string]string
+		record  map[string]string
+		field   string
+	)
+
+	s := bufio.NewScanner(bytes.NewReader(out))
+	s.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for s.Scan() {
+		line := s.Text()
+		if !strings.HasPrefix(line, "... ") {
+			if line == "" {
+				record, field = nil, ""
+			} else if record != nil && field != "" {
+				record[field] += "\n" + line
+			}
+			continue
+		}
+
+		if record == nil {
+			record = make(map[string]string)
+			records = append(records, record)
+		}
+
+		line = strings.TrimPrefix(line, "... ")
+		var value string
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			line, value = line[:i], line[i+1:]
+		}
+		field = line
+		record[field] = value
+	}
+	return records
+}
@@ -0,0 +1,2 @@
+// Package perforce implements a Perforce Helix Core client on top of the p4 command-line client.
+package perforce
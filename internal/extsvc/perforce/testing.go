@@ -0,0 +1,58 @@
+package perforce
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// SetupFakeP4 installs a fake p4 command-line client in the PATH, until the returned function
+// is called. The fake client writes the output mapped to the arguments it's invoked with (such
+// as "-ztag protects -u alice") to stdout, and fails if there is none. It fails as well if it's
+// not invoked with the credentials of the given client.
+func SetupFakeP4(t testing.TB, c *Client, outputs map[string]string) func() {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "fake-p4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := make([]string, 0, len(outputs))
+	for a := range outputs {
+		args = append(args, a)
+	}
+	sort.Strings(args)
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "#!/bin/sh\n")
+	fmt.Fprintf(&script, "if [ \"$P4PORT\" != %s ] || [ \"$P4USER\" != %s ] || [ \"$P4PASSWD\" != %s ]; then\n", shellQuote(c.Port), shellQuote(c.User), shellQuote(c.Password))
+	fmt.Fprintf(&script, "  echo 'Perforce password (P4PASSWD) invalid or unset.' >&2\n  exit 1\nfi\n")
+	fmt.Fprintf(&script, "case \"$*\" in\n")
+	for i, a := range args {
+		fmt.Fprintf(&script, "%s)\n  cat <<'EOF_%d'\n%s\nEOF_%d\n  ;;\n", shellQuote(a), i, strings.TrimSuffix(outputs[a], "\n"), i)
+	}
+	fmt.Fprintf(&script, "*)\n  echo \"unexpected command: p4 $*\" >&2\n  exit 1\n  ;;\nesac\n")
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "p4"), []byte(script.String()), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("PATH")
+	if err := os.Setenv("PATH", dir+string(os.PathListSeparator)+path); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		os.Setenv("PATH", path)
+		os.RemoveAll(dir)
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
@@ -0,0 +1,44 @@
+package perforce
+
+import "strings"
+
+// Depot is a Perforce depot, or a path in a depot, that is mirrored as a Git repository.
+type Depot struct {
+	// Path is the depot path with a leading "//" and a trailing "/", such as "//Engine/Cloud/".
+	Path string `json:"path"`
+	// Type is the type of the depot, such as "local" or "stream". It's empty for paths that
+	// were configured explicitly.
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Name returns the depot path without its leading and trailing slashes, such as "Engine/Cloud".
+func (d *Depot) Name() string {
+	return strings.Trim(d.Path, "/")
+}
+
+// User is a Perforce user, as returned by `p4 users`.
+type User struct {
+	Username string `json:"username"`
+	Email    string `json:"email,omitempty"`
+	FullName string `json:"fullName,omitempty"`
+}
+
+// Protection is a line of the protections table that applies to a user, as returned by
+// `p4 protects -u`. See https://www.perforce.com/manuals/cmdref/Content/CmdRef/p4_protect.html.
+type Protection struct {
+	// Level is the access level or right granted (or, for exclusions, revoked), such as
+	// "read", "write" or "=read".
+	Level string
+	// IsGroup is true if the line applies to a group the user is a member of.
+	IsGroup bool
+	// Name is the name of the user or group the line applies to.
+	Name string
+	// Host is the host (IP address) pattern the line applies to.
+	Host string
+	// Path is the depot path pattern the line applies to, without the leading "-" of
+	// exclusions.
+	Path string
+	// Exclusion is true if the line removes access from Path.
+	Exclusion bool
+}
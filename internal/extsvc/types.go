@@ -74,11 +74,15 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
+	KindGerrit          = "GERRIT"
+	KindGitea           = "GITEA"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
+	KindPerforce        = "PERFORCE"
 	KindPhabricator     = "PHABRICATOR"
 	KindOther           = "OTHER"
 )
@@ -91,6 +95,10 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the URL of the Azure DevOps organization (or Azure DevOps Server collection).
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -99,6 +107,14 @@ const (
 	// ServiceID value is the base URL to the Bitbucket Cloud.
 	TypeBitbucketCloud = "bitbucketCloud"
 
+	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects. The ServiceID value
+	// is the base URL to the Gerrit instance.
+	TypeGerrit = "gerrit"
+
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea (and Forgejo) repositories. The
+	// ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -110,6 +126,10 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce depots. The ServiceID
+	// value is the address of the Perforce server, as in P4PORT (e.g., "ssl:perforce.example.com:1666").
+	TypePerforce = "perforce"
+
 	// TypePhabricator is the (api.ExternalRepoSpec).ServiceType value for Phabricator projects.
 	TypePhabricator = "phabricator"
 
@@ -123,16 +143,24 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
+	case KindGerrit:
+		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
 		return TypeGitLab
 	case KindGitolite:
 		return TypeGitolite
+	case KindPerforce:
+		return TypePerforce
 	case KindPhabricator:
 		return TypePhabricator
 	case KindOther:
@@ -154,16 +182,24 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
+	case TypeGerrit:
+		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypePerforce:
+		return TypePerforce, true
 	case TypePhabricator:
 		return TypePhabricator, true
 	case TypeOther:
@@ -188,16 +224,24 @@ func ParseConfig(kind, config string) (cfg interface{}, _ error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		cfg = &schema.AWSCodeCommitConnection{}
+	case KindAzureDevOps:
+		cfg = &schema.AzureDevOpsConnection{}
 	case KindBitbucketServer:
 		cfg = &schema.BitbucketServerConnection{}
 	case KindBitbucketCloud:
 		cfg = &schema.BitbucketCloudConnection{}
+	case KindGerrit:
+		cfg = &schema.GerritConnection{}
+	case KindGitea:
+		cfg = &schema.GiteaConnection{}
 	case KindGitHub:
 		cfg = &schema.GitHubConnection{}
 	case KindGitLab:
 		cfg = &schema.GitLabConnection{}
 	case KindGitolite:
 		cfg = &schema.GitoliteConnection{}
+	case KindPerforce:
+		cfg = &schema.PerforceConnection{}
 	case KindPhabricator:
 		cfg = &schema.PhabricatorConnection{}
 	case KindOther:
@@ -255,14 +299,22 @@ func ExtractBaseURL(kind, config string) (*url.URL, error) {
 	switch c := cfg.(type) {
 	case *schema.AWSCodeCommitConnection:
 		return nil, errors.New("BaseURL unavailable for AWSCodeCommit")
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
 	case *schema.BitbucketServerConnection:
 		rawURL = c.Url
+	case *schema.GerritConnection:
+		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
 	case *schema.GitHubConnection:
 		rawURL = c.Url
 	case *schema.GitLabConnection:
 		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
+	case *schema.PerforceConnection:
+		return nil, errors.New("BaseURL unavailable for Perforce")
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
@@ -328,6 +380,30 @@ func GetLimitFromConfig(kind string, config interface{}) (rlc RateLimitConfig, e
 			rlc.IsDefault = false
 		}
 		rlc.BaseURL = c.Url
+	case *schema.GiteaConnection:
+		// 2/s is the default limit we enforce
+		rlc.Limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			rlc.Limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+			rlc.IsDefault = false
+		}
+		rlc.BaseURL = c.Url
+	case *schema.GerritConnection:
+		// 2/s is the default limit we enforce
+		rlc.Limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			rlc.Limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+			rlc.IsDefault = false
+		}
+		rlc.BaseURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		// 2/s is the default limit we enforce
+		rlc.Limit = rate.Limit(2)
+		if c != nil && c.RateLimit != nil {
+			rlc.Limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+			rlc.IsDefault = false
+		}
+		rlc.BaseURL = c.Url
 	default:
 		return rlc, ErrRateLimitUnsupported{codehostKind: kind}
 	}
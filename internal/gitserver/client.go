@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +23,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/neelance/parallel"
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
@@ -61,6 +63,9 @@ func NewClient(cli httpcli.Doer) *Client {
 		Addrs: func(ctx context.Context) []string {
 			return conf.Get().ServiceConnections.GitServers
 		},
+		ReplicationFactor: func() int {
+			return conf.Get().GitServerReplicationFactor
+		},
 		HTTPClient:  cli,
 		HTTPLimiter: parallel.NewRun(500),
 		// Use the binary name for UserAgent. This should effectively identify
@@ -83,6 +88,11 @@ type Client struct {
 	// concurrent use. It may return different results at different times.
 	Addrs func(ctx context.Context) []string
 
+	// ReplicationFactor is a function which should return the number of
+	// gitservers each repository is stored on. If it is nil or returns less
+	// than 2, repositories are not replicated.
+	ReplicationFactor func() int
+
 	// UserAgent is a string identifing who the client is. It will be logged in
 	// the telemetry in gitserver.
 	UserAgent string
@@ -101,13 +111,85 @@ func (c *Client) addrForKey(ctx context.Context, key string) string {
 	if len(addrs) == 0 {
 		panic("unexpected state: no gitserver addresses")
 	}
-	return addrForKey(addrs, key)
+	return AddrForKey(key, addrs)
 }
 
-func addrForKey(addrs []string, key string) string {
-	sum := md5.Sum([]byte(key))
-	serverIndex := binary.BigEndian.Uint64(sum[:]) % uint64(len(addrs))
-	return addrs[serverIndex]
+// AddrForKey returns the gitserver address in addrs which owns key.
+//
+// We use rendezvous (highest random weight) hashing: every address is scored
+// by hashing it together with key, and the highest score wins. Unlike taking
+// the hash modulo len(addrs), adding or removing a single gitserver only
+// moves the keys owned by that gitserver, so scaling gitserver does not
+// remap (and reclone) nearly every repository.
+//
+// The result does not depend on the order of addrs.
+func AddrForKey(key string, addrs []string) string {
+	var (
+		best      string
+		bestScore uint64
+	)
+	for i, addr := range addrs {
+		score := rendezvousScore(addr, key)
+		if i == 0 || score > bestScore || (score == bestScore && addr < best) {
+			best, bestScore = addr, score
+		}
+	}
+	return best
+}
+
+// AddrsForRepo returns the addresses of the gitservers which store the given
+// repo name, starting with the gitserver which owns it.
+func (c *Client) AddrsForRepo(ctx context.Context, repo api.RepoName) []string {
+	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
+	addrs := c.Addrs(ctx)
+	if len(addrs) == 0 {
+		panic("unexpected state: no gitserver addresses")
+	}
+	n := 1
+	if c.ReplicationFactor != nil {
+		n = c.ReplicationFactor()
+	}
+	return AddrsForKey(string(repo), addrs, n)
+}
+
+// AddrsForKey returns the n gitserver addresses in addrs which store key, in
+// order of preference. The first address is the one returned by AddrForKey,
+// and the others are its replicas. At least one and at most len(addrs)
+// addresses are returned.
+//
+// Like AddrForKey, the result does not depend on the order of addrs, and
+// adding or removing a gitserver only changes the replicas of the keys it
+// stores.
+func AddrsForKey(key string, addrs []string, n int) []string {
+	if n < 1 {
+		n = 1
+	}
+	if n > len(addrs) {
+		n = len(addrs)
+	}
+
+	scores := make(map[string]uint64, len(addrs))
+	sorted := make([]string, len(addrs))
+	for i, addr := range addrs {
+		scores[addr] = rendezvousScore(addr, key)
+		sorted[i] = addr
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		si, sj := scores[sorted[i]], scores[sorted[j]]
+		if si != sj {
+			return si > sj
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted[:n]
+}
+
+func rendezvousScore(addr, key string) uint64 {
+	h := md5.New()
+	_, _ = io.WriteString(h, addr)
+	_, _ = h.Write([]byte{0})
+	_, _ = io.WriteString(h, key)
+	return binary.BigEndian.Uint64(h.Sum(nil))
 }
 
 // ArchiveOptions contains options for the Archive func.
@@ -182,8 +264,9 @@ func (c *Client) Archive(ctx context.Context, repo Repo, opt ArchiveOptions) (_
 		return nil, err
 	}
 
+	// Request a relative URL so that the archive can be served by a replica.
 	u := c.ArchiveURL(ctx, repo, opt)
-	resp, err := c.do(ctx, repo.Name, "GET", u.String(), nil)
+	resp, err := c.do(ctx, repo.Name, "GET", "archive?"+u.RawQuery, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -477,6 +560,43 @@ func (c *Client) ListGitolite(ctx context.Context, gitoliteHost string) (list []
 	return list, err
 }
 
+// ListGitoliteForUser lists the Gitolite repositories the given Gitolite user can read.
+func (c *Client) ListGitoliteForUser(ctx context.Context, gitoliteHost, user string) (list []*gitolite.Repo, err error) {
+	q := url.Values{"gitolite": {gitoliteHost}, "user": {user}}
+	err = c.getGitolite(ctx, gitoliteHost, "/list-gitolite?"+q.Encode(), &list)
+	return list, err
+}
+
+// ListGitoliteUsers lists the users of the Gitolite host.
+func (c *Client) ListGitoliteUsers(ctx context.Context, gitoliteHost string) (users []string, err error) {
+	q := url.Values{"gitolite": {gitoliteHost}}
+	err = c.getGitolite(ctx, gitoliteHost, "/list-gitolite-users?"+q.Encode(), &users)
+	return users, err
+}
+
+// getGitolite decodes the response of the gitserver responsible for talking to the Gitolite
+// host to a request for the given path into result. Unlike ListGitolite, it returns an error
+// if the request failed, so that permissions are never computed from partial results.
+func (c *Client) getGitolite(ctx context.Context, gitoliteHost, path string, result interface{}) error {
+	req, err := http.NewRequest("GET", "http://"+c.addrForKey(ctx, gitoliteHost)+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("gitolite: unexpected status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
 // ListCloned lists all cloned repositories
 func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 	var (
@@ -496,7 +616,7 @@ func (c *Client) ListCloned(ctx context.Context) ([]string, error) {
 			if len(r) > 0 {
 				filtered := r[:0]
 				for _, repo := range r {
-					if addrForKey(addrs, repo) == addr {
+					if AddrForKey(repo, addrs) == addr {
 						filtered = append(filtered, repo)
 					}
 				}
@@ -871,6 +991,9 @@ func (c *Client) httpPost(ctx context.Context, repo api.RepoName, op string, pay
 
 // do performs a request to a gitserver, sharding based on the given
 // repo name (the repo name is otherwise not used).
+//
+// Requests which only read a repository are retried on its replicas if the
+// gitserver which owns it is unreachable, fails or doesn't have it.
 func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, payload interface{}) (resp *http.Response, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.do")
 	defer func() {
@@ -887,11 +1010,76 @@ func (c *Client) do(ctx context.Context, repo api.RepoName, method, op string, p
 		return nil, err
 	}
 
-	uri := op
-	if !strings.HasPrefix(op, "http") {
-		uri = "http://" + c.AddrForRepo(ctx, repo) + "/" + op
+	if strings.HasPrefix(op, "http") {
+		return c.doOnce(ctx, span, method, op, reqBody)
+	}
+
+	addrs := c.AddrsForRepo(ctx, repo)
+	endpoint := strings.SplitN(op, "?", 2)[0]
+	if !replicatedOps[endpoint] {
+		addrs = addrs[:1]
+	}
+
+	// ownerResp is the response of the gitserver which owns the repository
+	// if it failed. It is returned if no replica succeeds either, since only
+	// the owner can tell whether the repository doesn't exist or is being
+	// cloned.
+	var ownerResp *http.Response
+	for i, addr := range addrs {
+		resp, err = c.doOnce(ctx, span, method, "http://"+addr+"/"+op, reqBody)
+		if i == len(addrs)-1 || !shouldTryReplica(ctx, resp, err) {
+			break
+		}
+		if i == 0 && resp != nil {
+			ownerResp = resp
+		} else if resp != nil {
+			resp.Body.Close()
+		}
+		span.LogKV("event", "trying replica", "addr", addrs[i+1])
+		replicaFallbackCounter.WithLabelValues(endpoint).Inc()
 	}
 
+	if ownerResp != nil {
+		if err == nil && resp.StatusCode < 300 {
+			ownerResp.Body.Close()
+			return resp, nil
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return ownerResp, nil
+	}
+	return resp, err
+}
+
+// replicatedOps are the gitserver endpoints which can be served by any replica
+// of a repository.
+var replicatedOps = map[string]bool{
+	"archive": true,
+	"exec":    true,
+}
+
+// shouldTryReplica returns whether a request which returned resp and err
+// should be retried on the next replica of the repository.
+func shouldTryReplica(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Don't retry if the caller is no longer waiting.
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusNotFound
+}
+
+var replicaFallbackCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_fallback_total",
+	Help: "Times that a gitserver request was retried on a replica of the repository.",
+}, []string{"op"})
+
+func init() {
+	prometheus.MustRegister(replicaFallbackCounter)
+}
+
+// doOnce sends a single request to uri.
+func (c *Client) doOnce(ctx context.Context, span opentracing.Span, method, uri string, reqBody []byte) (*http.Response, error) {
 	req, err := http.NewRequest(method, uri, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, err
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
 )
 
 func TestClient_ListCloned(t *testing.T) {
@@ -33,11 +34,11 @@ func TestClient_ListCloned(t *testing.T) {
 			switch r.URL.String() {
 			case "http://gitserver-0/list?cloned":
 				return &http.Response{
-					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo0-a", "repo0-b"]`)),
+					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo0-a", "repo0-c"]`)),
 				}, nil
 			case "http://gitserver-1/list?cloned":
 				return &http.Response{
-					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo1-a", "repo1-b"]`)),
+					Body: ioutil.NopCloser(bytes.NewBufferString(`["repo1-b", "repo1-e"]`)),
 				}, nil
 			default:
 				return nil, fmt.Errorf("unexpected url: %s", r.URL.String())
@@ -45,7 +46,7 @@ func TestClient_ListCloned(t *testing.T) {
 		}),
 	}
 
-	want := []string{"repo0-a", "repo1-a", "repo1-b"}
+	want := []string{"repo0-c", "repo1-b", "repo1-e"}
 	got, err := cli.ListCloned(context.Background())
 	if err != nil {
 		t.Fatal(err)
@@ -57,6 +58,160 @@ func TestClient_ListCloned(t *testing.T) {
 	}
 }
 
+func TestAddrForKey(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2"}
+	grown := append(append([]string{}, addrs...), "gitserver-3")
+	reversed := []string{"gitserver-2", "gitserver-1", "gitserver-0"}
+
+	const n = 10000
+	counts := map[string]int{}
+	moved := 0
+	for i := 0; i < n; i++ {
+		key := fmt.Sprintf("github.com/foo/repo%d", i)
+		addr := gitserver.AddrForKey(key, addrs)
+		counts[addr]++
+
+		if got := gitserver.AddrForKey(key, reversed); got != addr {
+			t.Fatalf("AddrForKey(%q) depends on address order: got %q and %q", key, addr, got)
+		}
+
+		// Adding a gitserver should only ever move keys onto the new
+		// gitserver.
+		if got := gitserver.AddrForKey(key, grown); got != addr {
+			if got != "gitserver-3" {
+				t.Fatalf("AddrForKey(%q) moved from %q to existing gitserver %q", key, addr, got)
+			}
+			moved++
+		}
+	}
+
+	for _, addr := range addrs {
+		if c := counts[addr]; c < n/4 || c > n/2 {
+			t.Errorf("unbalanced assignment: %s owns %d of %d keys", addr, c, n)
+		}
+	}
+	if moved < n/8 || moved > n/3 {
+		t.Errorf("expected roughly a quarter of keys to move after adding a gitserver, got %d of %d", moved, n)
+	}
+}
+
+func TestAddrsForKey(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2", "gitserver-3"}
+	reversed := []string{"gitserver-3", "gitserver-2", "gitserver-1", "gitserver-0"}
+
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("github.com/foo/repo%d", i)
+
+		got := gitserver.AddrsForKey(key, addrs, 3)
+		if len(got) != 3 {
+			t.Fatalf("AddrsForKey(%q) returned %d addresses, want 3", key, len(got))
+		}
+		if got[0] != gitserver.AddrForKey(key, addrs) {
+			t.Fatalf("AddrsForKey(%q) starts with %q, want the owner %q", key, got[0], gitserver.AddrForKey(key, addrs))
+		}
+		if got[1] == got[0] || got[2] == got[0] || got[1] == got[2] {
+			t.Fatalf("AddrsForKey(%q) returned duplicate addresses %q", key, got)
+		}
+		if r := gitserver.AddrsForKey(key, reversed, 3); !cmp.Equal(got, r) {
+			t.Fatalf("AddrsForKey(%q) depends on address order: got %q and %q", key, got, r)
+		}
+
+		// Removing a replica should only replace that replica.
+		var shrunk []string
+		for _, addr := range addrs {
+			if addr != got[2] {
+				shrunk = append(shrunk, addr)
+			}
+		}
+		if s := gitserver.AddrsForKey(key, shrunk, 3); !cmp.Equal(got[:2], s[:2]) {
+			t.Fatalf("AddrsForKey(%q) changed from %q to %q after removing %q", key, got, s, got[2])
+		}
+	}
+
+	for _, tc := range []struct {
+		n, want int
+	}{
+		{n: 0, want: 1},
+		{n: 1, want: 1},
+		{n: 4, want: 4},
+		{n: 10, want: 4},
+	} {
+		if got := gitserver.AddrsForKey("foo", addrs, tc.n); len(got) != tc.want {
+			t.Errorf("AddrsForKey with n=%d returned %d addresses, want %d", tc.n, len(got), tc.want)
+		}
+	}
+}
+
+func TestClient_replicaFallback(t *testing.T) {
+	addrs := []string{"gitserver-0", "gitserver-1", "gitserver-2"}
+	replicas := gitserver.AddrsForKey("github.com/foo/bar", addrs, 2)
+
+	var requested []string
+	cli := &gitserver.Client{
+		Addrs:             func(ctx context.Context) []string { return addrs },
+		ReplicationFactor: func() int { return 2 },
+		HTTPClient: httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			requested = append(requested, r.URL.Host+r.URL.Path)
+			switch r.URL.Host {
+			case replicas[0]:
+				return nil, errors.New("connection refused")
+			case replicas[1]:
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString("HEAD")),
+					Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+				}, nil
+			default:
+				return nil, fmt.Errorf("unexpected url: %s", r.URL.String())
+			}
+		}),
+	}
+
+	cmd := cli.Command("git", "rev-parse", "HEAD")
+	cmd.Repo = gitserver.Repo{Name: "github.com/foo/bar"}
+	out, err := cmd.Output(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "HEAD" {
+		t.Errorf("got output %q, want %q", out, "HEAD")
+	}
+
+	want := []string{replicas[0] + "/exec", replicas[1] + "/exec"}
+	if !cmp.Equal(want, requested) {
+		t.Errorf("requests mismatch (-want +got):\n%s", cmp.Diff(want, requested))
+	}
+
+	// Requests which modify a repository are only sent to its owner.
+	requested = nil
+	if _, err := cli.RequestRepoUpdate(context.Background(), gitserver.Repo{Name: "github.com/foo/bar"}, 0); err == nil {
+		t.Error("expected error from unreachable owner")
+	}
+	if want := []string{replicas[0] + "/repo-update"}; !cmp.Equal(want, requested) {
+		t.Errorf("requests mismatch (-want +got):\n%s", cmp.Diff(want, requested))
+	}
+
+	// The response of the owner is returned if no replica has the repository.
+	requested = nil
+	cli.HTTPClient = httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.Host+r.URL.Path)
+		body := `{"cloneInProgress":false}`
+		if r.URL.Host == replicas[0] {
+			body = `{"cloneInProgress":true}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	})
+	if _, err := cmd.Output(context.Background()); !vcs.IsCloneInProgress(err) {
+		t.Errorf("got error %v, want clone in progress", err)
+	}
+	if !cmp.Equal(want, requested) {
+		t.Errorf("requests mismatch (-want +got):\n%s", cmp.Diff(want, requested))
+	}
+}
+
 func TestClient_Archive(t *testing.T) {
 	root, err := ioutil.TempDir("", t.Name())
 	if err != nil {
@@ -68,6 +68,47 @@ type RepoUpdateResponse struct {
 	Finished *time.Time // time request completed
 }
 
+// RepoMigrateRequest is a request sent by a gitserver to the gitserver which
+// now owns Repo. The receiver clones Repo from the sender's copy instead of
+// from the code host, so that rebalancing does not reclone from the code host.
+type RepoMigrateRequest struct {
+	Repo api.RepoName `json:"repo"` // identifying URL for repo
+	URL  string       `json:"url"`  // repo's remote URL
+
+	// Source is the address of the gitserver which currently has Repo
+	// cloned. Repo is fetched from its /git/ endpoint.
+	Source string `json:"source"`
+}
+
+// RepoMigrateResponse is the response to a RepoMigrateRequest.
+type RepoMigrateResponse struct {
+	// Cloned is true if the receiver has a complete copy of the repository.
+	// Only then may the sender delete its copy.
+	Cloned bool
+	Error  string // an error reported by the migration, as opposed to a protocol error
+}
+
+// RepoReplicateRequest is a request sent by the gitserver which owns Repo to
+// each of its replicas after Repo changed. The receiver fetches Repo from the
+// owner's copy instead of from the code host.
+type RepoReplicateRequest struct {
+	Repo api.RepoName `json:"repo"` // identifying URL for repo
+	URL  string       `json:"url"`  // repo's remote URL
+
+	// Source is the address of the gitserver which owns Repo. Repo is
+	// fetched from its /git/ endpoint.
+	Source string `json:"source"`
+
+	// RefHash is the hash of the refs of Repo on Source. The receiver does
+	// nothing if the hash of its copy is the same.
+	RefHash string `json:"refHash"`
+}
+
+// RepoReplicateResponse is the response to a RepoReplicateRequest.
+type RepoReplicateResponse struct {
+	Error string // an error reported by the replication, as opposed to a protocol error
+}
+
 type NotFoundPayload struct {
 	CloneInProgress bool `json:"cloneInProgress"` // If true, exec returned with noop because clone is in progress.
 
@@ -178,6 +219,10 @@ type CreateCommitFromPatchRequest struct {
 	CommitInfo PatchCommitInfo
 	// Push specifies whether the target ref will be pushed to the code host
 	Push bool
+	// PushRef, if set, is the ref on the code host that the commit is pushed
+	// to instead of TargetRef. Gerrit, for example, creates changes from
+	// commits pushed to refs/for/<branch>.
+	PushRef string
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
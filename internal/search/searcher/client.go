@@ -75,6 +75,13 @@ func Search(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.Repo
 		}
 		q.Set("Deadline", string(t))
 	}
+	if p.PatternExpression != nil {
+		expr, err := json.Marshal(p.PatternExpression)
+		if err != nil {
+			return nil, false, err
+		}
+		q.Set("PatternExpression", string(expr))
+	}
 	q.Set("FileMatchLimit", strconv.FormatInt(int64(p.FileMatchLimit), 10))
 	if p.IsRegExp {
 		q.Set("IsRegExp", "true")
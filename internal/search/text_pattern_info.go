@@ -6,7 +6,7 @@ import (
 )
 
 func (p *TextPatternInfo) IsEmpty() bool {
-	return p.Pattern == "" && p.ExcludePattern == "" && len(p.IncludePatterns) == 0
+	return p.Pattern == "" && p.PatternExpression == nil && p.ExcludePattern == "" && len(p.IncludePatterns) == 0
 }
 
 func (p *TextPatternInfo) Validate() error {
@@ -14,6 +14,13 @@ func (p *TextPatternInfo) Validate() error {
 		if _, err := syntax.Parse(p.Pattern, syntax.Perl); err != nil {
 			return err
 		}
+		if p.PatternExpression != nil {
+			for _, pattern := range p.PatternExpression.Patterns() {
+				if _, err := syntax.Parse(pattern, syntax.Perl); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	if p.ExcludePattern != "" {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -145,6 +146,12 @@ type TextParameters struct {
 	// to true if the user requests a specific timeout or maximum result size.
 	UseFullDeadline bool
 
+	// SkipUnindexed indicates that the file contents and paths of unindexed
+	// repositories are not searched, because they are searched separately for
+	// the whole and/or expression of search patterns that the query is an
+	// operand of.
+	SkipUnindexed bool
+
 	Zoekt        *searchbackend.Zoekt
 	SearcherURLs *endpoint.Map
 }
@@ -162,7 +169,12 @@ type TextParametersForCommitParameters struct {
 // TextPatternInfo is the struct used by vscode pass on search queries. Keep it in
 // sync with pkg/searcher/protocol.PatternInfo.
 type TextPatternInfo struct {
-	Pattern         string
+	Pattern string
+
+	// PatternExpression, if non-nil, is an and/or expression of patterns
+	// that searcher evaluates in a single search instead of Pattern.
+	PatternExpression *protocol.PatternExpression
+
 	IsNegated       bool
 	IsRegExp        bool
 	IsStructuralPat bool
@@ -189,6 +201,9 @@ type TextPatternInfo struct {
 
 func (p *TextPatternInfo) String() string {
 	args := []string{fmt.Sprintf("%q", p.Pattern)}
+	if p.PatternExpression != nil {
+		args[0] = p.PatternExpression.String()
+	}
 	if p.IsRegExp {
 		args = append(args, "re")
 	}
@@ -17,6 +17,10 @@ import (
 
 // ReadFile returns the first maxBytes of the named file at commit. If maxBytes <= 0, the entire
 // file is read. (If you just need to check a file's existence, use Stat, not ReadFile.)
+//
+// If gitserver fetches the Git LFS objects of repo (see the gitLFS site
+// configuration), the content of files stored with Git LFS is returned instead
+// of their pointer files.
 func ReadFile(ctx context.Context, repo gitserver.Repo, commit api.CommitID, name string, maxBytes int64) ([]byte, error) {
 	if Mocks.ReadFile != nil {
 		return Mocks.ReadFile(commit, name)
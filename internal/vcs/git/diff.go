@@ -17,6 +17,10 @@ type DiffOptions struct {
 	// These fields must be valid <commit> inputs as defined by gitrevisions(7).
 	Base string
 	Head string
+
+	// CanReadPath, if set, reports whether the user requesting the diff can read the file at the
+	// given path. Diffs of files that can't be read are skipped by the iterator.
+	CanReadPath func(path string) bool
 }
 
 // Diff returns an iterator that can be used to access the diff between two
@@ -54,14 +58,16 @@ func Diff(ctx context.Context, opts DiffOptions) (*DiffFileIterator, error) {
 	}
 
 	return &DiffFileIterator{
-		rdr:  rdr,
-		mfdr: diff.NewMultiFileDiffReader(rdr),
+		rdr:         rdr,
+		mfdr:        diff.NewMultiFileDiffReader(rdr),
+		canReadPath: opts.CanReadPath,
 	}, nil
 }
 
 type DiffFileIterator struct {
-	rdr  io.ReadCloser
-	mfdr *diff.MultiFileDiffReader
+	rdr         io.ReadCloser
+	mfdr        *diff.MultiFileDiffReader
+	canReadPath func(path string) bool
 }
 
 func (i *DiffFileIterator) Close() error {
@@ -71,5 +77,10 @@ func (i *DiffFileIterator) Close() error {
 // Next returns the next file diff. If no more diffs are available, the diff
 // will be nil and the error will be io.EOF.
 func (i *DiffFileIterator) Next() (*diff.FileDiff, error) {
-	return i.mfdr.ReadFile()
+	for {
+		fileDiff, err := i.mfdr.ReadFile()
+		if err != nil || i.canReadPath == nil || canReadFileDiff(fileDiff, i.canReadPath) {
+			return fileDiff, err
+		}
+	}
 }
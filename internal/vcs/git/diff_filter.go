@@ -21,9 +21,20 @@ func compilePathMatcher(options PathOptions) (pathmatch.PathMatcher, error) {
 	)
 }
 
+// canReadFileDiff reports whether both the original and the new name of the
+// file diff can be read, so that renames from or to an unreadable file are
+// excluded as well.
+func canReadFileDiff(fileDiff *diff.FileDiff, canReadPath func(string) bool) bool {
+	origNameReadable := fileDiff.OrigName == "/dev/null" || canReadPath(fileDiff.OrigName)
+	newNameReadable := fileDiff.NewName == "/dev/null" || canReadPath(fileDiff.NewName)
+	return origNameReadable && newNameReadable
+}
+
 // filterAndHighlightDiff returns the raw diff with query matches highlighted
 // and only hunks that satisfy the query (if onlyMatchingHunks) and path matcher.
-func filterAndHighlightDiff(rawDiff []byte, query *regexp.Regexp, onlyMatchingHunks bool, pathMatcher pathmatch.PathMatcher) (_ []byte, _ []Highlight, err error) {
+// If canReadPath is non-nil, files with an unreadable original or new name are
+// excluded as well.
+func filterAndHighlightDiff(rawDiff []byte, query *regexp.Regexp, onlyMatchingHunks bool, pathMatcher pathmatch.PathMatcher, canReadPath func(string) bool) (_ []byte, _ []Highlight, err error) {
 	// go-diff has been known to panic. Until we are sure it has been written
 	// to avoid panics, we protect calles from the panic. eg
 	// https://github.com/sourcegraph/go-diff/issues/54
@@ -61,6 +72,11 @@ func filterAndHighlightDiff(rawDiff []byte, query *regexp.Regexp, onlyMatchingHu
 			continue
 		}
 
+		// Exclude files that can't be read, including renames from or to them.
+		if canReadPath != nil && !canReadFileDiff(fileDiff, canReadPath) {
+			continue
+		}
+
 		// TODO(sqs): preserve the "no newline" message. We clear it out because our truncateLongLines
 		// and splitHunkMatches funcs don't properly adjust its offset as they modify hunk.Body. If
 		// the OrigNoNewlineAt points to an out-of-bounds offset, a panic will occur.
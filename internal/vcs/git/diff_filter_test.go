@@ -23,6 +23,7 @@ index a29bdeb434d874c9b1d8969c40c42161b03fafdc..c0d0fb45c382919737f8d0c20aaf57cf
 		rawDiff        string
 		query          string
 		paths          PathOptions
+		canReadPath    func(string) bool
 		want           string
 		wantHighlights []Highlight
 	}{
@@ -53,6 +54,20 @@ index a29bdeb434d874c9b1d8969c40c42161b03fafdc..c0d0fb45c382919737f8d0c20aaf57cf
 			want:           sampleRawDiff,
 			wantHighlights: []Highlight{{Line: 7, Character: 1, Length: 5}},
 		},
+		"readable path": {
+			rawDiff:        sampleRawDiff,
+			query:          "line2",
+			canReadPath:    func(path string) bool { return path == "f" },
+			want:           sampleRawDiff,
+			wantHighlights: []Highlight{{Line: 7, Character: 1, Length: 5}},
+		},
+		"unreadable path": {
+			rawDiff:        sampleRawDiff,
+			query:          "line2",
+			canReadPath:    func(path string) bool { return path != "f" },
+			want:           "",
+			wantHighlights: nil,
+		},
 	}
 	for label, test := range tests {
 		t.Run(label, func(t *testing.T) {
@@ -64,7 +79,7 @@ index a29bdeb434d874c9b1d8969c40c42161b03fafdc..c0d0fb45c382919737f8d0c20aaf57cf
 			if err != nil {
 				t.Fatal(err)
 			}
-			rawDiff, highlights, err := filterAndHighlightDiff([]byte(test.rawDiff), query, true, pathMatcher)
+			rawDiff, highlights, err := filterAndHighlightDiff([]byte(test.rawDiff), query, true, pathMatcher, test.canReadPath)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -59,6 +59,11 @@ type RawLogDiffSearchOptions struct {
 	// Paths specifies the paths to include/exclude.
 	Paths PathOptions
 
+	// CanReadPath, if set, reports whether the user searching can read the file at the given
+	// path. Files that can't be read are removed from diffs, and commits that only change such
+	// files are omitted from the results.
+	CanReadPath func(path string) bool
+
 	// FormatArgs is a list of format args that are passed to the `git log` command.
 	// Because the output is parsed, it is expected to be in a known format. If the
 	// FormatArgs does not match one of the server's expected values, the operation
@@ -327,7 +332,7 @@ func RawLogDiffSearch(ctx context.Context, repo gitserver.Repo, opt RawLogDiffSe
 	// Need --patch (TODO(sqs): or just --raw, which is smaller) if we are filtering by file paths,
 	// because we post-filter by path since we need to support regexps. Just the commit message
 	// alone would be insufficient for our post-filtering.
-	hasPathFilters := opt.Paths.ExcludePattern != "" || len(opt.Paths.IncludePatterns) > 0
+	hasPathFilters := opt.Paths.ExcludePattern != "" || len(opt.Paths.IncludePatterns) > 0 || opt.CanReadPath != nil
 	if hasPathFilters {
 		showArgs = append(showArgs, "--patch")
 	}
@@ -410,7 +415,7 @@ func RawLogDiffSearch(ctx context.Context, repo gitserver.Repo, opt RawLogDiffSe
 			}
 
 			var err error
-			rawDiff, result.DiffHighlights, err = filterAndHighlightDiff(rawDiff, query, opt.OnlyMatchingHunks, pathMatcher)
+			rawDiff, result.DiffHighlights, err = filterAndHighlightDiff(rawDiff, query, opt.OnlyMatchingHunks, pathMatcher, opt.CanReadPath)
 			if err != nil {
 				return nil, false, err
 			}
@@ -162,6 +162,31 @@ index 9bd8209..d2acfa9 100644
 		if count != testDiffFiles {
 			t.Errorf("unexpected diff count: have %d; want %d", count, testDiffFiles)
 		}
+
+		// Diffs of files that can't be read are skipped.
+		i, err = Diff(ctx, DiffOptions{
+			Base:        "foo",
+			Head:        "bar",
+			CanReadPath: func(path string) bool { return path != "JOKES.md" },
+		})
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		defer i.Close()
+
+		var names []string
+		for {
+			diff, err := i.Next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("unexpected iteration error: %+v", err)
+			}
+			names = append(names, diff.OrigName)
+		}
+		if have, want := strings.Join(names, ","), "INSTALL.md,README.md"; have != want {
+			t.Errorf("unexpected diff file names: have %s; want %s", have, want)
+		}
 	})
 }
 
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE lsif_index_configuration_errors;
+DROP TABLE lsif_index_requests;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE lsif_index_requests (
+    id bigserial NOT NULL PRIMARY KEY,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    commit text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT lsif_index_requests_commit_valid_chars CHECK (commit ~ '^[a-z0-9]{40}$')
+);
+
+CREATE TABLE lsif_index_configuration_errors (
+    repository_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    commit text NOT NULL,
+    message text NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
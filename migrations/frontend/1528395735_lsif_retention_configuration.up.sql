@@ -0,0 +1,11 @@
+BEGIN;
+
+CREATE TABLE lsif_retention_configuration (
+    id bigserial NOT NULL PRIMARY KEY,
+    repository_id integer NOT NULL UNIQUE REFERENCES repo(id) ON DELETE CASCADE,
+    max_age_seconds integer,
+    tag_pattern text,
+    max_tagged_age_seconds integer
+);
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE campaign_spec_executions (
+    id bigserial NOT NULL PRIMARY KEY,
+
+    campaign_spec_id bigint NOT NULL REFERENCES campaign_specs(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    base_ref text NOT NULL,
+    base_rev text NOT NULL,
+
+    changeset_spec_id bigint REFERENCES changeset_specs(id) ON DELETE SET NULL DEFERRABLE,
+    log_contents text,
+
+    state text DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX campaign_spec_executions_campaign_spec_id ON campaign_spec_executions(campaign_spec_id);
+CREATE INDEX campaign_spec_executions_state ON campaign_spec_executions(state);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS merging BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sub_repo_permissions (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    path_includes text[] NOT NULL DEFAULT '{}',
+    path_excludes text[] NOT NULL DEFAULT '{}',
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sub_repo_permissions_user_id_repo_id_unique ON sub_repo_permissions(user_id, repo_id);
+CREATE INDEX IF NOT EXISTS sub_repo_permissions_repo_id ON sub_repo_permissions(repo_id);
+
+COMMIT;
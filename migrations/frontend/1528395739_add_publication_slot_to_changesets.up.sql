@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS publication_slot TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
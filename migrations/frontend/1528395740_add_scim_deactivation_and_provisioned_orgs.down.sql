@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE orgs DROP COLUMN IF EXISTS scim_provisioned;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orgs ADD COLUMN IF NOT EXISTS scim_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE user_emails ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaign_spec_executions DROP CONSTRAINT IF EXISTS campaign_spec_executions_campaign_spec_id_repo_id_unique;
+
+COMMIT;
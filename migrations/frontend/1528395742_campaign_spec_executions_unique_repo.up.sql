@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Keep only the latest execution of a campaign spec in each repository.
+DELETE FROM campaign_spec_executions a
+USING campaign_spec_executions b
+WHERE a.campaign_spec_id = b.campaign_spec_id AND a.repo_id = b.repo_id AND a.id < b.id;
+
+ALTER TABLE campaign_spec_executions
+ADD CONSTRAINT campaign_spec_executions_campaign_spec_id_repo_id_unique UNIQUE (campaign_spec_id, repo_id);
+
+COMMIT;
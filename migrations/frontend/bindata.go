@@ -100,6 +100,24 @@
 // 1528395732_add_external_services_sync_jobs_state_index.up.sql (120B)
 // 1528395733_add_permissions_object_ids_default.down.sql (297B)
 // 1528395733_add_permissions_object_ids_default.up.sql (313B)
+// 1528395734_lsif_index_requests.down.sql (93B)
+// 1528395734_lsif_index_requests.up.sql (590B)
+// 1528395735_lsif_retention_configuration.down.sql (68B)
+// 1528395735_lsif_retention_configuration.up.sql (270B)
+// 1528395736_campaign_spec_executions.down.sql (64B)
+// 1528395736_campaign_spec_executions.up.sql (1.033kB)
+// 1528395737_add_merging_flag_to_changesets.down.sql (71B)
+// 1528395737_add_merging_flag_to_changesets.up.sql (105B)
+// 1528395738_sub_repo_permissions.up.sql (578B)
+// 1528395738_sub_repo_permissions.down.sql (60B)
+// 1528395739_add_publication_slot_to_changesets.up.sql (108B)
+// 1528395739_add_publication_slot_to_changesets.down.sql (80B)
+// 1528395740_add_scim_deactivation_and_provisioned_orgs.up.sql (192B)
+// 1528395740_add_scim_deactivation_and_provisioned_orgs.down.sql (130B)
+// 1528395741_add_is_primary_to_user_emails.down.sql (75B)
+// 1528395741_add_is_primary_to_user_emails.up.sql (109B)
+// 1528395742_campaign_spec_executions_unique_repo.up.sql (397B)
+// 1528395742_campaign_spec_executions_unique_repo.down.sql (138B)
 
 package migrations
 
@@ -2168,6 +2186,366 @@ func _1528395733_add_permissions_object_ids_defaultUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395734_lsif_index_requestsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xc8\x29\xce\x4c\x8b\xcf\xcc\x4b\x49\xad\x88\x4f\xce\xcf\x4b\xcb\x4c\x2f\x2d\x4a\x2c\xc9\xcc\xcf\x8b\x4f\x2d\x2a\xca\x2f\x2a\xb6\xc6\xa1\xb6\x28\xb5\xb0\x34\xb5\xb8\x04\x28\xcf\xe5\xec\xef\xeb\xeb\x19\x62\xcd\x05\x00\xcb\x00\x13\x2d\x5d\x00\x00\x00")
+
+func _1528395734_lsif_index_requestsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_lsif_index_requestsDownSql,
+		"1528395734_lsif_index_requests.down.sql",
+	)
+}
+
+func _1528395734_lsif_index_requestsDownSql() (*asset, error) {
+	bytes, err := _1528395734_lsif_index_requestsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_lsif_index_requests.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x53, 0xc1, 0x85, 0x7, 0xe2, 0x3f, 0x7d, 0x4e, 0x40, 0xf, 0x3, 0x18, 0x29, 0xb2, 0xbc, 0x9e, 0x60, 0x73, 0xeb, 0x55, 0x1c, 0xbf, 0x7f, 0x79, 0xfe, 0x4f, 0xd0, 0x14, 0x24, 0x91, 0x40}}
+	return a, nil
+}
+
+var __1528395734_lsif_index_requestsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x90\xc1\x6e\xc2\x30\x0c\x86\xef\x7d\x0a\x1f\x26\xd1\x4a\x43\xe2\xb0\xcb\xc4\x29\x84\xb0\x55\x94\x74\x2a\xe1\x80\xa6\x2d\xca\x68\x28\x96\x68\xc3\x92\x30\x18\xd3\xf6\xec\x54\x94\x8d\x1d\xd0\x26\xe1\x9b\xed\xdf\xf6\xef\xaf\xc7\xee\x62\xde\x0d\x02\x9a\x31\x22\x18\x08\xd2\x4b\x18\x2c\x1d\xce\x25\x56\xb9\xde\x4a\xab\x5f\xd7\xda\x79\x07\x61\x00\x75\x60\x0e\x2f\x58\x38\x6d\x51\x2d\x81\xa7\x02\xf8\x24\x49\xe0\x21\x8b\x47\x24\x9b\xc2\x90\x4d\xaf\x0f\x32\xab\x57\xc6\xa1\x37\xf6\x5d\xd6\x13\x58\x79\x5d\x68\x7b\xd2\x67\x6c\xc0\x32\xc6\x29\x1b\x1f\x94\x21\xe6\x11\xa4\x1c\xfa\x2c\x61\xb5\x07\x4a\xc6\x94\xf4\x59\xb3\x69\x66\xca\x12\x3d\x78\xbd\xf5\xc7\x82\xd5\xca\xeb\x5c\xaa\xba\x88\x65\x6d\x4d\x95\x2b\xd8\xa0\x5f\x1c\x52\xd8\x99\x4a\x9f\x0e\xf5\xd9\x80\x4c\x12\x01\x95\xd9\x84\x51\x33\x4f\x53\x3e\x16\x19\x89\xb9\x38\xf7\xa6\x6c\xee\xc9\x37\xb5\xc4\x5c\xce\x16\xca\x3a\xa0\xf7\x8c\x0e\x21\x3c\x3a\xf9\x82\xd6\xf3\xa3\x6a\xef\x3a\xed\xdb\xa7\x8f\x9b\xce\xe7\x55\x2b\x0a\xa2\x3f\x00\xce\x4c\x35\xc7\x62\x6d\x95\x47\x53\x49\x6d\xad\xb1\xdf\x30\xff\xa1\xf4\x8b\xea\x85\xc4\x7e\x76\x35\x9d\x9a\x96\x53\x85\x3e\xd7\x5a\xaf\xf2\x0b\xa9\x36\xcf\xa7\xa3\x51\x2c\xba\xc1\x1e\x35\x74\x9a\x7a\x4e\x02\x00\x00")
+
+func _1528395734_lsif_index_requestsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_lsif_index_requestsUpSql,
+		"1528395734_lsif_index_requests.up.sql",
+	)
+}
+
+func _1528395734_lsif_index_requestsUpSql() (*asset, error) {
+	bytes, err := _1528395734_lsif_index_requestsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_lsif_index_requests.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0x3e, 0x45, 0xc9, 0xbd, 0xa6, 0x8a, 0x95, 0xd5, 0x19, 0x27, 0x6, 0x85, 0xbf, 0x6e, 0x8d, 0xc3, 0x3e, 0x27, 0xb4, 0x1, 0x94, 0xc8, 0xfc, 0x67, 0x6b, 0x67, 0x42, 0x37, 0xdb, 0x1f, 0xa2}}
+	return a, nil
+}
+
+var __1528395735_lsif_retention_configurationDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x29\xce\x4c\x8b\x2f\x4a\x2d\x49\xcd\x2b\xc9\xcc\xcf\x8b\x4f\xce\xcf\x4b\xcb\x4c\x2f\x2d\x4a\x04\xf1\x80\x7a\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x29\x11\x19\xdd\x44\x00\x00\x00")
+
+func _1528395735_lsif_retention_configurationDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395735_lsif_retention_configurationDownSql,
+		"1528395735_lsif_retention_configuration.down.sql",
+	)
+}
+
+func _1528395735_lsif_retention_configurationDownSql() (*asset, error) {
+	bytes, err := _1528395735_lsif_retention_configurationDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395735_lsif_retention_configuration.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x14, 0x98, 0xcc, 0x70, 0x1, 0x23, 0x53, 0x36, 0x76, 0x52, 0x7b, 0xc3, 0x6f, 0x35, 0x22, 0xe, 0x2b, 0xc4, 0x12, 0xc, 0x76, 0xc, 0xf3, 0xd6, 0xc5, 0xc7, 0x96, 0xd6, 0xb7, 0x29, 0xb9, 0xc7}}
+	return a, nil
+}
+
+var __1528395735_lsif_retention_configurationUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8f\xc1\x6e\xc2\x30\x10\x44\xef\xfe\x8a\x3d\x82\xd4\x3f\xe0\x64\xc2\xb6\x8a\x48\x1c\x6a\x92\x03\x27\xcb\x25\x1b\x6b\x25\x70\x90\xbd\x95\xe8\xdf\xd7\x80\x10\x17\xf6\xb6\x9a\x79\xa3\x99\x35\x7e\xd5\x66\xa5\x54\x65\x51\xf7\x08\xbd\x5e\x37\x08\xa7\xcc\x93\x4b\x24\x14\x85\xe7\xe8\x8e\x73\x9c\x38\xfc\x26\x7f\xfb\x60\xa1\xa0\x1c\x8f\xf0\xc3\x21\x53\x62\x7f\x02\xd3\xf5\x60\x86\xa6\x81\x9d\xad\x5b\x6d\x0f\xb0\xc5\xc3\xc7\xdd\x96\xe8\x32\x67\x96\x39\xfd\xb9\x42\x70\x14\x0a\x94\x5e\xfe\xc1\xd4\xdf\x03\x82\xc5\x4f\xb4\x68\x2a\xdc\xdf\x81\x05\x8f\x4b\xe8\x0c\x6c\xb0\xc1\xd2\xa9\xd2\xfb\x4a\x6f\xf0\x11\x78\xf6\x57\xe7\x03\xb9\x4c\xa5\xd5\x98\x9f\x91\x0f\x51\x7c\x70\x17\x2f\x42\x29\x82\xd0\x55\x5e\x48\x51\x02\x8d\xef\x48\xb5\xbc\xad\xef\xda\xb6\xee\x57\xea\x1f\x84\x42\x0a\xdf\x0e\x01\x00\x00")
+
+func _1528395735_lsif_retention_configurationUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395735_lsif_retention_configurationUpSql,
+		"1528395735_lsif_retention_configuration.up.sql",
+	)
+}
+
+func _1528395735_lsif_retention_configurationUpSql() (*asset, error) {
+	bytes, err := _1528395735_lsif_retention_configurationUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395735_lsif_retention_configuration.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf8, 0xfa, 0xaf, 0xe5, 0x17, 0xf9, 0x2c, 0x63, 0x35, 0x37, 0x9d, 0x19, 0xb4, 0xc1, 0xd5, 0x60, 0x28, 0x8, 0x5d, 0xa0, 0xe8, 0x1e, 0xea, 0xd3, 0x91, 0x8e, 0xda, 0x64, 0xaa, 0x40, 0x9c, 0xdb}}
+	return a, nil
+}
+
+var __1528395736_campaign_spec_executionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x8b\x2f\x2e\x48\x4d\x8e\x4f\xad\x48\x4d\x2e\x2d\xc9\xcc\xcf\x2b\x06\xaa\x77\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa4\x86\x10\x8e\x40\x00\x00\x00")
+
+func _1528395736_campaign_spec_executionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_campaign_spec_executionsDownSql,
+		"1528395736_campaign_spec_executions.down.sql",
+	)
+}
+
+func _1528395736_campaign_spec_executionsDownSql() (*asset, error) {
+	bytes, err := _1528395736_campaign_spec_executionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_campaign_spec_executions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0x95, 0x50, 0x42, 0x73, 0xcc, 0xe3, 0xee, 0x28, 0x46, 0xf9, 0x20, 0x59, 0x42, 0x66, 0xfc, 0xd, 0x89, 0xa9, 0x8b, 0xd8, 0x50, 0xef, 0x81, 0x58, 0xeb, 0x2c, 0xff, 0xc5, 0x38, 0x83, 0x94}}
+	return a, nil
+}
+
+var __1528395736_campaign_spec_executionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x53\xc1\x6e\x82\x40\x10\xbd\xf3\x15\x73\x13\x13\x0f\xbd\x7b\x42\xdc\x36\xa4\x88\x0d\x62\x52\x4f\x9b\x15\x46\xdc\x44\x17\xba\xbb\x54\xd3\xaf\xef\x08\xb4\x54\xac\xd6\x74\x6f\x9b\x79\xef\xcd\x7b\x33\xbb\x13\xf6\x14\x44\x63\xc7\xf1\x63\xe6\x25\x0c\x12\x6f\x12\x32\x48\xc5\xbe\x14\x32\x57\xdc\x94\x98\x72\x3c\x62\x5a\x59\x59\x28\x03\xae\x03\x74\x64\x06\x6b\x99\x1b\xd4\x52\xec\x20\x9a\x27\x10\x2d\xc3\x10\x5e\xe2\x60\xe6\xc5\x2b\x78\x66\xab\x91\x53\xe3\xce\x65\x1a\x96\x54\xb6\xa3\xc4\xec\x91\xc5\x2c\xf2\xd9\xe2\x1c\x6b\x5c\x99\x0d\x61\x1e\xc1\x94\x85\x8c\x5c\xf9\xde\xc2\xf7\xa6\x8c\xae\x84\x8f\x4f\x16\x47\x75\x03\x8d\x65\x71\xd2\x25\x51\xcc\x51\xff\x2a\x7c\xc2\xdc\x29\xb7\x16\x06\xb9\xc6\x0d\x58\x3c\x76\x2e\xcf\x6a\xef\xfd\x5a\x13\x74\x2b\x54\x8e\x06\x6d\x3f\xe9\xcf\x80\x67\x98\x7e\xc2\x05\x6b\x9d\xf7\x3d\xed\x8a\x9c\xa7\x05\xe5\x53\xd6\xd4\xbd\xdb\x96\xc6\x0a\x8b\x8d\x19\xa2\x78\xcb\x30\x81\xc1\x5b\x85\x15\x66\x83\x86\xb8\x11\x72\x57\x69\xe4\x7b\x34\x46\xe4\xd8\x72\x5b\xaa\xb6\x98\x71\x61\xc1\x4a\x2a\x5b\x1a\x3d\x1c\xa4\xdd\xd6\x57\xf8\x28\x14\xb6\x12\x52\x49\xb3\xbd\x07\x59\xea\x22\xa5\x3e\x5c\x6c\x2c\xad\xe1\x36\x56\x55\x7b\x1a\x24\x0d\xc2\x5c\xee\xed\x2b\xca\x43\x07\x6d\x73\xdc\x06\x37\x5b\xd0\x28\xfe\xc8\xf5\xcd\x51\xc5\xc1\x1d\xf6\x56\x5c\x95\xd9\x3f\xf9\xce\xb0\xfb\x41\x41\x34\x65\xaf\x57\x7f\x10\xbf\xf8\x13\xf4\x06\xae\x81\xdd\x3e\x98\xda\xdc\xd7\xa5\x79\x1d\xb7\xa4\x6b\x44\x6d\x7b\x3e\x9b\x05\xc9\xd8\xf9\x04\x91\x6a\x61\x3c\x09\x04\x00\x00")
+
+func _1528395736_campaign_spec_executionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_campaign_spec_executionsUpSql,
+		"1528395736_campaign_spec_executions.up.sql",
+	)
+}
+
+func _1528395736_campaign_spec_executionsUpSql() (*asset, error) {
+	bytes, err := _1528395736_campaign_spec_executionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_campaign_spec_executions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7e, 0x54, 0x6a, 0x81, 0xa0, 0xbd, 0xc6, 0x4, 0x4b, 0xf0, 0x74, 0xfc, 0xd1, 0xd6, 0xea, 0x28, 0x68, 0x22, 0xdd, 0x2d, 0x61, 0x55, 0x91, 0x7b, 0x77, 0x39, 0x14, 0x4a, 0x55, 0x9d, 0x81, 0xdb}}
+	return a, nil
+}
+
+var __1528395737_add_merging_flag_to_changesetsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4d\x2d\x4a\xcf\xcc\x4b\x07\x6a\x73\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x67\x2b\xa0\x58\x47\x00\x00\x00")
+
+func _1528395737_add_merging_flag_to_changesetsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395737_add_merging_flag_to_changesetsDownSql,
+		"1528395737_add_merging_flag_to_changesets.down.sql",
+	)
+}
+
+func _1528395737_add_merging_flag_to_changesetsDownSql() (*asset, error) {
+	bytes, err := _1528395737_add_merging_flag_to_changesetsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395737_add_merging_flag_to_changesets.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0x86, 0x29, 0xb6, 0x40, 0x2f, 0x56, 0xf0, 0xb6, 0xa, 0x3a, 0xb7, 0x28, 0xfc, 0xb6, 0x8e, 0xdb, 0x98, 0x2d, 0x72, 0x46, 0x6a, 0x11, 0x7e, 0x83, 0x45, 0xba, 0xad, 0xd8, 0x14, 0x7a, 0x48}}
+	return a, nil
+}
+
+var __1528395737_add_merging_flag_to_changesetsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x1d\xcc\x31\x0a\xc3\x30\x0c\x05\xd0\xdd\xa7\xf8\xf7\xc8\x24\xc7\x72\x31\xc8\x12\xd4\x32\x64\x0d\xc5\xb8\x1d\x9a\xa1\xe9\xfd\x69\xe8\xfc\xe0\x45\xbe\x15\x5d\x42\x20\x71\xbe\xc3\x29\x0a\xe3\xf1\xdc\x8f\x39\xce\xf1\x3d\x41\x29\x61\x35\xe9\x55\x51\x32\xd4\x1c\xbc\x95\xe6\x0d\xef\xf1\x99\xaf\x63\x22\x9a\x09\x93\xfe\x49\xbb\x08\x12\x67\xea\xe2\xc8\x24\x8d\xaf\x78\xb5\x5a\x8b\x2f\xe1\x07\x11\x60\x23\x1c\x69\x00\x00\x00")
+
+func _1528395737_add_merging_flag_to_changesetsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395737_add_merging_flag_to_changesetsUpSql,
+		"1528395737_add_merging_flag_to_changesets.up.sql",
+	)
+}
+
+func _1528395737_add_merging_flag_to_changesetsUpSql() (*asset, error) {
+	bytes, err := _1528395737_add_merging_flag_to_changesetsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395737_add_merging_flag_to_changesets.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa8, 0xd8, 0x41, 0x57, 0x2a, 0x54, 0x28, 0xc5, 0xe9, 0xa4, 0xc3, 0x66, 0xbf, 0x68, 0xd8, 0xdf, 0x7e, 0xca, 0x82, 0xf2, 0x20, 0x43, 0xe6, 0x56, 0x6e, 0x7, 0x31, 0x9b, 0x61, 0x69, 0x45, 0x64}}
+	return a, nil
+}
+
+var __1528395738_sub_repo_permissionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x91\x5f\x6b\x83\x30\x14\x47\xdf\xf3\x29\xee\x5b\x15\xfa\x0d\xfa\x64\xf5\x3a\x04\x1b\xa9\x46\x28\x8c\x11\xdc\xbc\xac\x81\x19\xad\x49\x68\xd9\xd8\x77\x9f\x15\x8b\x83\xfd\xa9\x79\x0b\x9c\x9c\x1f\x9c\x6c\xf1\x21\xe1\x1b\xc6\xc2\x1c\x03\x81\x20\x82\x6d\x8a\x90\xc4\xc0\x33\x01\x78\x48\x0a\x51\x80\x71\xcf\xb2\xa7\xae\x95\x1d\xf5\x8d\x32\x46\xb5\xda\x80\xc7\x60\x38\xce\x50\x2f\x55\x0d\x4a\x5b\x7a\xa5\x7e\x7c\xc5\xcb\x34\x85\x1c\x63\xcc\x91\x87\x58\x8c\x8c\xf1\x54\xed\x43\xc6\x21\xc2\x14\x87\x99\x30\x28\xc2\x20\xc2\xf5\x28\x19\xdd\x77\x24\x57\xe6\x3f\x47\x57\xd9\xa3\x54\xfa\xe5\xcd\xd5\x64\xc0\xd2\xc5\x3e\x3e\xcd\xa2\x08\xe3\xa0\x4c\x05\xac\x3e\x3e\x57\xdf\x78\xba\x2c\xe6\x5d\x57\x57\x96\x6a\x59\x59\xb0\xaa\x21\x63\xab\xa6\x83\xb3\xb2\xc7\xf1\x0a\xef\xad\xa6\x9f\xcf\x75\x7b\xf6\x7c\xe6\xcf\x75\x4b\x9e\xec\xcb\x21\x2f\x8f\xf0\xb0\x20\xb2\x9c\xf2\xca\xa9\x90\x74\x5a\x9d\x1c\x5d\x1b\xfc\x86\x7b\x13\xbe\xbe\x15\x1d\x96\xa7\xe1\xc5\x8b\xb7\xbf\xf8\x6b\x62\x36\xb3\x30\xdb\xed\x12\xb1\x61\x5f\xe8\x49\x84\x92\x42\x02\x00\x00")
+
+func _1528395738_sub_repo_permissionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395738_sub_repo_permissionsUpSql,
+		"1528395738_sub_repo_permissions.up.sql",
+	)
+}
+
+func _1528395738_sub_repo_permissionsUpSql() (*asset, error) {
+	bytes, err := _1528395738_sub_repo_permissionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395738_sub_repo_permissions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x69, 0x9e, 0x81, 0xb7, 0x73, 0xba, 0xca, 0xe8, 0x25, 0xa3, 0x8d, 0xe, 0x6, 0x25, 0x4e, 0xf3, 0xec, 0x9e, 0x73, 0xb5, 0x44, 0x81, 0x6b, 0x4a, 0xca, 0xb7, 0xd0, 0xb5, 0x9e, 0xfa, 0x90, 0x0}}
+	return a, nil
+}
+
+var __1528395738_sub_repo_permissionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x2e\x4d\x8a\x2f\x4a\x2d\xc8\x8f\x2f\x48\x2d\xca\xcd\x2c\x2e\xce\xcc\xcf\x2b\x06\xaa\x75\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x18\x3d\x94\xd1\x3c\x00\x00\x00")
+
+func _1528395738_sub_repo_permissionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395738_sub_repo_permissionsDownSql,
+		"1528395738_sub_repo_permissions.down.sql",
+	)
+}
+
+func _1528395738_sub_repo_permissionsDownSql() (*asset, error) {
+	bytes, err := _1528395738_sub_repo_permissionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395738_sub_repo_permissions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x41, 0x58, 0x33, 0x7d, 0xc6, 0xd8, 0x2, 0xa8, 0x6f, 0x3b, 0x7e, 0xc1, 0xe2, 0x1c, 0xaa, 0xe7, 0x84, 0xda, 0x2, 0x4f, 0x53, 0x75, 0x3f, 0xaf, 0xb2, 0xf9, 0xe6, 0x5b, 0x49, 0xc6, 0xac, 0xeb}}
+	return a, nil
+}
+
+var __1528395739_add_publication_slot_to_changesetsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x1d\xcc\x41\x0a\x83\x30\x10\x05\xd0\x7d\x4e\xf1\xef\xe1\x2a\xea\x58\x07\x32\x49\x69\xa6\xb4\xb8\x11\x2b\xd2\x0a\x41\x0b\x49\xef\xaf\x74\xf9\x36\xaf\xa6\x0b\xfb\xca\x18\xeb\x94\x6e\x50\x5b\x3b\xc2\xfc\x99\xb6\xf7\x92\x97\x92\x61\xdb\x16\x4d\x70\x77\xf1\xe0\x0e\x3e\x28\xe8\xc9\x51\x23\xbe\xbf\x57\x5a\xe7\xa9\xac\xfb\x36\xe6\xb4\x17\x28\x0b\x45\xb5\x72\xc5\x83\xb5\xff\x13\x43\xf0\x74\xde\x4d\x10\x61\xad\xcc\x01\xee\x78\x06\x3a\x6c\x00\x00\x00")
+
+func _1528395739_add_publication_slot_to_changesetsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395739_add_publication_slot_to_changesetsUpSql,
+		"1528395739_add_publication_slot_to_changesets.up.sql",
+	)
+}
+
+func _1528395739_add_publication_slot_to_changesetsUpSql() (*asset, error) {
+	bytes, err := _1528395739_add_publication_slot_to_changesetsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395739_add_publication_slot_to_changesets.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc, 0xe4, 0x25, 0x17, 0x3a, 0x86, 0x39, 0x8c, 0x7e, 0xab, 0xcd, 0x78, 0x93, 0x16, 0xff, 0xbf, 0x78, 0x81, 0x76, 0xa7, 0x54, 0xa2, 0xac, 0x6d, 0xed, 0x74, 0x6c, 0x14, 0xa8, 0xb7, 0xb3, 0x20}}
+	return a, nil
+}
+
+var __1528395739_add_publication_slot_to_changesetsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x28\x4d\xca\xc9\x4c\x4e\x2c\xc9\xcc\xcf\x8b\x2f\xce\xc9\x2f\x01\xea\x77\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x64\x12\x4c\xd2\x50\x00\x00\x00")
+
+func _1528395739_add_publication_slot_to_changesetsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395739_add_publication_slot_to_changesetsDownSql,
+		"1528395739_add_publication_slot_to_changesets.down.sql",
+	)
+}
+
+func _1528395739_add_publication_slot_to_changesetsDownSql() (*asset, error) {
+	bytes, err := _1528395739_add_publication_slot_to_changesetsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395739_add_publication_slot_to_changesets.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfc, 0xba, 0x22, 0x7e, 0xd1, 0x64, 0x53, 0x93, 0x6a, 0xcb, 0x34, 0x80, 0x5a, 0x5, 0x4a, 0x8f, 0xa, 0xb5, 0xf4, 0x2e, 0x42, 0x7a, 0x2f, 0x48, 0x53, 0xdc, 0xc4, 0x6c, 0x9e, 0xc6, 0x6f, 0x36}}
+	return a, nil
+}
+
+var __1528395740_add_scim_deactivation_and_provisioned_orgsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\xcc\xb1\x0e\x82\x30\x14\x46\xe1\xbd\x4f\xf1\xbf\x07\x53\x81\x8b\x36\xb9\x6d\x8d\xbd\x44\xe3\x42\x08\x34\xa6\x83\xd6\x50\xe4\xf9\x35\x6c\x2e\x8e\x27\x39\xf9\x6a\x3a\x18\x57\x29\xa5\x59\xe8\x0c\xd1\x35\x13\xde\x25\x2e\x05\xba\x6d\xd1\x78\xee\xad\x83\xe9\xe0\xbc\x80\xae\x26\x48\xc0\x1c\xc7\x69\x4d\xdb\xb8\xc6\x79\x18\x57\x88\xb1\x14\x44\xdb\x13\x2e\x46\x8e\x7b\xe2\xe6\x1d\x55\x3f\x66\x5e\xee\x7f\xc8\x32\xa5\xc7\xf0\x5a\xf2\x96\x4a\xca\xcf\x38\xa3\xf6\x9e\x49\xbb\xfd\x71\x3d\x33\x5a\xea\x74\xcf\x82\x4e\x73\xf8\xd2\xaa\xf1\xd6\x1a\xa9\xd4\x07\xb9\xc7\xd2\x46\xc0\x00\x00\x00")
+
+func _1528395740_add_scim_deactivation_and_provisioned_orgsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395740_add_scim_deactivation_and_provisioned_orgsUpSql,
+		"1528395740_add_scim_deactivation_and_provisioned_orgs.up.sql",
+	)
+}
+
+func _1528395740_add_scim_deactivation_and_provisioned_orgsUpSql() (*asset, error) {
+	bytes, err := _1528395740_add_scim_deactivation_and_provisioned_orgsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395740_add_scim_deactivation_and_provisioned_orgs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe1, 0xec, 0xe4, 0x77, 0x70, 0x60, 0x63, 0x41, 0x1, 0x36, 0x1a, 0x95, 0x41, 0xea, 0x6, 0xf7, 0xfe, 0xba, 0x5, 0x9, 0xb1, 0x40, 0xfa, 0x2a, 0x59, 0xea, 0x33, 0x5b, 0xc3, 0x55, 0x47, 0x95}}
+	return a, nil
+}
+
+var __1528395740_add_scim_deactivation_and_provisioned_orgsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x49\x4d\x4c\x2e\xc9\x2c\x4b\x2c\x49\x4d\x89\x4f\x2c\xb1\x46\xd1\x97\x5f\x94\x8e\x4b\x5b\x71\x72\x66\x6e\x7c\x41\x51\x7e\x59\x66\x71\x66\x7e\x5e\x6a\x0a\xd0\x46\x67\x7f\x5f\x5f\xcf\x10\x6b\x2e\x00\x12\x5d\x7a\xe6\x82\x00\x00\x00")
+
+func _1528395740_add_scim_deactivation_and_provisioned_orgsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395740_add_scim_deactivation_and_provisioned_orgsDownSql,
+		"1528395740_add_scim_deactivation_and_provisioned_orgs.down.sql",
+	)
+}
+
+func _1528395740_add_scim_deactivation_and_provisioned_orgsDownSql() (*asset, error) {
+	bytes, err := _1528395740_add_scim_deactivation_and_provisioned_orgsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395740_add_scim_deactivation_and_provisioned_orgs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0x7e, 0xe8, 0x27, 0xbb, 0x9b, 0x3b, 0xd8, 0x41, 0xb7, 0x7f, 0x7d, 0x1e, 0xc5, 0xc7, 0xfd, 0x10, 0x56, 0x70, 0x74, 0xf1, 0x7a, 0x9f, 0x33, 0x44, 0xe5, 0xd4, 0xc8, 0xb8, 0x9a, 0x25, 0x42}}
+	return a, nil
+}
+
+var __1528395741_add_is_primary_to_user_emailsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x2d\x4e\x2d\x8a\x4f\xcd\x4d\xcc\xcc\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x2c\x8e\x2f\x28\xca\xcc\x4d\x2c\xaa\x04\x6a\x75\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\x0f\x46\xa8\xe9\x4b\x00\x00\x00")
+
+func _1528395741_add_is_primary_to_user_emailsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395741_add_is_primary_to_user_emailsDownSql,
+		"1528395741_add_is_primary_to_user_emails.down.sql",
+	)
+}
+
+func _1528395741_add_is_primary_to_user_emailsDownSql() (*asset, error) {
+	bytes, err := _1528395741_add_is_primary_to_user_emailsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395741_add_is_primary_to_user_emails.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4d, 0x61, 0x35, 0x92, 0x1b, 0x53, 0x20, 0x54, 0x2f, 0x6d, 0xdb, 0xf7, 0xe3, 0xfb, 0x50, 0x27, 0xb4, 0x8b, 0xca, 0x78, 0xf6, 0x96, 0xef, 0x16, 0x3f, 0x4a, 0x4b, 0xd7, 0xbf, 0xa8, 0x6c, 0xcf}}
+	return a, nil
+}
+
+var __1528395741_add_is_primary_to_user_emailsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x1d\xcc\xb1\x0a\xc3\x20\x10\x06\xe0\xdd\xa7\xf8\xdf\x23\xd3\x19\xcf\x20\x9c\x1e\xd4\x13\xba\x85\x0c\x19\x84\x06\x8a\xd2\xa1\x6f\x9f\xd2\xf9\x83\xcf\xf3\x96\xca\xe2\x1c\x89\xf1\x03\x46\x5e\x18\x9f\x79\x8e\xfd\xbc\x8e\xfe\x9a\xa0\x10\xb0\xaa\xb4\x5c\x90\x22\x8a\x1a\xf8\x99\xaa\x55\xf4\xb9\xbf\x47\xbf\x8e\xf1\x85\x57\x15\xa6\xf2\xd7\xd2\x44\x10\x38\x52\x13\x43\x24\xa9\xfc\xcb\x57\xcd\x39\xd9\xe2\x6e\x53\xf4\x9a\x66\x6d\x00\x00\x00")
+
+func _1528395741_add_is_primary_to_user_emailsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395741_add_is_primary_to_user_emailsUpSql,
+		"1528395741_add_is_primary_to_user_emails.up.sql",
+	)
+}
+
+func _1528395741_add_is_primary_to_user_emailsUpSql() (*asset, error) {
+	bytes, err := _1528395741_add_is_primary_to_user_emailsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395741_add_is_primary_to_user_emails.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x98, 0xdf, 0x65, 0xf0, 0x6c, 0x1a, 0x8, 0x8c, 0xc6, 0xb3, 0x5a, 0x59, 0xea, 0x72, 0xc8, 0x78, 0x3d, 0x8d, 0x19, 0xd6, 0x4c, 0xf, 0x41, 0xe9, 0xbf, 0xbf, 0x79, 0x8a, 0x8c, 0x8e, 0x1c, 0xb6}}
+	return a, nil
+}
+
+var __1528395742_campaign_spec_executions_unique_repoUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x75\xcf\xc1\x6e\xc2\x30\x0c\x06\xe0\x7b\x9e\xe2\x3f\x6e\x12\xf0\x02\x6c\x87\x40\x03\x8b\xd6\xa6\xa2\xa4\xda\x31\x0a\xc5\x1b\x91\x58\x52\x68\x2a\xc1\xdb\x2f\x68\x80\x34\xa6\xde\x2c\xfb\x93\xfd\x7b\x26\x96\x52\x4d\x19\x1b\x8f\xf1\x4e\xd4\x22\xf8\xfd\x19\x71\x47\xd8\xdb\x48\x5d\x04\x9d\xa8\xe9\xa3\x0b\x1e\xe1\x13\x16\x8d\xfd\x6e\xad\xfb\xf2\xe8\x5a\x6a\xe0\x3c\xc8\x36\x3b\x1c\xa9\x0d\x9d\x8b\xe1\x78\x9e\xb0\x4c\xe4\x42\x0b\x2c\xaa\xb2\xb8\x6b\x73\xd1\xe6\xbe\xaa\x83\x65\xf5\x5a\xaa\xe5\x30\xd8\xb0\x8f\x37\x51\x09\xd8\xc9\x5f\xe2\xb6\x78\xc5\xe6\x7f\x93\xab\x2c\xd9\x4b\x8e\x1b\xb9\xd5\xbf\x93\x54\xbc\xa4\xa6\xdb\xa6\x57\x79\xae\x45\x05\xcd\x67\xb9\x18\x0c\xc0\x78\x96\x61\x5e\xaa\xb5\xae\xb8\x54\x7a\xd0\x99\xc7\x24\xe6\x7a\xd7\xf4\xde\x1d\x7a\x42\xad\xe4\xaa\x16\x78\x7a\x74\x23\x5c\xe1\x73\x4a\x34\x2f\x8b\x42\xea\x29\xfb\x01\xe1\x57\xcc\x73\x8d\x01\x00\x00")
+
+func _1528395742_campaign_spec_executions_unique_repoUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395742_campaign_spec_executions_unique_repoUpSql,
+		"1528395742_campaign_spec_executions_unique_repo.up.sql",
+	)
+}
+
+func _1528395742_campaign_spec_executions_unique_repoUpSql() (*asset, error) {
+	bytes, err := _1528395742_campaign_spec_executions_unique_repoUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395742_campaign_spec_executions_unique_repo.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x15, 0xf6, 0x7e, 0x94, 0x1b, 0x94, 0x95, 0xd, 0x23, 0xe6, 0xaf, 0xd4, 0x22, 0x4e, 0x9b, 0xac, 0x55, 0xbf, 0x76, 0x81, 0xf4, 0xe1, 0xaa, 0xd4, 0x31, 0xb, 0xd9, 0x29, 0x95, 0x37, 0xeb, 0xd0}}
+	return a, nil
+}
+
+var __1528395742_campaign_spec_executions_unique_repoDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x73\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x8b\x2f\x2e\x48\x4d\x8e\x4f\xad\x48\x4d\x2e\x2d\xc9\xcc\xcf\x2b\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x0b\x0e\x09\x72\xf4\xf4\x0b\x51\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\xc6\xa9\x25\x1e\x55\x22\x33\x25\xbe\x28\xb5\x20\x1f\x44\x97\xe6\x65\x16\x96\xa6\x02\x6d\x76\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x00\xa2\xdc\x46\xc5\x8a\x00\x00\x00")
+
+func _1528395742_campaign_spec_executions_unique_repoDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395742_campaign_spec_executions_unique_repoDownSql,
+		"1528395742_campaign_spec_executions_unique_repo.down.sql",
+	)
+}
+
+func _1528395742_campaign_spec_executions_unique_repoDownSql() (*asset, error) {
+	bytes, err := _1528395742_campaign_spec_executions_unique_repoDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395742_campaign_spec_executions_unique_repo.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5d, 0x9c, 0xe3, 0xaf, 0x10, 0x64, 0x1e, 0x5e, 0x65, 0xec, 0xf, 0xd8, 0x9d, 0xa0, 0xed, 0xb6, 0x13, 0xbb, 0x62, 0x97, 0x42, 0xd8, 0xdc, 0x47, 0x23, 0x7a, 0xaa, 0x3c, 0xcd, 0xd2, 0x4d, 0xc4}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -2359,6 +2737,24 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395732_add_external_services_sync_jobs_state_index.up.sql":                _1528395732_add_external_services_sync_jobs_state_indexUpSql,
 	"1528395733_add_permissions_object_ids_default.down.sql":                       _1528395733_add_permissions_object_ids_defaultDownSql,
 	"1528395733_add_permissions_object_ids_default.up.sql":                         _1528395733_add_permissions_object_ids_defaultUpSql,
+	"1528395734_lsif_index_requests.down.sql":                                      _1528395734_lsif_index_requestsDownSql,
+	"1528395734_lsif_index_requests.up.sql":                                        _1528395734_lsif_index_requestsUpSql,
+	"1528395735_lsif_retention_configuration.down.sql":                             _1528395735_lsif_retention_configurationDownSql,
+	"1528395735_lsif_retention_configuration.up.sql":                               _1528395735_lsif_retention_configurationUpSql,
+	"1528395736_campaign_spec_executions.down.sql":                                 _1528395736_campaign_spec_executionsDownSql,
+	"1528395736_campaign_spec_executions.up.sql":                                   _1528395736_campaign_spec_executionsUpSql,
+	"1528395737_add_merging_flag_to_changesets.down.sql":                           _1528395737_add_merging_flag_to_changesetsDownSql,
+	"1528395737_add_merging_flag_to_changesets.up.sql":                             _1528395737_add_merging_flag_to_changesetsUpSql,
+	"1528395738_sub_repo_permissions.up.sql":                                       _1528395738_sub_repo_permissionsUpSql,
+	"1528395738_sub_repo_permissions.down.sql":                                     _1528395738_sub_repo_permissionsDownSql,
+	"1528395739_add_publication_slot_to_changesets.up.sql":                         _1528395739_add_publication_slot_to_changesetsUpSql,
+	"1528395739_add_publication_slot_to_changesets.down.sql":                       _1528395739_add_publication_slot_to_changesetsDownSql,
+	"1528395740_add_scim_deactivation_and_provisioned_orgs.up.sql":                 _1528395740_add_scim_deactivation_and_provisioned_orgsUpSql,
+	"1528395740_add_scim_deactivation_and_provisioned_orgs.down.sql":               _1528395740_add_scim_deactivation_and_provisioned_orgsDownSql,
+	"1528395741_add_is_primary_to_user_emails.down.sql":                            _1528395741_add_is_primary_to_user_emailsDownSql,
+	"1528395741_add_is_primary_to_user_emails.up.sql":                              _1528395741_add_is_primary_to_user_emailsUpSql,
+	"1528395742_campaign_spec_executions_unique_repo.up.sql":                       _1528395742_campaign_spec_executions_unique_repoUpSql,
+	"1528395742_campaign_spec_executions_unique_repo.down.sql":                     _1528395742_campaign_spec_executions_unique_repoDownSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -2368,11 +2764,13 @@ const AssetDebug = false
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"},
 // AssetDir("data/img") would return []string{"a.png", "b.png"},
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error, and
@@ -2505,6 +2903,24 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395732_add_external_services_sync_jobs_state_index.up.sql":                {_1528395732_add_external_services_sync_jobs_state_indexUpSql, map[string]*bintree{}},
 	"1528395733_add_permissions_object_ids_default.down.sql":                       {_1528395733_add_permissions_object_ids_defaultDownSql, map[string]*bintree{}},
 	"1528395733_add_permissions_object_ids_default.up.sql":                         {_1528395733_add_permissions_object_ids_defaultUpSql, map[string]*bintree{}},
+	"1528395734_lsif_index_requests.down.sql":                                      {_1528395734_lsif_index_requestsDownSql, map[string]*bintree{}},
+	"1528395734_lsif_index_requests.up.sql":                                        {_1528395734_lsif_index_requestsUpSql, map[string]*bintree{}},
+	"1528395735_lsif_retention_configuration.down.sql":                             {_1528395735_lsif_retention_configurationDownSql, map[string]*bintree{}},
+	"1528395735_lsif_retention_configuration.up.sql":                               {_1528395735_lsif_retention_configurationUpSql, map[string]*bintree{}},
+	"1528395736_campaign_spec_executions.down.sql":                                 {_1528395736_campaign_spec_executionsDownSql, map[string]*bintree{}},
+	"1528395736_campaign_spec_executions.up.sql":                                   {_1528395736_campaign_spec_executionsUpSql, map[string]*bintree{}},
+	"1528395737_add_merging_flag_to_changesets.down.sql":                           {_1528395737_add_merging_flag_to_changesetsDownSql, map[string]*bintree{}},
+	"1528395737_add_merging_flag_to_changesets.up.sql":                             {_1528395737_add_merging_flag_to_changesetsUpSql, map[string]*bintree{}},
+	"1528395738_sub_repo_permissions.up.sql":                                       {_1528395738_sub_repo_permissionsUpSql, map[string]*bintree{}},
+	"1528395738_sub_repo_permissions.down.sql":                                     {_1528395738_sub_repo_permissionsDownSql, map[string]*bintree{}},
+	"1528395739_add_publication_slot_to_changesets.up.sql":                         {_1528395739_add_publication_slot_to_changesetsUpSql, map[string]*bintree{}},
+	"1528395739_add_publication_slot_to_changesets.down.sql":                       {_1528395739_add_publication_slot_to_changesetsDownSql, map[string]*bintree{}},
+	"1528395740_add_scim_deactivation_and_provisioned_orgs.up.sql":                 {_1528395740_add_scim_deactivation_and_provisioned_orgsUpSql, map[string]*bintree{}},
+	"1528395740_add_scim_deactivation_and_provisioned_orgs.down.sql":               {_1528395740_add_scim_deactivation_and_provisioned_orgsDownSql, map[string]*bintree{}},
+	"1528395741_add_is_primary_to_user_emails.down.sql":                            {_1528395741_add_is_primary_to_user_emailsDownSql, map[string]*bintree{}},
+	"1528395741_add_is_primary_to_user_emails.up.sql":                              {_1528395741_add_is_primary_to_user_emailsUpSql, map[string]*bintree{}},
+	"1528395742_campaign_spec_executions_unique_repo.up.sql":                       {_1528395742_campaign_spec_executions_unique_repoUpSql, map[string]*bintree{}},
+	"1528395742_campaign_spec_executions_unique_repo.down.sql":                     {_1528395742_campaign_spec_executions_unique_repoDownSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
@@ -13,6 +13,28 @@ const BitbucketCloudSchemaJSON = `{
   "additionalProperties": false,
   "required": ["url", "username", "appPassword"],
   "properties": {
+    "authorization": {
+      "title": "BitbucketCloudAuthorization",
+      "description": "If non-null, enforces Bitbucket Cloud repository permissions as computed from the repository permissions of the workspaces of the \"teams\" setting and of the \"username\" user. This requires that the \"username\" user is an administrator of these workspaces and that the app password has the \"Account (Read)\" and \"Workspace membership (Read)\" permissions.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["identityProvider"],
+      "properties": {
+        "identityProvider": {
+          "description": "The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud user to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes that the username of a Sourcegraph user is identical to the nickname of a Bitbucket Cloud user who is a member of one of the workspaces, and ` + "`" + `auth.enableUsernameChanges` + "`" + ` must be set to false for security reasons.",
+          "title": "BitbucketCloudIdentityProvider",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["type"],
+          "properties": {
+            "type": {
+              "type": "string",
+              "enum": ["username"]
+            }
+          }
+        }
+      }
+    },
     "url": {
       "description": "URL of Bitbucket Cloud, such as https://bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.",
       "type": "string",
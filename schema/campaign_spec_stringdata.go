@@ -176,6 +176,83 @@ const CampaignSpecSchemaJSON = `{
               }
             }
           ]
+        },
+        "autoMerge": {
+          "title": "ChangesetAutoMerge",
+          "type": "object",
+          "description": "An opt-in policy to merge published changesets on the code host once their checks pass and they have been approved. If not set, changesets are never merged by Sourcegraph.",
+          "additionalProperties": false,
+          "properties": {
+            "method": {
+              "type": "string",
+              "description": "How to merge the changeset. Not every code host supports every method; changesets on code hosts that don't support the method are not merged.",
+              "enum": ["merge", "squash", "rebase"],
+              "default": "merge"
+            },
+            "requiredApprovals": {
+              "type": "integer",
+              "description": "The number of reviewers who must have approved the changeset before it is merged. No changeset is merged while a reviewer requests changes.",
+              "minimum": 0,
+              "default": 0
+            },
+            "requiredChecks": {
+              "type": "string",
+              "description": "Which checks must pass before the changeset is merged. With \"passed\", the changeset is only merged once all of its checks have passed; with \"none\", checks are ignored.",
+              "enum": ["passed", "none"],
+              "default": "passed"
+            }
+          }
+        }
+      }
+    },
+    "publicationSchedule": {
+      "title": "PublicationSchedule",
+      "type": "object",
+      "description": "Limits how fast the campaign's changesets are published on the code host. Changesets that can't be published yet are scheduled to be published later. If not set, changesets are published as soon as possible.",
+      "additionalProperties": false,
+      "properties": {
+        "maxOpen": {
+          "type": "integer",
+          "description": "The maximum number of the campaign's changesets that are open (and not merged) on the code host at the same time.",
+          "minimum": 1
+        },
+        "perHour": {
+          "type": "integer",
+          "description": "The maximum number of changesets that are published per hour. Publications are spread evenly over the hour.",
+          "minimum": 1
+        },
+        "windows": {
+          "type": "array",
+          "description": "The times at which changesets may be published. If not set, changesets may be published at any time.",
+          "items": {
+            "title": "PublicationWindow",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["start", "end"],
+            "properties": {
+              "days": {
+                "type": "array",
+                "description": "The days of the week on which the window is open. If not set, the window is open on every day.",
+                "items": {
+                  "type": "string",
+                  "enum": ["monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"]
+                },
+                "uniqueItems": true
+              },
+              "start": {
+                "type": "integer",
+                "description": "The hour of the day (in UTC) at which the window opens.",
+                "minimum": 0,
+                "maximum": 23
+              },
+              "end": {
+                "type": "integer",
+                "description": "The hour of the day (in UTC) at which the window closes. Must be after start; 24 closes the window at midnight.",
+                "minimum": 1,
+                "maximum": 24
+              }
+            }
+          }
         }
       }
     }
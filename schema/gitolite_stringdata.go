@@ -55,6 +55,13 @@ const GitoliteSchemaJSON = `{
       },
       "examples": [[{ "name": "myrepo" }, { "pattern": ".*secret.*" }]]
     },
+    "authorization": {
+      "title": "GitoliteAuthorization",
+      "description": "If non-null, enforces Gitolite repository permissions as reported by ` + "`" + `gitolite info` + "`" + ` for each user. This requires that the SSH key of gitserver belongs to a Gitolite super-user (with write access to the gitolite-admin repository) and that the ` + "`" + `sudo` + "`" + ` command is enabled in the ENABLE list of the .gitolite.rc file. Sourcegraph users are matched with the Gitolite user that has the same username.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {}
+    },
     "phabricatorMetadataCommand": {
       "description": "This is DEPRECATED. Use the ` + "`" + `phabricator` + "`" + ` field instead.",
       "type": "string"
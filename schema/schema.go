@@ -64,6 +64,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -85,6 +86,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -103,12 +107,63 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
+}
+
+// AzureDevOpsAuthorization description: If non-null, enforces Azure DevOps repository permissions. This requires that the personal access token has the "Identity (Read)" and "Security (Manage)" scopes.
+type AzureDevOpsAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Azure DevOps identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes that the username of a Sourcegraph user is identical to the account name of the Azure DevOps user without its domain (the "alice" in "alice@example.com" or "CORP\alice"), and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider AzureDevOpsIdentityProvider `json:"identityProvider"`
+}
+
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Authorization description: If non-null, enforces Azure DevOps repository permissions. This requires that the personal access token has the "Identity (Read)" and "Security (Manage)" scopes.
+	Authorization *AzureDevOpsAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from Azure DevOps. Takes precedence over "projects" configuration.
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// Projects description: An array of names of Azure DevOps projects whose repositories should be mirrored on Sourcegraph. If empty or unset, the repositories of all projects visible to the configured account are mirrored.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for an Azure DevOps repository. In the pattern, the variable "{host}" is replaced with the URL's host (such as dev.azure.com), "{org}" with the path of the organization or collection URL (such as "myorg"), "{project}" with the name of the repository's project and "{repo}" with the name of the repository.
+	//
+	// For example, if your organization is https://dev.azure.com/myorg and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{org}/{project}/{repo}" would mean that the repository myrepo in the project myproject is available on Sourcegraph at https://src.example.com/dev.azure.com/myorg/myproject/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Token description: A personal access token of the Azure DevOps account. It needs the "Code (Read)" and "Project and Team (Read)" scopes to sync repositories, "Code (Read & write)" and "Code (Status)" to create pull requests in campaigns, and "Identity (Read)" and "Security (Manage)" to enforce repository permissions.
+	Token string `json:"token"`
+	// Url description: URL of an Azure DevOps organization, such as https://dev.azure.com/myorg, or of an Azure DevOps Server collection, such as https://tfs.example.com/tfs/DefaultCollection.
+	Url string `json:"url"`
+	// Username description: The username of the Azure DevOps account that owns the personal access token.
+	Username string `json:"username"`
+}
+
+// AzureDevOpsIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Azure DevOps identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes that the username of a Sourcegraph user is identical to the account name of the Azure DevOps user without its domain (the "alice" in "alice@example.com" or "CORP\alice"), and `auth.enableUsernameChanges` must be set to false for security reasons.
+type AzureDevOpsIdentityProvider struct {
+	Type string `json:"type"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions as computed from the repository permissions of the workspaces of the "teams" setting and of the "username" user. This requires that the "username" user is an administrator of these workspaces and that the app password has the "Account (Read)" and "Workspace membership (Read)" permissions.
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud user to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes that the username of a Sourcegraph user is identical to the nickname of a Bitbucket Cloud user who is a member of one of the workspaces, and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
 }
 
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
@@ -117,6 +172,8 @@ type BitbucketCloudConnection struct {
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions as computed from the repository permissions of the workspaces of the "teams" setting and of the "username" user. This requires that the "username" user is an administrator of these workspaces and that the app password has the "Account (Read)" and "Workspace membership (Read)" permissions.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -145,6 +202,11 @@ type BitbucketCloudConnection struct {
 	Username string `json:"username"`
 }
 
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud user to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes that the username of a Sourcegraph user is identical to the nickname of a Bitbucket Cloud user who is a member of one of the workspaces, and `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Type string `json:"type"`
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -366,6 +428,14 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Id description: The ID of an Azure DevOps repository (as returned by the Azure DevOps API) to exclude from mirroring.
+	Id string `json:"id,omitempty"`
+	// Name description: The name of an Azure DevOps repository, including its project ("project/repo"), to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps repository, including its project ("project/repo").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -382,6 +452,12 @@ type ExcludedBitbucketServerRepo struct {
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Server repo.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGerritProject struct {
+	// Name description: The name of a Gerrit project to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gerrit project.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitHubRepo struct {
 	// Archived description: If set to true, archived repositories will be excluded.
 	Archived bool `json:"archived,omitempty"`
@@ -400,12 +476,30 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea API) to exclude from mirroring. Use this to exclude the repository, even if renamed.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Gitolite repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedPerforceDepot struct {
+	// Depot description: The path of a depot to exclude from mirroring, such as "//Attic/".
+	Depot string `json:"depot,omitempty"`
+	// Pattern description: Regular expression which matches against the path of a depot.
+	Pattern string `json:"pattern,omitempty"`
+}
 
 // ExpandedGitCommitDescription description: The Git commit to create with the changes.
 type ExpandedGitCommitDescription struct {
@@ -462,6 +556,36 @@ type ExternalIdentity struct {
 	Type           string `json:"type"`
 }
 
+// GerritConnection description: Configuration for a connection to Gerrit.
+type GerritConnection struct {
+	// Exclude description: A list of projects to never mirror from this Gerrit instance. Takes precedence over "projects" configuration.
+	Exclude []*ExcludedGerritProject `json:"exclude,omitempty"`
+	// Password description: The HTTP password of the Gerrit account. Generate one in Gerrit under Settings > HTTP Credentials.
+	Password string `json:"password"`
+	// Projects description: An array of Gerrit project names to mirror on Sourcegraph. If empty or unset, all projects visible to the configured user are mirrored.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gerrit.
+	RateLimit *GerritRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gerrit project. In the pattern, the variable "{host}" is replaced with the Gerrit URL's host (such as gerrit.example.com), and "{name}" is replaced with the Gerrit project's name (such as "myteam/myproject").
+	//
+	// For example, if your Gerrit is https://gerrit.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{name}" would mean that a Gerrit project named myteam/myproject is available on Sourcegraph at https://src.example.com/gerrit.example.com/myteam/myproject.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: URL of a Gerrit instance, such as https://gerrit.example.com.
+	Url string `json:"url"`
+	// Username description: The username of the Gerrit account used to list projects, clone repositories and create changes.
+	Username string `json:"username"`
+}
+
+// GerritRateLimit description: Rate limit applied when making background API requests to Gerrit.
+type GerritRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // GitCommitAuthor description: The author of the Git commit.
 type GitCommitAuthor struct {
 	// Email description: The Git commit author email.
@@ -573,6 +697,16 @@ type GitHubWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitLFS description: Fetch the Git LFS objects of some repositories, so that files stored with Git LFS are searchable and shown with their content instead of as LFS pointer files. Only repositories cloned over HTTP(S) are supported.
+type GitLFS struct {
+	// Include description: Glob patterns matching the paths of the files whose LFS objects are fetched, for example "**/*.proto". The LFS objects of all files are fetched if empty.
+	Include []string `json:"include,omitempty"`
+	// MaxFileSize description: LFS objects larger than this many bytes are not fetched. Defaults to 1048576 (1 MiB).
+	MaxFileSize int `json:"maxFileSize,omitempty"`
+	// Repos description: Regular expressions matching the names of the repositories whose LFS objects are fetched, for example "^github\.com/myorg/".
+	Repos []string `json:"repos"`
+}
+
 // GitLabAuthProvider description: Configures the GitLab OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitLab instance: https://docs.gitlab.com/ee/integration/oauth_provider.html. The application should have `api` and `read_user` scopes and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/gitlab/callback".
 type GitLabAuthProvider struct {
 	// ClientID description: The Client ID of the GitLab OAuth app, accessible from https://gitlab.com/oauth/applications (or the same path on your private GitLab instance).
@@ -655,8 +789,62 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "users" and "repositoryQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "owner/name"}) or by ID ({"id": 42}).
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form http(s)://gitea.example.com/myteam/myproject.git (using https: if the Gitea instance uses HTTPS).
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository. In the pattern, the variable "{host}" is replaced with the Gitea URL's host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" path (such as "myorg/myrepo").
+	//
+	// For example, if your Gitea is https://gitea.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. The valid values are:
+	//
+	// - `affiliated` mirrors all repositories the configured token's user can access
+	//
+	// - `none` mirrors no repositories (except those specified in the `orgs` and `users` configuration properties)
+	//
+	// - All other values are used as a keyword for Gitea's repository search (/api/v1/repos/search?q=...).
+	//
+	// If multiple values are provided, their results are unioned.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: A Gitea access token with read access to the repositories that should be mirrored. Create one in Gitea under Settings > Applications > Generate New Token.
+	Token string `json:"token"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+	// Users description: An array of user names identifying Gitea users whose repositories should be mirrored on Sourcegraph.
+	Users []string `json:"users,omitempty"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
+// GitoliteAuthorization description: If non-null, enforces Gitolite repository permissions as reported by `gitolite info` for each user. This requires that the SSH key of gitserver belongs to a Gitolite super-user (with write access to the gitolite-admin repository) and that the `sudo` command is enabled in the ENABLE list of the .gitolite.rc file. Sourcegraph users are matched with the Gitolite user that has the same username.
+type GitoliteAuthorization struct {
+}
+
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
+	// Authorization description: If non-null, enforces Gitolite repository permissions as reported by `gitolite info` for each user. This requires that the SSH key of gitserver belongs to a Gitolite super-user (with write access to the gitolite-admin repository) and that the `sudo` command is enabled in the ENABLE list of the .gitolite.rc file. Sourcegraph users are matched with the Gitolite user that has the same username.
+	Authorization *GitoliteAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
 	Exclude []*ExcludedGitoliteRepo `json:"exclude,omitempty"`
 	// Host description: Gitolite host that stores the repositories (e.g., git@gitolite.example.com, ssh://git@gitolite.example.com:2222/).
@@ -724,6 +912,40 @@ type ImportChangesets struct {
 	Repository string `json:"repository"`
 }
 
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which signs in users with the username and password of their account in an LDAP directory (such as Active Directory).
+type LDAPAuthProvider struct {
+	// BaseDN description: The DN of the subtree in which to search for users.
+	BaseDN string `json:"baseDN"`
+	// BindDN description: The DN of the account with which to search the directory for users. If not set, the directory is searched anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the account given by `bindDN`.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server. This is only necessary if the certificate is self-signed or signed by an internal CA.
+	Certificate string `json:"certificate,omitempty"`
+	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
+	ConfigID    string `json:"configID,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: The attribute of the user's entry that is used as the display name.
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+	// EmailAttribute description: The attribute of the user's entry that is used as the (verified) email address.
+	EmailAttribute string `json:"emailAttribute,omitempty"`
+	// GroupMemberAttribute description: The attribute of the `requiredGroup` entry that lists the DNs of the group's members.
+	GroupMemberAttribute string `json:"groupMemberAttribute,omitempty"`
+	// RequiredGroup description: If set, only members of the group with this DN can sign in.
+	RequiredGroup string `json:"requiredGroup,omitempty"`
+	// StartTLS description: Whether to upgrade the connection to TLS with the StartTLS operation after connecting to an ldap URL.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: The URL of the LDAP server. Use the ldaps scheme to connect with TLS, or set `startTLS` to upgrade an ldap connection to TLS.
+	Url string `json:"url"`
+	// UserFilter description: The LDAP filter with which to search for the user who signs in. `{username}` is replaced with the (escaped) username entered on the sign-in form. The filter must match exactly one entry.
+	UserFilter string `json:"userFilter,omitempty"`
+	// UserIDAttribute description: The attribute of the user's entry that permanently identifies it, and with which the Sourcegraph user is looked up. If not set, `entryUUID` (OpenLDAP and most other directories) or `objectGUID` (Active Directory) is used, whichever the entry has. If the entry has none of them, its DN is used, which changes when the entry is renamed or moved.
+	UserIDAttribute string `json:"userIDAttribute,omitempty"`
+	// UsernameAttribute description: The attribute of the user's entry that is used as the Sourcegraph username.
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
 	// Sentry description: Configuration for Sentry
@@ -909,6 +1131,32 @@ type ParentSourcegraph struct {
 	Url string `json:"url,omitempty"`
 }
 
+// PerforceAuthorization description: If non-null, enforces Perforce depot permissions as computed from the protections table of the Perforce server. Sourcegraph users are matched with the Perforce user that has the same username.
+type PerforceAuthorization struct {
+}
+
+// PerforceConnection description: Configuration for a connection to Perforce Helix Core.
+type PerforceConnection struct {
+	// Authorization description: If non-null, enforces Perforce depot permissions as computed from the protections table of the Perforce server. Sourcegraph users are matched with the Perforce user that has the same username.
+	Authorization *PerforceAuthorization `json:"authorization,omitempty"`
+	// Depots description: The depot paths to mirror on Sourcegraph, each of which is converted into a Git repository. If empty or unset, all local and stream depots on the server are mirrored.
+	Depots []string `json:"depots,omitempty"`
+	// Exclude description: A list of depots to never mirror from this Perforce server. Takes precedence over "depots" configuration.
+	Exclude []*ExcludedPerforceDepot `json:"exclude,omitempty"`
+	// P4Passwd description: The password or ticket of the Perforce user.
+	P4Passwd string `json:"p4.passwd"`
+	// P4Port description: The address of the Perforce server, in the format of the P4PORT environment variable (such as "ssl:perforce.example.com:1666").
+	P4Port string `json:"p4.port"`
+	// P4User description: The user to authenticate to the Perforce server as. To read the protections table of other users, the user must have super access.
+	P4User string `json:"p4.user"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Perforce depot. In the pattern, the variable "{depot}" is replaced with the depot path without its leading and trailing slashes (such as "Engine/Cloud").
+	//
+	// For example, a repositoryPathPattern of "perforce/{depot}" would mean that the depot //Engine/Cloud/ is available on Sourcegraph at https://src.example.com/perforce/Engine/Cloud.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+}
+
 // PermissionsUserMapping description: Settings for Sourcegraph permissions, which allow the site admin to explicitly manage repository permissions via the GraphQL API. This setting cannot be enabled if repository permissions for any specific external service are enabled (i.e., when the external service's `authorization` field is set).
 type PermissionsUserMapping struct {
 	// BindID description: The type of identifier to identify a user. The default is "email", which uses the email address to identify a user. Use "username" to identify a user by their username. Changing this setting will erase any permissions created for users that do not yet exist.
@@ -1127,6 +1375,8 @@ type SiteConfiguration struct {
 	AuthProviders []AuthProviders `json:"auth.providers,omitempty"`
 	// AuthPublic description: WARNING: This option has been removed as of 3.8.
 	AuthPublic bool `json:"auth.public,omitempty"`
+	// AuthScimToken description: The bearer token that SCIM 2.0 clients (such as the user provisioning of an identity provider) must send to the SCIM endpoint at /.api/scim/v2 to create, update and deactivate users and organizations. SCIM provisioning is disabled if this is not set.
+	AuthScimToken string `json:"auth.scimToken,omitempty"`
 	// AuthSessionExpiry description: The duration of a user session, after which it expires and the user is required to re-authenticate. The default is 90 days. There is typically no need to set this, but some users may have specific internal security requirements.
 	//
 	// The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). E.g., "720h", "43200m", "2592000s" all indicate a timespan of 30 days.
@@ -1183,8 +1433,12 @@ type SiteConfiguration struct {
 	ExternalURL string `json:"externalURL,omitempty"`
 	// GitCloneURLToRepositoryName description: JSON array of configuration that maps from Git clone URL to repository name. Sourcegraph automatically resolves remote clone URLs to their proper code host. However, there may be non-remote clone URLs (e.g., in submodule declarations) that Sourcegraph cannot automatically map to a code host. In this case, use this field to specify the mapping. The mappings are tried in the order they are specified and take precedence over automatic mappings.
 	GitCloneURLToRepositoryName []*CloneURLToRepositoryName `json:"git.cloneURLToRepositoryName,omitempty"`
+	// GitLFS description: Fetch the Git LFS objects of some repositories, so that files stored with Git LFS are searchable and shown with their content instead of as LFS pointer files. Only repositories cloned over HTTP(S) are supported.
+	GitLFS *GitLFS `json:"gitLFS,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
 	GitMaxConcurrentClones int `json:"gitMaxConcurrentClones,omitempty"`
+	// GitServerReplicationFactor description: Number of gitservers that store a copy of each repository, including the gitserver which owns it. The owner pushes every update of a repository to its replicas, and clients read from a replica if the owner is unavailable or does not have the repository. Values larger than the number of gitservers are capped.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GithubClientID description: Client ID for GitHub. (DEPRECATED)
 	GithubClientID string `json:"githubClientID,omitempty"`
 	// GithubClientSecret description: Client secret for GitHub. (DEPRECATED)
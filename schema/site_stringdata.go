@@ -341,6 +341,44 @@ const SiteSchemaJSON = `{
       "default": 5,
       "group": "External services"
     },
+    "gitServerReplicationFactor": {
+      "description": "Number of gitservers that store a copy of each repository, including the gitserver which owns it. The owner pushes every update of a repository to its replicas, and clients read from a replica if the owner is unavailable or does not have the repository. Values larger than the number of gitservers are capped.",
+      "type": "integer",
+      "minimum": 1,
+      "default": 1,
+      "group": "External services"
+    },
+    "gitLFS": {
+      "description": "Fetch the Git LFS objects of some repositories, so that files stored with Git LFS are searchable and shown with their content instead of as LFS pointer files. Only repositories cloned over HTTP(S) are supported.",
+      "type": "object",
+      "group": "External services",
+      "additionalProperties": false,
+      "required": ["repos"],
+      "properties": {
+        "repos": {
+          "description": "Regular expressions matching the names of the repositories whose LFS objects are fetched, for example \"^github\\.com/myorg/\".",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "format": "regex"
+          },
+          "minItems": 1
+        },
+        "include": {
+          "description": "Glob patterns matching the paths of the files whose LFS objects are fetched, for example \"**/*.proto\". The LFS objects of all files are fetched if empty.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "maxFileSize": {
+          "description": "LFS objects larger than this many bytes are not fetched. Defaults to 1048576 (1 MiB).",
+          "type": "integer",
+          "default": 1048576,
+          "minimum": 1
+        }
+      }
+    },
     "repoListUpdateInterval": {
       "description": "Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.",
       "type": "integer",
@@ -765,7 +803,7 @@ const SiteSchemaJSON = `{
         "properties": {
           "type": {
             "type": "string",
-            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab"]
+            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"]
           }
         },
         "oneOf": [
@@ -774,7 +812,8 @@ const SiteSchemaJSON = `{
           { "$ref": "#/definitions/OpenIDConnectAuthProvider" },
           { "$ref": "#/definitions/HTTPHeaderAuthProvider" },
           { "$ref": "#/definitions/GitHubAuthProvider" },
-          { "$ref": "#/definitions/GitLabAuthProvider" }
+          { "$ref": "#/definitions/GitLabAuthProvider" },
+          { "$ref": "#/definitions/LDAPAuthProvider" }
         ],
         "!go": {
           "taggedUnionType": true
@@ -808,6 +847,13 @@ const SiteSchemaJSON = `{
       "default": 12,
       "group": "Authentication"
     },
+    "auth.scimToken": {
+      "description": "The bearer token that SCIM 2.0 clients (such as the user provisioning of an identity provider) must send to the SCIM endpoint at /.api/scim/v2 to create, update and deactivate users and organizations. SCIM provisioning is disabled if this is not set.",
+      "type": "string",
+      "minLength": 32,
+      "examples": ["SE4ROBMcEOkJ9Uejh8e9oIMS2ZrhoqnqBBIIlB5x"],
+      "group": "Authentication"
+    },
     "update.channel": {
       "description": "The channel on which to automatically check for Sourcegraph updates.",
       "type": ["string"],
@@ -997,6 +1043,94 @@ const SiteSchemaJSON = `{
         }
       }
     },
+    "LDAPAuthProvider": {
+      "description": "Configures the LDAP authentication provider, which signs in users with the username and password of their account in an LDAP directory (such as Active Directory).",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["type", "url", "baseDN"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "const": "ldap"
+        },
+        "displayName": { "$ref": "#/definitions/AuthProviderCommon/properties/displayName" },
+        "configID": {
+          "description": "An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.",
+          "type": "string"
+        },
+        "url": {
+          "description": "The URL of the LDAP server. Use the ldaps scheme to connect with TLS, or set ` + "`" + `startTLS` + "`" + ` to upgrade an ldap connection to TLS.",
+          "type": "string",
+          "pattern": "^ldaps?://",
+          "examples": ["ldaps://ldap.example.com:636", "ldap://ldap.example.com:389"]
+        },
+        "startTLS": {
+          "description": "Whether to upgrade the connection to TLS with the StartTLS operation after connecting to an ldap URL.",
+          "type": "boolean",
+          "default": false
+        },
+        "certificate": {
+          "description": "TLS certificate of the LDAP server. This is only necessary if the certificate is self-signed or signed by an internal CA.",
+          "type": "string",
+          "pattern": "^-----BEGIN CERTIFICATE-----\n",
+          "examples": ["-----BEGIN CERTIFICATE-----\n..."]
+        },
+        "bindDN": {
+          "description": "The DN of the account with which to search the directory for users. If not set, the directory is searched anonymously.",
+          "type": "string",
+          "examples": ["cn=sourcegraph,ou=services,dc=example,dc=com"]
+        },
+        "bindPassword": {
+          "description": "The password of the account given by ` + "`" + `bindDN` + "`" + `.",
+          "type": "string"
+        },
+        "baseDN": {
+          "description": "The DN of the subtree in which to search for users.",
+          "type": "string",
+          "examples": ["ou=people,dc=example,dc=com"]
+        },
+        "userFilter": {
+          "description": "The LDAP filter with which to search for the user who signs in. ` + "`" + `{username}` + "`" + ` is replaced with the (escaped) username entered on the sign-in form. The filter must match exactly one entry.",
+          "type": "string",
+          "default": "(uid={username})",
+          "examples": ["(&(objectClass=person)(uid={username}))", "(sAMAccountName={username})"]
+        },
+        "userIDAttribute": {
+          "description": "The attribute of the user's entry that permanently identifies it, and with which the Sourcegraph user is looked up. If not set, ` + "`" + `entryUUID` + "`" + ` (OpenLDAP and most other directories) or ` + "`" + `objectGUID` + "`" + ` (Active Directory) is used, whichever the entry has. If the entry has none of them, its DN is used, which changes when the entry is renamed or moved.",
+          "type": "string",
+          "examples": ["nsUniqueId", "ipaUniqueID"]
+        },
+        "usernameAttribute": {
+          "description": "The attribute of the user's entry that is used as the Sourcegraph username.",
+          "type": "string",
+          "default": "uid",
+          "examples": ["sAMAccountName"]
+        },
+        "displayNameAttribute": {
+          "description": "The attribute of the user's entry that is used as the display name.",
+          "type": "string",
+          "default": "cn",
+          "examples": ["displayName"]
+        },
+        "emailAttribute": {
+          "description": "The attribute of the user's entry that is used as the (verified) email address.",
+          "type": "string",
+          "default": "mail",
+          "examples": ["userPrincipalName"]
+        },
+        "requiredGroup": {
+          "description": "If set, only members of the group with this DN can sign in.",
+          "type": "string",
+          "examples": ["cn=developers,ou=groups,dc=example,dc=com"]
+        },
+        "groupMemberAttribute": {
+          "description": "The attribute of the ` + "`" + `requiredGroup` + "`" + ` entry that lists the DNs of the group's members.",
+          "type": "string",
+          "default": "member",
+          "examples": ["uniqueMember"]
+        }
+      }
+    },
     "GitHubAuthProvider": {
       "description": "Configures the GitHub (or GitHub Enterprise) OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitHub instance: https://developer.github.com/apps/building-oauth-apps/creating-an-oauth-app/. When a user signs into Sourcegraph or links their GitHub account to their existing Sourcegraph account, GitHub will prompt the user for the repo scope.",
       "type": "object",